@@ -1,11 +1,15 @@
 package baseapp
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"runtime/debug"
 	"strconv"
 	"strings"
+	"syscall"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
@@ -54,17 +58,25 @@ type BaseApp struct {
 	codespacer  *sdk.Codespacer      // handle module codespacing
 	collect     sdk.CollectConfig
 
+	proofQueryRouter ProofQueryRouter // router for the queries returning a proof
+
 	TxDecoder sdk.TxDecoder // unmarshal []byte into sdk.Tx
 
 	anteHandler sdk.AnteHandler // ante handler for fee and auth
 	preChecker  sdk.PreChecker
 
 	// may be nil
-	initChainer      sdk.InitChainer  // initialize state with validators and state blob
-	beginBlocker     sdk.BeginBlocker // logic to run before any txs
-	endBlocker       sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
-	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
-	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
+	initChainer          sdk.InitChainer          // initialize state with validators and state blob
+	streamingInitChainer sdk.StreamingInitChainer // initialize state while decoding the state blob
+	beginBlocker         sdk.BeginBlocker         // logic to run before any txs
+	endBlocker           sdk.EndBlocker           // logic to run after all txs, and to determine valset changes
+	addrPeerFilter       sdk.PeerFilter           // filter peers by address and port
+	pubkeyPeerFilter     sdk.PeerFilter           // filter peers by public key
+
+	proposalChecker   sdk.ProposalChecker   // sanity checks on a block before execution
+	proposalTxChecker sdk.ProposalTxChecker // sanity checks on each tx before execution, in every mode
+
+	blockValidatorCreator sdk.BlockValidatorCreator // invariants over the txs of a block
 
 	//--------------------
 	// Volatile
@@ -74,19 +86,85 @@ type BaseApp struct {
 	CheckState   *state // for CheckTx
 	DeliverState *state // for DeliverTx
 
+	// set in BeginBlock if the proposalChecker rejects the block, all txs of
+	// the block fail with this error without being executed.
+	proposalErr sdk.Error
+	// created in BeginBlock by the blockValidatorCreator and cleared on Commit
+	blockValidator sdk.BlockValidator
+
 	AccountStoreCache sdk.AccountStoreCache
 	txMsgCache        *lru.Cache
 	Pool              *sdk.Pool
 
+	// the store and codec of the AccountStoreCache, to read the accounts of the past versions
+	accountStore    sdk.KVStore
+	accountStoreCdc *codec.Codec
+
+	// dumps the state changes of the delivered txs, nil unless SetStateDiffDump is used
+	stateDiff *stateDiffDumper
+
+	// number of goroutines executing the txs of DeliverTxs, see SetConcurrentDeliver
+	deliverWorkers int
+
+	// pending txs of the CheckTx state, nil if mempool TTL and replacement are disabled
+	mempool     *mempoolTracker
+	txFeeGetter TxFeeGetter
+
+	// limits the txs of each account accepted by CheckTx, nil unless SetCheckTxRateLimit is used
+	rateLimiter *accountRateLimiter
+
+	// pending txs replayed into the CheckTx state on Commit, nil unless SetCheckStateReset
+	// uses CheckStateResetReplayAnte
+	pendingTxs *pendingTxs
+
+	// starts the spans of the tx lifecycle, nil unless SetTracer is used
+	tracer sdk.Tracer
+
+	// height and unix time at which the node stops, 0 unless SetHaltHeight and SetHaltTime are used
+	haltHeight int64
+	haltTime   int64
+
+	// consensus version of each module reported by Info, see SetModuleVersions
+	moduleVersions map[string]uint64
+
+	// reserves a part of each block to system txs, nil unless SetSystemLane is used
+	lane *systemLane
+
+	// results of the last committed blocks, nil if disabled by SetBlockResultsRetention
+	blockResults *blockResults
+
+	// fees of the last delivered txs, nil if disabled by SetFeeSuggestionWindow
+	feeTracker *feeTracker
+
+	// loads the latest version of the multistore, see SetStoreLoader
+	storeLoader StoreLoader
+
 	// Snapshot for state sync related fields
 	StateSyncHelper *store.StateSyncHelper // manage state sync related status
 
+	// takes the snapshots of the committed states, nil unless SetSnapshotInterval is used
+	snapshots *snapshotSchedule
+
+	// given to the stores and the contexts of the app, the one of the process unless SetInstance is used
+	upgradeMgr *sdk.UpgradeManager
+
 	// flag for sealing
 	sealed bool
 }
 
 var _ abci.Application = (*BaseApp)(nil)
 
+// AppInfo is the application information reported in the Data of the ABCI Info response
+type AppInfo struct {
+	Name string `json:"name"`
+	// consensus version of each module
+	ModuleVersions map[string]uint64 `json:"module_versions"`
+	// heights of the upgrades configured on the node, activated or not
+	UpgradeHeights map[string]int64 `json:"upgrade_heights"`
+	// sorted names of the upgrades activated at the last block
+	ActivatedUpgrades []string `json:"activated_upgrades"`
+}
+
 // NewBaseApp returns a reference to an initialized BaseApp.
 //
 // TODO: Determine how to use a flexible and robust configuration paradigm that
@@ -113,9 +191,13 @@ func NewBaseApp(name string, logger log.Logger, db dbm.DB, txDecoder sdk.TxDecod
 		collect:     collectConfig,
 		txMsgCache:  cache,
 		Pool:        new(sdk.Pool),
-	}
+		storeLoader: DefaultStoreLoader,
 
-	sdk.UpgradeMgr.AddConfig(sdk.MainNetConfig) // TODO: make this configurable
+		proofQueryRouter: NewProofQueryRouter(),
+		blockResults:     newBlockResults(defaultBlockResultsRetention),
+		feeTracker:       newFeeTracker(defaultFeeSuggestionWindow),
+		upgradeMgr:       sdk.UpgradeMgr,
+	}
 
 	// Register the undefined & root codespaces, which should not be used by
 	// any modules.
@@ -123,9 +205,16 @@ func NewBaseApp(name string, logger log.Logger, db dbm.DB, txDecoder sdk.TxDecod
 	for _, option := range options {
 		option(app)
 	}
+
+	app.upgradeMgr.AddConfig(sdk.MainNetConfig) // TODO: make this configurable
 	return app
 }
 
+// UpgradeMgr returns the upgrade manager of the app, see SetInstance
+func (app *BaseApp) UpgradeMgr() *sdk.UpgradeManager {
+	return app.upgradeMgr
+}
+
 // BaseApp Name
 func (app *BaseApp) Name() string {
 	return app.name
@@ -166,9 +255,32 @@ func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
 	app.cms.MountStoreWithDB(key, typ, nil)
 }
 
+// ShadowStore runs the shadow loaded by loader alongside the mounted iavl store of key, to
+// verify a store implementation against the existing one, the mismatches are logged.
+// It must be called after the store is mounted and before it is loaded.
+func (app *BaseApp) ShadowStore(key *sdk.KVStoreKey, loader store.ShadowStoreLoader) {
+	shadowed, ok := app.cms.(interface {
+		SetShadowStore(key sdk.StoreKey, loader store.ShadowStoreLoader, logger log.Logger)
+	})
+	if !ok {
+		panic("multistore doesn't support shadow stores")
+	}
+	shadowed.SetShadowStore(key, loader, app.Logger)
+}
+
+// StoreLoader loads the latest version of the multistore. It lets an app run store
+// migrations, such as mounting or renaming stores at an upgrade height, before the stores
+// are loaded, see SetStoreLoader.
+type StoreLoader func(ms sdk.CommitMultiStore) error
+
+// DefaultStoreLoader just loads the latest version of the multistore
+func DefaultStoreLoader(ms sdk.CommitMultiStore) error {
+	return ms.LoadLatestVersion()
+}
+
 // only load latest multi store application version
 func (app *BaseApp) LoadCMSLatestVersion() error {
-	err := app.cms.LoadLatestVersion()
+	err := app.storeLoader(app.cms)
 	if err != nil {
 		return err
 	}
@@ -177,7 +289,7 @@ func (app *BaseApp) LoadCMSLatestVersion() error {
 
 // load latest application version
 func (app *BaseApp) LoadLatestVersion(mainKey sdk.StoreKey) error {
-	err := app.cms.LoadLatestVersion()
+	err := app.storeLoader(app.cms)
 	if err != nil {
 		return err
 	}
@@ -275,7 +387,7 @@ func (app *BaseApp) NewContext(mode sdk.RunTxMode, header abci.Header) sdk.Conte
 		ms = app.CheckState.ms
 		accountCache = app.CheckState.AccountCache
 	}
-	return sdk.NewContext(ms, header, mode, app.Logger).WithAccountCache(accountCache)
+	return sdk.WithTracer(sdk.NewContext(ms, header, mode, app.Logger).WithAccountCache(accountCache).WithUpgradeMgr(app.upgradeMgr), app.tracer)
 }
 
 type state struct {
@@ -299,7 +411,7 @@ func (app *BaseApp) SetCheckState(header abci.Header) {
 	app.CheckState = &state{
 		ms:           ms,
 		AccountCache: accountCache,
-		Ctx:          sdk.NewContext(ms, header, sdk.RunTxModeCheck, app.Logger).WithAccountCache(accountCache),
+		Ctx:          sdk.WithTracer(sdk.NewContext(ms, header, sdk.RunTxModeCheck, app.Logger).WithAccountCache(accountCache).WithUpgradeMgr(app.upgradeMgr), app.tracer),
 	}
 }
 
@@ -310,12 +422,23 @@ func (app *BaseApp) SetDeliverState(header abci.Header) {
 	app.DeliverState = &state{
 		ms:           ms,
 		AccountCache: accountCache,
-		Ctx:          sdk.NewContext(ms, header, sdk.RunTxModeDeliver, app.Logger).WithAccountCache(accountCache),
+		Ctx:          sdk.WithTracer(sdk.NewContext(ms, header, sdk.RunTxModeDeliver, app.Logger).WithAccountCache(accountCache).WithUpgradeMgr(app.upgradeMgr), app.tracer),
 	}
 }
 
 func (app *BaseApp) SetAccountStoreCache(cdc *codec.Codec, accountStore sdk.KVStore, cap int) {
 	app.AccountStoreCache = auth.NewAccountStoreCache(cdc, accountStore, cap)
+	app.accountStore, app.accountStoreCdc = accountStore, cdc
+}
+
+// pastAccountCache returns the account cache of the accounts of cms, the multistore of a past version
+func (app *BaseApp) pastAccountCache(cms sdk.CacheMultiStore) sdk.AccountCache {
+	for key, store := range app.cms.GetCommitKVStores() {
+		if store == app.accountStore {
+			return auth.NewAccountCache(auth.NewAccountStoreCache(app.accountStoreCdc, cms.GetKVStore(key), 0))
+		}
+	}
+	return auth.NewAccountCache(app.AccountStoreCache)
 }
 
 //______________________________________________________________________________
@@ -323,11 +446,32 @@ func (app *BaseApp) SetAccountStoreCache(cdc *codec.Codec, accountStore sdk.KVSt
 // ABCI
 
 // Implements ABCI
+// The Data of the response is the JSON encoded AppInfo, so that monitoring can tell
+// the nodes missing a module version or an upgrade before they fall out of consensus.
 func (app *BaseApp) Info(req abci.RequestInfo) abci.ResponseInfo {
 	lastCommitID := app.cms.LastCommitID()
 
+	info := AppInfo{
+		Name:              app.name,
+		ModuleVersions:    app.moduleVersions,
+		UpgradeHeights:    make(map[string]int64),
+		ActivatedUpgrades: app.upgradeMgr.ActivatedUpgrades(lastCommitID.Version),
+	}
+	for name, height := range app.upgradeMgr.Config.HeightMap {
+		if height != 0 {
+			info.UpgradeHeights[name] = height
+		}
+	}
+
+	// amino does not encode maps
+	data, err := json.Marshal(info)
+	if err != nil {
+		panic(err)
+	}
+
 	return abci.ResponseInfo{
-		Data:             app.name,
+		Data:             string(data),
+		Version:          version.GetVersion(),
 		LastBlockHeight:  lastCommitID.Version,
 		LastBlockAppHash: lastCommitID.Hash,
 	}
@@ -346,10 +490,18 @@ func (app *BaseApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitC
 	app.SetDeliverState(abci.Header{ChainID: req.ChainId})
 	app.SetCheckState(abci.Header{ChainID: req.ChainId})
 
-	if app.initChainer == nil {
+	switch {
+	case app.streamingInitChainer != nil:
+		var err error
+		res, err = app.streamingInitChainer(app.DeliverState.Ctx, req, bytes.NewReader(req.AppStateBytes))
+		if err != nil {
+			panic(fmt.Sprintf("failed to init chain: %v", err))
+		}
+	case app.initChainer != nil:
+		res = app.initChainer(app.DeliverState.Ctx, req)
+	default:
 		return
 	}
-	res = app.initChainer(app.DeliverState.Ctx, req)
 
 	// we need to write updates to underlying cache and storage
 	app.DeliverState.WriteAccountCache()
@@ -403,6 +555,8 @@ func (app *BaseApp) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		return handleQueryP2P(app, path, req)
 	case "custom":
 		return handleQueryCustom(app, path, req)
+	case "prove":
+		return handleQueryProve(app, path, req)
 	}
 
 	msg := "unknown query path"
@@ -426,6 +580,20 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 				Code:  uint32(sdk.ABCICodeOK),
 				Value: []byte(version.GetVersion()),
 			}
+		case "codespaces":
+			return abci.ResponseQuery{
+				Code:  uint32(sdk.ABCICodeOK),
+				Value: codec.Cdc.MustMarshalJSON(sdk.RegisteredCodespaces()),
+			}
+		case "block_results":
+			return handleQueryBlockResults(app, path)
+		case "fee-suggestion":
+			return handleQueryFeeSuggestion(app, path)
+		case "earliest_version":
+			return abci.ResponseQuery{
+				Code:  uint32(sdk.ABCICodeOK),
+				Value: []byte(strconv.FormatInt(app.cms.EarliestVersion(), 10)),
+			}
 		default:
 			result = sdk.ErrUnknownRequest(fmt.Sprintf("Unknown query: %s", path)).Result()
 		}
@@ -437,7 +605,7 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 			Value: value,
 		}
 	}
-	msg := "Expected second parameter to be either simulate or version, neither was present"
+	msg := "Expected second parameter to be either simulate, version, codespaces, earliest_version, block_results or fee-suggestion, none was present"
 	return sdk.ErrUnknownRequest(msg).QueryResult()
 }
 
@@ -486,8 +654,10 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 		return sdk.ErrUnknownRequest("no custom querier found for route " + path[1]).QueryResult()
 	}
 
-	ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.CheckState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger)
-	ctx = ctx.WithAccountCache(auth.NewAccountCache(app.AccountStoreCache))
+	ctx, err := app.customQueryContext(req.Height)
+	if err != nil {
+		return err.QueryResult()
+	}
 
 	// Passes the rest of the path as an argument to the querier.
 	// For example, in the path "custom/gov/proposal/test", the gov querier gets []string{"proposal", "test"} as the path
@@ -504,6 +674,76 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 	}
 }
 
+// customQueryContext returns the context of a custom query, on the state committed at height unless it
+// is 0, e.g. to chart how a value evolved. The past states are only available until they are pruned.
+func (app *BaseApp) customQueryContext(height int64) (sdk.Context, sdk.Error) {
+	if height == 0 || height == app.LastBlockHeight() {
+		ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.CheckState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger)
+		return ctx.WithAccountCache(auth.NewAccountCache(app.AccountStoreCache)).WithUpgradeMgr(app.upgradeMgr), nil
+	}
+	if height < 0 || height > app.LastBlockHeight() {
+		return sdk.Context{}, sdk.ErrUnknownRequest(fmt.Sprintf("invalid query height %d, latest height is %d", height, app.LastBlockHeight()))
+	}
+	versioned, ok := app.cms.(interface {
+		CacheMultiStoreWithVersion(version int64) (sdk.CacheMultiStore, error)
+	})
+	if !ok {
+		return sdk.Context{}, sdk.ErrUnknownRequest("multistore doesn't support queries at a past height")
+	}
+	cms, err := versioned.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return sdk.Context{}, sdk.ErrUnknownRequest(err.Error())
+	}
+	ctx := sdk.NewContext(cms, app.CheckState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger).WithBlockHeight(height)
+	return ctx.WithAccountCache(app.pastAccountCache(cms)).WithUpgradeMgr(app.upgradeMgr), nil
+}
+
+func handleQueryProve(app *BaseApp, path []string, req abci.RequestQuery) (res abci.ResponseQuery) {
+	// the proofQueryRouter routes using path[1], the querier gets the rest of the path like custom queriers
+	if len(path) < 2 || path[1] == "" {
+		return sdk.ErrUnknownRequest("No route for prove query specified").QueryResult()
+	}
+	querier := app.proofQueryRouter.Route(path[1])
+	if querier == nil {
+		return sdk.ErrUnknownRequest("no prove querier found for route " + path[1]).QueryResult()
+	}
+	queryable, ok := app.cms.(sdk.Queryable)
+	if !ok {
+		msg := "multistore doesn't support queries"
+		return sdk.ErrUnknownRequest(msg).QueryResult()
+	}
+
+	storeName, key, err := querier.Key(path[2:], req)
+	if err != nil {
+		return err.QueryResult()
+	}
+	// the key is read at the same height as it is proved
+	storeRes := queryable.Query(abci.RequestQuery{
+		Path:   fmt.Sprintf("/%s/key", storeName),
+		Data:   key,
+		Height: req.Height,
+		Prove:  req.Prove,
+	})
+	if !storeRes.IsOK() {
+		return storeRes
+	}
+	if storeRes.Log != "" {
+		return sdk.ErrUnknownRequest(storeRes.Log).QueryResult()
+	}
+
+	data, err := querier.Result(storeRes.Value, path[2:], req)
+	if err != nil {
+		return err.QueryResult()
+	}
+	return abci.ResponseQuery{
+		Code:   uint32(sdk.ABCICodeOK),
+		Key:    key,
+		Value:  codec.Cdc.MustMarshalBinaryLengthPrefixed(sdk.ProvedQueryResult{Data: data, StoreValue: storeRes.Value}),
+		Proof:  storeRes.Proof,
+		Height: storeRes.Height,
+	}
+}
+
 // BeginBlock implements the ABCI application interface.
 func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
 	if app.cms.TracingEnabled() {
@@ -513,7 +753,7 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		))
 	}
 
-	sdk.UpgradeMgr.SetHeight(req.Header.Height)
+	app.upgradeMgr.SetHeight(req.Header.Height)
 
 	// Initialize the DeliverTx state. If this is the first block, it should
 	// already be initialized in InitChain. Otherwise app.DeliverState will be
@@ -527,10 +767,29 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		app.DeliverState.Ctx = app.DeliverState.Ctx.WithBlockHash(req.Hash).WithBlockHeader(req.Header).WithBlockHeight(req.Header.Height)
 	}
 
+	app.proposalErr = nil
+	if app.proposalChecker != nil {
+		if err := app.proposalChecker(app.DeliverState.Ctx, req.Header); err != nil {
+			app.Logger.Error("block proposal rejected", "height", req.Header.Height, "err", err.ABCILog())
+			app.proposalErr = err
+		}
+	}
+	app.blockValidator = nil
+	if app.blockValidatorCreator != nil && app.proposalErr == nil {
+		app.blockValidator = app.blockValidatorCreator(app.DeliverState.Ctx, req.Header)
+	}
+
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.DeliverState.Ctx, req)
 	}
 
+	if app.lane != nil {
+		app.lane.beginBlock(app.DeliverState.Ctx)
+	}
+	if app.blockResults != nil {
+		app.blockResults.beginBlock(req.Header.Height, res.Events)
+	}
+
 	return
 }
 
@@ -562,25 +821,27 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 	var tx sdk.Tx
 	txBytes := req.Tx
 	// try to get the Tx first from cache, if succeed, it means it is PreChecked.
+	txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
 	tx, ok := app.GetTxFromCache(txBytes)
 	if ok {
-		txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
 		app.Logger.Debug("Handle CheckTx", "Tx", txHash)
-		result = app.RunTx(sdk.RunTxModeCheckAfterPre, tx, txHash)
+		result = app.runLaneCheckTx(sdk.RunTxModeCheckAfterPre, tx, len(txBytes), txHash)
 	} else {
-		tx, err := app.TxDecoder(txBytes)
+		var err sdk.Error
+		tx, err = app.TxDecoder(txBytes)
 		if err != nil {
 			result = err.Result()
 		} else {
 			app.txMsgCache.Add(string(txBytes), tx) // for recheck
-			txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
 			app.Logger.Debug("Handle CheckTx", "Tx", txHash)
-			result = app.RunTx(sdk.RunTxModeCheck, tx, txHash)
+			result = app.runLaneCheckTx(sdk.RunTxModeCheck, tx, len(txBytes), txHash)
 		}
 	}
 
 	if !result.IsOK() {
 		app.txMsgCache.Remove(string(req.Tx)) //not usable by DeliverTx
+	} else {
+		app.addPendingTx(txHash, tx)
 	}
 
 	return abci.ResponseCheckTx{
@@ -626,17 +887,24 @@ func (app *BaseApp) ReCheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx
 	// Decode the Tx.
 	var result sdk.Result
 	txBytes := req.Tx
+	txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
 	tx, ok := app.GetTxFromCache(txBytes)
-	if ok {
-		result = app.ReRunTx(txBytes, tx)
+	if replayed, isReplayed := app.replayedResult(txHash); isReplayed {
+		// the ante handler of the tx has already been replayed on Commit
+		result = replayed
+	} else if ok {
+		result = app.reRunTxInMempool(txBytes, tx)
 	} else { // not suppose to enter here actually
 		var tx, err = app.TxDecoder(txBytes)
 		if err != nil {
 			result = err.Result()
 		} else {
-			result = app.ReRunTx(txBytes, tx)
+			result = app.reRunTxInMempool(txBytes, tx)
 		}
 	}
+	if !result.IsOK() && app.pendingTxs != nil {
+		app.pendingTxs.remove(txHash)
+	}
 
 	return abci.ResponseCheckTx{
 		Code:   uint32(result.Code),
@@ -649,23 +917,34 @@ func (app *BaseApp) ReCheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx
 // Implements ABCI
 func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliverTx) {
 	// Decode the Tx.
+	tx, mode, decodeErr := app.decodeDeliverTx(req.Tx)
+	txHash := cmn.HexBytes(tmhash.Sum(req.Tx)).String()
+	// Tendermint removes the txs of the block from its mempool whatever their result
+	if decodeErr == nil {
+		defer app.forgetDeliveredTx(tx, txHash)
+	}
+
+	// the whole block is rejected, no need to execute the tx
+	if app.proposalErr != nil {
+		result := app.proposalErr.Result()
+		return abci.ResponseDeliverTx{
+			Code: uint32(result.Code),
+			Log:  result.Log,
+		}
+	}
+
 	var result sdk.Result
-	txBytes := req.Tx
-	tx, ok := app.GetTxFromCache(txBytes) //from checkTx
-	if ok {
-		// here means either the tx has passed PreDeliverTx or CheckTx,
-		// no need to verify signature
-		txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
-		app.Logger.Debug("Handle DeliverTx", "Tx", txHash)
-		result = app.RunTx(sdk.RunTxModeDeliverAfterPre, tx, txHash)
+	if decodeErr != nil {
+		result = decodeErr.Result()
+	} else if blockErr := app.validateBlockTx(tx); blockErr != nil {
+		result = blockErr.Result()
+	} else if laneErr := app.admitDeliverTx(tx, len(req.Tx)); laneErr != nil {
+		result = laneErr.Result()
 	} else {
-		var tx, err = app.TxDecoder(txBytes)
-		if err != nil {
-			result = err.Result()
-		} else {
-			txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
-			app.Logger.Debug("Handle DeliverTx", "Tx", txHash)
-			result = app.RunTx(sdk.RunTxModeDeliver, tx, txHash)
+		app.Logger.Debug("Handle DeliverTx", "Tx", txHash)
+		result = app.RunTx(mode, tx, txHash)
+		if result.IsOK() {
+			app.trackFee(tx)
 		}
 	}
 
@@ -673,6 +952,49 @@ func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliv
 	// namely fee deductions and sequence incrementing.
 
 	// Tell the blockchain engine (i.e. Tendermint).
+	return toResponseDeliverTx(result)
+}
+
+// decodeDeliverTx decodes a delivered tx and returns the mode to run it in
+func (app *BaseApp) decodeDeliverTx(txBytes []byte) (tx sdk.Tx, mode sdk.RunTxMode, err sdk.Error) {
+	tx, cached := app.GetTxFromCache(txBytes) //from checkTx
+	if !cached {
+		tx, err = app.TxDecoder(txBytes)
+	}
+
+	mode = sdk.RunTxModeDeliver
+	if cached {
+		// here means either the tx has passed PreDeliverTx or CheckTx,
+		// no need to verify signature
+		mode = sdk.RunTxModeDeliverAfterPre
+	}
+	return tx, mode, err
+}
+
+// validateBlockTx feeds the tx to the BlockValidator of the block. Once a tx
+// is rejected, all the following txs of the block are rejected with the same error.
+func (app *BaseApp) validateBlockTx(tx sdk.Tx) sdk.Error {
+	if app.blockValidator == nil {
+		return nil
+	}
+	if err := app.blockValidator.ValidateTx(app.DeliverState.Ctx, tx); err != nil {
+		app.Logger.Error("block rejected by block validator", "height", app.DeliverState.Ctx.BlockHeight(), "err", err.ABCILog())
+		app.proposalErr = err
+		app.blockValidator = nil
+		return err
+	}
+	return nil
+}
+
+// admitDeliverTx rejects a user tx beyond the user lane limit of the block
+func (app *BaseApp) admitDeliverTx(tx sdk.Tx, txSize int) sdk.Error {
+	if app.lane == nil {
+		return nil
+	}
+	return app.lane.admitDeliverTx(tx, txSize)
+}
+
+func toResponseDeliverTx(result sdk.Result) abci.ResponseDeliverTx {
 	return abci.ResponseDeliverTx{
 		Code:   uint32(result.Code),
 		Data:   result.Data,
@@ -694,16 +1016,16 @@ func (app *BaseApp) PreDeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDe
 }
 
 // Basic validator for msgs
-func validateBasicTxMsgs(msgs []sdk.Msg) sdk.Error {
+func validateBasicTxMsgs(upgradeMgr *sdk.UpgradeManager, msgs []sdk.Msg) sdk.Error {
 	if msgs == nil || len(msgs) != 1 {
 		// TODO: probably shouldn't be ErrInternal. Maybe new ErrInvalidMessage, or ?
 		return sdk.ErrInternal("Tx.GetMsgs() must return exactly one message")
 	}
 
 	for _, msg := range msgs {
-		if !sdk.IsMsgTypeSupported(msg.Type()) {
+		if !upgradeMgr.IsMsgTypeSupported(msg.Type()) {
 			return sdk.ErrMsgNotSupported(fmt.Sprintf("msg type(%s) is not supported before height %d",
-				msg.Type(), sdk.UpgradeMgr.GetMsgTypeHeight(msg.Type())))
+				msg.Type(), upgradeMgr.GetMsgTypeHeight(msg.Type())))
 		}
 
 		// Validate the Msg.
@@ -718,23 +1040,29 @@ func validateBasicTxMsgs(msgs []sdk.Msg) sdk.Error {
 }
 
 // retrieve the context with cache and store the tx bytes and tx hash
-func (app *BaseApp) getContextWithCache(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (sdk.Context,
+func (app *BaseApp) getContextWithCache(st *state, mode sdk.RunTxMode, tx sdk.Tx, txHash string) (sdk.Context,
 	sdk.CacheMultiStore, sdk.AccountCache) {
 	// Get the context
-	ctx := getState(app, mode).Ctx.WithTx(tx)
+	ctx := st.Ctx.WithTx(tx)
 	// Simulate a DeliverTx
 	if mode == sdk.RunTxModeSimulate {
 		ctx = ctx.WithRunTxMode(mode)
 	}
 
 	ms := ctx.MultiStore()
-	msCache := ms.CacheMultiStore()
+	var msCache sdk.CacheMultiStore
+	var accountCache sdk.AccountCache
+	if app.stateDiff != nil && (mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre) {
+		msCache, accountCache = app.stateDiff.cacheStores(ms, st.AccountCache)
+	} else {
+		msCache = ms.CacheMultiStore()
+		accountCache = st.AccountCache.Cache()
+	}
 	if msCache.TracingEnabled() {
 		msCache = msCache.WithTracingContext(sdk.TraceContext(
 			map[string]interface{}{"txHash": txHash},
 		)).(sdk.CacheMultiStore)
 	}
-	accountCache := getAccountCache(app, mode).Cache()
 
 	return ctx.WithMultiStore(msCache).WithAccountCache(accountCache), msCache, accountCache
 }
@@ -755,7 +1083,10 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode sdk.RunTxMode)
 			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msgRoute).Result()
 		}
 
-		msgResult := handler(ctx.WithRunTxMode(mode), msg)
+		msgCtx, span := sdk.StartSpan(ctx, "Msg", "msg.route", msgRoute, "msg.type", msg.Type())
+		msgResult := handler(msgCtx.WithRunTxMode(mode), msg)
+		span.SetAttribute("code", uint32(msgResult.Code))
+		span.End()
 		msgResult.Tags = append(msgResult.Tags, sdk.MakeTag("action", []byte(msg.Type())))
 
 		// Append Data and Tags
@@ -803,42 +1134,60 @@ func getState(app *BaseApp, mode sdk.RunTxMode) *state {
 	return app.DeliverState
 }
 
-// Returns AccountCache of CheckState or DeliverState according to the tx mode
-func getAccountCache(app *BaseApp, mode sdk.RunTxMode) sdk.AccountCache {
-	if mode == sdk.RunTxModeCheckAfterPre ||
-		mode == sdk.RunTxModeCheck ||
-		mode == sdk.RunTxModeSimulate ||
-		mode == sdk.RunTxModeReCheck {
-		return app.CheckState.AccountCache
-	}
-
-	return app.DeliverState.AccountCache
-}
-
 // RunTx processes a transaction. The transactions is proccessed via an
 // anteHandler. txBytes may be nil in some cases, eg. in tests. Also, in the
 // future we may support "internal" transactions.
 func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result sdk.Result) {
+	return app.runTx(getState(app, mode), mode, tx, txHash)
+}
+
+// runTx processes a transaction on top of the given state
+func (app *BaseApp) runTx(st *state, mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result sdk.Result) {
 	// meter so we initialize upfront.
-	ctx, msCache, accountCache := app.getContextWithCache(mode, tx, txHash)
+	ctx, msCache, accountCache := app.getContextWithCache(st, mode, tx, txHash)
+
+	result = app.execTx(ctx, mode, tx, txHash)
+
+	if mode == sdk.RunTxModeSimulate {
+		return
+	}
 
+	// only update state if all messages pass
+	if result.IsOK() {
+		app.writeTx(ctx, mode, tx, txHash, msCache, accountCache)
+	}
+
+	return
+}
+
+// execTx runs the checks, the ante handler and the msgs of a tx on ctx, without writing
+// the caches of ctx
+func (app *BaseApp) execTx(ctx sdk.Context, mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result sdk.Result) {
+	ctx, span := sdk.StartSpan(ctx, txSpanName(mode), "tx.hash", txHash)
 	defer func() {
 		if r := recover(); r != nil {
 			log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
 			result = sdk.ErrInternal(log).Result()
 		}
-
+		span.SetAttribute("code", uint32(result.Code))
+		span.End()
 	}()
 
 	var msgs = tx.GetMsgs()
-	if err := validateBasicTxMsgs(msgs); err != nil {
+	if err := validateBasicTxMsgs(app.upgradeMgr, msgs); err != nil {
 		return err.Result()
 	}
 
+	if app.proposalTxChecker != nil {
+		if err := app.proposalTxChecker(ctx, tx); err != nil {
+			return err.Result()
+		}
+	}
+
 	// run the ante handler
 	ctx = ctx.WithValue(TxHashKey, txHash)
 	if app.anteHandler != nil {
-		newCtx, result, abort := app.anteHandler(ctx, tx, mode)
+		newCtx, result, abort := app.runAnteHandler(ctx, tx, mode)
 		if !newCtx.IsZero() {
 			ctx = newCtx
 		}
@@ -852,31 +1201,56 @@ func (app *BaseApp) RunTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) (result
 	if stdTx, ok := tx.(auth.StdTx); ok {
 		txSrc = stdTx.GetSource()
 	}
-	result = app.runMsgs(
+	return app.runMsgs(
 		ctx.WithValue(TxSourceKey, txSrc),
 		msgs,
 		mode)
+}
 
-	if mode == sdk.RunTxModeSimulate {
-		return
+// runAnteHandler runs the ante handler within its own span. The ante handler gets ctx
+// rather than the context of the span, so that the msgs run on the context it returns are
+// not children of the span.
+func (app *BaseApp) runAnteHandler(ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode) (newCtx sdk.Context, result sdk.Result, abort bool) {
+	_, span := sdk.StartSpan(ctx, "AnteHandler")
+	defer span.End()
+	newCtx, result, abort = app.anteHandler(ctx, tx, mode)
+	span.SetAttribute("code", uint32(result.Code))
+	return newCtx, result, abort
+}
+
+// txSpanName returns the name of the root span of a tx run in mode
+func txSpanName(mode sdk.RunTxMode) string {
+	switch mode {
+	case sdk.RunTxModeCheck, sdk.RunTxModeCheckAfterPre:
+		return "CheckTx"
+	case sdk.RunTxModeReCheck:
+		return "ReCheckTx"
+	case sdk.RunTxModeSimulate:
+		return "Simulate"
+	default:
+		return "DeliverTx"
 	}
+}
 
-	// only update state if all messages pass
-	if result.IsOK() {
-		if mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre {
-			if app.collect.CollectAccountBalance {
-				app.Pool.AddAddrs(msgs[0].GetInvolvedAddresses())
-			}
-			if app.collect.CollectTxs {
-				// Should we add all msg here with no distinction ？
-				app.Pool.AddTx(tx, txHash)
-			}
+// writeTx writes the caches of a successful tx to the state
+func (app *BaseApp) writeTx(ctx sdk.Context, mode sdk.RunTxMode, tx sdk.Tx, txHash string,
+	msCache sdk.CacheMultiStore, accountCache sdk.AccountCache) {
+	if mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre {
+		if app.collect.CollectAccountBalance {
+			app.Pool.AddAddrs(tx.GetMsgs()[0].GetInvolvedAddresses())
+		}
+		if app.collect.CollectTxs {
+			// Should we add all msg here with no distinction ？
+			app.Pool.AddTx(tx, txHash)
+		}
+	}
+	accountCache.Write()
+	msCache.Write()
+	if app.stateDiff != nil && (mode == sdk.RunTxModeDeliver || mode == sdk.RunTxModeDeliverAfterPre) {
+		if err := app.stateDiff.dump(ctx.BlockHeight(), txHash); err != nil {
+			app.Logger.Error("failed to dump state diff", "tx", txHash, "err", err)
 		}
-		accountCache.Write()
-		msCache.Write()
 	}
-
-	return
 }
 
 // RunTx processes a transaction. The transactions is proccessed via an
@@ -886,19 +1260,28 @@ func (app *BaseApp) ReRunTx(txBytes []byte, tx sdk.Tx) (result sdk.Result) {
 	// meter so we initialize upfront.
 	mode := sdk.RunTxModeReCheck
 	txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
-	ctx, msCache, accountCache := app.getContextWithCache(mode, tx, txHash)
+	ctx, msCache, accountCache := app.getContextWithCache(getState(app, mode), mode, tx, txHash)
+	ctx, span := sdk.StartSpan(ctx, txSpanName(mode), "tx.hash", txHash)
 
 	defer func() {
 		if r := recover(); r != nil {
 			log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
 			result = sdk.ErrInternal(log).Result()
 		}
-
+		span.SetAttribute("code", uint32(result.Code))
+		span.End()
 	}()
 
+	// the block may have made the tx invalid, e.g. a sunset height has been reached
+	if app.proposalTxChecker != nil {
+		if err := app.proposalTxChecker(ctx, tx); err != nil {
+			return err.Result()
+		}
+	}
+
 	// run the ante handler
 	if app.anteHandler != nil {
-		newCtx, result, abort := app.anteHandler(ctx.WithValue(TxHashKey, txHash), tx, mode)
+		newCtx, result, abort := app.runAnteHandler(ctx.WithValue(TxHashKey, txHash), tx, mode)
 		if !newCtx.IsZero() {
 			ctx = newCtx
 		}
@@ -933,6 +1316,9 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 	if app.endBlocker != nil {
 		res = app.endBlocker(app.DeliverState.Ctx, req)
 	}
+	if app.blockResults != nil {
+		app.blockResults.endBlock(res.ValidatorUpdates, res.Events)
+	}
 
 	return
 }
@@ -952,7 +1338,17 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// Write the Deliver state and commit the MultiStore
 	app.DeliverState.WriteAccountCache()
 	app.DeliverState.ms.Write()
+	_, span := sdk.StartSpan(app.DeliverState.Ctx, "Commit", "height", header.Height)
 	commitID := app.cms.Commit()
+	span.SetAttribute("app_hash", fmt.Sprintf("%X", commitID.Hash))
+	span.End()
+	if app.blockResults != nil {
+		app.blockResults.commit()
+	}
+	if app.feeTracker != nil {
+		app.feeTracker.commit()
+	}
+	app.snapshot(header.Height)
 	// TODO: this is missing a module identifier and dumps byte array
 	app.Logger.Debug("Commit synced",
 		"commit", commitID,
@@ -962,16 +1358,51 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
 	// Use the header from this latest block.
 	app.SetCheckState(header)
+	if app.mempool != nil {
+		app.mempool.gc(header.Height)
+	}
+	app.replayPendingTxs()
+	if app.stateDiff != nil {
+		if err := app.stateDiff.flush(); err != nil {
+			app.Logger.Error("failed to flush state diffs", "err", err)
+		}
+	}
 
 	// Empty the Deliver state
 	app.DeliverState = nil
+	app.proposalErr = nil
+	app.blockValidator = nil
 	app.Pool.Clear()
 
+	if app.shouldHalt(header) {
+		app.halt(header)
+	}
+
 	return abci.ResponseCommit{
 		Data: commitID.Hash,
 	}
 }
 
+// shouldHalt returns whether the node must stop after committing the block of header
+func (app *BaseApp) shouldHalt(header abci.Header) bool {
+	return (app.haltHeight > 0 && header.Height >= app.haltHeight) ||
+		(app.haltTime > 0 && header.Time.Unix() >= app.haltTime)
+}
+
+// halt gracefully stops the node by signaling the process, as a Ctrl-C would, so that the
+// committed block is flushed and the services are stopped. It exits if it can't be signaled.
+func (app *BaseApp) halt(header abci.Header) {
+	app.Logger.Info("Halting node per configuration", "height", header.Height, "time", header.Time,
+		"haltHeight", app.haltHeight, "haltTime", app.haltTime)
+	if p, err := os.FindProcess(os.Getpid()); err == nil {
+		// try SIGTERM if SIGINT is not supported by the os
+		if p.Signal(syscall.SIGINT) == nil || p.Signal(syscall.SIGTERM) == nil {
+			return
+		}
+	}
+	os.Exit(0)
+}
+
 func (app *BaseApp) StartRecovery(manifest *abci.Manifest) error {
 	return app.StateSyncHelper.StartRecovery(manifest)
 }
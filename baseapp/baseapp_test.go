@@ -2,20 +2,26 @@ package baseapp
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -118,6 +124,29 @@ func TestMountStores(t *testing.T) {
 	require.NotNil(t, store2)
 }
 
+func TestSetStoreLoader(t *testing.T) {
+	app := newBaseApp(t.Name())
+	app.MountStoresIAVL(capKey1)
+
+	loaded := false
+	app.SetStoreLoader(func(ms sdk.CommitMultiStore) error {
+		loaded = true
+		return DefaultStoreLoader(ms)
+	})
+	require.Nil(t, app.LoadLatestVersion(capKey1))
+	require.True(t, loaded)
+
+	app = newBaseApp(t.Name())
+	app.MountStoresIAVL(capKey1)
+	app.SetStoreLoader(func(ms sdk.CommitMultiStore) error {
+		return errors.New("migration failed")
+	})
+	require.EqualError(t, app.LoadLatestVersion(capKey1), "migration failed")
+
+	app.Seal()
+	require.Panics(t, func() { app.SetStoreLoader(DefaultStoreLoader) })
+}
+
 // Test that we can make commits and then reload old versions.
 // Test that LoadLatestVersion actually does.
 func TestLoadVersion(t *testing.T) {
@@ -185,6 +214,39 @@ func TestOptionFunction(t *testing.T) {
 	require.Equal(t, bap.name, "new name", "BaseApp should have had name changed via option function")
 }
 
+func TestHaltTime(t *testing.T) {
+	haltTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	app := newBaseApp(t.Name())
+	require.False(t, app.shouldHalt(abci.Header{Time: haltTime}), "halt is disabled by default")
+
+	app = newBaseApp(t.Name(), SetHaltTime(haltTime.Unix()))
+	require.False(t, app.shouldHalt(abci.Header{Time: haltTime.Add(-time.Second)}))
+	require.True(t, app.shouldHalt(abci.Header{Time: haltTime}))
+	require.True(t, app.shouldHalt(abci.Header{Time: haltTime.Add(time.Hour)}))
+
+	require.Panics(t, func() { SetHaltTime(-1) })
+}
+
+func TestHaltHeight(t *testing.T) {
+	app := newBaseApp(t.Name())
+	require.False(t, app.shouldHalt(abci.Header{Height: 100}), "halt is disabled by default")
+
+	app = newBaseApp(t.Name(), SetHaltHeight(100))
+	require.False(t, app.shouldHalt(abci.Header{Height: 99}))
+	require.True(t, app.shouldHalt(abci.Header{Height: 100}))
+	require.True(t, app.shouldHalt(abci.Header{Height: 101}))
+
+	// the first of the height and the time reached halts the node
+	haltTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	app = newBaseApp(t.Name(), SetHaltHeight(100), SetHaltTime(haltTime.Unix()))
+	require.False(t, app.shouldHalt(abci.Header{Height: 99, Time: haltTime.Add(-time.Second)}))
+	require.True(t, app.shouldHalt(abci.Header{Height: 99, Time: haltTime}))
+	require.True(t, app.shouldHalt(abci.Header{Height: 100, Time: haltTime.Add(-time.Second)}))
+
+	require.Panics(t, func() { SetHaltHeight(-1) })
+}
+
 func testChangeNameHelper(name string) func(*BaseApp) {
 	return func(bap *BaseApp) {
 		bap.name = name
@@ -236,12 +298,62 @@ func TestInfo(t *testing.T) {
 
 	// should be empty
 	assert.Equal(t, "", res.Version)
-	assert.Equal(t, t.Name(), res.GetData())
 	assert.Equal(t, int64(0), res.LastBlockHeight)
 	require.Equal(t, []uint8(nil), res.LastBlockAppHash)
 
+	var info AppInfo
+	require.NoError(t, json.Unmarshal([]byte(res.Data), &info))
+	assert.Equal(t, t.Name(), info.Name)
+	assert.Empty(t, info.ModuleVersions)
+	assert.Empty(t, info.UpgradeHeights)
+	assert.Empty(t, info.ActivatedUpgrades)
+
 	// ----- test a proper response -------
-	// TODO
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight("activated", 1)
+	sdk.UpgradeMgr.AddUpgradeHeight("scheduled", 100)
+
+	app = setupBaseApp(t)
+	app.SetModuleVersions(map[string]uint64{"stake": 2, "gov": 1})
+	app.InitChain(abci.RequestInitChain{})
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	app.EndBlock(abci.RequestEndBlock{Height: 1})
+	app.Commit()
+
+	res = app.Info(abci.RequestInfo{})
+	require.Equal(t, int64(1), res.LastBlockHeight)
+
+	info = AppInfo{}
+	require.NoError(t, json.Unmarshal([]byte(res.Data), &info))
+	require.Equal(t, t.Name(), info.Name)
+	require.Equal(t, map[string]uint64{"stake": 2, "gov": 1}, info.ModuleVersions)
+	require.Equal(t, map[string]int64{"activated": 1, "scheduled": 100}, info.UpgradeHeights)
+	require.Equal(t, []string{"activated"}, info.ActivatedUpgrades)
+}
+
+func TestInstance(t *testing.T) {
+	beaconChain, sideChain := sdk.NewInstance("beacon"), sdk.NewInstance("side")
+	beaconChain.UpgradeMgr.AddUpgradeHeight(sdk.BEP159, 1)
+	beaconApp := setupBaseApp(t, SetInstance(beaconChain))
+	sideApp := setupBaseApp(t, SetInstance(sideChain))
+	require.True(t, beaconApp.UpgradeMgr() == beaconChain.UpgradeMgr)
+
+	for _, app := range []*BaseApp{beaconApp, sideApp} {
+		app.InitChain(abci.RequestInitChain{})
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	}
+
+	// each app reads its own upgrade manager, the one of the process is left alone
+	require.True(t, beaconApp.DeliverState.Ctx.UpgradeMgr().IsUpgrade(sdk.BEP159))
+	require.False(t, sideApp.DeliverState.Ctx.UpgradeMgr().IsUpgrade(sdk.BEP159))
+	require.Equal(t, int64(1), sideChain.UpgradeMgr.GetHeight())
+	require.False(t, sdk.IsUpgrade(sdk.BEP159))
+
+	var beaconInfo, sideInfo AppInfo
+	require.NoError(t, json.Unmarshal([]byte(beaconApp.Info(abci.RequestInfo{}).Data), &beaconInfo))
+	require.NoError(t, json.Unmarshal([]byte(sideApp.Info(abci.RequestInfo{}).Data), &sideInfo))
+	require.Equal(t, map[string]int64{sdk.BEP159: 1}, beaconInfo.UpgradeHeights)
+	require.Empty(t, sideInfo.UpgradeHeights)
 }
 
 //------------------------------------------------------------------------------------------
@@ -314,6 +426,74 @@ func TestInitChainer(t *testing.T) {
 	require.Equal(t, value, res.Value)
 }
 
+func TestStreamingInitChainer(t *testing.T) {
+	app := NewMockBaseApp(t.Name(), defaultLogger(), dbm.NewMemDB(), nil, sdk.CollectConfig{})
+	capKey := sdk.NewKVStoreKey("main")
+	app.MountStoresIAVL(capKey)
+
+	// the streaming init chainer takes precedence
+	app.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		panic("unexpected call")
+	})
+	app.SetStreamingInitChainer(func(ctx sdk.Context, req abci.RequestInitChain, appState io.Reader) (abci.ResponseInitChain, error) {
+		bz, err := io.ReadAll(appState)
+		ctx.KVStore(capKey).Set([]byte("state"), bz)
+		return abci.ResponseInitChain{}, err
+	})
+	require.Nil(t, app.LoadLatestVersion(capKey))
+
+	app.InitChain(abci.RequestInitChain{AppStateBytes: []byte(`{"a":1}`), ChainId: "test-chain-id"})
+	app.Commit()
+	res := app.Query(abci.RequestQuery{Path: "/store/main/key", Data: []byte("state")})
+	require.Equal(t, []byte(`{"a":1}`), res.Value)
+}
+
+// keyProofQuerier answers with the value of the key in the query data, prefixed
+type keyProofQuerier struct{}
+
+func (keyProofQuerier) Key(path []string, req abci.RequestQuery) (string, []byte, sdk.Error) {
+	return capKey1.Name(), req.Data, nil
+}
+
+func (keyProofQuerier) Result(value []byte, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+	if value == nil {
+		return nil, sdk.ErrUnknownRequest("no value")
+	}
+	return append([]byte(path[0]+":"), value...), nil
+}
+
+func TestProofQuery(t *testing.T) {
+	app := setupBaseApp(t, func(bapp *BaseApp) {
+		bapp.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+			ctx.KVStore(capKey1).Set([]byte("foo"), []byte("bar"))
+			return abci.ResponseInitChain{}
+		})
+		bapp.ProofQueryRouter().AddRoute("key", keyProofQuerier{})
+	})
+	app.InitChain(abci.RequestInitChain{ChainId: "test-chain-id"})
+	app.Commit()
+
+	res := app.Query(abci.RequestQuery{Path: "/prove/key/prefix", Data: []byte("foo"), Prove: true})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, []byte("foo"), res.Key)
+	var proved sdk.ProvedQueryResult
+	require.NoError(t, codec.Cdc.UnmarshalBinaryLengthPrefixed(res.Value, &proved))
+	require.Equal(t, []byte("prefix:bar"), proved.Data)
+	require.Equal(t, []byte("bar"), proved.StoreValue)
+
+	// the proof proves the store value under the app hash
+	kp := merkle.KeyPath{}.
+		AppendKey([]byte(capKey1.Name()), merkle.KeyEncodingURL).
+		AppendKey(res.Key, merkle.KeyEncodingURL)
+	prt := store.DefaultProofRuntime()
+	require.NoError(t, prt.VerifyValue(res.Proof, app.LastCommitID().Hash, kp.String(), proved.StoreValue))
+
+	res = app.Query(abci.RequestQuery{Path: "/prove/key/prefix", Data: []byte("baz"), Prove: true})
+	require.False(t, res.IsOK())
+	res = app.Query(abci.RequestQuery{Path: "/prove/none", Data: []byte("foo")})
+	require.False(t, res.IsOK())
+}
+
 //------------------------------------------------------------------------------------------
 // Mock tx, msgs, and mapper for the baseapp tests.
 // Self-contained, just uses counters.
@@ -558,6 +738,67 @@ func TestDeliverTx(t *testing.T) {
 	}
 }
 
+type recordedSpan struct {
+	name   string
+	parent string
+	attrs  map[string]interface{}
+	ended  bool
+}
+
+func (s *recordedSpan) SetAttribute(key string, value interface{}) { s.attrs[key] = value }
+func (s *recordedSpan) End()                                       { s.ended = true }
+
+type recordingTracer struct {
+	spans []*recordedSpan
+}
+
+type recordedSpanKey struct{}
+
+func (rt *recordingTracer) Start(ctx context.Context, name string) (context.Context, sdk.Span) {
+	span := &recordedSpan{name: name, attrs: make(map[string]interface{})}
+	if parent, ok := ctx.Value(recordedSpanKey{}).(*recordedSpan); ok {
+		span.parent = parent.name
+	}
+	rt.spans = append(rt.spans, span)
+	return context.WithValue(ctx, recordedSpanKey{}, span), span
+}
+
+func TestTracing(t *testing.T) {
+	anteOpt := func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, capKey1, []byte("ante-key"))) }
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, []byte("deliver-key")))
+	}
+	tracer := &recordingTracer{}
+	app := setupBaseApp(t, anteOpt, routerOpt, SetTracer(tracer))
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+
+	require.True(t, app.CheckTx(abci.RequestCheckTx{Tx: txBytes}).IsOK())
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	require.True(t, app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes}).IsOK())
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	var names, parents []string
+	for _, span := range tracer.spans {
+		require.True(t, span.ended, span.name)
+		names = append(names, span.name)
+		parents = append(parents, span.parent)
+	}
+	require.Equal(t, []string{"CheckTx", "AnteHandler", "Msg", "DeliverTx", "AnteHandler", "Msg", "Commit"}, names)
+	require.Equal(t, []string{"", "CheckTx", "CheckTx", "", "DeliverTx", "DeliverTx", ""}, parents)
+
+	txHash := tracer.spans[0].attrs["tx.hash"]
+	require.NotEmpty(t, txHash)
+	require.Equal(t, txHash, tracer.spans[3].attrs["tx.hash"])
+	require.Equal(t, uint32(sdk.ABCICodeOK), tracer.spans[3].attrs["code"])
+	require.Equal(t, routeMsgCounter, tracer.spans[5].attrs["msg.route"])
+	require.Equal(t, int64(1), tracer.spans[6].attrs["height"])
+}
+
 // Number of messages doesn't matter to CheckTx.
 func TestMultiMsgCheckTx(t *testing.T) {
 	// TODO: ensure we get the same results
@@ -596,6 +837,174 @@ func TestMultiMsgDeliverTx(t *testing.T) {
 	}
 }
 
+// A rejected block fails all of its txs without executing them,
+// and the tx checker rejects single txs.
+func TestProposalChecker(t *testing.T) {
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, handlerMsgCounter(t, capKey1, deliverKey))
+	}
+	maxTxs := int64(2)
+	checkerOpt := func(bapp *BaseApp) {
+		bapp.SetProposalChecker(func(ctx sdk.Context, header abci.Header) sdk.Error {
+			if header.NumTxs > maxTxs {
+				return sdk.ErrBlockRejected(fmt.Sprintf("too many txs: %d", header.NumTxs))
+			}
+			return nil
+		})
+		bapp.SetProposalTxChecker(func(ctx sdk.Context, tx sdk.Tx) sdk.Error {
+			if tx.(txTest).Counter < 0 {
+				return sdk.ErrMsgNotSupported("negative tx counter")
+			}
+			return nil
+		})
+	}
+
+	app := setupBaseApp(t, routerOpt, checkerOpt)
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	// rejected block, nothing is executed
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1, NumTxs: maxTxs + 1}})
+	for i := int64(0); i <= maxTxs; i++ {
+		txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(i, 0))
+		require.NoError(t, err)
+		res := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+		require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeBlockRejected), sdk.ABCICodeType(res.Code))
+	}
+	require.Equal(t, int64(0), getIntFromStore(app.DeliverState.Ctx.KVStore(capKey1), deliverKey))
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// valid block, only the tx rejected by the tx checker fails
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2, NumTxs: maxTxs}})
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(-1, 0))
+	require.NoError(t, err)
+	res := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeMsgNotSupported), sdk.ABCICodeType(res.Code))
+
+	txBytes, err = codec.MarshalBinaryLengthPrefixed(newTxCounter(0, 0))
+	require.NoError(t, err)
+	res = app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, int64(1), getIntFromStore(app.DeliverState.Ctx.KVStore(capKey1), deliverKey))
+
+	// the tx checker also guards the mempool
+	txBytes, err = codec.MarshalBinaryLengthPrefixed(newTxCounter(-1, 0))
+	require.NoError(t, err)
+	checkRes := app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeMsgNotSupported), sdk.ABCICodeType(checkRes.Code))
+	result := app.ReRunTx(txBytes, newTxCounter(-1, 0))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeMsgNotSupported), result.Code)
+}
+
+// The state written by the tx checker is kept only if the tx succeeds
+func TestProposalTxCheckerState(t *testing.T) {
+	// msgs with an odd counter fail
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			if msg.(*msgCounter).Counter%2 == 1 {
+				return sdk.ErrInternal("odd counter").Result()
+			}
+			return sdk.Result{}
+		})
+	}
+	// each tx counter may only be delivered once
+	checkerOpt := func(bapp *BaseApp) {
+		bapp.SetProposalTxChecker(func(ctx sdk.Context, tx sdk.Tx) sdk.Error {
+			store := ctx.KVStore(capKey2)
+			key := i2b(tx.(txTest).Counter)
+			if store.Has(key) {
+				return sdk.ErrMsgNotSupported("duplicated tx counter")
+			}
+			store.Set(key, []byte{1})
+			return nil
+		})
+	}
+
+	app := setupBaseApp(t, routerOpt, checkerOpt)
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	deliver := func(counter int64, msgCounter int64) abci.ResponseDeliverTx {
+		txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(counter, msgCounter))
+		require.NoError(t, err)
+		return app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	}
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	// the failed tx doesn't consume its counter, only the duplicated tx is rejected
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInternal), sdk.ABCICodeType(deliver(7, 1).Code))
+	require.True(t, deliver(7, 0).IsOK())
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeMsgNotSupported), sdk.ABCICodeType(deliver(7, 2).Code))
+	require.True(t, deliver(8, 2).IsOK())
+}
+
+// uniqueCounterValidator rejects a block delivering the same tx counter twice
+type uniqueCounterValidator struct {
+	seen map[int64]bool
+}
+
+func (v *uniqueCounterValidator) ValidateTx(ctx sdk.Context, tx sdk.Tx) sdk.Error {
+	counter := tx.(txTest).Counter
+	if v.seen[counter] {
+		return sdk.ErrBlockRejected(fmt.Sprintf("duplicated counter %d", counter))
+	}
+	v.seen[counter] = true
+	return nil
+}
+
+func TestBlockValidator(t *testing.T) {
+	executedKey := []byte("executed")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			store := ctx.KVStore(capKey1)
+			setIntOnStore(store, executedKey, getIntFromStore(store, executedKey)+1)
+			return sdk.Result{}
+		})
+	}
+	validatorOpt := func(bapp *BaseApp) {
+		bapp.SetBlockValidatorCreator(func(ctx sdk.Context, header abci.Header) sdk.BlockValidator {
+			return &uniqueCounterValidator{seen: make(map[int64]bool)}
+		})
+	}
+
+	app := setupBaseApp(t, routerOpt, validatorOpt, SetConcurrentDeliver(2))
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	req := func(counter int64) abci.RequestDeliverTx {
+		txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(counter, counter))
+		require.NoError(t, err)
+		return abci.RequestDeliverTx{Tx: txBytes}
+	}
+	executed := func() int64 {
+		return getIntFromStore(app.DeliverState.Ctx.KVStore(capKey1), executedKey)
+	}
+	rejected := sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeBlockRejected)
+
+	// the duplicated tx and all the following txs are rejected
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	require.True(t, app.DeliverTx(req(0)).IsOK())
+	require.Equal(t, rejected, sdk.ABCICodeType(app.DeliverTx(req(0)).Code))
+	require.Equal(t, rejected, sdk.ABCICodeType(app.DeliverTx(req(1)).Code))
+	require.Equal(t, int64(1), executed())
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// the validator is reset for every block, and sees the txs delivered together before any is executed
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	res := app.DeliverTxs([]abci.RequestDeliverTx{req(0), req(1), req(0), req(2)})
+	require.True(t, res[0].IsOK())
+	require.True(t, res[1].IsOK())
+	require.Equal(t, rejected, sdk.ABCICodeType(res[2].Code))
+	require.Equal(t, rejected, sdk.ABCICodeType(res[3].Code))
+	require.Equal(t, int64(3), executed())
+}
+
 // Interleave calls to Check and Deliver and ensure
 // that there is no cross-talk. Check sees results of the previous Check calls
 // and Deliver sees that of the previous Deliver calls, but they don't see eachother.
@@ -0,0 +1,107 @@
+package baseapp
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// number of committed blocks whose results are kept by default, see SetBlockResultsRetention
+const defaultBlockResultsRetention = 100
+
+// BlockResult holds the validator updates and the BeginBlock and EndBlock events of a
+// committed block, so that side chain relayers can learn about validator set changes
+// with an app query instead of a Tendermint RPC subscription.
+type BlockResult struct {
+	Height           int64                  `json:"height"`
+	ValidatorUpdates []abci.ValidatorUpdate `json:"validator_updates"`
+	Events           []abci.Event           `json:"events"`
+}
+
+// blockResults is a ring buffer of the results of the last committed blocks
+type blockResults struct {
+	mtx     sync.RWMutex
+	results []BlockResult
+	next    int // index of the next result to write
+	size    int
+
+	// result of the block being executed, added on Commit
+	pending BlockResult
+}
+
+func newBlockResults(retention int) *blockResults {
+	return &blockResults{
+		results: make([]BlockResult, retention),
+	}
+}
+
+func (br *blockResults) beginBlock(height int64, events []abci.Event) {
+	br.pending = BlockResult{
+		Height: height,
+		Events: events,
+	}
+}
+
+func (br *blockResults) endBlock(updates []abci.ValidatorUpdate, events []abci.Event) {
+	br.pending.ValidatorUpdates = updates
+	br.pending.Events = append(br.pending.Events, events...)
+}
+
+func (br *blockResults) commit() {
+	br.mtx.Lock()
+	defer br.mtx.Unlock()
+	br.results[br.next] = br.pending
+	br.next = (br.next + 1) % len(br.results)
+	if br.size < len(br.results) {
+		br.size++
+	}
+	br.pending = BlockResult{}
+}
+
+// get returns the result of the block at height, or of the last committed block if height is 0
+func (br *blockResults) get(height int64) (BlockResult, bool) {
+	br.mtx.RLock()
+	defer br.mtx.RUnlock()
+	if br.size == 0 {
+		return BlockResult{}, false
+	}
+	last := (br.next - 1 + len(br.results)) % len(br.results)
+	if height == 0 {
+		return br.results[last], true
+	}
+	offset := br.results[last].Height - height
+	if offset < 0 || offset >= int64(br.size) {
+		return BlockResult{}, false
+	}
+	result := br.results[(last-int(offset)+len(br.results))%len(br.results)]
+	return result, result.Height == height
+}
+
+// handleQueryBlockResults answers app/block_results[/<height>] with the JSON encoded BlockResult
+// of the last committed block or of the given height
+func handleQueryBlockResults(app *BaseApp, path []string) abci.ResponseQuery {
+	if app.blockResults == nil {
+		return sdk.ErrUnknownRequest("block results are not kept by this node").QueryResult()
+	}
+	var height int64
+	if len(path) > 2 {
+		var err error
+		height, err = strconv.ParseInt(path[2], 10, 64)
+		if err != nil || height <= 0 {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("invalid height %s", path[2])).QueryResult()
+		}
+	}
+	result, ok := app.blockResults.get(height)
+	if !ok {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("no block results for height %d", height)).QueryResult()
+	}
+	return abci.ResponseQuery{
+		Code:  uint32(sdk.ABCICodeOK),
+		Value: codec.Cdc.MustMarshalJSON(result),
+	}
+}
@@ -0,0 +1,73 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestBlockResultsRing(t *testing.T) {
+	br := newBlockResults(3)
+	_, ok := br.get(0)
+	require.False(t, ok)
+
+	for height := int64(1); height <= 5; height++ {
+		br.beginBlock(height, []abci.Event{{Type: "begin"}})
+		br.endBlock([]abci.ValidatorUpdate{{Power: height}}, []abci.Event{{Type: "end"}})
+		br.commit()
+	}
+
+	result, ok := br.get(0)
+	require.True(t, ok)
+	require.Equal(t, int64(5), result.Height)
+	require.Equal(t, []abci.ValidatorUpdate{{Power: 5}}, result.ValidatorUpdates)
+	require.Equal(t, []abci.Event{{Type: "begin"}, {Type: "end"}}, result.Events)
+
+	result, ok = br.get(3)
+	require.True(t, ok)
+	require.Equal(t, int64(3), result.Height)
+
+	// only the last 3 blocks are kept
+	_, ok = br.get(2)
+	require.False(t, ok)
+	_, ok = br.get(6)
+	require.False(t, ok)
+}
+
+func TestQueryBlockResults(t *testing.T) {
+	app := setupBaseApp(t)
+	app.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+		return abci.ResponseEndBlock{ValidatorUpdates: []abci.ValidatorUpdate{{Power: req.Height}}}
+	})
+	app.InitChain(abci.RequestInitChain{})
+
+	query := abci.RequestQuery{Path: "/app/block_results"}
+	require.False(t, app.Query(query).IsOK(), "no block committed yet")
+
+	for height := int64(1); height <= 2; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.EndBlock(abci.RequestEndBlock{Height: height})
+		app.Commit()
+	}
+
+	res := app.Query(query)
+	require.True(t, res.IsOK(), res.Log)
+	var result BlockResult
+	codec.Cdc.MustUnmarshalJSON(res.Value, &result)
+	require.Equal(t, int64(2), result.Height)
+	require.Len(t, result.ValidatorUpdates, 1)
+	require.Equal(t, int64(2), result.ValidatorUpdates[0].Power)
+
+	res = app.Query(abci.RequestQuery{Path: "/app/block_results/1"})
+	require.True(t, res.IsOK(), res.Log)
+	codec.Cdc.MustUnmarshalJSON(res.Value, &result)
+	require.Equal(t, int64(1), result.Height)
+
+	app = newBaseApp(t.Name(), SetBlockResultsRetention(0))
+	require.Nil(t, app.blockResults)
+	require.Panics(t, func() { SetBlockResultsRetention(-1) })
+}
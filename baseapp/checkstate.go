@@ -0,0 +1,176 @@
+package baseapp
+
+import (
+	"container/list"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// strategies rebuilding the CheckTx state on Commit, see SetCheckStateReset
+const (
+	// CheckStateResetCommitted rebuilds the CheckTx state from the committed state only,
+	// the pending txs are applied again by the recheck of Tendermint, if it is enabled.
+	CheckStateResetCommitted = "committed"
+	// CheckStateResetReplayAnte also replays the ante handler of the pending txs that have
+	// not been delivered into the rebuilt CheckTx state, in the order they were checked, so
+	// that the sequences of their signers are not reset to the committed ones until the
+	// recheck. The recheck of a replayed tx returns the result of its replay.
+	CheckStateResetReplayAnte = "replay-ante"
+)
+
+// maxPendingTxs bounds the pending txs replayed on Commit, the oldest are dropped first
+const maxPendingTxs = TxMsgCacheSize
+
+type pendingTx struct {
+	txHash string
+	tx     sdk.Tx
+}
+
+// pendingTxs are the txs accepted by CheckTx and not delivered yet, in the order they were checked.
+// Like the mempool tracker, it is kept across commits.
+type pendingTxs struct {
+	mtx   sync.Mutex
+	order *list.List
+	elems map[string]*list.Element
+	// results of the replays of the last Commit, returned by the following recheck
+	replayed map[string]sdk.Result
+}
+
+func newPendingTxs() *pendingTxs {
+	return &pendingTxs{
+		order:    list.New(),
+		elems:    make(map[string]*list.Element),
+		replayed: make(map[string]sdk.Result),
+	}
+}
+
+func (p *pendingTxs) add(txHash string, tx sdk.Tx) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if _, ok := p.elems[txHash]; ok {
+		return
+	}
+	p.elems[txHash] = p.order.PushBack(pendingTx{txHash: txHash, tx: tx})
+	if p.order.Len() > maxPendingTxs {
+		oldest := p.order.Remove(p.order.Front()).(pendingTx)
+		delete(p.elems, oldest.txHash)
+	}
+}
+
+func (p *pendingTxs) remove(txHash string) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if elem, ok := p.elems[txHash]; ok {
+		p.order.Remove(elem)
+		delete(p.elems, txHash)
+	}
+	delete(p.replayed, txHash)
+}
+
+// list returns the pending txs, in the order they were checked
+func (p *pendingTxs) list() []pendingTx {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	txs := make([]pendingTx, 0, p.order.Len())
+	for elem := p.order.Front(); elem != nil; elem = elem.Next() {
+		txs = append(txs, elem.Value.(pendingTx))
+	}
+	return txs
+}
+
+func (p *pendingTxs) setReplayed(results map[string]sdk.Result) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.replayed = results
+}
+
+// popReplayed returns the result of the last replay of the tx, once
+func (p *pendingTxs) popReplayed(txHash string) (sdk.Result, bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	result, ok := p.replayed[txHash]
+	delete(p.replayed, txHash)
+	return result, ok
+}
+
+// addPendingTx records a tx accepted by CheckTx, to be replayed on Commit
+func (app *BaseApp) addPendingTx(txHash string, tx sdk.Tx) {
+	if app.pendingTxs != nil {
+		app.pendingTxs.add(txHash, tx)
+	}
+}
+
+// replayedResult returns the result of the replay of a pending tx on the last Commit, once
+func (app *BaseApp) replayedResult(txHash string) (sdk.Result, bool) {
+	if app.pendingTxs == nil {
+		return sdk.Result{}, false
+	}
+	return app.pendingTxs.popReplayed(txHash)
+}
+
+// forgetDeliveredTx stops tracking a delivered tx, as Tendermint removes it from its mempool
+func (app *BaseApp) forgetDeliveredTx(tx sdk.Tx, txHash string) {
+	app.forgetMempoolEntry(tx, "")
+	if app.pendingTxs != nil {
+		app.pendingTxs.remove(txHash)
+	}
+}
+
+// replayPendingTxs replays the ante handler of the pending txs into the CheckTx state,
+// the txs failing it are dropped
+func (app *BaseApp) replayPendingTxs() {
+	if app.pendingTxs == nil {
+		return
+	}
+	txs := app.pendingTxs.list()
+	results := make(map[string]sdk.Result, len(txs))
+	for _, ptx := range txs {
+		result := app.replayAnte(ptx.tx, ptx.txHash)
+		if !result.IsOK() {
+			app.pendingTxs.remove(ptx.txHash)
+			app.forgetMempoolEntry(ptx.tx, ptx.txHash)
+		}
+		results[ptx.txHash] = result
+	}
+	app.pendingTxs.setReplayed(results)
+	app.Logger.Debug("Replayed pending txs", "txs", len(txs))
+}
+
+// replayAnte runs the ante handler of a pending tx on the CheckTx state, its effects are
+// written if it passes
+func (app *BaseApp) replayAnte(tx sdk.Tx, txHash string) (result sdk.Result) {
+	if err := app.recheckMempoolEntry(tx, txHash); err != nil {
+		return err.Result()
+	}
+
+	mode := sdk.RunTxModeReCheck
+	ctx, msCache, accountCache := app.getContextWithCache(app.CheckState, mode, tx, txHash)
+
+	defer func() {
+		if r := recover(); r != nil {
+			log := fmt.Sprintf("recovered: %v\nstack:\n%v", r, string(debug.Stack()))
+			result = sdk.ErrInternal(log).Result()
+		}
+	}()
+
+	// the block may have made the tx invalid, e.g. a sunset height has been reached
+	if app.proposalTxChecker != nil {
+		if err := app.proposalTxChecker(ctx, tx); err != nil {
+			return err.Result()
+		}
+	}
+
+	if app.anteHandler != nil {
+		_, result, abort := app.runAnteHandler(ctx.WithValue(TxHashKey, txHash), tx, mode)
+		if abort {
+			return result
+		}
+	}
+
+	accountCache.Write()
+	msCache.Write()
+	return sdk.Result{}
+}
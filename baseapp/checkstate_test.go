@@ -0,0 +1,82 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestCheckStateResetReplayAnte(t *testing.T) {
+	// the ante handler accepts the txs in the order of their counter, like account sequences
+	seqKey := []byte("seq")
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode) (sdk.Context, sdk.Result, bool) {
+			store := ctx.KVStore(capKey1)
+			seq := getIntFromStore(store, seqKey)
+			if tx.(txTest).Counter != seq {
+				return ctx, sdk.ErrInvalidSequence("sequence mismatch").Result(), true
+			}
+			setIntOnStore(store, seqKey, seq+1)
+			return ctx, sdk.Result{}, false
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	txs := make([][]byte, 4)
+	for i := range txs {
+		txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(int64(i), 0))
+		require.NoError(t, err)
+		txs[i] = txBytes
+	}
+
+	// txs 0, 1 and 2 are pending, tx 0 is delivered in block 1
+	run := func(app *BaseApp) {
+		for _, tx := range txs[:3] {
+			require.True(t, app.CheckTx(abci.RequestCheckTx{Tx: tx}).IsOK())
+		}
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+		require.True(t, app.DeliverTx(abci.RequestDeliverTx{Tx: txs[0]}).IsOK())
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+
+	// the CheckTx state is reset to the committed sequence
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	run(app)
+	require.False(t, app.CheckTx(abci.RequestCheckTx{Tx: txs[3]}).IsOK())
+
+	// the pending txs are replayed into the CheckTx state
+	app = setupBaseApp(t, anteOpt, routerOpt, SetCheckStateReset(CheckStateResetReplayAnte))
+	run(app)
+	require.Equal(t, int64(3), getIntFromStore(app.CheckState.ms.GetKVStore(capKey1), seqKey))
+	require.True(t, app.CheckTx(abci.RequestCheckTx{Tx: txs[3]}).IsOK())
+
+	// the recheck of a replayed tx returns the result of its replay
+	require.True(t, app.ReCheckTx(abci.RequestCheckTx{Tx: txs[1]}).IsOK())
+	require.True(t, app.ReCheckTx(abci.RequestCheckTx{Tx: txs[2]}).IsOK())
+	require.Equal(t, int64(4), getIntFromStore(app.CheckState.ms.GetKVStore(capKey1), seqKey))
+	// once
+	require.False(t, app.ReCheckTx(abci.RequestCheckTx{Tx: txs[1]}).IsOK())
+
+	// a pending tx failing its replay is dropped
+	other, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(2, 1))
+	require.NoError(t, err)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	require.True(t, app.DeliverTx(abci.RequestDeliverTx{Tx: txs[1]}).IsOK())
+	require.True(t, app.DeliverTx(abci.RequestDeliverTx{Tx: other}).IsOK())
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	pending := app.pendingTxs.list()
+	require.Len(t, pending, 1)
+	require.Equal(t, int64(3), pending[0].tx.(txTest).Counter)
+	require.False(t, app.ReCheckTx(abci.RequestCheckTx{Tx: txs[2]}).IsOK())
+	require.True(t, app.ReCheckTx(abci.RequestCheckTx{Tx: txs[3]}).IsOK())
+}
@@ -0,0 +1,174 @@
+package baseapp
+
+import (
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// deliverTxTask is a tx delivered by DeliverTxs, with the caches and the result of its
+// last execution
+type deliverTxTask struct {
+	tx        sdk.Tx
+	txHash    string
+	mode      sdk.RunTxMode
+	decodeErr sdk.Error
+	blockErr  sdk.Error
+	laneErr   sdk.Error
+
+	ctx          sdk.Context
+	msCache      sdk.CacheMultiStore
+	accountCache sdk.AccountCache
+	accesses     *store.AccessSet
+	result       sdk.Result
+}
+
+// DeliverTxs implements extended ABCI for concurrency, it delivers the txs of a block
+// with the same results and state changes as calling DeliverTx on each of them in order.
+//
+// With SetConcurrentDeliver, the txs are first executed in parallel on the state before
+// the batch, recording the keys each one reads and writes. They are then written to the
+// state in order, a tx that has read a key written by a previous tx of the batch is
+// executed again on top of the state written so far. The BlockValidator sees the whole
+// batch before any tx is executed. The ante handler, the handlers and
+// the ProposalTxChecker must only share state through the stores and the account cache.
+func (app *BaseApp) DeliverTxs(reqs []abci.RequestDeliverTx) []abci.ResponseDeliverTx {
+	res := make([]abci.ResponseDeliverTx, len(reqs))
+	st := app.DeliverState
+	if app.deliverWorkers <= 1 || len(reqs) < 2 || app.proposalErr != nil ||
+		app.stateDiff != nil || st.ms.TracingEnabled() {
+		for i, req := range reqs {
+			res[i] = app.DeliverTx(req)
+		}
+		return res
+	}
+
+	tasks := make([]*deliverTxTask, len(reqs))
+	for i, req := range reqs {
+		task := &deliverTxTask{txHash: cmn.HexBytes(tmhash.Sum(req.Tx)).String()}
+		task.tx, task.mode, task.decodeErr = app.decodeDeliverTx(req.Tx)
+		// validated and admitted in block order, before any tx is executed
+		if task.decodeErr == nil && app.proposalErr == nil {
+			app.validateBlockTx(task.tx)
+		}
+		if app.proposalErr != nil {
+			// the rest of the block is rejected by the block validator
+			task.blockErr = app.proposalErr
+		} else if task.decodeErr == nil {
+			task.laneErr = app.admitDeliverTx(task.tx, len(req.Tx))
+		}
+		tasks[i] = task
+	}
+
+	// speculative execution, nothing is written to the state until all the txs are executed
+	indexes := make(chan int, len(tasks))
+	for i := range tasks {
+		indexes <- i
+	}
+	close(indexes)
+	var wg sync.WaitGroup
+	for w := 0; w < app.deliverWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if tasks[i].decodeErr == nil && tasks[i].blockErr == nil && tasks[i].laneErr == nil {
+					app.execDeliverTxTask(st, tasks[i])
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	written := store.NewAccessSet()
+	var reExecuted int
+	for i, task := range tasks {
+		if task.blockErr != nil {
+			if task.decodeErr == nil {
+				app.forgetDeliveredTx(task.tx, task.txHash)
+			}
+			result := task.blockErr.Result()
+			res[i] = abci.ResponseDeliverTx{Code: uint32(result.Code), Log: result.Log}
+			continue
+		}
+		if task.decodeErr != nil {
+			res[i] = toResponseDeliverTx(task.decodeErr.Result())
+			continue
+		}
+		if task.laneErr != nil {
+			app.forgetDeliveredTx(task.tx, task.txHash)
+			res[i] = toResponseDeliverTx(task.laneErr.Result())
+			continue
+		}
+
+		if task.accesses.ReadsWrittenBy(written) {
+			app.execDeliverTxTask(st, task)
+			reExecuted++
+		}
+		app.writeDeliverTxTask(st, task)
+		written.AddWrites(task.accesses)
+
+		// Tendermint removes the txs of the block from its mempool whatever their result
+		app.forgetDeliveredTx(task.tx, task.txHash)
+		res[i] = toResponseDeliverTx(task.result)
+	}
+	app.Logger.Debug("Delivered txs concurrently", "txs", len(tasks), "reExecuted", reExecuted)
+
+	return res
+}
+
+// execDeliverTxTask executes the tx of task on top of st, the keys it reads from st and the
+// keys it writes to st when its caches are written are recorded in task.accesses
+func (app *BaseApp) execDeliverTxTask(st *state, task *deliverTxTask) {
+	task.accesses = store.NewAccessSet()
+	task.msCache = store.CacheMultiStoreWithAccessSet(st.ms, task.accesses)
+	task.accountCache = auth.NewAccountCache(accessAccountCache{AccountCache: st.AccountCache, accesses: task.accesses})
+	task.ctx = st.Ctx.WithTx(task.tx).
+		WithMultiStore(task.msCache).
+		WithAccountCache(task.accountCache).
+		WithRouterCallRecord(make(map[string]bool)).
+		WithEventManager(sdk.NewEventManager())
+	task.result = app.execTx(task.ctx, task.mode, task.tx, task.txHash)
+}
+
+// writeDeliverTxTask writes the executed tx of task to st
+func (app *BaseApp) writeDeliverTxTask(st *state, task *deliverTxTask) {
+	for route, called := range task.ctx.RouterCallRecord() {
+		if called {
+			st.Ctx.RouterCallRecord()[route] = true
+		}
+	}
+	st.Ctx.EventManager().EmitEvents(task.ctx.EventManager().Events())
+
+	if task.result.IsOK() {
+		app.writeTx(task.ctx, task.mode, task.tx, task.txHash, task.msCache, task.accountCache)
+	}
+}
+
+// accessAccountCache records the accounts read from and written to the account cache
+// of the state in an access set
+type accessAccountCache struct {
+	sdk.AccountCache
+	accesses *store.AccessSet
+}
+
+func (c accessAccountCache) GetAccount(addr sdk.AccAddress) sdk.Account {
+	c.accesses.RecordRead(accountCacheStore, addr)
+	return c.AccountCache.GetAccount(addr)
+}
+
+func (c accessAccountCache) SetAccount(addr sdk.AccAddress, acc sdk.Account) {
+	c.accesses.RecordWrite(accountCacheStore, addr)
+	c.AccountCache.SetAccount(addr, acc)
+}
+
+func (c accessAccountCache) Delete(addr sdk.AccAddress) {
+	c.accesses.RecordWrite(accountCacheStore, addr)
+	c.AccountCache.Delete(addr)
+}
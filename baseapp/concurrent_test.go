@@ -0,0 +1,71 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestDeliverTxsConcurrently(t *testing.T) {
+	// a msg increments the counter of its slot, or sums all the counters if its slot is
+	// sumSlot, the msgs of the slot failSlot fail after incrementing the counter
+	const sumSlot, failSlot = 9, 8
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			store := ctx.KVStore(capKey1)
+			slot := msg.(*msgCounter).Counter
+			if slot == sumSlot {
+				var sum int64
+				iter := store.Iterator(nil, nil)
+				for ; iter.Valid(); iter.Next() {
+					sum += getIntFromStore(store, iter.Key())
+				}
+				iter.Close()
+				ctx.KVStore(capKey2).Set([]byte("sum"), i2b(sum))
+				return sdk.Result{Data: i2b(sum)}
+			}
+
+			counter := getIntFromStore(store, i2b(slot)) + 1
+			setIntOnStore(store, i2b(slot), counter)
+			if slot == failSlot {
+				return sdk.ErrInternal("fail slot").Result()
+			}
+			return sdk.Result{Data: i2b(counter)}
+		})
+	}
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	var reqs []abci.RequestDeliverTx
+	for i, slot := range []int64{0, 1, 2, 0, 3, 8, sumSlot, 1, 4, 8, sumSlot, 5, 0} {
+		txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(int64(i), slot))
+		require.NoError(t, err)
+		reqs = append(reqs, abci.RequestDeliverTx{Tx: txBytes})
+	}
+	// undecodable tx
+	reqs = append(reqs, abci.RequestDeliverTx{Tx: []byte("invalid")})
+
+	deliver := func(app *BaseApp) ([]abci.ResponseDeliverTx, []byte) {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+		res := app.DeliverTxs(reqs)
+		app.EndBlock(abci.RequestEndBlock{})
+		return res, app.Commit().Data
+	}
+
+	seqRes, seqHash := deliver(setupBaseApp(t, routerOpt))
+	concRes, concHash := deliver(setupBaseApp(t, routerOpt, SetConcurrentDeliver(4)))
+	require.Equal(t, seqRes, concRes)
+	require.Equal(t, seqHash, concHash)
+
+	// the conflicting txs have been executed again on top of the previous ones
+	require.Equal(t, i2b(2), concRes[3].Data)
+	require.Equal(t, i2b(3), concRes[12].Data)
+	require.Equal(t, i2b(2+1+1+1), concRes[6].Data)
+	require.Equal(t, i2b(2+2+1+1+1), concRes[10].Data)
+	require.False(t, concRes[5].IsOK())
+	require.False(t, concRes[13].IsOK())
+}
@@ -0,0 +1,164 @@
+package baseapp
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// number of fees of each msg type the fee suggestions are computed from by default,
+// see SetFeeSuggestionWindow
+const defaultFeeSuggestionWindow = 200
+
+// FeeSuggestion gives percentiles of the fees paid by the last delivered txs of a msg type,
+// so that wallets can suggest fees instead of relying on static tables
+type FeeSuggestion struct {
+	MsgType string `json:"msg_type"`
+	Samples int    `json:"samples"`
+	Low     int64  `json:"low"`    // 25th percentile
+	Median  int64  `json:"median"` // 50th percentile
+	High    int64  `json:"high"`   // 90th percentile
+}
+
+type feeSample struct {
+	msgType string
+	fee     int64
+}
+
+// feeTracker keeps the fees of the last successful txs of each msg type in memory, the fees of a
+// block are added on Commit
+type feeTracker struct {
+	mtx    sync.RWMutex
+	window int
+	fees   map[string]*feeRing
+
+	// fees of the block being executed
+	pending []feeSample
+}
+
+// feeRing is a ring buffer of the last fees of a msg type
+type feeRing struct {
+	fees []int64
+	next int
+}
+
+func newFeeTracker(window int) *feeTracker {
+	return &feeTracker{
+		window: window,
+		fees:   make(map[string]*feeRing),
+	}
+}
+
+// add records the fee of a delivered tx, the txs must be added in block order
+func (ft *feeTracker) add(msgType string, fee int64) {
+	ft.pending = append(ft.pending, feeSample{msgType: msgType, fee: fee})
+}
+
+func (ft *feeTracker) commit() {
+	ft.mtx.Lock()
+	defer ft.mtx.Unlock()
+	for _, sample := range ft.pending {
+		ring, ok := ft.fees[sample.msgType]
+		if !ok {
+			ring = &feeRing{}
+			ft.fees[sample.msgType] = ring
+		}
+		if len(ring.fees) < ft.window {
+			ring.fees = append(ring.fees, sample.fee)
+		} else {
+			ring.fees[ring.next] = sample.fee
+		}
+		ring.next = (ring.next + 1) % ft.window
+	}
+	ft.pending = nil
+}
+
+// suggestion returns the FeeSuggestion of a msg type, false if no fee of the type is known
+func (ft *feeTracker) suggestion(msgType string) (FeeSuggestion, bool) {
+	ft.mtx.RLock()
+	ring, ok := ft.fees[msgType]
+	var fees []int64
+	if ok {
+		fees = append(fees, ring.fees...)
+	}
+	ft.mtx.RUnlock()
+	if !ok {
+		return FeeSuggestion{}, false
+	}
+
+	sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+	return FeeSuggestion{
+		MsgType: msgType,
+		Samples: len(fees),
+		Low:     percentile(fees, 25),
+		Median:  percentile(fees, 50),
+		High:    percentile(fees, 90),
+	}, true
+}
+
+// suggestions returns the FeeSuggestions of all the msg types, by msg type
+func (ft *feeTracker) suggestions() []FeeSuggestion {
+	ft.mtx.RLock()
+	msgTypes := make([]string, 0, len(ft.fees))
+	for msgType := range ft.fees {
+		msgTypes = append(msgTypes, msgType)
+	}
+	ft.mtx.RUnlock()
+
+	sort.Strings(msgTypes)
+	suggestions := make([]FeeSuggestion, 0, len(msgTypes))
+	for _, msgType := range msgTypes {
+		if suggestion, ok := ft.suggestion(msgType); ok {
+			suggestions = append(suggestions, suggestion)
+		}
+	}
+	return suggestions
+}
+
+// percentile returns the nearest-rank percentile p of the sorted fees
+func percentile(sorted []int64, p int) int64 {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// trackFee records the fee of a successful delivered tx
+func (app *BaseApp) trackFee(tx sdk.Tx) {
+	if app.feeTracker == nil || app.txFeeGetter == nil {
+		return
+	}
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return
+	}
+	app.feeTracker.add(msgs[0].Type(), app.txFeeGetter(tx))
+}
+
+// handleQueryFeeSuggestion answers app/fee-suggestion[/<msgType>] with the JSON encoded
+// FeeSuggestions of all the msg types, or the FeeSuggestion of the given msg type
+func handleQueryFeeSuggestion(app *BaseApp, path []string) abci.ResponseQuery {
+	if app.feeTracker == nil || app.txFeeGetter == nil {
+		return sdk.ErrUnknownRequest("fees are not tracked by this node").QueryResult()
+	}
+	if len(path) > 2 {
+		suggestion, ok := app.feeTracker.suggestion(path[2])
+		if !ok {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("no fee known for msg type %s", path[2])).QueryResult()
+		}
+		return abci.ResponseQuery{
+			Code:  uint32(sdk.ABCICodeOK),
+			Value: codec.Cdc.MustMarshalJSON(suggestion),
+		}
+	}
+	return abci.ResponseQuery{
+		Code:  uint32(sdk.ABCICodeOK),
+		Value: codec.Cdc.MustMarshalJSON(app.feeTracker.suggestions()),
+	}
+}
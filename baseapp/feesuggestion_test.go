@@ -0,0 +1,63 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestFeeTracker(t *testing.T) {
+	ft := newFeeTracker(10)
+	_, ok := ft.suggestion("send")
+	require.False(t, ok)
+
+	for fee := int64(1); fee <= 15; fee++ {
+		ft.add("send", fee)
+	}
+	ft.add("vote", 7)
+	// the fees are only known once committed
+	require.Empty(t, ft.suggestions())
+	ft.commit()
+
+	// only the last 10 fees are kept
+	suggestion, ok := ft.suggestion("send")
+	require.True(t, ok)
+	require.Equal(t, FeeSuggestion{MsgType: "send", Samples: 10, Low: 8, Median: 10, High: 14}, suggestion)
+
+	suggestions := ft.suggestions()
+	require.Len(t, suggestions, 2)
+	require.Equal(t, FeeSuggestion{MsgType: "vote", Samples: 1, Low: 7, Median: 7, High: 7}, suggestions[1])
+}
+
+func TestQueryFeeSuggestion(t *testing.T) {
+	app := setupBaseApp(t)
+	query := abci.RequestQuery{Path: "/app/fee-suggestion"}
+	require.False(t, app.Query(query).IsOK(), "no fee getter")
+
+	app = setupBaseApp(t, func(bapp *BaseApp) {
+		bapp.SetTxFeeGetter(func(tx sdk.Tx) int64 { return 1000 })
+	})
+	app.feeTracker.add("send", 1000)
+	app.feeTracker.commit()
+
+	res := app.Query(query)
+	require.True(t, res.IsOK(), res.Log)
+	var suggestions []FeeSuggestion
+	codec.Cdc.MustUnmarshalJSON(res.Value, &suggestions)
+	require.Len(t, suggestions, 1)
+
+	res = app.Query(abci.RequestQuery{Path: "/app/fee-suggestion/send"})
+	require.True(t, res.IsOK(), res.Log)
+	var suggestion FeeSuggestion
+	codec.Cdc.MustUnmarshalJSON(res.Value, &suggestion)
+	require.Equal(t, int64(1000), suggestion.Median)
+	require.False(t, app.Query(abci.RequestQuery{Path: "/app/fee-suggestion/vote"}).IsOK())
+
+	app = newBaseApp(t.Name(), SetFeeSuggestionWindow(0))
+	require.Nil(t, app.feeTracker)
+	require.Panics(t, func() { SetFeeSuggestionWindow(-1) })
+}
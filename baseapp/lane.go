@@ -0,0 +1,118 @@
+package baseapp
+
+import (
+	"fmt"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// UserLaneLimit returns the maximum total size in bytes of the user txs of a block, that is
+// the txs not in the system lane, 0 means no limit. It should be read from a param of the
+// state, so that all nodes reject the same txs in DeliverTx.
+type UserLaneLimit func(ctx sdk.Context) int64
+
+// systemLane reserves the part of each block above the user lane limit to system txs, the
+// txs whose msgs are all of a whitelisted type such as oracle claims, so that side chain
+// packages can not be crowded out of full blocks by user traffic.
+//
+// In DeliverTx, the user txs of a block beyond the limit are rejected without being executed.
+// The limit is read once per block in BeginBlock and the txs are counted in block order, so
+// the result does not depend on the execution of the txs. In CheckTx, the user txs accepted
+// since the last block are limited the same way, so that the mempool is not filled with more
+// user txs than a block can take while system txs are always accepted.
+type systemLane struct {
+	mtx      sync.Mutex
+	msgTypes map[string]bool
+	limit    UserLaneLimit
+
+	deliverLimit int64
+	deliverBytes int64
+
+	checkHeight int64
+	checkLimit  int64
+	checkBytes  int64
+}
+
+func newSystemLane(limit UserLaneLimit, msgTypes []string) *systemLane {
+	lane := &systemLane{
+		msgTypes: make(map[string]bool, len(msgTypes)),
+		limit:    limit,
+	}
+	for _, msgType := range msgTypes {
+		lane.msgTypes[msgType] = true
+	}
+	return lane
+}
+
+// isSystemTx tells whether all the msgs of tx are of a system msg type
+func (l *systemLane) isSystemTx(tx sdk.Tx) bool {
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return false
+	}
+	for _, msg := range msgs {
+		if !l.msgTypes[msg.Type()] {
+			return false
+		}
+	}
+	return true
+}
+
+// beginBlock reads the limit of the user txs of the block
+func (l *systemLane) beginBlock(ctx sdk.Context) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.deliverLimit = l.limit(ctx)
+	l.deliverBytes = 0
+}
+
+// admitDeliverTx counts a delivered tx of txSize bytes, the txs must be admitted in block order
+func (l *systemLane) admitDeliverTx(tx sdk.Tx, txSize int) sdk.Error {
+	if l.isSystemTx(tx) {
+		return nil
+	}
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.deliverLimit > 0 && l.deliverBytes+int64(txSize) > l.deliverLimit {
+		return sdk.ErrLaneFull(fmt.Sprintf("user txs of the block exceed %d bytes", l.deliverLimit))
+	}
+	l.deliverBytes += int64(txSize)
+	return nil
+}
+
+// checkTxExceeded tells whether a user tx of txSize bytes would exceed the limit of the user
+// txs accepted by CheckTx since the last block
+func (l *systemLane) checkTxExceeded(ctx sdk.Context, txSize int) (int64, bool) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if height := ctx.BlockHeight(); height != l.checkHeight {
+		l.checkHeight = height
+		l.checkLimit = l.limit(ctx)
+		l.checkBytes = 0
+	}
+	return l.checkLimit, l.checkLimit > 0 && l.checkBytes+int64(txSize) > l.checkLimit
+}
+
+func (l *systemLane) addCheckTx(txSize int) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	l.checkBytes += int64(txSize)
+}
+
+// runLaneCheckTx runs a tx in CheckTx mode, unless it is a user tx and the user txs accepted
+// since the last block have reached the user lane limit
+func (app *BaseApp) runLaneCheckTx(mode sdk.RunTxMode, tx sdk.Tx, txSize int, txHash string) sdk.Result {
+	if app.lane == nil || app.lane.isSystemTx(tx) {
+		return app.runRateLimitedCheckTx(mode, tx, txHash)
+	}
+
+	if limit, exceeded := app.lane.checkTxExceeded(app.CheckState.Ctx, txSize); exceeded {
+		return sdk.ErrLaneFull(fmt.Sprintf("user txs accepted since the last block exceed %d bytes", limit)).Result()
+	}
+	result := app.runRateLimitedCheckTx(mode, tx, txHash)
+	if result.IsOK() {
+		app.lane.addCheckTx(txSize)
+	}
+	return result
+}
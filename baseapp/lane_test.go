@@ -0,0 +1,72 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+type laneTestMsg struct {
+	*sdk.TestMsg
+	msgType string
+}
+
+func (msg laneTestMsg) Type() string { return msg.msgType }
+
+func laneTestTx(msgTypes ...string) sdk.Tx {
+	msgs := make([]sdk.Msg, len(msgTypes))
+	for i, msgType := range msgTypes {
+		msgs[i] = laneTestMsg{TestMsg: sdk.NewTestMsg(), msgType: msgType}
+	}
+	return auth.NewStdTx(msgs, nil, "", 0, nil)
+}
+
+func TestSystemLane(t *testing.T) {
+	limit := int64(100)
+	lane := newSystemLane(func(ctx sdk.Context) int64 { return limit }, []string{"claim"})
+	system, user := laneTestTx("claim"), laneTestTx("send")
+
+	require.True(t, lane.isSystemTx(system))
+	require.False(t, lane.isSystemTx(user))
+	require.False(t, lane.isSystemTx(laneTestTx("claim", "send")), "all msgs must be system msgs")
+
+	lane.beginBlock(sdk.Context{})
+	require.Nil(t, lane.admitDeliverTx(user, 60))
+	require.NotNil(t, lane.admitDeliverTx(user, 60))
+	require.Nil(t, lane.admitDeliverTx(user, 40))
+	// the reserved part of the block is left to the system txs
+	require.Nil(t, lane.admitDeliverTx(system, 500))
+
+	// the limit is read once per block
+	limit = 0
+	require.NotNil(t, lane.admitDeliverTx(user, 1))
+	lane.beginBlock(sdk.Context{})
+	require.Nil(t, lane.admitDeliverTx(user, 1000))
+}
+
+func TestSystemLaneCheckTx(t *testing.T) {
+	lane := newSystemLane(func(ctx sdk.Context) int64 { return 100 }, []string{"claim"})
+	ctx := sdk.Context{}.WithBlockHeight(1)
+
+	_, exceeded := lane.checkTxExceeded(ctx, 60)
+	require.False(t, exceeded)
+	lane.addCheckTx(60)
+	_, exceeded = lane.checkTxExceeded(ctx, 60)
+	require.True(t, exceeded)
+
+	// the count is reset with the next block
+	_, exceeded = lane.checkTxExceeded(ctx.WithBlockHeight(2), 60)
+	require.False(t, exceeded)
+}
+
+func TestSetSystemLane(t *testing.T) {
+	app := newBaseApp(t.Name())
+	require.Nil(t, app.lane)
+	app = newBaseApp(t.Name(), SetSystemLane(func(ctx sdk.Context) int64 { return 0 }, "claim"))
+	require.NotNil(t, app.lane)
+	require.Panics(t, func() { SetSystemLane(nil, "claim") })
+	require.Panics(t, func() { SetSystemLane(func(ctx sdk.Context) int64 { return 0 }) })
+}
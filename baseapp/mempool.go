@@ -0,0 +1,212 @@
+package baseapp
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// TxFeeGetter returns the fee a tx pays, it is used to decide whether a tx
+// can replace a pending tx with the same signer and sequence.
+type TxFeeGetter func(tx sdk.Tx) int64
+
+// mempoolEntry is the pending tx of a (signer, sequence)
+type mempoolEntry struct {
+	txHash    string
+	firstSeen int64
+	fee       int64
+}
+
+// entries of a tracker without ttl are still garbage collected after this many blocks, so that
+// the entry of a tx dropped by Tendermint without being delivered does not live forever.
+const defaultMempoolEntryMaxAge int64 = 1000
+
+// mempoolTracker tracks the pending txs of the CheckTx state by (signer, sequence).
+// It is kept across commits, since the CheckTx state itself is reset on every Commit.
+type mempoolTracker struct {
+	mtx     sync.Mutex
+	ttl     int64 // in blocks, 0 means txs never expire
+	entries map[string]mempoolEntry
+}
+
+func newMempoolTracker(ttl int64) *mempoolTracker {
+	return &mempoolTracker{
+		ttl:     ttl,
+		entries: make(map[string]mempoolEntry),
+	}
+}
+
+// mempoolKey returns the (signer, sequence) key of the first signer of a StdTx
+func mempoolKey(tx sdk.Tx) (string, bool) {
+	stdTx, ok := tx.(auth.StdTx)
+	if !ok {
+		return "", false
+	}
+	sigs := stdTx.GetSignatures()
+	signers := stdTx.GetSigners()
+	if len(sigs) == 0 || len(signers) == 0 {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%d", signers[0].String(), sigs[0].Sequence), true
+}
+
+func (mt *mempoolTracker) get(key string) (mempoolEntry, bool) {
+	mt.mtx.Lock()
+	defer mt.mtx.Unlock()
+	entry, ok := mt.entries[key]
+	return entry, ok
+}
+
+func (mt *mempoolTracker) set(key string, entry mempoolEntry) {
+	mt.mtx.Lock()
+	defer mt.mtx.Unlock()
+	mt.entries[key] = entry
+}
+
+// remove deletes the entry of key, if txHash is not empty the entry is only deleted if it belongs to the tx
+func (mt *mempoolTracker) remove(key string, txHash string) {
+	mt.mtx.Lock()
+	defer mt.mtx.Unlock()
+	if entry, ok := mt.entries[key]; ok && (txHash == "" || entry.txHash == txHash) {
+		delete(mt.entries, key)
+	}
+}
+
+func (mt *mempoolTracker) expired(entry mempoolEntry, height int64) bool {
+	return mt.ttl > 0 && height-entry.firstSeen > mt.ttl
+}
+
+// gc drops the expired entries, or the entries older than defaultMempoolEntryMaxAge if there is no ttl.
+// Entries are kept one more block, so that the recheck following the commit evicts the expired txs first.
+func (mt *mempoolTracker) gc(height int64) {
+	maxAge := mt.ttl
+	if maxAge == 0 {
+		maxAge = defaultMempoolEntryMaxAge
+	}
+	mt.mtx.Lock()
+	defer mt.mtx.Unlock()
+	for key, entry := range mt.entries {
+		if height-entry.firstSeen > maxAge+1 {
+			delete(mt.entries, key)
+		}
+	}
+}
+
+// runCheckTx runs a tx in CheckTx mode, rejecting expired txs and accepting
+// a tx with the same signer and sequence of a pending tx only if it pays a higher fee.
+func (app *BaseApp) runCheckTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) sdk.Result {
+	if app.mempool == nil {
+		return app.RunTx(mode, tx, txHash)
+	}
+	key, ok := mempoolKey(tx)
+	if !ok {
+		return app.RunTx(mode, tx, txHash)
+	}
+
+	height := app.CheckState.Ctx.BlockHeight()
+	entry, found := app.mempool.get(key)
+	switch {
+	case !found:
+		result := app.RunTx(mode, tx, txHash)
+		if result.IsOK() {
+			app.mempool.set(key, mempoolEntry{txHash: txHash, firstSeen: height, fee: app.txFee(tx)})
+		}
+		return result
+	case entry.txHash == txHash:
+		if app.mempool.expired(entry, height) {
+			return sdk.ErrTxExpired(fmt.Sprintf("tx has been pending since height %d", entry.firstSeen)).Result()
+		}
+		return app.RunTx(mode, tx, txHash)
+	default:
+		fee := app.txFee(tx)
+		pendingExpired := app.mempool.expired(entry, height)
+		if !pendingExpired && (app.txFeeGetter == nil || fee <= entry.fee) {
+			return sdk.ErrInvalidSequence(fmt.Sprintf(
+				"tx %s with the same sequence is pending, a replacement must pay a fee higher than %d", entry.txHash, entry.fee)).Result()
+		}
+		// an expired pending tx is replaced whatever the fee, it is evicted on the next recheck.
+		// The CheckTx state already contains the effects of the pending tx, so the replacement
+		// is checked against the last committed state. As a result only the lowest pending
+		// sequence of an account can be replaced.
+		result := app.runTx(app.newCommittedCheckState(), mode, tx, txHash)
+		if result.IsOK() {
+			app.Logger.Info("replaced pending tx", "old", entry.txHash, "new", txHash, "fee", fee, "expired", pendingExpired)
+			app.mempool.set(key, mempoolEntry{txHash: txHash, firstSeen: height, fee: fee})
+		}
+		return result
+	}
+}
+
+// reRunTxInMempool rechecks a pending tx, evicting it if it has expired or has been replaced
+func (app *BaseApp) reRunTxInMempool(txBytes []byte, tx sdk.Tx) sdk.Result {
+	if app.mempool == nil {
+		return app.ReRunTx(txBytes, tx)
+	}
+	txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
+	if err := app.recheckMempoolEntry(tx, txHash); err != nil {
+		return err.Result()
+	}
+	result := app.ReRunTx(txBytes, tx)
+	if !result.IsOK() {
+		app.forgetMempoolEntry(tx, txHash)
+	}
+	return result
+}
+
+// recheckMempoolEntry returns an error if the pending tx has expired or has been replaced
+func (app *BaseApp) recheckMempoolEntry(tx sdk.Tx, txHash string) sdk.Error {
+	if app.mempool == nil {
+		return nil
+	}
+	key, ok := mempoolKey(tx)
+	if !ok {
+		return nil
+	}
+	entry, found := app.mempool.get(key)
+	if !found {
+		return nil
+	}
+	if entry.txHash != txHash {
+		return sdk.ErrInvalidSequence(fmt.Sprintf("tx has been replaced by %s", entry.txHash))
+	}
+	if app.mempool.expired(entry, app.CheckState.Ctx.BlockHeight()) {
+		app.mempool.remove(key, txHash)
+		return sdk.ErrTxExpired(fmt.Sprintf("tx has been pending since height %d", entry.firstSeen))
+	}
+	return nil
+}
+
+// forgetMempoolEntry stops tracking the (signer, sequence) of the tx, e.g. it has been delivered or evicted.
+// If txHash is not empty, the entry is only removed if it still belongs to the tx.
+func (app *BaseApp) forgetMempoolEntry(tx sdk.Tx, txHash string) {
+	if app.mempool == nil {
+		return
+	}
+	if key, ok := mempoolKey(tx); ok {
+		app.mempool.remove(key, txHash)
+	}
+}
+
+func (app *BaseApp) txFee(tx sdk.Tx) int64 {
+	if app.txFeeGetter == nil {
+		return 0
+	}
+	return app.txFeeGetter(tx)
+}
+
+// newCommittedCheckState returns a throwaway CheckTx state on top of the last committed state
+func (app *BaseApp) newCommittedCheckState() *state {
+	accountCache := auth.NewAccountCache(app.AccountStoreCache)
+
+	ms := app.cms.CacheMultiStore()
+	return &state{
+		ms:           ms,
+		AccountCache: accountCache,
+		Ctx:          sdk.NewContext(ms, app.CheckState.Ctx.BlockHeader(), sdk.RunTxModeCheck, app.Logger).WithAccountCache(accountCache).WithUpgradeMgr(app.upgradeMgr),
+	}
+}
@@ -0,0 +1,167 @@
+package baseapp
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestMempoolKey(t *testing.T) {
+	addr := sdk.AccAddress([]byte("addr1"))
+	msg := sdk.NewTestMsg(addr)
+
+	tx := auth.NewStdTx([]sdk.Msg{msg}, []auth.StdSignature{{Sequence: 5}}, "", 0, nil)
+	key, ok := mempoolKey(tx)
+	require.True(t, ok)
+
+	replacement := auth.NewStdTx([]sdk.Msg{msg}, []auth.StdSignature{{Sequence: 5}}, "replacement", 0, nil)
+	replacementKey, ok := mempoolKey(replacement)
+	require.True(t, ok)
+	require.Equal(t, key, replacementKey)
+
+	next := auth.NewStdTx([]sdk.Msg{msg}, []auth.StdSignature{{Sequence: 6}}, "", 0, nil)
+	nextKey, ok := mempoolKey(next)
+	require.True(t, ok)
+	require.NotEqual(t, key, nextKey)
+
+	// no signatures
+	_, ok = mempoolKey(auth.NewStdTx([]sdk.Msg{msg}, nil, "", 0, nil))
+	require.False(t, ok)
+	// not a StdTx
+	_, ok = mempoolKey(newTxCounter(0, 0))
+	require.False(t, ok)
+}
+
+func TestMempoolTracker(t *testing.T) {
+	mt := newMempoolTracker(10)
+	entry := mempoolEntry{txHash: "hash1", firstSeen: 5, fee: 100}
+	mt.set("key", entry)
+
+	require.False(t, mt.expired(entry, 15))
+	require.True(t, mt.expired(entry, 16))
+
+	// only the owner of the entry removes it
+	mt.remove("key", "hash2")
+	_, found := mt.get("key")
+	require.True(t, found)
+	mt.remove("key", "hash1")
+	_, found = mt.get("key")
+	require.False(t, found)
+
+	// anyone removes it without a tx hash
+	mt.set("key", entry)
+	mt.remove("key", "")
+	_, found = mt.get("key")
+	require.False(t, found)
+
+	// no ttl, never expires
+	mt = newMempoolTracker(0)
+	require.False(t, mt.expired(entry, 1000000))
+}
+
+func TestMempoolTrackerGC(t *testing.T) {
+	mt := newMempoolTracker(10)
+	mt.set("old", mempoolEntry{txHash: "hash1", firstSeen: 5})
+	mt.set("new", mempoolEntry{txHash: "hash2", firstSeen: 10})
+	mt.gc(16)
+	_, found := mt.get("old")
+	require.True(t, found)
+	mt.gc(17)
+	_, found = mt.get("old")
+	require.False(t, found)
+	_, found = mt.get("new")
+	require.True(t, found)
+
+	// without ttl, entries are still dropped eventually
+	mt = newMempoolTracker(0)
+	mt.set("old", mempoolEntry{txHash: "hash1", firstSeen: 5})
+	mt.gc(6 + defaultMempoolEntryMaxAge)
+	_, found = mt.get("old")
+	require.True(t, found)
+	mt.gc(7 + defaultMempoolEntryMaxAge)
+	_, found = mt.get("old")
+	require.False(t, found)
+}
+
+func TestMempoolCheckTx(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute("TestMsg", func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+	// the fee of the test txs is their memo
+	feeOpt := func(bapp *BaseApp) {
+		bapp.SetTxFeeGetter(func(tx sdk.Tx) int64 {
+			fee, _ := strconv.ParseInt(tx.(auth.StdTx).Memo, 10, 64)
+			return fee
+		})
+	}
+	app := setupBaseApp(t, SetMempoolTTL(2), routerOpt, feeOpt)
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	// the txs are served from the tx cache, so they don't need to be decoded
+	nonce := 0
+	newTx := func(sequence int64, fee string) []byte {
+		nonce++
+		tx := auth.NewStdTx([]sdk.Msg{sdk.NewTestMsg(addr)}, []auth.StdSignature{{Sequence: sequence}}, fee, 0, nil)
+		txBytes := []byte(fmt.Sprintf("%d/%s/%d", sequence, fee, nonce))
+		app.AddTxToCache(txBytes, tx)
+		return txBytes
+	}
+	checkTx := func(txBytes []byte) abci.ResponseCheckTx {
+		return app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+	}
+	reCheckTx := func(txBytes []byte) abci.ResponseCheckTx {
+		return app.ReCheckTx(abci.RequestCheckTx{Tx: txBytes, Type: abci.CheckTxType_Recheck})
+	}
+	height := int64(0)
+	commit := func() {
+		height++
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+	}
+	invalidSequence := uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidSequence))
+	expired := uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeTxExpired))
+
+	// replace by fee
+	tx1 := newTx(0, "10")
+	require.True(t, checkTx(tx1).IsOK())
+	require.Equal(t, invalidSequence, checkTx(newTx(0, "10")).Code)
+	require.Equal(t, invalidSequence, checkTx(newTx(0, "5")).Code)
+	tx2 := newTx(0, "20")
+	require.True(t, checkTx(tx2).IsOK())
+	require.Equal(t, invalidSequence, reCheckTx(tx1).Code)
+	require.True(t, reCheckTx(tx2).IsOK())
+
+	// ttl eviction
+	commit()
+	commit()
+	require.True(t, reCheckTx(tx2).IsOK())
+	commit()
+	require.Equal(t, expired, reCheckTx(tx2).Code)
+
+	// the sequence is free again once the pending tx is evicted
+	tx3 := newTx(0, "1")
+	require.True(t, checkTx(tx3).IsOK())
+
+	// an expired pending tx is replaced whatever the fee, even before it is rechecked
+	commit()
+	commit()
+	commit()
+	require.True(t, checkTx(newTx(0, "0")).IsOK())
+	require.Equal(t, invalidSequence, reCheckTx(tx3).Code)
+
+	// delivered txs are not tracked anymore
+	tx4 := newTx(1, "10")
+	require.True(t, checkTx(tx4).IsOK())
+	height++
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+	require.True(t, app.DeliverTx(abci.RequestDeliverTx{Tx: tx4}).IsOK())
+	_, found := app.mempool.get(fmt.Sprintf("%s/1", addr.String()))
+	require.False(t, found)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/libs/autofile"
 	dbm "github.com/tendermint/tendermint/libs/db"
 )
 
@@ -25,8 +26,235 @@ func SetPruning(pruning string) func(*BaseApp) {
 	default:
 		panic(fmt.Sprintf("invalid pruning strategy: %s", pruning))
 	}
+	return SetPruningStrategy(pruningEnum)
+}
+
+// SetPruningStrategy sets a custom pruning strategy on the multistore associated with the app
+func SetPruningStrategy(pruning sdk.PruningStrategy) func(*BaseApp) {
+	if err := pruning.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid pruning strategy: %v", err))
+	}
+	return func(bap *BaseApp) {
+		bap.cms.SetPruning(pruning)
+	}
+}
+
+// SetKeyFilters keeps a bloom filter of the keys of the iavl stores for each range of versionRange
+// versions, so that the historical queries of absent keys do not read the trees
+func SetKeyFilters(versionRange int64, falsePositiveRate float64) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		filtered, ok := bap.cms.(interface {
+			SetKeyFilters(versionRange int64, falsePositiveRate float64) error
+		})
+		if !ok {
+			panic("multistore doesn't support key filters")
+		}
+		if err := filtered.SetKeyFilters(versionRange, falsePositiveRate); err != nil {
+			panic(fmt.Sprintf("invalid key filters: %v", err))
+		}
+	}
+}
+
+// SetQueryCache keeps the responses of the latest size proof queries at an explicit height, e.g. the
+// proofs of the cross chain packages requested by the relayers, metrics may be nil
+func SetQueryCache(size int, metrics *store.QueryCacheMetrics) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		cached, ok := bap.cms.(interface {
+			SetQueryCache(size int, metrics *store.QueryCacheMetrics) error
+		})
+		if !ok {
+			panic("multistore doesn't support query cache")
+		}
+		if err := cached.SetQueryCache(size, metrics); err != nil {
+			panic(fmt.Sprintf("invalid query cache: %v", err))
+		}
+	}
+}
+
+// SetColdVersionsDir attaches the cold version files backfilled to dir, see the backfill-archive command,
+// so that the pruned versions are served to the queries without proof
+func SetColdVersionsDir(dir string) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		backfilled, ok := bap.cms.(interface {
+			SetColdVersionsDir(dir string)
+		})
+		if !ok {
+			panic("multistore doesn't support cold versions")
+		}
+		backfilled.SetColdVersionsDir(dir)
+	}
+}
+
+// SetInstance runs the app with the upgrade manager of inst instead of the one of the process, so that several
+// apps run in the process, see sdk.Instance. The manager is given to the multistore and to the contexts of the
+// app, the upgrades of the app and of its keepers must be registered on it rather than on sdk.UpgradeMgr.
+func SetInstance(inst *sdk.Instance) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		upgraded, ok := bap.cms.(interface {
+			SetUpgradeMgr(upgradeMgr *sdk.UpgradeManager)
+		})
+		if !ok {
+			panic("multistore doesn't support an upgrade manager")
+		}
+		upgraded.SetUpgradeMgr(inst.UpgradeMgr)
+		bap.upgradeMgr = inst.UpgradeMgr
+	}
+}
+
+// SetSnapshotInterval takes a state sync snapshot of every interval-th committed state and
+// keeps the keepRecent last ones, 0 keeps them all. The snapshotted versions are not pruned
+// until their snapshot is taken. It requires the StateSyncHelper of the app to be initialized.
+func SetSnapshotInterval(interval int64, keepRecent int) func(*BaseApp) {
+	if interval <= 0 {
+		panic(fmt.Sprintf("invalid snapshot interval: %d", interval))
+	}
+	if keepRecent < 0 {
+		panic(fmt.Sprintf("invalid snapshot keep recent: %d", keepRecent))
+	}
+	return func(bap *BaseApp) {
+		bap.snapshots = &snapshotSchedule{interval: interval, keepRecent: keepRecent}
+	}
+}
+
+// SetMempoolTTL evicts pending txs from the mempool once they have been pending for
+// more than ttl blocks, and enables replacing a pending tx with a tx paying a higher fee
+func SetMempoolTTL(ttl int64) func(*BaseApp) {
+	if ttl < 0 {
+		panic(fmt.Sprintf("invalid mempool tx ttl: %d", ttl))
+	}
+	return func(bap *BaseApp) {
+		bap.mempool = newMempoolTracker(ttl)
+	}
+}
+
+// SetStateDiffDump writes the state changes of every successful delivered tx as a JSON line
+// to rotating files at path, of at most maxFileSize bytes each and maxTotalSize bytes in total.
+// It is a debugging tool, e.g. to find the first tx whose state changes differ between two
+// versions of the app when they disagree on the app hash.
+func SetStateDiffDump(path string, maxFileSize, maxTotalSize int64) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		group, err := autofile.OpenGroup(path, autofile.GroupHeadSizeLimit(maxFileSize), autofile.GroupTotalSizeLimit(maxTotalSize))
+		if err != nil {
+			panic(fmt.Sprintf("failed to open state diff dump %s: %v", path, err))
+		}
+		if err := group.Start(); err != nil {
+			panic(fmt.Sprintf("failed to start state diff dump %s: %v", path, err))
+		}
+		bap.stateDiff = newStateDiffDumper(group)
+	}
+}
+
+// SetCheckTxRateLimit limits the txs of each account accepted by CheckTx to maxTxs within
+// windows of blocks blocks, maxTxs 0 disables the limit. DeliverTx is never limited.
+func SetCheckTxRateLimit(maxTxs int, blocks int64) func(*BaseApp) {
+	if maxTxs < 0 || blocks < 1 {
+		panic(fmt.Sprintf("invalid check tx rate limit: %d txs per %d blocks", maxTxs, blocks))
+	}
+	return func(bap *BaseApp) {
+		if maxTxs > 0 {
+			bap.rateLimiter = newAccountRateLimiter(maxTxs, blocks)
+		}
+	}
+}
+
+// SetBlockResultsRetention sets the number of committed blocks whose validator updates and
+// BeginBlock and EndBlock events are kept for the app/block_results query, 0 disables it
+func SetBlockResultsRetention(blocks int) func(*BaseApp) {
+	if blocks < 0 {
+		panic(fmt.Sprintf("invalid block results retention: %d", blocks))
+	}
+	return func(bap *BaseApp) {
+		if blocks == 0 {
+			bap.blockResults = nil
+		} else {
+			bap.blockResults = newBlockResults(blocks)
+		}
+	}
+}
+
+// SetFeeSuggestionWindow sets the number of fees of the last delivered txs of each msg type
+// the app/fee-suggestion query is computed from, 0 disables it. The fees are given by the
+// TxFeeGetter, see SetTxFeeGetter.
+func SetFeeSuggestionWindow(txs int) func(*BaseApp) {
+	if txs < 0 {
+		panic(fmt.Sprintf("invalid fee suggestion window: %d", txs))
+	}
+	return func(bap *BaseApp) {
+		if txs == 0 {
+			bap.feeTracker = nil
+		} else {
+			bap.feeTracker = newFeeTracker(txs)
+		}
+	}
+}
+
+// SetSystemLane limits the total size of the user txs of each block to the size returned by
+// limit, reserving the rest of the block to the txs whose msgs are all of msgTypes.
+// The user txs beyond the limit are rejected by DeliverTx, and by CheckTx once the user
+// txs accepted since the last block reach the limit.
+func SetSystemLane(limit UserLaneLimit, msgTypes ...string) func(*BaseApp) {
+	if limit == nil || len(msgTypes) == 0 {
+		panic("system lane requires a limit and system msg types")
+	}
 	return func(bap *BaseApp) {
-		bap.cms.SetPruning(pruningEnum)
+		bap.lane = newSystemLane(limit, msgTypes)
+	}
+}
+
+// SetCheckStateReset sets how the CheckTx state is rebuilt on Commit, either
+// CheckStateResetCommitted (the default) or CheckStateResetReplayAnte
+func SetCheckStateReset(reset string) func(*BaseApp) {
+	switch reset {
+	case CheckStateResetCommitted:
+		return func(bap *BaseApp) {
+			bap.pendingTxs = nil
+		}
+	case CheckStateResetReplayAnte:
+		return func(bap *BaseApp) {
+			bap.pendingTxs = newPendingTxs()
+		}
+	default:
+		panic(fmt.Sprintf("invalid check state reset strategy: %s", reset))
+	}
+}
+
+// SetConcurrentDeliver executes the txs delivered together by DeliverTxs on up to workers
+// goroutines, see DeliverTxs
+func SetConcurrentDeliver(workers int) func(*BaseApp) {
+	if workers < 1 {
+		panic(fmt.Sprintf("invalid number of deliver workers: %d", workers))
+	}
+	return func(bap *BaseApp) {
+		bap.deliverWorkers = workers
+	}
+}
+
+// SetTracer traces the lifecycle of the txs: CheckTx, the ante handler, each msg handler,
+// the cross-chain packages emitted and the commits of the stores, see sdk.Tracer
+func SetTracer(tracer sdk.Tracer) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		bap.tracer = tracer
+	}
+}
+
+// SetHaltHeight stops the node after committing the block at haltHeight, 0 disables the halt
+func SetHaltHeight(haltHeight int64) func(*BaseApp) {
+	if haltHeight < 0 {
+		panic(fmt.Sprintf("invalid halt height: %d", haltHeight))
+	}
+	return func(bap *BaseApp) {
+		bap.haltHeight = haltHeight
+	}
+}
+
+// SetHaltTime stops the node after committing the first block whose header time reaches
+// haltTime, in unix seconds, 0 disables the halt
+func SetHaltTime(haltTime int64) func(*BaseApp) {
+	if haltTime < 0 {
+		panic(fmt.Sprintf("invalid halt time: %d", haltTime))
+	}
+	return func(bap *BaseApp) {
+		bap.haltTime = haltTime
 	}
 }
 
@@ -37,6 +265,24 @@ func (app *BaseApp) SetName(name string) {
 	app.name = name
 }
 
+// SetModuleVersions sets the consensus version of each module reported by Info,
+// usually the ConsensusVersions of the ModuleManager of the app
+func (app *BaseApp) SetModuleVersions(versions map[string]uint64) {
+	if app.sealed {
+		panic("SetModuleVersions() on sealed BaseApp")
+	}
+	app.moduleVersions = versions
+}
+
+// SetStoreLoader sets the loader called by LoadLatestVersion to load the multistore,
+// so that the app can apply store upgrades before the stores are loaded
+func (app *BaseApp) SetStoreLoader(loader StoreLoader) {
+	if app.sealed {
+		panic("SetStoreLoader() on sealed BaseApp")
+	}
+	app.storeLoader = loader
+}
+
 func (app *BaseApp) SetDB(db dbm.DB) {
 	if app.sealed {
 		panic("SetDB() on sealed BaseApp")
@@ -58,6 +304,15 @@ func (app *BaseApp) SetInitChainer(initChainer sdk.InitChainer) {
 	app.initChainer = initChainer
 }
 
+// SetStreamingInitChainer sets an init chainer decoding the app state while reading it,
+// it takes precedence over the one set by SetInitChainer
+func (app *BaseApp) SetStreamingInitChainer(initChainer sdk.StreamingInitChainer) {
+	if app.sealed {
+		panic("SetStreamingInitChainer() on sealed BaseApp")
+	}
+	app.streamingInitChainer = initChainer
+}
+
 func (app *BaseApp) SetBeginBlocker(beginBlocker sdk.BeginBlocker) {
 	if app.sealed {
 		panic("SetBeginBlocker() on sealed BaseApp")
@@ -86,6 +341,34 @@ func (app *BaseApp) SetPreChecker(pc sdk.PreChecker) {
 	app.preChecker = pc
 }
 
+func (app *BaseApp) SetProposalChecker(pc sdk.ProposalChecker) {
+	if app.sealed {
+		panic("SetProposalChecker() on sealed BaseApp")
+	}
+	app.proposalChecker = pc
+}
+
+func (app *BaseApp) SetProposalTxChecker(pc sdk.ProposalTxChecker) {
+	if app.sealed {
+		panic("SetProposalTxChecker() on sealed BaseApp")
+	}
+	app.proposalTxChecker = pc
+}
+
+func (app *BaseApp) SetBlockValidatorCreator(bc sdk.BlockValidatorCreator) {
+	if app.sealed {
+		panic("SetBlockValidatorCreator() on sealed BaseApp")
+	}
+	app.blockValidatorCreator = bc
+}
+
+func (app *BaseApp) SetTxFeeGetter(fg TxFeeGetter) {
+	if app.sealed {
+		panic("SetTxFeeGetter() on sealed BaseApp")
+	}
+	app.txFeeGetter = fg
+}
+
 func (app *BaseApp) SetAddrPeerFilter(pf sdk.PeerFilter) {
 	if app.sealed {
 		panic("SetAddrPeerFilter() on sealed BaseApp")
@@ -111,6 +394,11 @@ func (app *BaseApp) QueryRouter() QueryRouter {
 	return app.queryRouter
 }
 
+// ProofQueryRouter returns the router of the queries under "/prove"
+func (app *BaseApp) ProofQueryRouter() ProofQueryRouter {
+	return app.proofQueryRouter
+}
+
 func (app *BaseApp) Seal()          { app.sealed = true }
 func (app *BaseApp) IsSealed() bool { return app.sealed }
 func (app *BaseApp) enforceSeal() {
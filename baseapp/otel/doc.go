@@ -0,0 +1,14 @@
+/*
+Package otel exports the spans of the tx lifecycle traced by BaseApp, see baseapp.SetTracer,
+to OpenTelemetry.
+
+The package is only built with the otel build tag, which requires the OpenTelemetry modules
+go.opentelemetry.io/otel, go.opentelemetry.io/otel/sdk and
+go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc in the go.mod of the app:
+
+	tracer, shutdown, err := otel.NewOTLPTracer(ctx, "localhost:4317", "gaiad")
+	...
+	app := baseapp.NewBaseApp(name, logger, db, txDecoder, collectConfig, baseapp.SetTracer(tracer))
+	defer shutdown(context.Background())
+*/
+package otel
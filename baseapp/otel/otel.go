@@ -0,0 +1,80 @@
+//go:build otel
+// +build otel
+
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const instrumentationName = "github.com/cosmos/cosmos-sdk/baseapp"
+
+// NewTracer returns a sdk.Tracer starting the spans with an OpenTelemetry tracer
+func NewTracer(tracer trace.Tracer) sdk.Tracer {
+	return otelTracer{tracer}
+}
+
+// NewOTLPTracer returns a sdk.Tracer exporting the spans in batches via OTLP/gRPC to endpoint,
+// e.g. "localhost:4317". shutdown flushes the pending spans and must be called on exit.
+func NewOTLPTracer(ctx context.Context, endpoint, serviceName string) (tracer sdk.Tracer, shutdown func(context.Context) error, err error) {
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	return NewTracer(provider.Tracer(instrumentationName)), provider.Shutdown, nil
+}
+
+type otelTracer struct {
+	tracer trace.Tracer
+}
+
+func (t otelTracer) Start(ctx context.Context, name string) (context.Context, sdk.Span) {
+	ctx, span := t.tracer.Start(ctx, name)
+	return ctx, otelSpan{span}
+}
+
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s otelSpan) SetAttribute(key string, value interface{}) {
+	s.span.SetAttributes(toAttribute(key, value))
+}
+
+func (s otelSpan) End() {
+	s.span.End()
+}
+
+func toAttribute(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case uint8:
+		return attribute.Int64(key, int64(v))
+	case uint16:
+		return attribute.Int64(key, int64(v))
+	case uint32:
+		return attribute.Int64(key, int64(v))
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
@@ -39,3 +39,37 @@ func (rtr *queryrouter) AddRoute(r string, q sdk.Querier) QueryRouter {
 func (rtr *queryrouter) Route(path string) (h sdk.Querier) {
 	return rtr.routes[path]
 }
+
+// ProofQueryRouter provides the ProofQueriers for each route under "/prove".
+type ProofQueryRouter interface {
+	AddRoute(r string, q sdk.ProofQuerier) (rtr ProofQueryRouter)
+	Route(path string) (q sdk.ProofQuerier)
+}
+
+type proofQueryRouter struct {
+	routes map[string]sdk.ProofQuerier
+}
+
+// NewProofQueryRouter returns an empty ProofQueryRouter
+func NewProofQueryRouter() ProofQueryRouter {
+	return &proofQueryRouter{
+		routes: map[string]sdk.ProofQuerier{},
+	}
+}
+
+// AddRoute - Adds an sdk.ProofQuerier to the route provided. Panics on duplicate
+func (rtr *proofQueryRouter) AddRoute(r string, q sdk.ProofQuerier) ProofQueryRouter {
+	if !isAlphaNumeric(r) {
+		panic("route expressions can only contain alphanumeric characters")
+	}
+	if rtr.routes[r] != nil {
+		panic("route has already been initialized")
+	}
+	rtr.routes[r] = q
+	return rtr
+}
+
+// Returns the sdk.ProofQuerier for a certain route path
+func (rtr *proofQueryRouter) Route(path string) sdk.ProofQuerier {
+	return rtr.routes[path]
+}
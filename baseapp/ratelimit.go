@@ -0,0 +1,86 @@
+package baseapp
+
+import (
+	"fmt"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// accountRateLimiter limits the number of txs of each account accepted by CheckTx within
+// windows of a fixed number of blocks. It only protects the mempool of the node: it is
+// never applied in DeliverTx, so it does not affect consensus.
+type accountRateLimiter struct {
+	mtx         sync.Mutex
+	maxTxs      int
+	window      int64 // in blocks
+	windowStart int64
+	counts      map[string]int
+}
+
+func newAccountRateLimiter(maxTxs int, window int64) *accountRateLimiter {
+	return &accountRateLimiter{
+		maxTxs: maxTxs,
+		window: window,
+		counts: make(map[string]int),
+	}
+}
+
+// rateLimitKey returns the account a StdTx is counted for, its first signer
+func rateLimitKey(tx sdk.Tx) (string, bool) {
+	stdTx, ok := tx.(auth.StdTx)
+	if !ok {
+		return "", false
+	}
+	signers := stdTx.GetSigners()
+	if len(signers) == 0 {
+		return "", false
+	}
+	return signers[0].String(), true
+}
+
+// resetIfNewWindow drops the counts of the previous window, the caller must hold the lock
+func (rl *accountRateLimiter) resetIfNewWindow(height int64) {
+	if start := height - height%rl.window; start != rl.windowStart {
+		rl.windowStart = start
+		rl.counts = make(map[string]int)
+	}
+}
+
+func (rl *accountRateLimiter) exceeded(key string, height int64) bool {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+	rl.resetIfNewWindow(height)
+	return rl.counts[key] >= rl.maxTxs
+}
+
+func (rl *accountRateLimiter) add(key string, height int64) {
+	rl.mtx.Lock()
+	defer rl.mtx.Unlock()
+	rl.resetIfNewWindow(height)
+	rl.counts[key]++
+}
+
+// runRateLimitedCheckTx runs a tx in CheckTx mode, unless its account has reached the
+// number of txs accepted within the current window
+func (app *BaseApp) runRateLimitedCheckTx(mode sdk.RunTxMode, tx sdk.Tx, txHash string) sdk.Result {
+	if app.rateLimiter == nil {
+		return app.runCheckTx(mode, tx, txHash)
+	}
+	key, ok := rateLimitKey(tx)
+	if !ok {
+		return app.runCheckTx(mode, tx, txHash)
+	}
+
+	height := app.CheckState.Ctx.BlockHeight()
+	if app.rateLimiter.exceeded(key, height) {
+		return sdk.ErrTxRateLimited(fmt.Sprintf("account %s has reached %d txs within %d blocks",
+			key, app.rateLimiter.maxTxs, app.rateLimiter.window)).Result()
+	}
+	result := app.runCheckTx(mode, tx, txHash)
+	if result.IsOK() {
+		app.rateLimiter.add(key, height)
+	}
+	return result
+}
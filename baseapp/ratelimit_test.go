@@ -0,0 +1,50 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestRateLimitKey(t *testing.T) {
+	addr1, addr2 := sdk.AccAddress([]byte("addr1")), sdk.AccAddress([]byte("addr2"))
+	tx := auth.NewStdTx([]sdk.Msg{sdk.NewTestMsg(addr1, addr2)}, []auth.StdSignature{{Sequence: 5}, {}}, "", 0, nil)
+	key, ok := rateLimitKey(tx)
+	require.True(t, ok)
+	require.Equal(t, addr1.String(), key)
+
+	// whatever the sequence
+	tx = auth.NewStdTx([]sdk.Msg{sdk.NewTestMsg(addr1)}, []auth.StdSignature{{Sequence: 6}}, "", 0, nil)
+	nextKey, ok := rateLimitKey(tx)
+	require.True(t, ok)
+	require.Equal(t, key, nextKey)
+
+	// not a StdTx
+	_, ok = rateLimitKey(newTxCounter(0, 0))
+	require.False(t, ok)
+}
+
+func TestAccountRateLimiter(t *testing.T) {
+	rl := newAccountRateLimiter(2, 10)
+	require.False(t, rl.exceeded("acc1", 10))
+	rl.add("acc1", 10)
+	rl.add("acc1", 15)
+	require.True(t, rl.exceeded("acc1", 19))
+	require.False(t, rl.exceeded("acc2", 19))
+
+	// the counts are reset with the window
+	require.False(t, rl.exceeded("acc1", 20))
+	rl.add("acc1", 20)
+	require.False(t, rl.exceeded("acc1", 29))
+}
+
+func TestSetCheckTxRateLimit(t *testing.T) {
+	app := newBaseApp(t.Name(), SetCheckTxRateLimit(0, 1))
+	require.Nil(t, app.rateLimiter)
+	app = newBaseApp(t.Name(), SetCheckTxRateLimit(10, 5))
+	require.NotNil(t, app.rateLimiter)
+	require.Panics(t, func() { SetCheckTxRateLimit(10, 0) })
+}
@@ -0,0 +1,33 @@
+package baseapp
+
+// snapshotSchedule takes a state sync snapshot every interval blocks and deletes the
+// snapshots older than the keepRecent last ones
+type snapshotSchedule struct {
+	interval   int64
+	keepRecent int
+	heights    []int64 // heights of the snapshots taken since the node started
+}
+
+// taken records a snapshot taken at height and returns the heights of the
+// snapshots which are no longer kept
+func (s *snapshotSchedule) taken(height int64) []int64 {
+	s.heights = append(s.heights, height)
+	if s.keepRecent <= 0 || len(s.heights) <= s.keepRecent {
+		return nil
+	}
+	expired := s.heights[:len(s.heights)-s.keepRecent]
+	s.heights = append([]int64(nil), s.heights[len(expired):]...)
+	return expired
+}
+
+// snapshot takes a snapshot of the committed state at height if it's due. The version is pinned
+// by the StateSyncHelper until the snapshot is taken, so that pruning can't delete it meanwhile.
+func (app *BaseApp) snapshot(height int64) {
+	if app.snapshots == nil || app.StateSyncHelper == nil || height%app.snapshots.interval != 0 {
+		return
+	}
+	app.StateSyncHelper.TakeSnapshot(height)
+	for _, expired := range app.snapshots.taken(height) {
+		app.StateSyncHelper.RemoveSnapshot(expired)
+	}
+}
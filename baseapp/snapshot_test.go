@@ -0,0 +1,27 @@
+package baseapp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotScheduleTaken(t *testing.T) {
+	schedule := &snapshotSchedule{interval: 10, keepRecent: 2}
+	require.Empty(t, schedule.taken(10))
+	require.Empty(t, schedule.taken(20))
+	require.Equal(t, []int64{10}, schedule.taken(30))
+	require.Equal(t, []int64{20}, schedule.taken(40))
+	require.Equal(t, []int64{30, 40}, schedule.heights)
+
+	// all the snapshots are kept
+	schedule = &snapshotSchedule{interval: 10}
+	for height := int64(10); height <= 100; height += 10 {
+		require.Empty(t, schedule.taken(height))
+	}
+
+	require.Panics(t, func() { SetSnapshotInterval(0, 1) })
+	require.Panics(t, func() { SetSnapshotInterval(10, -1) })
+	app := newBaseApp(t.Name(), SetSnapshotInterval(10, 2))
+	require.Equal(t, int64(10), app.snapshots.interval)
+}
@@ -0,0 +1,122 @@
+package baseapp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// accountCacheStore is the store name of the accounts in the state diffs and the access sets,
+// the accounts are written to the account cache instead of a store
+const accountCacheStore = "accounts"
+
+// stateDiffDumper writes the state changes of every successful delivered tx
+// as a JSON line to w, see SetStateDiffDump
+type stateDiffDumper struct {
+	w       io.Writer
+	pending []store.KVDiff
+}
+
+// stateDiffFlusher is implemented by the writers buffering the state diffs
+type stateDiffFlusher interface {
+	FlushAndSync() error
+}
+
+type kvDiffJSON struct {
+	Store    string `json:"store"`
+	Key      string `json:"key"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+	Deleted  bool   `json:"deleted,omitempty"`
+}
+
+type txStateDiffJSON struct {
+	Height int64        `json:"height"`
+	TxHash string       `json:"tx_hash"`
+	Diffs  []kvDiffJSON `json:"diffs"`
+}
+
+func newStateDiffDumper(w io.Writer) *stateDiffDumper {
+	return &stateDiffDumper{w: w}
+}
+
+func (d *stateDiffDumper) record(diff store.KVDiff) {
+	d.pending = append(d.pending, diff)
+}
+
+// cacheStores cache-wraps the stores of a tx, the changes they write to ms and parent are recorded
+func (d *stateDiffDumper) cacheStores(ms sdk.MultiStore, parent sdk.AccountCache) (sdk.CacheMultiStore, sdk.AccountCache) {
+	d.pending = nil
+	accountCache := auth.NewAccountCache(diffAccountStoreCache{AccountCache: parent, record: d.record})
+	return store.CacheMultiStoreWithStateDiff(ms, d.record), accountCache
+}
+
+// dump writes the recorded changes of a tx, sorted by store and key
+func (d *stateDiffDumper) dump(height int64, txHash string) error {
+	diffs := d.pending
+	d.pending = nil
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Store != diffs[j].Store {
+			return diffs[i].Store < diffs[j].Store
+		}
+		return bytes.Compare(diffs[i].Key, diffs[j].Key) < 0
+	})
+
+	txDiff := txStateDiffJSON{Height: height, TxHash: txHash, Diffs: make([]kvDiffJSON, 0, len(diffs))}
+	for _, diff := range diffs {
+		txDiff.Diffs = append(txDiff.Diffs, kvDiffJSON{
+			Store:    diff.Store,
+			Key:      hex.EncodeToString(diff.Key),
+			OldValue: hex.EncodeToString(diff.OldValue),
+			NewValue: hex.EncodeToString(diff.NewValue),
+			Deleted:  diff.NewValue == nil,
+		})
+	}
+	bz, err := json.Marshal(txDiff)
+	if err != nil {
+		return err
+	}
+	_, err = d.w.Write(append(bz, '\n'))
+	return err
+}
+
+// flush flushes the state diffs of the block
+func (d *stateDiffDumper) flush() error {
+	if f, ok := d.w.(stateDiffFlusher); ok {
+		return f.FlushAndSync()
+	}
+	return nil
+}
+
+// diffAccountStoreCache records the accounts written to the account cache of the
+// state, the accounts are JSON encoded in the diffs
+type diffAccountStoreCache struct {
+	sdk.AccountCache
+	record func(store.KVDiff)
+}
+
+func (c diffAccountStoreCache) SetAccount(addr sdk.AccAddress, acc sdk.Account) {
+	newValue, _ := json.Marshal(acc)
+	var oldValue []byte
+	if old := c.AccountCache.GetAccount(addr); old != nil {
+		oldValue, _ = json.Marshal(old)
+	}
+	if !bytes.Equal(oldValue, newValue) {
+		c.record(store.KVDiff{Store: accountCacheStore, Key: addr, OldValue: oldValue, NewValue: newValue})
+	}
+	c.AccountCache.SetAccount(addr, acc)
+}
+
+func (c diffAccountStoreCache) Delete(addr sdk.AccAddress) {
+	if old := c.AccountCache.GetAccount(addr); old != nil {
+		oldValue, _ := json.Marshal(old)
+		c.record(store.KVDiff{Store: accountCacheStore, Key: addr, OldValue: oldValue})
+	}
+	c.AccountCache.Delete(addr)
+}
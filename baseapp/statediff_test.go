@@ -0,0 +1,66 @@
+package baseapp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestStateDiffDump(t *testing.T) {
+	// msgs with an odd counter fail, the others overwrite the key with their counter
+	key := []byte("key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			counter := msg.(*msgCounter).Counter
+			ctx.KVStore(capKey1).Set(key, []byte{byte(counter)})
+			if counter%2 == 1 {
+				return sdk.ErrInternal("odd counter").Result()
+			}
+			return sdk.Result{}
+		})
+	}
+	var buf bytes.Buffer
+	dumpOpt := func(bapp *BaseApp) { bapp.stateDiff = newStateDiffDumper(&buf) }
+	app := setupBaseApp(t, routerOpt, dumpOpt)
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	deliver := func(counter int64) {
+		txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(counter, counter))
+		require.NoError(t, err)
+		app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+	}
+
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	deliver(2)
+	// failed txs have no state diff
+	deliver(3)
+	deliver(4)
+	// checked txs are not dumped
+	txBytes, err := codec.MarshalBinaryLengthPrefixed(newTxCounter(6, 6))
+	require.NoError(t, err)
+	app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var diffs [2]txStateDiffJSON
+	for i, line := range lines {
+		require.NoError(t, json.Unmarshal([]byte(line), &diffs[i]))
+		require.Equal(t, int64(1), diffs[i].Height)
+		require.Len(t, diffs[i].Diffs, 1)
+		require.Equal(t, capKey1.Name(), diffs[i].Diffs[0].Store)
+		require.Equal(t, hex.EncodeToString(key), diffs[i].Diffs[0].Key)
+	}
+	require.Equal(t, "", diffs[0].Diffs[0].OldValue)
+	require.Equal(t, "02", diffs[0].Diffs[0].NewValue)
+	require.Equal(t, "02", diffs[1].Diffs[0].OldValue)
+	require.Equal(t, "04", diffs[1].Diffs[0].NewValue)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -60,12 +61,8 @@ type CLIContext struct {
 // NewCLIContext returns a new initialized CLIContext with parameters from the
 // command line using Viper.
 func NewCLIContext() CLIContext {
-	var rpc rpcclient.Client
-
 	nodeURI := viper.GetString(client.FlagNode)
-	if nodeURI != "" {
-		rpc = rpcclient.NewHTTP(nodeURI, "/websocket")
-	}
+	rpc := newRPCClient(nodeURI)
 
 	from := viper.GetString(client.FlagFrom)
 	fromAddress, fromName := fromFields(from)
@@ -128,7 +125,7 @@ func createVerifier() tmlite.Verifier {
 		fmt.Printf("Must specify these options: %s when --trust-node is false\n", errMsg.String())
 		os.Exit(1)
 	}
-	node := rpcclient.NewHTTP(nodeURI, "/websocket")
+	node := newRPCClient(nodeURI)
 	cacheSize := 10 // TODO: determine appropriate cache size
 	verifier, err := tmliteProxy.NewVerifier(
 		chainID, filepath.Join(home, ".bnblite"),
@@ -144,6 +141,26 @@ func createVerifier() tmlite.Verifier {
 	return verifier
 }
 
+// newRPCClient returns a client of the node, or a FailoverClient if nodeURI is a
+// comma separated list of nodes
+func newRPCClient(nodeURI string) rpcclient.Client {
+	var uris []string
+	for _, uri := range strings.Split(nodeURI, ",") {
+		if uri = strings.TrimSpace(uri); uri != "" {
+			uris = append(uris, uri)
+		}
+	}
+
+	switch len(uris) {
+	case 0:
+		return nil
+	case 1:
+		return rpcclient.NewHTTP(uris[0], "/websocket")
+	default:
+		return NewFailoverClient(uris)
+	}
+}
+
 func fromFields(from string) (fromAddr types.AccAddress, fromName string) {
 	if from == "" {
 		return nil, ""
@@ -212,13 +229,20 @@ func (ctx CLIContext) WithTrustNode(trustNode bool) CLIContext {
 	return ctx
 }
 
-// WithNodeURI returns a copy of the context with an updated node URI.
+// WithNodeURI returns a copy of the context with an updated node URI. A comma
+// separated list of nodes fails over between them, see FailoverClient.
 func (ctx CLIContext) WithNodeURI(nodeURI string) CLIContext {
 	ctx.NodeURI = nodeURI
-	ctx.Client = rpcclient.NewHTTP(nodeURI, "/websocket")
+	ctx.Client = newRPCClient(nodeURI)
 	return ctx
 }
 
+// WithNodeURIs returns a copy of the context failing over between the nodes,
+// by order of preference.
+func (ctx CLIContext) WithNodeURIs(nodeURIs []string) CLIContext {
+	return ctx.WithNodeURI(strings.Join(nodeURIs, ","))
+}
+
 // WithClient returns a copy of the context with an updated RPC client
 // instance.
 func (ctx CLIContext) WithClient(client rpcclient.Client) CLIContext {
@@ -0,0 +1,472 @@
+package context
+
+import (
+	gocontext "context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	// DefaultFailoverBackoff is how long a node is skipped after a failed request
+	DefaultFailoverBackoff = 30 * time.Second
+	// DefaultStickyPeriod is how long the requests stick to the node that accepted a tx
+	DefaultStickyPeriod = 10 * time.Second
+	// DefaultHealthCheckInterval is the interval of the health checks of a started FailoverClient
+	DefaultHealthCheckInterval = 10 * time.Second
+)
+
+var _ rpcclient.Client = (*FailoverClient)(nil)
+
+// FailoverClient is an RPC client sending the requests to the first healthy node of
+// a list. A request failing with a transport error is retried on the next node, and
+// the failed node is skipped for Backoff or until it passes a health check. RPC errors
+// returned by a node are not retried.
+//
+// After a broadcast, the requests stick to the node that accepted the tx for
+// StickyPeriod, so that the queries following a broadcast read its writes.
+//
+// Once started, the nodes are health checked every HealthCheckInterval.
+// Subscriptions are not failed over, they are made on the node preferred when
+// Subscribe is called.
+type FailoverClient struct {
+	cmn.BaseService
+
+	Backoff             time.Duration
+	StickyPeriod        time.Duration
+	HealthCheckInterval time.Duration
+
+	uris  []string
+	nodes []rpcclient.Client
+
+	mtx         sync.Mutex
+	downUntil   []time.Time
+	sticky      int
+	stickyUntil time.Time
+	subscribers map[string]int
+
+	quit chan struct{}
+}
+
+// NewFailoverClient returns a FailoverClient for the nodes, by order of preference
+func NewFailoverClient(nodeURIs []string) *FailoverClient {
+	nodes := make([]rpcclient.Client, len(nodeURIs))
+	for i, uri := range nodeURIs {
+		nodes[i] = rpcclient.NewHTTP(uri, "/websocket")
+	}
+	return newFailoverClient(nodeURIs, nodes)
+}
+
+func newFailoverClient(uris []string, nodes []rpcclient.Client) *FailoverClient {
+	if len(nodes) == 0 {
+		panic("failover client needs at least one node")
+	}
+	c := &FailoverClient{
+		Backoff:             DefaultFailoverBackoff,
+		StickyPeriod:        DefaultStickyPeriod,
+		HealthCheckInterval: DefaultHealthCheckInterval,
+		uris:                uris,
+		nodes:               nodes,
+		downUntil:           make([]time.Time, len(nodes)),
+		subscribers:         make(map[string]int),
+	}
+	c.BaseService = *cmn.NewBaseService(nil, "FailoverClient", c)
+	return c
+}
+
+// NodeURIs returns the URIs of the nodes, by order of preference
+func (c *FailoverClient) NodeURIs() []string {
+	return c.uris
+}
+
+// OnStart implements cmn.Service, it starts the nodes and their health checks
+func (c *FailoverClient) OnStart() error {
+	var started int
+	for i, node := range c.nodes {
+		if err := node.Start(); err != nil {
+			c.Logger.Error("failed to start node", "node", c.uris[i], "err", err)
+			c.markDown(i)
+			continue
+		}
+		started++
+	}
+	if started == 0 {
+		return fmt.Errorf("none of the nodes %v could be started", c.uris)
+	}
+
+	c.quit = make(chan struct{})
+	if c.HealthCheckInterval > 0 {
+		go c.healthCheckRoutine()
+	}
+	return nil
+}
+
+// OnStop implements cmn.Service
+func (c *FailoverClient) OnStop() {
+	if c.quit != nil {
+		close(c.quit)
+	}
+	for _, node := range c.nodes {
+		if node.IsRunning() {
+			node.Stop()
+		}
+	}
+}
+
+func (c *FailoverClient) healthCheckRoutine() {
+	ticker := time.NewTicker(c.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.CheckHealth()
+		case <-c.quit:
+			return
+		}
+	}
+}
+
+// CheckHealth checks the health of all the nodes, the unhealthy nodes are skipped
+// until they pass a health check or their backoff elapses
+func (c *FailoverClient) CheckHealth() {
+	for i, node := range c.nodes {
+		if _, err := node.Health(); err != nil {
+			c.Logger.Info("node is unhealthy", "node", c.uris[i], "err", err)
+			c.markDown(i)
+		} else {
+			c.markUp(i)
+		}
+	}
+}
+
+func (c *FailoverClient) markDown(i int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.downUntil[i] = time.Now().Add(c.Backoff)
+	if c.sticky == i {
+		c.stickyUntil = time.Time{}
+	}
+}
+
+func (c *FailoverClient) markUp(i int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.downUntil[i] = time.Time{}
+}
+
+func (c *FailoverClient) stick(i int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.sticky = i
+	c.stickyUntil = time.Now().Add(c.StickyPeriod)
+}
+
+// candidates returns the indexes of the nodes to try, the sticky node first, then
+// the healthy nodes by order of preference, then the unhealthy ones as a last resort
+func (c *FailoverClient) candidates() []int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := time.Now()
+	isDown := func(i int) bool { return now.Before(c.downUntil[i]) }
+	stuck := now.Before(c.stickyUntil) && !isDown(c.sticky)
+
+	order := make([]int, 0, len(c.nodes))
+	if stuck {
+		order = append(order, c.sticky)
+	}
+	for i := range c.nodes {
+		if !isDown(i) && !(stuck && i == c.sticky) {
+			order = append(order, i)
+		}
+	}
+	for i := range c.nodes {
+		if isDown(i) {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// do calls call on the candidate nodes until it succeeds or fails with an RPC error,
+// it returns the index of the last node called
+func (c *FailoverClient) do(call func(node rpcclient.Client) error) (int, error) {
+	var (
+		i   int
+		err error
+	)
+	for _, i = range c.candidates() {
+		err = call(c.nodes[i])
+		if err == nil || isRPCError(err) {
+			return i, err
+		}
+		c.Logger.Info("request to node failed, trying the next node", "node", c.uris[i], "err", err)
+		c.markDown(i)
+	}
+	return i, err
+}
+
+// isRPCError returns whether the node has handled the request and returned an error,
+// as opposed to an error reaching the node
+func isRPCError(err error) bool {
+	switch errors.Cause(err).(type) {
+	case *rpctypes.RPCError, rpctypes.RPCError:
+		return true
+	default:
+		return false
+	}
+}
+
+// ABCIInfo implements rpcclient.Client
+func (c *FailoverClient) ABCIInfo() (res *ctypes.ResultABCIInfo, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.ABCIInfo()
+		return err
+	})
+	return res, err
+}
+
+// ABCIQuery implements rpcclient.Client
+func (c *FailoverClient) ABCIQuery(path string, data cmn.HexBytes) (res *ctypes.ResultABCIQuery, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.ABCIQuery(path, data)
+		return err
+	})
+	return res, err
+}
+
+// ABCIQueryWithOptions implements rpcclient.Client
+func (c *FailoverClient) ABCIQueryWithOptions(path string, data cmn.HexBytes,
+	opts rpcclient.ABCIQueryOptions) (res *ctypes.ResultABCIQuery, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.ABCIQueryWithOptions(path, data, opts)
+		return err
+	})
+	return res, err
+}
+
+// BroadcastTxCommit implements rpcclient.Client
+func (c *FailoverClient) BroadcastTxCommit(tx tmtypes.Tx) (res *ctypes.ResultBroadcastTxCommit, err error) {
+	i, err := c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.BroadcastTxCommit(tx)
+		return err
+	})
+	if err == nil {
+		c.stick(i)
+	}
+	return res, err
+}
+
+// BroadcastTxAsync implements rpcclient.Client
+func (c *FailoverClient) BroadcastTxAsync(tx tmtypes.Tx) (res *ctypes.ResultBroadcastTx, err error) {
+	i, err := c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.BroadcastTxAsync(tx)
+		return err
+	})
+	if err == nil {
+		c.stick(i)
+	}
+	return res, err
+}
+
+// BroadcastTxSync implements rpcclient.Client
+func (c *FailoverClient) BroadcastTxSync(tx tmtypes.Tx) (res *ctypes.ResultBroadcastTx, err error) {
+	i, err := c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.BroadcastTxSync(tx)
+		return err
+	})
+	if err == nil {
+		c.stick(i)
+	}
+	return res, err
+}
+
+// Block implements rpcclient.Client
+func (c *FailoverClient) Block(height *int64) (res *ctypes.ResultBlock, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.Block(height)
+		return err
+	})
+	return res, err
+}
+
+// BlockByHash implements rpcclient.Client
+func (c *FailoverClient) BlockByHash(hash []byte) (res *ctypes.ResultBlock, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.BlockByHash(hash)
+		return err
+	})
+	return res, err
+}
+
+// BlockResults implements rpcclient.Client
+func (c *FailoverClient) BlockResults(height *int64) (res *ctypes.ResultBlockResults, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.BlockResults(height)
+		return err
+	})
+	return res, err
+}
+
+// Commit implements rpcclient.Client
+func (c *FailoverClient) Commit(height *int64) (res *ctypes.ResultCommit, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.Commit(height)
+		return err
+	})
+	return res, err
+}
+
+// Validators implements rpcclient.Client
+func (c *FailoverClient) Validators(height *int64) (res *ctypes.ResultValidators, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.Validators(height)
+		return err
+	})
+	return res, err
+}
+
+// Tx implements rpcclient.Client
+func (c *FailoverClient) Tx(hash []byte, prove bool) (res *ctypes.ResultTx, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.Tx(hash, prove)
+		return err
+	})
+	return res, err
+}
+
+// TxSearch implements rpcclient.Client
+func (c *FailoverClient) TxSearch(query string, prove bool, page, perPage int) (res *ctypes.ResultTxSearch, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.TxSearch(query, prove, page, perPage)
+		return err
+	})
+	return res, err
+}
+
+// Genesis implements rpcclient.Client
+func (c *FailoverClient) Genesis() (res *ctypes.ResultGenesis, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.Genesis()
+		return err
+	})
+	return res, err
+}
+
+// BlockchainInfo implements rpcclient.Client
+func (c *FailoverClient) BlockchainInfo(minHeight, maxHeight int64) (res *ctypes.ResultBlockchainInfo, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.BlockchainInfo(minHeight, maxHeight)
+		return err
+	})
+	return res, err
+}
+
+// NetInfo implements rpcclient.Client
+func (c *FailoverClient) NetInfo() (res *ctypes.ResultNetInfo, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.NetInfo()
+		return err
+	})
+	return res, err
+}
+
+// DumpConsensusState implements rpcclient.Client
+func (c *FailoverClient) DumpConsensusState() (res *ctypes.ResultDumpConsensusState, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.DumpConsensusState()
+		return err
+	})
+	return res, err
+}
+
+// ConsensusState implements rpcclient.Client
+func (c *FailoverClient) ConsensusState() (res *ctypes.ResultConsensusState, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.ConsensusState()
+		return err
+	})
+	return res, err
+}
+
+// Health implements rpcclient.Client
+func (c *FailoverClient) Health() (res *ctypes.ResultHealth, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.Health()
+		return err
+	})
+	return res, err
+}
+
+// Status implements rpcclient.Client
+func (c *FailoverClient) Status() (res *ctypes.ResultStatus, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.Status()
+		return err
+	})
+	return res, err
+}
+
+// BroadcastEvidence implements rpcclient.Client
+func (c *FailoverClient) BroadcastEvidence(ev tmtypes.Evidence) (res *ctypes.ResultBroadcastEvidence, err error) {
+	_, err = c.do(func(node rpcclient.Client) (err error) {
+		res, err = node.BroadcastEvidence(ev)
+		return err
+	})
+	return res, err
+}
+
+// Subscribe implements rpcclient.Client, the subscriber is subscribed on the preferred node
+func (c *FailoverClient) Subscribe(ctx gocontext.Context, subscriber, query string,
+	outCapacity ...int) (out <-chan ctypes.ResultEvent, err error) {
+	c.mtx.Lock()
+	i, subscribed := c.subscribers[subscriber]
+	c.mtx.Unlock()
+
+	if subscribed {
+		out, err = c.nodes[i].Subscribe(ctx, subscriber, query, outCapacity...)
+	} else {
+		i, err = c.do(func(node rpcclient.Client) (err error) {
+			out, err = node.Subscribe(ctx, subscriber, query, outCapacity...)
+			return err
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	c.subscribers[subscriber] = i
+	c.mtx.Unlock()
+	return out, nil
+}
+
+// Unsubscribe implements rpcclient.Client
+func (c *FailoverClient) Unsubscribe(ctx gocontext.Context, subscriber, query string) error {
+	c.mtx.Lock()
+	i, subscribed := c.subscribers[subscriber]
+	c.mtx.Unlock()
+	if !subscribed {
+		return fmt.Errorf("subscriber %s has no subscription", subscriber)
+	}
+	return c.nodes[i].Unsubscribe(ctx, subscriber, query)
+}
+
+// UnsubscribeAll implements rpcclient.Client
+func (c *FailoverClient) UnsubscribeAll(ctx gocontext.Context, subscriber string) error {
+	c.mtx.Lock()
+	i, subscribed := c.subscribers[subscriber]
+	delete(c.subscribers, subscriber)
+	c.mtx.Unlock()
+	if !subscribed {
+		return fmt.Errorf("subscriber %s has no subscription", subscriber)
+	}
+	return c.nodes[i].UnsubscribeAll(ctx, subscriber)
+}
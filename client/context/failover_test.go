@@ -0,0 +1,114 @@
+package context
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/lib/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+type fakeNode struct {
+	rpcclient.Client
+	name     string
+	down     bool
+	rpcError bool
+	queries  int
+}
+
+func (n *fakeNode) ABCIQuery(path string, data cmn.HexBytes) (*ctypes.ResultABCIQuery, error) {
+	n.queries++
+	if n.down {
+		return nil, errors.New("connection refused")
+	}
+	if n.rpcError {
+		return nil, pkgerrors.Wrap(&rpctypes.RPCError{Code: -32603, Message: "internal error"}, "response error")
+	}
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Log: n.name}}, nil
+}
+
+func (n *fakeNode) BroadcastTxSync(tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if n.down {
+		return nil, errors.New("connection refused")
+	}
+	return &ctypes.ResultBroadcastTx{Log: n.name}, nil
+}
+
+func (n *fakeNode) Health() (*ctypes.ResultHealth, error) {
+	if n.down {
+		return nil, errors.New("connection refused")
+	}
+	return &ctypes.ResultHealth{}, nil
+}
+
+func queriedNode(t *testing.T, c *FailoverClient) string {
+	res, err := c.ABCIQuery("/store/acc/key", nil)
+	require.NoError(t, err)
+	return res.Response.Log
+}
+
+func TestFailoverClient(t *testing.T) {
+	n0, n1, n2 := &fakeNode{name: "n0"}, &fakeNode{name: "n1"}, &fakeNode{name: "n2"}
+	c := newFailoverClient([]string{"n0", "n1", "n2"}, []rpcclient.Client{n0, n1, n2})
+
+	// the first node is preferred
+	require.Equal(t, "n0", queriedNode(t, c))
+
+	// a node that can't be reached is skipped until its backoff elapses
+	n0.down = true
+	require.Equal(t, "n1", queriedNode(t, c))
+	n0.down = false
+	n0.queries = 0
+	require.Equal(t, "n1", queriedNode(t, c))
+	require.Zero(t, n0.queries)
+
+	// or until it passes a health check
+	c.CheckHealth()
+	require.Equal(t, "n0", queriedNode(t, c))
+
+	// rpc errors are returned without trying the other nodes
+	n0.rpcError = true
+	n1.queries = 0
+	_, err := c.ABCIQuery("/store/acc/key", nil)
+	require.Error(t, err)
+	require.Zero(t, n1.queries)
+	n0.rpcError = false
+
+	// the queries stick to the node that accepted a tx
+	n0.down = true
+	res, err := c.BroadcastTxSync(tmtypes.Tx("tx"))
+	require.NoError(t, err)
+	require.Equal(t, "n1", res.Log)
+	n0.down = false
+	c.CheckHealth()
+	require.Equal(t, "n1", queriedNode(t, c))
+
+	// until the sticky period elapses
+	c.mtx.Lock()
+	c.stickyUntil = time.Now()
+	c.mtx.Unlock()
+	require.Equal(t, "n0", queriedNode(t, c))
+
+	// or the sticky node fails
+	c.stick(2)
+	require.Equal(t, "n2", queriedNode(t, c))
+	n2.down = true
+	require.Equal(t, "n0", queriedNode(t, c))
+
+	// the unhealthy nodes are the last resort
+	n0.down, n1.down, n2.down = true, true, false
+	c.CheckHealth()
+	c.markDown(2)
+	require.Equal(t, "n2", queriedNode(t, c))
+
+	n2.down = true
+	_, err = c.ABCIQuery("/store/acc/key", nil)
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package context
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -40,6 +42,100 @@ func (ctx CLIContext) QueryWithData(path string, data []byte) (res []byte, err e
 	return ctx.query(path, data)
 }
 
+// QueryWithProof performs a query of a route registered under "/prove", like
+// "/prove/<route>/<path>", answered by querier on the node. Unless the node is
+// trusted, the data is derived locally by querier from the store value proved by
+// the response.
+func (ctx CLIContext) QueryWithProof(path string, data []byte, querier sdk.ProofQuerier) (res []byte, err error) {
+	proved, err := ctx.queryProved(path, data, querier, !ctx.TrustNode)
+	if err != nil {
+		return res, err
+	}
+	return proved.Data, nil
+}
+
+// ProvedResponse is the answer to a query of a route registered under "/prove", along with
+// the proof of the store value it is derived from, the proof is checked against the app hash
+// of the header Height+1
+type ProvedResponse struct {
+	Data       []byte
+	Key        []byte
+	StoreValue []byte
+	Proof      *merkle.Proof
+	Height     int64
+}
+
+// QueryProved is like QueryWithProof, but the proof is always requested and returned, so that
+// it can be relayed to another chain. The proof is checked unless the node is trusted.
+func (ctx CLIContext) QueryProved(path string, data []byte, querier sdk.ProofQuerier) (ProvedResponse, error) {
+	return ctx.queryProved(path, data, querier, true)
+}
+
+func (ctx CLIContext) queryProved(path string, data []byte, querier sdk.ProofQuerier, prove bool) (res ProvedResponse, err error) {
+	node, err := ctx.GetNode()
+	if err != nil {
+		return res, err
+	}
+
+	opts := rpcclient.ABCIQueryOptions{
+		Height: ctx.Height,
+		Prove:  prove,
+	}
+
+	result, err := node.ABCIQueryWithOptions(path, data, opts)
+	if err != nil {
+		return res, err
+	}
+
+	resp := result.Response
+	if !resp.IsOK() {
+		return res, errors.Errorf(resp.Log)
+	}
+
+	var proved sdk.ProvedQueryResult
+	if err := codec.Cdc.UnmarshalBinaryLengthPrefixed(resp.Value, &proved); err != nil {
+		return res, err
+	}
+	res = ProvedResponse{
+		Data:       proved.Data,
+		Key:        resp.Key,
+		StoreValue: proved.StoreValue,
+		Proof:      resp.Proof,
+		Height:     resp.Height,
+	}
+	if !prove || ctx.TrustNode {
+		return res, nil
+	}
+
+	paths := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(paths) < 2 || paths[0] != "prove" {
+		return res, errors.New("expected format like /prove/<route>/<path>")
+	}
+	req := abci.RequestQuery{Path: path, Data: data, Height: ctx.Height, Prove: true}
+	storeName, key, sdkErr := querier.Key(paths[2:], req)
+	if sdkErr != nil {
+		return res, errors.New(sdkErr.Error())
+	}
+	if !bytes.Equal(resp.Key, key) {
+		return res, errors.Errorf("the response proves the key %X instead of %X", resp.Key, key)
+	}
+
+	resp.Value = proved.StoreValue
+	if len(resp.Value) == 0 {
+		resp.Value = nil
+	}
+	err = ctx.verifyProof(fmt.Sprintf("/store/%s/key", storeName), resp)
+	if err != nil {
+		return res, err
+	}
+
+	res.Data, sdkErr = querier.Result(resp.Value, paths[2:], req)
+	if sdkErr != nil {
+		return res, errors.New(sdkErr.Error())
+	}
+	return res, nil
+}
+
 // QueryStore performs a query from a Tendermint node with the provided key and
 // store name.
 func (ctx CLIContext) QueryStore(key cmn.HexBytes, storeName string) (res []byte, err error) {
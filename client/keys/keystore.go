@@ -0,0 +1,126 @@
+package keys
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/keystore"
+)
+
+const (
+	flagOut         = "out"
+	flagLightScrypt = "light-scrypt"
+)
+
+func exportKeystoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-keystore <name>",
+		Short: "Export a local key as an encrypted Ethereum keystore v3 JSON",
+		Long: `Export a local secp256k1 key as an Ethereum keystore v3 JSON encrypted with a new
+passphrase, so that it can be imported by the BSC tooling without exposing the private key.`,
+		RunE: runExportKeystoreCmd,
+		Args: cobra.ExactArgs(1),
+	}
+	cmd.Flags().String(flagOut, "", "File to write the keystore to, instead of the standard output")
+	cmd.Flags().Bool(flagLightScrypt, false, "Use the light scrypt parameters, faster but weaker")
+	return cmd
+}
+
+func runExportKeystoreCmd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	buf := client.BufferStdin()
+	kb, err := GetKeyBase()
+	if err != nil {
+		return err
+	}
+	passphrase, err := client.GetPassword("Enter the passphrase of the key:", buf)
+	if err != nil {
+		return err
+	}
+	priv, err := kb.ExportPrivateKeyObject(name, passphrase)
+	if err != nil {
+		return err
+	}
+	secpPriv, ok := priv.(secp256k1.PrivKeySecp256k1)
+	if !ok {
+		return fmt.Errorf("only secp256k1 keys can be exported as keystore")
+	}
+	keystorePassphrase, err := client.GetCheckPassword(
+		"Enter a passphrase to encrypt the keystore:",
+		"Repeat the passphrase:", buf)
+	if err != nil {
+		return err
+	}
+
+	scryptN, scryptP := keystore.StandardScryptN, keystore.StandardScryptP
+	if viper.GetBool(flagLightScrypt) {
+		scryptN, scryptP = keystore.LightScryptN, keystore.LightScryptP
+	}
+	keyJSON, err := keystore.EncryptKey(secpPriv, keystorePassphrase, scryptN, scryptP)
+	if err != nil {
+		return err
+	}
+
+	if out := viper.GetString(flagOut); out != "" {
+		return ioutil.WriteFile(out, keyJSON, 0600)
+	}
+	fmt.Println(string(keyJSON))
+	return nil
+}
+
+func importKeystoreCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-keystore <name> <keystore-file>",
+		Short: "Import a key from an encrypted Ethereum keystore v3 JSON",
+		Long: `Import a secp256k1 key from an Ethereum keystore v3 JSON, encrypted with scrypt or pbkdf2,
+e.g. exported by the BSC tooling, and store it as a local key encrypted with a new passphrase.`,
+		RunE: runImportKeystoreCmd,
+		Args: cobra.ExactArgs(2),
+	}
+	return cmd
+}
+
+func runImportKeystoreCmd(cmd *cobra.Command, args []string) error {
+	name, file := args[0], args[1]
+
+	buf := client.BufferStdin()
+	kb, err := GetKeyBaseWithWritePerm()
+	if err != nil {
+		return err
+	}
+	if _, err := kb.Get(name); err == nil {
+		return fmt.Errorf("key %s already exists", name)
+	}
+	keyJSON, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	keystorePassphrase, err := client.GetPassword("Enter the passphrase of the keystore:", buf)
+	if err != nil {
+		return err
+	}
+	privBytes, err := keystore.DecryptKey(keyJSON, keystorePassphrase)
+	if err != nil {
+		return err
+	}
+	passphrase, err := client.GetCheckPassword(
+		"Enter a passphrase to encrypt the key:",
+		"Repeat the passphrase:", buf)
+	if err != nil {
+		return err
+	}
+
+	info, err := kb.ImportPrivateKeyObject(name, secp256k1.PrivKeySecp256k1(privBytes), passphrase)
+	if err != nil {
+		return err
+	}
+	printKeyInfo(info, Bech32KeyOutput)
+	return nil
+}
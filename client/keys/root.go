@@ -26,6 +26,8 @@ func Commands() *cobra.Command {
 		client.LineBreak,
 		deleteKeyCommand(),
 		updateKeyCommand(),
+		exportKeystoreCommand(),
+		importKeystoreCommand(),
 	)
 	return cmd
 }
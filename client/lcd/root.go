@@ -148,6 +148,7 @@ func createHandler(cdc *codec.Codec) *mux.Router {
 	// TODO: make more functional? aka r = keys.RegisterRoutes(r)
 	r.HandleFunc("/version", CLIVersionRequestHandler).Methods("GET")
 	r.HandleFunc("/node_version", NodeVersionRequestHandler(cliCtx)).Methods("GET")
+	r.HandleFunc("/node_earliest_version", NodeEarliestVersionRequestHandler(cliCtx)).Methods("GET")
 
 	keys.RegisterRoutes(r, cliCtx.Indent)
 	rpc.RegisterRoutes(cliCtx, r)
@@ -27,3 +27,17 @@ func NodeVersionRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
 		w.Write(version)
 	}
 }
+
+// connected node earliest queryable height REST handler endpoint
+func NodeEarliestVersionRequestHandler(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		version, err := cliCtx.Query("/app/earliest_version", nil)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(version)
+	}
+}
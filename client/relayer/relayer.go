@@ -0,0 +1,259 @@
+// Package relayer relays the cross chain packages created on this chain to their destination
+// chains. A Relayer follows the package events of the blocks, queries each package along with
+// the proof of its value and hands it to a Submitter, which sends it to the destination chain.
+package relayer
+
+import (
+	gocontext "context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+const (
+	// DefaultRoute is the route under "/prove" of the ibc.PackageProofQuerier
+	DefaultRoute = "ibc"
+	// DefaultSubscriber is the name of the subscriber of the block events
+	DefaultSubscriber = "relayer"
+)
+
+// Package is a cross chain package along with the proof of its value
+type Package struct {
+	SrcChainID  sdk.ChainID
+	DestChainID sdk.ChainID
+	ChannelID   sdk.ChannelID
+	Sequence    uint64
+
+	// Value is the package as stored, the package header followed by the payload
+	Value []byte
+	// Key is the key of the package in the ibc store
+	Key []byte
+	// Proof proves Value under the app hash of the header Height+1
+	Proof  *merkle.Proof
+	Height int64
+}
+
+// Submitter sends packages to their destination chain
+type Submitter interface {
+	// NextSequence returns the sequence of the next package of the channel expected by the
+	// destination chain
+	NextSequence(destChainID sdk.ChainID, channelID sdk.ChannelID) (uint64, error)
+	// Submit sends a package to the destination chain, the packages of a channel are
+	// submitted in sequence order
+	Submit(pkg Package) error
+}
+
+type channelKey struct {
+	destChainID sdk.ChainID
+	channelID   sdk.ChannelID
+}
+
+// Relayer relays the packages of this chain to a Submitter.
+//
+// The packages created in block H are relayed once the header of block H+1 is received, as the
+// proof of a value of the state of block H is checked against the app hash of header H+1.
+// The packages of a channel are relayed from the next sequence expected by the destination chain,
+// so the packages missed while the relayer was down or the submissions that failed are relayed
+// again with the next package of the channel.
+type Relayer struct {
+	cliCtx     context.CLIContext
+	submitter  Submitter
+	srcChainID sdk.ChainID
+	route      string
+	querier    ibc.PackageProofQuerier
+	logger     log.Logger
+
+	// fetch queries a package, replaced in tests
+	fetch func(height int64, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) (Package, error)
+
+	mtx       sync.Mutex
+	sequences map[channelKey]uint64 // next sequence to submit of each channel
+	pending   *pendingBlock
+}
+
+type pendingBlock struct {
+	height int64
+	values []string
+}
+
+// NewRelayer returns a Relayer of the packages sent by the chain srcChainID, kept in the store
+// storeName. The proofs are checked against the verifier of cliCtx, unless the node is trusted.
+func NewRelayer(cliCtx context.CLIContext, srcChainID sdk.ChainID, storeName string, submitter Submitter) *Relayer {
+	r := &Relayer{
+		cliCtx:     cliCtx,
+		submitter:  submitter,
+		srcChainID: srcChainID,
+		route:      DefaultRoute,
+		querier:    ibc.NewPackageProofQuerier(storeName),
+		logger:     log.NewNopLogger(),
+		sequences:  make(map[channelKey]uint64),
+	}
+	r.fetch = r.QueryPackage
+	return r
+}
+
+// WithRoute sets the route under "/prove" of the ibc.PackageProofQuerier of the node
+func (r *Relayer) WithRoute(route string) *Relayer {
+	r.route = route
+	return r
+}
+
+// WithLogger sets the logger of the relayer
+func (r *Relayer) WithLogger(logger log.Logger) *Relayer {
+	r.logger = logger
+	return r
+}
+
+// Run subscribes to the block headers of the node and relays the packages of the blocks until
+// ctx is done or the subscription is closed
+func (r *Relayer) Run(ctx gocontext.Context) error {
+	node, err := r.cliCtx.GetNode()
+	if err != nil {
+		return err
+	}
+	if !node.IsRunning() {
+		if err := node.Start(); err != nil {
+			return err
+		}
+	}
+
+	query := tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
+	events, err := node.Subscribe(ctx, DefaultSubscriber, query)
+	if err != nil {
+		return err
+	}
+	defer node.UnsubscribeAll(gocontext.Background(), DefaultSubscriber) // nolint: errcheck
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return errors.New("subscription closed")
+			}
+			header, ok := event.Data.(tmtypes.EventDataNewBlockHeader)
+			if !ok {
+				continue
+			}
+			if err := r.HandleBlock(header.Header.Height, event.Events[ibc.PackageEventKey]); err != nil {
+				r.logger.Error("failed to relay packages", "height", header.Header.Height, "err", err)
+			}
+		}
+	}
+}
+
+// HandleBlock takes the values of the package events of the block at height, and relays the
+// packages of the previous block
+func (r *Relayer) HandleBlock(height int64, values []string) error {
+	r.mtx.Lock()
+	pending := r.pending
+	r.pending = nil
+	if len(values) > 0 {
+		r.pending = &pendingBlock{height: height, values: values}
+	}
+	r.mtx.Unlock()
+
+	if pending == nil {
+		return nil
+	}
+	if pending.height >= height {
+		return fmt.Errorf("block %d received after block %d", height, pending.height)
+	}
+	return r.RelayBlock(pending.height, pending.values)
+}
+
+// RelayBlock relays the packages created at height, given by the values of the package events
+// of the block. The packages of each channel are submitted up to the last one of the block.
+func (r *Relayer) RelayBlock(height int64, values []string) error {
+	var channels []channelKey
+	last := make(map[channelKey]uint64)
+	for _, value := range values {
+		destChainID, channelID, sequence, err := ibc.ParsePackageEventValue(value)
+		if err != nil {
+			return err
+		}
+		key := channelKey{destChainID: destChainID, channelID: channelID}
+		seq, ok := last[key]
+		if !ok {
+			channels = append(channels, key)
+		}
+		if !ok || sequence > seq {
+			last[key] = sequence
+		}
+	}
+
+	for _, key := range channels {
+		if err := r.relayChannel(height, key, last[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Relayer) relayChannel(height int64, key channelKey, last uint64) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	next, ok := r.sequences[key]
+	if !ok {
+		var err error
+		next, err = r.submitter.NextSequence(key.destChainID, key.channelID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the next sequence of channel %d of chain %d", key.channelID, key.destChainID)
+		}
+	}
+
+	for ; next <= last; next++ {
+		pkg, err := r.fetch(height, key.destChainID, key.channelID, next)
+		if err != nil {
+			return errors.Wrapf(err, "failed to query package %d of channel %d of chain %d", next, key.channelID, key.destChainID)
+		}
+		if err := r.submitter.Submit(pkg); err != nil {
+			// the failed sequence is submitted again with the next package of the channel
+			r.sequences[key] = next
+			return errors.Wrapf(err, "failed to submit package %d of channel %d of chain %d", next, key.channelID, key.destChainID)
+		}
+		r.logger.Info("relayed package", "dest_chain", key.destChainID, "channel", key.channelID, "sequence", next)
+	}
+	r.sequences[key] = next
+	return nil
+}
+
+// NextSequence returns the sequence of the next package of the channel to submit, false if the
+// relayer has not relayed any package of the channel yet
+func (r *Relayer) NextSequence(destChainID sdk.ChainID, channelID sdk.ChannelID) (uint64, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	next, ok := r.sequences[channelKey{destChainID: destChainID, channelID: channelID}]
+	return next, ok
+}
+
+// QueryPackage queries the package of the sequence in the state at height, along with its proof
+func (r *Relayer) QueryPackage(height int64, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) (Package, error) {
+	cliCtx := r.cliCtx
+	cliCtx.Height = height
+	path := ibc.PackageProofQueryPath(r.route, r.srcChainID, destChainID, channelID, sequence)
+	res, err := cliCtx.QueryProved(path, nil, r.querier)
+	if err != nil {
+		return Package{}, err
+	}
+	return Package{
+		SrcChainID:  r.srcChainID,
+		DestChainID: destChainID,
+		ChannelID:   channelID,
+		Sequence:    sequence,
+		Value:       res.Data,
+		Key:         res.Key,
+		Proof:       res.Proof,
+		Height:      res.Height,
+	}, nil
+}
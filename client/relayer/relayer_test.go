@@ -0,0 +1,71 @@
+package relayer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type testSubmitter struct {
+	next      uint64
+	submitted []Package
+	fail      bool
+}
+
+func (s *testSubmitter) NextSequence(destChainID sdk.ChainID, channelID sdk.ChannelID) (uint64, error) {
+	return s.next, nil
+}
+
+func (s *testSubmitter) Submit(pkg Package) error {
+	if s.fail {
+		return errors.New("submission failed")
+	}
+	s.submitted = append(s.submitted, pkg)
+	return nil
+}
+
+func newTestRelayer(submitter Submitter) *Relayer {
+	r := NewRelayer(context.CLIContext{}, 1, "ibc", submitter)
+	r.fetch = func(height int64, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) (Package, error) {
+		return Package{DestChainID: destChainID, ChannelID: channelID, Sequence: sequence, Height: height}, nil
+	}
+	return r
+}
+
+func sequences(pkgs []Package) []uint64 {
+	seqs := make([]uint64, len(pkgs))
+	for i, pkg := range pkgs {
+		seqs[i] = pkg.Sequence
+	}
+	return seqs
+}
+
+func TestRelayer(t *testing.T) {
+	submitter := &testSubmitter{next: 2}
+	r := newTestRelayer(submitter)
+
+	// the packages of a block are relayed with the next block
+	require.NoError(t, r.HandleBlock(10, []string{"15::1::3", "15::1::4"}))
+	require.Empty(t, submitter.submitted)
+	require.NoError(t, r.HandleBlock(11, nil))
+	// the missed package 2 is relayed first
+	require.Equal(t, []uint64{2, 3, 4}, sequences(submitter.submitted))
+	require.Equal(t, int64(10), submitter.submitted[0].Height)
+	next, ok := r.NextSequence(15, 1)
+	require.True(t, ok)
+	require.Equal(t, uint64(5), next)
+
+	// a failed submission is retried with the next package of the channel
+	submitter.fail = true
+	require.NoError(t, r.HandleBlock(12, []string{"15::1::5"}))
+	require.Error(t, r.HandleBlock(13, []string{"15::1::6"}))
+	submitter.fail = false
+	require.NoError(t, r.HandleBlock(14, nil))
+	require.Equal(t, []uint64{2, 3, 4, 5, 6}, sequences(submitter.submitted))
+
+	require.Error(t, r.RelayBlock(20, []string{"invalid"}))
+}
@@ -19,6 +19,7 @@ import (
 const (
 	queryArgDryRun       = "simulate"
 	queryArgGenerateOnly = "generate_only"
+	queryArgDisplay      = "display"
 )
 
 //----------------------------------------
@@ -47,6 +48,12 @@ func HasGenerateOnlyArg(r *http.Request) bool {
 	return urlQueryHasArg(r.URL, queryArgGenerateOnly)
 }
 
+// HasDisplayArg returns whether a URL's query "display" parameter is set to
+// "true", in which case coin amounts are returned in display units.
+func HasDisplayArg(r *http.Request) bool {
+	return urlQueryHasArg(r.URL, queryArgDisplay)
+}
+
 // ParseInt64OrReturnBadRequest converts s to a int64 value.
 func ParseInt64OrReturnBadRequest(w http.ResponseWriter, s string) (n int64, ok bool) {
 	var err error
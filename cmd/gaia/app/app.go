@@ -121,6 +121,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.cdc,
 		app.keyParams, app.tkeyParams,
 	)
+	app.accountKeeper.SetParamSpace(app.paramsKeeper.Subspace(auth.DefaultParamspace))
 	app.ibcKeeper = ibc.NewKeeper(app.keyIbc, app.paramsKeeper.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace,
 		sidechain.NewKeeper(app.keySide, app.paramsKeeper.Subspace(sidechain.DefaultParamspace), app.cdc))
 	app.stakeKeeper = stake.NewKeeper(
@@ -139,7 +140,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.keyDistr,
 		app.paramsKeeper.Subspace(distr.DefaultParamspace),
 		app.bankKeeper, app.stakeKeeper, app.feeCollectionKeeper,
-		app.RegisterCodespace(stake.DefaultCodespace),
+		app.RegisterCodespace(distr.DefaultCodespace),
 	)
 	app.slashingKeeper = slashing.NewKeeper(
 		app.cdc,
@@ -155,10 +156,12 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.RegisterCodespace(gov.DefaultCodespace),
 		app.Pool,
 	)
+	app.govKeeper.SetCommunityPool(app.distrKeeper)
 
-	// register the staking hooks
+	// register the staking and slashing hooks
 	app.stakeKeeper = app.stakeKeeper.WithHooks(
-		NewHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()))
+		sdk.NewMultiStakingHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks(), app.govKeeper.Hooks()))
+	app.slashingKeeper = app.slashingKeeper.WithHooks(app.stakeKeeper.SlashingHooks())
 
 	// register message routes
 	app.Router().
@@ -172,6 +175,10 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		AddRoute("gov", gov.NewQuerier(app.govKeeper)).
 		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc))
 
+	app.ProofQueryRouter().
+		AddRoute("account", auth.NewAccountProofQuerier(app.keyAccount.Name(), app.cdc)).
+		AddRoute("ibc", ibc.NewPackageProofQuerier(app.keyIbc.Name()))
+
 	// initialize BaseApp
 	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keyStakeReward, app.keyMint, app.keyDistr,
 		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyIbc)
@@ -180,6 +187,12 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper))
 	app.MountStoresTransient(app.tkeyParams, app.tkeyStake, app.tkeyDistr)
 	app.SetEndBlocker(app.EndBlocker)
+	app.SetModuleVersions(sdk.NewModuleManager(
+		auth.NewAppModule(app.accountKeeper),
+		stake.NewAppModule(&app.stakeKeeper),
+		slashing.NewAppModule(&app.slashingKeeper),
+		gov.NewAppModule(&app.govKeeper),
+	).ConsensusVersions())
 
 	err := app.LoadCMSLatestVersion()
 	if err != nil {
@@ -261,6 +274,7 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 		acc.AccountNumber = app.accountKeeper.GetNextAccountNumber(ctx)
 		app.accountKeeper.SetAccount(ctx, acc)
 	}
+	app.accountKeeper.SetParams(ctx, auth.DefaultParams())
 
 	// load the initial stake information
 	validators, err := stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
@@ -342,51 +356,3 @@ func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, val
 	validators = stake.WriteValidators(ctx, app.stakeKeeper)
 	return appState, validators, nil
 }
-
-//______________________________________________________________________________________________
-
-// Combined Staking Hooks
-type Hooks struct {
-	dh distr.Hooks
-	sh slashing.Hooks
-}
-
-func NewHooks(dh distr.Hooks, sh slashing.Hooks) Hooks {
-	return Hooks{dh, sh}
-}
-
-var _ sdk.StakingHooks = Hooks{}
-
-// nolint
-func (h Hooks) OnValidatorCreated(ctx sdk.Context, addr sdk.ValAddress) {
-	h.dh.OnValidatorCreated(ctx, addr)
-}
-func (h Hooks) OnValidatorModified(ctx sdk.Context, addr sdk.ValAddress) {
-	h.dh.OnValidatorModified(ctx, addr)
-}
-func (h Hooks) OnValidatorRemoved(ctx sdk.Context, addr sdk.ValAddress) {
-	h.dh.OnValidatorRemoved(ctx, addr)
-}
-func (h Hooks) OnValidatorBonded(ctx sdk.Context, addr sdk.ConsAddress, operator sdk.ValAddress) {
-	h.dh.OnValidatorBonded(ctx, addr, operator)
-	h.sh.OnValidatorBonded(ctx, addr, operator)
-}
-func (h Hooks) OnValidatorBeginUnbonding(ctx sdk.Context, addr sdk.ConsAddress, operator sdk.ValAddress) {
-	h.dh.OnValidatorBeginUnbonding(ctx, addr, operator)
-	h.sh.OnValidatorBeginUnbonding(ctx, addr, operator)
-}
-func (h Hooks) OnDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
-	h.dh.OnDelegationCreated(ctx, delAddr, valAddr)
-}
-func (h Hooks) OnDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
-	h.dh.OnDelegationSharesModified(ctx, delAddr, valAddr)
-}
-func (h Hooks) OnDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
-	h.dh.OnDelegationRemoved(ctx, delAddr, valAddr)
-}
-func (h Hooks) OnSideChainValidatorBonded(ctx sdk.Context, sideConsAddr []byte, operator sdk.ValAddress) {
-}
-func (h Hooks) OnSideChainValidatorBeginUnbonding(ctx sdk.Context, sideConsAddr []byte, operator sdk.ValAddress) {
-}
-func (h Hooks) OnSelfDelDropBelowMin(ctx sdk.Context, operator sdk.ValAddress) {
-}
@@ -111,7 +111,7 @@ func NewMockGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppO
 
 	// register the staking hooks
 	app.stakeKeeper = app.stakeKeeper.WithHooks(
-		NewHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()))
+		sdk.NewMultiStakingHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks(), app.govKeeper.Hooks()))
 
 	// register message routes
 	app.Router().
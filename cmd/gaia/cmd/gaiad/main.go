@@ -18,6 +18,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
 	gaiaInit "github.com/cosmos/cosmos-sdk/cmd/gaia/init"
 	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func main() {
@@ -46,9 +47,36 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewGaiaApp(logger, db, traceStore,
-		baseapp.SetPruning(viper.GetString("pruning")),
-	)
+	options := []func(*baseapp.BaseApp){
+		pruning(),
+		baseapp.SetCheckTxRateLimit(viper.GetInt("check_tx_rate_limit"), checkTxRateLimitBlocks()),
+		baseapp.SetHaltHeight(viper.GetInt64("halt_height")),
+		baseapp.SetHaltTime(viper.GetInt64("halt_time")),
+	}
+	if interval := viper.GetInt64("snapshot_interval"); interval > 0 {
+		options = append(options, baseapp.SetSnapshotInterval(interval, viper.GetInt("snapshot_keep_recent")))
+	}
+	return app.NewGaiaApp(logger, db, traceStore, options...)
+}
+
+// pruning returns the pruning option, the custom strategy is set by the pruning-* flags
+func pruning() func(*baseapp.BaseApp) {
+	if viper.GetString("pruning") != "custom" {
+		return baseapp.SetPruning(viper.GetString("pruning"))
+	}
+	return baseapp.SetPruningStrategy(sdk.NewPruningStrategy(
+		viper.GetInt64("pruning-keep-recent"),
+		viper.GetInt64("pruning-keep-every"),
+		viper.GetInt64("pruning-interval"),
+	))
+}
+
+// checkTxRateLimitBlocks returns the window of the CheckTx rate limit, 1 block if unset
+func checkTxRateLimitBlocks() int64 {
+	if blocks := viper.GetInt64("check_tx_rate_limit_blocks"); blocks > 0 {
+		return blocks
+	}
+	return 1
 }
 
 func exportAppStateAndTMValidators(
@@ -322,6 +322,16 @@ func (kb dbKeybase) ExportPrivateKeyObject(name string, passphrase string) (tmcr
 	return priv, nil
 }
 
+// ImportPrivateKeyObject stores priv as a local key encrypted with passphrase,
+// the name must not be taken.
+func (kb dbKeybase) ImportPrivateKeyObject(name string, priv tmcrypto.PrivKey, passphrase string) (Info, error) {
+	bz := kb.db.Get(infoKey(name))
+	if len(bz) > 0 {
+		return nil, errors.New("Cannot overwrite data for name " + name)
+	}
+	return kb.writeLocalKey(priv, name, passphrase), nil
+}
+
 func (kb dbKeybase) Export(name string) (armor string, err error) {
 	bz := kb.db.Get(infoKey(name))
 	if bz == nil {
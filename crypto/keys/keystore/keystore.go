@@ -0,0 +1,259 @@
+// Package keystore encrypts and decrypts secp256k1 private keys in the Ethereum keystore v3 JSON format,
+// so that the keys are moved between the BSC tooling and the keys of the cli without exposing them.
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+)
+
+const (
+	version = 3
+
+	cipherAES128CTR = "aes-128-ctr"
+	kdfScrypt       = "scrypt"
+	kdfPBKDF2       = "pbkdf2"
+	prfHmacSHA256   = "hmac-sha256"
+
+	scryptR     = 8
+	scryptDKLen = 32
+
+	// StandardScryptN and StandardScryptP are the scrypt parameters of geth
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+
+	// LightScryptN and LightScryptP are the lighter scrypt parameters of geth, e.g. for tests
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+
+	privKeyLength = 32
+)
+
+// ErrDecrypt is returned when the passphrase does not match the keystore
+var ErrDecrypt = errors.New("could not decrypt key with given passphrase")
+
+type encryptedKeyJSONV3 struct {
+	Address string     `json:"address"`
+	Crypto  cryptoJSON `json:"crypto"`
+	Id      string     `json:"id"`
+	Version int        `json:"version"`
+}
+
+type cryptoJSON struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams cipherParamsJSON       `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+// Address returns the BSC address of a secp256k1 private key
+func Address(privKey []byte) (bsc.Address, error) {
+	if len(privKey) != privKeyLength {
+		return bsc.Address{}, fmt.Errorf("invalid private key length %d, expected %d", len(privKey), privKeyLength)
+	}
+	_, pubKey := btcec.PrivKeyFromBytes(privKey)
+	var address bsc.Address
+	copy(address[:], bsc.Keccak256(pubKey.SerializeUncompressed()[1:])[12:])
+	return address, nil
+}
+
+// EncryptKey encrypts a secp256k1 private key with passphrase into a keystore v3 JSON, the key being derived
+// from the passphrase with scrypt of parameters scryptN and scryptP
+func EncryptKey(privKey []byte, passphrase string, scryptN, scryptP int) ([]byte, error) {
+	address, err := Address(privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	salt, err := randomBytes(32)
+	if err != nil {
+		return nil, err
+	}
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := randomBytes(aes.BlockSize)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := aesCTRXOR(derivedKey[:16], privKey, iv)
+	if err != nil {
+		return nil, err
+	}
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(encryptedKeyJSONV3{
+		Address: hex.EncodeToString(address[:]),
+		Crypto: cryptoJSON{
+			Cipher:       cipherAES128CTR,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+			KDF:          kdfScrypt,
+			KDFParams: map[string]interface{}{
+				"n":     scryptN,
+				"r":     scryptR,
+				"p":     scryptP,
+				"dklen": scryptDKLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(bsc.Keccak256(derivedKey[16:32], cipherText)),
+		},
+		Id:      id,
+		Version: version,
+	})
+}
+
+// DecryptKey decrypts the secp256k1 private key of a keystore v3 JSON with passphrase, the key being derived
+// with scrypt or pbkdf2
+func DecryptKey(keyJSON []byte, passphrase string) ([]byte, error) {
+	var k encryptedKeyJSONV3
+	if err := json.Unmarshal(keyJSON, &k); err != nil {
+		return nil, err
+	}
+	if k.Version != version {
+		return nil, fmt.Errorf("unsupported keystore version %d, expected %d", k.Version, version)
+	}
+	if k.Crypto.Cipher != cipherAES128CTR {
+		return nil, fmt.Errorf("unsupported cipher %s, expected %s", k.Crypto.Cipher, cipherAES128CTR)
+	}
+	mac, err := hex.DecodeString(k.Crypto.MAC)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := hex.DecodeString(k.Crypto.CipherParams.IV)
+	if err != nil {
+		return nil, err
+	}
+	cipherText, err := hex.DecodeString(k.Crypto.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := deriveKey(k.Crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(bsc.Keccak256(derivedKey[16:32], cipherText), mac) {
+		return nil, ErrDecrypt
+	}
+	privKey, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return nil, err
+	}
+
+	// the address is optional, but must match the key if present
+	address, err := Address(privKey)
+	if err != nil {
+		return nil, err
+	}
+	if k.Address != "" {
+		expected, err := hex.DecodeString(k.Address)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(expected, address[:]) {
+			return nil, fmt.Errorf("the key does not match the address %s of the keystore", k.Address)
+		}
+	}
+	return privKey, nil
+}
+
+func deriveKey(c cryptoJSON, passphrase string) ([]byte, error) {
+	salt, err := hex.DecodeString(kdfParamString(c.KDFParams, "salt"))
+	if err != nil {
+		return nil, err
+	}
+	dkLen := kdfParamInt(c.KDFParams, "dklen")
+	if dkLen < 32 {
+		return nil, fmt.Errorf("invalid derived key length %d", dkLen)
+	}
+
+	switch c.KDF {
+	case kdfScrypt:
+		n := kdfParamInt(c.KDFParams, "n")
+		r := kdfParamInt(c.KDFParams, "r")
+		p := kdfParamInt(c.KDFParams, "p")
+		return scrypt.Key([]byte(passphrase), salt, n, r, p, dkLen)
+	case kdfPBKDF2:
+		if prf := kdfParamString(c.KDFParams, "prf"); prf != prfHmacSHA256 {
+			return nil, fmt.Errorf("unsupported pbkdf2 pseudo random function %s", prf)
+		}
+		iterations := kdfParamInt(c.KDFParams, "c")
+		if iterations <= 0 {
+			return nil, fmt.Errorf("invalid pbkdf2 iteration count %d", iterations)
+		}
+		return pbkdf2.Key([]byte(passphrase), salt, iterations, dkLen, sha256.New), nil
+	}
+	return nil, fmt.Errorf("unsupported key derivation function %s", c.KDF)
+}
+
+// kdfParamInt reads an integer kdf param, which is a float64 once unmarshalled
+func kdfParamInt(params map[string]interface{}, name string) int {
+	if f, ok := params[name].(float64); ok {
+		return int(f)
+	}
+	return 0
+}
+
+func kdfParamString(params map[string]interface{}, name string) string {
+	if s, ok := params[name].(string); ok {
+		return s
+	}
+	return ""
+}
+
+func aesCTRXOR(key, in, iv []byte) ([]byte, error) {
+	aesBlock, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("invalid iv length %d, expected %d", len(iv), aes.BlockSize)
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(aesBlock, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// newUUID returns a random version 4 UUID, the id of a keystore
+func newUUID() (string, error) {
+	u, err := randomBytes(16)
+	if err != nil {
+		return "", err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
@@ -0,0 +1,46 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// the test vectors of the keystore v3 specification
+const (
+	pbkdf2KeyJSON  = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	scryptKeyJSON  = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"r":1,"p":8,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	testPassphrase = "testpassword"
+	testPrivKey    = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+	testAddress    = "008aeeda4d805471df9b2a5b0f38a0c3bcba786b"
+)
+
+func TestDecryptKey(t *testing.T) {
+	for _, keyJSON := range []string{pbkdf2KeyJSON, scryptKeyJSON} {
+		privKey, err := DecryptKey([]byte(keyJSON), testPassphrase)
+		require.Nil(t, err)
+		require.Equal(t, testPrivKey, hex.EncodeToString(privKey))
+
+		_, err = DecryptKey([]byte(keyJSON), "wrong")
+		require.Equal(t, ErrDecrypt, err)
+	}
+}
+
+func TestEncryptKey(t *testing.T) {
+	privKey, err := hex.DecodeString(testPrivKey)
+	require.Nil(t, err)
+	address, err := Address(privKey)
+	require.Nil(t, err)
+	require.Equal(t, testAddress, hex.EncodeToString(address[:]))
+
+	keyJSON, err := EncryptKey(privKey, testPassphrase, LightScryptN, LightScryptP)
+	require.Nil(t, err)
+	require.Contains(t, string(keyJSON), testAddress)
+	decrypted, err := DecryptKey(keyJSON, testPassphrase)
+	require.Nil(t, err)
+	require.Equal(t, privKey, decrypted)
+
+	_, err = EncryptKey(privKey[1:], testPassphrase, LightScryptN, LightScryptP)
+	require.NotNil(t, err)
+}
@@ -47,6 +47,8 @@ type Keybase interface {
 
 	// *only* works on locally-stored keys. Temporary method until we redo the exporting API
 	ExportPrivateKeyObject(name string, passphrase string) (crypto.PrivKey, error)
+	// Store a private key, e.g. decrypted from another keystore, as a local key encrypted with passphrase
+	ImportPrivateKeyObject(name string, priv crypto.PrivKey, passphrase string) (Info, error)
 
 	// Close closes the database.
 	CloseDB()
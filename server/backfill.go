@@ -0,0 +1,124 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+const (
+	flagColdSource  = "source"
+	flagColdDir     = "cold-dir"
+	flagFromVersion = "from"
+	flagToVersion   = "to"
+)
+
+// multistore of an app able to export and backfill its cold versions
+type coldVersionsStore interface {
+	ExportColdVersions(dir string, from, to int64) (int, error)
+	BackfillColdVersions(source store.ColdVersionSource, dir string, from, to int64, logger log.Logger) (int, error)
+}
+
+// ExportColdVersionsCmd writes the versions of the stores of an archive node to a directory, to be served
+// to the pruned nodes backfilling them with BackfillArchiveCmd
+func ExportColdVersionsCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-cold-versions [dir]",
+		Short: "Export the versions of the stores to a directory, for the pruned nodes to backfill them",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cms, from, to, err := openColdVersionsStore(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+			exported, err := cms.ExportColdVersions(args[0], from, to)
+			if err != nil {
+				return errors.Errorf("error exporting cold versions: %v\n", err)
+			}
+			fmt.Printf("exported %d versions of the stores from %d to %d\n", exported, from, to)
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagFromVersion, 1, "First version to export")
+	cmd.Flags().Int64(flagToVersion, 0, "Last version to export, 0 is the latest version")
+	return cmd
+}
+
+// BackfillArchiveCmd fetches the versions pruned from the stores from the export directory of an archive node,
+// verifies each of them against the commit info of the node and stores them as cold versions, so that the node
+// serves them once started with the cold versions directory, see baseapp.SetColdVersionsDir
+func BackfillArchiveCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backfill-archive",
+		Short: "Backfill the pruned versions of the stores from an archive node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sourceURI := viper.GetString(flagColdSource)
+			if sourceURI == "" {
+				return errors.New("the source of the cold versions is required")
+			}
+			var source store.ColdVersionSource
+			if strings.HasPrefix(sourceURI, "http://") || strings.HasPrefix(sourceURI, "https://") {
+				source = store.NewHTTPColdVersionSource(sourceURI)
+			} else {
+				source = store.NewDirColdVersionSource(sourceURI)
+			}
+			dir := viper.GetString(flagColdDir)
+			if dir == "" {
+				dir = filepath.Join(viper.GetString("home"), "data", "cold")
+			}
+
+			cms, from, to, err := openColdVersionsStore(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+			backfilled, err := cms.BackfillColdVersions(source, dir, from, to, ctx.Logger)
+			if err != nil {
+				return errors.Errorf("error backfilling cold versions: %v\n", err)
+			}
+			fmt.Printf("backfilled %d versions of the stores from %d to %d to %s\n", backfilled, from, to, dir)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagColdSource, "", "Export directory of an archive node, or http url serving it")
+	cmd.Flags().String(flagColdDir, "", "Directory the cold versions are stored to, defaults to <home>/data/cold")
+	cmd.Flags().Int64(flagFromVersion, 1, "First version to backfill")
+	cmd.Flags().Int64(flagToVersion, 0, "Last version to backfill, 0 is the latest version")
+	return cmd
+}
+
+// openColdVersionsStore loads the app and returns its multistore with the range of versions of the flags
+func openColdVersionsStore(ctx *Context, appCreator AppCreator) (coldVersionsStore, int64, int64, error) {
+	db, err := openDB(viper.GetString("home"))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	app := appCreator(ctx.Logger, db, nil)
+	withStore, ok := app.(interface {
+		GetCommitMultiStore() sdk.CommitMultiStore
+	})
+	if !ok {
+		return nil, 0, 0, errors.New("the app does not expose its multistore")
+	}
+	cms, ok := withStore.GetCommitMultiStore().(coldVersionsStore)
+	if !ok {
+		return nil, 0, 0, errors.New("the multistore of the app does not support cold versions")
+	}
+
+	from, to := viper.GetInt64(flagFromVersion), viper.GetInt64(flagToVersion)
+	if to == 0 {
+		to = withStore.GetCommitMultiStore().LastCommitID().Version
+	}
+	if from <= 0 || from > to {
+		return nil, 0, 0, errors.Errorf("invalid range of versions from %d to %d", from, to)
+	}
+	return cms, from, to, nil
+}
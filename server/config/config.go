@@ -2,6 +2,20 @@ package config
 
 // BaseConfig defines the server's basic configuration
 type BaseConfig struct {
+	// CheckTxRateLimit is the number of txs of an account accepted by CheckTx within
+	// CheckTxRateLimitBlocks blocks, 0 disables the limit
+	CheckTxRateLimit       int   `mapstructure:"check_tx_rate_limit"`
+	CheckTxRateLimitBlocks int64 `mapstructure:"check_tx_rate_limit_blocks"`
+
+	// HaltHeight is the height of the block after which the node stops, and HaltTime the unix
+	// time at which it stops, after committing the first block reaching it, 0 disables them
+	HaltHeight int64 `mapstructure:"halt_height"`
+	HaltTime   int64 `mapstructure:"halt_time"`
+
+	// SnapshotInterval is the number of blocks between two state sync snapshots, 0 disables
+	// them, and SnapshotKeepRecent the number of recent snapshots kept, 0 keeps them all
+	SnapshotInterval   int64 `mapstructure:"snapshot_interval"`
+	SnapshotKeepRecent int   `mapstructure:"snapshot_keep_recent"`
 }
 
 // Config defines the server's top level configuration
@@ -10,7 +24,14 @@ type Config struct {
 }
 
 func DefaultConfig() *Config {
-	return &Config{BaseConfig{}}
+	return &Config{BaseConfig{
+		CheckTxRateLimit:       0,
+		CheckTxRateLimitBlocks: 1,
+		HaltHeight:             0,
+		HaltTime:               0,
+		SnapshotInterval:       0,
+		SnapshotKeepRecent:     0,
+	}}
 }
 
 // Storage for init gen-tx command input parameters
@@ -13,6 +13,21 @@ const defaultConfigTemplate = `# This is a TOML config file.
 
 ##### main base config options #####
 
+# Number of txs of an account accepted by CheckTx within check_tx_rate_limit_blocks blocks,
+# 0 disables the limit. It only protects the mempool of the node, DeliverTx is never limited.
+check_tx_rate_limit = {{ .BaseConfig.CheckTxRateLimit }}
+check_tx_rate_limit_blocks = {{ .BaseConfig.CheckTxRateLimitBlocks }}
+
+# Height of the block after which the node gracefully stops, and unix time at which it stops, after
+# committing the first block whose header time reaches it, to coordinate a maintenance. The first
+# one reached halts the node, 0 disables the halt.
+halt_height = {{ .BaseConfig.HaltHeight }}
+halt_time = {{ .BaseConfig.HaltTime }}
+
+# Number of blocks between two state sync snapshots, 0 disables them, and number of recent
+# snapshots kept, 0 keeps them all. The state of a snapshot is not pruned until it is taken.
+snapshot_interval = {{ .BaseConfig.SnapshotInterval }}
+snapshot_keep_recent = {{ .BaseConfig.SnapshotKeepRecent }}
 `
 
 var configTemplate *template.Template
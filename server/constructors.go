@@ -20,6 +20,11 @@ type (
 	// AppExporter is a function that dumps all app state to
 	// JSON-serializable structure and returns the current validator set.
 	AppExporter func(log.Logger, dbm.DB, io.Writer) (json.RawMessage, []tmtypes.GenesisValidator, error)
+
+	// StreamingAppExporter is the streaming version of AppExporter, it returns the current
+	// validator set and a function writing the app state as JSON, e.g. with ModuleManager.ExportGenesisToWriter,
+	// so that the app state never has to be held in memory.
+	StreamingAppExporter func(log.Logger, dbm.DB, io.Writer) (validators []tmtypes.GenesisValidator, writeAppState func(w io.Writer) error, err error)
 )
 
 func openDB(rootDir string) (dbm.DB, error) {
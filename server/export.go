@@ -1,7 +1,10 @@
 package server
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/pkg/errors"
@@ -21,22 +24,11 @@ func ExportCmd(ctx *Context, cdc *codec.Codec, appExporter AppExporter) *cobra.C
 		RunE: func(cmd *cobra.Command, args []string) error {
 			home := viper.GetString("home")
 			traceWriterFile := viper.GetString(flagTraceStore)
-			emptyState, err := isEmptyState(home)
-			if err != nil {
+			emptyState, err := printGenesisIfEmptyState(home)
+			if err != nil || emptyState {
 				return err
 			}
 
-			if emptyState {
-				fmt.Println("WARNING: State is not initialized. Returning genesis file.")
-				genesisFile := path.Join(home, "config", "genesis.json")
-				genesis, err := os.ReadFile(genesisFile)
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(genesis))
-				return nil
-			}
-
 			db, err := openDB(home)
 			if err != nil {
 				return err
@@ -69,6 +61,92 @@ func ExportCmd(ctx *Context, cdc *codec.Codec, appExporter AppExporter) *cobra.C
 	}
 }
 
+// StreamingExportCmd dumps app state to JSON like ExportCmd, but the app state
+// is written to stdout while being exported instead of being built in memory.
+func StreamingExportCmd(ctx *Context, cdc *codec.Codec, appExporter StreamingAppExporter) *cobra.Command {
+	return &cobra.Command{
+		Use:   "export",
+		Short: "Export state to JSON",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			home := viper.GetString("home")
+			traceWriterFile := viper.GetString(flagTraceStore)
+			emptyState, err := printGenesisIfEmptyState(home)
+			if err != nil || emptyState {
+				return err
+			}
+
+			db, err := openDB(home)
+			if err != nil {
+				return err
+			}
+			traceWriter, err := openTraceWriter(traceWriterFile)
+			if err != nil {
+				return err
+			}
+			validators, writeAppState, err := appExporter(ctx.Logger, db, traceWriter)
+			if err != nil {
+				return errors.Errorf("error exporting state: %v\n", err)
+			}
+
+			doc, err := tmtypes.GenesisDocFromFile(ctx.Config.GenesisFile())
+			if err != nil {
+				return err
+			}
+			doc.Validators = validators
+
+			out := bufio.NewWriter(os.Stdout)
+			if err := writeGenesisDoc(cdc, doc, writeAppState, out); err != nil {
+				return errors.Errorf("error exporting state: %v\n", err)
+			}
+			return out.Flush()
+		},
+	}
+}
+
+// writeGenesisDoc writes doc as indented JSON, with the app state written by writeAppState as its last field
+func writeGenesisDoc(cdc *codec.Codec, doc *tmtypes.GenesisDoc, writeAppState func(w io.Writer) error, w io.Writer) error {
+	doc.AppState = nil
+	encoded, err := codec.MarshalJSONIndent(cdc, doc)
+	if err != nil {
+		return err
+	}
+
+	// reopen the encoded object to append the app state
+	encoded = bytes.TrimRight(encoded, " \n")
+	if len(encoded) == 0 || encoded[len(encoded)-1] != '}' {
+		return errors.New("unexpected encoding of the genesis doc")
+	}
+	encoded = bytes.TrimRight(encoded[:len(encoded)-1], " \n")
+	if _, err := w.Write(encoded); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, ",\n  \"app_state\": "); err != nil {
+		return err
+	}
+	if err := writeAppState(w); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n}\n")
+	return err
+}
+
+// printGenesisIfEmptyState prints the genesis file instead of the state if the state is not initialized
+func printGenesisIfEmptyState(home string) (bool, error) {
+	emptyState, err := isEmptyState(home)
+	if err != nil || !emptyState {
+		return false, err
+	}
+
+	fmt.Println("WARNING: State is not initialized. Returning genesis file.")
+	genesisFile := path.Join(home, "config", "genesis.json")
+	genesis, err := os.ReadFile(genesisFile)
+	if err != nil {
+		return true, err
+	}
+	fmt.Println(string(genesis))
+	return true, nil
+}
+
 func isEmptyState(home string) (bool, error) {
 	files, err := os.ReadDir(path.Join(home, "data"))
 	if err != nil {
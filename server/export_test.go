@@ -0,0 +1,30 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+func TestWriteGenesisDoc(t *testing.T) {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+
+	doc := &tmtypes.GenesisDoc{ChainID: "test-chain", AppState: []byte(`{"stale":true}`)}
+	var buf bytes.Buffer
+	err := writeGenesisDoc(cdc, doc, func(w io.Writer) error {
+		_, err := io.WriteString(w, `{"accounts":[1,2]}`)
+		return err
+	}, &buf)
+	require.NoError(t, err)
+
+	exported, err := tmtypes.GenesisDocFromJSON(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "test-chain", exported.ChainID)
+	require.JSONEq(t, `{"accounts":[1,2]}`, string(exported.AppState))
+}
@@ -82,6 +82,18 @@ func (ms multiStore) LoadVersion(ver int64) error {
 	panic("not implemented")
 }
 
+func (ms multiStore) EarliestVersion() int64 {
+	panic("not implemented")
+}
+
+func (ms multiStore) PinVersion(version int64) {
+	panic("not implemented")
+}
+
+func (ms multiStore) UnpinVersion(version int64) {
+	panic("not implemented")
+}
+
 func (ms multiStore) GetKVStore(key sdk.StoreKey) sdk.KVStore {
 	return ms.kv[key]
 }
@@ -0,0 +1,451 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	sm "github.com/tendermint/tendermint/state"
+	tmstore "github.com/tendermint/tendermint/store"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	flagAppA     = "app-a"
+	flagAppB     = "app-b"
+	flagTraceA   = "trace-a"
+	flagTraceB   = "trace-b"
+	flagMaxDiffs = "max-diffs"
+)
+
+// stages of the replay of a block
+const (
+	ReplayStageInitChain   = "init_chain"
+	ReplayStageBeginBlock  = "begin_block"
+	ReplayStageDeliverTx   = "deliver_tx"
+	ReplayStageEndBlock    = "end_block"
+	ReplayStageCommit      = "commit"
+	ReplayStageStoreWrites = "store_writes"
+)
+
+// ReplayDiff is a difference between the results of the two apps replaying the same block
+type ReplayDiff struct {
+	Height  int64  `json:"height"`
+	Stage   string `json:"stage"`
+	TxIndex int    `json:"tx_index,omitempty"` // index of the tx in the block, for the deliver_tx stage
+	A       string `json:"a"`
+	B       string `json:"b"`
+}
+
+// ReplayBlockSource is the stream of blocks replayed, e.g. the block store of a node
+type ReplayBlockSource interface {
+	Height() int64
+	LoadBlock(height int64) *tmtypes.Block
+}
+
+// ReplayDriver replays the same blocks on two ABCI apps, e.g. two binaries of an app, and diffs their per-tx
+// results, their events, their app hashes and, given their trace stores, their store writes, to check that a
+// refactor is bit-for-bit compatible before it is rolled out. The apps must start from the same state.
+type ReplayDriver struct {
+	logger   log.Logger
+	apps     [2]abcicli.Client
+	traces   [2]*replayTrace // nil unless WithTraces is called
+	stateDB  dbm.DB          // nil unless WithStateDB is called
+	maxDiffs int
+}
+
+func NewReplayDriver(logger log.Logger, appA, appB abcicli.Client) *ReplayDriver {
+	return &ReplayDriver{
+		logger: logger,
+		apps:   [2]abcicli.Client{appA, appB},
+	}
+}
+
+// WithTraces makes the driver diff the store writes of the apps, read from their trace stores from the
+// current position of the readers
+func (d *ReplayDriver) WithTraces(traceA, traceB io.Reader) *ReplayDriver {
+	d.traces = [2]*replayTrace{newReplayTrace(traceA), newReplayTrace(traceB)}
+	return d
+}
+
+// WithStateDB gives the driver the state of the node the blocks come from, to send the last commit info and
+// the evidence of the blocks to the apps as the node did. Without it, the blocks are replayed without them.
+func (d *ReplayDriver) WithStateDB(stateDB dbm.DB) *ReplayDriver {
+	d.stateDB = stateDB
+	return d
+}
+
+// WithMaxDiffs makes the driver stop after the block where maxDiffs differences are reached, 0 never stops.
+// Once the apps diverge, their states differ and most of the next blocks differ too.
+func (d *ReplayDriver) WithMaxDiffs(maxDiffs int) *ReplayDriver {
+	d.maxDiffs = maxDiffs
+	return d
+}
+
+// Replay replays the blocks of source after the last block of the apps up to height to, the apps are
+// initialized with genesis first if they have no block yet
+func (d *ReplayDriver) Replay(genesis *tmtypes.GenesisDoc, source ReplayBlockSource, to int64) ([]ReplayDiff, error) {
+	var heights [2]int64
+	for i, app := range d.apps {
+		res, err := app.InfoSync(abci.RequestInfo{})
+		if err != nil {
+			return nil, err
+		}
+		heights[i] = res.LastBlockHeight
+	}
+	if heights[0] != heights[1] {
+		return nil, fmt.Errorf("the apps are at different heights %d and %d", heights[0], heights[1])
+	}
+	if to > source.Height() {
+		return nil, fmt.Errorf("the last block is %d", source.Height())
+	}
+
+	diffs := make([]ReplayDiff, 0)
+	if heights[0] == 0 {
+		initDiffs, err := d.initChain(genesis)
+		if err != nil {
+			return diffs, err
+		}
+		diffs = append(diffs, initDiffs...)
+	}
+	for height := heights[0] + 1; height <= to; height++ {
+		if d.maxDiffs > 0 && len(diffs) >= d.maxDiffs {
+			break
+		}
+		block := source.LoadBlock(height)
+		if block == nil {
+			return diffs, fmt.Errorf("block %d not found", height)
+		}
+		blockDiffs, err := d.replayBlock(block)
+		if err != nil {
+			return diffs, err
+		}
+		diffs = append(diffs, blockDiffs...)
+		d.logger.Info("Replayed block", "height", height, "txs", len(block.Txs), "diffs", len(blockDiffs))
+	}
+	return diffs, nil
+}
+
+func (d *ReplayDriver) initChain(genesis *tmtypes.GenesisDoc) ([]ReplayDiff, error) {
+	validators := make([]*tmtypes.Validator, len(genesis.Validators))
+	for i, val := range genesis.Validators {
+		validators[i] = tmtypes.NewValidator(val.PubKey, val.Power)
+	}
+	req := abci.RequestInitChain{
+		Time:            genesis.GenesisTime,
+		ChainId:         genesis.ChainID,
+		ConsensusParams: tmtypes.TM2PB.ConsensusParams(genesis.ConsensusParams),
+		Validators:      tmtypes.TM2PB.ValidatorUpdates(tmtypes.NewValidatorSet(validators)),
+		AppStateBytes:   genesis.AppState,
+	}
+
+	var res [2]*abci.ResponseInitChain
+	for i, app := range d.apps {
+		var err error
+		if res[i], err = app.InitChainSync(req); err != nil {
+			return nil, err
+		}
+	}
+	diffs := make([]ReplayDiff, 0)
+	diffs = appendReplayDiff(diffs, 0, ReplayStageInitChain, 0, res[0], res[1])
+	return d.appendStoreWritesDiff(diffs, 0)
+}
+
+func (d *ReplayDriver) replayBlock(block *tmtypes.Block) ([]ReplayDiff, error) {
+	diffs := make([]ReplayDiff, 0)
+	req, err := d.beginBlockRequest(block)
+	if err != nil {
+		return nil, err
+	}
+	var resBegin [2]*abci.ResponseBeginBlock
+	for i, app := range d.apps {
+		if resBegin[i], err = app.BeginBlockSync(req); err != nil {
+			return nil, err
+		}
+	}
+	diffs = appendReplayDiff(diffs, block.Height, ReplayStageBeginBlock, 0, resBegin[0], resBegin[1])
+
+	for txIndex, tx := range block.Txs {
+		var resDeliver [2]*abci.ResponseDeliverTx
+		for i, app := range d.apps {
+			if resDeliver[i], err = app.DeliverTxSync(abci.RequestDeliverTx{Tx: tx}); err != nil {
+				return nil, err
+			}
+		}
+		diffs = appendReplayDiff(diffs, block.Height, ReplayStageDeliverTx, txIndex, resDeliver[0], resDeliver[1])
+	}
+
+	var resEnd [2]*abci.ResponseEndBlock
+	for i, app := range d.apps {
+		if resEnd[i], err = app.EndBlockSync(abci.RequestEndBlock{Height: block.Height}); err != nil {
+			return nil, err
+		}
+	}
+	diffs = appendReplayDiff(diffs, block.Height, ReplayStageEndBlock, 0, resEnd[0], resEnd[1])
+
+	var resCommit [2]*abci.ResponseCommit
+	for i, app := range d.apps {
+		if resCommit[i], err = app.CommitSync(); err != nil {
+			return nil, err
+		}
+	}
+	diffs = appendReplayDiff(diffs, block.Height, ReplayStageCommit, 0, resCommit[0], resCommit[1])
+	return d.appendStoreWritesDiff(diffs, block.Height)
+}
+
+// beginBlockRequest returns the begin block request tendermint sends to the app for block, see
+// getBeginBlockValidatorInfo in the state package of tendermint
+func (d *ReplayDriver) beginBlockRequest(block *tmtypes.Block) (abci.RequestBeginBlock, error) {
+	req := abci.RequestBeginBlock{
+		Hash:   block.Hash(),
+		Header: tmtypes.TM2PB.Header(&block.Header),
+	}
+	if d.stateDB == nil {
+		return req, nil
+	}
+
+	votes := make([]abci.VoteInfo, block.LastCommit.Size())
+	if block.Height > 1 {
+		lastValSet, err := sm.LoadValidators(d.stateDB, block.Height-1)
+		if err != nil {
+			return req, err
+		}
+		if len(lastValSet.Validators) != block.LastCommit.Size() {
+			return req, fmt.Errorf("the last commit of block %d has %d precommits for %d validators",
+				block.Height, block.LastCommit.Size(), len(lastValSet.Validators))
+		}
+		for i, val := range lastValSet.Validators {
+			votes[i] = abci.VoteInfo{
+				Validator:       tmtypes.TM2PB.Validator(val),
+				SignedLastBlock: block.LastCommit.Precommits[i] != nil,
+			}
+		}
+	}
+	byzVals := make([]abci.Evidence, len(block.Evidence.Evidence))
+	for i, ev := range block.Evidence.Evidence {
+		valSet, err := sm.LoadValidators(d.stateDB, ev.Height())
+		if err != nil {
+			return req, err
+		}
+		byzVals[i] = tmtypes.TM2PB.Evidence(ev, valSet, block.Time)
+	}
+	req.LastCommitInfo = abci.LastCommitInfo{
+		Round: int32(block.LastCommit.Round()),
+		Votes: votes,
+	}
+	req.ByzantineValidators = byzVals
+	return req, nil
+}
+
+// appendStoreWritesDiff diffs the store writes traced by the apps since the last call, the writes of a block
+// are compared regardless of their order, as a refactor may reorder the writes to different keys
+func (d *ReplayDriver) appendStoreWritesDiff(diffs []ReplayDiff, height int64) ([]ReplayDiff, error) {
+	if d.traces[0] == nil {
+		return diffs, nil
+	}
+	var writes [2][]string
+	for i, trace := range d.traces {
+		var err error
+		if writes[i], err = trace.readWrites(); err != nil {
+			return diffs, err
+		}
+	}
+	onlyA, onlyB := diffSortedStrings(writes[0], writes[1])
+	if len(onlyA) == 0 && len(onlyB) == 0 {
+		return diffs, nil
+	}
+	return append(diffs, ReplayDiff{
+		Height: height,
+		Stage:  ReplayStageStoreWrites,
+		A:      summarizeReplayWrites(onlyA),
+		B:      summarizeReplayWrites(onlyB),
+	}), nil
+}
+
+func appendReplayDiff(diffs []ReplayDiff, height int64, stage string, txIndex int, a, b interface{}) []ReplayDiff {
+	bzA, errA := json.Marshal(a)
+	bzB, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		panic(fmt.Sprintf("failed to serialize the abci responses: %v, %v", errA, errB))
+	}
+	if bytes.Equal(bzA, bzB) {
+		return diffs
+	}
+	return append(diffs, ReplayDiff{
+		Height:  height,
+		Stage:   stage,
+		TxIndex: txIndex,
+		A:       string(bzA),
+		B:       string(bzB),
+	})
+}
+
+// diffSortedStrings returns the elements of the sorted slices a and b missing from the other one
+func diffSortedStrings(a, b []string) (onlyA, onlyB []string) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case a[i] < b[j]:
+			onlyA = append(onlyA, a[i])
+			i++
+		default:
+			onlyB = append(onlyB, b[j])
+			j++
+		}
+	}
+	return append(onlyA, a[i:]...), append(onlyB, b[j:]...)
+}
+
+func summarizeReplayWrites(writes []string) string {
+	const maxShown = 3
+	if len(writes) <= maxShown {
+		return fmt.Sprintf("%d writes only here: %v", len(writes), writes)
+	}
+	return fmt.Sprintf("%d writes only here: %v ...", len(writes), writes[:maxShown])
+}
+
+// replayTrace reads the store writes of an app from its trace store, see store.TraceKVStore
+type replayTrace struct {
+	r       *bufio.Reader
+	pending []byte // start of a line whose end is not written yet
+}
+
+func newReplayTrace(r io.Reader) *replayTrace {
+	return &replayTrace{r: bufio.NewReader(r)}
+}
+
+// readWrites returns the sorted writes and deletes traced since the last call, as
+// "<tx hash> <operation> <key> <value>" strings
+func (trace *replayTrace) readWrites() ([]string, error) {
+	writes := make([]string, 0)
+	for {
+		line, err := trace.r.ReadBytes('\n')
+		if err == io.EOF {
+			trace.pending = append(trace.pending, line...)
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(trace.pending) > 0 {
+			line = append(trace.pending, line...)
+			trace.pending = nil
+		}
+
+		var op struct {
+			Operation string                 `json:"operation"`
+			Key       string                 `json:"key"`
+			Value     string                 `json:"value"`
+			Metadata  map[string]interface{} `json:"metadata"`
+		}
+		if err := json.Unmarshal(line, &op); err != nil {
+			return nil, errors.Errorf("invalid trace line %q: %v", line, err)
+		}
+		if op.Operation != "write" && op.Operation != "delete" {
+			continue
+		}
+		writes = append(writes, fmt.Sprintf("%v %s %s %s", op.Metadata["txHash"], op.Operation, op.Key, op.Value))
+	}
+	sort.Strings(writes)
+	return writes, nil
+}
+
+// ReplayDiffCmd replays the blocks of the node on two binaries of the app and prints the differences of their
+// results. The node must be stopped. Each binary runs stand-alone on its own copy of the same initial state, e.g.
+// `start --with-tendermint=false --address tcp://127.0.0.1:26660 --trace-store trace-a.log --home home-a`.
+func ReplayDiffCmd(ctx *Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay-diff",
+		Short: "Replay the blocks of the node on two binaries of the app and diff their results",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dataDir := filepath.Join(viper.GetString("home"), "data")
+			blockDB, err := dbm.NewGoLevelDB("blockstore", dataDir)
+			if err != nil {
+				return err
+			}
+			defer blockDB.Close()
+			stateDB, err := dbm.NewGoLevelDB("state", dataDir)
+			if err != nil {
+				return err
+			}
+			defer stateDB.Close()
+			genesis, err := tmtypes.GenesisDocFromFile(ctx.Config.GenesisFile())
+			if err != nil {
+				return err
+			}
+
+			var apps [2]abcicli.Client
+			for i, flag := range []string{flagAppA, flagAppB} {
+				apps[i], err = abcicli.NewClient(viper.GetString(flag), "socket", true)
+				if err != nil {
+					return err
+				}
+				apps[i].SetLogger(ctx.Logger.With("module", "abci-client", "app", flag))
+				if err := apps[i].Start(); err != nil {
+					return errors.Errorf("error connecting to the app %s: %v\n", flag, err)
+				}
+				defer apps[i].Stop()
+			}
+
+			blockStore := tmstore.NewBlockStore(blockDB)
+			driver := NewReplayDriver(ctx.Logger, apps[0], apps[1]).
+				WithStateDB(stateDB).
+				WithMaxDiffs(viper.GetInt(flagMaxDiffs))
+			if traceA, traceB := viper.GetString(flagTraceA), viper.GetString(flagTraceB); traceA != "" || traceB != "" {
+				var traces [2]*os.File
+				for i, file := range []string{traceA, traceB} {
+					if traces[i], err = os.Open(file); err != nil {
+						return err
+					}
+					defer traces[i].Close()
+					// the writes traced before the replay are skipped
+					if _, err := traces[i].Seek(0, io.SeekEnd); err != nil {
+						return err
+					}
+				}
+				driver = driver.WithTraces(traces[0], traces[1])
+			}
+
+			to := viper.GetInt64(flagToVersion)
+			if to == 0 {
+				to = blockStore.Height()
+			}
+			diffs, err := driver.Replay(genesis, blockStore, to)
+			for _, diff := range diffs {
+				bz, _ := json.Marshal(diff)
+				fmt.Println(string(bz))
+			}
+			if err != nil {
+				return errors.Errorf("error replaying the blocks: %v\n", err)
+			}
+			if len(diffs) > 0 {
+				return errors.Errorf("the apps diverged, %d differences\n", len(diffs))
+			}
+			fmt.Printf("the apps replayed the blocks up to %d identically\n", to)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagAppA, "tcp://127.0.0.1:26660", "ABCI address of the first binary")
+	cmd.Flags().String(flagAppB, "tcp://127.0.0.1:26661", "ABCI address of the second binary")
+	cmd.Flags().String(flagTraceA, "", "Trace store file of the first binary, to diff the store writes")
+	cmd.Flags().String(flagTraceB, "", "Trace store file of the second binary, to diff the store writes")
+	cmd.Flags().Int(flagMaxDiffs, 10, "Stop after the block where this number of differences is reached, 0 never stops")
+	cmd.Flags().Int64(flagToVersion, 0, "Last block to replay, 0 is the last block of the node")
+	return cmd
+}
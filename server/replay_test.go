@@ -0,0 +1,108 @@
+package server
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abcicli "github.com/tendermint/tendermint/abci/client"
+	"github.com/tendermint/tendermint/abci/example/kvstore"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+type replayBlocks []*tmtypes.Block
+
+func (blocks replayBlocks) Height() int64 {
+	return int64(len(blocks))
+}
+
+func (blocks replayBlocks) LoadBlock(height int64) *tmtypes.Block {
+	return blocks[height-1]
+}
+
+// divergingApp fails the txs of a key the kvstore app accepts
+type divergingApp struct {
+	*kvstore.KVStoreApplication
+	key string
+}
+
+func (app divergingApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	if strings.HasPrefix(string(req.Tx), app.key+"=") {
+		return abci.ResponseDeliverTx{Code: 1, Log: "diverged"}
+	}
+	return app.KVStoreApplication.DeliverTx(req)
+}
+
+func startReplayClient(t *testing.T, app abci.Application) abcicli.Client {
+	client := abcicli.NewLocalClient(nil, app)
+	require.Nil(t, client.Start())
+	return client
+}
+
+func TestReplayDriver(t *testing.T) {
+	blocks := replayBlocks{
+		tmtypes.MakeBlock(1, []tmtypes.Tx{tmtypes.Tx("a=1"), tmtypes.Tx("b=2")}, nil, nil),
+		tmtypes.MakeBlock(2, []tmtypes.Tx{tmtypes.Tx("c=3")}, nil, nil),
+		tmtypes.MakeBlock(3, []tmtypes.Tx{tmtypes.Tx("a=4")}, nil, nil),
+	}
+	genesis := &tmtypes.GenesisDoc{ChainID: "replay-chain"}
+
+	// the same app replays the blocks identically
+	driver := NewReplayDriver(log.NewNopLogger(),
+		startReplayClient(t, kvstore.NewKVStoreApplication()), startReplayClient(t, kvstore.NewKVStoreApplication()))
+	diffs, err := driver.Replay(genesis, blocks, blocks.Height())
+	require.Nil(t, err)
+	require.Empty(t, diffs)
+
+	// the apps must start at the same height
+	_, err = NewReplayDriver(log.NewNopLogger(), startReplayClient(t, kvstore.NewKVStoreApplication()), driver.apps[0]).
+		Replay(genesis, blocks, blocks.Height())
+	require.NotNil(t, err)
+
+	// the first tx result and the app hash of a diverging app differ
+	driver = NewReplayDriver(log.NewNopLogger(), startReplayClient(t, kvstore.NewKVStoreApplication()),
+		startReplayClient(t, divergingApp{kvstore.NewKVStoreApplication(), "b"}))
+	diffs, err = driver.Replay(genesis, blocks, blocks.Height())
+	require.Nil(t, err)
+	require.True(t, len(diffs) >= 2)
+	require.Equal(t, ReplayDiff{Height: 1, Stage: ReplayStageDeliverTx, TxIndex: 1, A: diffs[0].A, B: diffs[0].B}, diffs[0])
+	require.Contains(t, diffs[0].B, "diverged")
+	require.Equal(t, int64(1), diffs[1].Height)
+	require.Equal(t, ReplayStageCommit, diffs[1].Stage)
+
+	// the replay stops after the block reaching the max number of differences
+	driver = NewReplayDriver(log.NewNopLogger(), startReplayClient(t, kvstore.NewKVStoreApplication()),
+		startReplayClient(t, divergingApp{kvstore.NewKVStoreApplication(), "a"})).WithMaxDiffs(1)
+	diffs, err = driver.Replay(genesis, blocks, blocks.Height())
+	require.Nil(t, err)
+	require.Equal(t, int64(1), diffs[len(diffs)-1].Height)
+}
+
+func TestReplayTraceWrites(t *testing.T) {
+	traceA := bytes.NewBufferString(`{"operation":"write","key":"YQ==","value":"MQ==","metadata":{"txHash":"AA"}}
+{"operation":"read","key":"Yg==","value":"","metadata":{"txHash":"AA"}}
+{"operation":"delete","key":"Yw==","value":"","metadata":{"blockHeight":1}}
+`)
+	traceB := bytes.NewBufferString(`{"operation":"delete","key":"Yw==","value":"","metadata":{"blockHeight":1}}
+{"operation":"write","key":"YQ==","value":"Mg==","metadata":{"txHash":"AA"}}
+{"operation":"write","key":"ZA==","value"`)
+
+	driver := NewReplayDriver(log.NewNopLogger(), nil, nil).WithTraces(traceA, traceB)
+	diffs, err := driver.appendStoreWritesDiff(nil, 1)
+	require.Nil(t, err)
+	require.Len(t, diffs, 1)
+	require.Equal(t, ReplayStageStoreWrites, diffs[0].Stage)
+	require.Contains(t, diffs[0].A, "AA write YQ== MQ==")
+	require.Contains(t, diffs[0].B, "AA write YQ== Mg==")
+	require.NotContains(t, diffs[0].A, "read")
+
+	// the write of a line not ended yet is read once it is complete
+	traceA.WriteString(`{"operation":"write","key":"ZA==","value":"NA==","metadata":{"txHash":"BB"}}` + "\n")
+	traceB.WriteString(`:"NA==","metadata":{"txHash":"BB"}}` + "\n")
+	diffs, err = driver.appendStoreWritesDiff(nil, 2)
+	require.Nil(t, err)
+	require.Empty(t, diffs)
+}
@@ -22,7 +22,14 @@ const (
 	flagAddress        = "address"
 	flagTraceStore     = "trace-store"
 	flagPruning        = "pruning"
+	flagKeepRecent     = "pruning-keep-recent"
+	flagKeepEvery      = "pruning-keep-every"
+	flagPruneInterval  = "pruning-interval"
 	flagSequentialABCI = "seq-abci"
+	flagHaltHeight     = "halt_height"
+	flagHaltTime       = "halt_time"
+	flagSnapInterval   = "snapshot_interval"
+	flagSnapKeepRecent = "snapshot_keep_recent"
 )
 
 var BlockStore *tmstore.BlockStore
@@ -51,7 +58,14 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
 	cmd.Flags().Bool(flagSequentialABCI, false, "Run abci app in sync mode")
-	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
+	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything, custom")
+	cmd.Flags().Int64(flagKeepRecent, 0, "Number of recent states kept by the custom pruning strategy")
+	cmd.Flags().Int64(flagKeepEvery, 0, "Distance between the states kept forever by the custom pruning strategy, 0 keeps none")
+	cmd.Flags().Int64(flagPruneInterval, 1, "Number of blocks between two prunings of the custom pruning strategy")
+	cmd.Flags().Int64(flagHaltHeight, 0, "Height of the block after which the node stops, 0 disables the halt")
+	cmd.Flags().Int64(flagHaltTime, 0, "Unix time at which the node stops after committing the block reaching it, 0 disables the halt")
+	cmd.Flags().Int64(flagSnapInterval, 0, "Number of blocks between two state sync snapshots, 0 disables the snapshots")
+	cmd.Flags().Int(flagSnapKeepRecent, 0, "Number of recent state sync snapshots kept, 0 keeps them all")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
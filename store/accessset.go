@@ -0,0 +1,152 @@
+package store
+
+import (
+	"bytes"
+	"sync"
+)
+
+// AccessSet is the set of the keys read from and written to the parent of a cache-wrapped
+// multistore, see CacheMultiStoreWithAccessSet. The keys are grouped by store name.
+type AccessSet struct {
+	mtx    sync.Mutex
+	reads  map[string]map[string]struct{}
+	ranges map[string][]accessRange
+	writes map[string]map[string]struct{}
+}
+
+// accessRange is an iterated domain, a nil start or end is unbounded
+type accessRange struct {
+	start, end []byte
+}
+
+func (r accessRange) contains(key []byte) bool {
+	return (r.start == nil || bytes.Compare(key, r.start) >= 0) &&
+		(r.end == nil || bytes.Compare(key, r.end) < 0)
+}
+
+// NewAccessSet returns an empty AccessSet
+func NewAccessSet() *AccessSet {
+	return &AccessSet{
+		reads:  make(map[string]map[string]struct{}),
+		ranges: make(map[string][]accessRange),
+		writes: make(map[string]map[string]struct{}),
+	}
+}
+
+func addKey(keys map[string]map[string]struct{}, store string, key []byte) {
+	storeKeys, ok := keys[store]
+	if !ok {
+		storeKeys = make(map[string]struct{})
+		keys[store] = storeKeys
+	}
+	storeKeys[string(key)] = struct{}{}
+}
+
+// RecordRead records a read of key from store
+func (s *AccessSet) RecordRead(store string, key []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	addKey(s.reads, store, key)
+}
+
+// RecordIteration records an iteration over the domain [start, end) of store
+func (s *AccessSet) RecordIteration(store string, start, end []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.ranges[store] = append(s.ranges[store], accessRange{start: cp(start), end: cp(end)})
+}
+
+// RecordWrite records a write or a delete of key in store
+func (s *AccessSet) RecordWrite(store string, key []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	addKey(s.writes, store, key)
+}
+
+// AddWrites adds the writes recorded in other to s
+func (s *AccessSet) AddWrites(other *AccessSet) {
+	other.mtx.Lock()
+	defer other.mtx.Unlock()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for store, keys := range other.writes {
+		for key := range keys {
+			addKey(s.writes, store, []byte(key))
+		}
+	}
+}
+
+// ReadsWrittenBy returns whether s has read a key, or iterated over a domain containing
+// a key, written in other
+func (s *AccessSet) ReadsWrittenBy(other *AccessSet) bool {
+	other.mtx.Lock()
+	defer other.mtx.Unlock()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for store, keys := range other.writes {
+		reads, ranges := s.reads[store], s.ranges[store]
+		for key := range keys {
+			if _, ok := reads[key]; ok {
+				return true
+			}
+			for _, r := range ranges {
+				if r.contains([]byte(key)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// accessKVStore records the keys read from and written to its parent
+type accessKVStore struct {
+	KVStore
+	name string
+	set  *AccessSet
+}
+
+// Get implements KVStore
+func (as accessKVStore) Get(key []byte) []byte {
+	as.set.RecordRead(as.name, key)
+	return as.KVStore.Get(key)
+}
+
+// Has implements KVStore
+func (as accessKVStore) Has(key []byte) bool {
+	as.set.RecordRead(as.name, key)
+	return as.KVStore.Has(key)
+}
+
+// Set implements KVStore
+func (as accessKVStore) Set(key, value []byte) {
+	as.set.RecordWrite(as.name, key)
+	as.KVStore.Set(key, value)
+}
+
+// Delete implements KVStore
+func (as accessKVStore) Delete(key []byte) {
+	as.set.RecordWrite(as.name, key)
+	as.KVStore.Delete(key)
+}
+
+// Iterator implements KVStore
+func (as accessKVStore) Iterator(start, end []byte) Iterator {
+	as.set.RecordIteration(as.name, start, end)
+	return as.KVStore.Iterator(start, end)
+}
+
+// ReverseIterator implements KVStore
+func (as accessKVStore) ReverseIterator(start, end []byte) Iterator {
+	as.set.RecordIteration(as.name, start, end)
+	return as.KVStore.ReverseIterator(start, end)
+}
+
+// CacheMultiStoreWithAccessSet cache-wraps ms like ms.CacheMultiStore(), and records in set
+// the keys the cache reads from ms, and the keys it writes to ms on Write. Two caches of the
+// same ms can be used concurrently as long as nothing writes to ms.
+func CacheMultiStoreWithAccessSet(ms MultiStore, set *AccessSet) CacheMultiStore {
+	return cacheMultiStoreWithParents(ms, "access set", func(key StoreKey, parent KVStore) KVStore {
+		return accessKVStore{KVStore: parent, name: key.Name(), set: set}
+	})
+}
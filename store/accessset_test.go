@@ -0,0 +1,44 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessSetReadsWrittenBy(t *testing.T) {
+	written := NewAccessSet()
+	written.RecordWrite("acc", []byte("b"))
+	written.RecordRead("acc", []byte("c"))
+
+	set := NewAccessSet()
+	require.False(t, set.ReadsWrittenBy(written))
+
+	// keys read, or only written, by both are not conflicts
+	set.RecordRead("acc", []byte("c"))
+	set.RecordWrite("acc", []byte("b"))
+	require.False(t, set.ReadsWrittenBy(written))
+
+	// the keys are per store
+	set.RecordRead("stake", []byte("b"))
+	require.False(t, set.ReadsWrittenBy(written))
+
+	set.RecordIteration("acc", []byte("c"), nil)
+	set.RecordIteration("acc", nil, []byte("b"))
+	require.False(t, set.ReadsWrittenBy(written))
+
+	set.RecordIteration("acc", []byte("b"), []byte("c"))
+	require.True(t, set.ReadsWrittenBy(written))
+
+	set = NewAccessSet()
+	set.RecordRead("acc", []byte("b"))
+	require.True(t, set.ReadsWrittenBy(written))
+
+	// the writes of a set can be accumulated
+	other := NewAccessSet()
+	other.RecordWrite("stake", []byte("a"))
+	written.AddWrites(other)
+	set = NewAccessSet()
+	set.RecordIteration("stake", nil, nil)
+	require.True(t, set.ReadsWrittenBy(written))
+}
@@ -0,0 +1,285 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tendermint/iavl"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// The cold versions of an archive node are exported to a directory with one subdirectory per store:
+//
+//	<store name>/<version>.cold   the cold version file, see writeColdVersion
+//	<store name>/<version>.proof  a range proof of all the keys of the version
+//
+// A pruned node backfills the versions it pruned from such a directory, or from an http server serving
+// it, and serves them as cold versions, which turns it into an archive node for the queries without proof.
+// The proof lets the pruned node verify every key/value of a version against the root hash of its own
+// commit info, so the source does not have to be trusted.
+const (
+	coldVersionExt = ".cold"
+	coldProofExt   = ".proof"
+)
+
+func coldVersionName(storeName string, version int64) string {
+	return path.Join(storeName, strconv.FormatInt(version, 10)+coldVersionExt)
+}
+
+func coldProofName(storeName string, version int64) string {
+	return path.Join(storeName, strconv.FormatInt(version, 10)+coldProofExt)
+}
+
+// ColdVersionSource fetches the files exported by an archive node with ExportColdVersions
+type ColdVersionSource interface {
+	// Fetch writes the content of the file name, relative to the export directory, to w
+	Fetch(name string, w io.Writer) error
+}
+
+type dirColdVersionSource string
+
+// NewDirColdVersionSource returns a source reading the export directory dir, e.g. a mounted snapshot of an archive node
+func NewDirColdVersionSource(dir string) ColdVersionSource {
+	return dirColdVersionSource(dir)
+}
+
+func (dir dirColdVersionSource) Fetch(name string, w io.Writer) error {
+	f, err := os.Open(filepath.Join(string(dir), filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+type httpColdVersionSource struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPColdVersionSource returns a source downloading the files from an http server serving an export directory
+func NewHTTPColdVersionSource(baseURL string) ColdVersionSource {
+	return httpColdVersionSource{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  http.DefaultClient,
+	}
+}
+
+func (src httpColdVersionSource) Fetch(name string, w io.Writer) error {
+	resp, err := src.client.Get(src.baseURL + "/" + name)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch %s: %s", name, resp.Status)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// writeColdProof writes a range proof of all the keys of tree to path
+func writeColdProof(tree *iavl.ImmutableTree, path string) error {
+	var bz []byte
+	if tree.Size() > 0 {
+		_, _, proof, err := tree.GetRangeWithProof(nil, nil, 0)
+		if err != nil {
+			return err
+		}
+		bz, err = cdc.MarshalBinaryLengthPrefixed(proof)
+		if err != nil {
+			return err
+		}
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, bz, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// verifyColdVersion checks that the records of cv are all the key/values of the version with root hash rootHash
+func verifyColdVersion(cv *coldVersion, proofBz []byte, rootHash []byte) error {
+	if !bytes.Equal(cv.rootHash, rootHash) {
+		return fmt.Errorf("root hash %X, expected %X", cv.rootHash, rootHash)
+	}
+	if len(proofBz) == 0 {
+		if cv.count != 0 || len(rootHash) != 0 {
+			return fmt.Errorf("missing proof of %d records", cv.count)
+		}
+		return nil
+	}
+
+	var proof iavl.RangeProof
+	if err := cdc.UnmarshalBinaryLengthPrefixed(proofBz, &proof); err != nil {
+		return fmt.Errorf("invalid proof: %v", err)
+	}
+	if err := proof.Verify(rootHash); err != nil {
+		return fmt.Errorf("invalid proof: %v", err)
+	}
+	if keys := proof.Keys(); len(keys) != cv.count {
+		return fmt.Errorf("the proof has %d keys, the file has %d records", len(keys), cv.count)
+	}
+	for i := 0; i < cv.count; i++ {
+		key, value := cv.record(i)
+		if err := proof.VerifyItem(key, value); err != nil {
+			return fmt.Errorf("record %d does not match the proof: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// iavlStoreNames returns the names of the iavl stores, sorted
+func (rs *rootMultiStore) iavlStoreNames() []string {
+	names := make([]string, 0, len(rs.stores))
+	for key, store := range rs.stores {
+		if _, ok := unwrapIavlStore(store); ok {
+			names = append(names, key.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ExportColdVersions writes the cold version and proof files of the versions from `from` to `to` of the iavl
+// stores to dir, to be backfilled by pruned nodes. The versions pruned from the trees, the latest version and
+// the files already in dir are skipped. It returns the number of files written.
+func (rs *rootMultiStore) ExportColdVersions(dir string, from, to int64) (int, error) {
+	exported := 0
+	for _, name := range rs.iavlStoreNames() {
+		iavlStore, _ := unwrapIavlStore(rs.getStoreByName(name))
+		if err := os.MkdirAll(filepath.Join(dir, name), 0755); err != nil {
+			return exported, err
+		}
+		for version := from; version <= to; version++ {
+			versionPath := filepath.Join(dir, filepath.FromSlash(coldVersionName(name, version)))
+			// the latest version is not finalized, as in IavlStore.ExportColdVersion
+			if version >= iavlStore.Tree.Version() || !iavlStore.Tree.VersionExists(version) || fileExists(versionPath) {
+				continue
+			}
+			tree, err := iavlStore.Tree.GetImmutable(version)
+			if err != nil {
+				return exported, err
+			}
+			if err := writeColdProof(tree, filepath.Join(dir, filepath.FromSlash(coldProofName(name, version)))); err != nil {
+				return exported, err
+			}
+			// the version file is written last, so that it is only present along with its proof
+			if err := writeColdVersion(tree, versionPath); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+	}
+	return exported, nil
+}
+
+// BackfillColdVersions fetches from source the versions from `from` to `to` that are missing from the iavl
+// stores, verifies them against the commit info of each version and stores them in dir, where they are
+// attached as cold versions, see SetColdVersionsDir. It returns the number of versions backfilled.
+func (rs *rootMultiStore) BackfillColdVersions(source ColdVersionSource, dir string, from, to int64, logger log.Logger) (int, error) {
+	backfilled := 0
+	for version := from; version <= to; version++ {
+		cInfo, err := getCommitInfo(rs.db, version)
+		if err != nil {
+			return backfilled, err
+		}
+		for _, storeInfo := range cInfo.StoreInfos {
+			iavlStore, ok := unwrapIavlStore(rs.getStoreByName(storeInfo.Name))
+			if !ok {
+				continue
+			}
+			versionPath := filepath.Join(dir, filepath.FromSlash(coldVersionName(storeInfo.Name, version)))
+			if iavlStore.Tree.VersionExists(version) || fileExists(versionPath) {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(versionPath), 0755); err != nil {
+				return backfilled, err
+			}
+			if err := backfillColdVersion(source, storeInfo.Name, version, storeInfo.GetHash(), versionPath); err != nil {
+				return backfilled, fmt.Errorf("failed to backfill version %d of store %s: %v", version, storeInfo.Name, err)
+			}
+			logger.Info("backfilled cold version", "store", storeInfo.Name, "version", version)
+			backfilled++
+		}
+	}
+	return backfilled, nil
+}
+
+func backfillColdVersion(source ColdVersionSource, storeName string, version int64, rootHash []byte, versionPath string) (err error) {
+	var proofBz bytes.Buffer
+	if err := source.Fetch(coldProofName(storeName, version), &proofBz); err != nil {
+		return err
+	}
+
+	tmpPath := versionPath + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmpPath)
+		}
+	}()
+	err = source.Fetch(coldVersionName(storeName, version), f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	cv, err := openColdVersion(tmpPath)
+	if err != nil {
+		return err
+	}
+	err = verifyColdVersion(cv, proofBz.Bytes(), rootHash)
+	if closeErr := cv.close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, versionPath)
+}
+
+// SetColdVersionsDir attaches the cold version files of dir, as written by BackfillColdVersions, once the
+// store is loaded. It must be called before loading.
+func (rs *rootMultiStore) SetColdVersionsDir(dir string) {
+	rs.coldVersionsDir = dir
+}
+
+// attachColdVersions attaches the cold version files of dir
+func (rs *rootMultiStore) attachColdVersions(dir string) error {
+	for _, name := range rs.iavlStoreNames() {
+		paths, err := filepath.Glob(filepath.Join(dir, name, "*"+coldVersionExt))
+		if err != nil {
+			return err
+		}
+		for _, versionPath := range paths {
+			version, err := strconv.ParseInt(strings.TrimSuffix(filepath.Base(versionPath), coldVersionExt), 10, 64)
+			if err != nil {
+				continue
+			}
+			if err := rs.AttachColdVersion(rs.keysByName[name], version, versionPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
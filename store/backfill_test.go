@@ -0,0 +1,84 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+func TestBackfillColdVersions(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	require.Nil(t, multi.LoadLatestVersion())
+	store1 := multi.getStoreByName("store1").(*IavlStore)
+	store2 := multi.getStoreByName("store2").(*IavlStore)
+	store3 := multi.getStoreByName("store3").(*IavlStore)
+
+	store1.Set([]byte("hello"), []byte("goodbye"))
+	store2.Set([]byte("foo"), []byte("bar"))
+	store3.Set([]byte("key"), []byte("value"))
+	multi.Commit()
+	store1.Set([]byte("hello"), []byte("changed"))
+	multi.Commit()
+	store2.Set([]byte("foo"), []byte("baz"))
+	multi.Commit()
+
+	exportDir, err := os.MkdirTemp("", "backfill")
+	require.Nil(t, err)
+	defer os.RemoveAll(exportDir)
+	coldDir, err := os.MkdirTemp("", "backfill")
+	require.Nil(t, err)
+	defer os.RemoveAll(coldDir)
+
+	// versions 1 and 2 of the 3 stores, the latest version is not exported
+	exported, err := multi.ExportColdVersions(exportDir, 1, 3)
+	require.Nil(t, err)
+	require.Equal(t, 6, exported)
+	exported, err = multi.ExportColdVersions(exportDir, 1, 3)
+	require.Nil(t, err)
+	require.Equal(t, 0, exported)
+
+	// prune the versions from the trees
+	for _, name := range []string{"store1", "store2", "store3"} {
+		iavlStore := multi.getStoreByName(name).(*IavlStore)
+		require.Nil(t, iavlStore.Tree.DeleteVersion(1))
+		require.Nil(t, iavlStore.Tree.DeleteVersion(2))
+	}
+
+	// a tampered version is rejected and not stored
+	tamperedDir, err := os.MkdirTemp("", "backfill")
+	require.Nil(t, err)
+	defer os.RemoveAll(tamperedDir)
+	require.Nil(t, os.MkdirAll(filepath.Join(tamperedDir, "store1"), 0755))
+	bz, err := os.ReadFile(filepath.Join(exportDir, "store1", "2.cold"))
+	require.Nil(t, err)
+	require.Nil(t, os.WriteFile(filepath.Join(tamperedDir, "store1", "1.cold"), bz, 0644))
+	bz, err = os.ReadFile(filepath.Join(exportDir, "store1", "1.proof"))
+	require.Nil(t, err)
+	require.Nil(t, os.WriteFile(filepath.Join(tamperedDir, "store1", "1.proof"), bz, 0644))
+	_, err = multi.BackfillColdVersions(NewDirColdVersionSource(tamperedDir), coldDir, 1, 1, log.NewNopLogger())
+	require.NotNil(t, err)
+	_, err = os.Stat(filepath.Join(coldDir, "store1", "1.cold"))
+	require.True(t, os.IsNotExist(err))
+
+	backfilled, err := multi.BackfillColdVersions(NewDirColdVersionSource(exportDir), coldDir, 1, 3, log.NewNopLogger())
+	require.Nil(t, err)
+	require.Equal(t, 6, backfilled)
+	backfilled, err = multi.BackfillColdVersions(NewDirColdVersionSource(exportDir), coldDir, 1, 3, log.NewNopLogger())
+	require.Nil(t, err)
+	require.Equal(t, 0, backfilled)
+
+	// the backfilled versions are attached once loaded
+	reloaded := newMultiStoreWithMounts(db)
+	reloaded.SetColdVersionsDir(coldDir)
+	require.Nil(t, reloaded.LoadLatestVersion())
+	query := abci.RequestQuery{Path: "/store1/key", Data: []byte("hello"), Height: 1}
+	require.Equal(t, []byte("goodbye"), reloaded.Query(query).Value)
+	query = abci.RequestQuery{Path: "/store2/key", Data: []byte("foo"), Height: 2}
+	require.Equal(t, []byte("bar"), reloaded.Query(query).Value)
+}
@@ -0,0 +1,214 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/tendermint/iavl"
+)
+
+// A cold version file holds all the key/values of a finalized iavl version, sorted by key:
+//
+//	records: keyLen(uint32) valueLen(uint32) key value, repeated
+//	index:   the uint64 offset of every record
+//	hash:    the root hash of the version
+//	footer:  indexOffset(uint64) count(uint64) hashLen(uint64) magic
+//
+// The file is never modified once written, so it is mmapped and read without syscalls,
+// which is cheaper than going through the iavl tree for archive nodes serving historical queries.
+var coldVersionMagic = []byte("IAVLCOLD")
+
+const (
+	coldRecordHeaderLen = 8
+	coldFooterLen       = 32
+)
+
+// writeColdVersion writes the key/values of tree to path
+func writeColdVersion(tree *iavl.ImmutableTree, path string) (err error) {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	var offsets []uint64
+	var offset uint64
+	var writeErr error
+	header := make([]byte, coldRecordHeaderLen)
+	tree.Iterate(func(key []byte, value []byte) bool {
+		binary.BigEndian.PutUint32(header[:4], uint32(len(key)))
+		binary.BigEndian.PutUint32(header[4:], uint32(len(value)))
+		for _, bz := range [][]byte{header, key, value} {
+			if _, writeErr = w.Write(bz); writeErr != nil {
+				return true
+			}
+		}
+		offsets = append(offsets, offset)
+		offset += uint64(coldRecordHeaderLen + len(key) + len(value))
+		return false
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+
+	buf := make([]byte, 8)
+	for _, o := range offsets {
+		binary.BigEndian.PutUint64(buf, o)
+		if _, err = w.Write(buf); err != nil {
+			return err
+		}
+	}
+	rootHash := tree.Hash()
+	if _, err = w.Write(rootHash); err != nil {
+		return err
+	}
+	footer := make([]byte, 0, coldFooterLen)
+	footer = appendUint64(footer, offset)
+	footer = appendUint64(footer, uint64(len(offsets)))
+	footer = appendUint64(footer, uint64(len(rootHash)))
+	footer = append(footer, coldVersionMagic...)
+	if _, err = w.Write(footer); err != nil {
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+func appendUint64(bz []byte, i uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, i)
+	return append(bz, buf...)
+}
+
+// coldVersion is a read-only mmapped cold version file
+type coldVersion struct {
+	data     []byte
+	index    []byte
+	count    int
+	rootHash []byte
+}
+
+func openColdVersion(path string) (*coldVersion, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	if size < coldFooterLen {
+		return nil, fmt.Errorf("cold version file %s is too short", path)
+	}
+	data, err := mmapFile(f, int(size))
+	if err != nil {
+		return nil, err
+	}
+
+	cv, err := parseColdVersion(data)
+	if err != nil {
+		munmapFile(data) // nolint: errcheck
+		return nil, fmt.Errorf("cold version file %s is corrupted: %v", path, err)
+	}
+	return cv, nil
+}
+
+// parseColdVersion checks every offset and length of data, so that record never reads out of bounds
+// on a truncated or corrupted file, and that the keys are sorted, so that get can binary search them.
+func parseColdVersion(data []byte) (*coldVersion, error) {
+	size := uint64(len(data))
+	footer := data[size-coldFooterLen:]
+	if !bytes.Equal(footer[24:], coldVersionMagic) {
+		return nil, errors.New("bad magic")
+	}
+	indexOffset := binary.BigEndian.Uint64(footer[:8])
+	count := binary.BigEndian.Uint64(footer[8:16])
+	hashLen := binary.BigEndian.Uint64(footer[16:24])
+	end := size - coldFooterLen
+	if hashLen > end || indexOffset > end-hashLen || count != (end-hashLen-indexOffset)/8 ||
+		(end-hashLen-indexOffset)%8 != 0 {
+		return nil, errors.New("bad footer")
+	}
+
+	cv := &coldVersion{
+		data:     data,
+		index:    data[indexOffset : end-hashLen],
+		count:    int(count),
+		rootHash: data[end-hashLen : end],
+	}
+	var prevKey []byte
+	for i := 0; i < cv.count; i++ {
+		offset := binary.BigEndian.Uint64(cv.index[i*8 : i*8+8])
+		if offset > indexOffset || indexOffset-offset < coldRecordHeaderLen {
+			return nil, fmt.Errorf("record %d is out of bounds", i)
+		}
+		keyLen := uint64(binary.BigEndian.Uint32(data[offset : offset+4]))
+		valueLen := uint64(binary.BigEndian.Uint32(data[offset+4 : offset+8]))
+		if keyLen+valueLen > indexOffset-offset-coldRecordHeaderLen {
+			return nil, fmt.Errorf("record %d is out of bounds", i)
+		}
+		key, _ := cv.record(i)
+		if i > 0 && bytes.Compare(prevKey, key) >= 0 {
+			return nil, fmt.Errorf("record %d is not sorted", i)
+		}
+		prevKey = key
+	}
+	return cv, nil
+}
+
+// record returns the key and value of the i-th record, the bounds have been checked by parseColdVersion
+func (cv *coldVersion) record(i int) (key, value []byte) {
+	offset := binary.BigEndian.Uint64(cv.index[i*8 : i*8+8])
+	keyLen := uint64(binary.BigEndian.Uint32(cv.data[offset : offset+4]))
+	valueLen := uint64(binary.BigEndian.Uint32(cv.data[offset+4 : offset+8]))
+	start := offset + coldRecordHeaderLen
+	return cv.data[start : start+keyLen], cv.data[start+keyLen : start+keyLen+valueLen]
+}
+
+// get returns a copy of the value of key, or nil if the key does not exist
+func (cv *coldVersion) get(key []byte) []byte {
+	i := sort.Search(cv.count, func(i int) bool {
+		k, _ := cv.record(i)
+		return bytes.Compare(k, key) >= 0
+	})
+	if i >= cv.count {
+		return nil
+	}
+	k, v := cv.record(i)
+	if !bytes.Equal(k, key) {
+		return nil
+	}
+	return cp(v)
+}
+
+func (cv *coldVersion) close() error {
+	if cv.data == nil {
+		return errors.New("cold version already closed")
+	}
+	err := munmapFile(cv.data)
+	cv.data, cv.index = nil, nil
+	return err
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func TestColdVersionFile(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree, _ := newTree(t, db)
+	for i := 0; i < 100; i++ {
+		tree.Set([]byte(fmt.Sprintf("key%03d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+	tree.Set([]byte("empty"), []byte{})
+	_, _, err := tree.SaveVersion()
+	require.Nil(t, err)
+
+	dir, err := os.MkdirTemp("", "coldversion")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "2.cold")
+
+	require.Nil(t, writeColdVersion(tree.ImmutableTree, path))
+	cv, err := openColdVersion(path)
+	require.Nil(t, err)
+	require.Equal(t, 103, cv.count)
+
+	for k, v := range treeData {
+		require.Equal(t, []byte(v), cv.get([]byte(k)))
+	}
+	require.Equal(t, []byte("value42"), cv.get([]byte("key042")))
+	require.Equal(t, []byte{}, cv.get([]byte("empty")))
+	require.Nil(t, cv.get([]byte("key100")))
+	require.Nil(t, cv.get([]byte("a")))
+	require.Nil(t, cv.get([]byte("zzz")))
+	require.Equal(t, tree.Hash(), cv.rootHash)
+	require.Nil(t, cv.close())
+
+	// truncated records
+	bz, err := os.ReadFile(path)
+	require.Nil(t, err)
+	truncated := append(append([]byte{}, bz[:10]...), bz[len(bz)-coldFooterLen-32-103*8:]...)
+	require.Nil(t, os.WriteFile(path, truncated, 0600))
+	_, err = openColdVersion(path)
+	require.NotNil(t, err)
+
+	// corrupted file
+	require.Nil(t, os.WriteFile(path, []byte("not a cold version file"), 0600))
+	_, err = openColdVersion(path)
+	require.NotNil(t, err)
+}
+
+func TestIAVLStoreColdVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree, _ := newTree(t, db)
+	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+
+	k, v := []byte("hello"), []byte("goodbye")
+	iavlStore.Set(k, []byte("changed"))
+	cid := iavlStore.Commit()
+	require.Equal(t, int64(2), cid.Version)
+
+	dir, err := os.MkdirTemp("", "coldversion")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "1.cold")
+
+	// the latest version is not finalized
+	require.NotNil(t, iavlStore.ExportColdVersion(2, path))
+	require.Nil(t, iavlStore.ExportColdVersion(1, path))
+	// the root hash of the file must match the version
+	require.NotNil(t, iavlStore.AttachColdVersion(2, path))
+	require.Nil(t, iavlStore.AttachColdVersion(1, path))
+
+	// served from the cold version even once pruned from the tree
+	require.Nil(t, tree.DeleteVersion(1))
+	query := abci.RequestQuery{Path: "/key", Data: k, Height: 1}
+	res := iavlStore.Query(query)
+	require.Equal(t, v, res.Value)
+
+	// proofs still need the tree
+	query.Prove = true
+	res = iavlStore.Query(query)
+	require.Nil(t, res.Value)
+
+	require.Nil(t, iavlStore.DetachColdVersion(1))
+	require.NotNil(t, iavlStore.DetachColdVersion(1))
+	query.Prove = false
+	res = iavlStore.Query(query)
+	require.Nil(t, res.Value)
+}
+
+func TestMultistoreAttachColdVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	require.Nil(t, multi.LoadLatestVersion())
+	key := multi.keysByName["store1"]
+	iavlStore := multi.getStoreByName("store1").(*IavlStore)
+
+	iavlStore.Set([]byte("hello"), []byte("goodbye"))
+	multi.Commit()
+	iavlStore.Set([]byte("hello"), []byte("changed"))
+	multi.Commit()
+
+	dir, err := os.MkdirTemp("", "coldversion")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "1.cold")
+	require.Nil(t, iavlStore.ExportColdVersion(1, path))
+
+	// once pruned, the file is checked against the commit info
+	require.Nil(t, iavlStore.Tree.DeleteVersion(1))
+	require.NotNil(t, iavlStore.AttachColdVersion(1, path))
+	require.NotNil(t, multi.AttachColdVersion(key, 2, path))
+	require.NotNil(t, multi.AttachColdVersion(multi.keysByName["store2"], 1, path))
+	require.Nil(t, multi.AttachColdVersion(key, 1, path))
+
+	res := iavlStore.Query(abci.RequestQuery{Path: "/key", Data: []byte("hello"), Height: 1})
+	require.Equal(t, []byte("goodbye"), res.Value)
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// immutableIavlStore is a read-only KVStore of a version of an iavl tree
+type immutableIavlStore struct {
+	tree *iavl.ImmutableTree
+}
+
+var _ KVStore = immutableIavlStore{}
+
+// Implements Store.
+func (st immutableIavlStore) GetStoreType() StoreType {
+	return sdk.StoreTypeIAVL
+}
+
+// Implements Store.
+func (st immutableIavlStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(st)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (st immutableIavlStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Get(key []byte) []byte {
+	_, value := st.tree.Get(key)
+	return value
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Has(key []byte) bool {
+	return st.tree.Has(key)
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Set(key, value []byte) {
+	panic("a past version of a store is read-only")
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Delete(key []byte) {
+	panic("a past version of a store is read-only")
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{st, prefix}
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) Iterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, true)
+}
+
+// Implements KVStore.
+func (st immutableIavlStore) ReverseIterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, false)
+}
+
+// CacheMultiStoreWithVersion returns a cache multistore of a committed version, the writes go to the cache
+// only. The iavl stores committed after the version, e.g. the stores added by an upgrade, are empty. It fails
+// if the version is pruned from a store.
+func (rs *rootMultiStore) CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error) {
+	if version == rs.lastCommitID.Version {
+		return rs.CacheMultiStore(), nil
+	}
+	cInfo, err := getCommitInfo(rs.db, version)
+	if err != nil {
+		return nil, fmt.Errorf("version %d is not committed: %v", version, err)
+	}
+	committed := make(map[string]bool, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		committed[storeInfo.Name] = true
+	}
+
+	cms := newCacheMultiStoreFromRMS(rs)
+	for key, store := range rs.stores {
+		iavlStore, ok := unwrapIavlStore(store)
+		if !ok {
+			continue
+		}
+		var versionStore KVStore
+		if !committed[key.Name()] {
+			versionStore = dbStoreAdapter{dbm.NewMemDB()}
+		} else {
+			tree, err := iavlStore.Tree.GetImmutable(version)
+			if err != nil {
+				return nil, fmt.Errorf("version %d of store %s is pruned: %v", version, key.Name(), err)
+			}
+			versionStore = immutableIavlStore{tree}
+		}
+		if cms.TracingEnabled() {
+			cms.stores[key] = versionStore.CacheWrapWithTrace(cms.traceWriter, cms.traceContext)
+		} else {
+			cms.stores[key] = versionStore.CacheWrap()
+		}
+	}
+	return cms, nil
+}
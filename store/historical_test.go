@@ -0,0 +1,51 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestCacheMultiStoreWithVersion(t *testing.T) {
+	db := dbm.NewMemDB()
+	multi := newMultiStoreWithMounts(db)
+	multi.SetPruning(sdk.PruneNothing)
+	require.Nil(t, multi.LoadLatestVersion())
+	key1 := multi.nameToKey("store1")
+	store1 := multi.getStoreByName("store1").(*IavlStore)
+
+	store1.Set([]byte("hello"), []byte("goodbye"))
+	store1.Set([]byte("foo"), []byte("bar"))
+	multi.Commit()
+	store1.Set([]byte("hello"), []byte("changed"))
+	store1.Delete([]byte("foo"))
+	multi.Commit()
+
+	cms, err := multi.CacheMultiStoreWithVersion(1)
+	require.Nil(t, err)
+	past := cms.GetKVStore(key1)
+	require.Equal(t, []byte("goodbye"), past.Get([]byte("hello")))
+	require.Equal(t, []byte("bar"), past.Get([]byte("foo")))
+	iter := past.Iterator(nil, nil)
+	count := 0
+	for ; iter.Valid(); iter.Next() {
+		count++
+	}
+	iter.Close()
+	require.Equal(t, 2, count)
+
+	// the writes go to the cache only
+	past.Set([]byte("hello"), []byte("cached"))
+	require.Equal(t, []byte("changed"), store1.Get([]byte("hello")))
+
+	cms, err = multi.CacheMultiStoreWithVersion(2)
+	require.Nil(t, err)
+	require.Equal(t, []byte("changed"), cms.GetKVStore(key1).Get([]byte("hello")))
+	require.Nil(t, cms.GetKVStore(key1).Get([]byte("foo")))
+
+	_, err = multi.CacheMultiStoreWithVersion(3)
+	require.NotNil(t, err)
+}
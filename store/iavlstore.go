@@ -1,8 +1,10 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 
 	"github.com/bnb-chain/ics23"
@@ -54,15 +56,35 @@ type IavlStore struct {
 	// By default this value should be set the same across all nodes,
 	// so that nodes can know the waypoints their peers store.
 	storeEvery int64
+
+	// The number of versions between two prunings of the old versions.
+	// A value of 1 means prune at every commit.
+	pruneInterval int64
+
+	// finalized versions served from mmapped files, see AttachColdVersion
+	coldMtx      sync.RWMutex
+	coldVersions map[int64]*coldVersion
+
+	// versions that must not be pruned, such as a version a snapshot is taken from, see PinVersion.
+	// A pinned version due for pruning is pruned by the first commit after it is unpinned.
+	pinMtx         sync.Mutex
+	pinnedVersions map[int64]int
+	deferredPrunes map[int64]bool
+
+	// bloom filters of the keys of the recent versions, nil if disabled, see EnableKeyFilters
+	keyFilters *keyFilters
 }
 
 // CONTRACT: tree should be fully loaded.
 // nolint: unparam
 func newIAVLStore(tree *iavl.MutableTree, numRecent int64, storeEvery int64) *IavlStore {
 	st := &IavlStore{
-		Tree:       tree,
-		numRecent:  numRecent,
-		storeEvery: storeEvery,
+		Tree:           tree,
+		numRecent:      numRecent,
+		storeEvery:     storeEvery,
+		pruneInterval:  1,
+		pinnedVersions: make(map[int64]int),
+		deferredPrunes: make(map[int64]bool),
 	}
 	return st
 }
@@ -83,18 +105,27 @@ func (st *IavlStore) Commit() CommitID {
 		// TODO: Do we want to extend Commit to allow returning errors?
 		panic(err)
 	}
+	if st.keyFilters != nil {
+		st.keyFilters.commit(version, st.Tree.ImmutableTree)
+	}
+
+	st.pinMtx.Lock()
+	defer st.pinMtx.Unlock()
 
-	// Release an old version of history, if not a sync waypoint.
-	previous := version - 1
-	if st.numRecent < previous {
-		toRelease := previous - st.numRecent
-		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
-			err := st.Tree.DeleteVersion(toRelease)
-			if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
-				panic(err)
+	// Release the old versions of history of the last interval, if not sync waypoints.
+	// The versions are derived from the current one, so none is missed across restarts.
+	if version%st.pruneInterval == 0 {
+		for previous := version - st.pruneInterval; previous < version; previous++ {
+			toRelease := previous - st.numRecent
+			if toRelease > 0 && (st.storeEvery == 0 || toRelease%st.storeEvery != 0) {
+				st.pruneVersion(toRelease)
 			}
 		}
 	}
+	// release the versions which were pinned when they were due for pruning
+	for toRelease := range st.deferredPrunes {
+		st.pruneVersion(toRelease)
+	}
 
 	return CommitID{
 		Version: version,
@@ -102,6 +133,38 @@ func (st *IavlStore) Commit() CommitID {
 	}
 }
 
+// pruneVersion deletes version from the tree, unless it is pinned, the caller must hold pinMtx
+func (st *IavlStore) pruneVersion(version int64) {
+	if st.pinnedVersions[version] > 0 {
+		st.deferredPrunes[version] = true
+		return
+	}
+	delete(st.deferredPrunes, version)
+	err := st.Tree.DeleteVersion(version)
+	if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
+		panic(err)
+	}
+}
+
+// PinVersion keeps version from being pruned until it is unpinned as many times as it is pinned.
+// The version does not need to be committed yet. It is safe to call concurrently with Commit.
+func (st *IavlStore) PinVersion(version int64) {
+	st.pinMtx.Lock()
+	defer st.pinMtx.Unlock()
+	st.pinnedVersions[version]++
+}
+
+// UnpinVersion releases a pin of version, it is pruned by the next commit if it was due for pruning
+func (st *IavlStore) UnpinVersion(version int64) {
+	st.pinMtx.Lock()
+	defer st.pinMtx.Unlock()
+	if st.pinnedVersions[version] <= 1 {
+		delete(st.pinnedVersions, version)
+	} else {
+		st.pinnedVersions[version]--
+	}
+}
+
 // Implements Committer.
 func (st *IavlStore) LastCommitID() CommitID {
 	return CommitID{
@@ -112,16 +175,12 @@ func (st *IavlStore) LastCommitID() CommitID {
 
 // Implements Committer.
 func (st *IavlStore) SetPruning(pruning sdk.PruningStrategy) {
-	switch pruning {
-	case sdk.PruneEverything:
-		st.numRecent = 0
-		st.storeEvery = 0
-	case sdk.PruneNothing:
-		st.storeEvery = 1
-	case sdk.PruneSyncable:
-		st.numRecent = 100000 // fork github.com/cosmos/cosmos-sdk/blob/9a16e2675f392b083dd1074ff92ff1f9fbda750d/store/types/pruning.go#L34
-		st.storeEvery = 100000
+	if err := pruning.Validate(); err != nil {
+		panic(err)
 	}
+	st.numRecent = pruning.KeepRecent
+	st.storeEvery = pruning.KeepEvery
+	st.pruneInterval = pruning.Interval
 }
 
 // VersionExists returns whether or not a given version is stored.
@@ -129,6 +188,28 @@ func (st *IavlStore) VersionExists(version int64) bool {
 	return st.Tree.VersionExists(version)
 }
 
+// EarliestVersion returns the earliest version from which all the versions up to the latest
+// one are stored, the sync waypoints kept below it are not counted.
+func (st *IavlStore) EarliestVersion() int64 {
+	latest := st.Tree.Version()
+	if latest == 0 {
+		return 0
+	}
+	if st.storeEvery == 1 {
+		// nothing is pruned, the versions are contiguous from the first one, which is
+		// not 1 if the store has been restored from a snapshot
+		first := sort.Search(int(latest), func(i int) bool {
+			return st.Tree.VersionExists(int64(i) + 1)
+		})
+		return int64(first) + 1
+	}
+	earliest := latest
+	for earliest > 1 && st.Tree.VersionExists(earliest-1) {
+		earliest--
+	}
+	return earliest
+}
+
 // Implements Store.
 func (st *IavlStore) GetStoreType() StoreType {
 	return sdk.StoreTypeIAVL
@@ -147,6 +228,9 @@ func (st *IavlStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap
 // Implements KVStore.
 func (st *IavlStore) Set(key, value []byte) {
 	st.Tree.Set(key, value)
+	if st.keyFilters != nil {
+		st.keyFilters.add(key)
+	}
 }
 
 // Implements KVStore.
@@ -217,10 +301,21 @@ func (st *IavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	case "/store", "/key": // Get by key
 		key := req.Data // Data holds the key bytes
 		res.Key = key
+		// cold versions may have been pruned from the tree, but they can't prove anything
+		if !req.Prove {
+			if value, ok := st.getCold(res.Height, key); ok {
+				res.Value = value
+				break
+			}
+		}
 		if !st.VersionExists(res.Height) {
 			res.Log = cmn.ErrorWrap(iavl.ErrVersionDoesNotExist, "").Error()
 			break
 		}
+		// absent keys can't be proved without reading the tree
+		if !req.Prove && st.keyFilters != nil && st.keyFilters.absent(res.Height, key) {
+			break
+		}
 		if req.Prove {
 			value, proof, err := tree.GetVersionedWithProof(key, res.Height)
 			if err != nil {
@@ -288,6 +383,91 @@ func (st *IavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	return
 }
 
+// EnableKeyFilters keeps a bloom filter of the keys of each range of versionRange versions, with
+// the given false positive rate, so that the queries without proof of keys that do not exist at
+// a version are answered without reading the tree. The first filter is built from the keys of the
+// latest version, the queries of older versions are not filtered. It must be called before the
+// store is used, with the tree loaded.
+func (st *IavlStore) EnableKeyFilters(versionRange int64, falsePositiveRate float64) error {
+	kf, err := newKeyFilters(versionRange, falsePositiveRate)
+	if err != nil {
+		return err
+	}
+	kf.start(st.Tree.ImmutableTree)
+	st.keyFilters = kf
+	return nil
+}
+
+// ExportColdVersion writes the key/values of a finalized version to an immutable file at path,
+// the file can then be served by AttachColdVersion, even after the version is pruned from the tree.
+func (st *IavlStore) ExportColdVersion(version int64, path string) error {
+	if version >= st.Tree.Version() {
+		return fmt.Errorf("version %d is not finalized, latest version is %d", version, st.Tree.Version())
+	}
+	tree, err := st.Tree.GetImmutable(version)
+	if err != nil {
+		return err
+	}
+	return writeColdVersion(tree, path)
+}
+
+// AttachColdVersion serves the queries without proof of version from the file written by
+// ExportColdVersion. The file is mmapped, which avoids the syscalls of reading the tree nodes from the db.
+// The version must still be in the tree to check the root hash of the file, once it is pruned the file
+// has to be attached through the root multi store, which checks it against the commit info.
+func (st *IavlStore) AttachColdVersion(version int64, path string) error {
+	tree, err := st.Tree.GetImmutable(version)
+	if err != nil {
+		return err
+	}
+	return st.attachColdVersion(version, tree.Hash(), path)
+}
+
+func (st *IavlStore) attachColdVersion(version int64, rootHash []byte, path string) error {
+	cv, err := openColdVersion(path)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(cv.rootHash, rootHash) {
+		cv.close() // nolint: errcheck
+		return fmt.Errorf("cold version file %s has root hash %X, expected %X for version %d",
+			path, cv.rootHash, rootHash, version)
+	}
+
+	st.coldMtx.Lock()
+	defer st.coldMtx.Unlock()
+	if st.coldVersions == nil {
+		st.coldVersions = make(map[int64]*coldVersion)
+	}
+	if old, ok := st.coldVersions[version]; ok {
+		old.close() // nolint: errcheck
+	}
+	st.coldVersions[version] = cv
+	return nil
+}
+
+// DetachColdVersion stops serving version from its cold version file and unmaps it
+func (st *IavlStore) DetachColdVersion(version int64) error {
+	st.coldMtx.Lock()
+	defer st.coldMtx.Unlock()
+	cv, ok := st.coldVersions[version]
+	if !ok {
+		return fmt.Errorf("version %d is not attached", version)
+	}
+	delete(st.coldVersions, version)
+	return cv.close()
+}
+
+func (st *IavlStore) getCold(version int64, key []byte) ([]byte, bool) {
+	st.coldMtx.RLock()
+	defer st.coldMtx.RUnlock()
+	cv, ok := st.coldVersions[version]
+	if !ok {
+		return nil, false
+	}
+	return cv.get(key), true
+}
+
 // Takes a MutableTree, a key, and a flag for creating existence or absence proof and returns the
 // appropriate merkle.Proof. Since this must be called after querying for the value, this function should never error
 // Thus, it will panic on error rather than returning it
@@ -323,20 +323,50 @@ type pruneState struct {
 	deleted []int64
 }
 
+func TestIAVLPruningInterval(t *testing.T) {
+	//Expected stored / deleted version numbers for:
+	//numRecent = 2, storeEvery = 5, pruned every 3 versions
+	var states = []pruneState{
+		{[]int64{}, []int64{}},
+		{[]int64{1}, []int64{}},
+		{[]int64{1, 2}, []int64{}},
+		{[]int64{1, 2, 3}, []int64{}},
+		{[]int64{1, 2, 3, 4}, []int64{}},
+		{[]int64{1, 2, 3, 4, 5}, []int64{}},
+		{[]int64{4, 5, 6}, []int64{1, 2, 3}},
+		{[]int64{4, 5, 6, 7}, []int64{1, 2, 3}},
+		{[]int64{4, 5, 6, 7, 8}, []int64{1, 2, 3}},
+		{[]int64{5, 7, 8, 9}, []int64{1, 2, 3, 4, 6}},
+		{[]int64{5, 7, 8, 9, 10}, []int64{1, 2, 3, 4, 6}},
+		{[]int64{5, 7, 8, 9, 10, 11}, []int64{1, 2, 3, 4, 6}},
+		{[]int64{5, 10, 11, 12}, []int64{1, 2, 3, 4, 6, 7, 8, 9}},
+	}
+	testPruningStrategy(t, sdk.NewPruningStrategy(2, 5, 3), states)
+
+	require.Panics(t, func() {
+		newIAVLStore(iavl.NewMutableTree(dbm.NewMemDB(), cacheSize), 0, 0).SetPruning(sdk.PruningStrategy{})
+	})
+}
+
 func testPruning(t *testing.T, numRecent int64, storeEvery int64, states []pruneState) {
+	testPruningStrategy(t, sdk.NewPruningStrategy(numRecent, storeEvery, 1), states)
+}
+
+func testPruningStrategy(t *testing.T, pruning sdk.PruningStrategy, states []pruneState) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewMutableTree(db, cacheSize)
-	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+	iavlStore := newIAVLStore(tree, 0, 0)
+	iavlStore.SetPruning(pruning)
 	for step, state := range states {
 		for _, ver := range state.stored {
 			require.True(t, iavlStore.VersionExists(ver),
-				"Missing version %d with latest version %d. Should save last %d and every %d",
-				ver, step, numRecent, storeEvery)
+				"Missing version %d with latest version %d. Should save with %v",
+				ver, step, pruning)
 		}
 		for _, ver := range state.deleted {
 			require.False(t, iavlStore.VersionExists(ver),
-				"Unpruned version %d with latest version %d. Should prune all but last %d and every %d",
-				ver, step, numRecent, storeEvery)
+				"Unpruned version %d with latest version %d. Should prune with %v",
+				ver, step, pruning)
 		}
 		nextVersion(iavlStore)
 	}
@@ -375,6 +405,34 @@ func TestIAVLPruneEverything(t *testing.T) {
 	}
 }
 
+func TestIAVLEarliestVersion(t *testing.T) {
+	newStore := func(pruning sdk.PruningStrategy) *IavlStore {
+		iavlStore := newIAVLStore(iavl.NewMutableTree(dbm.NewMemDB(), cacheSize), 0, 0)
+		iavlStore.SetPruning(pruning)
+		return iavlStore
+	}
+
+	iavlStore := newStore(sdk.NewPruningStrategy(2, 5, 3))
+	require.Equal(t, int64(0), iavlStore.EarliestVersion())
+	for i := 0; i < 12; i++ {
+		nextVersion(iavlStore)
+	}
+	// versions 5, 10, 11 and 12 are stored, 5 is a sync waypoint
+	require.Equal(t, int64(10), iavlStore.EarliestVersion())
+
+	iavlStore = newStore(sdk.PruneNothing)
+	for i := 0; i < 12; i++ {
+		nextVersion(iavlStore)
+	}
+	require.Equal(t, int64(1), iavlStore.EarliestVersion())
+
+	iavlStore = newStore(sdk.PruneEverything)
+	for i := 0; i < 12; i++ {
+		nextVersion(iavlStore)
+	}
+	require.Equal(t, int64(12), iavlStore.EarliestVersion())
+}
+
 func TestIAVLStoreQuery(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree := iavl.NewMutableTree(db, cacheSize)
@@ -487,3 +545,28 @@ func BenchmarkIAVLIteratorNext(b *testing.B) {
 		}
 	}
 }
+
+func TestIAVLPinVersion(t *testing.T) {
+	iavlStore := newIAVLStore(iavl.NewMutableTree(dbm.NewMemDB(), cacheSize), 0, 0)
+	iavlStore.SetPruning(sdk.PruneEverything)
+
+	// a version can be pinned before it's committed
+	iavlStore.PinVersion(2)
+	iavlStore.PinVersion(2)
+	for i := 0; i < 4; i++ {
+		nextVersion(iavlStore)
+	}
+	require.False(t, iavlStore.VersionExists(1))
+	require.True(t, iavlStore.VersionExists(2))
+	require.False(t, iavlStore.VersionExists(3))
+
+	// the version is pruned by the first commit after it's unpinned as many times as pinned
+	iavlStore.UnpinVersion(2)
+	nextVersion(iavlStore)
+	require.True(t, iavlStore.VersionExists(2))
+	iavlStore.UnpinVersion(2)
+	require.True(t, iavlStore.VersionExists(2))
+	nextVersion(iavlStore)
+	require.False(t, iavlStore.VersionExists(2))
+	require.Empty(t, iavlStore.deferredPrunes)
+}
@@ -0,0 +1,179 @@
+package store
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/tendermint/iavl"
+)
+
+const (
+	// minimum number of keys a key filter is sized for
+	minKeyFilterCapacity = 1024
+	// number of version ranges whose key filter is kept
+	maxKeyFilters = 64
+)
+
+// bloomFilter tells that a key was never added to it, or that it may have been
+type bloomFilter struct {
+	bits     []uint64
+	m        uint64 // number of bits
+	k        uint64 // number of hashes per key
+	capacity int    // number of keys the filter is sized for
+	count    int    // approximate number of distinct keys added
+}
+
+func newBloomFilter(capacity int, falsePositiveRate float64) *bloomFilter {
+	if capacity < minKeyFilterCapacity {
+		capacity = minKeyFilterCapacity
+	}
+	m := uint64(math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	k := uint64(math.Round(float64(m) / float64(capacity) * math.Ln2))
+	if k == 0 {
+		k = 1
+	}
+	return &bloomFilter{
+		bits:     make([]uint64, (m+63)/64),
+		m:        m,
+		k:        k,
+		capacity: capacity,
+	}
+}
+
+// keyHashes returns the two hashes of key the k bit positions are derived from
+func keyHashes(key []byte) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write(key) // nolint: errcheck
+	h1 := h.Sum64()
+	h.Write([]byte{0}) // nolint: errcheck
+	return h1, h.Sum64() | 1
+}
+
+func (bf *bloomFilter) add(h1, h2 uint64) {
+	if bf.mayContain(h1, h2) {
+		return
+	}
+	for i := uint64(0); i < bf.k; i++ {
+		bit := (h1 + i*h2) % bf.m
+		bf.bits[bit/64] |= 1 << (bit % 64)
+	}
+	bf.count++
+}
+
+func (bf *bloomFilter) mayContain(h1, h2 uint64) bool {
+	for i := uint64(0); i < bf.k; i++ {
+		bit := (h1 + i*h2) % bf.m
+		if bf.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (bf *bloomFilter) clone() *bloomFilter {
+	cloned := *bf
+	cloned.bits = make([]uint64, len(bf.bits))
+	copy(cloned.bits, bf.bits)
+	return &cloned
+}
+
+// rangeFilter holds the keys that exist at any version from `from` to `to`: the keys of
+// version from-1 and the keys set since then, the deleted keys are not removed
+type rangeFilter struct {
+	from, to int64
+	bloom    *bloomFilter
+}
+
+// keyFilters keeps a bloom filter of the keys of an iavl store for each range of versionRange
+// versions, so that the historical queries of keys that do not exist at a version, such as
+// explorers probing addresses, are answered without reading the tree. A new filter is cloned
+// from the last one at the end of each range, or rebuilt from the tree once the last one holds
+// more keys than it is sized for, which also drops the deleted keys.
+type keyFilters struct {
+	mtx               sync.RWMutex
+	versionRange      int64
+	falsePositiveRate float64
+	filters           []*rangeFilter // sorted by version, the last one is the current one
+}
+
+func newKeyFilters(versionRange int64, falsePositiveRate float64) (*keyFilters, error) {
+	if versionRange <= 0 {
+		return nil, errors.New("the version range of the key filters must be positive")
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return nil, errors.New("the false positive rate of the key filters must be between 0 and 1")
+	}
+	return &keyFilters{
+		versionRange:      versionRange,
+		falsePositiveRate: falsePositiveRate,
+	}, nil
+}
+
+// start builds the first filter from the keys of tree, it is valid from the version of the tree
+func (kf *keyFilters) start(tree *iavl.ImmutableTree) {
+	kf.mtx.Lock()
+	defer kf.mtx.Unlock()
+	version := tree.Version()
+	kf.filters = []*rangeFilter{{from: version, to: version, bloom: kf.build(tree)}}
+}
+
+// build returns a filter of the keys of tree, sized for twice their number
+func (kf *keyFilters) build(tree *iavl.ImmutableTree) *bloomFilter {
+	var hashes [][2]uint64
+	tree.Iterate(func(key []byte, value []byte) bool {
+		h1, h2 := keyHashes(key)
+		hashes = append(hashes, [2]uint64{h1, h2})
+		return false
+	})
+	bloom := newBloomFilter(2*len(hashes), kf.falsePositiveRate)
+	for _, h := range hashes {
+		bloom.add(h[0], h[1])
+	}
+	return bloom
+}
+
+// add records a key set in the version being executed
+func (kf *keyFilters) add(key []byte) {
+	h1, h2 := keyHashes(key)
+	kf.mtx.Lock()
+	defer kf.mtx.Unlock()
+	kf.filters[len(kf.filters)-1].bloom.add(h1, h2)
+}
+
+// commit extends the current filter to version, and starts the filter of the next range at the
+// end of a range. tree is the committed version.
+func (kf *keyFilters) commit(version int64, tree *iavl.ImmutableTree) {
+	kf.mtx.Lock()
+	defer kf.mtx.Unlock()
+	current := kf.filters[len(kf.filters)-1]
+	current.to = version
+	if version%kf.versionRange != 0 {
+		return
+	}
+
+	var bloom *bloomFilter
+	if current.bloom.count <= current.bloom.capacity {
+		bloom = current.bloom.clone()
+	} else {
+		bloom = kf.build(tree)
+	}
+	kf.filters = append(kf.filters, &rangeFilter{from: version + 1, to: version, bloom: bloom})
+	if len(kf.filters) > maxKeyFilters {
+		kf.filters = kf.filters[len(kf.filters)-maxKeyFilters:]
+	}
+}
+
+// absent tells whether key certainly does not exist at version, it is false if no filter covers version
+func (kf *keyFilters) absent(version int64, key []byte) bool {
+	kf.mtx.RLock()
+	defer kf.mtx.RUnlock()
+	i := sort.Search(len(kf.filters), func(i int) bool { return kf.filters[i].to >= version })
+	if i == len(kf.filters) || kf.filters[i].from > version {
+		return false
+	}
+	h1, h2 := keyHashes(key)
+	return !kf.filters[i].bloom.mayContain(h1, h2)
+}
@@ -0,0 +1,57 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+func TestIAVLKeyFilters(t *testing.T) {
+	tree, _ := newTree(t, dbm.NewMemDB())
+	iavlStore := newIAVLStore(tree, 100, 1)
+	require.NotNil(t, iavlStore.EnableKeyFilters(0, 0.01))
+	require.NotNil(t, iavlStore.EnableKeyFilters(2, 1))
+	require.Nil(t, iavlStore.EnableKeyFilters(2, 0.01))
+
+	kf := iavlStore.keyFilters
+	require.False(t, kf.absent(1, []byte("hello")))
+	require.True(t, kf.absent(1, []byte("missing")))
+
+	// version 2 ends the first range
+	iavlStore.Set([]byte("key2"), []byte("value2"))
+	iavlStore.Commit()
+	iavlStore.Set([]byte("key3"), []byte("value3"))
+	iavlStore.Commit()
+	require.Len(t, kf.filters, 2)
+
+	// the keys set later in the same range are false positives
+	require.False(t, kf.absent(1, []byte("key2")))
+	require.True(t, kf.absent(1, []byte("key3")))
+	require.True(t, kf.absent(2, []byte("key3")))
+	require.False(t, kf.absent(3, []byte("key2")))
+	require.False(t, kf.absent(3, []byte("key3")))
+	// no filter covers the versions before it is enabled
+	require.False(t, kf.absent(0, []byte("missing")))
+
+	query := abci.RequestQuery{Path: "/key", Data: []byte("key3"), Height: 2}
+	res := iavlStore.Query(query)
+	require.Equal(t, uint32(0), res.Code)
+	require.Nil(t, res.Value)
+	query.Height = 3
+	res = iavlStore.Query(query)
+	require.Equal(t, []byte("value3"), res.Value)
+
+	// a filter holding more keys than it is sized for is rebuilt at the end of the range
+	for i := 0; i < 2*minKeyFilterCapacity; i++ {
+		iavlStore.Set([]byte(fmt.Sprintf("key%d", i+10)), []byte("value"))
+	}
+	iavlStore.Commit()
+	require.Len(t, kf.filters, 3)
+	bloom := kf.filters[2].bloom
+	require.True(t, bloom.capacity >= 4*minKeyFilterCapacity)
+	require.True(t, bloom.count <= 2*minKeyFilterCapacity+4)
+	require.False(t, kf.absent(4, []byte("key100")))
+}
@@ -0,0 +1,22 @@
+//go:build windows
+// +build windows
+
+package store
+
+import (
+	"io"
+	"os"
+)
+
+// mmapFile falls back to reading the whole file into memory
+func mmapFile(f *os.File, size int) ([]byte, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(f, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func munmapFile(_ []byte) error {
+	return nil
+}
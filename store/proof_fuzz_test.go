@@ -0,0 +1,300 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/bnb-chain/ics23"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// The proof fuzz harness fills a multistore with random contents, queries random present and absent keys
+// with proof on both the ics23 and the legacy query paths, checks that the proofs verify, then checks that
+// the verifier rejects every mutation of the proofs and of the statements they prove. The rounds are seeded
+// by their number, so that a failing round can be replayed.
+
+const (
+	proofFuzzRounds         = 32
+	proofFuzzShortRounds    = 4
+	proofFuzzQueries        = 8
+	proofFuzzMutations      = 16
+	proofFuzzMaxKeyLength   = 6
+	proofFuzzMaxValueLength = 32
+)
+
+var proofFuzzStores = []string{"store1", "store2", "store3"}
+
+type proofFuzzStatement struct {
+	root    []byte
+	keypath string
+	value   []byte // nil for an absence proof
+}
+
+func (st proofFuzzStatement) verify(prt *merkle.ProofRuntime, proof *merkle.Proof) (err error) {
+	// a verifier panicking on a malformed proof does not accept it, the panic is reported as an error
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("verifier panicked: %v", r)
+		}
+	}()
+	if st.value == nil {
+		return prt.VerifyAbsence(proof, st.root, st.keypath)
+	}
+	return prt.VerifyValue(proof, st.root, st.keypath, st.value)
+}
+
+func TestProofFuzz(t *testing.T) {
+	height := sdk.UpgradeMgr.GetHeight()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.BEP171, 1)
+	sdk.UpgradeMgr.SetHeight(100)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.BEP171)
+		sdk.UpgradeMgr.SetHeight(height)
+	}()
+
+	rounds := proofFuzzRounds
+	if testing.Short() {
+		rounds = proofFuzzShortRounds
+	}
+	for seed := int64(1); seed <= int64(rounds); seed++ {
+		fuzzProofRound(t, seed)
+	}
+}
+
+func fuzzProofRound(t *testing.T, seed int64) {
+	r := rand.New(rand.NewSource(seed))
+	multi := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, multi.LoadLatestVersion())
+
+	// random contents over a few versions. Every store keeps two seed keys out of the random alphabet, so
+	// that the absence proofs exist and the iavl proofs have inner ops, which tell them from simple proofs.
+	contents := make(map[string]map[string][]byte, len(proofFuzzStores))
+	for _, name := range proofFuzzStores {
+		contents[name] = make(map[string][]byte)
+		for _, key := range []string{"seed1", "seed2"} {
+			contents[name][key] = randomProofFuzzBytes(r, proofFuzzMaxValueLength)
+			multi.getStoreByName(name).(KVStore).Set([]byte(key), contents[name][key])
+		}
+	}
+	var cid CommitID
+	for version := 1 + r.Intn(3); version > 0; version-- {
+		for n := 1 + r.Intn(64); n > 0; n-- {
+			name := proofFuzzStores[r.Intn(len(proofFuzzStores))]
+			store := multi.getStoreByName(name).(KVStore)
+			key := randomProofFuzzBytes(r, proofFuzzMaxKeyLength)
+			if _, ok := contents[name][string(key)]; ok && r.Intn(4) == 0 {
+				store.Delete(key)
+				delete(contents[name], string(key))
+				continue
+			}
+			value := randomProofFuzzBytes(r, proofFuzzMaxValueLength)
+			store.Set(key, value)
+			contents[name][string(key)] = value
+		}
+		cid = multi.Commit()
+	}
+
+	prt := DefaultProofRuntime()
+	for q := 0; q < proofFuzzQueries; q++ {
+		name := proofFuzzStores[r.Intn(len(proofFuzzStores))]
+		key := randomProofFuzzBytes(r, proofFuzzMaxKeyLength)
+		if r.Intn(2) == 0 {
+			keys := make([]string, 0, len(contents[name]))
+			for k := range contents[name] {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			key = []byte(keys[r.Intn(len(keys))])
+		}
+		value := contents[name][string(key)]
+		statement := proofFuzzStatement{
+			root:    cid.Hash,
+			keypath: proofFuzzKeyPath(name, key),
+			value:   value,
+		}
+
+		for _, subpath := range []string{"/ics23-key", "/key"} {
+			res := multi.Query(abci.RequestQuery{Path: "/" + name + subpath, Data: key, Height: cid.Version, Prove: true})
+			require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOK), sdk.ABCICodeType(res.Code), "seed %d: %s", seed, res.Log)
+			require.Equal(t, value, res.Value, "seed %d", seed)
+			require.NotNil(t, res.Proof, "seed %d", seed)
+			require.Nil(t, statement.verify(prt, res.Proof), "seed %d: %s %X", seed, subpath, key)
+
+			fuzzProofStatement(t, r, seed, prt, res.Proof, statement, name, key)
+			fuzzProofOps(t, r, seed, prt, res.Proof, statement, subpath == "/ics23-key")
+		}
+	}
+}
+
+// fuzzProofStatement checks that a valid proof does not prove anything else than its statement
+func fuzzProofStatement(t *testing.T, r *rand.Rand, seed int64, prt *merkle.ProofRuntime, proof *merkle.Proof,
+	statement proofFuzzStatement, name string, key []byte) {
+	mutations := map[string]proofFuzzStatement{}
+
+	root := statement
+	root.root = flipProofFuzzBit(r, statement.root)
+	mutations["root"] = root
+
+	otherKey := statement
+	otherKey.keypath = proofFuzzKeyPath(name, append(append([]byte{}, key...), byte(r.Intn(256))))
+	mutations["key"] = otherKey
+
+	otherStore := statement
+	otherStore.keypath = proofFuzzKeyPath(proofFuzzStores[(indexOfProofFuzzStore(name)+1)%len(proofFuzzStores)], key)
+	mutations["store"] = otherStore
+
+	if statement.value != nil {
+		value := statement
+		value.value = flipProofFuzzBit(r, statement.value)
+		mutations["value"] = value
+
+		absence := statement
+		absence.value = nil
+		mutations["absence"] = absence
+	} else {
+		existence := statement
+		existence.value = randomProofFuzzBytes(r, proofFuzzMaxValueLength)
+		mutations["existence"] = existence
+	}
+
+	for kind, mutated := range mutations {
+		require.NotNil(t, mutated.verify(prt, proof), "seed %d: the %s mutation of the statement is accepted", seed, kind)
+	}
+}
+
+// fuzzProofOps checks that the mutations of a valid proof are rejected. The bit flips in the data of the ops are
+// only applied to the ics23 proofs, whose every field is bound to the root but the key of a non existence proof:
+// a flip in it, or in its tag, is accepted as the proof still proves the same statement.
+func fuzzProofOps(t *testing.T, r *rand.Rand, seed int64, prt *merkle.ProofRuntime, proof *merkle.Proof,
+	statement proofFuzzStatement, ics23Proof bool) {
+	kinds := []string{"drop-op", "duplicate-op", "swap-ops", "op-key", "op-type", "truncate"}
+	if ics23Proof {
+		kinds = append(kinds, "flip", "flip", "flip")
+	}
+	opTypes := []string{merkle.ProofOpSimpleValue, ProofOpIAVLCommitment, ProofOpSimpleMerkleCommitment, ProofOpMultiStore}
+
+	for m := 0; m < proofFuzzMutations; m++ {
+		kind := kinds[r.Intn(len(kinds))]
+		mutated := copyProofFuzzProof(proof)
+		i := r.Intn(len(mutated.Ops))
+		switch kind {
+		case "drop-op":
+			if len(mutated.Ops) == 1 {
+				continue
+			}
+			mutated.Ops = append(mutated.Ops[:i], mutated.Ops[i+1:]...)
+		case "duplicate-op":
+			mutated.Ops = append(mutated.Ops[:i+1], mutated.Ops[i:]...)
+		case "swap-ops":
+			j := (i + 1) % len(mutated.Ops)
+			if i == j {
+				continue
+			}
+			mutated.Ops[i], mutated.Ops[j] = mutated.Ops[j], mutated.Ops[i]
+		case "op-key":
+			mutated.Ops[i].Key = append(mutated.Ops[i].Key, byte(r.Intn(256)))
+		case "op-type":
+			opType := opTypes[r.Intn(len(opTypes))]
+			if opType == mutated.Ops[i].Type {
+				continue
+			}
+			mutated.Ops[i].Type = opType
+		case "truncate":
+			if len(mutated.Ops[i].Data) == 0 {
+				continue
+			}
+			mutated.Ops[i].Data = mutated.Ops[i].Data[:r.Intn(len(mutated.Ops[i].Data))]
+		case "flip":
+			mutated.Ops[i].Data = flipProofFuzzBit(r, mutated.Ops[i].Data)
+		}
+
+		err := statement.verify(prt, mutated)
+		if err == nil && kind == "flip" && sameIcs23Binding(proof.Ops[i], mutated.Ops[i]) {
+			continue
+		}
+		require.NotNil(t, err, "seed %d: the %s mutation of op %d is accepted", seed, kind, i)
+	}
+}
+
+// sameIcs23Binding tells whether two ics23 proof ops prove the same thing, i.e. they only differ by the key of
+// a non existence proof, which is not bound to the root
+func sameIcs23Binding(orig, mutated merkle.ProofOp) bool {
+	if orig.Type != mutated.Type || !bytes.Equal(orig.Key, mutated.Key) {
+		return false
+	}
+	origProof, mutatedProof := &ics23.CommitmentProof{}, &ics23.CommitmentProof{}
+	if origProof.Unmarshal(orig.Data) != nil || mutatedProof.Unmarshal(mutated.Data) != nil {
+		return false
+	}
+	if exist := origProof.GetExist(); exist != nil {
+		return bytes.Equal(marshalIcs23Exist(exist), marshalIcs23Exist(mutatedProof.GetExist()))
+	}
+	origNonexist, mutatedNonexist := origProof.GetNonexist(), mutatedProof.GetNonexist()
+	if origNonexist == nil || mutatedNonexist == nil {
+		return false
+	}
+	return bytes.Equal(marshalIcs23Exist(origNonexist.Left), marshalIcs23Exist(mutatedNonexist.Left)) &&
+		bytes.Equal(marshalIcs23Exist(origNonexist.Right), marshalIcs23Exist(mutatedNonexist.Right))
+}
+
+func marshalIcs23Exist(proof *ics23.ExistenceProof) []byte {
+	if proof == nil {
+		return nil
+	}
+	bz, err := proof.Marshal()
+	if err != nil {
+		return nil
+	}
+	return bz
+}
+
+func proofFuzzKeyPath(name string, key []byte) string {
+	return merkle.KeyPath{}.AppendKey([]byte(name), merkle.KeyEncodingURL).AppendKey(key, merkle.KeyEncodingHex).String()
+}
+
+func indexOfProofFuzzStore(name string) int {
+	for i, storeName := range proofFuzzStores {
+		if storeName == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// randomProofFuzzBytes returns 1 to maxLength random bytes from a small alphabet, so that the keys collide
+func randomProofFuzzBytes(r *rand.Rand, maxLength int) []byte {
+	bz := make([]byte, 1+r.Intn(maxLength))
+	for i := range bz {
+		bz[i] = byte('a' + r.Intn(4))
+	}
+	return bz
+}
+
+func flipProofFuzzBit(r *rand.Rand, bz []byte) []byte {
+	flipped := append([]byte{}, bz...)
+	if len(flipped) == 0 {
+		return []byte{byte(1 + r.Intn(255))}
+	}
+	flipped[r.Intn(len(flipped))] ^= 1 << uint(r.Intn(8))
+	return flipped
+}
+
+func copyProofFuzzProof(proof *merkle.Proof) *merkle.Proof {
+	ops := make([]merkle.ProofOp, len(proof.Ops))
+	for i, op := range proof.Ops {
+		ops[i] = merkle.ProofOp{
+			Type: op.Type,
+			Key:  append([]byte{}, op.Key...),
+			Data: append([]byte{}, op.Data...),
+		}
+	}
+	return &merkle.Proof{Ops: ops}
+}
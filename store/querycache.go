@@ -0,0 +1,136 @@
+package store
+
+import (
+	"fmt"
+
+	metricsPkg "github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	lru "github.com/hashicorp/golang-lru"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// QueryCacheMetrics are the metrics of the query cache of the multistore
+type QueryCacheMetrics struct {
+	Hits          metricsPkg.Counter
+	Misses        metricsPkg.Counter
+	Invalidations metricsPkg.Counter
+	Size          metricsPkg.Gauge
+}
+
+// PrometheusQueryCacheMetrics returns QueryCacheMetrics build using Prometheus client library.
+func PrometheusQueryCacheMetrics() *QueryCacheMetrics {
+	return &QueryCacheMetrics{
+		Hits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "store",
+			Name:      "query_cache_hits",
+			Help:      "The number of proof queries answered from the query cache",
+		}, []string{}),
+		Misses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "store",
+			Name:      "query_cache_misses",
+			Help:      "The number of cacheable proof queries not found in the query cache",
+		}, []string{}),
+		Invalidations: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "store",
+			Name:      "query_cache_invalidations",
+			Help:      "The number of cached query responses dropped as their version was pruned",
+		}, []string{}),
+		Size: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Subsystem: "store",
+			Name:      "query_cache_size",
+			Help:      "The number of query responses in the query cache",
+		}, []string{}),
+	}
+}
+
+// NopQueryCacheMetrics returns no-op QueryCacheMetrics.
+func NopQueryCacheMetrics() *QueryCacheMetrics {
+	return &QueryCacheMetrics{
+		Hits:          discard.NewCounter(),
+		Misses:        discard.NewCounter(),
+		Invalidations: discard.NewCounter(),
+		Size:          discard.NewGauge(),
+	}
+}
+
+type queryCacheKey struct {
+	path   string
+	data   string
+	height int64
+}
+
+type queryCacheEntry struct {
+	storeName string
+	res       abci.ResponseQuery
+}
+
+// queryCache keeps the recent responses of the proof queries at an explicit height, which do not change
+// until the version is pruned, e.g. the proofs of the cross chain packages requested again and again by
+// the relayers
+type queryCache struct {
+	entries *lru.Cache
+	metrics *QueryCacheMetrics
+}
+
+func newQueryCache(size int, metrics *QueryCacheMetrics) (*queryCache, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("query cache size must be positive, got %d", size)
+	}
+	entries, err := lru.New(size)
+	if err != nil {
+		return nil, err
+	}
+	if metrics == nil {
+		metrics = NopQueryCacheMetrics()
+	}
+	return &queryCache{entries: entries, metrics: metrics}, nil
+}
+
+// cacheable tells whether the response of req only depends on a committed version
+func (qc *queryCache) cacheable(req abci.RequestQuery) bool {
+	return req.Prove && req.Height > 0
+}
+
+func (qc *queryCache) get(req abci.RequestQuery) (abci.ResponseQuery, bool) {
+	if !qc.cacheable(req) {
+		return abci.ResponseQuery{}, false
+	}
+	entry, ok := qc.entries.Get(queryCacheKey{req.Path, string(req.Data), req.Height})
+	if !ok {
+		qc.metrics.Misses.Add(1)
+		return abci.ResponseQuery{}, false
+	}
+	qc.metrics.Hits.Add(1)
+	return entry.(queryCacheEntry).res, true
+}
+
+// add caches the response of req, only the successful responses with a proof are cached
+func (qc *queryCache) add(req abci.RequestQuery, storeName string, res abci.ResponseQuery) {
+	if !qc.cacheable(req) || !res.IsOK() || res.Proof == nil {
+		return
+	}
+	qc.entries.Add(queryCacheKey{req.Path, string(req.Data), req.Height}, queryCacheEntry{storeName, res})
+	qc.metrics.Size.Set(float64(qc.entries.Len()))
+}
+
+// invalidate drops the responses whose version does not exist any more in their store
+func (qc *queryCache) invalidate(versionExists func(storeName string, version int64) bool) {
+	for _, key := range qc.entries.Keys() {
+		entry, ok := qc.entries.Peek(key)
+		if !ok {
+			continue
+		}
+		if !versionExists(entry.(queryCacheEntry).storeName, key.(queryCacheKey).height) {
+			qc.entries.Remove(key)
+			qc.metrics.Invalidations.Add(1)
+		}
+	}
+	qc.metrics.Size.Set(float64(qc.entries.Len()))
+}
+
+func (qc *queryCache) purge() {
+	qc.entries.Purge()
+	qc.metrics.Size.Set(0)
+}
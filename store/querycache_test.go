@@ -0,0 +1,58 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/generic"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestMultiStoreQueryCache(t *testing.T) {
+	multi := newMultiStoreWithMounts(dbm.NewMemDB())
+	// keep the last version only
+	multi.SetPruning(sdk.NewPruningStrategy(1, 0, 1))
+	require.NotNil(t, multi.SetQueryCache(0, nil))
+	hits, invalidations := generic.NewCounter("hits"), generic.NewCounter("invalidations")
+	require.Nil(t, multi.SetQueryCache(10, &QueryCacheMetrics{
+		Hits:          hits,
+		Misses:        discard.NewCounter(),
+		Invalidations: invalidations,
+		Size:          discard.NewGauge(),
+	}))
+	require.Nil(t, multi.LoadLatestVersion())
+
+	k := []byte("wind")
+	store1 := multi.getStoreByName("store1").(KVStore)
+	store1.Set(k, []byte("blows"))
+	multi.Commit()
+	store1.Set(k, []byte("calms"))
+	multi.Commit()
+
+	// the proof queries at an explicit height are cached
+	query := abci.RequestQuery{Path: "/store1/key", Data: k, Height: 1, Prove: true}
+	qres := multi.Query(query)
+	require.True(t, qres.IsOK())
+	require.Equal(t, []byte("blows"), qres.Value)
+	require.NotNil(t, qres.Proof)
+	require.Equal(t, 1, multi.queryCache.entries.Len())
+	require.Equal(t, qres, multi.Query(query))
+	require.Equal(t, float64(1), hits.Value())
+
+	// the queries without proof or at the latest height are not cached
+	multi.Query(abci.RequestQuery{Path: "/store1/key", Data: k, Height: 1})
+	multi.Query(abci.RequestQuery{Path: "/store1/key", Data: k, Prove: true})
+	require.Equal(t, 1, multi.queryCache.entries.Len())
+
+	// the responses of a pruned version are dropped
+	multi.Commit()
+	require.Equal(t, 0, multi.queryCache.entries.Len())
+	require.Equal(t, float64(1), invalidations.Value())
+	qres = multi.Query(query)
+	require.Nil(t, qres.Proof)
+	require.Equal(t, 0, multi.queryCache.entries.Len())
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/tendermint/tendermint/crypto/merkle"
 	"github.com/tendermint/tendermint/crypto/tmhash"
 	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
 
 	sdkproofs "github.com/cosmos/cosmos-sdk/store/proofs"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -33,6 +34,19 @@ type rootMultiStore struct {
 
 	traceWriter  io.Writer
 	traceContext TraceContext
+
+	// key filters of the iavl stores, disabled if keyFilterRange is 0, see SetKeyFilters
+	keyFilterRange  int64
+	keyFilterFPRate float64
+
+	// directory of the cold version files attached once loaded, see SetColdVersionsDir
+	coldVersionsDir string
+
+	// cache of the proof query responses, disabled if nil, see SetQueryCache
+	queryCache *queryCache
+
+	// decides which stores are committed and how the commit hash is computed, see SetUpgradeMgr
+	upgradeMgr *sdk.UpgradeManager
 }
 
 var _ CommitMultiStore = (*rootMultiStore)(nil)
@@ -42,9 +56,11 @@ var _ Queryable = (*rootMultiStore)(nil)
 func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 	return &rootMultiStore{
 		db:           db,
+		pruning:      sdk.PruneSyncable,
 		storesParams: make(map[StoreKey]storeParams),
 		stores:       make(map[StoreKey]CommitStore),
 		keysByName:   make(map[string]StoreKey),
+		upgradeMgr:   sdk.UpgradeMgr,
 	}
 }
 
@@ -80,6 +96,49 @@ func (rs *rootMultiStore) MountStoreWithDB(key StoreKey, typ StoreType, db dbm.D
 	rs.keysByName[key.Name()] = key
 }
 
+// SetShadowStore runs the shadow loaded by loader alongside the mounted iavl store of key,
+// the mismatches between them are logged to logger. It must be called before loading.
+func (rs *rootMultiStore) SetShadowStore(key StoreKey, loader ShadowStoreLoader, logger log.Logger) {
+	params, ok := rs.storesParams[key]
+	if !ok {
+		panic(fmt.Sprintf("store %v is not mounted", key))
+	}
+	if params.typ != sdk.StoreTypeIAVL {
+		panic(fmt.Sprintf("store %v is not an iavl store", key))
+	}
+	params.shadow = loader
+	params.shadowLogger = logger
+	rs.storesParams[key] = params
+}
+
+// SetKeyFilters keeps bloom filters of the keys of the iavl stores, see IavlStore.EnableKeyFilters.
+// It must be called before loading.
+func (rs *rootMultiStore) SetKeyFilters(versionRange int64, falsePositiveRate float64) error {
+	if _, err := newKeyFilters(versionRange, falsePositiveRate); err != nil {
+		return err
+	}
+	rs.keyFilterRange = versionRange
+	rs.keyFilterFPRate = falsePositiveRate
+	return nil
+}
+
+// SetQueryCache caches the responses of the proof queries at an explicit height, at most size of them. The
+// responses of a version are dropped once it is pruned. metrics may be nil.
+func (rs *rootMultiStore) SetQueryCache(size int, metrics *QueryCacheMetrics) error {
+	queryCache, err := newQueryCache(size, metrics)
+	if err != nil {
+		return err
+	}
+	rs.queryCache = queryCache
+	return nil
+}
+
+// SetUpgradeMgr replaces the upgrade manager of the process with the one of the app owning the store,
+// see sdk.Instance. It must be called before loading.
+func (rs *rootMultiStore) SetUpgradeMgr(upgradeMgr *sdk.UpgradeManager) {
+	rs.upgradeMgr = upgradeMgr
+}
+
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) GetCommitStore(key StoreKey) CommitStore {
 	return rs.stores[key]
@@ -108,6 +167,10 @@ func (rs *rootMultiStore) LoadLatestVersion() error {
 
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) LoadVersion(ver int64) error {
+	// the cached responses may be of versions above the loaded one
+	if rs.queryCache != nil {
+		rs.queryCache.purge()
+	}
 
 	// Special logic for version 0
 	if ver == 0 {
@@ -154,8 +217,13 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 	}
 
 	// Success.
-	rs.lastCommitID = cInfo.CommitID()
+	rs.lastCommitID = cInfo.commitID(rs.upgradeMgr)
 	rs.stores = newStores
+	if rs.coldVersionsDir != "" {
+		if err := rs.attachColdVersions(rs.coldVersionsDir); err != nil {
+			return fmt.Errorf("failed to attach cold versions: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -201,6 +269,42 @@ func (rs *rootMultiStore) LastCommitID() CommitID {
 	return rs.lastCommitID
 }
 
+// Implements CommitMultiStore.
+// All the stores are pruned the same way, they only differ by their first version when they
+// have been added by an upgrade, so the earliest version is the smallest one of the stores.
+func (rs *rootMultiStore) EarliestVersion() int64 {
+	earliest := rs.lastCommitID.Version
+	if earliest == 0 {
+		return 0
+	}
+	for _, store := range rs.stores {
+		if iavlStore, ok := store.(*IavlStore); ok {
+			if version := iavlStore.EarliestVersion(); version > 0 && version < earliest {
+				earliest = version
+			}
+		}
+	}
+	return earliest
+}
+
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) PinVersion(version int64) {
+	for _, store := range rs.stores {
+		if iavlStore, ok := unwrapIavlStore(store); ok {
+			iavlStore.PinVersion(version)
+		}
+	}
+}
+
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) UnpinVersion(version int64) {
+	for _, store := range rs.stores {
+		if iavlStore, ok := unwrapIavlStore(store); ok {
+			iavlStore.UnpinVersion(version)
+		}
+	}
+}
+
 // Implements Committer/CommitStore.
 func (rs *rootMultiStore) SetVersion(version int64) {}
 
@@ -208,7 +312,7 @@ func (rs *rootMultiStore) SetVersion(version int64) {}
 func (rs *rootMultiStore) Commit() CommitID {
 	version := rs.lastCommitID.Version + 1
 	// Commit stores.
-	commitInfo := commitStores(version, rs.stores)
+	commitInfo := commitStores(rs.upgradeMgr, version, rs.stores)
 
 	// Need to update atomically.
 	batch := rs.db.NewBatch()
@@ -220,12 +324,22 @@ func (rs *rootMultiStore) Commit() CommitID {
 	// Prepare for next version.
 	commitID := CommitID{
 		Version: version,
-		Hash:    commitInfo.Hash(),
+		Hash:    commitInfo.hash(rs.upgradeMgr),
 	}
 	rs.lastCommitID = commitID
+
+	if rs.queryCache != nil {
+		rs.queryCache.invalidate(rs.versionExists)
+	}
 	return commitID
 }
 
+// versionExists tells whether version of the iavl store storeName is still stored
+func (rs *rootMultiStore) versionExists(storeName string, version int64) bool {
+	iavlStore, ok := unwrapIavlStore(rs.getStoreByName(storeName))
+	return ok && iavlStore.VersionExists(version)
+}
+
 // Implements CacheWrapper/Store/CommitStore.
 func (rs *rootMultiStore) CacheWrap() CacheWrap {
 	return rs.CacheMultiStore().(CacheWrap)
@@ -277,6 +391,25 @@ func (rs *rootMultiStore) getStoreByName(name string) Store {
 	return rs.stores[key]
 }
 
+// AttachColdVersion attaches the cold version file of an iavl substore, the root hash of the file
+// is checked against the commit info of version, so it works even once version is pruned from the tree.
+func (rs *rootMultiStore) AttachColdVersion(key StoreKey, version int64, path string) error {
+	iavlStore, ok := unwrapIavlStore(rs.stores[key])
+	if !ok {
+		return fmt.Errorf("store %s is not an iavl store", key.Name())
+	}
+	cInfo, err := getCommitInfo(rs.db, version)
+	if err != nil {
+		return err
+	}
+	for _, storeInfo := range cInfo.StoreInfos {
+		if storeInfo.Name == key.Name() {
+			return iavlStore.attachColdVersion(version, storeInfo.GetHash(), path)
+		}
+	}
+	return fmt.Errorf("store %s is not in the commit info of version %d", key.Name(), version)
+}
+
 //---------------------- Query ------------------
 
 // Query calls substore.Query with the same `req` where `req.Path` is
@@ -284,6 +417,13 @@ func (rs *rootMultiStore) getStoreByName(name string) Store {
 // Ie. `req.Path` here is `/<substore>/<path>`, and trimmed to `/<path>` for the substore.
 // TODO: add proof for `multistore -> substore`.
 func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
+	if rs.queryCache != nil {
+		if res, ok := rs.queryCache.get(req); ok {
+			return res
+		}
+	}
+	origReq := req
+
 	// Query just routes this to a substore.
 	path := req.Path
 	storeName, subpath, err := parsePath(path)
@@ -316,7 +456,7 @@ func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 	}
 
 	if subpath == "/ics23-key" {
-		res.Proof.Ops = append(res.Proof.Ops, commitInfo.ProofOp(storeName))
+		res.Proof.Ops = append(res.Proof.Ops, commitInfo.ProofOp(rs.upgradeMgr, storeName))
 	} else {
 		// Restore origin path and append proof op.
 		res.Proof.Ops = append(res.Proof.Ops, NewMultiStoreProofOp(
@@ -325,6 +465,9 @@ func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 		).ProofOp())
 	}
 
+	if rs.queryCache != nil {
+		rs.queryCache.add(origReq, storeName, res)
+	}
 	return res
 }
 
@@ -360,6 +503,12 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
 		store, err = LoadIAVLStore(db, id, rs.pruning)
+		if err == nil && rs.keyFilterRange > 0 {
+			err = store.(*IavlStore).EnableKeyFilters(rs.keyFilterRange, rs.keyFilterFPRate)
+		}
+		if err == nil && params.shadow != nil {
+			store, err = rs.loadShadowStore(key, store.(CommitKVStore), id, params)
+		}
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
@@ -376,6 +525,21 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 	}
 }
 
+func (rs *rootMultiStore) loadShadowStore(key sdk.StoreKey, primary CommitKVStore, id CommitID, params storeParams) (CommitStore, error) {
+	var db dbm.DB
+	if params.db != nil {
+		db = dbm.NewPrefixDB(params.db, []byte("s/shadow/"))
+	} else {
+		db = dbm.NewPrefixDB(rs.db, []byte("s/shadow:"+params.key.Name()+"/"))
+	}
+	shadow, err := params.shadow(db, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the shadow of store %s: %v", key.Name(), err)
+	}
+	shadow.SetPruning(rs.pruning)
+	return newShadowStore(primary, shadow, key.Name(), params.shadowLogger), nil
+}
+
 func (rs *rootMultiStore) nameToKey(name string) StoreKey {
 	for key := range rs.storesParams {
 		if key.Name() == name {
@@ -392,6 +556,9 @@ type storeParams struct {
 	key StoreKey
 	db  dbm.DB
 	typ StoreType
+
+	shadow       ShadowStoreLoader
+	shadowLogger log.Logger
 }
 
 //----------------------------------------
@@ -418,10 +585,14 @@ func (si StoreInfo) GetHash() []byte {
 	return si.Core.CommitID.Hash
 }
 
-// Hash returns the simple merkle root hash of the stores sorted by name.
+// Hash returns the simple merkle root hash of the stores sorted by name, as of the upgrades of the process.
 func (ci CommitInfo) Hash() []byte {
+	return ci.hash(sdk.UpgradeMgr)
+}
+
+func (ci CommitInfo) hash(upgradeMgr *sdk.UpgradeManager) []byte {
 	m := make(map[string][]byte, len(ci.StoreInfos))
-	if sdk.IsUpgrade(sdk.BEP171) {
+	if upgradeMgr.IsUpgrade(sdk.BEP171) {
 		for _, storeInfo := range ci.StoreInfos {
 			m[storeInfo.Name] = storeInfo.GetHash()
 		}
@@ -435,15 +606,19 @@ func (ci CommitInfo) Hash() []byte {
 }
 
 func (ci CommitInfo) CommitID() CommitID {
+	return ci.commitID(sdk.UpgradeMgr)
+}
+
+func (ci CommitInfo) commitID(upgradeMgr *sdk.UpgradeManager) CommitID {
 	return CommitID{
 		Version: ci.Version,
-		Hash:    ci.Hash(),
+		Hash:    ci.hash(upgradeMgr),
 	}
 }
 
-func (ci CommitInfo) toMap() map[string][]byte {
+func (ci CommitInfo) toMap(upgradeMgr *sdk.UpgradeManager) map[string][]byte {
 	m := make(map[string][]byte, len(ci.StoreInfos))
-	if sdk.IsUpgrade(sdk.BEP171) {
+	if upgradeMgr.IsUpgrade(sdk.BEP171) {
 		for _, storeInfo := range ci.StoreInfos {
 			m[storeInfo.Name] = storeInfo.Core.CommitID.Hash
 		}
@@ -456,8 +631,8 @@ func (ci CommitInfo) toMap() map[string][]byte {
 	return m
 }
 
-func (ci CommitInfo) ProofOp(storeName string) merkle.ProofOp {
-	cmap := ci.toMap()
+func (ci CommitInfo) ProofOp(upgradeMgr *sdk.UpgradeManager, storeName string) merkle.ProofOp {
+	cmap := ci.toMap(upgradeMgr)
 	_, proofs, _ := merkle.SimpleProofsFromMap(cmap)
 
 	proof := proofs[storeName]
@@ -534,16 +709,16 @@ func setLatestVersion(batch dbm.Batch, version int64) {
 }
 
 // Commits each store and returns a new CommitInfo.
-func commitStores(version int64, storeMap map[StoreKey]CommitStore) CommitInfo {
+func commitStores(upgradeMgr *sdk.UpgradeManager, version int64, storeMap map[StoreKey]CommitStore) CommitInfo {
 	storeInfos := make([]StoreInfo, 0, len(storeMap))
 
 	for key, store := range storeMap {
-		if !sdk.ShouldCommitStore(key.Name()) {
+		if !upgradeMgr.ShouldCommitStore(key.Name()) {
 			continue
 		}
 
 		// set version for store to commit, just to keep the same as the other stores
-		if sdk.ShouldSetStoreVersion(key.Name()) {
+		if upgradeMgr.ShouldSetStoreVersion(key.Name()) {
 			store.SetVersion(version - 1)
 		}
 
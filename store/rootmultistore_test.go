@@ -148,7 +148,7 @@ func TestMultiStoreQueryICS23Proof(t *testing.T) {
 
 	commitInfo, _ := getCommitInfo(db, cid.Version)
 
-	cmap := commitInfo.toMap()
+	cmap := commitInfo.toMap(sdk.UpgradeMgr)
 	_, proofs, _ := merkle.SimpleProofsFromMap(cmap)
 
 	proof := proofs["store1"]
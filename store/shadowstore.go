@@ -0,0 +1,192 @@
+package store
+
+import (
+	"bytes"
+	"io"
+	"sync/atomic"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ShadowStoreLoader loads the shadow of a substore from its own db, at the commit id
+// the shadow had when the substore was committed at id.Version.
+type ShadowStoreLoader func(db dbm.DB, id CommitID) (CommitKVStore, error)
+
+// shadowStore runs a shadow implementation of a store alongside the primary one, to verify
+// a refactored store against the existing one on a live node. The writes are applied to
+// both, the results of the reads, of the iterations and the root hashes are compared and
+// the mismatches are logged. The results are always the ones of the primary, so the shadow
+// never affects consensus.
+type shadowStore struct {
+	CommitKVStore
+	shadow     CommitKVStore
+	name       string
+	logger     log.Logger
+	mismatches *int64
+}
+
+var _ CommitKVStore = shadowStore{}
+var _ Queryable = shadowStore{}
+
+func newShadowStore(primary, shadow CommitKVStore, name string, logger log.Logger) shadowStore {
+	return shadowStore{
+		CommitKVStore: primary,
+		shadow:        shadow,
+		name:          name,
+		logger:        logger.With("module", "store", "store", name),
+		mismatches:    new(int64),
+	}
+}
+
+// Mismatches returns the number of mismatches found since the store has been loaded
+func (ss shadowStore) Mismatches() int64 {
+	return atomic.LoadInt64(ss.mismatches)
+}
+
+func (ss shadowStore) mismatch(msg string, keyvals ...interface{}) {
+	atomic.AddInt64(ss.mismatches, 1)
+	ss.logger.Error("Shadow store mismatch: "+msg, keyvals...)
+}
+
+// Get implements KVStore
+func (ss shadowStore) Get(key []byte) []byte {
+	value := ss.CommitKVStore.Get(key)
+	if shadowValue := ss.shadow.Get(key); !bytes.Equal(value, shadowValue) {
+		ss.mismatch("get", "key", key, "value", value, "shadow", shadowValue)
+	}
+	return value
+}
+
+// Has implements KVStore
+func (ss shadowStore) Has(key []byte) bool {
+	has := ss.CommitKVStore.Has(key)
+	if shadowHas := ss.shadow.Has(key); has != shadowHas {
+		ss.mismatch("has", "key", key, "has", has, "shadow", shadowHas)
+	}
+	return has
+}
+
+// Set implements KVStore
+func (ss shadowStore) Set(key, value []byte) {
+	ss.CommitKVStore.Set(key, value)
+	ss.shadow.Set(key, value)
+}
+
+// Delete implements KVStore
+func (ss shadowStore) Delete(key []byte) {
+	ss.CommitKVStore.Delete(key)
+	ss.shadow.Delete(key)
+}
+
+// Iterator implements KVStore
+func (ss shadowStore) Iterator(start, end []byte) Iterator {
+	return newShadowIterator(ss, ss.CommitKVStore.Iterator(start, end), ss.shadow.Iterator(start, end))
+}
+
+// ReverseIterator implements KVStore
+func (ss shadowStore) ReverseIterator(start, end []byte) Iterator {
+	return newShadowIterator(ss, ss.CommitKVStore.ReverseIterator(start, end), ss.shadow.ReverseIterator(start, end))
+}
+
+// Prefix implements KVStore
+func (ss shadowStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{ss, prefix}
+}
+
+// CacheWrap implements Store, the cache writes through the shadow store
+func (ss shadowStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(ss)
+}
+
+// CacheWrapWithTrace implements Store
+func (ss shadowStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(ss, w, tc))
+}
+
+// SetPruning implements Committer
+func (ss shadowStore) SetPruning(pruning sdk.PruningStrategy) {
+	ss.CommitKVStore.SetPruning(pruning)
+	ss.shadow.SetPruning(pruning)
+}
+
+// SetVersion implements Committer
+func (ss shadowStore) SetVersion(version int64) {
+	ss.CommitKVStore.SetVersion(version)
+	ss.shadow.SetVersion(version)
+}
+
+// Commit implements Committer, the root hashes of the primary and the shadow are compared
+func (ss shadowStore) Commit() CommitID {
+	id := ss.CommitKVStore.Commit()
+	if shadowID := ss.shadow.Commit(); !bytes.Equal(id.Hash, shadowID.Hash) || id.Version != shadowID.Version {
+		ss.mismatch("commit", "commitID", id, "shadow", shadowID)
+	}
+	return id
+}
+
+// Query implements Queryable, the queries are answered by the primary only
+func (ss shadowStore) Query(req abci.RequestQuery) abci.ResponseQuery {
+	queryable, ok := ss.CommitKVStore.(Queryable)
+	if !ok {
+		return sdk.ErrUnknownRequest("store doesn't support queries").QueryResult()
+	}
+	return queryable.Query(req)
+}
+
+// shadowIterator iterates the primary and the shadow in lockstep, comparing their entries
+type shadowIterator struct {
+	Iterator
+	shadow   Iterator
+	store    shadowStore
+	diverged bool
+}
+
+func newShadowIterator(store shadowStore, primary, shadow Iterator) *shadowIterator {
+	it := &shadowIterator{Iterator: primary, shadow: shadow, store: store}
+	it.compare()
+	return it
+}
+
+// compare logs the first entry at which the iterations diverge
+func (it *shadowIterator) compare() {
+	if it.diverged {
+		return
+	}
+	valid, shadowValid := it.Iterator.Valid(), it.shadow.Valid()
+	switch {
+	case valid != shadowValid:
+		it.diverged = true
+		it.store.mismatch("iteration end", "valid", valid, "shadow", shadowValid)
+	case valid && (!bytes.Equal(it.Iterator.Key(), it.shadow.Key()) || !bytes.Equal(it.Iterator.Value(), it.shadow.Value())):
+		it.diverged = true
+		it.store.mismatch("iteration", "key", it.Iterator.Key(), "shadowKey", it.shadow.Key())
+	}
+}
+
+// Next implements Iterator
+func (it *shadowIterator) Next() {
+	it.Iterator.Next()
+	if !it.diverged {
+		it.shadow.Next()
+		it.compare()
+	}
+}
+
+// Close implements Iterator
+func (it *shadowIterator) Close() {
+	it.Iterator.Close()
+	it.shadow.Close()
+}
+
+// unwrapIavlStore returns the iavl store of a substore, which may be the primary of a shadowStore
+func unwrapIavlStore(store Store) (*IavlStore, bool) {
+	if ss, ok := store.(shadowStore); ok {
+		store = ss.CommitKVStore
+	}
+	iavlStore, ok := store.(*IavlStore)
+	return iavlStore, ok
+}
@@ -0,0 +1,83 @@
+package store
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// lossyStore drops the writes of a key
+type lossyStore struct {
+	CommitKVStore
+	lost []byte
+}
+
+func (ls lossyStore) Set(key, value []byte) {
+	if !bytes.Equal(key, ls.lost) {
+		ls.CommitKVStore.Set(key, value)
+	}
+}
+
+func TestShadowStore(t *testing.T) {
+	loader := func(lost []byte) ShadowStoreLoader {
+		return func(db dbm.DB, id CommitID) (CommitKVStore, error) {
+			store, err := LoadIAVLStore(db, id, sdk.PruneSyncable)
+			if err != nil {
+				return nil, err
+			}
+			return lossyStore{CommitKVStore: store.(CommitKVStore), lost: lost}, nil
+		}
+	}
+	load := func(db dbm.DB, lost []byte) (*rootMultiStore, shadowStore) {
+		multi := newMultiStoreWithMounts(db)
+		multi.SetShadowStore(multi.keysByName["store1"], loader(lost), log.NewNopLogger())
+		require.Nil(t, multi.LoadLatestVersion())
+		return multi, multi.getStoreByName("store1").(shadowStore)
+	}
+
+	// the same implementation never mismatches
+	db := dbm.NewMemDB()
+	multi, store := load(db, nil)
+	store.Set([]byte("a"), []byte("1"))
+	store.Set([]byte("b"), []byte("2"))
+	cache := multi.CacheMultiStore()
+	cache.GetKVStore(multi.keysByName["store1"]).Set([]byte("c"), []byte("3"))
+	cache.Write()
+	require.Equal(t, []byte("3"), store.Get([]byte("c")))
+	iter := store.Iterator(nil, nil)
+	for ; iter.Valid(); iter.Next() {
+	}
+	iter.Close()
+	commitID := multi.Commit()
+	require.Zero(t, store.Mismatches())
+
+	// the shadow is loaded at the same version, the queries are still answered by the primary
+	multi, store = load(db, []byte("d"))
+	require.Equal(t, commitID, multi.LastCommitID())
+	require.Equal(t, []byte("1"), store.Get([]byte("a")))
+	require.Zero(t, store.Mismatches())
+	_, ok := unwrapIavlStore(store)
+	require.True(t, ok)
+
+	// a lost write mismatches on the reads, the iterations and the commit
+	store.Set([]byte("d"), []byte("4"))
+	require.Equal(t, []byte("4"), store.Get([]byte("d")))
+	require.Equal(t, int64(1), store.Mismatches())
+	require.True(t, store.Has([]byte("d")))
+	require.Equal(t, int64(2), store.Mismatches())
+	iter = store.Iterator([]byte("c"), nil)
+	require.Equal(t, []byte("c"), iter.Key())
+	iter.Next()
+	require.Equal(t, []byte("d"), iter.Key())
+	iter.Next()
+	require.False(t, iter.Valid())
+	iter.Close()
+	require.Equal(t, int64(3), store.Mismatches())
+	multi.Commit()
+	require.Equal(t, int64(4), store.Mismatches())
+}
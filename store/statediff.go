@@ -0,0 +1,71 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// KVDiff is the change of a key written to a store, a nil NewValue means the key has been deleted
+type KVDiff struct {
+	Store    string
+	Key      []byte
+	OldValue []byte
+	NewValue []byte
+}
+
+// diffKVStore records the changes written to its parent
+type diffKVStore struct {
+	KVStore
+	name   string
+	record func(KVDiff)
+}
+
+// Set implements KVStore
+func (ds diffKVStore) Set(key, value []byte) {
+	if old := ds.KVStore.Get(key); !bytes.Equal(old, value) {
+		ds.record(KVDiff{Store: ds.name, Key: key, OldValue: old, NewValue: value})
+	}
+	ds.KVStore.Set(key, value)
+}
+
+// Delete implements KVStore
+func (ds diffKVStore) Delete(key []byte) {
+	if old := ds.KVStore.Get(key); old != nil {
+		ds.record(KVDiff{Store: ds.name, Key: key, OldValue: old})
+	}
+	ds.KVStore.Delete(key)
+}
+
+// CacheMultiStoreWithStateDiff cache-wraps ms like ms.CacheMultiStore(), and passes every
+// change flushed to ms by Write to record, with the value it overwrites. It is meant for
+// debugging, e.g. to compare the state changes of a tx between two versions of the app.
+func CacheMultiStoreWithStateDiff(ms MultiStore, record func(KVDiff)) CacheMultiStore {
+	return cacheMultiStoreWithParents(ms, "state diff", func(key StoreKey, parent KVStore) KVStore {
+		return diffKVStore{KVStore: parent, name: key.Name(), record: record}
+	})
+}
+
+// cacheMultiStoreWithParents cache-wraps the stores of ms, each cache is on top of
+// the store returned by wrap instead of the store of ms
+func cacheMultiStoreWithParents(ms MultiStore, what string, wrap func(key StoreKey, parent KVStore) KVStore) CacheMultiStore {
+	cms, ok := ms.(cacheMultiStore)
+	if !ok {
+		panic(fmt.Sprintf("%s of a %T is not supported", what, ms))
+	}
+
+	cms2 := cacheMultiStore{
+		db:           NewCacheKVStore(cms.db),
+		stores:       make(map[StoreKey]CacheWrap, len(cms.stores)),
+		keysByName:   cms.keysByName,
+		traceWriter:  cms.traceWriter,
+		traceContext: cms.traceContext,
+	}
+	for key, store := range cms.stores {
+		parent := wrap(key, store.(KVStore))
+		if cms2.TracingEnabled() {
+			parent = NewTraceKVStore(parent, cms2.traceWriter, cms2.traceContext)
+		}
+		cms2.stores[key] = NewCacheKVStore(parent)
+	}
+	return cms2
+}
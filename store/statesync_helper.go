@@ -94,6 +94,14 @@ func NewStateSyncHelper(
 	return &helper
 }
 
+// upgradeMgr returns the upgrade manager of the multistore, see rootMultiStore.SetUpgradeMgr
+func (helper *StateSyncHelper) upgradeMgr() *sdk.UpgradeManager {
+	if rs, ok := helper.commitMS.(*rootMultiStore); ok {
+		return rs.upgradeMgr
+	}
+	return sdk.UpgradeMgr
+}
+
 // not all key in cms is committed
 // for example the BEP9 timelock store upgrade will not commit the newly added store until upgrade height
 func (helper *StateSyncHelper) getCommitedSortedStoreKeys() []sdk.StoreKey {
@@ -101,17 +109,15 @@ func (helper *StateSyncHelper) getCommitedSortedStoreKeys() []sdk.StoreKey {
 	names := make([]string, 0, len(kvStores))
 	nameToKey := make(map[string]sdk.StoreKey, len(kvStores))
 	for key, store := range kvStores {
-		if !sdk.ShouldCommitStore(key.Name()) {
+		if !helper.upgradeMgr().ShouldCommitStore(key.Name()) {
 			continue
 		}
 
-		switch store.(type) {
-		case *IavlStore:
+		if _, ok := unwrapIavlStore(store); ok {
 			nameToKey[key.Name()] = key
 			names = append(names, key.Name())
-		default:
-			// deliberately do nothing other store type doesn't effect app hash
 		}
+		// deliberately do nothing other store type doesn't effect app hash
 	}
 	sort.Strings(names)
 	storeKeys := make([]sdk.StoreKey, 0, len(names))
@@ -122,11 +128,17 @@ func (helper *StateSyncHelper) getCommitedSortedStoreKeys() []sdk.StoreKey {
 }
 
 // Split Init method and NewStateSyncHelper for snapshot command
+// The heights of the snapshots are unpinned once they are taken, see TakeSnapshot.
 func (helper *StateSyncHelper) Init(lastBreatheBlockHeight int64) {
-	go helper.ReloadSnapshotRoutine(lastBreatheBlockHeight, 0)
+	helper.commitMS.PinVersion(lastBreatheBlockHeight)
+	go func() {
+		helper.ReloadSnapshotRoutine(lastBreatheBlockHeight, 0)
+		helper.commitMS.UnpinVersion(lastBreatheBlockHeight)
+	}()
 	go func() {
 		for height := range helper.SnapshotHeights {
 			helper.ReloadSnapshotRoutine(height, snapshotRetry)
+			helper.commitMS.UnpinVersion(height)
 		}
 	}()
 	go func() {
@@ -136,10 +148,31 @@ func (helper *StateSyncHelper) Init(lastBreatheBlockHeight int64) {
 	}()
 }
 
+// TakeSnapshot queues a snapshot of the state at height, the version is pinned so that it can't
+// be pruned until the snapshot is taken. It doesn't block, the snapshot is skipped if the queue is full.
+func (helper *StateSyncHelper) TakeSnapshot(height int64) {
+	helper.commitMS.PinVersion(height)
+	select {
+	case helper.SnapshotHeights <- height:
+	default:
+		helper.commitMS.UnpinVersion(height)
+		helper.logger.Error("skipped snapshot, too many snapshots in progress", "height", height)
+	}
+}
+
+// RemoveSnapshot queues the deletion of the snapshot at height, it doesn't block
+func (helper *StateSyncHelper) RemoveSnapshot(height int64) {
+	select {
+	case helper.HeightsToDelete <- height:
+	default:
+		helper.logger.Error("skipped snapshot deletion, too many deletions in progress", "height", height)
+	}
+}
+
 func (helper *StateSyncHelper) StartRecovery(manifest *abci.Manifest) error {
 	helper.logger.Info("start recovery")
 
-	sdk.UpgradeMgr.SetHeight(manifest.Height)
+	helper.upgradeMgr().SetHeight(manifest.Height)
 	storeKeys := helper.getCommitedSortedStoreKeys()
 
 	helper.manifest = manifest
@@ -435,7 +468,8 @@ func (helper *StateSyncHelper) takeSnapshotImpl(height int64, retry int) {
 			store := helper.commitMS.GetKVStore(key)
 			// TODO: use Iterator method of store interface, no longer rely on implementation of KVStore
 			// as we only append storeKeys for IavlStore at constructor, so this type assertion should never fail
-			mutableTree := store.(*IavlStore).Tree
+			iavlStore, _ := unwrapIavlStore(store)
+			mutableTree := iavlStore.Tree
 			if tree, err := mutableTree.GetImmutable(height); err == nil {
 				tree.IterateFirst(func(nodeBytes []byte) {
 					nodeBytesLength := len(nodeBytes)
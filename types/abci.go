@@ -1,15 +1,45 @@
 package types
 
-import abci "github.com/tendermint/tendermint/abci/types"
+import (
+	"io"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
 
 // initialize application state at genesis
 type InitChainer func(ctx Context, req abci.RequestInitChain) abci.ResponseInitChain
 
+// initialize application state at genesis while decoding the app state from appState,
+// so that the decoded genesis state never has to be held in memory at once, see ModuleManager.InitGenesisFromReader
+type StreamingInitChainer func(ctx Context, req abci.RequestInitChain, appState io.Reader) (abci.ResponseInitChain, error)
+
 // run code before the transactions in a block
 type BeginBlocker func(ctx Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock
 
 // run code after the transactions in a block and return updates to the validator set
 type EndBlocker func(ctx Context, req abci.RequestEndBlock) abci.ResponseEndBlock
 
+// run cheap deterministic sanity checks on a received block before any of its txs is executed,
+// e.g. caps on the number of txs. Returning an error rejects every tx of the block.
+type ProposalChecker func(ctx Context, header abci.Header) Error
+
+// run cheap deterministic sanity checks on a single tx before it is executed, e.g. msg types that
+// are forbidden after a sunset height. It is also a mempool check: it runs in CheckTx, ReCheckTx and
+// simulation as well as in DeliverTx, so txs that would be rejected in a block never get proposed.
+// It may record state across the txs of a block, e.g. in a transient store: the writes to ctx are
+// kept only if the tx succeeds, and a rejected tx fails alone.
+type ProposalTxChecker func(ctx Context, tx Tx) Error
+
+// verify invariants over the tx list of a block, e.g. at most one oracle claim per validator. The txs
+// are fed in block order before they are executed: DeliverTxs feeds the whole list before executing any
+// tx, DeliverTx feeds each tx right before executing it as ABCI does not expose the list earlier.
+// A rejected tx and all the following txs of the block fail with the error.
+type BlockValidator interface {
+	ValidateTx(ctx Context, tx Tx) Error
+}
+
+// create the BlockValidator of a block, it is called in BeginBlock
+type BlockValidatorCreator func(ctx Context, header abci.Header) BlockValidator
+
 // respond to p2p filtering queries from Tendermint
 type PeerFilter func(info string) abci.ResponseQuery
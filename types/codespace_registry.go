@@ -0,0 +1,66 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// CodespaceInfo describes a registered codespace and the codes of its errors
+type CodespaceInfo struct {
+	Codespace CodespaceType `json:"codespace"`
+	Module    string        `json:"module"`
+	Codes     []CodeType    `json:"codes"`
+}
+
+var codespaceRegistry = struct {
+	sync.RWMutex
+	byCodespace map[CodespaceType]CodespaceInfo
+	byModule    map[string]CodespaceType
+}{
+	byCodespace: make(map[CodespaceType]CodespaceInfo),
+	byModule:    make(map[string]CodespaceType),
+}
+
+// RegisterCodespace registers the default codespace of a module with the codes of its errors,
+// typically in the init of the package defining them. It panics if the codespace or the module
+// is already registered, so that two modules can't share a codespace and confuse the clients
+// decoding the errors. The codes may repeat, e.g. a root code aliased by a module.
+func RegisterCodespace(codespace CodespaceType, module string, codes ...CodeType) {
+	if codespace == CodespaceUndefined || codespace == MaximumCodespace {
+		panic(fmt.Sprintf("invalid codespace %d for module %s", codespace, module))
+	}
+	codespaceRegistry.Lock()
+	defer codespaceRegistry.Unlock()
+	if info, ok := codespaceRegistry.byCodespace[codespace]; ok {
+		panic(fmt.Sprintf("codespace %d of module %s is already registered by module %s", codespace, module, info.Module))
+	}
+	if registered, ok := codespaceRegistry.byModule[module]; ok {
+		panic(fmt.Sprintf("module %s is already registered with codespace %d", module, registered))
+	}
+
+	unique := make(map[CodeType]struct{}, len(codes))
+	sorted := make([]CodeType, 0, len(codes))
+	for _, code := range codes {
+		if _, ok := unique[code]; !ok {
+			unique[code] = struct{}{}
+			sorted = append(sorted, code)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	codespaceRegistry.byCodespace[codespace] = CodespaceInfo{Codespace: codespace, Module: module, Codes: sorted}
+	codespaceRegistry.byModule[module] = codespace
+}
+
+// RegisteredCodespaces returns the registered codespaces, sorted by codespace
+func RegisteredCodespaces() []CodespaceInfo {
+	codespaceRegistry.RLock()
+	defer codespaceRegistry.RUnlock()
+	infos := make([]CodespaceInfo, 0, len(codespaceRegistry.byCodespace))
+	for _, info := range codespaceRegistry.byCodespace {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Codespace < infos[j].Codespace })
+	return infos
+}
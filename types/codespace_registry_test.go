@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterCodespace(t *testing.T) {
+	// the root codespace is registered at init
+	infos := RegisteredCodespaces()
+	require.Equal(t, CodespaceRoot, infos[0].Codespace)
+	require.Equal(t, "sdk", infos[0].Module)
+	require.Contains(t, infos[0].Codes, CodeTxRateLimited)
+
+	// the codes are sorted without duplicates
+	RegisterCodespace(CodespaceType(60000), "testRegistry", CodeType(102), CodeInternal, CodeType(102))
+	infos = RegisteredCodespaces()
+	require.Equal(t, CodespaceInfo{
+		Codespace: CodespaceType(60000),
+		Module:    "testRegistry",
+		Codes:     []CodeType{CodeInternal, CodeType(102)},
+	}, infos[len(infos)-1])
+
+	// a codespace or a module can't be registered twice
+	require.Panics(t, func() { RegisterCodespace(CodespaceType(60000), "otherModule") })
+	require.Panics(t, func() { RegisterCodespace(CodespaceType(60001), "testRegistry") })
+	require.Panics(t, func() { RegisterCodespace(CodespaceUndefined, "undefined") })
+	require.Equal(t, len(infos), len(RegisteredCodespaces()))
+}
@@ -30,6 +30,7 @@ type Context struct {
 	sideChainKeyPrefix []byte
 	sideChainId        string
 	crossStake         bool
+	upgradeMgr         *UpgradeManager
 }
 
 // create a new context
@@ -123,6 +124,15 @@ func (c Context) CrossStake() bool {
 	return c.crossStake
 }
 
+// UpgradeMgr returns the upgrade manager of the app the context belongs to, or the one of the process
+// if the context was not created by an app, see WithUpgradeMgr
+func (c Context) UpgradeMgr() *UpgradeManager {
+	if c.upgradeMgr == nil {
+		return UpgradeMgr
+	}
+	return c.upgradeMgr
+}
+
 //----------------------------------------
 // With* (setting a value)
 
@@ -234,6 +244,11 @@ func (c Context) WithCrossStake(crossStake bool) Context {
 	return c
 }
 
+func (c Context) WithUpgradeMgr(upgradeMgr *UpgradeManager) Context {
+	c.upgradeMgr = upgradeMgr
+	return c
+}
+
 // is context nil
 func (c Context) IsZero() bool {
 	return c.ctx == nil && c.ms == nil
@@ -85,6 +85,13 @@ type CrossChainApplication interface {
 	ExecuteFailAckPackage(ctx Context, payload []byte) ExecuteResult
 }
 
+// CrossChainPackageValuer is optionally implemented by a CrossChainApplication whose packages
+// carry value, so that the oracle can delay the execution of high value packages.
+type CrossChainPackageValuer interface {
+	// PackageValue returns the value carried by the payload (without header) in the smallest unit of the native token
+	PackageValue(ctx Context, payload []byte, packageType CrossChainPackageType) (int64, error)
+}
+
 type ExecuteResult struct {
 	Err     Error
 	Tags    Tags
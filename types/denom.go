@@ -0,0 +1,245 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// MaxDenomExponent is the largest exponent a denom may declare. 10^18 is the
+// largest power of ten that still fits in an int64.
+const MaxDenomExponent = 18
+
+// DenomMetadata describes how the raw integer amount of a denom is scaled for display,
+// e.g. an Exponent of 8 means the raw amount 150000000 is displayed as 1.5
+type DenomMetadata struct {
+	Denom    string `json:"denom"`
+	Exponent uint8  `json:"exponent"`
+}
+
+// DenomRegistry keeps track of the display metadata of known denoms.
+// Denoms that are not registered fall back to the default exponent.
+type DenomRegistry struct {
+	mtx             sync.RWMutex
+	defaultExponent uint8
+	metas           map[string]DenomMetadata
+}
+
+var denomRegistry = NewDenomRegistry(Precision)
+
+func init() {
+	denomRegistry.Register(DenomMetadata{Denom: NativeTokenSymbol, Exponent: Precision})
+}
+
+// GetDenomRegistry returns the global denom registry used by the display helpers
+func GetDenomRegistry() *DenomRegistry {
+	return denomRegistry
+}
+
+// NewDenomRegistry creates an empty registry with the given default exponent
+func NewDenomRegistry(defaultExponent uint8) *DenomRegistry {
+	if defaultExponent > MaxDenomExponent {
+		panic(fmt.Sprintf("default exponent %d exceeds maximum %d", defaultExponent, MaxDenomExponent))
+	}
+	return &DenomRegistry{
+		defaultExponent: defaultExponent,
+		metas:           make(map[string]DenomMetadata),
+	}
+}
+
+// Register adds or replaces the metadata of a denom, and panics if the metadata is invalid
+func (r *DenomRegistry) Register(meta DenomMetadata) {
+	if len(meta.Denom) == 0 {
+		panic("denom of metadata should not be empty")
+	}
+	if meta.Exponent > MaxDenomExponent {
+		panic(fmt.Sprintf("exponent %d of denom %s exceeds maximum %d", meta.Exponent, meta.Denom, MaxDenomExponent))
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.metas[meta.Denom] = meta
+}
+
+// Metadata returns the registered metadata of a denom, the bool is false if the denom is unknown
+func (r *DenomRegistry) Metadata(denom string) (DenomMetadata, bool) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	meta, ok := r.metas[denom]
+	return meta, ok
+}
+
+// Exponent returns the display exponent of a denom, or the default exponent if it is unknown
+func (r *DenomRegistry) Exponent(denom string) uint8 {
+	if meta, ok := r.Metadata(denom); ok {
+		return meta.Exponent
+	}
+	return r.defaultExponent
+}
+
+// FormatCoin formats a coin using the denom exponent, e.g. 150000000BNB => "1.5:BNB"
+func (r *DenomRegistry) FormatCoin(coin Coin) string {
+	return fmt.Sprintf("%s:%s", FormatAmount(coin.Amount, r.Exponent(coin.Denom)), coin.Denom)
+}
+
+// FormatCoins formats a set of coins, separated by commas
+func (r *DenomRegistry) FormatCoins(coins Coins) string {
+	strs := make([]string, 0, len(coins))
+	for _, coin := range coins {
+		strs = append(strs, r.FormatCoin(coin))
+	}
+	return strings.Join(strs, ",")
+}
+
+// DisplayCoin is a coin whose amount is formatted with the denom exponent,
+// e.g. {"denom": "BNB", "amount": "1.5"}
+type DisplayCoin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// DisplayCoins converts a set of coins into their display form
+func (r *DenomRegistry) DisplayCoins(coins Coins) []DisplayCoin {
+	res := make([]DisplayCoin, 0, len(coins))
+	for _, coin := range coins {
+		res = append(res, DisplayCoin{Denom: coin.Denom, Amount: FormatAmount(coin.Amount, r.Exponent(coin.Denom))})
+	}
+	return res
+}
+
+// ParseCoin parses a display coin expression like "1.5:BNB" into the raw coin
+func (r *DenomRegistry) ParseCoin(coinStr string) (coin Coin, err error) {
+	coinStr = strings.TrimSpace(coinStr)
+
+	matches := reDisplayCoin.FindStringSubmatch(coinStr)
+	if matches == nil {
+		return coin, fmt.Errorf("invalid display coin expression: %s", coinStr)
+	}
+	denomStr, amountStr := matches[2], matches[1]
+
+	amount, err := ParseAmount(amountStr, r.Exponent(denomStr))
+	if err != nil {
+		return coin, err
+	}
+	return Coin{denomStr, amount}, nil
+}
+
+// ParseCoins parses display coin expressions separated by commas.
+// If nothing is provided, it returns nil Coins. Returned coins are sorted.
+func (r *DenomRegistry) ParseCoins(coinsStr string) (coins Coins, err error) {
+	coinsStr = strings.TrimSpace(coinsStr)
+	if len(coinsStr) == 0 {
+		return nil, nil
+	}
+
+	for _, coinStr := range strings.Split(coinsStr, ",") {
+		coin, err := r.ParseCoin(coinStr)
+		if err != nil {
+			return nil, err
+		}
+		coins = append(coins, coin)
+	}
+
+	coins.Sort()
+	if !coins.IsValid() {
+		return nil, fmt.Errorf("parseCoins invalid: %#v", coins)
+	}
+	return coins, nil
+}
+
+//----------------------------------------
+// Amount formatting
+
+var (
+	reDisplayAmt  = `-?[[:digit:]]+(?:\.[[:digit:]]+)?`
+	reDisplayCoin = regexp.MustCompile(fmt.Sprintf(`^(%s)%s(%s)$`, reDisplayAmt, reSpc, reDnm))
+	reAmount      = regexp.MustCompile(fmt.Sprintf(`^%s$`, reDisplayAmt))
+)
+
+// FormatAmount renders a raw amount with the decimal point shifted left by exponent digits.
+// Trailing zeros of the fraction are trimmed and no grouping separators are used,
+// so the output does not depend on any locale, e.g. FormatAmount(150000000, 8) == "1.5"
+func FormatAmount(amount int64, exponent uint8) string {
+	if exponent > MaxDenomExponent {
+		panic(fmt.Sprintf("exponent %d exceeds maximum %d", exponent, MaxDenomExponent))
+	}
+
+	sign := ""
+	abs := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		// avoid overflow on math.MinInt64
+		abs = uint64(-(amount + 1)) + 1
+	}
+	if exponent == 0 {
+		return fmt.Sprintf("%s%d", sign, abs)
+	}
+
+	unit := uint64(1)
+	for i := uint8(0); i < exponent; i++ {
+		unit *= 10
+	}
+	whole, frac := abs/unit, abs%unit
+	if frac == 0 {
+		return fmt.Sprintf("%s%d", sign, whole)
+	}
+	fracStr := strings.TrimRight(fmt.Sprintf("%0*d", exponent, frac), "0")
+	return fmt.Sprintf("%s%d.%s", sign, whole, fracStr)
+}
+
+// ParseAmount is the inverse of FormatAmount. It only accepts plain decimal notation
+// (no grouping separators, exponents or surrounding spaces) and rejects inputs
+// with more fractional digits than exponent or that overflow int64.
+func ParseAmount(amountStr string, exponent uint8) (int64, error) {
+	if exponent > MaxDenomExponent {
+		return 0, fmt.Errorf("exponent %d exceeds maximum %d", exponent, MaxDenomExponent)
+	}
+	if !reAmount.MatchString(amountStr) {
+		return 0, fmt.Errorf("invalid amount expression: %q", amountStr)
+	}
+
+	neg := strings.HasPrefix(amountStr, "-")
+	digits := strings.TrimPrefix(amountStr, "-")
+	whole, frac := digits, ""
+	if idx := strings.IndexByte(digits, '.'); idx >= 0 {
+		whole, frac = digits[:idx], digits[idx+1:]
+	}
+	if len(frac) > int(exponent) {
+		return 0, fmt.Errorf("amount %s has more than %d decimal places", amountStr, exponent)
+	}
+	frac += strings.Repeat("0", int(exponent)-len(frac))
+
+	value, ok := new(big.Int).SetString(whole+frac, 10)
+	if !ok {
+		return 0, fmt.Errorf("invalid amount expression: %q", amountStr)
+	}
+	if neg {
+		value.Neg(value)
+	}
+	if !value.IsInt64() {
+		return 0, fmt.Errorf("amount %s overflows int64", amountStr)
+	}
+	return value.Int64(), nil
+}
+
+// FormatCoin formats a coin with the global denom registry
+func FormatCoin(coin Coin) string {
+	return denomRegistry.FormatCoin(coin)
+}
+
+// FormatCoins formats coins with the global denom registry
+func FormatCoins(coins Coins) string {
+	return denomRegistry.FormatCoins(coins)
+}
+
+// DisplayCoins converts coins into their display form with the global denom registry
+func DisplayCoins(coins Coins) []DisplayCoin {
+	return denomRegistry.DisplayCoins(coins)
+}
+
+// ParseDisplayCoins parses display coin expressions with the global denom registry
+func ParseDisplayCoins(coinsStr string) (Coins, error) {
+	return denomRegistry.ParseCoins(coinsStr)
+}
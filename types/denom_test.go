@@ -0,0 +1,94 @@
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatAmount(t *testing.T) {
+	cases := []struct {
+		amount   int64
+		exponent uint8
+		expected string
+	}{
+		{0, 8, "0"},
+		{1, 8, "0.00000001"},
+		{100000000, 8, "1"},
+		{150000000, 8, "1.5"},
+		{-150000000, 8, "-1.5"},
+		{123, 0, "123"},
+		{123, 2, "1.23"},
+		{math.MaxInt64, 8, "92233720368.54775807"},
+		{math.MinInt64, 8, "-92233720368.54775808"},
+	}
+
+	for tcIndex, tc := range cases {
+		res := FormatAmount(tc.amount, tc.exponent)
+		require.Equal(t, tc.expected, res, "unexpected format, tc #%d", tcIndex)
+
+		amount, err := ParseAmount(res, tc.exponent)
+		require.Nil(t, err, "tc #%d", tcIndex)
+		require.Equal(t, tc.amount, amount, "round trip failed, tc #%d", tcIndex)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	cases := []struct {
+		input    string
+		exponent uint8
+		expected int64
+		valid    bool
+	}{
+		{"1", 8, 100000000, true},
+		{"1.5", 8, 150000000, true},
+		{"0.00000001", 8, 1, true},
+		{"1.123456789", 8, 0, false}, // too many decimal places
+		{"1,5", 8, 0, false},         // no locale separators
+		{"1,000", 8, 0, false},
+		{"1e8", 0, 0, false},
+		{" 1", 8, 0, false},
+		{"1.", 8, 0, false},
+		{".5", 8, 0, false},
+		{"+1", 8, 0, false},
+		{"", 8, 0, false},
+		{"99999999999", 8, 0, false}, // overflow
+		{"1", MaxDenomExponent + 1, 0, false},
+	}
+
+	for tcIndex, tc := range cases {
+		res, err := ParseAmount(tc.input, tc.exponent)
+		if !tc.valid {
+			require.NotNil(t, err, "%s parsed but should have failed, tc #%d", tc.input, tcIndex)
+			continue
+		}
+		require.Nil(t, err, "%s failed to parse, tc #%d", tc.input, tcIndex)
+		require.Equal(t, tc.expected, res, "tc #%d", tcIndex)
+	}
+}
+
+func TestDenomRegistry(t *testing.T) {
+	registry := NewDenomRegistry(8)
+	registry.Register(DenomMetadata{Denom: "ABC-123", Exponent: 2})
+	require.Equal(t, uint8(2), registry.Exponent("ABC-123"))
+	require.Equal(t, uint8(8), registry.Exponent("XYZ-000"))
+
+	coins := Coins{NewCoin("ABC-123", 150), NewCoin("XYZ-000", 150000000)}
+	formatted := registry.FormatCoins(coins)
+	require.Equal(t, "1.5:ABC-123,1.5:XYZ-000", formatted)
+
+	parsed, err := registry.ParseCoins(formatted)
+	require.Nil(t, err)
+	require.True(t, coins.IsEqual(parsed))
+
+	_, err = registry.ParseCoins("1.555:ABC-123")
+	require.NotNil(t, err)
+
+	require.Panics(t, func() { registry.Register(DenomMetadata{Denom: "ABC-123", Exponent: MaxDenomExponent + 1}) })
+	require.Panics(t, func() { registry.Register(DenomMetadata{Exponent: 2}) })
+
+	require.Equal(t, []DisplayCoin{{Denom: "ABC-123", Amount: "1.5"}, {Denom: "XYZ-000", Amount: "1.5"}}, registry.DisplayCoins(coins))
+
+	require.Equal(t, "1.5:BNB", FormatCoin(NewCoin(NativeTokenSymbol, 150000000)))
+}
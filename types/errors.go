@@ -59,6 +59,12 @@ const (
 	CodeMsgNotSupported     CodeType = 14
 	CodeInvalidAccountFlags CodeType = 15
 	CodeInvalidTxMemo       CodeType = 16
+	CodeBlockRejected       CodeType = 17
+	CodeTxExpired           CodeType = 18
+	CodeTxTooLarge          CodeType = 19
+	CodeTooManyMsgs         CodeType = 20
+	CodeTxRateLimited       CodeType = 21
+	CodeLaneFull            CodeType = 22
 
 	// CodespaceRoot is a codespace for error codes in this file only.
 	// Notice that 0 is an "unset" codespace, which can be overridden with
@@ -70,6 +76,15 @@ const (
 	MaximumCodespace CodespaceType = 65535
 )
 
+func init() {
+	RegisterCodespace(CodespaceRoot, "sdk",
+		CodeOK, CodeInternal, CodeTxDecode, CodeInvalidSequence, CodeUnauthorized, CodeInsufficientFunds,
+		CodeUnknownRequest, CodeInvalidAddress, CodeInvalidPubKey, CodeUnknownAddress, CodeInsufficientCoins,
+		CodeInvalidCoins, CodeMemoTooLarge, CodeInsufficientFee, CodeMsgNotSupported, CodeInvalidAccountFlags,
+		CodeInvalidTxMemo, CodeBlockRejected, CodeTxExpired, CodeTxTooLarge, CodeTooManyMsgs, CodeTxRateLimited,
+		CodeLaneFull)
+}
+
 func unknownCodeMsg(code CodeType) string {
 	return fmt.Sprintf("unknown code %d", code)
 }
@@ -109,6 +124,18 @@ func CodeToDefaultMsg(code CodeType) string {
 		return "account flags is invalid"
 	case CodeInvalidTxMemo:
 		return "transaction memo is invalid"
+	case CodeBlockRejected:
+		return "block rejected"
+	case CodeTxExpired:
+		return "tx expired"
+	case CodeTxTooLarge:
+		return "tx too large"
+	case CodeTooManyMsgs:
+		return "too many msgs"
+	case CodeTxRateLimited:
+		return "tx rate limited"
+	case CodeLaneFull:
+		return "user lane full"
 	default:
 		return unknownCodeMsg(code)
 	}
@@ -164,6 +191,24 @@ func ErrInvalidAccountFlags(msg string) Error {
 func ErrInvalidTxMemo(msg string) Error {
 	return newErrorWithRootCodespace(CodeInvalidTxMemo, msg)
 }
+func ErrBlockRejected(msg string) Error {
+	return newErrorWithRootCodespace(CodeBlockRejected, msg)
+}
+func ErrTxExpired(msg string) Error {
+	return newErrorWithRootCodespace(CodeTxExpired, msg)
+}
+func ErrTxTooLarge(msg string) Error {
+	return newErrorWithRootCodespace(CodeTxTooLarge, msg)
+}
+func ErrTooManyMsgs(msg string) Error {
+	return newErrorWithRootCodespace(CodeTooManyMsgs, msg)
+}
+func ErrTxRateLimited(msg string) Error {
+	return newErrorWithRootCodespace(CodeTxRateLimited, msg)
+}
+func ErrLaneFull(msg string) Error {
+	return newErrorWithRootCodespace(CodeLaneFull, msg)
+}
 
 //----------------------------------------
 // Error & sdkError
@@ -215,8 +260,9 @@ func newError(codespace CodespaceType, code CodeType, format string, args ...int
 }
 
 type sdkError struct {
-	codespace CodespaceType
-	code      CodeType
+	codespace   CodespaceType
+	code        CodeType
+	fieldErrors FieldErrors
 	cmnError
 }
 
@@ -227,9 +273,10 @@ func (err *sdkError) WithDefaultCodespace(cs CodespaceType) Error {
 		codespace = cs
 	}
 	return &sdkError{
-		codespace: cs,
-		code:      err.code,
-		cmnError:  err.cmnError,
+		codespace:   cs,
+		code:        err.code,
+		fieldErrors: err.fieldErrors,
+		cmnError:    err.cmnError,
 	}
 }
 
@@ -274,10 +321,11 @@ func (err *sdkError) ABCILog() string {
 	cdc := codec.New()
 	errMsg := err.cmnError.Error()
 	jsonErr := humanReadableError{
-		Codespace: err.codespace,
-		Code:      err.code,
-		ABCICode:  err.ABCICode(),
-		Message:   errMsg,
+		Codespace:   err.codespace,
+		Code:        err.code,
+		ABCICode:    err.ABCICode(),
+		Message:     errMsg,
+		FieldErrors: err.fieldErrors,
 	}
 	bz, er := cdc.MarshalJSON(jsonErr)
 	if er != nil {
@@ -331,8 +379,9 @@ func mustGetMsgIndex(abciLog string) int {
 
 // parses the error into an object-like struct for exporting
 type humanReadableError struct {
-	Codespace CodespaceType `json:"codespace"`
-	Code      CodeType      `json:"code"`
-	ABCICode  ABCICodeType  `json:"abci_code"`
-	Message   string        `json:"message"`
+	Codespace   CodespaceType `json:"codespace"`
+	Code        CodeType      `json:"code"`
+	ABCICode    ABCICodeType  `json:"abci_code"`
+	Message     string        `json:"message"`
+	FieldErrors FieldErrors   `json:"field_errors,omitempty"`
 }
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// Instance is the state of an app that is not shared with the other apps of the process, so that several apps,
+// e.g. a Beacon Chain and a mock side chain with different chain-ids and home directories in an integration test,
+// run in one process. Nothing is swapped in the package globals, the state is passed explicitly:
+//  - the upgrade manager is given to the stores of the app and to the contexts it creates, the modules read it
+//    with ctx.UpgradeMgr() instead of the global UpgradeMgr, see baseapp.SetInstance
+//  - the types of the app are registered on its own codec, which the app builds its keepers and tx decoder
+//    with. The package codecs of the modules are sealed once initialized, no registration is shared.
+//
+// What is computed without a context stays the process's: the Bech32 prefixes of the addresses, see GetConfig,
+// and the FixSignBytesOverflow upgrade of the sign bytes, see SortJSON. The apps of a process must agree on them.
+type Instance struct {
+	Name       string
+	UpgradeMgr *UpgradeManager
+	Codec      *codec.Codec
+}
+
+// NewInstance returns an instance with an empty upgrade manager and a codec with only the crypto types
+// registered, name identifies it in the logs, e.g. its chain-id or home directory
+func NewInstance(name string) *Instance {
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	return &Instance{
+		Name:       name,
+		UpgradeMgr: NewUpgradeManager(UpgradeConfig{}),
+		Codec:      cdc,
+	}
+}
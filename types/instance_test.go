@@ -0,0 +1,37 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+type instanceTestType struct {
+	Value int64 `json:"value"`
+}
+
+func TestInstance(t *testing.T) {
+	beaconChain, sideChain := NewInstance("beacon"), NewInstance("side")
+	beaconChain.UpgradeMgr.AddUpgradeHeight(BEP159, 10)
+	beaconChain.UpgradeMgr.SetHeight(10)
+	sideChain.UpgradeMgr.SetHeight(10)
+
+	// the contexts of the apps read their own upgrade manager
+	beaconCtx := NewContext(nil, abci.Header{Height: 10}, RunTxModeDeliver, log.NewNopLogger()).WithUpgradeMgr(beaconChain.UpgradeMgr)
+	sideCtx := NewContext(nil, abci.Header{Height: 10}, RunTxModeDeliver, log.NewNopLogger()).WithUpgradeMgr(sideChain.UpgradeMgr)
+	require.True(t, beaconCtx.UpgradeMgr().IsUpgrade(BEP159))
+	require.False(t, sideCtx.UpgradeMgr().IsUpgrade(BEP159))
+
+	// the upgrade manager of the process is left alone
+	require.Equal(t, int64(0), UpgradeMgr.GetUpgradeHeight(BEP159))
+	require.True(t, NewContext(nil, abci.Header{}, RunTxModeDeliver, log.NewNopLogger()).UpgradeMgr() == UpgradeMgr)
+
+	// the types registered by an app are not known to the others
+	beaconChain.Codec.RegisterConcrete(instanceTestType{}, "test/InstanceTestType", nil)
+	bz := beaconChain.Codec.MustMarshalJSON(instanceTestType{Value: 1})
+	require.Contains(t, string(bz), "test/InstanceTestType")
+	bz = sideChain.Codec.MustMarshalJSON(instanceTestType{Value: 1})
+	require.NotContains(t, string(bz), "test/InstanceTestType")
+}
@@ -0,0 +1,394 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// AppModule is the minimal interface of a module registered in the ModuleManager
+type AppModule interface {
+	Name() string
+}
+
+// AppModuleGenesis is implemented by modules that own a part of the genesis state
+type AppModuleGenesis interface {
+	AppModule
+	DefaultGenesis() json.RawMessage
+	InitGenesis(ctx Context, data json.RawMessage) []abci.ValidatorUpdate
+	ExportGenesis(ctx Context) json.RawMessage
+}
+
+// AppModuleStreamingGenesis is implemented by modules whose genesis state is too large to be
+// held in memory, e.g. tens of millions of accounts. The ModuleManager uses the streaming
+// methods in InitGenesisFromReader and ExportGenesisToWriter.
+type AppModuleStreamingGenesis interface {
+	AppModuleGenesis
+	// InitGenesisStream decodes exactly one JSON value, the genesis state of the module, from the decoder
+	InitGenesisStream(ctx Context, decoder *json.Decoder) ([]abci.ValidatorUpdate, error)
+	// ExportGenesisStream writes the genesis state of the module as one JSON value
+	ExportGenesisStream(ctx Context, w io.Writer) error
+}
+
+// AppModuleBeginBlocker is implemented by modules running logic before the txs of a block
+type AppModuleBeginBlocker interface {
+	AppModule
+	BeginBlock(ctx Context, req abci.RequestBeginBlock)
+}
+
+// AppModuleEndBlocker is implemented by modules running logic after the txs of a block
+type AppModuleEndBlocker interface {
+	AppModule
+	EndBlock(ctx Context, req abci.RequestEndBlock) []abci.ValidatorUpdate
+}
+
+// AppModuleVersioned is implemented by modules tracking the version of their state machine,
+// the version is bumped on every consensus breaking change of the module
+type AppModuleVersioned interface {
+	AppModule
+	ConsensusVersion() uint64
+}
+
+// ModuleManager drives the genesis and begin/end block logic of the registered modules,
+// in an order set explicitly by the app instead of being hardcoded in its blockers.
+type ModuleManager struct {
+	Modules            map[string]AppModule
+	OrderInitGenesis   []string
+	OrderExportGenesis []string
+	OrderBeginBlockers []string
+	OrderEndBlockers   []string
+}
+
+// NewModuleManager creates a ModuleManager, by default the modules run in the order they are registered
+func NewModuleManager(modules ...AppModule) *ModuleManager {
+	moduleMap := make(map[string]AppModule, len(modules))
+	names := make([]string, 0, len(modules))
+	for _, module := range modules {
+		if _, ok := moduleMap[module.Name()]; ok {
+			panic(fmt.Sprintf("module %s is registered twice", module.Name()))
+		}
+		moduleMap[module.Name()] = module
+		names = append(names, module.Name())
+	}
+
+	return &ModuleManager{
+		Modules:            moduleMap,
+		OrderInitGenesis:   names,
+		OrderExportGenesis: names,
+		OrderBeginBlockers: names,
+		OrderEndBlockers:   names,
+	}
+}
+
+// SetOrderInitGenesis sets the order of the modules in InitGenesis
+func (mm *ModuleManager) SetOrderInitGenesis(moduleNames ...string) {
+	mm.assertRegistered(moduleNames)
+	mm.OrderInitGenesis = moduleNames
+}
+
+// SetOrderExportGenesis sets the order of the modules in ExportGenesis
+func (mm *ModuleManager) SetOrderExportGenesis(moduleNames ...string) {
+	mm.assertRegistered(moduleNames)
+	mm.OrderExportGenesis = moduleNames
+}
+
+// SetOrderBeginBlockers sets the order of the modules in BeginBlock
+func (mm *ModuleManager) SetOrderBeginBlockers(moduleNames ...string) {
+	mm.assertRegistered(moduleNames)
+	mm.OrderBeginBlockers = moduleNames
+}
+
+// SetOrderEndBlockers sets the order of the modules in EndBlock
+func (mm *ModuleManager) SetOrderEndBlockers(moduleNames ...string) {
+	mm.assertRegistered(moduleNames)
+	mm.OrderEndBlockers = moduleNames
+}
+
+func (mm *ModuleManager) assertRegistered(moduleNames []string) {
+	seen := make(map[string]bool, len(moduleNames))
+	for _, name := range moduleNames {
+		if _, ok := mm.Modules[name]; !ok {
+			panic(fmt.Sprintf("module %s is not registered", name))
+		}
+		if seen[name] {
+			panic(fmt.Sprintf("module %s appears twice in the order", name))
+		}
+		seen[name] = true
+	}
+}
+
+// ConsensusVersions returns the consensus version of every registered module,
+// modules not implementing AppModuleVersioned are at version 1
+func (mm *ModuleManager) ConsensusVersions() map[string]uint64 {
+	versions := make(map[string]uint64, len(mm.Modules))
+	for name, module := range mm.Modules {
+		if vm, ok := module.(AppModuleVersioned); ok {
+			versions[name] = vm.ConsensusVersion()
+		} else {
+			versions[name] = 1
+		}
+	}
+	return versions
+}
+
+// DefaultGenesis returns the default genesis state of all modules owning genesis state
+func (mm *ModuleManager) DefaultGenesis() map[string]json.RawMessage {
+	genesis := make(map[string]json.RawMessage)
+	for name, module := range mm.Modules {
+		if gm, ok := module.(AppModuleGenesis); ok {
+			genesis[name] = gm.DefaultGenesis()
+		}
+	}
+	return genesis
+}
+
+// InitGenesis initializes the genesis state of the modules in OrderInitGenesis.
+// Modules without an entry in genesisData are skipped. Only one module may return validator updates.
+func (mm *ModuleManager) InitGenesis(ctx Context, genesisData map[string]json.RawMessage) abci.ResponseInitChain {
+	var validatorUpdates []abci.ValidatorUpdate
+	for _, name := range mm.OrderInitGenesis {
+		gm, ok := mm.Modules[name].(AppModuleGenesis)
+		if !ok {
+			continue
+		}
+		data, ok := genesisData[name]
+		if !ok {
+			continue
+		}
+		updates := gm.InitGenesis(ctx, data)
+		if len(updates) > 0 {
+			if len(validatorUpdates) > 0 {
+				panic("validator InitGenesis updates already set by a previous module")
+			}
+			validatorUpdates = updates
+		}
+	}
+	return abci.ResponseInitChain{
+		Validators: validatorUpdates,
+	}
+}
+
+// ExportGenesis exports the genesis state of the modules in OrderExportGenesis
+func (mm *ModuleManager) ExportGenesis(ctx Context) map[string]json.RawMessage {
+	genesisData := make(map[string]json.RawMessage)
+	for _, name := range mm.OrderExportGenesis {
+		if gm, ok := mm.Modules[name].(AppModuleGenesis); ok {
+			genesisData[name] = gm.ExportGenesis(ctx)
+		}
+	}
+	return genesisData
+}
+
+// InitGenesisFromReader is the streaming version of InitGenesis, it reads the genesis state
+// as a JSON object from r and initializes the modules one by one while decoding it, so that only
+// the state of one module, or nothing for streaming modules, is held in memory at a time.
+// The modules must appear in OrderInitGenesis, as written by ExportGenesisToWriter.
+// Keys that are not modules with genesis state are skipped.
+func (mm *ModuleManager) InitGenesisFromReader(ctx Context, r io.Reader) (abci.ResponseInitChain, error) {
+	order := make(map[string]int, len(mm.OrderInitGenesis))
+	for i, name := range mm.OrderInitGenesis {
+		order[name] = i
+	}
+
+	decoder := json.NewDecoder(r)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return abci.ResponseInitChain{}, err
+	}
+	var validatorUpdates []abci.ValidatorUpdate
+	last := -1
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return abci.ResponseInitChain{}, err
+		}
+		name, ok := token.(string)
+		if !ok {
+			return abci.ResponseInitChain{}, fmt.Errorf("invalid genesis key %v", token)
+		}
+
+		gm, isGenesis := mm.Modules[name].(AppModuleGenesis)
+		idx, ordered := order[name]
+		if !isGenesis || !ordered {
+			// not a module, skip its value
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return abci.ResponseInitChain{}, err
+			}
+			continue
+		}
+		if idx <= last {
+			return abci.ResponseInitChain{}, fmt.Errorf("genesis of module %s is out of the init genesis order", name)
+		}
+		last = idx
+
+		var updates []abci.ValidatorUpdate
+		if sm, ok := gm.(AppModuleStreamingGenesis); ok {
+			updates, err = sm.InitGenesisStream(ctx, decoder)
+			if err != nil {
+				return abci.ResponseInitChain{}, fmt.Errorf("failed to init genesis of module %s: %v", name, err)
+			}
+		} else {
+			var data json.RawMessage
+			if err := decoder.Decode(&data); err != nil {
+				return abci.ResponseInitChain{}, err
+			}
+			updates = gm.InitGenesis(ctx, data)
+		}
+		if len(updates) > 0 {
+			if len(validatorUpdates) > 0 {
+				return abci.ResponseInitChain{}, fmt.Errorf("validator InitGenesis updates already set by a previous module")
+			}
+			validatorUpdates = updates
+		}
+	}
+	if err := expectDelim(decoder, '}'); err != nil {
+		return abci.ResponseInitChain{}, err
+	}
+
+	return abci.ResponseInitChain{
+		Validators: validatorUpdates,
+	}, nil
+}
+
+// ExportGenesisToWriter is the streaming version of ExportGenesis, it writes the genesis state of
+// the modules as a JSON object to w. The modules are written in OrderInitGenesis,
+// so that the output can be imported with InitGenesisFromReader.
+func (mm *ModuleManager) ExportGenesisToWriter(ctx Context, w io.Writer) error {
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+	first := true
+	for _, name := range mm.OrderInitGenesis {
+		gm, ok := mm.Modules[name].(AppModuleGenesis)
+		if !ok {
+			continue
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		if _, err := fmt.Fprintf(w, "%s:", key); err != nil {
+			return err
+		}
+
+		if sm, ok := gm.(AppModuleStreamingGenesis); ok {
+			if err := sm.ExportGenesisStream(ctx, w); err != nil {
+				return fmt.Errorf("failed to export genesis of module %s: %v", name, err)
+			}
+		} else if _, err := w.Write(gm.ExportGenesis(ctx)); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "}")
+	return err
+}
+
+// DecodeJSONArray streams the elements of a JSON array from the decoder, calling fn with each
+// element. A null value is decoded as an empty array, as amino encodes empty slices.
+// It helps modules implementing AppModuleStreamingGenesis.InitGenesisStream.
+func DecodeJSONArray(decoder *json.Decoder, fn func(element json.RawMessage) error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if d, ok := token.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("expected [ but got %v", token)
+	}
+	for decoder.More() {
+		var element json.RawMessage
+		if err := decoder.Decode(&element); err != nil {
+			return err
+		}
+		if err := fn(element); err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, ']')
+}
+
+// EncodeJSONArray streams the JSON encoded elements returned by next as a JSON array to w until next
+// returns false. It helps modules implementing AppModuleStreamingGenesis.ExportGenesisStream.
+func EncodeJSONArray(w io.Writer, next func() (element json.RawMessage, ok bool, err error)) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for i := 0; ; i++ {
+		element, ok, err := next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(element); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := token.(json.Delim); !ok || d != delim {
+		return fmt.Errorf("expected %s but got %v", delim, token)
+	}
+	return nil
+}
+
+// BeginBlock runs the BeginBlock of the modules in OrderBeginBlockers,
+// it can be used directly as the BeginBlocker of the app
+func (mm *ModuleManager) BeginBlock(ctx Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	ctx = ctx.WithEventManager(NewEventManager())
+	for _, name := range mm.OrderBeginBlockers {
+		if bm, ok := mm.Modules[name].(AppModuleBeginBlocker); ok {
+			bm.BeginBlock(ctx, req)
+		}
+	}
+	return abci.ResponseBeginBlock{
+		Events: ctx.EventManager().ABCIEvents(),
+	}
+}
+
+// EndBlock runs the EndBlock of the modules in OrderEndBlockers, it can be used
+// directly as the EndBlocker of the app. Only one module may return validator updates.
+func (mm *ModuleManager) EndBlock(ctx Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
+	ctx = ctx.WithEventManager(NewEventManager())
+	var validatorUpdates []abci.ValidatorUpdate
+	for _, name := range mm.OrderEndBlockers {
+		em, ok := mm.Modules[name].(AppModuleEndBlocker)
+		if !ok {
+			continue
+		}
+		updates := em.EndBlock(ctx, req)
+		if len(updates) > 0 {
+			if len(validatorUpdates) > 0 {
+				panic("validator EndBlock updates already set by a previous module")
+			}
+			validatorUpdates = updates
+		}
+	}
+	return abci.ResponseEndBlock{
+		ValidatorUpdates: validatorUpdates,
+		Events:           ctx.EventManager().ABCIEvents(),
+	}
+}
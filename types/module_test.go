@@ -0,0 +1,166 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+type mockModule struct {
+	name    string
+	calls   *[]string
+	updates []abci.ValidatorUpdate
+}
+
+func (m mockModule) Name() string { return m.name }
+
+func (m mockModule) DefaultGenesis() json.RawMessage { return json.RawMessage(`{}`) }
+
+func (m mockModule) InitGenesis(_ Context, _ json.RawMessage) []abci.ValidatorUpdate {
+	*m.calls = append(*m.calls, "init:"+m.name)
+	return m.updates
+}
+
+func (m mockModule) ExportGenesis(_ Context) json.RawMessage {
+	*m.calls = append(*m.calls, "export:"+m.name)
+	return json.RawMessage(`{}`)
+}
+
+func (m mockModule) BeginBlock(_ Context, _ abci.RequestBeginBlock) {
+	*m.calls = append(*m.calls, "begin:"+m.name)
+}
+
+func (m mockModule) EndBlock(_ Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	*m.calls = append(*m.calls, "end:"+m.name)
+	return m.updates
+}
+
+func TestModuleManagerOrder(t *testing.T) {
+	var calls []string
+	update := []abci.ValidatorUpdate{{Power: 1}}
+	mm := NewModuleManager(
+		mockModule{name: "a", calls: &calls},
+		mockModule{name: "b", calls: &calls, updates: update},
+		mockModule{name: "c", calls: &calls},
+	)
+
+	mm.BeginBlock(Context{}, abci.RequestBeginBlock{})
+	require.Equal(t, []string{"begin:a", "begin:b", "begin:c"}, calls)
+
+	calls = nil
+	mm.SetOrderEndBlockers("c", "b")
+	res := mm.EndBlock(Context{}, abci.RequestEndBlock{})
+	require.Equal(t, []string{"end:c", "end:b"}, calls)
+	require.Equal(t, update, res.ValidatorUpdates)
+
+	calls = nil
+	mm.SetOrderInitGenesis("b", "a", "c")
+	initRes := mm.InitGenesis(Context{}, map[string]json.RawMessage{"a": nil, "b": nil})
+	require.Equal(t, []string{"init:b", "init:a"}, calls)
+	require.Equal(t, update, initRes.Validators)
+
+	require.Len(t, mm.DefaultGenesis(), 3)
+}
+
+func TestModuleManagerPanics(t *testing.T) {
+	var calls []string
+	update := []abci.ValidatorUpdate{{Power: 1}}
+	require.Panics(t, func() {
+		NewModuleManager(mockModule{name: "a", calls: &calls}, mockModule{name: "a", calls: &calls})
+	})
+
+	mm := NewModuleManager(
+		mockModule{name: "a", calls: &calls, updates: update},
+		mockModule{name: "b", calls: &calls, updates: update},
+	)
+	require.Panics(t, func() { mm.SetOrderBeginBlockers("a", "unknown") })
+	require.Panics(t, func() { mm.SetOrderEndBlockers("a", "a") })
+
+	// only one module may update the validator set
+	require.Panics(t, func() { mm.EndBlock(Context{}, abci.RequestEndBlock{}) })
+}
+
+// mockStreamingModule keeps its genesis state as a list of numbers
+type mockStreamingModule struct {
+	mockModule
+	values *[]int
+}
+
+func (m mockStreamingModule) InitGenesisStream(_ Context, decoder *json.Decoder) ([]abci.ValidatorUpdate, error) {
+	*m.calls = append(*m.calls, "init:"+m.name)
+	return m.updates, DecodeJSONArray(decoder, func(element json.RawMessage) error {
+		var value int
+		if err := json.Unmarshal(element, &value); err != nil {
+			return err
+		}
+		*m.values = append(*m.values, value)
+		return nil
+	})
+}
+
+func (m mockStreamingModule) ExportGenesisStream(_ Context, w io.Writer) error {
+	i := 0
+	return EncodeJSONArray(w, func() (json.RawMessage, bool, error) {
+		if i >= len(*m.values) {
+			return nil, false, nil
+		}
+		i++
+		bz, err := json.Marshal((*m.values)[i-1])
+		return bz, true, err
+	})
+}
+
+func TestModuleManagerStreamingGenesis(t *testing.T) {
+	var calls []string
+	values := []int{1, 2, 3}
+	update := []abci.ValidatorUpdate{{Power: 1}}
+	mm := NewModuleManager(
+		mockModule{name: "a", calls: &calls},
+		mockStreamingModule{mockModule: mockModule{name: "b", calls: &calls, updates: update}, values: &values},
+	)
+	mm.SetOrderInitGenesis("b", "a")
+
+	var buf bytes.Buffer
+	require.Nil(t, mm.ExportGenesisToWriter(Context{}, &buf))
+	require.Equal(t, `{"b":[1,2,3],"a":{}}`, buf.String())
+
+	calls, values = nil, nil
+	res, err := mm.InitGenesisFromReader(Context{}, strings.NewReader(`{"b":[1,2,3],"accounts":[{"x":1}],"a":{}}`))
+	require.Nil(t, err)
+	require.Equal(t, []string{"init:b", "init:a"}, calls)
+	require.Equal(t, []int{1, 2, 3}, values)
+	require.Equal(t, update, res.Validators)
+
+	// null is an empty list
+	calls, values = nil, nil
+	_, err = mm.InitGenesisFromReader(Context{}, strings.NewReader(`{"b":null}`))
+	require.Nil(t, err)
+	require.Empty(t, values)
+
+	// modules out of order
+	_, err = mm.InitGenesisFromReader(Context{}, strings.NewReader(`{"a":{},"b":[]}`))
+	require.NotNil(t, err)
+	// malformed
+	_, err = mm.InitGenesisFromReader(Context{}, strings.NewReader(`{"b":[1,2`))
+	require.NotNil(t, err)
+}
+
+type versionedModule struct {
+	mockModule
+	version uint64
+}
+
+func (m versionedModule) ConsensusVersion() uint64 { return m.version }
+
+func TestModuleManagerConsensusVersions(t *testing.T) {
+	mm := NewModuleManager(
+		mockModule{name: "a"},
+		versionedModule{mockModule: mockModule{name: "b"}, version: 3},
+	)
+	require.Equal(t, map[string]uint64{"a": 1, "b": 3}, mm.ConsensusVersions())
+}
@@ -4,3 +4,20 @@ import abci "github.com/tendermint/tendermint/abci/types"
 
 // Type for querier functions on keepers to implement to handle custom queries
 type Querier = func(ctx Context, path []string, req abci.RequestQuery) (res []byte, err Error)
+
+// ProofQuerier handles the queries of a route registered under "/prove", which return data
+// derived from the value of a single store key along with the proof of that value.
+// Key returns the store and the key the answer depends on, Result derives the data from
+// the value of the key, nil if it is absent. Both are also run by the clients verifying
+// the answer, so they must only depend on their arguments.
+type ProofQuerier interface {
+	Key(path []string, req abci.RequestQuery) (storeName string, key []byte, err Error)
+	Result(value []byte, path []string, req abci.RequestQuery) (res []byte, err Error)
+}
+
+// ProvedQueryResult is the value of the response to a query of a ProofQuerier, the proof
+// of the response proves StoreValue
+type ProvedQueryResult struct {
+	Data       []byte `json:"data"`
+	StoreValue []byte `json:"store_value"`
+}
@@ -51,6 +51,7 @@ type Validator interface {
 	GetBondHeight() int64            // height in which the validator became active
 	GetSideChainConsAddr() []byte    // validation consensus address on side chain
 	IsSideChainValidator() bool      // if it belongs to side chain
+	GetMinSelfDelegation() int64     // self delegation the validator committed to, 0 if none
 }
 
 // validator which fulfills abci validator interface for use in Tendermint
@@ -84,6 +85,9 @@ type ValidatorSet interface {
 	// and delegator outside the scope of the staking module.
 	Delegation(Context, AccAddress, ValAddress) Delegation
 
+	// iterate through the delegations to a validator
+	IterateDelegationsToValidator(ctx Context, valAddr ValAddress, fn func(del Delegation) (stop bool))
+
 	// functions for side chain
 	ValidatorBySideChainConsAddr(Context, []byte) Validator
 	UnjailSideChain(Context, []byte)
@@ -142,3 +146,75 @@ type StakingHooks interface {
 
 	OnSelfDelDropBelowMin(ctx Context, operator ValAddress)
 }
+
+// MultiStakingHooks combines the staking hooks of several modules, every event is passed to each of them in order
+type MultiStakingHooks []StakingHooks
+
+var _ StakingHooks = MultiStakingHooks{}
+
+func NewMultiStakingHooks(hooks ...StakingHooks) MultiStakingHooks {
+	return hooks
+}
+
+// nolint
+func (h MultiStakingHooks) OnValidatorCreated(ctx Context, address ValAddress) {
+	for i := range h {
+		h[i].OnValidatorCreated(ctx, address)
+	}
+}
+func (h MultiStakingHooks) OnValidatorModified(ctx Context, address ValAddress) {
+	for i := range h {
+		h[i].OnValidatorModified(ctx, address)
+	}
+}
+func (h MultiStakingHooks) OnValidatorRemoved(ctx Context, address ValAddress) {
+	for i := range h {
+		h[i].OnValidatorRemoved(ctx, address)
+	}
+}
+func (h MultiStakingHooks) OnValidatorBonded(ctx Context, address ConsAddress, operator ValAddress) {
+	for i := range h {
+		h[i].OnValidatorBonded(ctx, address, operator)
+	}
+}
+func (h MultiStakingHooks) OnValidatorBeginUnbonding(ctx Context, address ConsAddress, operator ValAddress) {
+	for i := range h {
+		h[i].OnValidatorBeginUnbonding(ctx, address, operator)
+	}
+}
+func (h MultiStakingHooks) OnDelegationCreated(ctx Context, delAddr AccAddress, valAddr ValAddress) {
+	for i := range h {
+		h[i].OnDelegationCreated(ctx, delAddr, valAddr)
+	}
+}
+func (h MultiStakingHooks) OnDelegationSharesModified(ctx Context, delAddr AccAddress, valAddr ValAddress) {
+	for i := range h {
+		h[i].OnDelegationSharesModified(ctx, delAddr, valAddr)
+	}
+}
+func (h MultiStakingHooks) OnDelegationRemoved(ctx Context, delAddr AccAddress, valAddr ValAddress) {
+	for i := range h {
+		h[i].OnDelegationRemoved(ctx, delAddr, valAddr)
+	}
+}
+func (h MultiStakingHooks) OnSideChainValidatorBonded(ctx Context, sideConsAddr []byte, operator ValAddress) {
+	for i := range h {
+		h[i].OnSideChainValidatorBonded(ctx, sideConsAddr, operator)
+	}
+}
+func (h MultiStakingHooks) OnSideChainValidatorBeginUnbonding(ctx Context, sideConsAddr []byte, operator ValAddress) {
+	for i := range h {
+		h[i].OnSideChainValidatorBeginUnbonding(ctx, sideConsAddr, operator)
+	}
+}
+func (h MultiStakingHooks) OnSelfDelDropBelowMin(ctx Context, operator ValAddress) {
+	for i := range h {
+		h[i].OnSelfDelDropBelowMin(ctx, operator)
+	}
+}
+
+// event hooks for the slashing of validators, called before a validator is likely to be slashed
+type SlashingHooks interface {
+	OnDowntimeWarning(ctx Context, operator ValAddress) // Must be called when a validator has missed half of the blocks it may miss in the signing window
+	OnEvidencePending(ctx Context, operator ValAddress) // Must be called when an evidence against a validator is scheduled to be handled
+}
@@ -0,0 +1,63 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHooks records the staking events it is called with
+type recordingHooks struct {
+	name   string
+	events *[]string
+}
+
+func (h recordingHooks) record(event string) {
+	*h.events = append(*h.events, h.name+":"+event)
+}
+
+func (h recordingHooks) OnValidatorCreated(_ Context, _ ValAddress)  { h.record("created") }
+func (h recordingHooks) OnValidatorModified(_ Context, _ ValAddress) { h.record("modified") }
+func (h recordingHooks) OnValidatorRemoved(_ Context, _ ValAddress)  { h.record("removed") }
+func (h recordingHooks) OnValidatorBonded(_ Context, _ ConsAddress, _ ValAddress) {
+	h.record("bonded")
+}
+func (h recordingHooks) OnValidatorBeginUnbonding(_ Context, _ ConsAddress, _ ValAddress) {
+	h.record("unbonding")
+}
+func (h recordingHooks) OnDelegationCreated(_ Context, _ AccAddress, _ ValAddress) {
+	h.record("delegationCreated")
+}
+func (h recordingHooks) OnDelegationSharesModified(_ Context, _ AccAddress, _ ValAddress) {
+	h.record("delegationModified")
+}
+func (h recordingHooks) OnDelegationRemoved(_ Context, _ AccAddress, _ ValAddress) {
+	h.record("delegationRemoved")
+}
+func (h recordingHooks) OnSideChainValidatorBonded(_ Context, _ []byte, _ ValAddress) {
+	h.record("sideChainBonded")
+}
+func (h recordingHooks) OnSideChainValidatorBeginUnbonding(_ Context, _ []byte, _ ValAddress) {
+	h.record("sideChainUnbonding")
+}
+func (h recordingHooks) OnSelfDelDropBelowMin(_ Context, _ ValAddress) { h.record("dropBelowMin") }
+
+func TestMultiStakingHooks(t *testing.T) {
+	var events []string
+	hooks := NewMultiStakingHooks(recordingHooks{"a", &events}, recordingHooks{"b", &events})
+	ctx := Context{}
+
+	hooks.OnValidatorBonded(ctx, nil, nil)
+	hooks.OnDelegationRemoved(ctx, nil, nil)
+	hooks.OnSideChainValidatorBonded(ctx, nil, nil)
+	hooks.OnSelfDelDropBelowMin(ctx, nil)
+	require.Equal(t, []string{
+		"a:bonded", "b:bonded",
+		"a:delegationRemoved", "b:delegationRemoved",
+		"a:sideChainBonded", "b:sideChainBonded",
+		"a:dropBelowMin", "b:dropBelowMin",
+	}, events)
+
+	// no hooks at all is fine
+	NewMultiStakingHooks().OnValidatorCreated(ctx, nil)
+}
@@ -13,19 +13,55 @@ import (
 // NOTE: These are implemented in cosmos-sdk/store.
 
 // PruningStrategy specfies how old states will be deleted over time
-type PruningStrategy uint8
+type PruningStrategy struct {
+	// KeepRecent is the number of recent states kept before the current one
+	KeepRecent int64 `json:"keep_recent"`
+	// KeepEvery is the distance between the states kept forever, 1 keeps every state and 0 none
+	KeepEvery int64 `json:"keep_every"`
+	// Interval is the number of commits between two prunings of the old states, at least 1
+	Interval int64 `json:"interval"`
+}
 
-const (
-	// PruneSyncable means only those states not needed for state syncing will be deleted (keeps last 100 + every 10000th)
-	PruneSyncable PruningStrategy = iota
+var (
+	// PruneSyncable means only those states not needed for state syncing will be deleted (keeps last 100000 + every 100000th)
+	PruneSyncable = NewPruningStrategy(100000, 100000, 1)
 
 	// PruneEverything means all saved states will be deleted, storing only the current state
-	PruneEverything PruningStrategy = iota
+	PruneEverything = NewPruningStrategy(0, 0, 1)
 
 	// PruneNothing means all historic states will be saved, nothing will be deleted
-	PruneNothing PruningStrategy = iota
+	PruneNothing = NewPruningStrategy(0, 1, 1)
 )
 
+// NewPruningStrategy returns a strategy keeping the keepRecent last states plus every keepEvery-th
+// state, pruning the others every interval commits. E.g. with a block every second,
+// NewPruningStrategy(172800, 10000, 10) keeps 2 days plus every 10000th state.
+func NewPruningStrategy(keepRecent, keepEvery, interval int64) PruningStrategy {
+	return PruningStrategy{
+		KeepRecent: keepRecent,
+		KeepEvery:  keepEvery,
+		Interval:   interval,
+	}
+}
+
+// Validate checks that the strategy can be applied by the stores
+func (ps PruningStrategy) Validate() error {
+	if ps.KeepRecent < 0 {
+		return fmt.Errorf("keep recent should be no less than 0, got %d", ps.KeepRecent)
+	}
+	if ps.KeepEvery < 0 {
+		return fmt.Errorf("keep every should be no less than 0, got %d", ps.KeepEvery)
+	}
+	if ps.Interval < 1 {
+		return fmt.Errorf("pruning interval should be no less than 1, got %d", ps.Interval)
+	}
+	return nil
+}
+
+func (ps PruningStrategy) String() string {
+	return fmt.Sprintf("keep-recent=%d keep-every=%d interval=%d", ps.KeepRecent, ps.KeepEvery, ps.Interval)
+}
+
 type Store interface { //nolint
 	GetStoreType() StoreType
 	CacheWrapper
@@ -120,6 +156,15 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// EarliestVersion returns the earliest version from which all the versions up
+	// to the latest one can be queried, the older ones may have been pruned.
+	EarliestVersion() int64
+
+	// PinVersion keeps a version from being pruned, e.g. while a snapshot is taken from it,
+	// until UnpinVersion is called as many times. Both are safe to call concurrently with Commit.
+	PinVersion(version int64)
+	UnpinVersion(version int64)
 }
 
 //---------subsp-------------------------------
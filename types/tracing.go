@@ -0,0 +1,49 @@
+package types
+
+import (
+	"context"
+	"fmt"
+)
+
+// Tracer starts the spans tracing the lifecycle of the txs, from CheckTx to the commit of
+// the stores. It is typically backed by an OpenTelemetry tracer exporting the spans via OTLP,
+// the parent of a span is the span carried by ctx, if any.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer
+type Span interface {
+	SetAttribute(key string, value interface{})
+	End()
+}
+
+type tracerKey struct{}
+
+// WithTracer returns a copy of ctx whose spans are started by tracer, ctx itself if tracer is nil
+func WithTracer(ctx Context, tracer Tracer) Context {
+	if tracer == nil {
+		return ctx
+	}
+	return ctx.WithValue(tracerKey{}, tracer)
+}
+
+// StartSpan starts a span, child of the current span of ctx, with the given key/value
+// attributes. The returned context carries the new span, which must be ended by the caller.
+// Without tracer in ctx, the span does nothing.
+func StartSpan(ctx Context, name string, keyvals ...interface{}) (Context, Span) {
+	tracer, ok := ctx.Value(tracerKey{}).(Tracer)
+	if !ok {
+		return ctx, noopSpan{}
+	}
+	goCtx, span := tracer.Start(ctx.Context(), name)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		span.SetAttribute(fmt.Sprint(keyvals[i]), keyvals[i+1])
+	}
+	return ctx.WithContext(goCtx), span
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, interface{}) {}
+func (noopSpan) End()                             {}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"sort"
 )
 
 var UpgradeMgr = NewUpgradeManager(UpgradeConfig{})
@@ -23,6 +24,20 @@ const (
 	BEP171                      = "BEP171" //https://github.com/bnb-chain/BEPs/pull/171
 	BEP173                      = "BEP173" // https://github.com/bnb-chain/BEPs/pull/173
 	FixDoubleSignChainId        = "FixDoubleSignChainId"
+	GovSunset                   = "GovSunset"                  // governance becomes read-only, no new proposal is accepted
+	GovArchive                  = "GovArchive"                 // the final proposal results are archived into the state
+	GovDelegatorVote            = "GovDelegatorVote"           // delegators can vote, overriding the vote of their validators on their share
+	FixAccountNumbers           = "FixAccountNumbers"          // the accounts sharing their account number are given new ones
+	GovSideChainParams          = "GovSideChainParams"         // the gov params of a side chain are changed by its generic parameter change proposals
+	GovPowerSnapshot            = "GovPowerSnapshot"           // the side chain proposals are tallied against the voting power at the start of their voting period
+	GovSideChainDepositParams   = "GovSideChainDepositParams"  // the deposit and voting params of a side chain are changed by its side chain param change proposals
+	ValidatorMinSelfDelegation  = "ValidatorMinSelfDelegation" // the validators commit to their own minimum self delegation
+	CancelUnbonding             = "CancelUnbonding"            // the delegators can cancel their pending unbondings
+	LazyRewardDistribution      = "LazyRewardDistribution"     // the rewards of the delegators are accumulated per validator period and paid on withdrawal
+	RestakeRewards              = "RestakeRewards"             // the delegators can have their rewards delegated again to their validator
+	SideChainStakeMigration     = "SideChainStakeMigration"    // the side chain delegators can undelegate and have the tokens transferred to BSC
+	HistoricalValidatorSets     = "HistoricalValidatorSets"    // the validator sets elected in the breathe blocks are kept for the queries by height
+	SideChainStakeParams        = "SideChainStakeParams"       // the side chains can have their own bond denom through the side chain param changes
 )
 
 var MainNetConfig = UpgradeConfig{
@@ -107,6 +122,18 @@ func (mgr *UpgradeManager) GetUpgradeHeight(name string) int64 {
 	return mgr.Config.HeightMap[name]
 }
 
+// ActivatedUpgrades returns the sorted names of the upgrades activated at or before height
+func (mgr *UpgradeManager) ActivatedUpgrades(height int64) []string {
+	names := make([]string, 0, len(mgr.Config.HeightMap))
+	for name, upgradeHeight := range mgr.Config.HeightMap {
+		if upgradeHeight != 0 && upgradeHeight <= height {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 func (mgr *UpgradeManager) RegisterStoreKeys(upgradeName string, storeKeyNames ...string) {
 	height := mgr.GetUpgradeHeight(upgradeName)
 	if height == 0 {
@@ -153,62 +180,62 @@ func (mgr *UpgradeManager) GetMsgTypeHeight(msgType string) int64 {
 	return mgr.Config.MsgTypeMap[msgType]
 }
 
-func IsUpgradeHeight(name string) bool {
-	upgradeHeight := UpgradeMgr.GetUpgradeHeight(name)
+func (mgr *UpgradeManager) IsUpgradeHeight(name string) bool {
+	upgradeHeight := mgr.GetUpgradeHeight(name)
 	if upgradeHeight == 0 {
 		return false
 	}
 
-	return upgradeHeight == UpgradeMgr.GetHeight()
+	return upgradeHeight == mgr.GetHeight()
 }
 
-func IsUpgrade(name string) bool {
-	upgradeHeight := UpgradeMgr.GetUpgradeHeight(name)
+func (mgr *UpgradeManager) IsUpgrade(name string) bool {
+	upgradeHeight := mgr.GetUpgradeHeight(name)
 	if upgradeHeight == 0 {
 		return false
 	}
 
-	return UpgradeMgr.GetHeight() >= upgradeHeight
+	return mgr.GetHeight() >= upgradeHeight
 }
 
-func ShouldCommitStore(storeKeyName string) bool {
-	storeKeyHeight := UpgradeMgr.GetStoreKeyHeight(storeKeyName)
+func (mgr *UpgradeManager) ShouldCommitStore(storeKeyName string) bool {
+	storeKeyHeight := mgr.GetStoreKeyHeight(storeKeyName)
 	if storeKeyHeight == 0 {
 		return true
 	}
 
-	return UpgradeMgr.GetHeight() >= storeKeyHeight
+	return mgr.GetHeight() >= storeKeyHeight
 }
 
-func ShouldSetStoreVersion(storeKeyName string) bool {
-	storeKeyHeight := UpgradeMgr.GetStoreKeyHeight(storeKeyName)
+func (mgr *UpgradeManager) ShouldSetStoreVersion(storeKeyName string) bool {
+	storeKeyHeight := mgr.GetStoreKeyHeight(storeKeyName)
 	if storeKeyHeight == 0 {
 		return false
 	}
 
-	return UpgradeMgr.GetHeight() == storeKeyHeight
+	return mgr.GetHeight() == storeKeyHeight
 }
 
-func IsMsgTypeSupported(msgType string) bool {
-	msgTypeHeight := UpgradeMgr.GetMsgTypeHeight(msgType)
+func (mgr *UpgradeManager) IsMsgTypeSupported(msgType string) bool {
+	msgTypeHeight := mgr.GetMsgTypeHeight(msgType)
 	if msgTypeHeight == 0 {
 		return true
 	}
 
-	return UpgradeMgr.GetHeight() >= msgTypeHeight
+	return mgr.GetHeight() >= msgTypeHeight
 }
 
-func Upgrade(name string, before func(), in func(), after func()) {
+func (mgr *UpgradeManager) Upgrade(name string, before func(), in func(), after func()) {
 	// if no special logic for the UpgradeHeight, apply the `after` logic
 	if in == nil {
 		in = after
 	}
 
-	if IsUpgradeHeight(name) {
+	if mgr.IsUpgradeHeight(name) {
 		if in != nil {
 			in()
 		}
-	} else if IsUpgrade(name) {
+	} else if mgr.IsUpgrade(name) {
 		if after != nil {
 			after()
 		}
@@ -218,3 +245,30 @@ func Upgrade(name string, before func(), in func(), after func()) {
 		}
 	}
 }
+
+// The functions below apply to the upgrade manager of the process. The apps, and the modules through
+// ctx.UpgradeMgr(), use their own upgrade manager, so that several apps can run in the process, see Instance.
+
+func IsUpgradeHeight(name string) bool {
+	return UpgradeMgr.IsUpgradeHeight(name)
+}
+
+func IsUpgrade(name string) bool {
+	return UpgradeMgr.IsUpgrade(name)
+}
+
+func ShouldCommitStore(storeKeyName string) bool {
+	return UpgradeMgr.ShouldCommitStore(storeKeyName)
+}
+
+func ShouldSetStoreVersion(storeKeyName string) bool {
+	return UpgradeMgr.ShouldSetStoreVersion(storeKeyName)
+}
+
+func IsMsgTypeSupported(msgType string) bool {
+	return UpgradeMgr.IsMsgTypeSupported(msgType)
+}
+
+func Upgrade(name string, before func(), in func(), after func()) {
+	UpgradeMgr.Upgrade(name, before, in, after)
+}
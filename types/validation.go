@@ -0,0 +1,108 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError is the failure of the validation of a field of a msg. Field is the path of the field in the JSON
+// encoding of the msg, like "inputs[0].coins".
+type FieldError struct {
+	Field     string        `json:"field"`
+	Codespace CodespaceType `json:"codespace"`
+	Code      CodeType      `json:"code"`
+	Message   string        `json:"message"`
+}
+
+// FieldErrors are all the field errors found validating a msg, in the order of the checks
+type FieldErrors []FieldError
+
+func (errs FieldErrors) String() string {
+	fields := make([]string, 0, len(errs))
+	for _, err := range errs {
+		fields = append(fields, fmt.Sprintf("%s: %s", err.Field, err.Message))
+	}
+	return strings.Join(fields, "; ")
+}
+
+// MsgValidator collects the field errors of a msg in ValidateBasic, so that the clients get all of them at once:
+//
+//	return sdk.NewMsgValidator().
+//		Address("from", msg.From).
+//		Require(msg.Amount > 0, "amount", ErrBadAmount(DefaultCodespace)).
+//		Error()
+//
+// The error returned has the codespace and the code of the first field error, as the msg would have failed with
+// checking its fields one by one, and carries all the field errors in its ABCI log.
+type MsgValidator struct {
+	errs FieldErrors
+}
+
+func NewMsgValidator() *MsgValidator {
+	return &MsgValidator{}
+}
+
+// Check records err against field if it is not nil
+func (v *MsgValidator) Check(field string, err Error) *MsgValidator {
+	if err == nil {
+		return v
+	}
+	fieldErrs := FieldErrorsOf(err)
+	if len(fieldErrs) == 0 {
+		v.errs = append(v.errs, FieldError{
+			Field:     field,
+			Codespace: err.Codespace(),
+			Code:      err.Code(),
+			Message:   err.RawError(),
+		})
+		return v
+	}
+	// the field errors of a nested validation are relative to field
+	for _, fieldErr := range fieldErrs {
+		fieldErr.Field = field + "." + fieldErr.Field
+		v.errs = append(v.errs, fieldErr)
+	}
+	return v
+}
+
+// Require records err against field unless cond holds
+func (v *MsgValidator) Require(cond bool, field string, err Error) *MsgValidator {
+	if cond {
+		return v
+	}
+	return v.Check(field, err)
+}
+
+// Address records an ErrInvalidAddress against field unless addr is AddrLen bytes long
+func (v *MsgValidator) Address(field string, addr []byte) *MsgValidator {
+	if len(addr) == AddrLen {
+		return v
+	}
+	return v.Check(field, ErrInvalidAddress(
+		fmt.Sprintf("Expected %s length is %d, actual length is %d", field, AddrLen, len(addr))))
+}
+
+// HasErrors returns whether a field error is recorded, the checks depending on valid fields can be skipped then
+func (v *MsgValidator) HasErrors() bool {
+	return len(v.errs) != 0
+}
+
+// Error returns nil if no field error is recorded, otherwise an error with the codespace, the code and the message
+// of the first field error, carrying all of them
+func (v *MsgValidator) Error() Error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	first := v.errs[0]
+	err := newError(first.Codespace, first.Code, "%s", first.Message)
+	err.fieldErrors = v.errs
+	return err
+}
+
+// FieldErrorsOf returns the field errors carried by err, none if it is not the error of a MsgValidator
+func FieldErrorsOf(err Error) FieldErrors {
+	if sdkErr, ok := err.(*sdkError); ok {
+		return sdkErr.fieldErrors
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgValidator(t *testing.T) {
+	require.Nil(t, NewMsgValidator().
+		Address("from", make([]byte, AddrLen)).
+		Require(true, "amount", ErrInvalidCoins("amount")).
+		Check("memo", nil).
+		Error())
+
+	// all the field errors are kept, the error is the first one
+	err := NewMsgValidator().
+		Address("from", make([]byte, 3)).
+		Require(false, "amount", ErrInvalidCoins("negative amount")).
+		Error()
+	require.NotNil(t, err)
+	require.Equal(t, CodespaceRoot, err.Codespace())
+	require.Equal(t, CodeInvalidAddress, err.Code())
+	require.Equal(t, "Expected from length is 20, actual length is 3", err.RawError())
+	fieldErrs := FieldErrorsOf(err)
+	require.Len(t, fieldErrs, 2)
+	require.Equal(t, FieldError{"from", CodespaceRoot, CodeInvalidAddress, err.RawError()}, fieldErrs[0])
+	require.Equal(t, FieldError{"amount", CodespaceRoot, CodeInvalidCoins, "negative amount"}, fieldErrs[1])
+
+	// the field errors of a nested validation are relative to its field
+	nested := NewMsgValidator().Check("inputs[1]", err).Error()
+	require.Equal(t, "inputs[1].from", FieldErrorsOf(nested)[0].Field)
+	require.Equal(t, "inputs[1].amount", FieldErrorsOf(nested)[1].Field)
+
+	// the field errors are in the log, and survive the change of codespace
+	var log humanReadableError
+	require.Nil(t, json.Unmarshal([]byte(err.WithDefaultCodespace(CodespaceType(2)).ABCILog()), &log))
+	require.Equal(t, fieldErrs, log.FieldErrors)
+	require.Nil(t, FieldErrorsOf(ErrInvalidCoins("amount")))
+}
@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// maximum number of unused account numbers listed in an AccountNumberReport
+const maxReportedGaps = 100
+
+// QueryAccountNumbers is the path of the query of the AccountNumberReport
+const QueryAccountNumbers = "accountNumbers"
+
+// AccountNumberDuplicate is an account number given to several accounts
+type AccountNumberDuplicate struct {
+	AccountNumber int64            `json:"account_number"`
+	Addresses     []sdk.AccAddress `json:"addresses"` // sorted
+}
+
+// AccountNumberReport is the audit of the account numbers. The numbers are allocated from a global
+// counter, so each account has its own number below the counter. Past bugs left accounts sharing
+// their number, which breaks the signing tooling, or numbers at or above the counter, which would
+// be allocated again. The gaps, the numbers below the counter that no account has, are harmless
+// since the numbers are never reused.
+type AccountNumberReport struct {
+	NextAccountNumber int64                    `json:"next_account_number"`
+	Accounts          int64                    `json:"accounts"`
+	Duplicates        []AccountNumberDuplicate `json:"duplicates"`
+	AboveNext         []sdk.AccAddress         `json:"above_next"` // accounts numbered at or above the counter
+	Gaps              int64                    `json:"gaps"`
+	FirstGaps         []int64                  `json:"first_gaps"` // the first maxReportedGaps gaps
+}
+
+// Consistent tells whether each account has its own number below the counter
+func (report AccountNumberReport) Consistent() bool {
+	return len(report.Duplicates) == 0 && len(report.AboveNext) == 0
+}
+
+func (report AccountNumberReport) String() string {
+	return fmt.Sprintf("next account number: %d, accounts: %d, duplicated numbers: %d, accounts above next: %d, gaps: %d",
+		report.NextAccountNumber, report.Accounts, len(report.Duplicates), len(report.AboveNext), report.Gaps)
+}
+
+// peekNextAccountNumber returns the global account number counter without incrementing it
+func (am AccountKeeper) peekNextAccountNumber(ctx sdk.Context) int64 {
+	var accNumber int64
+	store := ctx.KVStore(am.key)
+	if bz := store.Get(globalAccountNumberKey); bz != nil {
+		am.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &accNumber)
+	}
+	return accNumber
+}
+
+// accountsByNumber returns the accounts of the store by number, the accounts of a number are sorted by address
+func (am AccountKeeper) accountsByNumber(ctx sdk.Context) map[int64][]sdk.Account {
+	byNumber := make(map[int64][]sdk.Account)
+	// the accounts are iterated by address
+	am.IterateAccounts(ctx, func(acc sdk.Account) bool {
+		byNumber[acc.GetAccountNumber()] = append(byNumber[acc.GetAccountNumber()], acc)
+		return false
+	})
+	return byNumber
+}
+
+// AuditAccountNumbers checks the account numbers of all the accounts, it iterates the whole account store
+func (am AccountKeeper) AuditAccountNumbers(ctx sdk.Context) AccountNumberReport {
+	byNumber := am.accountsByNumber(ctx)
+	report := AccountNumberReport{
+		NextAccountNumber: am.peekNextAccountNumber(ctx),
+		Duplicates:        make([]AccountNumberDuplicate, 0),
+		AboveNext:         make([]sdk.AccAddress, 0),
+		FirstGaps:         make([]int64, 0),
+	}
+
+	numbers := make([]int64, 0, len(byNumber))
+	for number, accounts := range byNumber {
+		numbers = append(numbers, number)
+		report.Accounts += int64(len(accounts))
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	usedBelowNext := int64(0)
+	for _, number := range numbers {
+		accounts := byNumber[number]
+		if len(accounts) > 1 {
+			duplicate := AccountNumberDuplicate{AccountNumber: number}
+			for _, acc := range accounts {
+				duplicate.Addresses = append(duplicate.Addresses, acc.GetAddress())
+			}
+			report.Duplicates = append(report.Duplicates, duplicate)
+		}
+		if number >= report.NextAccountNumber {
+			for _, acc := range accounts {
+				report.AboveNext = append(report.AboveNext, acc.GetAddress())
+			}
+		} else if number >= 0 {
+			usedBelowNext++
+		}
+	}
+
+	report.Gaps = report.NextAccountNumber - usedBelowNext
+	for number := int64(0); number < report.NextAccountNumber && len(report.FirstGaps) < maxReportedGaps; number++ {
+		if _, ok := byNumber[number]; !ok {
+			report.FirstGaps = append(report.FirstGaps, number)
+		}
+	}
+	return report
+}
+
+// RepairAccountNumbers raises the counter above the highest account number, and gives new numbers to
+// the accounts sharing their number but one. The account that keeps a shared number is the one with
+// the highest sequence, as it is the most likely to be signed for, then the one with the lowest address.
+// The gaps are left, so that no number is reused. It returns the accounts given new numbers.
+func (am AccountKeeper) RepairAccountNumbers(ctx sdk.Context) []sdk.AccAddress {
+	byNumber := am.accountsByNumber(ctx)
+
+	numbers := make([]int64, 0, len(byNumber))
+	for number := range byNumber {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	if len(numbers) > 0 {
+		am.setNextAccountNumber(ctx, numbers[len(numbers)-1]+1)
+	}
+
+	renumbered := make([]sdk.AccAddress, 0)
+	for _, number := range numbers {
+		accounts := byNumber[number]
+		if len(accounts) < 2 {
+			continue
+		}
+		sort.SliceStable(accounts, func(i, j int) bool {
+			if accounts[i].GetSequence() != accounts[j].GetSequence() {
+				return accounts[i].GetSequence() > accounts[j].GetSequence()
+			}
+			return bytes.Compare(accounts[i].GetAddress(), accounts[j].GetAddress()) < 0
+		})
+		for _, acc := range accounts[1:] {
+			am.NewAccount(ctx, acc)
+			am.SetAccount(ctx, acc)
+			renumbered = append(renumbered, acc.GetAddress())
+		}
+	}
+	return renumbered
+}
+
+// RegisterAccountNumberRepair repairs the account numbers at the FixAccountNumbers upgrade of upgradeMgr,
+// the upgrade manager of the app
+func RegisterAccountNumberRepair(upgradeMgr *sdk.UpgradeManager, am AccountKeeper) {
+	upgradeMgr.RegisterBeginBlocker(sdk.FixAccountNumbers, func(ctx sdk.Context) {
+		logger := ctx.Logger().With("module", "auth")
+		report := am.AuditAccountNumbers(ctx)
+		if report.Consistent() {
+			logger.Info("account numbers are consistent", "report", report.String())
+			return
+		}
+		renumbered := am.RepairAccountNumbers(ctx)
+		logger.Info("repaired account numbers", "report", report.String(), "renumbered", len(renumbered))
+		for _, addr := range renumbered {
+			logger.Info("account renumbered", "address", addr.String(), "accountNumber", am.GetAccount(ctx, addr).GetAccountNumber())
+		}
+	})
+}
+
+// NewQuerier returns the querier of the AccountNumberReport, it iterates the whole account store
+func NewQuerier(am AccountKeeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 || path[0] != QueryAccountNumbers {
+			return nil, sdk.ErrUnknownRequest("unknown auth query endpoint")
+		}
+		bz, err := codec.MarshalJSONIndent(am.cdc, am.AuditAccountNumbers(ctx))
+		if err != nil {
+			return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+		}
+		return bz, nil
+	}
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	codec "github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestAuditAndRepairAccountNumbers(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	addrs := []sdk.AccAddress{
+		sdk.AccAddress([]byte("address-a")),
+		sdk.AccAddress([]byte("address-b")),
+		sdk.AccAddress([]byte("address-c")),
+		sdk.AccAddress([]byte("address-d")),
+	}
+	for _, addr := range addrs {
+		mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, addr))
+	}
+	accountCache.Write()
+
+	report := mapper.AuditAccountNumbers(ctx)
+	require.True(t, report.Consistent())
+	require.Equal(t, int64(4), report.NextAccountNumber)
+	require.Equal(t, int64(4), report.Accounts)
+	require.Equal(t, int64(0), report.Gaps)
+
+	// b and c share number 1, d is numbered above the counter, leaving 3 unused
+	acc := mapper.GetAccount(ctx, addrs[1])
+	acc.SetSequence(5)
+	mapper.SetAccount(ctx, acc)
+	acc = mapper.GetAccount(ctx, addrs[2])
+	acc.SetAccountNumber(1)
+	mapper.SetAccount(ctx, acc)
+	acc = mapper.GetAccount(ctx, addrs[3])
+	acc.SetAccountNumber(6)
+	mapper.SetAccount(ctx, acc)
+	accountCache.Write()
+
+	report = mapper.AuditAccountNumbers(ctx)
+	require.False(t, report.Consistent())
+	require.Equal(t, []AccountNumberDuplicate{{AccountNumber: 1, Addresses: []sdk.AccAddress{addrs[1], addrs[2]}}}, report.Duplicates)
+	require.Equal(t, []sdk.AccAddress{addrs[3]}, report.AboveNext)
+	require.Equal(t, int64(2), report.Gaps)
+	require.Equal(t, []int64{2, 3}, report.FirstGaps)
+
+	// the account with the highest sequence keeps the shared number
+	renumbered := mapper.RepairAccountNumbers(ctx)
+	require.Equal(t, []sdk.AccAddress{addrs[2]}, renumbered)
+	accountCache.Write()
+	require.Equal(t, int64(1), mapper.GetAccount(ctx, addrs[1]).GetAccountNumber())
+	require.Equal(t, int64(7), mapper.GetAccount(ctx, addrs[2]).GetAccountNumber())
+
+	report = mapper.AuditAccountNumbers(ctx)
+	require.True(t, report.Consistent())
+	require.Equal(t, int64(8), report.NextAccountNumber)
+	require.Equal(t, []int64{2, 3, 4, 5}, report.FirstGaps)
+	require.Empty(t, mapper.RepairAccountNumbers(ctx))
+}
@@ -19,6 +19,7 @@ const (
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers
 func NewAnteHandler(am AccountKeeper) sdk.AnteHandler {
+	sigCache := newSigVerifyCache(defaultSigCacheSize)
 	return func(
 		ctx sdk.Context, tx sdk.Tx, mode sdk.RunTxMode,
 	) (newCtx sdk.Context, res sdk.Result, abort bool) {
@@ -40,6 +41,18 @@ func NewAnteHandler(am AccountKeeper) sdk.AnteHandler {
 			if err != nil {
 				return newCtx, err.Result(), true
 			}
+			err = validateMemo(newCtx, stdTx.GetMemo())
+			if err != nil {
+				return newCtx, err.Result(), true
+			}
+			err = validateChainIDEpoch(newCtx, am)
+			if err != nil {
+				return newCtx, err.Result(), true
+			}
+			err = validateTxLimits(newCtx, am, stdTx)
+			if err != nil {
+				return newCtx, err.Result(), true
+			}
 		}
 
 		// stdSigs contains the sequence number, account number, and signatures
@@ -71,7 +84,7 @@ func NewAnteHandler(am AccountKeeper) sdk.AnteHandler {
 				signBytes = nil
 			}
 			signerAccs[i], res = processSig(newCtx, signerAccs[i],
-				stdSigs[i], signBytes, mode)
+				stdSigs[i], signBytes, mode, sigCache)
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
@@ -149,8 +162,9 @@ func validateAccNumAndSequence(ctx sdk.Context, accs []sdk.Account, sigs []StdSi
 
 // verify the signature and increment the sequence.
 // if the account doesn't have a pubkey, set it.
+// the signatures verified in CheckTx are not verified again in DeliverTx, see sigVerifyCache.
 func processSig(ctx sdk.Context,
-	acc sdk.Account, sig StdSignature, signBytes []byte, mode sdk.RunTxMode) (updatedAcc sdk.Account, res sdk.Result) {
+	acc sdk.Account, sig StdSignature, signBytes []byte, mode sdk.RunTxMode, sigCache *sigVerifyCache) (updatedAcc sdk.Account, res sdk.Result) {
 	pubKey, res := processPubKey(acc, sig, mode == sdk.RunTxModeSimulate)
 	if !res.IsOK() {
 		return nil, res
@@ -160,7 +174,7 @@ func processSig(ctx sdk.Context,
 	if err != nil {
 		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
 	}
-	if (mode == sdk.RunTxModeCheck || mode == sdk.RunTxModeDeliver) && !pubKey.VerifyBytes(signBytes, sig.Signature) {
+	if (mode == sdk.RunTxModeCheck || mode == sdk.RunTxModeDeliver) && !sigCache.verify(mode, pubKey, signBytes, sig.Signature) {
 		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
 	}
 	// increment the sequence number
@@ -5,11 +5,14 @@ import (
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -139,6 +142,132 @@ func TestAnteHandlerSigErrors(t *testing.T) {
 	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeUnknownAddress)
 }
 
+// Test the memo validators registered by the app.
+func TestAnteHandlerMemoValidator(t *testing.T) {
+	// setup
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	anteHandler := NewAnteHandler(mapper)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	RegisterMemoValidator(NewMemoValidator(map[string]MemoParams{
+		"mychainid":    {MaxLength: 8, NumericOnly: true},
+		"otherchainid": {Pattern: "^[a-z]+$"},
+	}))
+	defer func() { memoValidators = nil }()
+
+	priv1, addr1 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []int64{0}
+
+	tx := newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{0}, "123456789")
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeMemoTooLarge)
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{0}, "1234a")
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeInvalidTxMemo)
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{0}, "12345678")
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+
+	// the params of the other chains
+	ctx = ctx.WithChainID("otherchainid")
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{1}, "1234")
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeInvalidTxMemo)
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{1}, "memo")
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+	ctx = ctx.WithChainID("unknownchainid")
+	tx = newTestTxWithMemo(ctx, msgs, privs, accnums, []int64{2}, "any memo")
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+}
+
+// Test the txs are only accepted with the chain-id of the current epoch.
+func TestAnteHandlerChainIDEpoch(t *testing.T) {
+	// setup
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	accountCache := getAccountCache(cdc, ms, capKey)
+	anteHandler := NewAnteHandler(mapper)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	priv1, addr1 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []int64{0}
+
+	// no epoch
+	tx := newTestTx(ctx, msgs, privs, accnums, []int64{0})
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+
+	// the tx signed for the previous revision is rejected by a node still running with its chain-id
+	mapper.SetChainIDEpoch(ctx, ChainIDEpoch{ChainID: "mychainid", Revision: 1})
+	tx = newTestTx(ctx, msgs, privs, accnums, []int64{1})
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeUnauthorized)
+
+	ctx = ctx.WithChainID("mychainid-1")
+	checkInvalidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver, sdk.CodeUnauthorized)
+	tx = newTestTx(ctx, msgs, privs, accnums, []int64{1})
+	checkValidTx(t, anteHandler, ctx, tx, sdk.RunTxModeDeliver)
+
+	require.Panics(t, func() { mapper.SetChainIDEpoch(ctx, ChainIDEpoch{ChainID: "mychainid"}) })
+}
+
+func TestAnteHandlerTxLimits(t *testing.T) {
+	// setup
+	db := dbm.NewMemDB()
+	capKey := sdk.NewKVStoreKey("capkey")
+	paramsKey, tparamsKey := sdk.NewKVStoreKey("params"), sdk.NewTransientStoreKey("transient_params")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(capKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tparamsKey, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	sdk.RegisterCodec(cdc)
+	cdc.RegisterConcrete(&sdk.TestMsg{}, "cosmos-sdk/TestMsg", nil)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	mapper.SetParamSpace(params.NewKeeper(cdc, paramsKey, tparamsKey).Subspace(DefaultParamspace))
+	accountCache := getAccountCache(cdc, ms, capKey)
+	anteHandler := NewAnteHandler(mapper)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+
+	priv1, addr1 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+	privs, accnums := []crypto.PrivKey{priv1}, []int64{0}
+	msgs := []sdk.Msg{newTestMsg(addr1), newTestMsg(addr1)}
+	newLargeTx := func(seq int64) sdk.Tx {
+		data := make([]byte, 2048)
+		signBytes := StdSignBytes(ctx.ChainID(), 0, seq, msgs[:1], "", 0, data)
+		tx := newTestTxWithSignBytes(msgs[:1], privs, accnums, []int64{seq}, signBytes, "").(StdTx)
+		tx.Data = data
+		return tx
+	}
+
+	// no limits until the params are set
+	checkValidTx(t, anteHandler, ctx, newTestTx(ctx, msgs, privs, accnums, []int64{0}), sdk.RunTxModeDeliver)
+	checkValidTx(t, anteHandler, ctx, newLargeTx(1), sdk.RunTxModeDeliver)
+
+	mapper.SetParams(ctx, Params{MaxTxBytes: 2048, MaxMsgsPerTx: 1})
+	checkInvalidTx(t, anteHandler, ctx, newTestTx(ctx, msgs, privs, accnums, []int64{2}), sdk.RunTxModeDeliver, sdk.CodeTooManyMsgs)
+	checkInvalidTx(t, anteHandler, ctx, newLargeTx(2), sdk.RunTxModeDeliver, sdk.CodeTxTooLarge)
+	checkValidTx(t, anteHandler, ctx, newTestTx(ctx, msgs[:1], privs, accnums, []int64{2}), sdk.RunTxModeDeliver)
+
+	require.Error(t, (&Params{MaxTxBytes: 100}).UpdateCheck())
+	require.Error(t, (&Params{MaxMsgsPerTx: -1}).UpdateCheck())
+	defaults := DefaultParams()
+	require.NoError(t, defaults.UpdateCheck())
+}
+
 // Test logic around account number checking with one signer and many signers.
 func TestAnteHandlerAccountNumbers(t *testing.T) {
 	// setup
@@ -547,3 +676,37 @@ func TestProcessPubKey(t *testing.T) {
 		})
 	}
 }
+
+type countingPubKey struct {
+	crypto.PubKey
+	verifications *int
+}
+
+func (pk countingPubKey) VerifyBytes(msg []byte, sig []byte) bool {
+	*pk.verifications++
+	return pk.PubKey.VerifyBytes(msg, sig)
+}
+
+func TestSigVerifyCache(t *testing.T) {
+	priv, _ := privAndAddr()
+	var verifications int
+	pubKey := countingPubKey{PubKey: priv.PubKey(), verifications: &verifications}
+	signBytes := []byte("sign bytes")
+	sig, err := priv.Sign(signBytes)
+	require.NoError(t, err)
+
+	cache := newSigVerifyCache(10)
+	require.True(t, cache.verify(sdk.RunTxModeCheck, pubKey, signBytes, sig))
+	require.Equal(t, 1, verifications)
+
+	// a signature verified in CheckTx is not verified again in DeliverTx, only once
+	require.True(t, cache.verify(sdk.RunTxModeDeliver, pubKey, signBytes, sig))
+	require.Equal(t, 1, verifications)
+	require.True(t, cache.verify(sdk.RunTxModeDeliver, pubKey, signBytes, sig))
+	require.Equal(t, 2, verifications)
+
+	// other sign bytes, e.g. with another sequence, miss the cache
+	require.True(t, cache.verify(sdk.RunTxModeCheck, pubKey, signBytes, sig))
+	require.False(t, cache.verify(sdk.RunTxModeDeliver, pubKey, []byte("other sign bytes"), sig))
+	require.Equal(t, 4, verifications)
+}
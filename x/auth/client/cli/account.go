@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -11,6 +12,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
+const flagDisplay = "display"
+
 // GetAccountCmdDefault invokes the GetAccountCmd for the auth.BaseAccount type.
 func GetAccountCmdDefault(storeName string, cdc *codec.Codec) *cobra.Command {
 	return GetAccountCmd(storeName, cdc, GetAccountDecoder(cdc))
@@ -32,7 +35,7 @@ func GetAccountDecoder(cdc *codec.Codec) auth.AccountDecoder {
 // account at a given address.
 // nolint: unparam
 func GetAccountCmd(storeName string, cdc *codec.Codec, decoder auth.AccountDecoder) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "account [address]",
 		Short: "Query account balance",
 		Args:  cobra.ExactArgs(1),
@@ -58,6 +61,11 @@ func GetAccountCmd(storeName string, cdc *codec.Codec, decoder auth.AccountDecod
 				return err
 			}
 
+			if viper.GetBool(flagDisplay) {
+				fmt.Println(displayAccount(acc))
+				return nil
+			}
+
 			var output []byte
 			if cliCtx.Indent {
 				output, err = cdc.MarshalJSONIndent(acc, "", "  ")
@@ -72,4 +80,18 @@ func GetAccountCmd(storeName string, cdc *codec.Codec, decoder auth.AccountDecod
 			return nil
 		},
 	}
+	cmd.Flags().Bool(flagDisplay, false, "Print the account with its balances in display units instead of its JSON encoding")
+
+	return cmd
+}
+
+// displayAccount renders the account with the coin amounts scaled by the denom exponents
+func displayAccount(acc sdk.Account) string {
+	resp := "Account \n"
+	resp += fmt.Sprintf("Address: %s\n", acc.GetAddress())
+	resp += fmt.Sprintf("Coins: %s\n", sdk.FormatCoins(acc.GetCoins()))
+	resp += fmt.Sprintf("Account number: %d\n", acc.GetAccountNumber())
+	resp += fmt.Sprintf("Sequence: %d", acc.GetSequence())
+
+	return resp
 }
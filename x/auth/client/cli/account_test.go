@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestDisplayAccount(t *testing.T) {
+	addr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	acc := auth.NewBaseAccountWithAddress(addr)
+	require.Nil(t, acc.SetCoins(sdk.Coins{sdk.NewCoin("ABC-123", 100000000), sdk.NewCoin(sdk.NativeTokenSymbol, 150000000)}))
+	require.Nil(t, acc.SetAccountNumber(3))
+	require.Nil(t, acc.SetSequence(7))
+
+	expected := "Account \n" +
+		"Address: " + addr.String() + "\n" +
+		"Coins: 1:ABC-123,1.5:BNB\n" +
+		"Account number: 3\n" +
+		"Sequence: 7"
+	require.Equal(t, expected, displayAccount(&acc))
+}
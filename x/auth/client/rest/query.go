@@ -102,6 +102,10 @@ func QueryBalancesRequestHandlerFn(
 			return
 		}
 
+		if utils.HasDisplayArg(r) {
+			utils.PostProcessResponse(w, cdc, sdk.DisplayCoins(account.GetCoins()), cliCtx.Indent)
+			return
+		}
 		utils.PostProcessResponse(w, cdc, account.GetCoins(), cliCtx.Indent)
 	}
 }
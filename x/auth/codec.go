@@ -10,11 +10,15 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterInterface((*types.Account)(nil), nil)
 	cdc.RegisterConcrete(&BaseAccount{}, "auth/Account", nil)
 	cdc.RegisterConcrete(StdTx{}, "auth/StdTx", nil)
+	cdc.RegisterConcrete(&Params{}, "params/AuthParamSet", nil)
 }
 
-var msgCdc = codec.New()
+// sealed codec of the sign bytes, the apps register their types on their own codec
+var msgCdc *codec.Codec
 
 func init() {
-	RegisterCodec(msgCdc)
-	codec.RegisterCrypto(msgCdc)
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+	msgCdc = cdc.Seal()
 }
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var chainIDEpochKey = []byte("chainIDEpoch")
+
+// ChainIDEpoch is the revision of the chain-id the txs are signed for. A chain fork bumps
+// the revision, so that the txs signed before the fork can not be replayed on a node that
+// still runs with the chain-id of the previous revision.
+type ChainIDEpoch struct {
+	ChainID  string `json:"chain_id"`
+	Revision int64  `json:"revision"`
+}
+
+// SignChainID returns the chain-id the txs of the epoch are signed for, the chain-id itself
+// for the revision 0 and "<chain-id>-<revision>" for the following ones
+func (e ChainIDEpoch) SignChainID() string {
+	if e.Revision == 0 {
+		return e.ChainID
+	}
+	return fmt.Sprintf("%s-%d", e.ChainID, e.Revision)
+}
+
+// GetChainIDEpoch returns the current epoch, false if none has been set
+func (am AccountKeeper) GetChainIDEpoch(ctx sdk.Context) (epoch ChainIDEpoch, found bool) {
+	bz := ctx.KVStore(am.key).Get(chainIDEpochKey)
+	if bz == nil {
+		return epoch, false
+	}
+	am.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &epoch)
+	return epoch, true
+}
+
+// SetChainIDEpoch sets the epoch enforced by the ante handler, it is typically called by an
+// upgrade BeginBlocker at the fork height. The revision never decreases.
+func (am AccountKeeper) SetChainIDEpoch(ctx sdk.Context, epoch ChainIDEpoch) {
+	if current, found := am.GetChainIDEpoch(ctx); found && epoch.Revision < current.Revision {
+		panic(fmt.Sprintf("chain-id revision can not decrease from %d to %d", current.Revision, epoch.Revision))
+	}
+	ctx.KVStore(am.key).Set(chainIDEpochKey, am.cdc.MustMarshalBinaryLengthPrefixed(epoch))
+}
+
+// validateChainIDEpoch rejects the txs if the node does not run with the chain-id of the
+// current epoch, as their signatures are checked against the chain-id of the node
+func validateChainIDEpoch(ctx sdk.Context, am AccountKeeper) sdk.Error {
+	epoch, found := am.GetChainIDEpoch(ctx)
+	if !found {
+		return nil
+	}
+	if ctx.ChainID() != epoch.SignChainID() {
+		return sdk.ErrUnauthorized(fmt.Sprintf("txs are signed for the chain-id %s of revision %d, the node runs with %s",
+			epoch.SignChainID(), epoch.Revision, ctx.ChainID()))
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 /*
@@ -36,6 +37,9 @@ type AccountKeeper struct {
 
 	// The codec codec for binary encoding/decoding of accounts.
 	cdc *codec.Codec
+
+	// The param space of the tx limits, nil unless SetParamSpace is used.
+	paramSpace *params.Subspace
 }
 
 // NewAccountKeeper returns a new sdk.AccountKeeper that
@@ -175,6 +179,20 @@ func (am AccountKeeper) GetNextAccountNumber(ctx sdk.Context) int64 {
 	return accNumber
 }
 
+// setNextAccountNumber raises the global account number counter to accNumber,
+// it never lowers it so that account numbers are not reused
+func (am AccountKeeper) setNextAccountNumber(ctx sdk.Context, accNumber int64) {
+	store := ctx.KVStore(am.key)
+	if bz := store.Get(globalAccountNumberKey); bz != nil {
+		var current int64
+		am.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &current)
+		if current >= accNumber {
+			return
+		}
+	}
+	store.Set(globalAccountNumberKey, am.cdc.MustMarshalBinaryLengthPrefixed(accNumber))
+}
+
 //----------------------------------------
 // misc.
 
@@ -1,6 +1,9 @@
 package auth
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -106,6 +109,49 @@ func TestAccountMapperRemoveAccount(t *testing.T) {
 	require.Equal(t, accSeq2, acc2.GetSequence())
 }
 
+func TestAccountsStreamingGenesis(t *testing.T) {
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	newContext := func() (sdk.Context, sdk.AccountCache, AppModule) {
+		ms, capKey, _ := setupMultiStore()
+		accountCache := getAccountCache(cdc, ms, capKey)
+		ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger()).WithAccountCache(accountCache)
+		return ctx, accountCache, NewAppModule(NewAccountKeeper(cdc, capKey, ProtoBaseAccount))
+	}
+
+	ctx, accountCache, module := newContext()
+	addrs := []sdk.AccAddress{sdk.AccAddress([]byte("addr1")), sdk.AccAddress([]byte("addr2"))}
+	for _, addr := range addrs {
+		acc := module.keeper.NewAccountWithAddress(ctx, addr)
+		acc.SetSequence(5)
+		module.keeper.SetAccount(ctx, acc)
+	}
+	accountCache.Write()
+
+	var buf bytes.Buffer
+	require.Nil(t, module.ExportGenesisStream(ctx, &buf))
+
+	ctx2, _, module2 := newContext()
+	_, err := module2.InitGenesisStream(ctx2, json.NewDecoder(&buf))
+	require.Nil(t, err)
+	for i, addr := range addrs {
+		acc := module2.keeper.GetAccount(ctx2, addr)
+		require.NotNil(t, acc)
+		require.Equal(t, int64(i), acc.GetAccountNumber())
+		require.Equal(t, int64(5), acc.GetSequence())
+	}
+	// new accounts don't reuse the account numbers of the genesis accounts
+	require.Equal(t, int64(len(addrs)), module2.keeper.GetNextAccountNumber(ctx2))
+
+	// duplicate accounts are rejected
+	ctx3, _, module3 := newContext()
+	accounts := module.ExportGenesis(ctx)
+	accounts = accounts[1 : len(accounts)-1]
+	require.Panics(t, func() {
+		module3.InitGenesis(ctx3, json.RawMessage(fmt.Sprintf("[%s,%s]", accounts, accounts)))
+	})
+}
+
 func BenchmarkAccountMapperGetAccountFound(b *testing.B) {
 	ms, capKey, _ := setupMultiStore()
 	cdc := codec.New()
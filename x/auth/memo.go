@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"fmt"
+	"regexp"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MemoValidator checks the memo of a tx in the ante handler, see RegisterMemoValidator
+type MemoValidator func(ctx sdk.Context, memo string) sdk.Error
+
+var memoValidators []MemoValidator
+
+// RegisterMemoValidator adds a validator run by the ante handler on the memo of every tx,
+// after the length check of maxMemoCharacters. It must be called before the app starts.
+func RegisterMemoValidator(validator MemoValidator) {
+	memoValidators = append(memoValidators, validator)
+}
+
+func validateMemo(ctx sdk.Context, memo string) sdk.Error {
+	for _, validator := range memoValidators {
+		if err := validator(ctx, memo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MemoParams are the memo format enforced on a chain by NewMemoValidator
+type MemoParams struct {
+	// MaxLength is the maximum number of bytes of the memo, 0 keeps the default one
+	MaxLength int `json:"max_length"`
+	// NumericOnly only accepts the digits 0-9
+	NumericOnly bool `json:"numeric_only"`
+	// Pattern is a regular expression the memo must match, if set
+	Pattern string `json:"pattern"`
+}
+
+// NewMemoValidator returns a MemoValidator enforcing the params of the chain of the context,
+// the memos of the other chains are not checked. It panics if a pattern does not compile.
+func NewMemoValidator(paramsByChainID map[string]MemoParams) MemoValidator {
+	patterns := make(map[string]*regexp.Regexp)
+	for chainID, params := range paramsByChainID {
+		if params.MaxLength < 0 || params.MaxLength > maxMemoCharacters {
+			panic(fmt.Sprintf("max memo length of chain %s should be between 0 and %d", chainID, maxMemoCharacters))
+		}
+		if params.Pattern != "" {
+			patterns[chainID] = regexp.MustCompile(params.Pattern)
+		}
+	}
+
+	return func(ctx sdk.Context, memo string) sdk.Error {
+		params, ok := paramsByChainID[ctx.ChainID()]
+		if !ok {
+			return nil
+		}
+		if params.MaxLength > 0 && len(memo) > params.MaxLength {
+			return sdk.ErrMemoTooLarge(
+				fmt.Sprintf("maximum number of characters is %d but received %d characters",
+					params.MaxLength, len(memo)))
+		}
+		if params.NumericOnly {
+			for _, c := range memo {
+				if c < '0' || c > '9' {
+					return sdk.ErrInvalidTxMemo("memo should only contain digits")
+				}
+			}
+		}
+		if pattern, ok := patterns[ctx.ChainID()]; ok && !pattern.MatchString(memo) {
+			return sdk.ErrInvalidTxMemo(fmt.Sprintf("memo should match %s", params.Pattern))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ModuleName is the name of the accounts module in the ModuleManager
+const ModuleName = "accounts"
+
+var _ sdk.AppModuleStreamingGenesis = AppModule{}
+
+// AppModule registers the accounts in the ModuleManager, their genesis state is
+// the list of accounts, streamed one by one as there may be tens of millions of them
+type AppModule struct {
+	keeper AccountKeeper
+}
+
+// NewAppModule creates a new AppModule of the accounts
+func NewAppModule(keeper AccountKeeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// Name returns the module name
+func (AppModule) Name() string {
+	return ModuleName
+}
+
+// DefaultGenesis returns an empty list of accounts
+func (AppModule) DefaultGenesis() json.RawMessage {
+	return json.RawMessage(`[]`)
+}
+
+// InitGenesis sets the accounts
+func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	updates, err := am.InitGenesisStream(ctx, json.NewDecoder(bytes.NewReader(data)))
+	if err != nil {
+		panic(fmt.Sprintf("failed to init accounts genesis: %v", err))
+	}
+	return updates
+}
+
+// ExportGenesis exports all the accounts
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	var buf bytes.Buffer
+	if err := am.ExportGenesisStream(ctx, &buf); err != nil {
+		panic(fmt.Sprintf("failed to export accounts genesis: %v", err))
+	}
+	return buf.Bytes()
+}
+
+// InitGenesisStream sets the accounts while decoding them. The accounts keep their account
+// numbers, the next account number is set after the highest one.
+func (am AppModule) InitGenesisStream(ctx sdk.Context, decoder *json.Decoder) ([]abci.ValidatorUpdate, error) {
+	nextAccountNumber := int64(0)
+	err := sdk.DecodeJSONArray(decoder, func(element json.RawMessage) error {
+		var acc sdk.Account
+		if err := am.keeper.cdc.UnmarshalJSON(element, &acc); err != nil {
+			return err
+		}
+		if acc.GetAddress().Empty() {
+			return fmt.Errorf("genesis account has no address")
+		}
+		if am.keeper.GetAccount(ctx, acc.GetAddress()) != nil {
+			return fmt.Errorf("duplicate genesis account %s", acc.GetAddress())
+		}
+		if acc.GetAccountNumber() >= nextAccountNumber {
+			nextAccountNumber = acc.GetAccountNumber() + 1
+		}
+		am.keeper.SetAccount(ctx, acc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	am.keeper.setNextAccountNumber(ctx, nextAccountNumber)
+	return nil, nil
+}
+
+// ExportGenesisStream writes the accounts one by one while iterating the store
+func (am AppModule) ExportGenesisStream(ctx sdk.Context, w io.Writer) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	var err error
+	first := true
+	am.keeper.IterateAccounts(ctx, func(acc sdk.Account) (stop bool) {
+		var bz []byte
+		bz, err = am.keeper.cdc.MarshalJSON(acc)
+		if err == nil && !first {
+			_, err = io.WriteString(w, ",")
+		}
+		if err == nil {
+			_, err = w.Write(bz)
+		}
+		first = false
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "]")
+	return err
+}
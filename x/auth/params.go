@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	pTypes "github.com/cosmos/cosmos-sdk/x/paramHub/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+const (
+	// DefaultParamspace is the param space of the tx limits
+	DefaultParamspace = "auth"
+
+	// a governance tx must always fit, so that a too low limit can be raised again
+	minMaxTxBytes int64 = 1024
+)
+
+// nolint - Keys for parameter access
+var (
+	KeyMaxTxBytes   = []byte("MaxTxBytes")
+	KeyMaxMsgsPerTx = []byte("MaxMsgsPerTx")
+)
+
+var _ params.ParamSet = (*Params)(nil)
+
+// Params are the limits on the size of the txs enforced by the ante handler, 0 means no limit
+type Params struct {
+	MaxTxBytes   int64 `json:"max_tx_bytes"`    // maximum size of the amino encoding of a tx
+	MaxMsgsPerTx int64 `json:"max_msgs_per_tx"` // maximum number of msgs of a tx
+}
+
+// DefaultParams returns the params without limits
+func DefaultParams() Params {
+	return Params{}
+}
+
+// ParamTypeTable returns the type table of the tx limits
+func ParamTypeTable() params.TypeTable {
+	return params.NewTypeTable().RegisterParamSet(&Params{})
+}
+
+// Implements params.ParamSet
+func (p *Params) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{KeyMaxTxBytes, &p.MaxTxBytes},
+		{KeyMaxMsgsPerTx, &p.MaxMsgsPerTx},
+	}
+}
+
+func (p *Params) GetBCParamAttribute() string {
+	return "auth"
+}
+
+func (p *Params) UpdateCheck() error {
+	if p.MaxTxBytes != 0 && p.MaxTxBytes < minMaxTxBytes {
+		return fmt.Errorf("the max_tx_bytes should be 0 or no less than %d", minMaxTxBytes)
+	}
+	if p.MaxMsgsPerTx < 0 {
+		return fmt.Errorf("the max_msgs_per_tx should be no less than 0")
+	}
+	return nil
+}
+
+// SetParamSpace enables the tx limits of the ante handler, stored in paramSpace. It must be
+// called before the keeper is passed to NewAnteHandler.
+func (am *AccountKeeper) SetParamSpace(paramSpace params.Subspace) {
+	paramSpace = paramSpace.WithTypeTable(ParamTypeTable())
+	am.paramSpace = &paramSpace
+}
+
+// GetParams returns the tx limits, without limits if they have not been set
+func (am AccountKeeper) GetParams(ctx sdk.Context) (res Params) {
+	if am.paramSpace == nil {
+		return DefaultParams()
+	}
+	am.paramSpace.GetIfExists(ctx, KeyMaxTxBytes, &res.MaxTxBytes)
+	am.paramSpace.GetIfExists(ctx, KeyMaxMsgsPerTx, &res.MaxMsgsPerTx)
+	return res
+}
+
+// SetParams sets the tx limits, typically at genesis or in an upgrade BeginBlocker, as
+// the params must be set before they are governed, see SubscribeBCParamChange
+func (am AccountKeeper) SetParams(ctx sdk.Context, p Params) {
+	am.paramSpace.SetParamSet(ctx, &p)
+}
+
+// SubscribeBCParamChange applies the tx limits changed by the param change proposals
+func (am AccountKeeper) SubscribeBCParamChange(hub pTypes.BCParamChangePublisher) {
+	hub.SubscribeBCParamChange(
+		func(context sdk.Context, iChange interface{}) {
+			switch change := iChange.(type) {
+			case *Params:
+				err := change.UpdateCheck()
+				if err != nil {
+					context.Logger().Error("[bc] skip invalid param change", "err", err, "param", change)
+				} else {
+					am.SetParams(context, *change)
+				}
+			default:
+				context.Logger().Debug("[bc] skip unknown bc param change")
+			}
+		},
+		&pTypes.BCParamSpaceProto{ParamSpace: *am.paramSpace, Proto: func() pTypes.BCParam {
+			return new(Params)
+		}},
+	)
+}
+
+// validateTxLimits rejects the txs exceeding the tx limits
+func validateTxLimits(ctx sdk.Context, am AccountKeeper, tx StdTx) sdk.Error {
+	p := am.GetParams(ctx)
+	if p.MaxMsgsPerTx > 0 && int64(len(tx.Msgs)) > p.MaxMsgsPerTx {
+		return sdk.ErrTooManyMsgs(fmt.Sprintf("maximum number of msgs is %d but received %d msgs",
+			p.MaxMsgsPerTx, len(tx.Msgs)))
+	}
+	if p.MaxTxBytes > 0 {
+		// the canonical encoding, a tx padded with ignored bytes is not larger
+		size := int64(len(am.cdc.MustMarshalBinaryLengthPrefixed(tx)))
+		if size > p.MaxTxBytes {
+			return sdk.ErrTxTooLarge(fmt.Sprintf("maximum tx size is %d bytes but received %d bytes",
+				p.MaxTxBytes, size))
+		}
+	}
+	return nil
+}
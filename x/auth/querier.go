@@ -0,0 +1,47 @@
+package auth
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AccountProofQuerier answers the queries "/prove/<route>" with the JSON of the account
+// of the address in the query data, along with the proof of the account
+type AccountProofQuerier struct {
+	storeName string
+	cdc       *codec.Codec
+}
+
+var _ sdk.ProofQuerier = AccountProofQuerier{}
+
+// NewAccountProofQuerier returns an AccountProofQuerier of the accounts kept in the store
+// storeName, cdc must register the concrete account types
+func NewAccountProofQuerier(storeName string, cdc *codec.Codec) AccountProofQuerier {
+	return AccountProofQuerier{storeName: storeName, cdc: cdc}
+}
+
+// Key implements sdk.ProofQuerier
+func (q AccountProofQuerier) Key(path []string, req abci.RequestQuery) (string, []byte, sdk.Error) {
+	if len(req.Data) == 0 {
+		return "", nil, sdk.ErrInvalidAddress("account address is empty")
+	}
+	return q.storeName, AddressStoreKey(req.Data), nil
+}
+
+// Result implements sdk.ProofQuerier
+func (q AccountProofQuerier) Result(value []byte, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+	if value == nil {
+		return nil, sdk.ErrUnknownAddress(sdk.AccAddress(req.Data).String())
+	}
+	var acc sdk.Account
+	if err := q.cdc.UnmarshalBinaryBare(value, &acc); err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not decode account", err.Error()))
+	}
+	bz, err := codec.MarshalJSONIndent(q.cdc, acc)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// number of signatures verified by CheckTx remembered until the tx is delivered
+const defaultSigCacheSize = 50000
+
+// sigVerifyCache remembers the signatures verified by CheckTx, so that DeliverTx does not
+// verify them again. A signature is keyed by the hash of the public key, the sign bytes and the
+// signature, so that any change of the chain id, or of the account number, sequence or public key
+// of the signer since CheckTx misses the cache and the signature is verified again.
+type sigVerifyCache struct {
+	cache *lru.Cache
+}
+
+func newSigVerifyCache(size int) *sigVerifyCache {
+	cache, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	return &sigVerifyCache{cache: cache}
+}
+
+func sigCacheKey(pubKey crypto.PubKey, signBytes []byte, sig []byte) [sha256.Size]byte {
+	hasher := sha256.New()
+	for _, bz := range [][]byte{pubKey.Bytes(), signBytes, sig} {
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(bz)))
+		hasher.Write(length[:])
+		hasher.Write(bz)
+	}
+	var key [sha256.Size]byte
+	copy(key[:], hasher.Sum(nil))
+	return key
+}
+
+// verify verifies a signature, the signatures verified in CheckTx are remembered and the
+// ones found in DeliverTx are forgotten, since a tx is only delivered once
+func (c *sigVerifyCache) verify(mode sdk.RunTxMode, pubKey crypto.PubKey, signBytes []byte, sig []byte) bool {
+	key := sigCacheKey(pubKey, signBytes, sig)
+	if mode == sdk.RunTxModeDeliver && c.cache.Contains(key) {
+		c.cache.Remove(key)
+		return true
+	}
+	if !pubKey.VerifyBytes(signBytes, sig) {
+		return false
+	}
+	if mode == sdk.RunTxModeCheck {
+		c.cache.Add(key, struct{}{})
+	}
+	return true
+}
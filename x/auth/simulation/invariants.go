@@ -0,0 +1,25 @@
+package simulation
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+)
+
+// AccountNumberInvariant checks that each account has its own account number, below the next account number
+func AccountNumberInvariant(mapper auth.AccountKeeper) simulation.Invariant {
+	return func(app *baseapp.BaseApp) error {
+		ctx := app.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+		app.DeliverState.WriteAccountCache()
+		report := mapper.AuditAccountNumbers(ctx)
+		if !report.Consistent() {
+			return fmt.Errorf("inconsistent account numbers: %s", report)
+		}
+		return nil
+	}
+}
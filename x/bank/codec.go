@@ -7,10 +7,14 @@ import (
 // Register concrete types on codec codec
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSend{}, "cosmos-sdk/Send", nil)
+	cdc.RegisterConcrete(MsgSweepDust{}, "cosmos-sdk/SweepDust", nil)
 }
 
-var msgCdc = codec.New()
+// sealed codec of the sign bytes, the apps register their types on their own codec
+var msgCdc *codec.Codec
 
 func init() {
-	RegisterCodec(msgCdc)
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	msgCdc = cdc.Seal()
 }
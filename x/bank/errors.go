@@ -11,8 +11,14 @@ const (
 
 	CodeInvalidInput  sdk.CodeType = 101
 	CodeInvalidOutput sdk.CodeType = 102
+	CodeNotDust       sdk.CodeType = 103
 )
 
+func init() {
+	sdk.RegisterCodespace(DefaultCodespace, "bank",
+		CodeInvalidInput, CodeInvalidOutput, CodeNotDust)
+}
+
 // NOTE: Don't stringer this, we'll put better messages in later.
 func codeToDefaultMsg(code sdk.CodeType) string {
 	switch code {
@@ -20,6 +26,8 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid input coins"
 	case CodeInvalidOutput:
 		return "invalid output coins"
+	case CodeNotDust:
+		return "balance is not dust"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -44,6 +52,10 @@ func ErrNoOutputs(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeInvalidOutput, "")
 }
 
+func ErrNotDust(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeNotDust, msg)
+}
+
 //----------------------------------------
 
 func msgOrDefaultMsg(msg string, code sdk.CodeType) string {
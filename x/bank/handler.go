@@ -12,6 +12,8 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgSend:
 			return handleMsgSend(ctx, k, msg)
+		case MsgSweepDust:
+			return handleMsgSweepDust(ctx, k, msg)
 		default:
 			errMsg := "Unrecognized bank Msg type: %s" + msg.Type()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -32,7 +34,7 @@ func handleMsgSend(ctx sdk.Context, k Keeper, msg MsgSend) sdk.Result {
 		}
 	}
 
-	if sdk.IsUpgrade(sdk.BEP8) {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP8) {
 		am := k.GetAccountKeeper()
 		for _, in := range msg.Inputs {
 			if err := CheckAndValidateMiniTokenCoins(ctx, am, in.Address, in.Coins); err != nil {
@@ -50,3 +52,38 @@ func handleMsgSend(ctx sdk.Context, k Keeper, msg MsgSend) sdk.Result {
 		Tags: tags,
 	}
 }
+
+// Handle MsgSweepDust.
+func handleMsgSweepDust(ctx sdk.Context, k Keeper, msg MsgSweepDust) sdk.Result {
+	logger := ctx.Logger()
+	for _, script := range sdk.GetRegisteredScripts(msg.Type()) {
+		if script == nil {
+			logger.Error(fmt.Sprintf("Empty script is specified for msg %s", msg.Type()))
+			continue
+		}
+		if err := script(ctx, msg); err != nil {
+			return err.Result()
+		}
+	}
+
+	balances := k.GetCoins(ctx, msg.From)
+	dust := make(sdk.Coins, 0, len(msg.Denoms))
+	for _, denom := range msg.Denoms {
+		amount := balances.AmountOf(denom)
+		if amount <= 0 || amount >= SweepDustThreshold {
+			return ErrNotDust(DefaultCodespace,
+				fmt.Sprintf("balance of %s is %d, dust must be positive and below %d", denom, amount, SweepDustThreshold)).Result()
+		}
+		dust = append(dust, sdk.NewCoin(denom, amount))
+	}
+	dust.Sort()
+
+	_, tags, err := k.SubtractCoins(ctx, msg.From, dust)
+	if err != nil {
+		return err.Result()
+	}
+	logger.Info("swept dust", "address", msg.From.String(), "coins", dust.String())
+	return sdk.Result{
+		Tags: tags.AppendTag("swept", []byte(dust.String())),
+	}
+}
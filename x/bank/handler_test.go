@@ -122,3 +122,29 @@ func createSendMsg(from sdk.AccAddress, to sdk.AccAddress, coins sdk.Coins) sdk.
 	msg := NewMsgSend([]Input{input}, []Output{output})
 	return msg
 }
+
+func TestHandleSweepDust(t *testing.T) {
+	ctx, handler, bankKeeper, accountKeeper := setup()
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	accountKeeper.SetAccount(ctx, accountKeeper.NewAccountWithAddress(ctx, addr))
+	bankKeeper.SetCoins(ctx, addr, sdk.Coins{
+		sdk.NewCoin("AAA-000", 10),
+		sdk.NewCoin("BBB-000", 1e8),
+		sdk.NewCoin("BNB", 10),
+		sdk.NewCoin("CCC-000", 99999999),
+	})
+
+	// all the denoms must be dust
+	sdkResult := handler(ctx, NewMsgSweepDust(addr, []string{"AAA-000", "BBB-000"}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNotDust), sdkResult.Code)
+	sdkResult = handler(ctx, NewMsgSweepDust(addr, []string{"AAA-000", "DDD-000"}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeNotDust), sdkResult.Code)
+
+	sdkResult = handler(ctx, NewMsgSweepDust(addr, []string{"CCC-000", "AAA-000"}))
+	require.True(t, sdkResult.Code.IsOK(), sdkResult.Log)
+	require.True(t, bankKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{
+		sdk.NewCoin("BBB-000", 1e8),
+		sdk.NewCoin("BNB", 10),
+	}))
+}
@@ -2,6 +2,8 @@ package bank
 
 import (
 	"encoding/json"
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -27,31 +29,24 @@ func (msg MsgSend) Type() string  { return "send" }
 func (msg MsgSend) ValidateBasic() sdk.Error {
 	// this just makes sure all the inputs and outputs are properly formatted,
 	// not that they actually have the money inside
-	if len(msg.Inputs) == 0 {
-		return ErrNoInputs(DefaultCodespace).TraceSDK("")
-	}
-	if len(msg.Outputs) == 0 {
-		return ErrNoOutputs(DefaultCodespace).TraceSDK("")
-	}
+	v := sdk.NewMsgValidator().
+		Require(len(msg.Inputs) != 0, "inputs", ErrNoInputs(DefaultCodespace)).
+		Require(len(msg.Outputs) != 0, "outputs", ErrNoOutputs(DefaultCodespace))
 	// make sure all inputs and outputs are individually valid
 	var totalIn, totalOut sdk.Coins
-	for _, in := range msg.Inputs {
-		if err := in.ValidateBasic(); err != nil {
-			return err.TraceSDK("")
-		}
+	for i, in := range msg.Inputs {
+		v.Check(fmt.Sprintf("inputs[%d]", i), in.ValidateBasic())
 		totalIn = totalIn.Plus(in.Coins)
 	}
-	for _, out := range msg.Outputs {
-		if err := out.ValidateBasic(); err != nil {
-			return err.TraceSDK("")
-		}
+	for i, out := range msg.Outputs {
+		v.Check(fmt.Sprintf("outputs[%d]", i), out.ValidateBasic())
 		totalOut = totalOut.Plus(out.Coins)
 	}
-	// make sure inputs and outputs match
-	if !totalIn.IsEqual(totalOut) {
-		return sdk.ErrInvalidCoins(totalIn.String()).TraceSDK("inputs and outputs don't match")
+	// make sure inputs and outputs match, the totals only make sense if they are all valid
+	if !v.HasErrors() && !totalIn.IsEqual(totalOut) {
+		v.Check("outputs", sdk.ErrInvalidCoins(totalIn.String()+", inputs and outputs don't match"))
 	}
-	return nil
+	return v.Error()
 }
 
 // Implements Msg.
@@ -148,3 +143,76 @@ func NewOutput(addr sdk.AccAddress, coins sdk.Coins) Output {
 	}
 	return output
 }
+
+//----------------------------------------
+// MsgSweepDust
+
+const (
+	// SweepDustThreshold is the balance below which a token is dust, 1 token with 8 decimals
+	SweepDustThreshold int64 = 1e8
+	// MaxSweepDustDenoms is the maximum number of denoms swept by a MsgSweepDust
+	MaxSweepDustDenoms = 100
+)
+
+// MsgSweepDust burns the whole balance of each given denom of the sender, all of them must
+// be dust, that is below SweepDustThreshold. It cleans the tiny balances of many tokens in a
+// single tx. The dust is burnt rather than converted into the native token as the prices of
+// the tokens are not known to this module.
+type MsgSweepDust struct {
+	From   sdk.AccAddress `json:"from"`
+	Denoms []string       `json:"denoms"`
+}
+
+var _ sdk.Msg = MsgSweepDust{}
+
+// NewMsgSweepDust - construct a msg sweeping the dust of denoms
+func NewMsgSweepDust(from sdk.AccAddress, denoms []string) MsgSweepDust {
+	return MsgSweepDust{From: from, Denoms: denoms}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgSweepDust) Route() string { return "bank" }
+func (msg MsgSweepDust) Type() string  { return "sweepDust" }
+
+// Implements Msg.
+func (msg MsgSweepDust) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(msg.From.String())
+	}
+	if len(msg.Denoms) == 0 {
+		return ErrInvalidInput(DefaultCodespace, "no denoms to sweep")
+	}
+	if len(msg.Denoms) > MaxSweepDustDenoms {
+		return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("can not sweep more than %d denoms", MaxSweepDustDenoms))
+	}
+	seen := make(map[string]bool, len(msg.Denoms))
+	for _, denom := range msg.Denoms {
+		if denom == "" || denom == sdk.NativeTokenSymbol {
+			return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("can not sweep denom %q", denom))
+		}
+		if seen[denom] {
+			return ErrInvalidInput(DefaultCodespace, fmt.Sprintf("duplicated denom %s", denom))
+		}
+		seen[denom] = true
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgSweepDust) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Implements Msg.
+func (msg MsgSweepDust) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+func (msg MsgSweepDust) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
@@ -253,3 +253,34 @@ func TestMsgSendSigners(t *testing.T) {
 	require.Equal(t, signers, tx.Signers())
 }
 */
+
+func TestMsgSweepDustValidation(t *testing.T) {
+	addr := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	tooMany := make([]string, MaxSweepDustDenoms+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("T%d-000", i)
+	}
+
+	cases := []struct {
+		valid bool
+		msg   MsgSweepDust
+	}{
+		{true, NewMsgSweepDust(addr, []string{"AAA-000", "BBB-000"})},
+		{true, NewMsgSweepDust(addr, tooMany[:MaxSweepDustDenoms])},
+		{false, NewMsgSweepDust(sdk.AccAddress([]byte{1, 2}), []string{"AAA-000"})},
+		{false, NewMsgSweepDust(addr, nil)},
+		{false, NewMsgSweepDust(addr, tooMany)},
+		{false, NewMsgSweepDust(addr, []string{"AAA-000", "AAA-000"})},
+		{false, NewMsgSweepDust(addr, []string{sdk.NativeTokenSymbol})},
+		{false, NewMsgSweepDust(addr, []string{""})},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
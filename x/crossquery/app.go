@@ -0,0 +1,80 @@
+package crossquery
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// QueryHandler answers a query of the side chain with the RLP encoded result, from the
+// RLP encoded params of the query. It must not change the state.
+type QueryHandler func(ctx sdk.Context, params []byte) ([]byte, sdk.Error)
+
+var queryHandlers = make(map[QueryType]QueryHandler)
+
+// RegisterQueryHandler registers the handler of the queries of queryType, it panics if the
+// query type is already registered
+func RegisterQueryHandler(queryType QueryType, handler QueryHandler) {
+	if _, ok := queryHandlers[queryType]; ok {
+		panic(fmt.Sprintf("query type %d is already registered", queryType))
+	}
+	queryHandlers[queryType] = handler
+}
+
+// CrossQueryApp is the cross chain application of the query channel. The contracts of a side
+// chain send query packages through the channel and get the state of this chain back in the
+// ack packages. As the ack packages are committed to the state like any other package, the
+// relayers deliver them with a merkle proof against the app hash, which is checked by the
+// light client of the side chain, so that the contracts can trust the results without a
+// dedicated relayer. The app is registered with
+//
+//	scKeeper.RegisterChannel(crossquery.ChannelName, crossquery.ChannelID, crossquery.NewCrossQueryApp())
+type CrossQueryApp struct{}
+
+func NewCrossQueryApp() *CrossQueryApp {
+	return &CrossQueryApp{}
+}
+
+func (app *CrossQueryApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, _ int64) sdk.ExecuteResult {
+	var result []byte
+	pack, err := DeserializeQuerySynPackage(payload)
+	var sdkErr sdk.Error
+	if err != nil {
+		sdkErr = ErrInvalidQuery(DefaultCodespace, fmt.Sprintf("unmarshal query package error: %s", err.Error()))
+	} else if handler, ok := queryHandlers[pack.QueryType]; !ok {
+		sdkErr = ErrUnknownQueryType(DefaultCodespace, pack.QueryType)
+	} else {
+		result, sdkErr = handler(ctx, pack.Params)
+	}
+
+	ackPackage := &QueryAckPackage{
+		Height: uint64(ctx.BlockHeight()),
+		Result: result,
+	}
+	if sdkErr != nil {
+		ctx.Logger().Info("cross chain query failed", "err", sdkErr.Error())
+		ackPackage.Code = uint32(sdkErr.ABCICode())
+		ackPackage.Result = nil
+	}
+	ackPayload, err := rlp.EncodeToBytes(ackPackage)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.ExecuteResult{
+		Payload: ackPayload,
+		Err:     sdkErr,
+		Tags:    sdk.EmptyTags(),
+	}
+}
+
+func (app *CrossQueryApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	ctx.Logger().Error("receive unexpected cross query ack package")
+	return sdk.ExecuteResult{}
+}
+
+// When the ack application crash, payload is the payload of the origin package.
+func (app *CrossQueryApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	ctx.Logger().Error("receive unexpected cross query fail ack package")
+	return sdk.ExecuteResult{}
+}
@@ -0,0 +1,54 @@
+package crossquery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func executeQuery(t *testing.T, ctx sdk.Context, payload []byte) QueryAckPackage {
+	result := NewCrossQueryApp().ExecuteSynPackage(ctx, payload, 0)
+	var ack QueryAckPackage
+	require.NoError(t, rlp.DecodeBytes(result.Payload, &ack))
+	return ack
+}
+
+func TestExecuteSynPackage(t *testing.T) {
+	ctx := sdk.NewContext(nil, abci.Header{Height: 5}, sdk.RunTxModeDeliver, log.NewNopLogger())
+	queryType := QueryType(200)
+	RegisterQueryHandler(queryType, func(ctx sdk.Context, params []byte) ([]byte, sdk.Error) {
+		if len(params) == 0 {
+			return nil, ErrInvalidQuery(DefaultCodespace, "no params")
+		}
+		return append([]byte("result of "), params...), nil
+	})
+	defer delete(queryHandlers, queryType)
+	require.Panics(t, func() { RegisterQueryHandler(queryType, nil) })
+
+	payload, err := rlp.EncodeToBytes(&QuerySynPackage{QueryType: queryType, Params: []byte("query")})
+	require.NoError(t, err)
+	ack := executeQuery(t, ctx, payload)
+	require.Equal(t, uint32(0), ack.Code)
+	require.Equal(t, uint64(5), ack.Height)
+	require.Equal(t, []byte("result of query"), ack.Result)
+
+	// the errors are returned in the ack package
+	payload, err = rlp.EncodeToBytes(&QuerySynPackage{QueryType: queryType})
+	require.NoError(t, err)
+	ack = executeQuery(t, ctx, payload)
+	require.Equal(t, uint32(sdk.ToABCICode(DefaultCodespace, CodeInvalidQuery)), ack.Code)
+	require.Empty(t, ack.Result)
+
+	payload, err = rlp.EncodeToBytes(&QuerySynPackage{QueryType: queryType + 1})
+	require.NoError(t, err)
+	ack = executeQuery(t, ctx, payload)
+	require.Equal(t, uint32(sdk.ToABCICode(DefaultCodespace, CodeUnknownQueryType)), ack.Code)
+
+	ack = executeQuery(t, ctx, []byte("not rlp"))
+	require.Equal(t, uint32(sdk.ToABCICode(DefaultCodespace, CodeInvalidQuery)), ack.Code)
+}
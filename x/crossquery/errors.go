@@ -0,0 +1,26 @@
+package crossquery
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 32
+
+	CodeUnknownQueryType sdk.CodeType = 1
+	CodeInvalidQuery     sdk.CodeType = 2
+)
+
+func init() {
+	sdk.RegisterCodespace(DefaultCodespace, "crossquery", CodeUnknownQueryType, CodeInvalidQuery)
+}
+
+func ErrUnknownQueryType(codespace sdk.CodespaceType, queryType QueryType) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownQueryType, fmt.Sprintf("unknown query type %d", queryType))
+}
+
+func ErrInvalidQuery(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidQuery, msg)
+}
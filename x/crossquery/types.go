@@ -0,0 +1,44 @@
+package crossquery
+
+import (
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	ChannelName = "crossQuery"
+
+	ChannelID sdk.ChannelID = 17
+)
+
+// QueryType identifies the state queried by a query package
+type QueryType uint8
+
+const (
+	// QueryTypeDelegation queries the amount delegated by a smart chain address with cross stake
+	QueryTypeDelegation QueryType = 1
+)
+
+// QuerySynPackage is the query sent by a contract of the side chain, the encoding of
+// Params depends on the query type
+type QuerySynPackage struct {
+	QueryType QueryType
+	Params    []byte
+}
+
+// QueryAckPackage answers a QuerySynPackage with the result of the query, Code is the ABCI
+// code of the error if the query failed. Height is the height of the block the package is
+// delivered in, the result reflects the state of the chain when the block is executed.
+type QueryAckPackage struct {
+	Code   uint32
+	Height uint64
+	Result []byte
+}
+
+func DeserializeQuerySynPackage(serializedPackage []byte) (*QuerySynPackage, error) {
+	var pack QuerySynPackage
+	if err := rlp.DecodeBytes(serializedPackage, &pack); err != nil {
+		return nil, err
+	}
+	return &pack, nil
+}
@@ -55,6 +55,31 @@ func (k Keeper) SetFeePool(ctx sdk.Context, feePool types.FeePool) {
 	store.Set(FeePoolKey, b)
 }
 
+// send coins from the community pool to an account, e.g. for the gov vote incentives
+func (k Keeper) DistributeFromCommunityPool(ctx sdk.Context, amount sdk.Coins, receiver sdk.AccAddress) sdk.Error {
+	feePool := k.GetFeePool(ctx)
+	for _, coin := range amount {
+		if feePool.CommunityPool.AmountOf(coin.Denom).LT(sdk.NewDecFromInt(coin.Amount)) {
+			return types.ErrInsufficientCommunityPool(k.codespace)
+		}
+	}
+	feePool.CommunityPool = feePool.CommunityPool.Minus(types.NewDecCoins(amount))
+	k.SetFeePool(ctx, feePool)
+	_, _, err := k.bankKeeper.AddCoins(ctx, receiver, amount)
+	return err
+}
+
+// send coins from an account to the community pool, e.g. for the deposits of the vetoed gov proposals
+func (k Keeper) FundCommunityPool(ctx sdk.Context, amount sdk.Coins, sender sdk.AccAddress) sdk.Error {
+	if _, _, err := k.bankKeeper.SubtractCoins(ctx, sender, amount); err != nil {
+		return err
+	}
+	feePool := k.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Plus(types.NewDecCoins(amount))
+	k.SetFeePool(ctx, feePool)
+	return nil
+}
+
 //______________________________________________________________________
 
 // set the proposer public key for this block
@@ -11,8 +11,14 @@ const (
 	DefaultCodespace       sdk.CodespaceType = 6
 	CodeInvalidInput       CodeType          = 103
 	CodeNoDistributionInfo CodeType          = 104
+	CodeInsufficientPool   CodeType          = 105
 )
 
+func init() {
+	sdk.RegisterCodespace(DefaultCodespace, "distribution",
+		CodeInvalidInput, CodeNoDistributionInfo, CodeInsufficientPool)
+}
+
 func ErrNilDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidInput, "delegator address is nil")
 }
@@ -28,3 +34,6 @@ func ErrNoDelegationDistInfo(codespace sdk.CodespaceType) sdk.Error {
 func ErrNoValidatorDistInfo(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeNoDistributionInfo, "no validator distribution info")
 }
+func ErrInsufficientCommunityPool(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInsufficientPool, "community pool is short of the amount")
+}
@@ -0,0 +1,74 @@
+package gov
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// actions recorded in the audit log
+const (
+	AuditActionParamsChanged        = "params_changed"
+	AuditActionFeesChanged          = "fees_changed"
+	AuditActionChannelPermissionSet = "channel_permission_set"
+	AuditActionValidatorCreated     = "validator_created"
+	AuditActionValidatorRemoved     = "validator_removed"
+	AuditActionCircuitBreakerSet    = "circuit_breaker_set"
+	AuditActionGenericParamsChanged = "generic_params_changed"
+	AuditActionExecutionCancelled   = "execution_cancelled"
+	AuditActionBatchExecuted        = "batch_executed"
+	AuditActionForceUndelegated     = "force_undelegated"
+
+	AuditActionRegisteredTypeExecuted = "registered_type_executed"
+)
+
+// AuditRecord is a state change applied on behalf of a passed proposal. The records are
+// appended to the audit log with consecutive sequences starting from 0, and never removed.
+type AuditRecord struct {
+	Sequence     int64        `json:"sequence"`
+	Height       int64        `json:"height"`
+	Time         time.Time    `json:"time"`
+	ProposalID   int64        `json:"proposal_id"`
+	ProposalType ProposalKind `json:"proposal_type"`
+	SideChainId  string       `json:"side_chain_id"` // empty for the native chain
+	Action       string       `json:"action"`
+	Detail       string       `json:"detail"` // the change applied, as described in the proposal
+}
+
+// AppendAuditRecord records in the audit log that action has been applied on behalf of proposal.
+// The log is kept in the store of the native chain, for the proposals of all the chains.
+func (keeper Keeper) AppendAuditRecord(ctx sdk.Context, sideChainId string, proposal Proposal, action string) AuditRecord {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	var seq int64
+	if bz := store.Get(KeyNextAuditSequence); bz != nil {
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &seq)
+	}
+	record := AuditRecord{
+		Sequence:     seq,
+		Height:       ctx.BlockHeight(),
+		Time:         ctx.BlockHeader().Time,
+		ProposalID:   proposal.GetProposalID(),
+		ProposalType: proposal.GetProposalType(),
+		SideChainId:  sideChainId,
+		Action:       action,
+		Detail:       proposal.GetDescription(),
+	}
+	store.Set(KeyAuditRecord(seq), keeper.cdc.MustMarshalBinaryLengthPrefixed(record))
+	store.Set(KeyNextAuditSequence, keeper.cdc.MustMarshalBinaryLengthPrefixed(seq+1))
+	return record
+}
+
+// GetAuditRecords returns at most limit records of the audit log, from the sequence fromSeq
+func (keeper Keeper) GetAuditRecords(ctx sdk.Context, fromSeq int64, limit int) []AuditRecord {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	iterator := store.Iterator(KeyAuditRecord(fromSeq), sdk.PrefixEndBytes(KeyAuditLogSubspace))
+	defer iterator.Close()
+
+	records := make([]AuditRecord, 0)
+	for ; iterator.Valid() && len(records) < limit; iterator.Next() {
+		var record AuditRecord
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &record)
+		records = append(records, record)
+	}
+	return records
+}
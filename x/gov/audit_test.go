@@ -0,0 +1,50 @@
+package gov_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+func TestAuditLog(t *testing.T) {
+	mapp, _, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Height: 10})
+
+	proposal := keeper.NewTextProposal(ctx, "Test", `{"key":"value"}`, gov.ProposalTypeParameterChange, 1000*time.Second)
+	record := keeper.AppendAuditRecord(ctx, "", proposal, gov.AuditActionParamsChanged)
+	require.Equal(t, int64(0), record.Sequence)
+	require.Equal(t, int64(10), record.Height)
+	require.Equal(t, `{"key":"value"}`, record.Detail)
+
+	// the records of the side chains are appended to the same log
+	scCtx := ctx.WithSideChainKeyPrefix([]byte{0x01}).WithSideChainId("bsc")
+	for i := 1; i < 5; i++ {
+		record = keeper.AppendAuditRecord(scCtx, "bsc", proposal, gov.AuditActionChannelPermissionSet)
+		require.Equal(t, int64(i), record.Sequence)
+	}
+
+	records := keeper.GetAuditRecords(ctx, 0, 10)
+	require.Len(t, records, 5)
+	require.Equal(t, "", records[0].SideChainId)
+	require.Equal(t, "bsc", records[4].SideChainId)
+	require.Len(t, keeper.GetAuditRecords(ctx, 3, 10), 2)
+	require.Len(t, keeper.GetAuditRecords(ctx, 1, 2), 2)
+	require.Empty(t, keeper.GetAuditRecords(ctx, 5, 10))
+
+	cdc := codec.New()
+	querier := gov.NewQuerier(keeper)
+	bz, err := cdc.MarshalJSON(gov.QueryAuditLogParams{FromSequence: 2, Limit: 2})
+	require.NoError(t, err)
+	res, sdkErr := querier(ctx, []string{gov.QueryAuditLog}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var queried []gov.AuditRecord
+	require.NoError(t, cdc.UnmarshalJSON(res, &queried))
+	require.Equal(t, records[2:4], queried)
+}
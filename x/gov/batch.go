@@ -0,0 +1,133 @@
+package gov
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// maximum number of sub-proposals bundled by a batch proposal
+const MaxBatchProposals = 16
+
+//-----------------------------------------------------------
+// Batch
+
+// BatchProposalContent is a sub-proposal of a batch proposal, its description is that of a proposal of its type
+type BatchProposalContent struct {
+	ProposalType ProposalKind `json:"proposal_type"`
+	Description  string       `json:"description"`
+}
+
+// BatchSetting is the description of a batch proposal. Its sub-proposals are executed in order once the batch
+// passes, either all of them or none of them. Only the sub-proposals executed by gov itself, i.e. circuit breaker
+// and generic parameter change ones, can be bundled.
+type BatchSetting struct {
+	Proposals []BatchProposalContent `json:"proposals"`
+}
+
+func (setting BatchSetting) Check() error {
+	if len(setting.Proposals) == 0 || len(setting.Proposals) > MaxBatchProposals {
+		return fmt.Errorf("a batch setting has between 1 and %d proposals", MaxBatchProposals)
+	}
+	for i, content := range setting.Proposals {
+		if !validBatchProposalType(content.ProposalType) {
+			return fmt.Errorf("%s proposals can not be bundled in a batch", content.ProposalType)
+		}
+		var err sdk.Error
+		switch content.ProposalType {
+		case ProposalTypeCircuitBreaker:
+			_, err = parseCircuitBreakerSetting(DefaultCodespace, content.Description)
+		case ProposalTypeGenericParamChange:
+			_, err = parseParamChangeSetting(DefaultCodespace, content.Description)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid proposal %d of the batch: %s", i, err.RawError())
+		}
+	}
+	return nil
+}
+
+// validBatchProposalType tells whether the proposals of a type can be bundled in a batch proposal
+func validBatchProposalType(pt ProposalKind) bool {
+	return pt == ProposalTypeCircuitBreaker || pt == ProposalTypeGenericParamChange
+}
+
+// parseBatchSetting parses the description of a batch proposal
+func parseBatchSetting(codespace sdk.CodespaceType, description string) (BatchSetting, sdk.Error) {
+	var setting BatchSetting
+	if err := msgCdc.UnmarshalJSON([]byte(description), &setting); err != nil {
+		return setting, ErrInvalidDescription(codespace, fmt.Sprintf("the description of a batch proposal is not a batch setting: %v", err))
+	}
+	if err := setting.Check(); err != nil {
+		return setting, ErrInvalidDescription(codespace, err.Error())
+	}
+	return setting, nil
+}
+
+// checkBatch checks the parameter changes of the sub-proposals of setting against the params keeper
+func (keeper Keeper) checkBatch(ctx sdk.Context, setting BatchSetting) sdk.Error {
+	for _, content := range setting.Proposals {
+		if content.ProposalType != ProposalTypeGenericParamChange {
+			continue
+		}
+		paramChange, err := parseParamChangeSetting(keeper.codespace, content.Description)
+		if err != nil {
+			return err
+		}
+		if _, err := keeper.checkParamChanges(ctx, paramChange); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyBatchProposal applies a sub-proposal of a batch
+func (keeper Keeper) applyBatchProposal(ctx sdk.Context, content BatchProposalContent) sdk.Error {
+	switch content.ProposalType {
+	case ProposalTypeCircuitBreaker:
+		setting, err := parseCircuitBreakerSetting(keeper.codespace, content.Description)
+		if err != nil {
+			return err
+		}
+		keeper.applyCircuitBreaker(ctx, setting)
+	case ProposalTypeGenericParamChange:
+		setting, err := parseParamChangeSetting(keeper.codespace, content.Description)
+		if err != nil {
+			return err
+		}
+		return keeper.applyParamChanges(ctx, setting)
+	default:
+		return ErrInvalidDescription(keeper.codespace, fmt.Sprintf("%s proposals can not be bundled in a batch", content.ProposalType))
+	}
+	return nil
+}
+
+// executeBatch executes the sub-proposals of a passed batch proposal in order on a cache of ctx, which is
+// written only if all of them succeed, so that a batch is never partially applied
+func executeBatch(ctx sdk.Context, keeper Keeper, proposal Proposal) {
+	logger := ctx.Logger().With("module", "x/gov")
+	setting, err := parseBatchSetting(keeper.codespace, proposal.GetDescription())
+	if err != nil {
+		logger.Error("Get broken batch setting, will skip.", "proposalId", proposal.GetProposalID(), "err", err)
+		return
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	for i, content := range setting.Proposals {
+		if err := keeper.applyBatchProposal(cacheCtx, content); err != nil {
+			logger.Error("Get invalid proposal in the batch, will skip the whole batch.",
+				"proposalId", proposal.GetProposalID(), "index", i, "err", err)
+			return
+		}
+	}
+	write()
+	proposal.SetStatus(StatusExecuted)
+	keeper.AppendAuditRecord(ctx, "", proposal, AuditActionBatchExecuted)
+
+	types := make([]string, 0, len(setting.Proposals))
+	for _, content := range setting.Proposals {
+		types = append(types, content.ProposalType.String())
+	}
+	logger.Info(fmt.Sprintf("proposal %d executed the batch %s", proposal.GetProposalID(), strings.Join(types, ",")))
+}
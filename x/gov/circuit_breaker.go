@@ -0,0 +1,138 @@
+package gov
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// maximum number of msg routes halted or resumed by a circuit breaker proposal
+const MaxCircuitBreakerRoutes = 16
+
+//-----------------------------------------------------------
+// Circuit Breaker
+
+// CircuitBreakerSetting is the description of a circuit breaker proposal, it halts or resumes the msgs of some
+// routes once the proposal passes. The msgs of the gov route can not be halted, so that they can be resumed.
+type CircuitBreakerSetting struct {
+	Routes []string `json:"routes"` // msg routes to halt or resume
+	Halt   bool     `json:"halt"`   // whether the msgs of the routes are halted or resumed
+}
+
+func (setting CircuitBreakerSetting) Check() error {
+	if len(setting.Routes) == 0 || len(setting.Routes) > MaxCircuitBreakerRoutes {
+		return fmt.Errorf("a circuit breaker setting has between 1 and %d routes", MaxCircuitBreakerRoutes)
+	}
+	seen := make(map[string]bool, len(setting.Routes))
+	for _, route := range setting.Routes {
+		if !validRoute(route) {
+			return fmt.Errorf("invalid msg route %q", route)
+		}
+		if route == MsgRoute {
+			return fmt.Errorf("the msgs of route %s can not be halted", MsgRoute)
+		}
+		if seen[route] {
+			return fmt.Errorf("duplicate msg route %s", route)
+		}
+		seen[route] = true
+	}
+	return nil
+}
+
+func validRoute(route string) bool {
+	if len(route) == 0 {
+		return false
+	}
+	for _, c := range route {
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// parseCircuitBreakerSetting parses the description of a circuit breaker proposal
+func parseCircuitBreakerSetting(codespace sdk.CodespaceType, description string) (CircuitBreakerSetting, sdk.Error) {
+	var setting CircuitBreakerSetting
+	if err := msgCdc.UnmarshalJSON([]byte(description), &setting); err != nil {
+		return setting, ErrInvalidDescription(codespace, fmt.Sprintf("the description of a circuit breaker proposal is not a circuit breaker setting: %v", err))
+	}
+	if err := setting.Check(); err != nil {
+		return setting, ErrInvalidDescription(codespace, err.Error())
+	}
+	return setting, nil
+}
+
+// IsRouteHalted tells whether the msgs of route are halted by the circuit breaker
+func (keeper Keeper) IsRouteHalted(ctx sdk.Context, route string) bool {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	return store.Has(KeyHaltedRoute(route))
+}
+
+// GetHaltedRoutes returns the msg routes halted by the circuit breaker, in lexicographic order
+func (keeper Keeper) GetHaltedRoutes(ctx sdk.Context) []string {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, KeyHaltedRoutesSubspace)
+	defer iterator.Close()
+
+	routes := make([]string, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		routes = append(routes, string(iterator.Key()[len(KeyHaltedRoutesSubspace):]))
+	}
+	return routes
+}
+
+// SetRouteHalted halts or resumes the msgs of route
+func (keeper Keeper) SetRouteHalted(ctx sdk.Context, route string, halted bool) {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	if halted {
+		store.Set(KeyHaltedRoute(route), []byte{1})
+	} else {
+		store.Delete(KeyHaltedRoute(route))
+	}
+}
+
+// NewCircuitBreakerHandler wraps the handler of the msgs of route, so that they fail while the route is halted
+// by a circuit breaker proposal, e.g.
+//
+//	app.Router().AddRoute("bank", gov.NewCircuitBreakerHandler(app.govKeeper, "bank", bank.NewHandler(app.CoinKeeper)))
+func NewCircuitBreakerHandler(keeper Keeper, route string, handler sdk.Handler) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		if keeper.IsRouteHalted(ctx, route) {
+			return ErrRouteHalted(keeper.codespace, route).Result()
+		}
+		return handler(ctx, msg)
+	}
+}
+
+// applyCircuitBreaker halts or resumes the routes of setting
+func (keeper Keeper) applyCircuitBreaker(ctx sdk.Context, setting CircuitBreakerSetting) {
+	for _, route := range setting.Routes {
+		keeper.SetRouteHalted(ctx, route, setting.Halt)
+	}
+}
+
+// executeCircuitBreaker halts or resumes the routes of a passed circuit breaker proposal
+func executeCircuitBreaker(ctx sdk.Context, keeper Keeper, proposal Proposal) {
+	logger := ctx.Logger().With("module", "x/gov")
+	setting, err := parseCircuitBreakerSetting(keeper.codespace, proposal.GetDescription())
+	if err != nil {
+		logger.Error("Get broken circuit breaker setting, will skip.", "proposalId", proposal.GetProposalID(), "err", err)
+		return
+	}
+	keeper.applyCircuitBreaker(ctx, setting)
+	proposal.SetStatus(StatusExecuted)
+	keeper.AppendAuditRecord(ctx, "", proposal, AuditActionCircuitBreakerSet)
+	logger.Info(fmt.Sprintf("proposal %d set the circuit breaker, halted: %v, routes: %s",
+		proposal.GetProposalID(), setting.Halt, strings.Join(setting.Routes, ",")))
+}
+
+//-----------------------------------------------------------
+// Emergency Proposals
+
+// validEmergencyProposalType tells whether the proposals of a type can be emergency proposals, they can
+// only pause cross chain channels, halt msg routes or cancel the execution of a passed proposal
+func validEmergencyProposalType(pt ProposalKind) bool {
+	return pt == ProposalTypeManageChanPermission || pt == ProposalTypeCircuitBreaker || pt == ProposalTypeCancelExecution
+}
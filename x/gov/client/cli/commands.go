@@ -24,6 +24,8 @@ func AddCommands(cmd *cobra.Command, cdc *codec.Codec) {
 			GetCmdSubmitListProposal(cdc),
 			GetCmdSubmitDelistProposal(cdc),
 			GetCmdVote(cdc),
+			GetCmdVoteWeighted(cdc),
+			GetCmdVoteChoice(cdc),
 		)...,
 	)
 
@@ -33,10 +35,17 @@ func AddCommands(cmd *cobra.Command, cdc *codec.Codec) {
 		client.GetCommands(
 			GetCmdQueryProposal(storeGov, cdc),
 			GetCmdQueryProposals(storeGov, cdc),
+			GetCmdQueryIndexedProposals(storeGov, cdc),
 			GetCmdQueryDeposit(storeGov, cdc),
 			GetCmdQueryDeposits(storeGov, cdc),
+			GetCmdQuerySettledDeposits(storeGov, cdc),
+			GetCmdQueryArchivedProposals(storeGov, cdc),
+			GetCmdQuerySimulateTally(storeGov, cdc),
 			GetCmdQueryVote(storeGov, cdc),
 			GetCmdQueryVotes(storeGov, cdc),
+			GetCmdQueryVoterHistory(storeGov, cdc),
+			GetCmdQueryAuditLog(storeGov, cdc),
+			GetCmdQueryInvariants(storeGov, cdc),
 		)...,
 	)
 	cmd.AddCommand(govCmd)
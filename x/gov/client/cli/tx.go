@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -32,6 +33,7 @@ const (
 	flagDeposit           = "deposit"
 	flagVoter             = "voter"
 	flagOption            = "option"
+	flagOptions           = "options"
 	flagDepositer         = "depositer"
 	flagStatus            = "status"
 	flagLatestProposalIDs = "latest"
@@ -41,6 +43,19 @@ const (
 	flagInitPrice         = "init-price"
 	flagExpireTime        = "expire-time"
 	flagSideChainId       = "side-chain-id"
+	flagFromSequence      = "from-sequence"
+	flagLimit             = "limit"
+	flagPage              = "page"
+	flagNextKey           = "next-key"
+	flagFollow            = "follow"
+	flagPollInterval      = "poll-interval"
+	flagExpedited         = "expedited"
+	flagEmergency         = "emergency"
+	flagChoices           = "choices"
+	flagChoice            = "choice"
+	flagMetadata          = "metadata"
+	flagQuorum            = "quorum"
+	flagThreshold         = "threshold"
 )
 
 type proposal struct {
@@ -50,6 +65,11 @@ type proposal struct {
 	Type         string `json:"type"`
 	Deposit      string `json:"deposit"`
 	SideChainId  string `json:"side_chain_id, omitempty"`
+	Expedited    bool   `json:"expedited,omitempty"`
+	Emergency    bool   `json:"emergency,omitempty"`
+	Metadata     string `json:"metadata,omitempty"`
+
+	Choices []string `json:"choices,omitempty"`
 }
 
 var proposalFlags = []string{
@@ -77,12 +97,25 @@ where proposal.json contains:
   "description": "My awesome proposal",
   "voting_period": 1000,
   "type": "Text",
-  "deposit": "1000:test"
+  "deposit": "1000:test",
+  "expedited": false
 }
 
 is equivalent to
 
 $ CLI gov submit-proposal --title="Test Proposal" --description="My awesome proposal" --type="Text" --deposit="1000:test" --voting-period=1000
+
+A multiple choice proposal is given its choices, which are voted with vote-choice:
+
+$ CLI gov submit-proposal --title="Fee schedule" --description="Pick a fee schedule" --type="multiple_choice" --choices="low,medium,high" --deposit="1000:test"
+
+An emergency proposal pauses cross chain channels or halts msg routes, it is voted by the validators with the emergency params:
+
+$ CLI gov submit-proposal --title="Halt transfers" --description='{"routes":["bank"],"halt":true}' --type="circuit_breaker" --emergency --deposit="1000:test"
+
+A generic parameter change proposal sets any parameter registered in the params keeper to its JSON encoded value:
+
+$ CLI gov submit-proposal --title="Raise quorum" --description='{"changes":[{"subspace":"gov","key":"tallyparams","value":"{\"quorum\":\"0.5\",\"threshold\":\"0.5\",\"veto\":\"0.334\"}"}]}' --type="generic_param_change" --deposit="1000:test"
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			proposal, err := parseSubmitProposalFlags()
@@ -137,9 +170,17 @@ $ CLI gov submit-proposal --title="Test Proposal" --description="My awesome prop
 			}
 			var msg sdk.Msg
 			if sideChainId == gov.NativeChainID {
-				msg = gov.NewMsgSubmitProposal(proposal.Title, proposal.Description, proposalType, fromAddr, amount, votingPeriod)
+				submitMsg := gov.NewMsgSubmitProposal(proposal.Title, proposal.Description, proposalType, fromAddr, amount, votingPeriod)
+				submitMsg.Expedited = proposal.Expedited
+				submitMsg.Choices = proposal.Choices
+				submitMsg.Emergency = proposal.Emergency
+				submitMsg.Metadata = proposal.Metadata
+				msg = submitMsg
 			} else {
-				msg = gov.NewMsgSideChainSubmitProposal(proposal.Title, proposal.Description, proposalType, fromAddr, amount, votingPeriod, sideChainId)
+				submitMsg := gov.NewMsgSideChainSubmitProposal(proposal.Title, proposal.Description, proposalType, fromAddr, amount, votingPeriod, sideChainId)
+				submitMsg.Expedited = proposal.Expedited
+				submitMsg.Metadata = proposal.Metadata
+				msg = submitMsg
 			}
 			err = msg.ValidateBasic()
 			if err != nil {
@@ -164,6 +205,10 @@ $ CLI gov submit-proposal --title="Test Proposal" --description="My awesome prop
 	cmd.Flags().String(flagDeposit, "", "deposit of proposal")
 	cmd.Flags().String(flagProposal, "", "proposal file path (if this path is given, other proposal flags are ignored)")
 	cmd.Flags().String(flagSideChainId, gov.NativeChainID, "the id of side chain, default is native chain")
+	cmd.Flags().Bool(flagExpedited, false, "vote the proposal faster with the expedited deposit, voting period and threshold, the voting period is used if it fails")
+	cmd.Flags().StringSlice(flagChoices, nil, "comma separated choices of a multiple choice proposal")
+	cmd.Flags().String(flagMetadata, "", "off-chain reference to the full proposal, e.g. an IPFS or URL reference and its checksum")
+	cmd.Flags().Bool(flagEmergency, false, "vote the proposal by the validators with the emergency deposit, voting period and threshold, only for manage_chan_permission and circuit_breaker proposals")
 	return cmd
 }
 
@@ -178,6 +223,10 @@ func parseSubmitProposalFlags() (*proposal, error) {
 		proposal.Type = client.NormalizeProposalType(viper.GetString(flagProposalType))
 		proposal.Deposit = viper.GetString(flagDeposit)
 		proposal.SideChainId = viper.GetString(flagSideChainId)
+		proposal.Expedited = viper.GetBool(flagExpedited)
+		proposal.Choices = viper.GetStringSlice(flagChoices)
+		proposal.Emergency = viper.GetBool(flagEmergency)
+		proposal.Metadata = viper.GetString(flagMetadata)
 		return proposal, nil
 	}
 
@@ -320,6 +369,115 @@ func GetCmdVote(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdVoteWeighted implements creating a new weighted vote command.
+func GetCmdVoteWeighted(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote-weighted",
+		Short: "Split the voting power among several options of an active proposal, e.g. yes=60000000,no=40000000",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			voterAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			proposalID := viper.GetInt64(flagProposalID)
+			options := viper.GetString(flagOptions)
+			sideChainId := viper.GetString(flagSideChainId)
+
+			if len(sideChainId) > types.MaxSideChainIdLength {
+				return fmt.Errorf("side-chain-id exceed the max length %d", types.MaxSideChainIdLength)
+			}
+
+			weightedOptions, err := gov.WeightedVoteOptionsFromString(client.NormalizeWeightedVoteOptions(options))
+			if err != nil {
+				return err
+			}
+			var msg sdk.Msg
+			if sideChainId == gov.NativeChainID {
+				msg = gov.NewMsgVoteWeighted(voterAddr, proposalID, weightedOptions)
+			} else {
+				msg = gov.NewMsgSideChainVoteWeighted(voterAddr, proposalID, weightedOptions, sideChainId)
+			}
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			if sideChainId == gov.NativeChainID {
+				fmt.Printf("VoteWeighted[Voter:%s,ProposalID:%d,Options:%s]",
+					voterAddr.String(), proposalID, weightedOptions,
+				)
+			} else {
+				fmt.Printf("VoteWeighted[Voter:%s,ProposalID:%d,Options:%s, sideChainId:%s]",
+					voterAddr.String(), proposalID, weightedOptions, sideChainId,
+				)
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of proposal voting on")
+	cmd.Flags().String(flagOptions, "", "weighted vote options {yes, no, no_with_veto, abstain} with weights of 8 decimals summing up to 100000000, e.g. yes=60000000,no=40000000")
+	cmd.Flags().String(flagSideChainId, gov.NativeChainID, "the id of side chain, default is native chain")
+
+	return cmd
+}
+
+// GetCmdVoteChoice implements the command voting for a choice of a multiple choice proposal.
+func GetCmdVoteChoice(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote-choice",
+		Short: "Vote for a choice of an active multiple choice proposal, choices are numbered from 1",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			voterAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			proposalID := viper.GetInt64(flagProposalID)
+			choice := viper.GetInt64(flagChoice)
+
+			msg := gov.NewMsgVoteChoice(voterAddr, proposalID, choice)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+			fmt.Printf("VoteChoice[Voter:%s,ProposalID:%d,Choice:%d]",
+				voterAddr.String(), proposalID, choice,
+			)
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.CompleteAndBroadcastTxCli(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of proposal voting on")
+	cmd.Flags().Int64(flagChoice, 0, "1-based index of the choice")
+
+	return cmd
+}
+
 // GetCmdQueryProposal implements the query proposal command.
 func GetCmdQueryProposal(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -368,9 +526,15 @@ func GetCmdQueryProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			latestProposalsIDs := viper.GetInt64(flagLatestProposalIDs)
 			sideChainId := viper.GetString(flagSideChainId)
 
+			pageParams, err := getPageParams()
+			if err != nil {
+				return err
+			}
+
 			params := gov.QueryProposalsParams{
 				BaseParams:         gov.NewBaseParams(sideChainId),
 				NumLatestProposals: latestProposalsIDs,
+				PageParams:         pageParams,
 			}
 
 			if len(bechDepositerAddr) != 0 {
@@ -409,20 +573,27 @@ func GetCmdQueryProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			var matchingProposals []gov.Proposal
-			err = cdc.UnmarshalJSON(res, &matchingProposals)
+			var page gov.PagedProposals
+			if pageParams.Paginated() {
+				err = cdc.UnmarshalJSON(res, &page)
+			} else {
+				err = cdc.UnmarshalJSON(res, &page.Proposals)
+			}
 			if err != nil {
 				return err
 			}
 
-			if len(matchingProposals) == 0 {
+			if len(page.Proposals) == 0 {
 				fmt.Println("No matching proposals found")
 				return nil
 			}
 
-			for _, proposal := range matchingProposals {
+			for _, proposal := range page.Proposals {
 				fmt.Printf("  %d - %s\n", proposal.GetProposalID(), proposal.GetTitle())
 			}
+			if len(page.NextKey) > 0 {
+				fmt.Printf("next key: %X\n", page.NextKey)
+			}
 
 			return nil
 		},
@@ -433,6 +604,7 @@ func GetCmdQueryProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagVoter, "", "(optional) filter by proposals voted on by voted")
 	cmd.Flags().String(flagStatus, "", "(optional) filter proposals by proposal status, status: deposit_period/voting_period/passed/rejected")
 	cmd.Flags().String(flagSideChainId, "", "the id of side chain, default is native chain")
+	addPageFlags(cmd)
 
 	return cmd
 }
@@ -480,6 +652,56 @@ func GetCmdQueryVote(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryVoterHistory implements the command to query for the votes of a voter on all the chains.
+func GetCmdQueryVoterHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-voter-history",
+		Short: "Query the votes of a voter on the proposals of the native chain and of the side chains",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			voterAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagVoter))
+			if err != nil {
+				return err
+			}
+			pageParams, err := getPageParams()
+			if err != nil {
+				return err
+			}
+
+			params := gov.QueryVoterHistoryParams{
+				Voter:      voterAddr,
+				PageParams: pageParams,
+			}
+			if strProposalStatus := viper.GetString(flagStatus); len(strProposalStatus) != 0 {
+				proposalStatus, err := gov.ProposalStatusFromString(client.NormalizeProposalStatus(strProposalStatus))
+				if err != nil {
+					return err
+				}
+				params.ProposalStatus = proposalStatus
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, gov.QueryVoterHistory), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagVoter, "", "bech32 voter address")
+	cmd.Flags().String(flagStatus, "", "(optional) filter the votes by proposal status, status: deposit_period/voting_period/passed/rejected")
+	addPageFlags(cmd)
+
+	return cmd
+}
+
 // GetCmdQueryVotes implements the command to query for proposal votes.
 func GetCmdQueryVotes(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -490,9 +712,15 @@ func GetCmdQueryVotes(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			proposalID := viper.GetInt64(flagProposalID)
 			sideChainId := viper.GetString(flagSideChainId)
 
+			pageParams, err := getPageParams()
+			if err != nil {
+				return err
+			}
+
 			params := gov.QueryVotesParams{
 				BaseParams: gov.NewBaseParams(sideChainId),
 				ProposalID: proposalID,
+				PageParams: pageParams,
 			}
 			bz, err := cdc.MarshalJSON(params)
 			if err != nil {
@@ -511,6 +739,43 @@ func GetCmdQueryVotes(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal's votes are being queried")
 	cmd.Flags().String(flagSideChainId, "", "the id of side chain, default is native chain")
+	addPageFlags(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryIndexedProposals implements the command to list the proposals of all the side chains at once.
+func GetCmdQueryIndexedProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-side-chain-proposals",
+		Short: "Query the id, status and tally of the proposals of all the side chains, or of one side chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			pageParams, err := getPageParams()
+			if err != nil {
+				return err
+			}
+			params := gov.QueryIndexedProposalsParams{
+				SideChainId: viper.GetString(flagSideChainId),
+				PageParams:  pageParams,
+			}
+
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, gov.QueryIndexedProposals), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "(optional) the id of the side chain, all the side chains by default")
+	addPageFlags(cmd)
 
 	return cmd
 }
@@ -567,9 +832,15 @@ func GetCmdQueryDeposits(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			proposalID := viper.GetInt64(flagProposalID)
 			sideChainId := viper.GetString(flagSideChainId)
 
+			pageParams, err := getPageParams()
+			if err != nil {
+				return err
+			}
+
 			params := gov.QueryDepositsParams{
 				BaseParams: gov.NewBaseParams(sideChainId),
 				ProposalID: proposalID,
+				PageParams: pageParams,
 			}
 			bz, err := cdc.MarshalJSON(params)
 			if err != nil {
@@ -588,6 +859,157 @@ func GetCmdQueryDeposits(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal's deposits are being queried")
 	cmd.Flags().String(flagSideChainId, "", "the id of side chain, default is native chain")
+	addPageFlags(cmd)
+
+	return cmd
+}
+
+// GetCmdQuerySettledDeposits implements the command to query for the refunded or distributed deposits of a settled proposal.
+func GetCmdQuerySettledDeposits(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-settled-deposits",
+		Short: "Query the deposits on a settled proposal, with whether they were refunded or distributed",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			proposalID := viper.GetInt64(flagProposalID)
+			sideChainId := viper.GetString(flagSideChainId)
+
+			pageParams, err := getPageParams()
+			if err != nil {
+				return err
+			}
+
+			params := gov.QuerySettledDepositsParams{
+				BaseParams: gov.NewBaseParams(sideChainId),
+				ProposalID: proposalID,
+				PageParams: pageParams,
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, gov.QuerySettledDeposits), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagProposalID, "", "proposalID of which proposal's settled deposits are being queried")
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain, default is native chain")
+	addPageFlags(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryArchivedProposals implements the command to query for the archived records of the pruned proposals.
+func GetCmdQueryArchivedProposals(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-archived-proposals",
+		Short: "Query the archived records of the finished proposals pruned after their retention period",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId := viper.GetString(flagSideChainId)
+
+			pageParams, err := getPageParams()
+			if err != nil {
+				return err
+			}
+
+			params := gov.QueryArchivedProposalsParams{
+				BaseParams: gov.NewBaseParams(sideChainId),
+				PageParams: pageParams,
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, gov.QueryArchivedProposals), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain, default is native chain")
+	addPageFlags(cmd)
+
+	return cmd
+}
+
+// GetCmdQueryInvariants implements the command to check the invariants of the gov module.
+func GetCmdQueryInvariants(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query-invariants",
+		Short: "Check the invariants of the deposits and the votes of the native chain and all the side chains",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, gov.QueryInvariants), nil)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQuerySimulateTally implements the command to simulate other tally params against the last tallied proposals.
+func GetCmdQuerySimulateTally(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate-tally",
+		Short: "Recompute the outcomes of the last tallied proposals with another quorum or threshold",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			params := gov.QueryTallySimulationParams{
+				BaseParams: gov.NewBaseParams(viper.GetString(flagSideChainId)),
+				NumLatest:  viper.GetInt(flagLatestProposalIDs),
+			}
+			if quorumStr := viper.GetString(flagQuorum); quorumStr != "" {
+				quorum, err := sdk.NewDecFromStr(quorumStr)
+				if err != nil {
+					return err
+				}
+				params.Quorum = &quorum
+			}
+			if thresholdStr := viper.GetString(flagThreshold); thresholdStr != "" {
+				threshold, err := sdk.NewDecFromStr(thresholdStr)
+				if err != nil {
+					return err
+				}
+				params.Threshold = &threshold
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, gov.QueryTallySimulation), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagQuorum, "", "quorum to simulate, e.g. 0.4, the current one if unset")
+	cmd.Flags().String(flagThreshold, "", "threshold to simulate, e.g. 0.6, the current one if unset")
+	cmd.Flags().Int(flagLatestProposalIDs, gov.MaxResultsPerPage, "number of the last tallied proposals to simulate")
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain, default is native chain")
 
 	return cmd
 }
@@ -596,7 +1018,7 @@ func GetCmdQueryDeposits(queryRoute string, cdc *codec.Codec) *cobra.Command {
 func GetCmdQueryTally(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "tally",
-		Short: "Get the tally of a proposal vote",
+		Short: "Get the tally of a proposal vote, at a past height of its voting period with --height",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			proposalID := viper.GetInt64(flagProposalID)
@@ -627,6 +1049,64 @@ func GetCmdQueryTally(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdQueryAuditLog implements the command to query, or follow, the audit log of the governance actions.
+func GetCmdQueryAuditLog(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit-log",
+		Short: "Get the state changes applied on behalf of passed proposals, one JSON record per line",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			params := gov.QueryAuditLogParams{
+				FromSequence: viper.GetInt64(flagFromSequence),
+				Limit:        viper.GetInt(flagLimit),
+			}
+			pageSize := params.Limit
+			if pageSize <= 0 || pageSize > gov.MaxAuditRecordsPerQuery {
+				pageSize = gov.MaxAuditRecordsPerQuery
+			}
+
+			for {
+				bz, err := cdc.MarshalJSON(params)
+				if err != nil {
+					return err
+				}
+				res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, gov.QueryAuditLog), bz)
+				if err != nil {
+					return err
+				}
+
+				var records []gov.AuditRecord
+				if err := cdc.UnmarshalJSON(res, &records); err != nil {
+					return err
+				}
+				for _, record := range records {
+					out, err := cdc.MarshalJSON(record)
+					if err != nil {
+						return err
+					}
+					fmt.Println(string(out))
+					params.FromSequence = record.Sequence + 1
+				}
+
+				if !viper.GetBool(flagFollow) {
+					return nil
+				}
+				// a full page may be followed by more records right away
+				if len(records) < pageSize {
+					time.Sleep(viper.GetDuration(flagPollInterval))
+				}
+			}
+		},
+	}
+
+	cmd.Flags().Int64(flagFromSequence, 0, "sequence of the first record")
+	cmd.Flags().Int(flagLimit, 0, fmt.Sprintf("maximum number of records per query, at most %d", gov.MaxAuditRecordsPerQuery))
+	cmd.Flags().Bool(flagFollow, false, "keep polling for the records appended to the log")
+	cmd.Flags().Duration(flagPollInterval, 5*time.Second, "interval between the polls when following the log")
+
+	return cmd
+}
+
 // GetCmdSubmitListProposal implements submitting a proposal transaction command.
 func GetCmdSubmitListProposal(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -824,3 +1304,22 @@ func GetCmdSubmitDelistProposal(cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// addPageFlags adds the flags paginating the results of a query
+func addPageFlags(cmd *cobra.Command) {
+	cmd.Flags().Int(flagPage, 0, "(optional) page of the results to get, starting from 1")
+	cmd.Flags().Int(flagLimit, 0, fmt.Sprintf("(optional) number of results per page, at most %d", gov.MaxResultsPerPage))
+	cmd.Flags().String(flagNextKey, "", "(optional) hex encoded key of the next page, as returned with the previous page")
+}
+
+func getPageParams() (gov.PageParams, error) {
+	nextKey, err := hex.DecodeString(viper.GetString(flagNextKey))
+	if err != nil {
+		return gov.PageParams{}, fmt.Errorf("invalid next key: %v", err)
+	}
+	return gov.PageParams{
+		Page:    viper.GetInt(flagPage),
+		Limit:   viper.GetInt(flagLimit),
+		NextKey: nextKey,
+	}, nil
+}
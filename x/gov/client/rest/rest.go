@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"time"
@@ -24,6 +25,11 @@ const (
 	RestVoter          = "voter"
 	RestProposalStatus = "status"
 	RestNumLatest      = "latest"
+	RestPage           = "page"
+	RestLimit          = "limit"
+	RestNextKey        = "next_key"
+	RestSideChainId    = "side_chain_id"
+	RestHeight         = "height"
 	storeName          = "gov"
 )
 
@@ -32,8 +38,12 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 	r.HandleFunc("/gov/proposals", postProposalHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits", RestProposalID), depositHandlerFn(cdc, cliCtx)).Methods("POST")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes", RestProposalID), voteHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/weighted_votes", RestProposalID), voteWeightedHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/choice_votes", RestProposalID), voteChoiceHandlerFn(cdc, cliCtx)).Methods("POST")
 
 	r.HandleFunc("/gov/proposals", queryProposalsWithParameterFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc("/gov/side_chain_proposals", queryIndexedProposalsHandlerFn(cdc, cliCtx)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/gov/voters/{%s}/votes", RestVoter), queryVoterHistoryHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}", RestProposalID), queryProposalHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits", RestProposalID), queryDepositsHandlerFn(cdc, cliCtx)).Methods("GET")
 	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits/{%s}", RestProposalID, RestDepositer), queryDepositHandlerFn(cdc, cliCtx)).Methods("GET")
@@ -49,6 +59,10 @@ type postProposalReq struct {
 	ProposalType   string         `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
 	Proposer       sdk.AccAddress `json:"proposer"`        //  Address of the proposer
 	InitialDeposit sdk.Coins      `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	Expedited      bool           `json:"expedited"`       // Whether the proposal is voted faster with the expedited params
+	Choices        []string       `json:"choices"`         // Choices of a multiple choice proposal
+	Emergency      bool           `json:"emergency"`       // Whether the proposal is voted by the validators with the emergency params
+	Metadata       string         `json:"metadata"`        // Off-chain reference to the full proposal, e.g. an IPFS or URL reference and its checksum
 }
 
 type depositReq struct {
@@ -63,6 +77,18 @@ type voteReq struct {
 	Option  string         `json:"option"` //  option from OptionSet chosen by the voter
 }
 
+type voteWeightedReq struct {
+	BaseReq utils.BaseReq  `json:"base_req"`
+	Voter   sdk.AccAddress `json:"voter"`   //  address of the voter
+	Options string         `json:"options"` //  weighted options chosen by the voter, e.g. yes=60000000,no=40000000
+}
+
+type voteChoiceReq struct {
+	BaseReq utils.BaseReq  `json:"base_req"`
+	Voter   sdk.AccAddress `json:"voter"`  //  address of the voter
+	Choice  int64          `json:"choice"` //  1-based index of the choice of the multiple choice proposal
+}
+
 func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req postProposalReq
@@ -96,6 +122,10 @@ func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, proposalType, req.Proposer, req.InitialDeposit, votingPeriod)
+		msg.Expedited = req.Expedited
+		msg.Choices = req.Choices
+		msg.Emergency = req.Emergency
+		msg.Metadata = req.Metadata
 		err = msg.ValidateBasic()
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -190,6 +220,90 @@ func voteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc
 	}
 }
 
+func voteWeightedHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strProposalID := vars[RestProposalID]
+
+		if len(strProposalID) == 0 {
+			err := errors.New("proposalId required but not specified")
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		proposalID, ok := utils.ParseInt64OrReturnBadRequest(w, strProposalID)
+		if !ok {
+			return
+		}
+
+		var req voteWeightedReq
+		err := utils.ReadRESTReq(w, r, cdc, &req)
+		if err != nil {
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		options, err := gov.WeightedVoteOptionsFromString(client.NormalizeWeightedVoteOptions(req.Options))
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// create the message
+		msg := gov.NewMsgVoteWeighted(req.Voter, proposalID, options)
+		err = msg.ValidateBasic()
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.CompleteAndBroadcastTxREST(w, r, cliCtx, baseReq, []sdk.Msg{msg}, cdc)
+	}
+}
+
+func voteChoiceHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		strProposalID := vars[RestProposalID]
+
+		if len(strProposalID) == 0 {
+			err := errors.New("proposalId required but not specified")
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		proposalID, ok := utils.ParseInt64OrReturnBadRequest(w, strProposalID)
+		if !ok {
+			return
+		}
+
+		var req voteChoiceReq
+		err := utils.ReadRESTReq(w, r, cdc, &req)
+		if err != nil {
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		// create the message
+		msg := gov.NewMsgVoteChoice(req.Voter, proposalID, req.Choice)
+		err = msg.ValidateBasic()
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.CompleteAndBroadcastTxREST(w, r, cliCtx, baseReq, []sdk.Msg{msg}, cdc)
+	}
+}
+
 func queryProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -236,8 +350,14 @@ func queryDepositsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Ha
 			return
 		}
 
+		pageParams, ok := parsePageParamsOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := gov.QueryDepositsParams{
 			ProposalID: proposalID,
+			PageParams: pageParams,
 		}
 
 		bz, err := cdc.MarshalJSON(params)
@@ -387,6 +507,76 @@ func queryVoteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Handle
 	}
 }
 
+// queryIndexedProposalsHandlerFn lists the proposals of all the side chains, or of the side chain given by side_chain_id
+func queryIndexedProposalsHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pageParams, ok := parsePageParamsOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+		params := gov.QueryIndexedProposalsParams{
+			SideChainId: r.URL.Query().Get(RestSideChainId),
+			PageParams:  pageParams,
+		}
+
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/gov/%s", gov.QueryIndexedProposals), bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// queryVoterHistoryHandlerFn lists the votes of a voter on the proposals of all the chains, optionally filtered by
+// proposal status
+func queryVoterHistoryHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		voterAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)[RestVoter])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		pageParams, ok := parsePageParamsOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+		params := gov.QueryVoterHistoryParams{
+			Voter:      voterAddr,
+			PageParams: pageParams,
+		}
+		if strProposalStatus := r.URL.Query().Get(RestProposalStatus); len(strProposalStatus) != 0 {
+			proposalStatus, err := gov.ProposalStatusFromString(client.NormalizeProposalStatus(strProposalStatus))
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			params.ProposalStatus = proposalStatus
+		}
+
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/gov/%s", gov.QueryVoterHistory), bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
 // todo: Split this functionality into helper functions to remove the above
 func queryVotesOnProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -404,8 +594,14 @@ func queryVotesOnProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext)
 			return
 		}
 
+		pageParams, ok := parsePageParamsOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
 		params := gov.QueryVotesParams{
 			ProposalID: proposalID,
+			PageParams: pageParams,
 		}
 		bz, err := cdc.MarshalJSON(params)
 		if err != nil {
@@ -431,7 +627,12 @@ func queryProposalsWithParameterFn(cdc *codec.Codec, cliCtx context.CLIContext)
 		strProposalStatus := r.URL.Query().Get(RestProposalStatus)
 		strNumLatest := r.URL.Query().Get(RestNumLatest)
 
-		params := gov.QueryProposalsParams{}
+		pageParams, ok := parsePageParamsOrReturnBadRequest(w, r)
+		if !ok {
+			return
+		}
+
+		params := gov.QueryProposalsParams{PageParams: pageParams}
 
 		if len(bechVoterAddr) != 0 {
 			voterAddr, err := sdk.AccAddressFromBech32(bechVoterAddr)
@@ -502,6 +703,15 @@ func queryTallyOnProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext)
 			return
 		}
 
+		// the tally is computed at a past height with the voting power of that height
+		if strHeight := r.URL.Query().Get(RestHeight); len(strHeight) != 0 {
+			height, ok := utils.ParseInt64OrReturnBadRequest(w, strHeight)
+			if !ok {
+				return
+			}
+			cliCtx.Height = height
+		}
+
 		params := gov.QueryTallyParams{
 			ProposalID: proposalID,
 		}
@@ -522,3 +732,30 @@ func queryTallyOnProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext)
 		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
 }
+
+// parsePageParamsOrReturnBadRequest reads the optional page, limit and hex encoded next_key
+// query parameters paginating the results of a query
+func parsePageParamsOrReturnBadRequest(w http.ResponseWriter, r *http.Request) (gov.PageParams, bool) {
+	var params gov.PageParams
+	if strPage := r.URL.Query().Get(RestPage); len(strPage) != 0 {
+		page, ok := utils.ParseInt64OrReturnBadRequest(w, strPage)
+		if !ok {
+			return params, false
+		}
+		params.Page = int(page)
+	}
+	if strLimit := r.URL.Query().Get(RestLimit); len(strLimit) != 0 {
+		limit, ok := utils.ParseInt64OrReturnBadRequest(w, strLimit)
+		if !ok {
+			return params, false
+		}
+		params.Limit = int(limit)
+	}
+	nextKey, err := hex.DecodeString(r.URL.Query().Get(RestNextKey))
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid next key: %v", err))
+		return params, false
+	}
+	params.NextKey = nextKey
+	return params, true
+}
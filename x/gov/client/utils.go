@@ -1,5 +1,7 @@
 package client
 
+import "strings"
+
 // NormalizeVoteOption - normalize user specified vote option
 func NormalizeVoteOption(option string) string {
 	switch option {
@@ -15,6 +17,20 @@ func NormalizeVoteOption(option string) string {
 	return ""
 }
 
+// NormalizeWeightedVoteOptions - normalize the options of user specified weighted vote options
+// of the form "yes=60000000,no=40000000"
+func NormalizeWeightedVoteOptions(options string) string {
+	pairs := strings.Split(options, ",")
+	for i, pair := range pairs {
+		fields := strings.Split(strings.TrimSpace(pair), "=")
+		if len(fields) == 2 {
+			fields[0] = NormalizeVoteOption(fields[0])
+		}
+		pairs[i] = strings.Join(fields, "=")
+	}
+	return strings.Join(pairs, ",")
+}
+
 //NormalizeProposalType - normalize user specified proposal type
 func NormalizeProposalType(proposalType string) string {
 	switch proposalType {
@@ -38,6 +54,18 @@ func NormalizeProposalType(proposalType string) string {
 		return "CSCParamsChange"
 	case "ManageChanPermission", "manage_chan_permission":
 		return "ManageChanPermission"
+	case "MultipleChoice", "multiple_choice":
+		return "MultipleChoice"
+	case "CircuitBreaker", "circuit_breaker":
+		return "CircuitBreaker"
+	case "GenericParamChange", "generic_param_change":
+		return "GenericParamChange"
+	case "CancelExecution", "cancel_execution":
+		return "CancelExecution"
+	case "Batch", "batch":
+		return "Batch"
+	case "ForceUndelegation", "force_undelegation":
+		return "ForceUndelegation"
 	}
 	return ""
 }
@@ -53,6 +81,10 @@ func NormalizeProposalStatus(status string) string {
 		return "Passed"
 	case "Rejected", "rejected":
 		return "Rejected"
+	case "PendingExecution", "pending_execution":
+		return "PendingExecution"
+	case "Cancelled", "cancelled":
+		return "Cancelled"
 	}
 	return ""
 }
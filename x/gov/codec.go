@@ -10,13 +10,20 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSubmitProposal{}, "cosmos-sdk/MsgSubmitProposal", nil)
 	cdc.RegisterConcrete(MsgDeposit{}, "cosmos-sdk/MsgDeposit", nil)
 	cdc.RegisterConcrete(MsgVote{}, "cosmos-sdk/MsgVote", nil)
+	cdc.RegisterConcrete(MsgVoteWeighted{}, "cosmos-sdk/MsgVoteWeighted", nil)
+	cdc.RegisterConcrete(MsgVoteChoice{}, "cosmos-sdk/MsgVoteChoice", nil)
 
 	cdc.RegisterConcrete(MsgSideChainSubmitProposal{}, "cosmos-sdk/MsgSideChainSubmitProposal", nil)
 	cdc.RegisterConcrete(MsgSideChainDeposit{}, "cosmos-sdk/MsgSideChainDeposit", nil)
 	cdc.RegisterConcrete(MsgSideChainVote{}, "cosmos-sdk/MsgSideChainVote", nil)
+	cdc.RegisterConcrete(MsgSideChainVoteWeighted{}, "cosmos-sdk/MsgSideChainVoteWeighted", nil)
 
 	cdc.RegisterInterface((*Proposal)(nil), nil)
 	cdc.RegisterConcrete(&TextProposal{}, "gov/TextProposal", nil)
+	cdc.RegisterConcrete(&MultipleChoiceProposal{}, "gov/MultipleChoiceProposal", nil)
+
+	cdc.RegisterConcrete(&SideChainParams{}, "params/GovParamSet", nil)
 }
 
-var msgCdc = codec.New()
+// sealed codec of the sign bytes and the proposal settings, the apps register their types on their own codec
+var msgCdc = codec.New().Seal()
@@ -3,6 +3,7 @@ package gov
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
@@ -13,11 +14,25 @@ type Vote struct {
 	Voter      sdk.AccAddress `json:"voter"`       //  address of the voter
 	ProposalID int64          `json:"proposal_id"` //  proposalID of the proposal
 	Option     VoteOption     `json:"option"`      //  option from OptionSet chosen by the voter
+
+	// weighted options of a split vote, Option is empty if they are set
+	Options WeightedVoteOptions `json:"options,omitempty"`
+	// 1-based choice of a vote on a multiple choice proposal, Option is empty if it is set
+	Choice int64 `json:"choice,omitempty"`
 }
 
 // Returns whether 2 votes are equal
 func (voteA Vote) Equals(voteB Vote) bool {
-	return voteA.Voter.Equals(voteB.Voter) && voteA.ProposalID == voteB.ProposalID && voteA.Option == voteB.Option
+	return voteA.Voter.Equals(voteB.Voter) && voteA.ProposalID == voteB.ProposalID && voteA.Option == voteB.Option &&
+		voteA.Options.Equals(voteB.Options) && voteA.Choice == voteB.Choice
+}
+
+// Returns the weighted options of the vote, a plain vote has its option with the full weight
+func (voteA Vote) WeightedOptions() WeightedVoteOptions {
+	if len(voteA.Options) != 0 {
+		return voteA.Options
+	}
+	return WeightedVoteOptions{NewWeightedVoteOption(voteA.Option, sdk.OneDec())}
 }
 
 // Returns whether a vote is empty
@@ -44,6 +59,109 @@ func (depositA Deposit) Empty() bool {
 	return depositA.Equals(depositB)
 }
 
+// outcomes of the deposits of a settled proposal
+const (
+	DepositOutcomeRefunded      = "refunded"
+	DepositOutcomeDistributed   = "distributed"
+	DepositOutcomeBurnt         = "burnt"          // deposits of a vetoed proposal, see VetoParams
+	DepositOutcomeCommunityPool = "community_pool" // deposits of a vetoed proposal, see VetoParams
+)
+
+// SettledDeposit is a deposit of a settled proposal with its outcome, kept once the deposit is refunded to
+// the depositer, distributed to the block proposer, burnt or sent to the community pool, so that the depositers can verify what became of it
+type SettledDeposit struct {
+	Deposit
+	Outcome       string `json:"outcome"`        //  One of the DepositOutcome constants
+	SettledHeight int64  `json:"settled_height"` //  Block height at which the deposit was settled
+}
+
+// WeightedVoteOption is an option of a split vote with the weight of the voting power it is given
+type WeightedVoteOption struct {
+	Option VoteOption `json:"option"`
+	Weight sdk.Dec    `json:"weight"`
+}
+
+func NewWeightedVoteOption(option VoteOption, weight sdk.Dec) WeightedVoteOption {
+	return WeightedVoteOption{Option: option, Weight: weight}
+}
+
+func (o WeightedVoteOption) String() string {
+	return fmt.Sprintf("%s=%s", o.Option, o.Weight)
+}
+
+// WeightedVoteOptions are the options of a split vote, their weights sum up to 1
+type WeightedVoteOptions []WeightedVoteOption
+
+// Returns whether 2 weighted options are equal
+func (options WeightedVoteOptions) Equals(other WeightedVoteOptions) bool {
+	if len(options) != len(other) {
+		return false
+	}
+	for i := range options {
+		if options[i].Option != other[i].Option || !options[i].Weight.Equal(other[i].Weight) {
+			return false
+		}
+	}
+	return true
+}
+
+func (options WeightedVoteOptions) String() string {
+	strs := make([]string, len(options))
+	for i, option := range options {
+		strs[i] = option.String()
+	}
+	return strings.Join(strs, ",")
+}
+
+// Validate checks that the options are valid and distinct, and that their weights are
+// positive and sum up to 1
+func (options WeightedVoteOptions) Validate(codespace sdk.CodespaceType) sdk.Error {
+	if len(options) == 0 {
+		return ErrInvalidWeightedVote(codespace, "no option")
+	}
+	seen := make(map[VoteOption]bool, len(options))
+	total := sdk.ZeroDec()
+	for _, option := range options {
+		if !validVoteOption(option.Option) {
+			return ErrInvalidVote(codespace, option.Option)
+		}
+		if seen[option.Option] {
+			return ErrInvalidWeightedVote(codespace, fmt.Sprintf("duplicated option %s", option.Option))
+		}
+		seen[option.Option] = true
+		if !option.Weight.GT(sdk.ZeroDec()) || option.Weight.GT(sdk.OneDec()) {
+			return ErrInvalidWeightedVote(codespace, fmt.Sprintf("weight of option %s should be in (0, %s]", option.Option, sdk.OneDec()))
+		}
+		total = total.Add(option.Weight)
+	}
+	if !total.Equal(sdk.OneDec()) {
+		return ErrInvalidWeightedVote(codespace, fmt.Sprintf("total weight %s should be %s", total, sdk.OneDec()))
+	}
+	return nil
+}
+
+// WeightedVoteOptionsFromString parses weighted options of the form "Yes=60000000,No=40000000",
+// the weights are decimals with a precision of 8 like the other decimals of the chain
+func WeightedVoteOptionsFromString(str string) (WeightedVoteOptions, error) {
+	var options WeightedVoteOptions
+	for _, pair := range strings.Split(str, ",") {
+		fields := strings.Split(strings.TrimSpace(pair), "=")
+		if len(fields) != 2 {
+			return nil, errors.Errorf("'%s' is not a valid weighted vote option, should be option=weight", pair)
+		}
+		option, err := VoteOptionFromString(fields[0])
+		if err != nil {
+			return nil, err
+		}
+		weight, sdkErr := sdk.NewDecFromStr(fields[1])
+		if sdkErr != nil {
+			return nil, errors.Errorf("'%s' is not a valid weight", fields[1])
+		}
+		options = append(options, NewWeightedVoteOption(option, weight))
+	}
+	return options, nil
+}
+
 // Type that represents VoteOption as a byte
 type VoteOption byte
 
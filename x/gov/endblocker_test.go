@@ -1,6 +1,7 @@
 package gov_test
 
 import (
+	"fmt"
 	"strconv"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/gov/events"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
 
@@ -68,6 +70,55 @@ func TestTickExpiredDepositPeriod(t *testing.T) {
 	require.False(t, gov.ShouldPopInactiveProposalQueue(ctx, keeper))
 }
 
+func TestTickExpiredDepositPeriodRefund(t *testing.T) {
+	mapp, ck, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 10)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+	stakeKeeper.SetValidator(ctx, validator)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+
+	require.False(t, keeper.GetExpiredDepositParams(ctx).Refund)
+	keeper.SetExpiredDepositParams(ctx, gov.ExpiredDepositParams{Refund: true})
+
+	govHandler := gov.NewHandler(keeper)
+	proposerCoins := ck.GetCoins(ctx, addrs[1])
+	depositerCoins := ck.GetCoins(ctx, addrs[2])
+
+	res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[1], sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1000e8)}, 1000))
+	require.True(t, res.IsOK())
+	proposalIDInt, _ := strconv.Atoi(string(res.Data))
+	proposalID := int64(proposalIDInt)
+	res = govHandler(ctx, gov.NewMsgDeposit(addrs[2], proposalID, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 10e8)}))
+	require.True(t, res.IsOK())
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetDepositParams(ctx).MaxDepositPeriod)
+	ctx = ctx.WithBlockHeader(newHeader).WithEventManager(sdk.NewEventManager())
+
+	refunded, notRefunded := gov.EndBlocker(ctx, keeper)
+	require.Equal(t, []gov.SimpleProposal{{proposalID, gov.NativeChainID}}, refunded)
+	require.Empty(t, notRefunded)
+	require.Nil(t, keeper.GetProposal(ctx, proposalID))
+
+	// the deposits are refunded to each depositer instead of being distributed to the block proposer
+	require.Equal(t, sdk.Coins(nil), ck.GetCoins(ctx, feeAccount[0]))
+	require.Equal(t, sdk.Coins(nil), ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
+	require.Equal(t, proposerCoins, ck.GetCoins(ctx, addrs[1]))
+	require.Equal(t, depositerCoins, ck.GetCoins(ctx, addrs[2]))
+
+	refundEvents := 0
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == events.EventTypeDepositRefunded {
+			refundEvents++
+		}
+	}
+	require.Equal(t, 2, refundEvents)
+}
+
 func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
 	mapp, ck, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 10)
 
@@ -253,6 +304,58 @@ func TestTickPassedVotingPeriodRejected(t *testing.T) {
 	require.Equal(t, sdk.Coins(nil), ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
 }
 
+func TestTickPassedVotingPeriodVetoed(t *testing.T) {
+	mapp, ck, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 10)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+	stakeKeeper.SetValidator(ctx, validator)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+	stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator, true)
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	govHandler := gov.NewHandler(keeper)
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+	votingPeriod := 1000 * time.Second
+
+	require.NotNil(t, keeper.SetVetoParams(ctx, gov.VetoParams{DepositAction: "unknown"}))
+	require.Equal(t, gov.VetoDepositDistribute, keeper.GetVetoParams(ctx).DepositAction)
+
+	for _, action := range []string{gov.VetoDepositBurn, gov.VetoDepositRefund} {
+		require.Nil(t, keeper.SetVetoParams(ctx, gov.VetoParams{DepositAction: action}))
+		proposerCoins := ck.GetCoins(ctx, addrs[1])
+
+		res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[1], deposit, votingPeriod))
+		require.True(t, res.IsOK(), "%v", res)
+		proposalIDInt, _ := strconv.Atoi(string(res.Data))
+		proposalID := int64(proposalIDInt)
+		res = govHandler(ctx, gov.NewMsgVote(addrs[0], proposalID, gov.OptionNoWithVeto))
+		require.True(t, res.IsOK(), "%v", res)
+
+		newHeader := ctx.BlockHeader()
+		newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+		ctx = ctx.WithBlockHeader(newHeader)
+		gov.EndBlocker(ctx, keeper)
+		require.Equal(t, gov.StatusRejected, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+		// the deposits are not distributed to the block proposer
+		require.Equal(t, sdk.Coins(nil), ck.GetCoins(ctx, feeAccount[0]))
+		require.Equal(t, sdk.Coins(nil), ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
+		settled, ok := keeper.GetSettledDeposit(ctx, proposalID, addrs[1])
+		require.True(t, ok)
+		if action == gov.VetoDepositBurn {
+			require.Equal(t, gov.DepositOutcomeBurnt, settled.Outcome)
+			require.Equal(t, proposerCoins.Minus(deposit), ck.GetCoins(ctx, addrs[1]))
+		} else {
+			require.Equal(t, gov.DepositOutcomeRefunded, settled.Outcome)
+			require.Equal(t, proposerCoins, ck.GetCoins(ctx, addrs[1]))
+		}
+	}
+}
+
 func TestTickPassedVotingPeriodPassed(t *testing.T) {
 	mapp, ck, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
 
@@ -482,3 +585,708 @@ func TestTickPassedVotingPeriodAllAbstain(t *testing.T) {
 	validatorCoins := ck.GetCoins(ctx, addrs[0])
 	require.Equal(t, validatorCoins, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 5000e8)})
 }
+
+func TestAppModuleEndBlockEvents(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 10)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+	stakeKeeper.SetValidator(ctx, validator)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+
+	newProposalMsg := gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[1], sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1000e8)}, 1000)
+	res := gov.NewHandler(keeper)(ctx, newProposalMsg)
+	require.True(t, res.IsOK())
+	proposalID, _ := strconv.Atoi(string(res.Data))
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetDepositParams(ctx).MaxDepositPeriod)
+	ctx = ctx.WithBlockHeader(newHeader).WithEventManager(sdk.NewEventManager())
+
+	gov.NewAppModule(&keeper).EndBlock(ctx, abci.RequestEndBlock{})
+	var found, foundDeposit bool
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == events.EventTypeDepositsDistributed {
+			found = true
+			require.Equal(t, events.ProposalID, string(event.Attributes[0].Key))
+		}
+		if event.Type == events.EventTypeDepositDistributed {
+			foundDeposit = true
+			require.Equal(t, events.Depositor, string(event.Attributes[1].Key))
+			require.Equal(t, addrs[1].String(), string(event.Attributes[1].Value))
+		}
+	}
+	require.True(t, found)
+	require.True(t, foundDeposit)
+
+	// the distributed deposit is kept once the proposal is deleted
+	settled, ok := keeper.GetSettledDeposit(ctx, int64(proposalID), addrs[1])
+	require.True(t, ok)
+	require.Equal(t, gov.DepositOutcomeDistributed, settled.Outcome)
+}
+
+type testCommunityPool struct {
+	balance int64
+	paid    map[string]sdk.Coins
+}
+
+func (p *testCommunityPool) DistributeFromCommunityPool(ctx sdk.Context, amount sdk.Coins, receiver sdk.AccAddress) sdk.Error {
+	if p.balance < amount.AmountOf(gov.DefaultDepositDenom) {
+		return sdk.ErrInsufficientCoins("community pool is short of the amount")
+	}
+	p.balance -= amount.AmountOf(gov.DefaultDepositDenom)
+	p.paid[receiver.String()] = p.paid[receiver.String()].Plus(amount)
+	return nil
+}
+
+func TestProposalEvents(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+	stakeKeeper.SetValidator(ctx, validator)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+	stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator, true)
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	attributes := func(event sdk.Event) map[string]string {
+		attrs := make(map[string]string)
+		for _, attr := range event.Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+		return attrs
+	}
+
+	govHandler := gov.NewHandler(keeper)
+	votingPeriod := 1000 * time.Second
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1000e8)}
+	res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[1], deposit, votingPeriod))
+	require.True(t, res.IsOK(), "%v", res)
+	proposalIDInt, _ := strconv.Atoi(string(res.Data))
+	proposalID := strconv.Itoa(proposalIDInt)
+	require.Len(t, res.Events, 1)
+	require.Equal(t, events.EventTypeProposalSubmitted, res.Events[0].Type)
+	require.Equal(t, map[string]string{
+		events.ProposalID:   proposalID,
+		events.ProposalType: "Text",
+		events.Proposer:     addrs[1].String(),
+		events.Amount:       deposit.String(),
+	}, attributes(res.Events[0]))
+
+	// the end of the voting period is told once the min deposit is reached
+	res = govHandler(ctx, gov.NewMsgDeposit(addrs[2], int64(proposalIDInt), deposit))
+	require.True(t, res.IsOK(), "%v", res)
+	require.Equal(t, events.EventTypeProposalDeposit, res.Events[0].Type)
+	votingPeriodEnd := ctx.BlockHeader().Time.Add(votingPeriod).UTC().Format(time.RFC3339)
+	require.Equal(t, map[string]string{
+		events.ProposalID:      proposalID,
+		events.ProposalType:    "Text",
+		events.VotingPeriodEnd: votingPeriodEnd,
+		events.Depositor:       addrs[2].String(),
+		events.Amount:          deposit.String(),
+	}, attributes(res.Events[0]))
+
+	res = govHandler(ctx, gov.NewMsgVote(addrs[0], int64(proposalIDInt), gov.OptionYes))
+	require.True(t, res.IsOK(), "%v", res)
+	require.Equal(t, events.EventTypeProposalVote, res.Events[0].Type)
+	require.Equal(t, gov.OptionYes.String(), attributes(res.Events[0])[events.Option])
+	require.Equal(t, addrs[0].String(), attributes(res.Events[0])[events.Voter])
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader).WithEventManager(sdk.NewEventManager())
+	gov.EndBlocker(ctx, keeper)
+	var found bool
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == events.EventTypeProposalPassed {
+			found = true
+			require.Equal(t, map[string]string{
+				events.ProposalID:      proposalID,
+				events.ProposalType:    "Text",
+				events.VotingPeriodEnd: votingPeriodEnd,
+				events.Outcome:         gov.StatusPassed.String(),
+			}, attributes(event))
+		}
+	}
+	require.True(t, found)
+}
+
+func TestTickPassedVotingPeriodVoteIncentive(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccounts := mock.GeneratePrivKeyAddressPairs(2)
+	reward := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 10e8)}
+	communityPool := &testCommunityPool{balance: 15e8, paid: make(map[string]sdk.Coins)}
+	keeper.SetCommunityPool(communityPool)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+	keeper.SetVoteIncentiveParams(ctx, gov.VoteIncentiveParams{Reward: reward})
+
+	// two validators, only the first one votes
+	for i := range feeAccounts {
+		validator := stake.NewValidatorWithFeeAddr(feeAccounts[i], sdk.ValAddress(addrs[i]), pubKeys[i], stake.Description{})
+		stakeKeeper.SetValidator(ctx, validator)
+		stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+		stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator, true)
+	}
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	govHandler := gov.NewHandler(keeper)
+	votingPeriod := 1000 * time.Second
+	submit := func() int64 {
+		res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}, votingPeriod))
+		require.True(t, res.IsOK(), "%v", res)
+		proposalID, _ := strconv.Atoi(string(res.Data))
+		res = govHandler(ctx, gov.NewMsgVote(addrs[0], int64(proposalID), gov.OptionNo))
+		require.True(t, res.IsOK(), "%v", res)
+		return int64(proposalID)
+	}
+	endVotingPeriod := func() {
+		newHeader := ctx.BlockHeader()
+		newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+		ctx = ctx.WithBlockHeader(newHeader).WithEventManager(sdk.NewEventManager())
+		gov.EndBlocker(ctx, keeper)
+	}
+
+	// the voter is rewarded although the proposal is rejected
+	proposalID := submit()
+	endVotingPeriod()
+	require.Equal(t, gov.StatusRejected, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Equal(t, map[string]sdk.Coins{feeAccounts[0].String(): reward}, communityPool.paid)
+	var found bool
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == events.EventTypeVoteIncentiveDistributed {
+			found = true
+			require.Equal(t, sdk.ValAddress(addrs[0]).String(), string(event.Attributes[1].Value))
+		}
+	}
+	require.True(t, found)
+
+	// nothing is paid once the pool is short of the reward
+	submit()
+	endVotingPeriod()
+	require.Equal(t, map[string]sdk.Coins{feeAccounts[0].String(): reward}, communityPool.paid)
+	require.Equal(t, int64(5e8), communityPool.balance)
+}
+
+func TestTickExpeditedProposalConverted(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccounts := mock.GeneratePrivKeyAddressPairs(2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+
+	for i := range feeAccounts {
+		validator := stake.NewValidatorWithFeeAddr(feeAccounts[i], sdk.ValAddress(addrs[i]), pubKeys[i], stake.Description{})
+		stakeKeeper.SetValidator(ctx, validator)
+		stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+		stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator, true)
+	}
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	govHandler := gov.NewHandler(keeper)
+	votingPeriod := 1000 * time.Second
+	msg := gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[0], sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}, votingPeriod)
+	msg.Expedited = true
+	res := govHandler(ctx, msg)
+	require.False(t, res.IsOK(), "expedited proposals are disabled without params")
+
+	expeditedPeriod := 100 * time.Second
+	keeper.SetExpeditedParams(ctx, gov.ExpeditedParams{
+		MinDeposit:   sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 3000e8)},
+		VotingPeriod: expeditedPeriod,
+		Quorum:       sdk.NewDecWithPrec(5, 1),
+		Threshold:    sdk.NewDecWithPrec(67, 2),
+	})
+	res = govHandler(ctx, msg)
+	require.True(t, res.IsOK(), "%v", res)
+	proposalIDInt, _ := strconv.Atoi(string(res.Data))
+	proposalID := int64(proposalIDInt)
+
+	// the expedited deposit is needed to start voting
+	proposal := keeper.GetProposal(ctx, proposalID)
+	require.True(t, proposal.GetExpedited())
+	require.Equal(t, gov.StatusDepositPeriod, proposal.GetStatus())
+	res = govHandler(ctx, gov.NewMsgDeposit(addrs[1], proposalID, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1000e8)}))
+	require.True(t, res.IsOK(), "%v", res)
+	require.Equal(t, gov.StatusVotingPeriod, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Equal(t, expeditedPeriod, keeper.GetProposal(ctx, proposalID).GetVotingPeriod())
+
+	// 0.6 of the voting power votes yes, short of the expedited threshold but above the regular one
+	res = govHandler(ctx, gov.NewMsgVote(addrs[0], proposalID, gov.OptionYes))
+	require.True(t, res.IsOK(), "%v", res)
+	res = govHandler(ctx, gov.NewMsgVoteWeighted(addrs[1], proposalID, gov.WeightedVoteOptions{
+		gov.NewWeightedVoteOption(gov.OptionYes, sdk.NewDecWithPrec(2, 1)),
+		gov.NewWeightedVoteOption(gov.OptionNo, sdk.NewDecWithPrec(8, 1)),
+	}))
+	require.True(t, res.IsOK(), "%v", res)
+
+	tick := func(d time.Duration) {
+		newHeader := ctx.BlockHeader()
+		newHeader.Time = ctx.BlockHeader().Time.Add(d)
+		ctx = ctx.WithBlockHeader(newHeader).WithEventManager(sdk.NewEventManager())
+		gov.EndBlocker(ctx, keeper)
+	}
+
+	// the expedited proposal is converted to a regular one instead of being rejected
+	tick(expeditedPeriod)
+	proposal = keeper.GetProposal(ctx, proposalID)
+	require.False(t, proposal.GetExpedited())
+	require.Equal(t, gov.StatusVotingPeriod, proposal.GetStatus())
+	require.Equal(t, votingPeriod, proposal.GetVotingPeriod())
+	require.Equal(t, events.EventTypeProposalConverted, ctx.EventManager().Events()[0].Type)
+	_, found := keeper.GetVote(ctx, proposalID, addrs[0])
+	require.True(t, found, "votes are kept for the regular vote")
+
+	// and passes with the regular threshold at the end of its voting period
+	tick(votingPeriod - expeditedPeriod)
+	require.Equal(t, gov.StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	_, found = keeper.GetVote(ctx, proposalID, addrs[0])
+	require.False(t, found)
+}
+
+func TestTickEmergencyCircuitBreaker(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccounts := mock.GeneratePrivKeyAddressPairs(2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+
+	for i := range feeAccounts {
+		validator := stake.NewValidatorWithFeeAddr(feeAccounts[i], sdk.ValAddress(addrs[i]), pubKeys[i], stake.Description{})
+		stakeKeeper.SetValidator(ctx, validator)
+		stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+		stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator, true)
+	}
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	govHandler := gov.NewHandler(keeper)
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+	msg := gov.NewMsgSubmitProposal("Halt", `{"routes":["bank"],"halt":true}`, gov.ProposalTypeCircuitBreaker, addrs[0], deposit, 1000*time.Second)
+	msg.Emergency = true
+	require.Nil(t, msg.ValidateBasic())
+	res := govHandler(ctx, msg)
+	require.False(t, res.IsOK(), "emergency proposals are disabled without params")
+
+	emergencyPeriod := 10 * time.Second
+	keeper.SetEmergencyParams(ctx, gov.EmergencyParams{
+		MinDeposit:   sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1000e8)},
+		VotingPeriod: emergencyPeriod,
+		Quorum:       sdk.NewDecWithPrec(5, 1),
+		Threshold:    sdk.NewDecWithPrec(67, 2),
+	})
+	res = govHandler(ctx, msg)
+	require.True(t, res.IsOK(), "%v", res)
+	proposalIDInt, _ := strconv.Atoi(string(res.Data))
+	proposalID := int64(proposalIDInt)
+	proposal := keeper.GetProposal(ctx, proposalID)
+	require.True(t, proposal.GetEmergency())
+	require.Equal(t, gov.StatusVotingPeriod, proposal.GetStatus())
+	require.Equal(t, emergencyPeriod, proposal.GetVotingPeriod())
+
+	// only the validators vote on the proposal
+	res = govHandler(ctx, gov.NewMsgVote(addrs[2], proposalID, gov.OptionYes))
+	require.False(t, res.IsOK())
+	for _, addr := range addrs[:2] {
+		res = govHandler(ctx, gov.NewMsgVote(addr, proposalID, gov.OptionYes))
+		require.True(t, res.IsOK(), "%v", res)
+	}
+
+	bankHandler := gov.NewCircuitBreakerHandler(keeper, "bank", func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		return sdk.Result{}
+	})
+	require.True(t, bankHandler(ctx, msg).IsOK())
+
+	// the routes are halted once the proposal passes
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(emergencyPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+	require.Equal(t, gov.StatusExecuted, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.True(t, keeper.IsRouteHalted(ctx, "bank"))
+	require.Equal(t, []string{"bank"}, keeper.GetHaltedRoutes(ctx))
+	res = bankHandler(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(gov.DefaultCodespace, gov.CodeRouteHalted), res.Code)
+
+	keeper.SetRouteHalted(ctx, "bank", false)
+	require.True(t, bankHandler(ctx, msg).IsOK())
+}
+
+func TestTickGenericParamChange(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+	stakeKeeper.SetValidator(ctx, validator)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+	stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator, true)
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	govHandler := gov.NewHandler(keeper)
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+	votingPeriod := 1000 * time.Second
+
+	// the changes are checked against the registered params when submitted
+	for _, description := range []string{
+		`{"changes":[{"subspace":"unknown","key":"tallyparams","value":"{}"}]}`,
+		`{"changes":[{"subspace":"testgov","key":"unknown","value":"{}"}]}`,
+		`{"changes":[{"subspace":"testgov","key":"tallyparams","value":"1"}]}`,
+		`{"changes":[{"subspace":"testgov","key":"vetoparams","value":"{\"deposit_action\":\"unknown\"}"}]}`,
+	} {
+		msg := gov.NewMsgSubmitProposal("Params", description, gov.ProposalTypeGenericParamChange, addrs[0], deposit, votingPeriod)
+		require.Nil(t, msg.ValidateBasic())
+		require.False(t, govHandler(ctx, msg).IsOK(), description)
+	}
+
+	description := `{"changes":[` +
+		`{"subspace":"testgov","key":"tallyparams","value":"{\"quorum\":\"0.4\",\"threshold\":\"0.6\",\"veto\":\"0.3\"}"},` +
+		`{"subspace":"testgov","key":"expeditedparams","value":"{\"min_deposit\":[],\"voting_period\":\"10000000000\",\"quorum\":\"0.5\",\"threshold\":\"0.67\"}"}]}`
+	msg := gov.NewMsgSubmitProposal("Params", description, gov.ProposalTypeGenericParamChange, addrs[0], deposit, votingPeriod)
+	require.Nil(t, msg.ValidateBasic())
+	res := govHandler(ctx, msg)
+	require.True(t, res.IsOK(), "%v", res)
+	proposalIDInt, _ := strconv.Atoi(string(res.Data))
+	proposalID := int64(proposalIDInt)
+	res = govHandler(ctx, gov.NewMsgVote(addrs[0], proposalID, gov.OptionYes))
+	require.True(t, res.IsOK(), "%v", res)
+
+	// all the params are changed once the proposal passes
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+	require.Equal(t, gov.StatusExecuted, keeper.GetProposal(ctx, proposalID).GetStatus())
+	tallyParams := keeper.GetTallyParams(ctx)
+	require.True(t, tallyParams.Quorum.Equal(sdk.NewDecWithPrec(4, 1)))
+	require.True(t, tallyParams.Threshold.Equal(sdk.NewDecWithPrec(6, 1)))
+	require.Equal(t, 10*time.Second, keeper.GetExpeditedParams(ctx).VotingPeriod)
+}
+
+func TestTickRegisteredProposalType(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+	stakeKeeper.SetValidator(ctx, validator)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+	stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator, true)
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	const kind = gov.ProposalKind(0x40)
+	var executed []string
+	gov.RegisterProposalTypeHandler(kind, "TestRegistered", false,
+		func(ctx sdk.Context, proposal gov.Proposal) sdk.Error {
+			if proposal.GetDescription() == "invalid" {
+				return gov.ErrInvalidProposal(gov.DefaultCodespace, "invalid description")
+			}
+			return nil
+		},
+		func(ctx sdk.Context, proposal gov.Proposal) sdk.Error {
+			executed = append(executed, proposal.GetDescription())
+			return nil
+		})
+	require.Panics(t, func() { gov.RegisterProposalTypeHandler(kind, "TestRegisteredAgain", false, nil, nil) })
+	require.Panics(t, func() { gov.RegisterProposalTypeHandler(gov.ProposalKind(0x41), "Text", false, nil, nil) })
+	require.Panics(t, func() { gov.RegisterProposalTypeHandler(gov.ProposalTypeBatch, "TestBatch", false, nil, nil) })
+
+	// the registered type is named like the built-in ones, and is not accepted for the side chains
+	pt, err := gov.ProposalTypeFromString("TestRegistered")
+	require.Nil(t, err)
+	require.Equal(t, kind, pt)
+	require.Equal(t, "TestRegistered", kind.String())
+	require.NotNil(t, gov.NewMsgSideChainSubmitProposal("Registered", "valid", kind, addrs[0],
+		sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}, time.Second, "bsc").ValidateBasic())
+
+	govHandler := gov.NewHandler(keeper)
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+	votingPeriod := 1000 * time.Second
+
+	msg := gov.NewMsgSubmitProposal("Registered", "invalid", kind, addrs[0], deposit, votingPeriod)
+	require.Nil(t, msg.ValidateBasic())
+	require.False(t, govHandler(ctx, msg).IsOK())
+
+	msg = gov.NewMsgSubmitProposal("Registered", "valid", kind, addrs[0], deposit, votingPeriod)
+	res := govHandler(ctx, msg)
+	require.True(t, res.IsOK(), "%v", res)
+	proposalIDInt, _ := strconv.Atoi(string(res.Data))
+	proposalID := int64(proposalIDInt)
+	res = govHandler(ctx, gov.NewMsgVote(addrs[0], proposalID, gov.OptionYes))
+	require.True(t, res.IsOK(), "%v", res)
+
+	// the executor of the type applies the passed proposal
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+	require.Equal(t, gov.StatusExecuted, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Equal(t, []string{"valid"}, executed)
+}
+
+func TestTickBatch(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccount := mock.GeneratePrivKeyAddressPairs(1)
+	validator := stake.NewValidatorWithFeeAddr(feeAccount[0], sdk.ValAddress(addrs[0]), pubKeys[0], stake.Description{})
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+	stakeKeeper.SetValidator(ctx, validator)
+	stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+	stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator, true)
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	govHandler := gov.NewHandler(keeper)
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+	votingPeriod := 1000 * time.Second
+
+	// the sub-proposals are checked when submitted
+	for _, description := range []string{
+		`{"proposals":[]}`,
+		`{"proposals":[{"proposal_type":"Text","description":"text"}]}`,
+		`{"proposals":[{"proposal_type":"CircuitBreaker","description":"{\"routes\":[],\"halt\":true}"}]}`,
+	} {
+		msg := gov.NewMsgSubmitProposal("Batch", description, gov.ProposalTypeBatch, addrs[0], deposit, votingPeriod)
+		require.NotNil(t, msg.ValidateBasic(), description)
+	}
+	description := `{"proposals":[` +
+		`{"proposal_type":"CircuitBreaker","description":"{\"routes\":[\"bank\"],\"halt\":true}"},` +
+		`{"proposal_type":"GenericParamChange","description":"{\"changes\":[{\"subspace\":\"unknown\",\"key\":\"tallyparams\",\"value\":\"{}\"}]}"}]}`
+	msg := gov.NewMsgSubmitProposal("Batch", description, gov.ProposalTypeBatch, addrs[0], deposit, votingPeriod)
+	require.Nil(t, msg.ValidateBasic())
+	require.False(t, govHandler(ctx, msg).IsOK(), "the parameter changes are checked against the registered params")
+
+	description = `{"proposals":[` +
+		`{"proposal_type":"CircuitBreaker","description":"{\"routes\":[\"bank\"],\"halt\":true}"},` +
+		`{"proposal_type":"GenericParamChange","description":"{\"changes\":[{\"subspace\":\"testgov\",\"key\":\"tallyparams\",\"value\":\"{\\\"quorum\\\":\\\"0.4\\\",\\\"threshold\\\":\\\"0.6\\\",\\\"veto\\\":\\\"0.3\\\"}\"}]}"}]}`
+	msg = gov.NewMsgSubmitProposal("Batch", description, gov.ProposalTypeBatch, addrs[0], deposit, votingPeriod)
+	require.Nil(t, msg.ValidateBasic())
+	res := govHandler(ctx, msg)
+	require.True(t, res.IsOK(), "%v", res)
+	proposalID, _ := strconv.ParseInt(string(res.Data), 10, 64)
+	res = govHandler(ctx, gov.NewMsgVote(addrs[0], proposalID, gov.OptionYes))
+	require.True(t, res.IsOK(), "%v", res)
+
+	// all the sub-proposals are executed once the batch passes
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+	require.Equal(t, gov.StatusExecuted, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.True(t, keeper.IsRouteHalted(ctx, "bank"))
+	tallyParams := keeper.GetTallyParams(ctx)
+	require.True(t, tallyParams.Quorum.Equal(sdk.NewDecWithPrec(4, 1)))
+	require.True(t, tallyParams.Threshold.Equal(sdk.NewDecWithPrec(6, 1)))
+}
+
+func TestTickExecutionDelay(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccounts := mock.GeneratePrivKeyAddressPairs(2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+
+	for i := range feeAccounts {
+		validator := stake.NewValidatorWithFeeAddr(feeAccounts[i], sdk.ValAddress(addrs[i]), pubKeys[i], stake.Description{})
+		stakeKeeper.SetValidator(ctx, validator)
+		stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+		stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator, true)
+	}
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	delay := 100 * time.Second
+	err := keeper.SetExecutionDelayParams(ctx, gov.ExecutionDelayParams{Delays: []gov.ProposalTypeDelay{
+		{ProposalType: gov.ProposalTypeCancelExecution, Delay: delay},
+	}})
+	require.NotNil(t, err, "the cancel execution proposals can not be delayed")
+	err = keeper.SetExecutionDelayParams(ctx, gov.ExecutionDelayParams{Delays: []gov.ProposalTypeDelay{
+		{ProposalType: gov.ProposalTypeText, Delay: gov.MaxExecutionDelay + time.Second},
+	}})
+	require.NotNil(t, err)
+	err = keeper.SetExecutionDelayParams(ctx, gov.ExecutionDelayParams{Delays: []gov.ProposalTypeDelay{
+		{ProposalType: gov.ProposalTypeText, Delay: delay},
+	}})
+	require.Nil(t, err)
+
+	govHandler := gov.NewHandler(keeper)
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+	votingPeriod := 1000 * time.Second
+	submitAndVote := func(ctx sdk.Context, msg gov.MsgSubmitProposal) int64 {
+		require.Nil(t, msg.ValidateBasic())
+		res := govHandler(ctx, msg)
+		require.True(t, res.IsOK(), "%v", res)
+		proposalID, _ := strconv.ParseInt(string(res.Data), 10, 64)
+		for _, addr := range addrs[:2] {
+			res = govHandler(ctx, gov.NewMsgVote(addr, proposalID, gov.OptionYes))
+			require.True(t, res.IsOK(), "%v", res)
+		}
+		return proposalID
+	}
+	executedID := submitAndVote(ctx, gov.NewMsgSubmitProposal("Executed", "executed", gov.ProposalTypeText, addrs[0], deposit, votingPeriod))
+	cancelledID := submitAndVote(ctx, gov.NewMsgSubmitProposal("Cancelled", "cancelled", gov.ProposalTypeText, addrs[0], deposit, votingPeriod))
+
+	// the passed proposals wait for the execution delay
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+	for _, proposalID := range []int64{executedID, cancelledID} {
+		require.Equal(t, gov.StatusPendingExecution, keeper.GetProposal(ctx, proposalID).GetStatus())
+		executionTime, ok := keeper.GetExecutionTime(ctx, proposalID)
+		require.True(t, ok)
+		require.Equal(t, newHeader.Time.Add(delay), executionTime)
+	}
+
+	// a cancel execution proposal must be an emergency proposal
+	description := fmt.Sprintf(`{"proposal_id":%d}`, cancelledID)
+	msg := gov.NewMsgSubmitProposal("Cancel", description, gov.ProposalTypeCancelExecution, addrs[0], deposit, votingPeriod)
+	require.NotNil(t, msg.ValidateBasic())
+	msg.Emergency = true
+	emergencyPeriod := 10 * time.Second
+	keeper.SetEmergencyParams(ctx, gov.EmergencyParams{
+		MinDeposit:   sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1000e8)},
+		VotingPeriod: emergencyPeriod,
+		Quorum:       sdk.NewDecWithPrec(5, 1),
+		Threshold:    sdk.NewDecWithPrec(67, 2),
+	})
+	cancelID := submitAndVote(ctx, msg)
+
+	// the emergency proposal is executed at once and cancels the execution of its target
+	newHeader.Time = newHeader.Time.Add(emergencyPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+	require.Equal(t, gov.StatusExecuted, keeper.GetProposal(ctx, cancelID).GetStatus())
+	require.Equal(t, gov.StatusCancelled, keeper.GetProposal(ctx, cancelledID).GetStatus())
+	_, ok := keeper.GetExecutionTime(ctx, cancelledID)
+	require.False(t, ok)
+	require.Equal(t, gov.StatusPendingExecution, keeper.GetProposal(ctx, executedID).GetStatus())
+
+	// the other proposal passes once the delay is over
+	newHeader.Time = newHeader.Time.Add(delay)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+	require.Equal(t, gov.StatusPassed, keeper.GetProposal(ctx, executedID).GetStatus())
+	require.Equal(t, gov.StatusCancelled, keeper.GetProposal(ctx, cancelledID).GetStatus())
+	_, ok = keeper.GetExecutionTime(ctx, executedID)
+	require.False(t, ok)
+}
+
+func TestTickPruneProposals(t *testing.T) {
+	mapp, _, keeper, stakeKeeper, addrs, pubKeys, _ := getMockApp(t, 3)
+
+	_, feeAccounts := mock.GeneratePrivKeyAddressPairs(2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{ProposerAddress: pubKeys[0].Address()})
+
+	for i := range feeAccounts {
+		validator := stake.NewValidatorWithFeeAddr(feeAccounts[i], sdk.ValAddress(addrs[i]), pubKeys[i], stake.Description{})
+		stakeKeeper.SetValidator(ctx, validator)
+		stakeKeeper.SetValidatorByConsAddr(ctx, validator)
+		stakeKeeper.Delegate(ctx, sdk.AccAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 1000), validator, true)
+	}
+	stakeKeeper.ApplyAndReturnValidatorSetUpdates(ctx)
+
+	err := keeper.SetPruningParams(ctx, gov.PruningParams{RetentionPeriod: gov.MinRetentionPeriod - time.Second})
+	require.NotNil(t, err)
+	retention := gov.MinRetentionPeriod
+	err = keeper.SetPruningParams(ctx, gov.PruningParams{RetentionPeriod: retention})
+	require.Nil(t, err)
+
+	govHandler := gov.NewHandler(keeper)
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 2000e8)}
+	votingPeriod := 1000 * time.Second
+	submitAndVote := func(ctx sdk.Context, votingPeriod time.Duration) int64 {
+		res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[0], deposit, votingPeriod))
+		require.True(t, res.IsOK(), "%v", res)
+		proposalID, _ := strconv.ParseInt(string(res.Data), 10, 64)
+		for _, addr := range addrs[:2] {
+			res = govHandler(ctx, gov.NewMsgVote(addr, proposalID, gov.OptionYes))
+			require.True(t, res.IsOK(), "%v", res)
+		}
+		return proposalID
+	}
+	prunedID := submitAndVote(ctx, votingPeriod)
+	// the voting period of this proposal is over after the retention period of the other one
+	keptID := submitAndVote(ctx, retention+2*votingPeriod)
+
+	// the finished proposal is kept during its retention period
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+	require.Equal(t, gov.StatusPassed, keeper.GetProposal(ctx, prunedID).GetStatus())
+	_, ok := keeper.GetSettledDeposit(ctx, prunedID, addrs[0])
+	require.True(t, ok)
+
+	// it is pruned once its retention period is over, its archived record is kept
+	newHeader.Time = newHeader.Time.Add(retention)
+	ctx = ctx.WithBlockHeader(newHeader)
+	gov.EndBlocker(ctx, keeper)
+	require.Nil(t, keeper.GetProposal(ctx, prunedID))
+	_, ok = keeper.GetVote(ctx, prunedID, addrs[0])
+	require.False(t, ok)
+	_, ok = keeper.GetSettledDeposit(ctx, prunedID, addrs[0])
+	require.False(t, ok)
+	archived, ok := keeper.GetArchivedProposal(ctx, prunedID)
+	require.True(t, ok)
+	require.Equal(t, prunedID, archived.ProposalID)
+	require.Equal(t, gov.StatusPassed, archived.Status)
+	require.Equal(t, int64(2), archived.NumVotes)
+
+	// the proposal still in voting period is not pruned
+	require.Equal(t, gov.StatusVotingPeriod, keeper.GetProposal(ctx, keptID).GetStatus())
+	_, ok = keeper.GetArchivedProposal(ctx, keptID)
+	require.False(t, ok)
+	require.Len(t, keeper.GetArchivedProposals(ctx), 1)
+}
+
+func TestSunsetReadOnlyAndArchive(t *testing.T) {
+	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	govHandler := gov.NewHandler(keeper)
+
+	height := sdk.UpgradeMgr.GetHeight()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovSunset, 10)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovArchive, 20)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.GovSunset)
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.GovArchive)
+		sdk.UpgradeMgr.SetHeight(height)
+	}()
+
+	deposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1000e8)}
+	res := govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[1], deposit, 1000))
+	require.True(t, res.IsOK(), res.Log)
+	proposalID, _ := strconv.ParseInt(string(res.Data), 10, 64)
+
+	// no new proposal after the sunset, but the proposals in flight can still be deposited on
+	sdk.UpgradeMgr.SetHeight(10)
+	res = govHandler(ctx, gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[1], deposit, 1000))
+	require.Equal(t, sdk.ToABCICode(gov.DefaultCodespace, gov.CodeGovernanceReadOnly), res.Code)
+	res = govHandler(ctx, gov.NewMsgDeposit(addrs[2], proposalID, deposit))
+	require.True(t, res.IsOK(), res.Log)
+
+	gov.EndBlocker(ctx, keeper)
+	_, archived := keeper.GetArchive(ctx)
+	require.False(t, archived)
+
+	sdk.UpgradeMgr.SetHeight(20)
+	gov.EndBlocker(ctx.WithBlockHeight(20), keeper)
+	archive, archived := keeper.GetArchive(ctx)
+	require.True(t, archived)
+	require.Equal(t, int64(20), archive.Height)
+	require.Len(t, archive.Proposals, 1)
+	require.Equal(t, proposalID, archive.Proposals[0].ProposalID)
+	require.Equal(t, gov.NativeChainID, archive.Proposals[0].ChainID)
+	require.Equal(t, gov.StatusVotingPeriod, archive.Proposals[0].Status)
+	require.Equal(t, deposit.Plus(deposit), archive.Proposals[0].TotalDeposit)
+}
@@ -25,8 +25,24 @@ const (
 	CodeInvalidProposal         sdk.CodeType = 12
 	CodeInvalidVotingPeriod     sdk.CodeType = 13
 	CodeInvalidSideChainId      sdk.CodeType = 14
+	CodeGovernanceReadOnly      sdk.CodeType = 15
+	CodeInvalidWeightedVote     sdk.CodeType = 16
+	CodeInvalidChoice           sdk.CodeType = 17
+	CodeRouteHalted             sdk.CodeType = 18
+	CodeInvalidMetadata         sdk.CodeType = 19
+	CodeInvalidParams           sdk.CodeType = 20
 )
 
+func init() {
+	sdk.RegisterCodespace(DefaultCodespace, "gov",
+		CodeUnknownProposal, CodeInactiveProposal, CodeAlreadyActiveProposal,
+		CodeAlreadyFinishedProposal, CodeAddressNotStaked, CodeInvalidTitle,
+		CodeInvalidDescription, CodeInvalidProposalType, CodeInvalidVote, CodeInvalidGenesis,
+		CodeInvalidProposalStatus, CodeInvalidProposal, CodeInvalidVotingPeriod,
+		CodeInvalidSideChainId, CodeGovernanceReadOnly, CodeInvalidWeightedVote, CodeInvalidChoice,
+		CodeRouteHalted, CodeInvalidMetadata, CodeInvalidParams)
+}
+
 //----------------------------------------
 // Error constructors
 
@@ -81,3 +97,27 @@ func ErrInvalidVotingPeriod(codespace sdk.CodespaceType, votingPeriod time.Durat
 func ErrInvalidSideChainId(codespace sdk.CodespaceType, sideChain string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidSideChainId, fmt.Sprintf("Invalid side chain id: %s", sideChain))
 }
+
+func ErrGovernanceReadOnly(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeGovernanceReadOnly, "Governance is read-only since the chain sunset, no new proposal is accepted")
+}
+
+func ErrInvalidWeightedVote(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidWeightedVote, fmt.Sprintf("Invalid weighted vote: %s", msg))
+}
+
+func ErrInvalidChoice(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidChoice, fmt.Sprintf("Invalid choice: %s", msg))
+}
+
+func ErrRouteHalted(codespace sdk.CodespaceType, route string) sdk.Error {
+	return sdk.NewError(codespace, CodeRouteHalted, fmt.Sprintf("The msgs of route %s are halted by the circuit breaker", route))
+}
+
+func ErrInvalidMetadata(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidMetadata, fmt.Sprintf("Invalid metadata: %s", msg))
+}
+
+func ErrInvalidParams(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidParams, fmt.Sprintf("Invalid params: %s", msg))
+}
@@ -1,11 +1,59 @@
 package events
 
 var (
+	// emitted by the submit, deposit and vote messages, on the native chain and on the side chains
+	EventTypeProposalSubmitted = "proposal-submitted"
+	EventTypeProposalDeposit   = "proposal-deposit"
+	EventTypeProposalVote      = "proposal-vote"
+
 	EventTypeProposalDropped  = "proposal-dropped"
 	EventTypeProposalPassed   = "proposal-passed"
 	EventTypeProposalRejected = "proposal-rejected"
 
+	// emitted when a proposal passes but its execution is delayed by the execution delay params, and when
+	// the execution of a proposal pending execution is cancelled
+	EventTypeProposalPendingExecution   = "proposal-pending-execution"
+	EventTypeProposalExecutionCancelled = "proposal-execution-cancelled"
+
+	// emitted when an expedited proposal that did not pass is converted to a regular one
+	EventTypeProposalConverted = "proposal-converted"
+
+	// emitted by the AppModule for the proposals settled in EndBlocker
+	EventTypeDepositsRefunded    = "deposits-refunded"
+	EventTypeDepositsDistributed = "deposits-distributed"
+
+	// emitted for each deposit of a settled proposal
+	EventTypeDepositRefunded        = "deposit-refunded"
+	EventTypeDepositDistributed     = "deposit-distributed"
+	EventTypeDepositBurnt           = "deposit-burnt"
+	EventTypeDepositToCommunityPool = "deposit-to-community-pool"
+
+	// emitted for each validator rewarded for voting on a tallied proposal
+	EventTypeVoteIncentiveDistributed = "vote-incentive-distributed"
+
+	// emitted when a finished proposal is archived and its votes and deposits are pruned
+	EventTypeProposalPruned = "proposal-pruned"
+
+	// emitted when the final proposal results are archived at the chain sunset
+	EventTypeProposalsArchived = "proposals-archived"
+
+	// emitted when the result of a passed side chain proposal is pushed to the side chain
+	EventTypeProposalResultSent = "proposal-result-sent"
+
 	ProposalID        = "proposal-id"
 	VotingPeriodStart = "voting-period-start"
 	SideChainID       = "side-chain-id"
+	Validator         = "validator"
+	Depositor         = "depositor"
+	Amount            = "amount"
+	NumProposals      = "num-proposals"
+	Sequence          = "sequence"
+	ExecutionTime     = "execution-time"
+	Proposer          = "proposer"
+	ProposalType      = "proposal-type"
+	VotingPeriodEnd   = "voting-period-end"
+	Voter             = "voter"
+	Option            = "option"
+	Choice            = "choice"
+	Outcome           = "outcome"
 )
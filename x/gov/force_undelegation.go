@@ -0,0 +1,50 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//-----------------------------------------------------------
+// Force Undelegation
+
+// MinForceUndelegationThreshold is the least threshold a force undelegation proposal passes with, whatever the
+// tally params of its type
+var MinForceUndelegationThreshold = sdk.NewDecWithPrec(8, 1)
+
+// ForceUndelegator force-undelegates the stake of the validator a ForceUndelegation proposal is about, it is
+// implemented by the stake module. Its hooks check the description of the proposals when they are submitted.
+type ForceUndelegator interface {
+	GovHooks
+
+	// ForceUndelegate starts the unbonding of all the delegations to the validator of a passed proposal
+	ForceUndelegate(ctx sdk.Context, proposal Proposal) sdk.Error
+}
+
+// SetForceUndelegator enables the ForceUndelegation proposals, executed by undelegator
+func (keeper *Keeper) SetForceUndelegator(undelegator ForceUndelegator) {
+	keeper.forceUndelegator = undelegator
+	keeper.hooks[ProposalTypeForceUndelegation] = append(keeper.hooks[ProposalTypeForceUndelegation], undelegator)
+}
+
+// executeForceUndelegation executes a passed ForceUndelegation proposal on a cache of ctx, which is written only
+// if all the delegations start unbonding
+func executeForceUndelegation(ctx sdk.Context, keeper Keeper, proposal Proposal) {
+	logger := ctx.Logger().With("module", "x/gov")
+	if keeper.forceUndelegator == nil {
+		logger.Error("Force undelegations are not supported, will skip.", "proposalId", proposal.GetProposalID())
+		return
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	if err := keeper.forceUndelegator.ForceUndelegate(cacheCtx, proposal); err != nil {
+		logger.Error("Failed to force undelegate, will skip.", "proposalId", proposal.GetProposalID(), "err", err)
+		return
+	}
+	write()
+	proposal.SetStatus(StatusExecuted)
+	keeper.AppendAuditRecord(ctx, "", proposal, AuditActionForceUndelegated)
+
+	logger.Info(fmt.Sprintf("proposal %d force undelegated the stake of a validator", proposal.GetProposalID()))
+}
@@ -3,6 +3,7 @@ package gov
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov/events"
@@ -19,12 +20,18 @@ func NewHandler(keeper Keeper) sdk.Handler {
 			return handleMsgSubmitProposal(ctx, keeper, msg)
 		case MsgVote:
 			return handleMsgVote(ctx, keeper, msg)
+		case MsgVoteWeighted:
+			return handleMsgVoteWeighted(ctx, keeper, msg)
+		case MsgVoteChoice:
+			return handleMsgVoteChoice(ctx, keeper, msg)
 		case MsgSideChainDeposit:
 			return handleMsgSideChainDeposit(ctx, keeper, msg)
 		case MsgSideChainSubmitProposal:
 			return handleMsgSideChainSubmitProposal(ctx, keeper, msg)
 		case MsgSideChainVote:
 			return handleMsgSideChainVote(ctx, keeper, msg)
+		case MsgSideChainVoteWeighted:
+			return handleMsgSideChainVoteWeighted(ctx, keeper, msg)
 		default:
 			errMsg := "Unrecognized gov msg type"
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -33,13 +40,68 @@ func NewHandler(keeper Keeper) sdk.Handler {
 }
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
+	if IsReadOnly(ctx) {
+		return ErrGovernanceReadOnly(keeper.codespace).Result()
+	}
+
+	if msg.ProposalType == ProposalTypeGenericParamChange {
+		setting, err := parseParamChangeSetting(keeper.codespace, msg.Description)
+		if err != nil {
+			return err.Result()
+		}
+		if _, err := keeper.checkParamChanges(ctx, setting); err != nil {
+			return err.Result()
+		}
+	}
+	if msg.ProposalType == ProposalTypeBatch {
+		setting, err := parseBatchSetting(keeper.codespace, msg.Description)
+		if err != nil {
+			return err.Result()
+		}
+		if err := keeper.checkBatch(ctx, setting); err != nil {
+			return err.Result()
+		}
+	}
+
+	if msg.ProposalType == ProposalTypeForceUndelegation && keeper.forceUndelegator == nil {
+		return ErrInvalidProposal(keeper.codespace, "force undelegation proposals are not enabled").Result()
+	}
+
+	if maxLength := keeper.GetMetadataParams(ctx).MaxLength; len(msg.Metadata) > maxLength {
+		return ErrInvalidMetadata(keeper.codespace, fmt.Sprintf("Proposal metadata is longer than max length of %d", maxLength)).Result()
+	}
 
-	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType, msg.VotingPeriod)
+	var proposal Proposal
+	if msg.ProposalType == ProposalTypeMultipleChoice {
+		if msg.Expedited {
+			return ErrInvalidProposal(keeper.codespace, "multiple choice proposals can not be expedited").Result()
+		}
+		proposal = keeper.NewMultipleChoiceProposal(ctx, msg.Title, msg.Description, msg.Choices, msg.VotingPeriod)
+	} else if msg.Emergency {
+		if !keeper.GetEmergencyParams(ctx).Enabled() {
+			return ErrInvalidProposal(keeper.codespace, "emergency proposals are not enabled").Result()
+		}
+		proposal = keeper.NewEmergencyTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType, msg.VotingPeriod)
+	} else if msg.Expedited {
+		if !keeper.GetExpeditedParams(ctx).Enabled() {
+			return ErrInvalidProposal(keeper.codespace, "expedited proposals are not enabled").Result()
+		}
+		proposal = keeper.NewExpeditedTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType, msg.VotingPeriod)
+	} else {
+		proposal = keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType, msg.VotingPeriod)
+	}
+	if msg.Metadata != "" {
+		proposal.SetMetadata(msg.Metadata)
+		keeper.SetProposal(ctx, proposal)
+	}
 
 	hooksErr := keeper.OnProposalSubmitted(ctx, proposal)
 	if hooksErr != nil {
 		return ErrInvalidProposal(keeper.codespace, hooksErr.Error()).Result()
 	}
+	if err := validateRegisteredProposal(ctx, proposal); err != nil {
+		return err.Result()
+	}
 
 	proposalID := proposal.GetProposalID()
 	proposalIDBytes := []byte(fmt.Sprintf("%d", proposalID))
@@ -58,10 +120,20 @@ func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitPropos
 	if votingStarted {
 		resTags.AppendTag(tags.VotingPeriodStart, proposalIDBytes)
 	}
+	if msg.Metadata != "" {
+		resTags = resTags.AppendTag(tags.Metadata, []byte(msg.Metadata))
+	}
+
+	event := proposalEvent(ctx, keeper, events.EventTypeProposalSubmitted, keeper.GetProposal(ctx, proposalID)).
+		AppendAttributes(
+			sdk.NewAttribute(events.Proposer, msg.Proposer.String()),
+			sdk.NewAttribute(events.Amount, msg.InitialDeposit.String()),
+		)
 
 	return sdk.Result{
-		Data: proposalIDBytes,
-		Tags: resTags,
+		Data:   proposalIDBytes,
+		Tags:   resTags,
+		Events: sdk.Events{event},
 	}
 }
 
@@ -85,37 +157,133 @@ func handleMsgDeposit(ctx sdk.Context, keeper Keeper, msg MsgDeposit) sdk.Result
 		resTags.AppendTag(tags.VotingPeriodStart, proposalIDBytes)
 	}
 
+	event := proposalEvent(ctx, keeper, events.EventTypeProposalDeposit, keeper.GetProposal(ctx, msg.ProposalID)).
+		AppendAttributes(
+			sdk.NewAttribute(events.Depositor, msg.Depositer.String()),
+			sdk.NewAttribute(events.Amount, msg.Amount.String()),
+		)
+
 	return sdk.Result{
-		Tags: resTags,
+		Tags:   resTags,
+		Events: sdk.Events{event},
 	}
 }
 
 func handleMsgVote(ctx sdk.Context, keeper Keeper, msg MsgVote) sdk.Result {
-	validator := keeper.vs.Validator(ctx, sdk.ValAddress(msg.Voter))
+	if err := checkVoter(ctx, keeper, msg.ProposalID, msg.Voter); err != nil {
+		return err.Result()
+	}
 
-	if validator == nil {
-		return sdk.ErrUnauthorized("Vote is not from a validator operator").Result()
+	err := keeper.AddVote(ctx, msg.ProposalID, msg.Voter, msg.Option)
+	if err != nil {
+		return err.Result()
 	}
 
-	if validator.GetPower().IsZero() {
-		return sdk.ErrUnauthorized("Validator is not bonded").Result()
+	return voteResult(ctx, keeper, msg.Voter, msg.ProposalID)
+}
+
+func handleMsgVoteWeighted(ctx sdk.Context, keeper Keeper, msg MsgVoteWeighted) sdk.Result {
+	if err := checkVoter(ctx, keeper, msg.ProposalID, msg.Voter); err != nil {
+		return err.Result()
 	}
 
-	err := keeper.AddVote(ctx, msg.ProposalID, msg.Voter, msg.Option)
+	err := keeper.AddWeightedVote(ctx, msg.ProposalID, msg.Voter, msg.Options)
 	if err != nil {
 		return err.Result()
 	}
 
-	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(msg.ProposalID)
+	return voteResult(ctx, keeper, msg.Voter, msg.ProposalID)
+}
+
+func handleMsgVoteChoice(ctx sdk.Context, keeper Keeper, msg MsgVoteChoice) sdk.Result {
+	if err := checkVoter(ctx, keeper, msg.ProposalID, msg.Voter); err != nil {
+		return err.Result()
+	}
+
+	err := keeper.AddChoiceVote(ctx, msg.ProposalID, msg.Voter, msg.Choice)
+	if err != nil {
+		return err.Result()
+	}
+
+	return voteResult(ctx, keeper, msg.Voter, msg.ProposalID)
+}
+
+// only the operators of bonded validators can vote
+// checkVoter checks that voter is a bonded validator operator. Since the GovDelegatorVote upgrade, a delegator
+// can also vote on the proposals that are not emergency proposals, its vote overrides the vote of its validators
+// on its share of their voting power.
+func checkVoter(ctx sdk.Context, keeper Keeper, proposalID int64, voter sdk.AccAddress) sdk.Error {
+	validator := keeper.vs.Validator(ctx, sdk.ValAddress(voter))
+
+	if validator == nil {
+		if ctx.UpgradeMgr().IsUpgrade(sdk.GovDelegatorVote) {
+			if proposal := keeper.GetProposal(ctx, proposalID); proposal != nil && !proposal.GetEmergency() {
+				return checkDelegatorVoter(ctx, keeper, voter)
+			}
+		}
+		return sdk.ErrUnauthorized("Vote is not from a validator operator")
+	}
+
+	if validator.GetPower().IsZero() {
+		return sdk.ErrUnauthorized("Validator is not bonded")
+	}
+	return nil
+}
+
+// checkDelegatorVoter checks that voter delegates to a bonded validator, so that its vote is counted
+func checkDelegatorVoter(ctx sdk.Context, keeper Keeper, voter sdk.AccAddress) sdk.Error {
+	bonded := false
+	keeper.ds.IterateDelegations(ctx, voter, func(index int64, delegation sdk.Delegation) (stop bool) {
+		validator := keeper.vs.Validator(ctx, delegation.GetValidatorAddr())
+		bonded = validator != nil && !validator.GetPower().IsZero()
+		return bonded
+	})
+	if !bonded {
+		return sdk.ErrUnauthorized("Vote is not from a validator operator or a delegator of a bonded validator")
+	}
+	return nil
+}
+
+func voteResult(ctx sdk.Context, keeper Keeper, voter sdk.AccAddress, proposalID int64) sdk.Result {
+	proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(proposalID)
 
 	resTags := sdk.NewTags(
 		tags.Action, tags.ActionVote,
-		tags.Voter, []byte(msg.Voter.String()),
+		tags.Voter, []byte(voter.String()),
 		tags.ProposalID, proposalIDBytes,
 	)
+
+	event := proposalEvent(ctx, keeper, events.EventTypeProposalVote, keeper.GetProposal(ctx, proposalID)).
+		AppendAttributes(sdk.NewAttribute(events.Voter, voter.String()))
+	if vote, ok := keeper.GetVote(ctx, proposalID, voter); ok {
+		switch {
+		case vote.Choice != 0:
+			event = event.AppendAttributes(sdk.NewAttribute(events.Choice, strconv.FormatInt(vote.Choice, 10)))
+		case len(vote.Options) != 0:
+			event = event.AppendAttributes(sdk.NewAttribute(events.Option, vote.Options.String()))
+		default:
+			event = event.AppendAttributes(sdk.NewAttribute(events.Option, vote.Option.String()))
+		}
+	}
 	return sdk.Result{
-		Tags: resTags,
+		Tags:   resTags,
+		Events: sdk.Events{event},
+	}
+}
+
+// proposalEvent returns an event of eventType about a proposal of the chain of ctx, telling its type and, once its
+// voting period has started, when it ends
+func proposalEvent(ctx sdk.Context, keeper Keeper, eventType string, proposal Proposal) sdk.Event {
+	event := sdk.NewEvent(eventType, sdk.NewAttribute(events.ProposalID, strconv.FormatInt(proposal.GetProposalID(), 10)))
+	if sideChainId, ok := keeper.sideChainIdOf(ctx); ok {
+		event = event.AppendAttributes(sdk.NewAttribute(events.SideChainID, sideChainId))
+	}
+	event = event.AppendAttributes(sdk.NewAttribute(events.ProposalType, proposal.GetProposalType().String()))
+	if !proposal.GetVotingStartTime().IsZero() {
+		votingPeriodEnd := proposal.GetVotingStartTime().Add(proposal.GetVotingPeriod())
+		event = event.AppendAttributes(sdk.NewAttribute(events.VotingPeriodEnd, votingPeriodEnd.UTC().Format(time.RFC3339)))
 	}
+	return event
 }
 
 type SimpleProposal struct {
@@ -129,19 +297,23 @@ func EndBlocker(baseCtx sdk.Context, keeper Keeper) (refundProposals, notRefundP
 	notRefundProposals = make([]SimpleProposal, 0)
 	chainIDs := []string{NativeChainID}
 	contexts := []sdk.Context{baseCtx}
-	if sdk.IsUpgrade(sdk.LaunchBscUpgrade) && keeper.ScKeeper != nil {
+	if baseCtx.UpgradeMgr().IsUpgrade(sdk.LaunchBscUpgrade) && keeper.ScKeeper != nil {
 		tmpSideIDs, storePrefixes := keeper.ScKeeper.GetAllSideChainPrefixes(baseCtx)
 		chainIDs = append(chainIDs, tmpSideIDs...)
 		for i := range storePrefixes {
-			contexts = append(contexts, baseCtx.WithSideChainKeyPrefix(storePrefixes[i]))
+			contexts = append(contexts, baseCtx.WithSideChainKeyPrefix(storePrefixes[i]).WithSideChainId(tmpSideIDs[i]))
 		}
 	}
 	for i := 0; i < len(chainIDs); i++ {
 		resEvents, refund, noRefund := settleProposals(contexts[i], keeper, chainIDs[i])
 		events = events.AppendEvents(resEvents)
+		events = events.AppendEvents(pruneProposals(contexts[i], keeper, chainIDs[i]))
 		refundProposals = append(refundProposals, refund...)
 		notRefundProposals = append(notRefundProposals, noRefund...)
 	}
+	if baseCtx.UpgradeMgr().IsUpgradeHeight(sdk.GovArchive) {
+		events = events.AppendEvent(archiveProposals(baseCtx, keeper))
+	}
 	baseCtx.EventManager().EmitEvents(events)
 	return
 }
@@ -154,31 +326,39 @@ func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents
 	refundProposals = make([]SimpleProposal, 0)
 	notRefundProposals = make([]SimpleProposal, 0)
 
+	// Execute the passed proposals whose execution delay is over
+	resEvents = resEvents.AppendEvents(releasePendingProposals(ctx, keeper, chainId))
+
 	// Delete proposals that haven't met minDeposit
 	for ShouldPopInactiveProposalQueue(ctx, keeper) {
 		inactiveProposal := keeper.InactiveProposalQueuePop(ctx)
 		if inactiveProposal.GetStatus() != StatusDepositPeriod {
 			continue
 		}
-		// distribute deposits to proposer
-		keeper.DistributeDeposits(ctx, inactiveProposal.GetProposalID())
+		action := "distribute to validator"
+		if keeper.GetExpiredDepositParams(ctx).Refund {
+			action = "refund deposits"
+			resEvents = resEvents.AppendEvents(depositEvents(events.EventTypeDepositRefunded, chainId,
+				keeper.RefundDeposits(ctx, inactiveProposal.GetProposalID())))
+			refundProposals = append(refundProposals, SimpleProposal{inactiveProposal.GetProposalID(), chainId})
+		} else {
+			// distribute deposits to proposer
+			resEvents = resEvents.AppendEvents(depositEvents(events.EventTypeDepositDistributed, chainId,
+				keeper.DistributeDeposits(ctx, inactiveProposal.GetProposalID())))
+			notRefundProposals = append(notRefundProposals, SimpleProposal{inactiveProposal.GetProposalID(), chainId})
+		}
 
 		keeper.DeleteProposal(ctx, inactiveProposal)
 
-		notRefundProposals = append(notRefundProposals, SimpleProposal{inactiveProposal.GetProposalID(), chainId})
-		event := sdk.NewEvent(events.EventTypeProposalDropped, sdk.NewAttribute(events.ProposalID,
-			strconv.FormatInt(inactiveProposal.GetProposalID(), 10)))
-		if chainId != NativeChainID {
-			event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
-		}
-		resEvents = resEvents.AppendEvent(event)
+		resEvents = resEvents.AppendEvent(proposalEvent(ctx, keeper, events.EventTypeProposalDropped, inactiveProposal))
 
 		logger.Info(
-			fmt.Sprintf("proposal %d (%s) didn't meet minimum deposit of %v (had only %v); distribute to validator",
+			fmt.Sprintf("proposal %d (%s) didn't meet minimum deposit of %v (had only %v); %s",
 				inactiveProposal.GetProposalID(),
 				inactiveProposal.GetTitle(),
-				keeper.GetDepositParams(ctx).MinDeposit,
+				keeper.minDeposit(ctx, inactiveProposal),
 				inactiveProposal.GetTotalDeposit(),
+				action,
 			),
 		)
 	}
@@ -193,14 +373,30 @@ func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents
 			continue
 		}
 
-		passes, refundDeposits, tallyResults := Tally(ctx, keeper, activeProposal)
+		passes, refundDeposits, tallyResults, voters := tally(ctx, keeper, activeProposal)
+		if !passes && activeProposal.GetExpedited() {
+			resEvents = resEvents.AppendEvent(convertExpeditedProposal(ctx, keeper, chainId, activeProposal, tallyResults))
+			continue
+		}
 		var action string
+		var executionEvents sdk.Events
+		var executionTime time.Time
 		if passes {
-			activeProposal.SetStatus(StatusPassed)
-			action = events.EventTypeProposalPassed
+			if delay := keeper.executionDelay(ctx, activeProposal); delay > 0 {
+				// executed by releasePendingProposals once the delay is over, unless cancelled before
+				activeProposal.SetStatus(StatusPendingExecution)
+				action = events.EventTypeProposalPendingExecution
+				executionTime = ctx.BlockHeader().Time.Add(delay)
+				keeper.ExecutionQueueInsert(ctx, activeProposal.GetProposalID(), executionTime)
+			} else {
+				activeProposal.SetStatus(StatusPassed)
+				action = events.EventTypeProposalPassed
+				executionEvents = executeProposal(ctx, keeper, chainId, activeProposal)
+			}
 
 			// refund deposits
-			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
+			resEvents = resEvents.AppendEvents(depositEvents(events.EventTypeDepositRefunded, chainId,
+				keeper.RefundDeposits(ctx, activeProposal.GetProposalID())))
 			refundProposals = append(refundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
 		} else {
 			activeProposal.SetStatus(StatusRejected)
@@ -208,10 +404,22 @@ func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents
 
 			// if votes reached quorum and not all votes are abstain, distribute deposits to validator, else refund deposits
 			if refundDeposits {
-				keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
+				resEvents = resEvents.AppendEvents(depositEvents(events.EventTypeDepositRefunded, chainId,
+					keeper.RefundDeposits(ctx, activeProposal.GetProposalID())))
 				refundProposals = append(refundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
+			} else if activeProposal.GetProposalType() != ProposalTypeMultipleChoice &&
+				vetoed(tallyResults, keeper.tallyParams(ctx, activeProposal)) {
+				// the deposits of a vetoed proposal are settled by the veto params of the chain
+				vetoEvents, refunded := settleVetoedDeposits(ctx, keeper, chainId, activeProposal)
+				resEvents = resEvents.AppendEvents(vetoEvents)
+				if refunded {
+					refundProposals = append(refundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
+				} else {
+					notRefundProposals = append(notRefundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
+				}
 			} else {
-				keeper.DistributeDeposits(ctx, activeProposal.GetProposalID())
+				resEvents = resEvents.AppendEvents(depositEvents(events.EventTypeDepositDistributed, chainId,
+					keeper.DistributeDeposits(ctx, activeProposal.GetProposalID())))
 				notRefundProposals = append(notRefundProposals, SimpleProposal{activeProposal.GetProposalID(), chainId})
 			}
 		}
@@ -221,17 +429,81 @@ func settleProposals(ctx sdk.Context, keeper Keeper, chainId string) (resEvents
 
 		logger.Info(fmt.Sprintf("proposal %d (%s) tallied; passed: %v",
 			activeProposal.GetProposalID(), activeProposal.GetTitle(), passes))
-		event := sdk.NewEvent(action, sdk.NewAttribute(events.ProposalID,
-			strconv.FormatInt(activeProposal.GetProposalID(), 10)))
-		if chainId != NativeChainID {
-			event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
+		event := proposalEvent(ctx, keeper, action, activeProposal).
+			AppendAttributes(sdk.NewAttribute(events.Outcome, activeProposal.GetStatus().String()))
+		if !executionTime.IsZero() {
+			event = event.AppendAttributes(sdk.NewAttribute(events.ExecutionTime, executionTime.UTC().Format(time.RFC3339)))
 		}
 		resEvents = resEvents.AppendEvent(event)
+		resEvents = resEvents.AppendEvents(executionEvents)
+		resEvents = resEvents.AppendEvents(distributeVoteIncentives(ctx, keeper, chainId, activeProposal, voters))
 	}
 
 	return
 }
 
+// depositEvents returns an event of eventType for each deposit of a settled proposal
+func depositEvents(eventType string, chainId string, deposits []Deposit) sdk.Events {
+	var resEvents sdk.Events
+	for _, deposit := range deposits {
+		event := sdk.NewEvent(eventType,
+			sdk.NewAttribute(events.ProposalID, strconv.FormatInt(deposit.ProposalID, 10)),
+			sdk.NewAttribute(events.Depositor, deposit.Depositer.String()),
+			sdk.NewAttribute(events.Amount, deposit.Amount.String()))
+		if chainId != NativeChainID {
+			event = event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
+		}
+		resEvents = resEvents.AppendEvent(event)
+	}
+	return resEvents
+}
+
+// convertExpeditedProposal turns an expedited proposal that did not pass into a regular one, which keeps
+// its deposits and votes and is voted until the end of its regular voting period
+func convertExpeditedProposal(ctx sdk.Context, keeper Keeper, chainId string, proposal Proposal, tallyResults TallyResult) sdk.Event {
+	proposal.SetExpedited(false)
+	proposal.SetVotingPeriod(proposal.GetRegularVotingPeriod())
+	proposal.SetRegularVotingPeriod(0)
+	proposal.SetTallyResult(tallyResults)
+	keeper.SetProposal(ctx, proposal)
+	keeper.ActiveProposalQueuePush(ctx, proposal)
+
+	ctx.Logger().With("module", "x/gov").Info(fmt.Sprintf("expedited proposal %d (%s) did not pass, converted to a regular proposal",
+		proposal.GetProposalID(), proposal.GetTitle()))
+	event := sdk.NewEvent(events.EventTypeProposalConverted, sdk.NewAttribute(events.ProposalID,
+		strconv.FormatInt(proposal.GetProposalID(), 10)))
+	if chainId != NativeChainID {
+		event = event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
+	}
+	return event
+}
+
+// sendProposalResult pushes the result of a passed side chain proposal to the side chain if its type is in
+// the ResultRelayParams. A failure is logged only, the result can still be relayed by polling the proposal.
+func sendProposalResult(ctx sdk.Context, keeper Keeper, chainId string, proposal Proposal) sdk.Events {
+	if !keeper.GetResultRelayParams(ctx).Relayed(proposal.GetProposalType()) {
+		return nil
+	}
+	logger := ctx.Logger().With("module", "x/gov")
+	sender, ok := keeper.ScKeeper.(ProposalResultSender)
+	if !ok {
+		logger.Error("the side chain keeper can not send the proposal results", "proposalId", proposal.GetProposalID())
+		return nil
+	}
+	// the cross chain packages are stored by the native chain
+	seq, err := sender.SendProposalResult(ctx.DepriveSideChainKeyPrefix(), chainId, proposal)
+	if err != nil {
+		logger.Error("failed to send the proposal result to the side chain", "proposalId", proposal.GetProposalID(),
+			"sideChainId", chainId, "err", err.Error())
+		return nil
+	}
+	return sdk.Events{sdk.NewEvent(events.EventTypeProposalResultSent,
+		sdk.NewAttribute(events.ProposalID, strconv.FormatInt(proposal.GetProposalID(), 10)),
+		sdk.NewAttribute(events.SideChainID, chainId),
+		sdk.NewAttribute(events.Sequence, strconv.FormatUint(seq, 10)),
+	)}
+}
+
 func ShouldPopInactiveProposalQueue(ctx sdk.Context, keeper Keeper) bool {
 	depositParams := keeper.GetDepositParams(ctx)
 	peekProposal := keeper.InactiveProposalQueuePeek(ctx)
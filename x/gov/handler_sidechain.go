@@ -6,7 +6,10 @@ import (
 )
 
 func handleMsgSideChainSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSideChainSubmitProposal) sdk.Result {
-	if msg.ProposalType == ProposalTypeText && !sdk.IsUpgrade(sdk.BEP173) {
+	if msg.ProposalType == ProposalTypeText && !ctx.UpgradeMgr().IsUpgrade(sdk.BEP173) {
+		return ErrInvalidProposalType(keeper.codespace, msg.ProposalType).Result()
+	}
+	if msg.ProposalType == ProposalTypeGenericParamChange && !ctx.UpgradeMgr().IsUpgrade(sdk.GovSideChainParams) {
 		return ErrInvalidProposalType(keeper.codespace, msg.ProposalType).Result()
 	}
 
@@ -15,9 +18,17 @@ func handleMsgSideChainSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSid
 		return ErrInvalidSideChainId(keeper.codespace, msg.SideChainId).Result()
 	}
 
-	result := handleMsgSubmitProposal(ctx, keeper,
-		NewMsgSubmitProposal(msg.Title, msg.Description, msg.ProposalType, msg.Proposer, msg.InitialDeposit,
-			msg.VotingPeriod))
+	submitMsg := NewMsgSubmitProposal(msg.Title, msg.Description, msg.ProposalType, msg.Proposer, msg.InitialDeposit,
+		msg.VotingPeriod)
+	submitMsg.Expedited = msg.Expedited
+	submitMsg.Metadata = msg.Metadata
+	if ctx.UpgradeMgr().IsUpgrade(sdk.GovSideChainDepositParams) {
+		// the voting period set for the side chain through the param hub overrides that of the message
+		if votingParams := keeper.GetVotingParams(ctx); votingParams.VotingPeriod > 0 {
+			submitMsg.VotingPeriod = votingParams.VotingPeriod
+		}
+	}
+	result := handleMsgSubmitProposal(ctx, keeper, submitMsg)
 	if result.IsOK() {
 		result.Tags = result.Tags.AppendTag(events.SideChainID, []byte(msg.SideChainId))
 	}
@@ -48,3 +59,15 @@ func handleMsgSideChainVote(ctx sdk.Context, keeper Keeper, msg MsgSideChainVote
 	}
 	return result
 }
+
+func handleMsgSideChainVoteWeighted(ctx sdk.Context, keeper Keeper, msg MsgSideChainVoteWeighted) sdk.Result {
+	ctx, err := keeper.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId)
+	if err != nil {
+		return ErrInvalidSideChainId(keeper.codespace, msg.SideChainId).Result()
+	}
+	result := handleMsgVoteWeighted(ctx, keeper, NewMsgVoteWeighted(msg.Voter, msg.ProposalID, msg.Options))
+	if result.IsOK() {
+		result.Tags = result.Tags.AppendTag(events.SideChainID, []byte(msg.SideChainId))
+	}
+	return result
+}
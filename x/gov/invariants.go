@@ -0,0 +1,106 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//-----------------------------------------------------------
+// Invariants
+
+// Invariant checks the state of the gov module on the native chain and all the side chains, it returns an error
+// describing the first violation found
+type Invariant func(ctx sdk.Context, keeper Keeper) error
+
+// InvariantResult is the result of an invariant check, as answered to the invariants query
+type InvariantResult struct {
+	Name    string `json:"name"`
+	Broken  bool   `json:"broken"`
+	Message string `json:"message,omitempty"`
+}
+
+var invariants = []struct {
+	name  string
+	check Invariant
+}{
+	{"deposits", DepositsInvariant},
+	{"votes", VotesInvariant},
+}
+
+// CheckInvariants runs all the invariants of the gov module
+func (keeper Keeper) CheckInvariants(ctx sdk.Context) []InvariantResult {
+	results := make([]InvariantResult, 0, len(invariants))
+	for _, invariant := range invariants {
+		result := InvariantResult{Name: invariant.name}
+		if err := invariant.check(ctx, keeper); err != nil {
+			result.Broken = true
+			result.Message = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// chainContexts returns the ids and the contexts of the native chain and all the side chains
+func (keeper Keeper) chainContexts(ctx sdk.Context) ([]string, []sdk.Context) {
+	ctx = ctx.DepriveSideChainKeyPrefix()
+	chainIDs := []string{NativeChainID}
+	contexts := []sdk.Context{ctx}
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LaunchBscUpgrade) && keeper.ScKeeper != nil {
+		sideChainIDs, storePrefixes := keeper.ScKeeper.GetAllSideChainPrefixes(ctx)
+		for i := range sideChainIDs {
+			chainIDs = append(chainIDs, sideChainIDs[i])
+			contexts = append(contexts, ctx.WithSideChainKeyPrefix(storePrefixes[i]).WithSideChainId(sideChainIDs[i]))
+		}
+	}
+	return chainIDs, contexts
+}
+
+// DepositsInvariant checks that every deposit kept is on a proposal in its deposit or voting period, and that the
+// deposits of all the chains add up to the balance of DepositedCoinsAccAddr
+func DepositsInvariant(ctx sdk.Context, keeper Keeper) error {
+	total := sdk.Coins{}
+	chainIDs, contexts := keeper.chainContexts(ctx)
+	for i, chainCtx := range contexts {
+		iterator := sdk.KVStorePrefixIterator(chainCtx.KVStore(keeper.storeKey), KeyDeposits)
+		for ; iterator.Valid(); iterator.Next() {
+			var deposit Deposit
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &deposit)
+			proposal := keeper.GetProposal(chainCtx, deposit.ProposalID)
+			if proposal == nil ||
+				(proposal.GetStatus() != StatusDepositPeriod && proposal.GetStatus() != StatusVotingPeriod) {
+				iterator.Close()
+				return fmt.Errorf("deposit of %s on proposal %d of chain %q is not settled",
+					deposit.Depositer, deposit.ProposalID, chainIDs[i])
+			}
+			total = total.Plus(deposit.Amount)
+		}
+		iterator.Close()
+	}
+
+	balance := keeper.ck.GetCoins(contexts[0], DepositedCoinsAccAddr)
+	if !total.IsEqual(balance) {
+		return fmt.Errorf("the deposits add up to %v but the deposit account holds %v", total, balance)
+	}
+	return nil
+}
+
+// VotesInvariant checks that every vote kept is on an existing proposal of its chain
+func VotesInvariant(ctx sdk.Context, keeper Keeper) error {
+	chainIDs, contexts := keeper.chainContexts(ctx)
+	for i, chainCtx := range contexts {
+		iterator := sdk.KVStorePrefixIterator(chainCtx.KVStore(keeper.storeKey), KeyVotes)
+		for ; iterator.Valid(); iterator.Next() {
+			var vote Vote
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &vote)
+			if keeper.GetProposal(chainCtx, vote.ProposalID) == nil {
+				iterator.Close()
+				return fmt.Errorf("vote of %s is on unknown proposal %d of chain %q",
+					vote.Voter, vote.ProposalID, chainIDs[i])
+			}
+		}
+		iterator.Close()
+	}
+	return nil
+}
@@ -22,6 +22,18 @@ var (
 	ParamStoreKeyDepositParams = []byte("depositparams")
 	ParamStoreKeyTallyParams   = []byte("tallyparams")
 
+	ParamStoreKeyVoteIncentiveParams  = []byte("voteincentiveparams")
+	ParamStoreKeyExpeditedParams      = []byte("expeditedparams")
+	ParamStoreKeyProposalTypeParams   = []byte("proposaltypeparams")
+	ParamStoreKeyEmergencyParams      = []byte("emergencyparams")
+	ParamStoreKeyMetadataParams       = []byte("metadataparams")
+	ParamStoreKeyResultRelayParams    = []byte("resultrelayparams")
+	ParamStoreKeyVetoParams           = []byte("vetoparams")
+	ParamStoreKeyExecutionDelayParams = []byte("executiondelayparams")
+	ParamStoreKeyPruningParams        = []byte("pruningparams")
+	ParamStoreKeyVotingParams         = []byte("votingparams")
+	ParamStoreKeyExpiredDepositParams = []byte("expireddepositparams")
+
 	// Will hold deposit of both BC chain and side chain.
 	DepositedCoinsAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainDepositedCoins")))
 )
@@ -31,6 +43,17 @@ func ParamTypeTable() params.TypeTable {
 	return params.NewTypeTable(
 		ParamStoreKeyDepositParams, DepositParams{},
 		ParamStoreKeyTallyParams, TallyParams{},
+		ParamStoreKeyVoteIncentiveParams, VoteIncentiveParams{},
+		ParamStoreKeyExpeditedParams, ExpeditedParams{},
+		ParamStoreKeyProposalTypeParams, []ProposalTypeParams{},
+		ParamStoreKeyEmergencyParams, EmergencyParams{},
+		ParamStoreKeyMetadataParams, MetadataParams{},
+		ParamStoreKeyResultRelayParams, ResultRelayParams{},
+		ParamStoreKeyVetoParams, VetoParams{},
+		ParamStoreKeyExecutionDelayParams, ExecutionDelayParams{},
+		ParamStoreKeyPruningParams, PruningParams{},
+		ParamStoreKeyVotingParams, VotingParams{},
+		ParamStoreKeyExpiredDepositParams, ExpiredDepositParams{},
 	)
 }
 
@@ -39,6 +62,13 @@ type SideChainKeeper interface {
 	GetAllSideChainPrefixes(ctx sdk.Context) ([]string, [][]byte)
 }
 
+// ProposalResultSender is optionally implemented by the SideChainKeeper to push the result of a passed side
+// chain proposal to the side chain in a cross chain package, see ResultRelayParams. ctx is the context of
+// the native chain, it returns the sequence of the package.
+type ProposalResultSender interface {
+	SendProposalResult(ctx sdk.Context, sideChainId string, proposal Proposal) (uint64, sdk.Error)
+}
+
 // Governance Keeper
 type Keeper struct {
 	// The reference to the Param Keeper to get and set Global Params
@@ -73,6 +103,12 @@ type Keeper struct {
 
 	// if you want to enable side chains, you need call `SetupForSideChain`
 	ScKeeper SideChainKeeper
+
+	// source of the vote incentives, nil unless `SetCommunityPool` is called
+	communityPool CommunityPool
+
+	// executor of the force undelegation proposals, nil unless `SetForceUndelegator` is called
+	forceUndelegator ForceUndelegator
 }
 
 // NewKeeper returns a governance keeper. It handles:
@@ -99,6 +135,12 @@ func (keeper *Keeper) SetupForSideChain(scKeeper SideChainKeeper) {
 	keeper.ScKeeper = scKeeper
 }
 
+// SetCommunityPool enables the vote incentives, paid from pool, see VoteIncentiveParams. If pool is also
+// a CommunityPoolFunder, it can receive the deposits of the vetoed proposals, see VetoParams
+func (keeper *Keeper) SetCommunityPool(pool CommunityPool) {
+	keeper.communityPool = pool
+}
+
 // AddHooks add hooks for gov keeper
 func (keeper Keeper) AddHooks(proposalType ProposalKind, hooks GovHooks) Keeper {
 	hs := keeper.hooks[proposalType]
@@ -135,6 +177,41 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 	return proposal
 }
 
+// Creates a new expedited proposal, voted during the expedited voting period with the expedited params.
+// If it does not pass, it is converted to a regular proposal voted until the end of votingPeriod.
+func (keeper Keeper) NewExpeditedTextProposal(ctx sdk.Context, title string, description string, proposalType ProposalKind, votingPeriod time.Duration) Proposal {
+	proposal := keeper.NewTextProposal(ctx, title, description, proposalType, votingPeriod)
+	if proposal == nil {
+		return nil
+	}
+	expeditedVotingPeriod := keeper.GetExpeditedParams(ctx).VotingPeriod
+	if expeditedVotingPeriod > votingPeriod {
+		expeditedVotingPeriod = votingPeriod
+	}
+	proposal.SetExpedited(true)
+	proposal.SetRegularVotingPeriod(votingPeriod)
+	proposal.SetVotingPeriod(expeditedVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+	return proposal
+}
+
+// Creates a new emergency proposal, voted by the validators during the emergency voting period with the
+// emergency params. It is rejected if it does not pass.
+func (keeper Keeper) NewEmergencyTextProposal(ctx sdk.Context, title string, description string, proposalType ProposalKind, votingPeriod time.Duration) Proposal {
+	proposal := keeper.NewTextProposal(ctx, title, description, proposalType, votingPeriod)
+	if proposal == nil {
+		return nil
+	}
+	emergencyVotingPeriod := keeper.GetEmergencyParams(ctx).VotingPeriod
+	if emergencyVotingPeriod > votingPeriod {
+		emergencyVotingPeriod = votingPeriod
+	}
+	proposal.SetEmergency(true)
+	proposal.SetVotingPeriod(emergencyVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+	return proposal
+}
+
 // Get Proposal from store by ProposalID
 func (keeper Keeper) GetProposal(ctx sdk.Context, proposalID int64) Proposal {
 	store := ctx.KVStore(keeper.storeKey)
@@ -154,12 +231,15 @@ func (keeper Keeper) SetProposal(ctx sdk.Context, proposal Proposal) {
 	store := ctx.KVStore(keeper.storeKey)
 	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(proposal)
 	store.Set(KeyProposal(proposal.GetProposalID()), bz)
+	keeper.indexProposal(ctx, proposal)
 }
 
 // Implements sdk.AccountKeeper.
 func (keeper Keeper) DeleteProposal(ctx sdk.Context, proposal Proposal) {
 	store := ctx.KVStore(keeper.storeKey)
 	store.Delete(KeyProposal(proposal.GetProposalID()))
+	keeper.unindexProposal(ctx, proposal)
+	keeper.deletePowerSnapshot(ctx, proposal.GetProposalID())
 }
 
 func (keeper Keeper) Iterate(ctx sdk.Context, voterAddr sdk.AccAddress, depositerAddr sdk.AccAddress, status ProposalStatus, numLatest int64, reverse bool, iter func(Proposal) bool) {
@@ -283,6 +363,7 @@ func (keeper Keeper) ActivateVotingPeriod(ctx sdk.Context, proposal Proposal) {
 	proposal.SetStatus(StatusVotingPeriod)
 	keeper.SetProposal(ctx, proposal)
 	keeper.ActiveProposalQueuePush(ctx, proposal)
+	keeper.snapshotVotingPower(ctx, proposal)
 }
 
 // =====================================================
@@ -304,16 +385,246 @@ func (keeper Keeper) GetTallyParams(ctx sdk.Context) TallyParams {
 	return tallyParams
 }
 
+// Returns the current Vote Incentive Params from the global param store, without incentive if unset
+func (keeper Keeper) GetVoteIncentiveParams(ctx sdk.Context) VoteIncentiveParams {
+	var voteIncentiveParams VoteIncentiveParams
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyVoteIncentiveParams, &voteIncentiveParams)
+	return voteIncentiveParams
+}
+
+// Returns the current Expedited Params from the global param store, expedited proposals are disabled if unset
+func (keeper Keeper) GetExpeditedParams(ctx sdk.Context) ExpeditedParams {
+	var expeditedParams ExpeditedParams
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyExpeditedParams, &expeditedParams)
+	return expeditedParams
+}
+
+// Returns the current Emergency Params from the global param store, emergency proposals are disabled if unset
+func (keeper Keeper) GetEmergencyParams(ctx sdk.Context) EmergencyParams {
+	var emergencyParams EmergencyParams
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyEmergencyParams, &emergencyParams)
+	return emergencyParams
+}
+
+// Returns the current Metadata Params from the global param store, limited to DefaultMaxMetadataLength if unset
+func (keeper Keeper) GetMetadataParams(ctx sdk.Context) MetadataParams {
+	metadataParams := MetadataParams{MaxLength: DefaultMaxMetadataLength}
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyMetadataParams, &metadataParams)
+	return metadataParams
+}
+
+// Returns the current Result Relay Params from the global param store, no result is pushed if unset
+func (keeper Keeper) GetResultRelayParams(ctx sdk.Context) ResultRelayParams {
+	var resultRelayParams ResultRelayParams
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyResultRelayParams, &resultRelayParams)
+	return resultRelayParams
+}
+
+// Returns the current Veto Params from the global param store, the deposits are distributed if unset
+func (keeper Keeper) GetVetoParams(ctx sdk.Context) VetoParams {
+	vetoParams := VetoParams{DepositAction: VetoDepositDistribute}
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyVetoParams, &vetoParams)
+	return vetoParams
+}
+
+// Returns the current Expired Deposit Params from the global param store, the deposits are distributed if unset
+func (keeper Keeper) GetExpiredDepositParams(ctx sdk.Context) ExpiredDepositParams {
+	var expiredDepositParams ExpiredDepositParams
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyExpiredDepositParams, &expiredDepositParams)
+	return expiredDepositParams
+}
+
+// Returns the current Execution Delay Params from the global param store, the passed proposals are executed at once if unset
+func (keeper Keeper) GetExecutionDelayParams(ctx sdk.Context) ExecutionDelayParams {
+	var executionDelayParams ExecutionDelayParams
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyExecutionDelayParams, &executionDelayParams)
+	return executionDelayParams
+}
+
+// Returns the current Pruning Params from the global param store, the proposals are never pruned if unset
+func (keeper Keeper) GetPruningParams(ctx sdk.Context) PruningParams {
+	var pruningParams PruningParams
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyPruningParams, &pruningParams)
+	return pruningParams
+}
+
+// Returns the current Voting Params of a side chain, the proposals keep the voting period they are submitted with if unset
+func (keeper Keeper) GetVotingParams(ctx sdk.Context) VotingParams {
+	var votingParams VotingParams
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyVotingParams, &votingParams)
+	return votingParams
+}
+
+// Returns the params overriding the deposit and tally params of some proposal types, none if unset
+func (keeper Keeper) GetProposalTypeParams(ctx sdk.Context) []ProposalTypeParams {
+	var proposalTypeParams []ProposalTypeParams
+	keeper.paramSpace.GetIfExists(ctx, ParamStoreKeyProposalTypeParams, &proposalTypeParams)
+	return proposalTypeParams
+}
+
+// Returns the Deposit Params of the proposals of a type, the global ones unless overridden by ProposalTypeParams
+func (keeper Keeper) GetDepositParamsOf(ctx sdk.Context, proposalType ProposalKind) DepositParams {
+	depositParams := keeper.GetDepositParams(ctx)
+	if typeParams, ok := keeper.getProposalTypeParams(ctx, proposalType); ok {
+		depositParams.MinDeposit = typeParams.MinDeposit
+	}
+	return depositParams
+}
+
+// Returns the Tally Params of the proposals of a type, the global ones unless overridden by ProposalTypeParams
+func (keeper Keeper) GetTallyParamsOf(ctx sdk.Context, proposalType ProposalKind) TallyParams {
+	if typeParams, ok := keeper.getProposalTypeParams(ctx, proposalType); ok {
+		return typeParams.TallyParams
+	}
+	return keeper.GetTallyParams(ctx)
+}
+
+func (keeper Keeper) getProposalTypeParams(ctx sdk.Context, proposalType ProposalKind) (ProposalTypeParams, bool) {
+	for _, typeParams := range keeper.GetProposalTypeParams(ctx) {
+		if typeParams.ProposalType == proposalType {
+			return typeParams, true
+		}
+	}
+	return ProposalTypeParams{}, false
+}
+
 // nolint: errcheck
 func (keeper Keeper) SetDepositParams(ctx sdk.Context, depositParams DepositParams) {
 	keeper.paramSpace.Set(ctx, ParamStoreKeyDepositParams, &depositParams)
 }
 
+// Sets the voting period of the proposals of a side chain, at most MaxVotingPeriod
+func (keeper Keeper) SetVotingParams(ctx sdk.Context, votingParams VotingParams) sdk.Error {
+	if err := votingParams.Validate(); err != nil {
+		return ErrInvalidParams(keeper.codespace, err.Error())
+	}
+	keeper.paramSpace.Set(ctx, ParamStoreKeyVotingParams, &votingParams)
+	return nil
+}
+
 // nolint: errcheck
 func (keeper Keeper) SetTallyParams(ctx sdk.Context, tallyParams TallyParams) {
 	keeper.paramSpace.Set(ctx, ParamStoreKeyTallyParams, &tallyParams)
 }
 
+// nolint: errcheck
+func (keeper Keeper) SetVoteIncentiveParams(ctx sdk.Context, voteIncentiveParams VoteIncentiveParams) {
+	keeper.paramSpace.Set(ctx, ParamStoreKeyVoteIncentiveParams, &voteIncentiveParams)
+}
+
+// nolint: errcheck
+func (keeper Keeper) SetExpeditedParams(ctx sdk.Context, expeditedParams ExpeditedParams) {
+	keeper.paramSpace.Set(ctx, ParamStoreKeyExpeditedParams, &expeditedParams)
+}
+
+// nolint: errcheck
+func (keeper Keeper) SetEmergencyParams(ctx sdk.Context, emergencyParams EmergencyParams) {
+	keeper.paramSpace.Set(ctx, ParamStoreKeyEmergencyParams, &emergencyParams)
+}
+
+// Sets the limit of the metadata length, at most MaxMetadataLength
+func (keeper Keeper) SetMetadataParams(ctx sdk.Context, metadataParams MetadataParams) sdk.Error {
+	if metadataParams.MaxLength < 0 || metadataParams.MaxLength > MaxMetadataLength {
+		return ErrInvalidMetadata(keeper.codespace, fmt.Sprintf("the max length of the metadata must be between 0 and %d", MaxMetadataLength))
+	}
+	keeper.paramSpace.Set(ctx, ParamStoreKeyMetadataParams, &metadataParams)
+	return nil
+}
+
+// Sets the types of the side chain proposals whose results are pushed to the side chain
+func (keeper Keeper) SetResultRelayParams(ctx sdk.Context, resultRelayParams ResultRelayParams) sdk.Error {
+	for _, pt := range resultRelayParams.ProposalTypes {
+		if !validSideProposalType(pt) {
+			return ErrInvalidProposalType(keeper.codespace, pt)
+		}
+	}
+	keeper.paramSpace.Set(ctx, ParamStoreKeyResultRelayParams, &resultRelayParams)
+	return nil
+}
+
+// Sets the action on the deposits of the vetoed proposals
+func (keeper Keeper) SetVetoParams(ctx sdk.Context, vetoParams VetoParams) sdk.Error {
+	if err := vetoParams.Validate(); err != nil {
+		return ErrInvalidParams(keeper.codespace, err.Error())
+	}
+	keeper.paramSpace.Set(ctx, ParamStoreKeyVetoParams, &vetoParams)
+	return nil
+}
+
+// Sets whether the deposits of the proposals dropped in their deposit period are refunded
+func (keeper Keeper) SetExpiredDepositParams(ctx sdk.Context, expiredDepositParams ExpiredDepositParams) {
+	keeper.paramSpace.Set(ctx, ParamStoreKeyExpiredDepositParams, &expiredDepositParams)
+}
+
+// Sets the execution delays of the passed proposals of some types
+func (keeper Keeper) SetExecutionDelayParams(ctx sdk.Context, executionDelayParams ExecutionDelayParams) sdk.Error {
+	if err := executionDelayParams.Validate(); err != nil {
+		return ErrInvalidParams(keeper.codespace, err.Error())
+	}
+	keeper.paramSpace.Set(ctx, ParamStoreKeyExecutionDelayParams, &executionDelayParams)
+	return nil
+}
+
+// Sets the retention period of the finished proposals
+func (keeper Keeper) SetPruningParams(ctx sdk.Context, pruningParams PruningParams) sdk.Error {
+	if err := pruningParams.Validate(); err != nil {
+		return ErrInvalidParams(keeper.codespace, err.Error())
+	}
+	keeper.paramSpace.Set(ctx, ParamStoreKeyPruningParams, &pruningParams)
+	return nil
+}
+
+// Sets the params overriding the deposit and tally params of some proposal types, at most one per type
+func (keeper Keeper) SetProposalTypeParams(ctx sdk.Context, proposalTypeParams []ProposalTypeParams) sdk.Error {
+	if err := validateProposalTypeParams(keeper.codespace, proposalTypeParams); err != nil {
+		return err
+	}
+	keeper.paramSpace.Set(ctx, ParamStoreKeyProposalTypeParams, &proposalTypeParams)
+	return nil
+}
+
+func validateProposalTypeParams(codespace sdk.CodespaceType, proposalTypeParams []ProposalTypeParams) sdk.Error {
+	seen := make(map[ProposalKind]bool, len(proposalTypeParams))
+	for _, typeParams := range proposalTypeParams {
+		if !validProposalType(typeParams.ProposalType) && !validSideProposalType(typeParams.ProposalType) {
+			return ErrInvalidProposalType(codespace, typeParams.ProposalType)
+		}
+		if seen[typeParams.ProposalType] {
+			return ErrInvalidProposal(codespace, fmt.Sprintf("params of proposal type %s are set twice", typeParams.ProposalType))
+		}
+		seen[typeParams.ProposalType] = true
+	}
+	return nil
+}
+
+// Returns the deposit needed by a proposal to enter voting period
+func (keeper Keeper) minDeposit(ctx sdk.Context, proposal Proposal) sdk.Coins {
+	if proposal.GetEmergency() {
+		return keeper.GetEmergencyParams(ctx).MinDeposit
+	}
+	if proposal.GetExpedited() {
+		return keeper.GetExpeditedParams(ctx).MinDeposit
+	}
+	return keeper.GetDepositParamsOf(ctx, proposal.GetProposalType()).MinDeposit
+}
+
+// Returns the tally params of a proposal
+func (keeper Keeper) tallyParams(ctx sdk.Context, proposal Proposal) TallyParams {
+	tallyParams := keeper.GetTallyParamsOf(ctx, proposal.GetProposalType())
+	if proposal.GetExpedited() {
+		expeditedParams := keeper.GetExpeditedParams(ctx)
+		tallyParams.Quorum, tallyParams.Threshold = expeditedParams.Quorum, expeditedParams.Threshold
+	}
+	if proposal.GetEmergency() {
+		emergencyParams := keeper.GetEmergencyParams(ctx)
+		tallyParams.Quorum, tallyParams.Threshold = emergencyParams.Quorum, emergencyParams.Threshold
+	}
+	if proposal.GetProposalType() == ProposalTypeForceUndelegation && tallyParams.Threshold.LT(MinForceUndelegationThreshold) {
+		tallyParams.Threshold = MinForceUndelegationThreshold
+	}
+	return tallyParams
+}
+
 // =====================================================
 // Votes
 
@@ -326,6 +637,9 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Ac
 	if proposal.GetStatus() != StatusVotingPeriod {
 		return ErrInactiveProposal(keeper.codespace, proposalID)
 	}
+	if proposal.GetProposalType() == ProposalTypeMultipleChoice {
+		return ErrInvalidChoice(keeper.codespace, fmt.Sprintf("proposal %d is voted by choice", proposalID))
+	}
 
 	if !validVoteOption(option) {
 		return ErrInvalidVote(keeper.codespace, option)
@@ -341,6 +655,38 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Ac
 	return nil
 }
 
+// Adds a split vote on a specific proposal, the voting power of the voter is divided
+// among the options by their weights
+func (keeper Keeper) AddWeightedVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress, options WeightedVoteOptions) sdk.Error {
+	proposal := keeper.GetProposal(ctx, proposalID)
+	if proposal == nil {
+		return ErrUnknownProposal(keeper.codespace, proposalID)
+	}
+	if proposal.GetStatus() != StatusVotingPeriod {
+		return ErrInactiveProposal(keeper.codespace, proposalID)
+	}
+	if proposal.GetProposalType() == ProposalTypeMultipleChoice {
+		return ErrInvalidChoice(keeper.codespace, fmt.Sprintf("proposal %d is voted by choice", proposalID))
+	}
+
+	if err := options.Validate(keeper.codespace); err != nil {
+		return err
+	}
+
+	vote := Vote{
+		ProposalID: proposalID,
+		Voter:      voterAddr,
+		Options:    options,
+	}
+	// a vote with a single option is stored as a plain vote
+	if len(options) == 1 {
+		vote.Option, vote.Options = options[0].Option, nil
+	}
+	keeper.setVote(ctx, proposalID, voterAddr, vote)
+
+	return nil
+}
+
 // Gets the vote of a specific voter on a specific proposal
 func (keeper Keeper) GetVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress) (Vote, bool) {
 	store := ctx.KVStore(keeper.storeKey)
@@ -357,6 +703,7 @@ func (keeper Keeper) setVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Ac
 	store := ctx.KVStore(keeper.storeKey)
 	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(vote)
 	store.Set(KeyVote(proposalID, voterAddr), bz)
+	keeper.recordVote(ctx, vote)
 }
 
 // Gets all the votes on a specific proposal
@@ -422,7 +769,7 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 	// Check if deposit tipped proposal into voting period
 	// Active voting period if so
 	activatedVotingPeriod := false
-	if proposal.GetStatus() == StatusDepositPeriod && proposal.GetTotalDeposit().IsGTE(keeper.GetDepositParams(ctx).MinDeposit) {
+	if proposal.GetStatus() == StatusDepositPeriod && proposal.GetTotalDeposit().IsGTE(keeper.minDeposit(ctx, proposal)) {
 		keeper.ActivateVotingPeriod(ctx, proposal)
 		activatedVotingPeriod = true
 	}
@@ -446,11 +793,11 @@ func (keeper Keeper) GetDeposits(ctx sdk.Context, proposalID int64) sdk.Iterator
 	return sdk.KVStorePrefixIterator(store, KeyDepositsSubspace(proposalID))
 }
 
-// Returns and deletes all the deposits on a specific proposal
-func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
+// Returns and deletes all the deposits on a specific proposal, the refunded deposits are returned and kept as settled deposits
+func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) []Deposit {
 	store := ctx.KVStore(keeper.storeKey)
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
-	defer depositsIterator.Close()
+	var refunded []Deposit
 	for ; depositsIterator.Valid(); depositsIterator.Next() {
 		deposit := &Deposit{}
 		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(depositsIterator.Value(), deposit)
@@ -462,11 +809,16 @@ func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
 
 		keeper.pool.AddAddrs([]sdk.AccAddress{deposit.Depositer, DepositedCoinsAccAddr})
 		store.Delete(depositsIterator.Key())
+		refunded = append(refunded, *deposit)
 	}
+	depositsIterator.Close()
+
+	keeper.setSettledDeposits(ctx, refunded, DepositOutcomeRefunded)
+	return refunded
 }
 
-// DistributeDeposits distributes deposits to proposer
-func (keeper Keeper) DistributeDeposits(ctx sdk.Context, proposalID int64) {
+// DistributeDeposits distributes deposits to proposer, the distributed deposits are returned and kept as settled deposits
+func (keeper Keeper) DistributeDeposits(ctx sdk.Context, proposalID int64) []Deposit {
 	proposerValAddr := ctx.BlockHeader().ProposerAddress
 	proposerValidator := keeper.vs.ValidatorByConsAddr(ctx.DepriveSideChainKeyPrefix(), proposerValAddr)
 	proposerAccAddr := proposerValidator.GetFeeAddr()
@@ -475,14 +827,17 @@ func (keeper Keeper) DistributeDeposits(ctx sdk.Context, proposalID int64) {
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
 
 	depositCoins := sdk.Coins{}
+	var distributed []Deposit
 	for ; depositsIterator.Valid(); depositsIterator.Next() {
 		deposit := &Deposit{}
 		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(depositsIterator.Value(), deposit)
 
 		depositCoins = depositCoins.Plus(deposit.Amount)
 		store.Delete(depositsIterator.Key())
+		distributed = append(distributed, *deposit)
 	}
 	depositsIterator.Close()
+	keeper.setSettledDeposits(ctx, distributed, DepositOutcomeDistributed)
 
 	if depositCoins.IsPositive() {
 		ctx.Logger().Info("distribute empty deposits")
@@ -493,6 +848,27 @@ func (keeper Keeper) DistributeDeposits(ctx sdk.Context, proposalID int64) {
 		panic(fmt.Sprintf("distribute deposits error(%s) should not happen", err.Error()))
 	}
 	keeper.pool.AddAddrs([]sdk.AccAddress{sdk.AccAddress(proposerAccAddr), DepositedCoinsAccAddr})
+	return distributed
+}
+
+func (keeper Keeper) setSettledDeposits(ctx sdk.Context, deposits []Deposit, outcome string) {
+	store := ctx.KVStore(keeper.storeKey)
+	for _, deposit := range deposits {
+		settled := SettledDeposit{Deposit: deposit, Outcome: outcome, SettledHeight: ctx.BlockHeight()}
+		store.Set(KeySettledDeposit(deposit.ProposalID, deposit.Depositer), keeper.cdc.MustMarshalBinaryLengthPrefixed(settled))
+	}
+}
+
+// Gets the settled deposit of a depositer on a specific proposal, once the proposal is settled
+func (keeper Keeper) GetSettledDeposit(ctx sdk.Context, proposalID int64, depositerAddr sdk.AccAddress) (SettledDeposit, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeySettledDeposit(proposalID, depositerAddr))
+	if bz == nil {
+		return SettledDeposit{}, false
+	}
+	var settled SettledDeposit
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &settled)
+	return settled, true
 }
 
 // =====================================================
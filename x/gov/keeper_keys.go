@@ -2,6 +2,7 @@ package gov
 
 import (
 	"fmt"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -13,6 +14,17 @@ var (
 	KeyNextProposalID        = []byte("newProposalID")
 	KeyActiveProposalQueue   = []byte("activeProposalQueue")
 	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
+	KeyNextAuditSequence     = []byte("nextAuditSequence")
+	KeyAuditLogSubspace      = []byte("auditLog:")
+	KeyArchive               = []byte("archive")
+	KeyHaltedRoutesSubspace  = []byte("haltedRoutes:")
+	KeyProposalIndex         = []byte("proposalIndex:")
+	KeyExecutionQueue        = []byte("executionQueue:")
+	KeyPruneCursor           = []byte("pruneCursor")
+	KeyArchivedProposals     = []byte("archivedProposals:")
+	KeyPowerSnapshots        = []byte("powerSnapshots:")
+	KeyDeposits              = []byte("deposits:")
+	KeyVotes                 = []byte("votes:")
 )
 
 // Key for getting a specific proposal from the store
@@ -25,6 +37,16 @@ func KeyDeposit(proposalID int64, depositerAddr sdk.AccAddress) []byte {
 	return []byte(fmt.Sprintf("deposits:%d:%d", proposalID, depositerAddr))
 }
 
+// Key for getting a specific settled deposit from the store
+func KeySettledDeposit(proposalID int64, depositerAddr sdk.AccAddress) []byte {
+	return []byte(fmt.Sprintf("settledDeposits:%d:%d", proposalID, depositerAddr))
+}
+
+// Key for getting all settled deposits of a proposal from the store
+func KeySettledDepositsSubspace(proposalID int64) []byte {
+	return []byte(fmt.Sprintf("settledDeposits:%d:", proposalID))
+}
+
 // Key for getting a specific vote from the store
 func KeyVote(proposalID int64, voterAddr sdk.AccAddress) []byte {
 	return []byte(fmt.Sprintf("votes:%d:%d", proposalID, voterAddr))
@@ -39,3 +61,71 @@ func KeyDepositsSubspace(proposalID int64) []byte {
 func KeyVotesSubspace(proposalID int64) []byte {
 	return []byte(fmt.Sprintf("votes:%d:", proposalID))
 }
+
+// Key for getting a specific record of the audit log from the store, the keys are ordered by sequence
+func KeyAuditRecord(sequence int64) []byte {
+	return []byte(fmt.Sprintf("auditLog:%020d", sequence))
+}
+
+// Key for getting whether the msgs of a route are halted by the circuit breaker
+func KeyHaltedRoute(route string) []byte {
+	return []byte(fmt.Sprintf("haltedRoutes:%s", route))
+}
+
+// Key for getting the entry of a side chain proposal from the index, the keys are ordered by side chain and proposal id
+func KeyIndexedProposal(sideChainId string, proposalID int64) []byte {
+	return []byte(fmt.Sprintf("proposalIndex:%s:%020d", sideChainId, proposalID))
+}
+
+// Key for getting the entries of the proposals of a side chain from the index, of all the side chains if sideChainId is empty
+func KeyIndexedProposalsSubspace(sideChainId string) []byte {
+	if sideChainId == "" {
+		return KeyProposalIndex
+	}
+	return []byte(fmt.Sprintf("proposalIndex:%s:", sideChainId))
+}
+
+// Key for getting a proposal pending execution from the execution queue, the keys are ordered by execution time
+func KeyExecutionQueueProposal(executionTime time.Time, proposalID int64) []byte {
+	return []byte(fmt.Sprintf("executionQueue:%s:%020d", sdk.FormatTimeBytes(executionTime), proposalID))
+}
+
+// Key for getting the execution time of a proposal pending execution
+func KeyExecutionTime(proposalID int64) []byte {
+	return []byte(fmt.Sprintf("executionTime:%d", proposalID))
+}
+
+// Key for getting the archived record of a pruned proposal, the keys are ordered by proposal id
+func KeyArchivedProposal(proposalID int64) []byte {
+	return []byte(fmt.Sprintf("archivedProposals:%020d", proposalID))
+}
+
+// Key for getting the voting power snapshot of a side chain proposal taken at the start of its voting period
+func KeyPowerSnapshot(proposalID int64) []byte {
+	return []byte(fmt.Sprintf("powerSnapshots:%020d", proposalID))
+}
+
+// Key for getting the shares a delegation had at the start of the voting period of a proposal, recorded when
+// the delegation changes during the voting period
+func KeyDelegationSnapshot(proposalID int64, delAddr sdk.AccAddress, valAddr sdk.ValAddress) []byte {
+	key := KeyDelegationSnapshotsSubspace(proposalID, delAddr)
+	return append(key, valAddr.Bytes()...)
+}
+
+// Key for getting the shares the delegations of a delegator had at the start of the voting period of a
+// proposal, of all the delegators if delAddr is empty
+func KeyDelegationSnapshotsSubspace(proposalID int64, delAddr sdk.AccAddress) []byte {
+	key := []byte(fmt.Sprintf("delegationSnapshots:%020d:", proposalID))
+	return append(key, delAddr.Bytes()...)
+}
+
+// Key for getting the vote of a voter on a proposal of a chain from the voter history, the keys are ordered by
+// voter, chain and proposal id
+func KeyVoterHistory(voterAddr sdk.AccAddress, sideChainId string, proposalID int64) []byte {
+	return []byte(fmt.Sprintf("voterHistory:%d:%s:%020d", voterAddr, sideChainId, proposalID))
+}
+
+// Key for getting the votes of a voter on the proposals of all the chains from the voter history
+func KeyVoterHistorySubspace(voterAddr sdk.AccAddress) []byte {
+	return []byte(fmt.Sprintf("voterHistory:%d:", voterAddr))
+}
@@ -207,9 +207,15 @@ func TestDeposits(t *testing.T) {
 	deposit, found = keeper.GetDeposit(ctx, proposalID, addrs[1])
 	require.True(t, found)
 	require.Equal(t, fiveHundredSteak, deposit.Amount)
-	keeper.RefundDeposits(ctx, proposalID)
+	refunded := keeper.RefundDeposits(ctx, proposalID)
+	require.Len(t, refunded, 2)
 	deposit, found = keeper.GetDeposit(ctx, proposalID, addrs[1])
 	require.False(t, found)
+	settled, found := keeper.GetSettledDeposit(ctx, proposalID, addrs[1])
+	require.True(t, found)
+	require.Equal(t, fiveHundredSteak, settled.Amount)
+	require.Equal(t, gov.DepositOutcomeRefunded, settled.Outcome)
+	require.Equal(t, ctx.BlockHeight(), settled.SettledHeight)
 	require.Equal(t, addr0Initial, ck.GetCoins(ctx, addrs[0]))
 	require.Equal(t, addr1Initial, ck.GetCoins(ctx, addrs[1]))
 	require.Equal(t, sdk.Coins(nil), ck.GetCoins(ctx, gov.DepositedCoinsAccAddr))
@@ -271,6 +277,43 @@ func TestVotes(t *testing.T) {
 	votesIterator.Close()
 }
 
+func TestInvariants(t *testing.T) {
+	mapp, ck, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	broken := func() []string {
+		var names []string
+		for _, result := range keeper.CheckInvariants(ctx) {
+			if result.Broken {
+				names = append(names, result.Name)
+			}
+		}
+		return names
+	}
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	proposalID := proposal.GetProposalID()
+	err, _ := keeper.AddDeposit(ctx, proposalID, addrs[0], sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 500e8)})
+	require.Nil(t, err)
+	proposal = keeper.GetProposal(ctx, proposalID)
+	proposal.SetStatus(gov.StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+	require.Nil(t, keeper.AddVote(ctx, proposalID, addrs[1], gov.OptionYes))
+	require.Empty(t, broken())
+
+	// coins sent to the deposit account are not deposits
+	_, _, err = ck.AddCoins(ctx, gov.DepositedCoinsAccAddr, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1)})
+	require.Nil(t, err)
+	require.Equal(t, []string{"deposits"}, broken())
+	_, _, err = ck.SubtractCoins(ctx, gov.DepositedCoinsAccAddr, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 1)})
+	require.Nil(t, err)
+
+	// the deposits and the votes of a deleted proposal are left over
+	keeper.DeleteProposal(ctx, proposal)
+	require.Equal(t, []string{"deposits", "votes"}, broken())
+}
+
 func TestProposalQueues(t *testing.T) {
 	mapp, _, keeper, _, _, _, _ := getMockApp(t, 0)
 	mapp.BeginBlock(abci.RequestBeginBlock{})
@@ -318,3 +361,32 @@ func TestProposalQueues(t *testing.T) {
 	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal4.GetProposalID())
 	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal4.GetProposalID())
 }
+
+func TestProposalTypeParams(t *testing.T) {
+	mapp, _, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	require.Empty(t, keeper.GetProposalTypeParams(ctx))
+	require.Equal(t, keeper.GetTallyParams(ctx), keeper.GetTallyParamsOf(ctx, gov.ProposalTypeSCParamsChange))
+
+	tallyParams := gov.TallyParams{
+		Quorum:    sdk.NewDecWithPrec(8, 1),
+		Threshold: sdk.NewDecWithPrec(67, 2),
+		Veto:      sdk.NewDecWithPrec(334, 3),
+	}
+	minDeposit := sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 5000e8)}
+	require.NoError(t, keeper.SetProposalTypeParams(ctx, []gov.ProposalTypeParams{
+		{ProposalType: gov.ProposalTypeSCParamsChange, MinDeposit: minDeposit, TallyParams: tallyParams},
+	}))
+	require.Equal(t, tallyParams, keeper.GetTallyParamsOf(ctx, gov.ProposalTypeSCParamsChange))
+	require.Equal(t, minDeposit, keeper.GetDepositParamsOf(ctx, gov.ProposalTypeSCParamsChange).MinDeposit)
+	// the other types keep the global params
+	require.Equal(t, keeper.GetTallyParams(ctx), keeper.GetTallyParamsOf(ctx, gov.ProposalTypeText))
+	require.Equal(t, keeper.GetDepositParams(ctx), keeper.GetDepositParamsOf(ctx, gov.ProposalTypeText))
+
+	require.Error(t, keeper.SetProposalTypeParams(ctx, []gov.ProposalTypeParams{{ProposalType: gov.ProposalTypeNil}}))
+	require.Error(t, keeper.SetProposalTypeParams(ctx, []gov.ProposalTypeParams{
+		{ProposalType: gov.ProposalTypeText}, {ProposalType: gov.ProposalTypeText},
+	}))
+}
@@ -0,0 +1,74 @@
+package gov
+
+import (
+	"encoding/json"
+	"strconv"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov/events"
+)
+
+// ModuleName is the name of the gov module in the ModuleManager
+const ModuleName = "gov"
+
+var (
+	_ sdk.AppModuleGenesis    = AppModule{}
+	_ sdk.AppModuleEndBlocker = AppModule{}
+)
+
+// AppModule registers the gov module in the ModuleManager
+type AppModule struct {
+	keeper *Keeper
+}
+
+// NewAppModule creates a new AppModule of gov, the keeper is kept by reference
+// so that it can still be set up for side chains after the module is registered
+func NewAppModule(keeper *Keeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// Name returns the module name
+func (AppModule) Name() string {
+	return ModuleName
+}
+
+// DefaultGenesis returns the default genesis state
+func (am AppModule) DefaultGenesis() json.RawMessage {
+	return am.keeper.cdc.MustMarshalJSON(DefaultGenesisState())
+}
+
+// InitGenesis initializes the genesis state
+func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	var genesisState GenesisState
+	am.keeper.cdc.MustUnmarshalJSON(data, &genesisState)
+	InitGenesis(ctx, *am.keeper, genesisState)
+	return nil
+}
+
+// ExportGenesis exports the genesis state
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return am.keeper.cdc.MustMarshalJSON(WriteGenesis(ctx, *am.keeper))
+}
+
+// EndBlock settles the proposals whose deposit or voting period has ended, an event
+// is emitted for every settled proposal telling whether its deposits have been refunded
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	refundProposals, notRefundProposals := EndBlocker(ctx, *am.keeper)
+	for _, proposal := range refundProposals {
+		ctx.EventManager().EmitEvent(depositsEvent(events.EventTypeDepositsRefunded, proposal))
+	}
+	for _, proposal := range notRefundProposals {
+		ctx.EventManager().EmitEvent(depositsEvent(events.EventTypeDepositsDistributed, proposal))
+	}
+	return nil
+}
+
+func depositsEvent(eventType string, proposal SimpleProposal) sdk.Event {
+	event := sdk.NewEvent(eventType, sdk.NewAttribute(events.ProposalID, strconv.FormatInt(proposal.Id, 10)))
+	if proposal.ChainID != NativeChainID {
+		event = event.AppendAttributes(sdk.NewAttribute(events.SideChainID, proposal.ChainID))
+	}
+	return event
+}
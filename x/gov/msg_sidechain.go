@@ -12,9 +12,10 @@ const (
 	MsgTypeSideSubmitProposal = "side_submit_proposal"
 	MsgTypeSideDeposit        = "side_deposit"
 	MsgTypeSideVote           = "side_vote"
+	MsgTypeSideVoteWeighted   = "side_vote_weighted"
 )
 
-var _, _, _ sdk.Msg = MsgSideChainSubmitProposal{}, MsgSideChainDeposit{}, MsgSideChainVote{}
+var _, _, _, _ sdk.Msg = MsgSideChainSubmitProposal{}, MsgSideChainDeposit{}, MsgSideChainVote{}, MsgSideChainVoteWeighted{}
 
 //-----------------------------------------------------------
 // MsgSideChainSubmitProposal
@@ -26,6 +27,9 @@ type MsgSideChainSubmitProposal struct {
 	InitialDeposit sdk.Coins      `json:"initial_deposit"` //  Initial deposit paid by sender. Must be strictly positive.
 	VotingPeriod   time.Duration  `json:"voting_period"`   //  Length of the voting period (s)
 	SideChainId    string         `json:"side_chain_id"`
+
+	Expedited bool   `json:"expedited,omitempty"` //  Whether the proposal is voted faster with the expedited params
+	Metadata  string `json:"metadata,omitempty"`  //  Off-chain reference to the full proposal, e.g. an IPFS or URL reference and its checksum
 }
 
 func NewMsgSideChainSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.AccAddress, initialDeposit sdk.Coins, votingPeriod time.Duration, sideChainId string) MsgSideChainSubmitProposal {
@@ -64,6 +68,11 @@ func (msg MsgSideChainSubmitProposal) ValidateBasic() sdk.Error {
 	if !validSideProposalType(msg.ProposalType) {
 		return ErrInvalidProposalType(DefaultCodespace, msg.ProposalType)
 	}
+	if msg.ProposalType == ProposalTypeGenericParamChange {
+		if _, err := parseParamChangeSetting(DefaultCodespace, msg.Description); err != nil {
+			return err
+		}
+	}
 	if len(msg.Proposer) != sdk.AddrLen {
 		return sdk.ErrInvalidAddress(fmt.Sprintf("length of address(%s) should be %d", string(msg.Proposer), sdk.AddrLen))
 	}
@@ -76,6 +85,9 @@ func (msg MsgSideChainSubmitProposal) ValidateBasic() sdk.Error {
 	if msg.VotingPeriod <= 0 || msg.VotingPeriod > MaxVotingPeriod {
 		return ErrInvalidVotingPeriod(DefaultCodespace, msg.VotingPeriod)
 	}
+	if len(msg.Metadata) > MaxMetadataLength {
+		return ErrInvalidMetadata(DefaultCodespace, fmt.Sprintf("Proposal metadata is longer than max length of %d", MaxMetadataLength))
+	}
 	return nil
 }
 
@@ -230,3 +242,62 @@ func (msg MsgSideChainVote) GetSigners() []sdk.AccAddress {
 func (msg MsgSideChainVote) GetInvolvedAddresses() []sdk.AccAddress {
 	return msg.GetSigners()
 }
+
+//-----------------------------------------------------------
+// MsgSideChainVoteWeighted
+
+type MsgSideChainVoteWeighted struct {
+	ProposalID  int64               `json:"proposal_id"` // ID of the proposal
+	Voter       sdk.AccAddress      `json:"voter"`       //  address of the voter
+	Options     WeightedVoteOptions `json:"options"`     //  options chosen by the voter with the weights of its voting power
+	SideChainId string              `json:"side_chain_id"`
+}
+
+func NewMsgSideChainVoteWeighted(voter sdk.AccAddress, proposalID int64, options WeightedVoteOptions, sideChainId string) MsgSideChainVoteWeighted {
+	return MsgSideChainVoteWeighted{
+		ProposalID:  proposalID,
+		Voter:       voter,
+		Options:     options,
+		SideChainId: sideChainId,
+	}
+}
+
+func (msg MsgSideChainVoteWeighted) Route() string { return MsgRoute }
+func (msg MsgSideChainVoteWeighted) Type() string  { return MsgTypeSideVoteWeighted }
+
+// Implements Msg.
+func (msg MsgSideChainVoteWeighted) ValidateBasic() sdk.Error {
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return ErrInvalidSideChainId(DefaultCodespace, msg.SideChainId)
+	}
+	if len(msg.Voter) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("length of address(%s) should be %d", string(msg.Voter), sdk.AddrLen))
+	}
+	if msg.ProposalID < 0 {
+		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
+	}
+	return msg.Options.Validate(DefaultCodespace)
+}
+
+func (msg MsgSideChainVoteWeighted) String() string {
+	return fmt.Sprintf("MsgSideChainVoteWeighted{%v - %s, %s}", msg.ProposalID, msg.Options, msg.SideChainId)
+}
+
+// Implements Msg.
+func (msg MsgSideChainVoteWeighted) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg. Identical to MsgVoteWeighted, keep here for code readability.
+func (msg MsgSideChainVoteWeighted) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Voter}
+}
+
+// Implements Msg. Identical to MsgVoteWeighted, keep here for code readability.
+func (msg MsgSideChainVoteWeighted) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
@@ -14,9 +14,12 @@ const (
 	MaxTitleLength           = 128
 	MaxDescriptionLength int = 2048
 	MaxVotingPeriod          = 2 * 7 * 24 * 60 * 60 * time.Second // 2 weeks
+
+	// hard limit of the metadata length, the limit of the metadata params can not exceed it
+	MaxMetadataLength = 1024
 )
 
-var _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}
+var _, _, _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}, MsgVoteWeighted{}, MsgVoteChoice{}
 
 //-----------------------------------------------------------
 type ListTradingPairParams struct {
@@ -44,6 +47,11 @@ type MsgSubmitProposal struct {
 	Proposer       sdk.AccAddress `json:"proposer"`        //  Address of the proposer
 	InitialDeposit sdk.Coins      `json:"initial_deposit"` //  Initial deposit paid by sender. Must be strictly positive.
 	VotingPeriod   time.Duration  `json:"voting_period"`   //  Length of the voting period (s)
+
+	Expedited bool     `json:"expedited,omitempty"` //  Whether the proposal is voted faster with the expedited params
+	Choices   []string `json:"choices,omitempty"`   //  Choices of a multiple choice proposal
+	Emergency bool     `json:"emergency,omitempty"` //  Whether the proposal is voted by the validators with the emergency params
+	Metadata  string   `json:"metadata,omitempty"`  //  Off-chain reference to the full proposal, e.g. an IPFS or URL reference and its checksum
 }
 
 func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.AccAddress, initialDeposit sdk.Coins, votingPeriod time.Duration) MsgSubmitProposal {
@@ -90,6 +98,49 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if msg.VotingPeriod <= 0 || msg.VotingPeriod > MaxVotingPeriod {
 		return ErrInvalidVotingPeriod(DefaultCodespace, msg.VotingPeriod)
 	}
+	if len(msg.Metadata) > MaxMetadataLength {
+		return ErrInvalidMetadata(DefaultCodespace, fmt.Sprintf("Proposal metadata is longer than max length of %d", MaxMetadataLength))
+	}
+	if msg.Emergency {
+		if msg.Expedited {
+			return ErrInvalidProposal(DefaultCodespace, "an emergency proposal can not be expedited")
+		}
+		if !validEmergencyProposalType(msg.ProposalType) {
+			return ErrInvalidProposal(DefaultCodespace, fmt.Sprintf("%s proposals can not be emergency proposals", msg.ProposalType))
+		}
+	}
+	if msg.ProposalType == ProposalTypeCircuitBreaker {
+		if _, err := parseCircuitBreakerSetting(DefaultCodespace, msg.Description); err != nil {
+			return err
+		}
+	}
+	if msg.ProposalType == ProposalTypeGenericParamChange {
+		if _, err := parseParamChangeSetting(DefaultCodespace, msg.Description); err != nil {
+			return err
+		}
+	}
+	if msg.ProposalType == ProposalTypeBatch {
+		if _, err := parseBatchSetting(DefaultCodespace, msg.Description); err != nil {
+			return err
+		}
+	}
+	if msg.ProposalType == ProposalTypeForceUndelegation && msg.Expedited {
+		return ErrInvalidProposal(DefaultCodespace, fmt.Sprintf("%s proposals can not be expedited", msg.ProposalType))
+	}
+	if msg.ProposalType == ProposalTypeCancelExecution {
+		if !msg.Emergency {
+			return ErrInvalidProposal(DefaultCodespace, fmt.Sprintf("%s proposals must be emergency proposals", msg.ProposalType))
+		}
+		if _, err := parseCancelExecutionSetting(DefaultCodespace, msg.Description); err != nil {
+			return err
+		}
+	}
+	if msg.ProposalType == ProposalTypeMultipleChoice {
+		return validateChoices(DefaultCodespace, msg.Choices)
+	}
+	if len(msg.Choices) != 0 {
+		return ErrInvalidChoice(DefaultCodespace, fmt.Sprintf("choices are only given to %s proposals", ProposalTypeMultipleChoice))
+	}
 	return nil
 }
 
@@ -143,19 +194,11 @@ func (msg MsgDeposit) Type() string  { return "deposit" }
 
 // Implements Msg.
 func (msg MsgDeposit) ValidateBasic() sdk.Error {
-	if len(msg.Depositer) != sdk.AddrLen {
-		return sdk.ErrInvalidAddress(fmt.Sprintf("length of address(%s) should be %d", string(msg.Depositer), sdk.AddrLen))
-	}
-	if !msg.Amount.IsValid() {
-		return sdk.ErrInvalidCoins(msg.Amount.String())
-	}
-	if !msg.Amount.IsNotNegative() {
-		return sdk.ErrInvalidCoins(msg.Amount.String())
-	}
-	if msg.ProposalID < 0 {
-		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
-	}
-	return nil
+	return sdk.NewMsgValidator().
+		Address("depositer", msg.Depositer).
+		Require(msg.Amount.IsValid() && msg.Amount.IsNotNegative(), "amount", sdk.ErrInvalidCoins(msg.Amount.String())).
+		Require(msg.ProposalID >= 0, "proposal_id", ErrUnknownProposal(DefaultCodespace, msg.ProposalID)).
+		Error()
 }
 
 func (msg MsgDeposit) String() string {
@@ -208,29 +251,145 @@ func (msg MsgVote) Type() string  { return "vote" }
 
 // Implements Msg.
 func (msg MsgVote) ValidateBasic() sdk.Error {
+	return sdk.NewMsgValidator().
+		Address("voter", msg.Voter).
+		Require(msg.ProposalID >= 0, "proposal_id", ErrUnknownProposal(DefaultCodespace, msg.ProposalID)).
+		Require(validVoteOption(msg.Option), "option", ErrInvalidVote(DefaultCodespace, msg.Option)).
+		Error()
+}
+
+func (msg MsgVote) String() string {
+	return fmt.Sprintf("MsgVote{%v - %s}", msg.ProposalID, msg.Option)
+}
+
+// Implements Msg.
+func (msg MsgVote) Get(key interface{}) (value interface{}) {
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgVote) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgVote) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Voter}
+}
+
+func (msg MsgVote) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+//-----------------------------------------------------------
+// MsgVoteWeighted
+type MsgVoteWeighted struct {
+	ProposalID int64               `json:"proposal_id"` // ID of the proposal
+	Voter      sdk.AccAddress      `json:"voter"`       //  address of the voter
+	Options    WeightedVoteOptions `json:"options"`     //  options chosen by the voter with the weights of its voting power
+}
+
+func NewMsgVoteWeighted(voter sdk.AccAddress, proposalID int64, options WeightedVoteOptions) MsgVoteWeighted {
+	return MsgVoteWeighted{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Options:    options,
+	}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgVoteWeighted) Route() string { return MsgRoute }
+func (msg MsgVoteWeighted) Type() string  { return "vote_weighted" }
+
+// Implements Msg.
+func (msg MsgVoteWeighted) ValidateBasic() sdk.Error {
+	if len(msg.Voter) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("length of address(%s) should be %d", string(msg.Voter), sdk.AddrLen))
+	}
+	if msg.ProposalID < 0 {
+		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
+	}
+	return msg.Options.Validate(DefaultCodespace)
+}
+
+func (msg MsgVoteWeighted) String() string {
+	return fmt.Sprintf("MsgVoteWeighted{%v - %s}", msg.ProposalID, msg.Options)
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) Get(key interface{}) (value interface{}) {
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgVoteWeighted) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Voter}
+}
+
+func (msg MsgVoteWeighted) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+//-----------------------------------------------------------
+// MsgVoteChoice
+type MsgVoteChoice struct {
+	ProposalID int64          `json:"proposal_id"` // ID of the proposal
+	Voter      sdk.AccAddress `json:"voter"`       //  address of the voter
+	Choice     int64          `json:"choice"`      //  1-based index of the choice of the multiple choice proposal
+}
+
+func NewMsgVoteChoice(voter sdk.AccAddress, proposalID int64, choice int64) MsgVoteChoice {
+	return MsgVoteChoice{
+		ProposalID: proposalID,
+		Voter:      voter,
+		Choice:     choice,
+	}
+}
+
+// Implements Msg.
+// nolint
+func (msg MsgVoteChoice) Route() string { return MsgRoute }
+func (msg MsgVoteChoice) Type() string  { return "vote_choice" }
+
+// Implements Msg.
+func (msg MsgVoteChoice) ValidateBasic() sdk.Error {
 	if len(msg.Voter) != sdk.AddrLen {
 		return sdk.ErrInvalidAddress(fmt.Sprintf("length of address(%s) should be %d", string(msg.Voter), sdk.AddrLen))
 	}
 	if msg.ProposalID < 0 {
 		return ErrUnknownProposal(DefaultCodespace, msg.ProposalID)
 	}
-	if !validVoteOption(msg.Option) {
-		return ErrInvalidVote(DefaultCodespace, msg.Option)
+	if msg.Choice < 1 || msg.Choice > MaxChoices {
+		return ErrInvalidChoice(DefaultCodespace, fmt.Sprintf("choice %d is out of range [1, %d]", msg.Choice, MaxChoices))
 	}
 	return nil
 }
 
-func (msg MsgVote) String() string {
-	return fmt.Sprintf("MsgVote{%v - %s}", msg.ProposalID, msg.Option)
+func (msg MsgVoteChoice) String() string {
+	return fmt.Sprintf("MsgVoteChoice{%v - %d}", msg.ProposalID, msg.Choice)
 }
 
 // Implements Msg.
-func (msg MsgVote) Get(key interface{}) (value interface{}) {
+func (msg MsgVoteChoice) Get(key interface{}) (value interface{}) {
 	return nil
 }
 
 // Implements Msg.
-func (msg MsgVote) GetSignBytes() []byte {
+func (msg MsgVoteChoice) GetSignBytes() []byte {
 	b, err := msgCdc.MarshalJSON(msg)
 	if err != nil {
 		panic(err)
@@ -239,10 +398,10 @@ func (msg MsgVote) GetSignBytes() []byte {
 }
 
 // Implements Msg.
-func (msg MsgVote) GetSigners() []sdk.AccAddress {
+func (msg MsgVoteChoice) GetSigners() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.Voter}
 }
 
-func (msg MsgVote) GetInvolvedAddresses() []sdk.AccAddress {
+func (msg MsgVoteChoice) GetInvolvedAddresses() []sdk.AccAddress {
 	return msg.GetSigners()
 }
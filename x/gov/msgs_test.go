@@ -1,14 +1,17 @@
 package gov_test
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/gov/tags"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 )
 
@@ -115,6 +118,43 @@ func TestMsgVote(t *testing.T) {
 	}
 }
 
+func TestMsgVoteWeighted(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	half := sdk.NewDecWithPrec(5, 1)
+	tests := []struct {
+		proposalID int64
+		voterAddr  sdk.AccAddress
+		options    gov.WeightedVoteOptions
+		expectPass bool
+	}{
+		{0, addrs[0], gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, sdk.OneDec())}, true},
+		{0, addrs[0], gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, half), gov.NewWeightedVoteOption(gov.OptionNo, half)}, true},
+		{-1, addrs[0], gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, sdk.OneDec())}, false},
+		{0, sdk.AccAddress{1, 2}, gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, sdk.OneDec())}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, half)}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, half), gov.NewWeightedVoteOption(gov.OptionYes, half)}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, sdk.OneDec()), gov.NewWeightedVoteOption(gov.OptionNo, sdk.ZeroDec())}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, sdk.NewDec(2)), gov.NewWeightedVoteOption(gov.OptionNo, sdk.OneDec().Neg())}, false},
+		{0, addrs[0], gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.VoteOption(0x13), sdk.OneDec())}, false},
+	}
+
+	for i, tc := range tests {
+		msg := gov.NewMsgVoteWeighted(tc.voterAddr, tc.proposalID, tc.options)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	options, err := gov.WeightedVoteOptionsFromString("Yes=60000000, No=40000000")
+	require.Nil(t, err)
+	require.Equal(t, gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, sdk.NewDecWithPrec(6, 1)), gov.NewWeightedVoteOption(gov.OptionNo, sdk.NewDecWithPrec(4, 1))}, options)
+	_, err = gov.WeightedVoteOptionsFromString("Yes")
+	require.NotNil(t, err)
+}
+
 func TestMsgSideChainSubmitProposal(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
 	tests := []struct {
@@ -188,3 +228,90 @@ func TestMsgSideChainVote(t *testing.T) {
 		}
 	}
 }
+
+func TestMsgSubmitProposalChoices(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		proposalType gov.ProposalKind
+		choices      []string
+		expectPass   bool
+	}{
+		{gov.ProposalTypeMultipleChoice, []string{"low", "high"}, true},
+		{gov.ProposalTypeMultipleChoice, []string{"low"}, false},
+		{gov.ProposalTypeMultipleChoice, []string{"low", "low"}, false},
+		{gov.ProposalTypeMultipleChoice, []string{"low", ""}, false},
+		{gov.ProposalTypeText, []string{"low", "high"}, false},
+	}
+
+	for i, tc := range tests {
+		msg := gov.NewMsgSubmitProposal("Test", "desc", tc.proposalType, addrs[0], coinsPos, 1000*time.Second)
+		msg.Choices = tc.choices
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+
+	require.Nil(t, gov.NewMsgVoteChoice(addrs[0], 0, 1).ValidateBasic())
+	require.NotNil(t, gov.NewMsgVoteChoice(addrs[0], 0, 0).ValidateBasic())
+}
+
+func TestMsgSubmitEmergencyProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	msg := gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[0], coinsPos, time.Hour)
+	msg.Emergency = true
+	require.NotNil(t, msg.ValidateBasic(), "only channel permissions and circuit breakers are emergencies")
+
+	msg = gov.NewMsgSubmitProposal("Halt", `{"routes":["gov"],"halt":true}`, gov.ProposalTypeCircuitBreaker, addrs[0], coinsPos, time.Hour)
+	require.NotNil(t, msg.ValidateBasic(), "the gov route can not be halted")
+	msg.Description = `{"routes":["bank","bank"],"halt":true}`
+	require.NotNil(t, msg.ValidateBasic())
+	msg.Description = "halt bank"
+	require.NotNil(t, msg.ValidateBasic())
+	msg.Description = `{"routes":["bank"],"halt":true}`
+	msg.Emergency = true
+	require.Nil(t, msg.ValidateBasic())
+	msg.Expedited = true
+	require.NotNil(t, msg.ValidateBasic())
+}
+
+func TestSubmitProposalMetadata(t *testing.T) {
+	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	govHandler := gov.NewHandler(keeper)
+
+	metadata := "ipfs://QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG#sha256=" + strings.Repeat("0", 64)
+	msg := gov.NewMsgSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[0], coinsPos, time.Hour)
+	msg.Metadata = strings.Repeat("a", gov.MaxMetadataLength+1)
+	require.NotNil(t, msg.ValidateBasic())
+	sideMsg := gov.NewMsgSideChainSubmitProposal("Test", "test", gov.ProposalTypeText, addrs[0], coinsPos, time.Hour, "bsc")
+	sideMsg.Metadata = msg.Metadata
+	require.NotNil(t, sideMsg.ValidateBasic())
+
+	// the metadata params limit the length below the hard limit
+	msg.Metadata = strings.Repeat("a", gov.DefaultMaxMetadataLength+1)
+	require.Nil(t, msg.ValidateBasic())
+	res := govHandler(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(gov.DefaultCodespace, gov.CodeInvalidMetadata), res.Code)
+	require.NotNil(t, keeper.SetMetadataParams(ctx, gov.MetadataParams{MaxLength: gov.MaxMetadataLength + 1}))
+	require.Nil(t, keeper.SetMetadataParams(ctx, gov.MetadataParams{MaxLength: 64}))
+	msg.Metadata = metadata
+	res = govHandler(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(gov.DefaultCodespace, gov.CodeInvalidMetadata), res.Code)
+
+	require.Nil(t, keeper.SetMetadataParams(ctx, gov.MetadataParams{MaxLength: 256}))
+	res = govHandler(ctx, msg)
+	require.True(t, res.IsOK(), "%v", res)
+	proposalID, _ := strconv.Atoi(string(res.Data))
+	require.Equal(t, metadata, keeper.GetProposal(ctx, int64(proposalID)).GetMetadata())
+	found := false
+	for _, tag := range res.Tags {
+		if string(tag.Key) == tags.Metadata {
+			require.Equal(t, metadata, string(tag.Value))
+			found = true
+		}
+	}
+	require.True(t, found)
+}
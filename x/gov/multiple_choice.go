@@ -0,0 +1,162 @@
+package gov
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	MaxChoices      = 16  // maximum number of choices of a multiple choice proposal
+	MaxChoiceLength = 128 // maximum length of a choice of a multiple choice proposal
+)
+
+//-----------------------------------------------------------
+// Multiple Choice Proposals
+
+// MultipleChoiceProposal is voted by picking one of its choices instead of a yes/no option, e.g. to select
+// a fee schedule among several candidates. It passes with the choice that has the most voting power, once
+// the votes reach the quorum.
+type MultipleChoiceProposal struct {
+	TextProposal
+
+	Choices           []string          `json:"choices"`             //  Choices defined by the proposer
+	ChoiceTallyResult ChoiceTallyResult `json:"choice_tally_result"` //  Distribution of the voting power among the choices
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*MultipleChoiceProposal)(nil)
+
+// ChoiceTallyResult is the tally of a multiple choice proposal
+type ChoiceTallyResult struct {
+	Distribution  []sdk.Dec `json:"distribution"`   // voting power of each choice, in the order of the choices
+	Total         sdk.Dec   `json:"total"`          // total voting power of the bonded validators
+	WinningChoice int64     `json:"winning_choice"` // 1-based index of the choice with the most voting power, 0 if there is none
+}
+
+// EmptyChoiceTallyResult returns the tally of a multiple choice proposal with numChoices choices and no votes
+func EmptyChoiceTallyResult(numChoices int) ChoiceTallyResult {
+	distribution := make([]sdk.Dec, numChoices)
+	for i := range distribution {
+		distribution[i] = sdk.ZeroDec()
+	}
+	return ChoiceTallyResult{
+		Distribution: distribution,
+		Total:        sdk.ZeroDec(),
+	}
+}
+
+func validateChoices(codespace sdk.CodespaceType, choices []string) sdk.Error {
+	if len(choices) < 2 || len(choices) > MaxChoices {
+		return ErrInvalidChoice(codespace, fmt.Sprintf("a multiple choice proposal has between 2 and %d choices", MaxChoices))
+	}
+	seen := make(map[string]bool, len(choices))
+	for _, choice := range choices {
+		if len(choice) == 0 || len(choice) > MaxChoiceLength {
+			return ErrInvalidChoice(codespace, fmt.Sprintf("the length of a choice must be between 1 and %d", MaxChoiceLength))
+		}
+		if seen[choice] {
+			return ErrInvalidChoice(codespace, fmt.Sprintf("duplicate choice %s", choice))
+		}
+		seen[choice] = true
+	}
+	return nil
+}
+
+// Creates a new multiple choice proposal
+func (keeper Keeper) NewMultipleChoiceProposal(ctx sdk.Context, title string, description string, choices []string, votingPeriod time.Duration) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &MultipleChoiceProposal{
+		TextProposal: TextProposal{
+			ProposalID:   proposalID,
+			Title:        title,
+			Description:  description,
+			ProposalType: ProposalTypeMultipleChoice,
+			VotingPeriod: votingPeriod,
+			Status:       StatusDepositPeriod,
+			TallyResult:  EmptyTallyResult(),
+			TotalDeposit: sdk.Coins{},
+			SubmitTime:   ctx.BlockHeader().Time,
+		},
+		Choices:           choices,
+		ChoiceTallyResult: EmptyChoiceTallyResult(len(choices)),
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
+// Adds a vote for one of the choices of a multiple choice proposal
+func (keeper Keeper) AddChoiceVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress, choice int64) sdk.Error {
+	proposal := keeper.GetProposal(ctx, proposalID)
+	if proposal == nil {
+		return ErrUnknownProposal(keeper.codespace, proposalID)
+	}
+	if proposal.GetStatus() != StatusVotingPeriod {
+		return ErrInactiveProposal(keeper.codespace, proposalID)
+	}
+	mcProposal, ok := proposal.(*MultipleChoiceProposal)
+	if !ok {
+		return ErrInvalidChoice(keeper.codespace, fmt.Sprintf("proposal %d is not a multiple choice proposal", proposalID))
+	}
+	if choice < 1 || choice > int64(len(mcProposal.Choices)) {
+		return ErrInvalidChoice(keeper.codespace, fmt.Sprintf("choice %d is out of range [1, %d]", choice, len(mcProposal.Choices)))
+	}
+
+	vote := Vote{
+		ProposalID: proposalID,
+		Voter:      voterAddr,
+		Choice:     choice,
+	}
+	keeper.setVote(ctx, proposalID, voterAddr, vote)
+
+	return nil
+}
+
+// tallyChoiceVotes tallies a multiple choice proposal and records the distribution of the voting
+// power on the proposal, the returned TallyResult only holds the total voting power
+func tallyChoiceVotes(ctx sdk.Context, keeper Keeper, proposal *MultipleChoiceProposal) (passes bool, refundDeposits bool, tallyResults TallyResult, voters []sdk.ValAddress, votes []sdk.AccAddress) {
+	result := EmptyChoiceTallyResult(len(proposal.Choices))
+	totalVotingPower, voters, votes := tallyVotingPower(ctx, keeper, proposal, func(vote Vote, votingPower sdk.Dec) {
+		if vote.Choice >= 1 && vote.Choice <= int64(len(result.Distribution)) {
+			result.Distribution[vote.Choice-1] = result.Distribution[vote.Choice-1].Add(votingPower)
+		}
+	})
+
+	totalPower := keeper.totalPower(ctx, proposal.GetProposalID())
+	result.Total = totalPower
+	tallyResults = EmptyTallyResult()
+	tallyResults.Total = totalPower
+
+	// the winning choice is the one with the most voting power, there is none on a tie
+	var winningPower sdk.Dec
+	for i, power := range result.Distribution {
+		if !power.GT(sdk.ZeroDec()) {
+			continue
+		}
+		if result.WinningChoice == 0 || power.GT(winningPower) {
+			result.WinningChoice, winningPower = int64(i+1), power
+		} else if power.Equal(winningPower) {
+			result.WinningChoice = -1
+		}
+	}
+	if result.WinningChoice < 0 {
+		result.WinningChoice = 0
+	}
+	proposal.ChoiceTallyResult = result
+
+	// If there is no staked coins, the proposal fails
+	if totalPower.IsZero() {
+		return false, true, tallyResults, voters, votes
+	}
+	// If there is not enough quorum of votes, the proposal fails
+	if totalVotingPower.Quo(totalPower).LT(keeper.tallyParams(ctx, proposal).Quorum) {
+		return false, true, tallyResults, voters, votes
+	}
+	// If no choice has the most voting power, the proposal fails
+	return result.WinningChoice != 0, true, tallyResults, voters, votes
+}
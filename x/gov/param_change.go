@@ -0,0 +1,135 @@
+package gov
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// maximum number of parameters changed by a generic parameter change proposal
+const MaxParamChanges = 16
+
+//-----------------------------------------------------------
+// Generic Parameter Change
+
+// ParamChange sets the parameter key of a subspace of the params keeper to value, the JSON encoding of a
+// value of the type registered for key
+type ParamChange struct {
+	Subspace string `json:"subspace"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+}
+
+// ParamChangeSetting is the description of a generic parameter change proposal, its changes are all applied
+// once the proposal passes, or none of them is. Unlike the ParameterChange proposals handled by the param hub,
+// it changes any parameter registered in the params keeper. The proposals of a side chain only change its gov
+// parameters, e.g. its VetoParams.
+type ParamChangeSetting struct {
+	Changes []ParamChange `json:"changes"`
+}
+
+func (setting ParamChangeSetting) Check() error {
+	if len(setting.Changes) == 0 || len(setting.Changes) > MaxParamChanges {
+		return fmt.Errorf("a parameter change setting has between 1 and %d changes", MaxParamChanges)
+	}
+	seen := make(map[string]bool, len(setting.Changes))
+	for _, change := range setting.Changes {
+		if len(change.Subspace) == 0 {
+			return fmt.Errorf("the subspace of parameter %s is empty", change.Key)
+		}
+		if !validRoute(change.Key) {
+			return fmt.Errorf("invalid parameter key %q", change.Key)
+		}
+		if len(change.Value) == 0 {
+			return fmt.Errorf("the value of parameter %s/%s is empty", change.Subspace, change.Key)
+		}
+		id := change.Subspace + "/" + change.Key
+		if seen[id] {
+			return fmt.Errorf("duplicate parameter %s", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// parseParamChangeSetting parses the description of a generic parameter change proposal
+func parseParamChangeSetting(codespace sdk.CodespaceType, description string) (ParamChangeSetting, sdk.Error) {
+	var setting ParamChangeSetting
+	if err := msgCdc.UnmarshalJSON([]byte(description), &setting); err != nil {
+		return setting, ErrInvalidDescription(codespace, fmt.Sprintf("the description of a generic parameter change proposal is not a parameter change setting: %v", err))
+	}
+	if err := setting.Check(); err != nil {
+		return setting, ErrInvalidDescription(codespace, err.Error())
+	}
+	return setting, nil
+}
+
+// paramUpdate is a parameter change checked against the params keeper
+type paramUpdate struct {
+	subspace params.Subspace
+	key      []byte
+	value    interface{}
+}
+
+// checkParamChanges checks the changes of setting against the types registered in the params keeper, the
+// values with a Validate method are validated with it
+func (keeper Keeper) checkParamChanges(ctx sdk.Context, setting ParamChangeSetting) ([]paramUpdate, sdk.Error) {
+	updates := make([]paramUpdate, 0, len(setting.Changes))
+	for _, change := range setting.Changes {
+		if ctx.SideChainId() != "" && change.Subspace != keeper.paramSpace.Name() {
+			return nil, ErrInvalidDescription(keeper.codespace, fmt.Sprintf("only the %s parameters of a side chain can be changed", keeper.paramSpace.Name()))
+		}
+		subspace, ok := keeper.paramsKeeper.GetSubspace(change.Subspace)
+		if !ok {
+			return nil, ErrInvalidDescription(keeper.codespace, fmt.Sprintf("unknown parameter subspace %s", change.Subspace))
+		}
+		value, err := subspace.ParseParam([]byte(change.Key), []byte(change.Value))
+		if err != nil {
+			return nil, ErrInvalidDescription(keeper.codespace, err.Error())
+		}
+		if validated, ok := value.(interface{ Validate() error }); ok {
+			if err := validated.Validate(); err != nil {
+				return nil, ErrInvalidDescription(keeper.codespace, fmt.Sprintf("invalid parameter %s/%s: %v", change.Subspace, change.Key, err))
+			}
+		}
+		updates = append(updates, paramUpdate{subspace, []byte(change.Key), value})
+	}
+	return updates, nil
+}
+
+// applyParamChanges checks the changes of setting and applies them, none of them is applied if one is invalid
+func (keeper Keeper) applyParamChanges(ctx sdk.Context, setting ParamChangeSetting) sdk.Error {
+	updates, err := keeper.checkParamChanges(ctx, setting)
+	if err != nil {
+		return err
+	}
+	for _, update := range updates {
+		update.subspace.Set(ctx, update.key, update.value)
+	}
+	return nil
+}
+
+// executeParamChange applies the changes of a passed generic parameter change proposal, they are checked
+// again as the registered types may have changed since the proposal was submitted
+func executeParamChange(ctx sdk.Context, keeper Keeper, proposal Proposal) {
+	logger := ctx.Logger().With("module", "x/gov")
+	setting, err := parseParamChangeSetting(keeper.codespace, proposal.GetDescription())
+	if err != nil {
+		logger.Error("Get broken parameter change setting, will skip.", "proposalId", proposal.GetProposalID(), "err", err)
+		return
+	}
+	if err := keeper.applyParamChanges(ctx, setting); err != nil {
+		logger.Error("Get invalid parameter change, will skip.", "proposalId", proposal.GetProposalID(), "err", err)
+		return
+	}
+	proposal.SetStatus(StatusExecuted)
+	keeper.AppendAuditRecord(ctx, "", proposal, AuditActionGenericParamsChanged)
+
+	changed := make([]string, 0, len(setting.Changes))
+	for _, change := range setting.Changes {
+		changed = append(changed, change.Subspace+"/"+change.Key)
+	}
+	logger.Info(fmt.Sprintf("proposal %d changed the parameters %s", proposal.GetProposalID(), strings.Join(changed, ",")))
+}
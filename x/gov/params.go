@@ -1,6 +1,7 @@
 package gov
 
 import (
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -12,9 +13,189 @@ type DepositParams struct {
 	MaxDepositPeriod time.Duration `json:"max_deposit_period"` //  Maximum period for Atom holders to deposit on a proposal. Initial value: 2 months
 }
 
+// Validate checks the min deposit and the deposit period, it is called for the generic parameter changes too
+func (dp DepositParams) Validate() error {
+	if !dp.MinDeposit.IsValid() || !dp.MinDeposit.IsPositive() {
+		return fmt.Errorf("the min deposit should be valid positive coins")
+	}
+	if dp.MaxDepositPeriod <= 0 {
+		return fmt.Errorf("the max deposit period should be positive")
+	}
+	return nil
+}
+
+// Param around the voting period of the proposals of a side chain, each side chain has its own
+type VotingParams struct {
+	VotingPeriod time.Duration `json:"voting_period"` //  Voting period of the proposals submitted to the side chain, at most MaxVotingPeriod. Initial value: none, the proposals keep the voting period they are submitted with
+}
+
+// Validate checks the voting period, it is called for the generic parameter changes too
+func (vp VotingParams) Validate() error {
+	if vp.VotingPeriod < 0 || vp.VotingPeriod > MaxVotingPeriod {
+		return fmt.Errorf("the voting period should be in range 0 to %s", MaxVotingPeriod)
+	}
+	return nil
+}
+
 // Param around Tally votes in governance
 type TallyParams struct {
 	Quorum    sdk.Dec `json:"quorum"`    //  Minimum percentage of total stake needed to vote for a result to be considered valid. Initial value: 0.5
 	Threshold sdk.Dec `json:"threshold"` //  Minimum proportion of Yes votes for proposal to pass. Initial value: 0.5
 	Veto      sdk.Dec `json:"veto"`      //  Minimum value of Veto votes to Total votes ratio for proposal to be vetoed. Initial value: 1/3
 }
+
+// Param around the incentives of the validators voting on the proposals
+type VoteIncentiveParams struct {
+	Reward sdk.Coins `json:"reward"` //  Reward from the community pool of each validator that voted on a tallied proposal. Initial value: none, no incentive
+}
+
+// Param overriding the deposit and tally params of the proposals of a type, e.g. to require a higher
+// quorum for side chain param changes than for text proposals. The params of a side chain are kept
+// in the side chain store, so they only apply to the proposals of that chain.
+type ProposalTypeParams struct {
+	ProposalType ProposalKind `json:"proposal_type"` //  Type of the proposals the params apply to
+	MinDeposit   sdk.Coins    `json:"min_deposit"`   //  Minimum deposit for a proposal of the type to enter voting period.
+	TallyParams  TallyParams  `json:"tally_params"`  //  Quorum, threshold and veto of the proposals of the type
+}
+
+// Param around the expedited proposals, which are voted faster with a higher deposit, quorum and threshold
+type ExpeditedParams struct {
+	MinDeposit   sdk.Coins     `json:"min_deposit"`   //  Minimum deposit for an expedited proposal to enter voting period.
+	VotingPeriod time.Duration `json:"voting_period"` //  Length of the expedited voting period. Initial value: none, expedited proposals are disabled
+	Quorum       sdk.Dec       `json:"quorum"`        //  Minimum percentage of total stake needed to vote for an expedited result to be considered valid.
+	Threshold    sdk.Dec       `json:"threshold"`     //  Minimum proportion of Yes votes for an expedited proposal to pass.
+}
+
+// Enabled tells whether expedited proposals can be submitted
+func (ep ExpeditedParams) Enabled() bool {
+	return ep.VotingPeriod > 0
+}
+
+// Param around the emergency proposals, which pause cross chain channels or halt msg routes. They are voted by
+// the bonded validators during a very short voting period and need a supermajority to pass.
+type EmergencyParams struct {
+	MinDeposit   sdk.Coins     `json:"min_deposit"`   //  Minimum deposit for an emergency proposal to enter voting period.
+	VotingPeriod time.Duration `json:"voting_period"` //  Length of the emergency voting period, e.g. 1 hour. Initial value: none, emergency proposals are disabled
+	Quorum       sdk.Dec       `json:"quorum"`        //  Minimum percentage of total stake needed to vote for an emergency result to be considered valid.
+	Threshold    sdk.Dec       `json:"threshold"`     //  Minimum proportion of Yes votes for an emergency proposal to pass, e.g. 2/3.
+}
+
+// Enabled tells whether emergency proposals can be submitted
+func (ep EmergencyParams) Enabled() bool {
+	return ep.VotingPeriod > 0
+}
+
+// default limit of the metadata length, applied while the metadata params are unset
+const DefaultMaxMetadataLength = 256
+
+// Param around the metadata of the proposals, the off-chain reference to the full proposal
+type MetadataParams struct {
+	MaxLength int `json:"max_length"` //  Maximum length of the metadata of a proposal, at most MaxMetadataLength. Initial value: DefaultMaxMetadataLength
+}
+
+// actions on the deposits of a vetoed proposal
+const (
+	VetoDepositDistribute    = "distribute"     // the deposits are sent to the block proposer, like the ones of the other rejected proposals
+	VetoDepositBurn          = "burn"           // the deposits are burnt
+	VetoDepositCommunityPool = "community_pool" // the deposits are sent to the community pool
+	VetoDepositRefund        = "refund"         // the deposits are refunded to the depositers
+)
+
+// Param around the deposits of the vetoed proposals, each side chain has its own
+type VetoParams struct {
+	DepositAction string `json:"deposit_action"` //  Action on the deposits of a vetoed proposal. Initial value: VetoDepositDistribute
+}
+
+// Validate checks the deposit action, it is called for the generic parameter changes too
+func (vp VetoParams) Validate() error {
+	switch vp.DepositAction {
+	case VetoDepositDistribute, VetoDepositBurn, VetoDepositCommunityPool, VetoDepositRefund:
+		return nil
+	}
+	return fmt.Errorf("unknown veto deposit action %q", vp.DepositAction)
+}
+
+// Param around the deposits of the proposals dropped at the end of their deposit period without reaching the min
+// deposit, each side chain has its own
+type ExpiredDepositParams struct {
+	Refund bool `json:"refund"` //  Whether the deposits of a dropped proposal are refunded to the depositers. Initial value: false, the deposits are distributed to the block proposer
+}
+
+// minimum retention period of the finished proposals, so that the modules consuming the passed proposals, e.g.
+// the param hub at the breathe blocks, see them before they are pruned
+const MinRetentionPeriod = 7 * 24 * time.Hour
+
+// Param around the pruning of the finished proposals, each side chain has its own
+type PruningParams struct {
+	RetentionPeriod time.Duration `json:"retention_period"` //  Period a finished proposal is kept in full after the end of its voting period, 0 or at least MinRetentionPeriod. Initial value: 0, the proposals are never pruned
+}
+
+// Validate checks the retention period, it is called for the generic parameter changes too
+func (pp PruningParams) Validate() error {
+	if pp.RetentionPeriod != 0 && pp.RetentionPeriod < MinRetentionPeriod {
+		return fmt.Errorf("the retention period should be 0 or at least %s", MinRetentionPeriod)
+	}
+	return nil
+}
+
+// maximum execution delay of the passed proposals of a type
+const MaxExecutionDelay = 30 * 24 * time.Hour
+
+// Param around the delay between the passing of a proposal and its execution, so that a passed proposal can
+// still be cancelled by an emergency CancelExecution proposal, each side chain has its own
+type ExecutionDelayParams struct {
+	Delays []ProposalTypeDelay `json:"delays"` //  Execution delays of some proposal types, at most one per type. Initial value: none, the passed proposals are executed at once
+}
+
+// Execution delay of the passed proposals of a type
+type ProposalTypeDelay struct {
+	ProposalType ProposalKind  `json:"proposal_type"` //  Type of the proposals the delay applies to
+	Delay        time.Duration `json:"delay"`         //  Delay between the passing of a proposal of the type and its execution, at most MaxExecutionDelay
+}
+
+// Delay returns the execution delay of the passed proposals of a type, 0 if they are executed at once
+func (ep ExecutionDelayParams) Delay(proposalType ProposalKind) time.Duration {
+	for _, delay := range ep.Delays {
+		if delay.ProposalType == proposalType {
+			return delay.Delay
+		}
+	}
+	return 0
+}
+
+// Validate checks the delays, it is called for the generic parameter changes too
+func (ep ExecutionDelayParams) Validate() error {
+	seen := make(map[ProposalKind]bool, len(ep.Delays))
+	for _, delay := range ep.Delays {
+		if !validProposalType(delay.ProposalType) && !validSideProposalType(delay.ProposalType) {
+			return fmt.Errorf("invalid proposal type %s", delay.ProposalType)
+		}
+		if delay.ProposalType == ProposalTypeCancelExecution {
+			return fmt.Errorf("the execution of the %s proposals can not be delayed", delay.ProposalType)
+		}
+		if seen[delay.ProposalType] {
+			return fmt.Errorf("the execution delay of proposal type %s is set twice", delay.ProposalType)
+		}
+		seen[delay.ProposalType] = true
+		if delay.Delay < 0 || delay.Delay > MaxExecutionDelay {
+			return fmt.Errorf("the execution delay of proposal type %s should be in range 0 to %s", delay.ProposalType, MaxExecutionDelay)
+		}
+	}
+	return nil
+}
+
+// Param around the results of the side chain proposals pushed to the side chain in cross chain packages once
+// they pass, so that the side chain gets them without a relayer polling the proposals
+type ResultRelayParams struct {
+	ProposalTypes []ProposalKind `json:"proposal_types"` //  Types of the side chain proposals whose results are pushed. Initial value: none, the results are not pushed
+}
+
+// Relayed tells whether the result of the proposals of a type is pushed to the side chain
+func (rp ResultRelayParams) Relayed(proposalType ProposalKind) bool {
+	for _, pt := range rp.ProposalTypes {
+		if pt == proposalType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,211 @@
+package gov
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//-----------------------------------------------------------
+// Voting power snapshot
+
+// Once GovPowerSnapshot is upgraded, the voting power of the bonded validators of a side chain is recorded when the
+// voting period of a proposal starts, and the proposal is tallied against it, so that the stake moved during the
+// voting period does not change the result. The delegations are snapshotted lazily: the shares a delegation had at
+// the start of the voting period are recorded the first time it changes, through the staking hooks of the keeper.
+// The proposals whose voting period started before the upgrade have no snapshot and are tallied with the live
+// voting power.
+
+// ValidatorPower is the voting power of a bonded validator in a snapshot
+type ValidatorPower struct {
+	Operator        sdk.ValAddress `json:"operator"`
+	Power           sdk.Dec        `json:"power"`
+	DelegatorShares sdk.Dec        `json:"delegator_shares"`
+}
+
+// PowerSnapshot is the voting power of a side chain at the start of the voting period of a proposal
+type PowerSnapshot struct {
+	TotalPower sdk.Dec          `json:"total_power"`
+	Validators []ValidatorPower `json:"validators"`
+}
+
+// delegationShares are the shares of a delegation of a voter, as tallied
+type delegationShares struct {
+	ValidatorAddr sdk.ValAddress
+	Shares        sdk.Dec
+}
+
+// snapshotVotingPower records the voting power of the bonded validators for a side chain proposal whose voting
+// period starts
+func (keeper Keeper) snapshotVotingPower(ctx sdk.Context, proposal Proposal) {
+	if ctx.SideChainId() == "" || !ctx.UpgradeMgr().IsUpgrade(sdk.GovPowerSnapshot) {
+		return
+	}
+	snapshot := PowerSnapshot{
+		TotalPower: keeper.vs.TotalPower(ctx),
+		Validators: make([]ValidatorPower, 0),
+	}
+	keeper.vs.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
+		snapshot.Validators = append(snapshot.Validators, ValidatorPower{
+			Operator:        validator.GetOperator(),
+			Power:           validator.GetPower(),
+			DelegatorShares: validator.GetDelegatorShares(),
+		})
+		return false
+	})
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(KeyPowerSnapshot(proposal.GetProposalID()), keeper.cdc.MustMarshalBinaryLengthPrefixed(snapshot))
+}
+
+// GetPowerSnapshot returns the voting power snapshot of a proposal, if it is tallied against one
+func (keeper Keeper) GetPowerSnapshot(ctx sdk.Context, proposalID int64) (PowerSnapshot, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyPowerSnapshot(proposalID))
+	if bz == nil {
+		return PowerSnapshot{}, false
+	}
+	var snapshot PowerSnapshot
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &snapshot)
+	return snapshot, true
+}
+
+// deletePowerSnapshot deletes the voting power snapshot of a proposal and the delegations recorded for it
+func (keeper Keeper) deletePowerSnapshot(ctx sdk.Context, proposalID int64) {
+	store := ctx.KVStore(keeper.storeKey)
+	if !store.Has(KeyPowerSnapshot(proposalID)) {
+		return
+	}
+	store.Delete(KeyPowerSnapshot(proposalID))
+
+	iterator := sdk.KVStorePrefixIterator(store, KeyDelegationSnapshotsSubspace(proposalID, nil))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// totalPower returns the total voting power a proposal is tallied against
+func (keeper Keeper) totalPower(ctx sdk.Context, proposalID int64) sdk.Dec {
+	if snapshot, ok := keeper.GetPowerSnapshot(ctx, proposalID); ok {
+		return snapshot.TotalPower
+	}
+	return keeper.vs.TotalPower(ctx)
+}
+
+// recordDelegation records the current shares of a delegation, zero if it does not exist, for every snapshotted
+// proposal it was not recorded for yet. It is called before the delegation changes.
+func (keeper Keeper) recordDelegation(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, KeyPowerSnapshots)
+	var proposalIDs []int64
+	for ; iterator.Valid(); iterator.Next() {
+		var proposalID int64
+		if _, err := fmt.Sscanf(string(iterator.Key()[len(KeyPowerSnapshots):]), "%d", &proposalID); err == nil {
+			proposalIDs = append(proposalIDs, proposalID)
+		}
+	}
+	iterator.Close()
+	if len(proposalIDs) == 0 {
+		return
+	}
+
+	shares := sdk.ZeroDec()
+	if delegation := keeper.vs.Delegation(ctx, delAddr, valAddr); delegation != nil {
+		shares = delegation.GetShares()
+	}
+	for _, proposalID := range proposalIDs {
+		key := KeyDelegationSnapshot(proposalID, delAddr, valAddr)
+		if !store.Has(key) {
+			store.Set(key, keeper.cdc.MustMarshalBinaryLengthPrefixed(shares))
+		}
+	}
+}
+
+// voterDelegations returns the delegations of a voter a proposal is tallied with, sorted by validator
+func (keeper Keeper) voterDelegations(ctx sdk.Context, proposalID int64, snapshotted bool, voter sdk.AccAddress) []delegationShares {
+	delegations := make([]delegationShares, 0)
+	keeper.ds.IterateDelegations(ctx, voter, func(index int64, delegation sdk.Delegation) (stop bool) {
+		delegations = append(delegations, delegationShares{delegation.GetValidatorAddr(), delegation.GetShares()})
+		return false
+	})
+	if !snapshotted {
+		return delegations
+	}
+
+	// the shares recorded at the start of the voting period override the live ones
+	store := ctx.KVStore(keeper.storeKey)
+	prefix := KeyDelegationSnapshotsSubspace(proposalID, voter)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+	recorded := make(map[string]sdk.Dec)
+	for ; iterator.Valid(); iterator.Next() {
+		var shares sdk.Dec
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &shares)
+		recorded[string(iterator.Key()[len(prefix):])] = shares
+	}
+	if len(recorded) == 0 {
+		return delegations
+	}
+
+	result := make([]delegationShares, 0, len(delegations))
+	for _, delegation := range delegations {
+		if _, ok := recorded[string(delegation.ValidatorAddr)]; !ok {
+			result = append(result, delegation)
+		}
+	}
+	for valAddr, shares := range recorded {
+		if shares.GT(sdk.ZeroDec()) {
+			result = append(result, delegationShares{sdk.ValAddress(valAddr), shares})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return bytes.Compare(result[i].ValidatorAddr, result[j].ValidatorAddr) < 0
+	})
+	return result
+}
+
+//_________________________________________________________________________________________
+
+// Hooks record the delegations changed during the voting period of the snapshotted proposals, they must be
+// combined into the staking hooks of the app
+type Hooks struct {
+	k Keeper
+}
+
+var _ sdk.StakingHooks = Hooks{}
+
+// Return the wrapper struct
+func (keeper Keeper) Hooks() Hooks {
+	return Hooks{keeper}
+}
+
+// Implements sdk.StakingHooks
+func (h Hooks) OnDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.k.recordDelegation(ctx, delAddr, valAddr)
+}
+
+// Implements sdk.StakingHooks
+func (h Hooks) OnDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.k.recordDelegation(ctx, delAddr, valAddr)
+}
+
+// Implements sdk.StakingHooks
+func (h Hooks) OnDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.k.recordDelegation(ctx, delAddr, valAddr)
+}
+
+// nolint - unused hooks
+func (h Hooks) OnValidatorCreated(_ sdk.Context, _ sdk.ValAddress)                           {}
+func (h Hooks) OnValidatorModified(_ sdk.Context, _ sdk.ValAddress)                          {}
+func (h Hooks) OnValidatorRemoved(_ sdk.Context, _ sdk.ValAddress)                           {}
+func (h Hooks) OnValidatorBonded(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress)         {}
+func (h Hooks) OnValidatorBeginUnbonding(_ sdk.Context, _ sdk.ConsAddress, _ sdk.ValAddress) {}
+func (h Hooks) OnSideChainValidatorBonded(_ sdk.Context, _ []byte, _ sdk.ValAddress)         {}
+func (h Hooks) OnSideChainValidatorBeginUnbonding(_ sdk.Context, _ []byte, _ sdk.ValAddress) {}
+func (h Hooks) OnSelfDelDropBelowMin(_ sdk.Context, _ sdk.ValAddress)                        {}
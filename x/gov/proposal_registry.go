@@ -0,0 +1,95 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//-----------------------------------------------------------
+// Registered proposal types
+
+// ProposalValidator checks a proposal of a registered type when it is submitted, on the native chain or on a side
+// chain, see ctx.SideChainId(). The proposal is rejected if an error is returned.
+type ProposalValidator func(ctx sdk.Context, proposal Proposal) sdk.Error
+
+// ProposalExecutor applies the effects of a passed proposal of a registered type, on a cache of ctx which is
+// written only if no error is returned
+type ProposalExecutor func(ctx sdk.Context, proposal Proposal) sdk.Error
+
+type proposalTypeHandler struct {
+	name      string
+	sideChain bool
+	validator ProposalValidator
+	executor  ProposalExecutor
+}
+
+var proposalTypeHandlers = make(map[ProposalKind]proposalTypeHandler)
+
+// RegisterProposalTypeHandler adds a proposal type handled by another module, so that it can be submitted without
+// changing the gov package. name is the name of the type in the messages and the queries, the proposals of the type
+// can be submitted on the side chains too if sideChain is set. validator and executor may be nil. It panics if the
+// type or its name is already used, and must be called before the app starts.
+func RegisterProposalTypeHandler(kind ProposalKind, name string, sideChain bool, validator ProposalValidator, executor ProposalExecutor) {
+	if kind == ProposalTypeNil || kind.String() != "" {
+		panic(fmt.Sprintf("proposal type %d is already used", byte(kind)))
+	}
+	if name == "" {
+		panic(fmt.Sprintf("proposal type %d has no name", byte(kind)))
+	}
+	if _, err := ProposalTypeFromString(name); err == nil {
+		panic(fmt.Sprintf("proposal type name %s is already used", name))
+	}
+	proposalTypeHandlers[kind] = proposalTypeHandler{
+		name:      name,
+		sideChain: sideChain,
+		validator: validator,
+		executor:  executor,
+	}
+}
+
+// registeredProposalType returns the handler of a registered proposal type
+func registeredProposalType(pt ProposalKind) (proposalTypeHandler, bool) {
+	handler, ok := proposalTypeHandlers[pt]
+	return handler, ok
+}
+
+// registeredProposalTypeFromString returns the registered proposal type of a name
+func registeredProposalTypeFromString(str string) (ProposalKind, bool) {
+	for kind, handler := range proposalTypeHandlers {
+		if handler.name == str {
+			return kind, true
+		}
+	}
+	return ProposalTypeNil, false
+}
+
+// validateRegisteredProposal checks a submitted proposal with the validator of its type, if it is registered
+func validateRegisteredProposal(ctx sdk.Context, proposal Proposal) sdk.Error {
+	handler, ok := registeredProposalType(proposal.GetProposalType())
+	if !ok || handler.validator == nil {
+		return nil
+	}
+	return handler.validator(ctx, proposal)
+}
+
+// executeRegisteredProposal executes a passed proposal of a registered type with the executor of its type
+func executeRegisteredProposal(ctx sdk.Context, keeper Keeper, chainId string, proposal Proposal) {
+	handler, ok := registeredProposalType(proposal.GetProposalType())
+	if !ok || handler.executor == nil {
+		return
+	}
+	logger := ctx.Logger().With("module", "x/gov")
+
+	cacheCtx, write := ctx.CacheContext()
+	if err := handler.executor(cacheCtx, proposal); err != nil {
+		logger.Error("Failed to execute proposal, will skip.", "proposalId", proposal.GetProposalID(),
+			"proposalType", handler.name, "err", err)
+		return
+	}
+	write()
+	proposal.SetStatus(StatusExecuted)
+	keeper.AppendAuditRecord(ctx, chainId, proposal, AuditActionRegisteredTypeExecuted)
+
+	logger.Info(fmt.Sprintf("proposal %d of type %s executed", proposal.GetProposalID(), handler.name))
+}
@@ -42,6 +42,18 @@ type Proposal interface {
 
 	GetVotingPeriod() time.Duration
 	SetVotingPeriod(time.Duration)
+
+	GetExpedited() bool
+	SetExpedited(bool)
+
+	GetRegularVotingPeriod() time.Duration
+	SetRegularVotingPeriod(time.Duration)
+
+	GetEmergency() bool
+	SetEmergency(bool)
+
+	GetMetadata() string
+	SetMetadata(string)
 }
 
 // checks if two proposals are equal
@@ -55,7 +67,11 @@ func ProposalEqual(proposalA Proposal, proposalB Proposal) bool {
 		proposalA.GetSubmitTime().Equal(proposalB.GetSubmitTime()) &&
 		proposalA.GetTotalDeposit().IsEqual(proposalB.GetTotalDeposit()) &&
 		proposalA.GetVotingStartTime().Equal(proposalB.GetVotingStartTime()) &&
-		proposalA.GetVotingPeriod() == proposalB.GetVotingPeriod() {
+		proposalA.GetVotingPeriod() == proposalB.GetVotingPeriod() &&
+		proposalA.GetExpedited() == proposalB.GetExpedited() &&
+		proposalA.GetRegularVotingPeriod() == proposalB.GetRegularVotingPeriod() &&
+		proposalA.GetEmergency() == proposalB.GetEmergency() &&
+		proposalA.GetMetadata() == proposalB.GetMetadata() {
 		return true
 	}
 	return false
@@ -77,6 +93,11 @@ type TextProposal struct {
 	TotalDeposit sdk.Coins `json:"total_deposit"` //  Current deposit on this proposal. Initial value is set at InitialDeposit
 
 	VotingStartTime time.Time `json:"voting_start_time"` //  Height of the block where MinDeposit was reached. -1 if MinDeposit is not reached
+
+	Expedited           bool          `json:"expedited,omitempty"`             //  Whether the proposal is voted with the expedited params
+	RegularVotingPeriod time.Duration `json:"regular_voting_period,omitempty"` //  Voting period of the proposal once converted to a regular one if the expedited vote fails
+	Emergency           bool          `json:"emergency,omitempty"`             //  Whether the proposal is voted by the validators with the emergency params
+	Metadata            string        `json:"metadata,omitempty"`              //  Off-chain reference to the full proposal, e.g. an IPFS or URL reference and its checksum
 }
 
 // Implements Proposal Interface
@@ -107,6 +128,18 @@ func (tp TextProposal) GetVotingPeriod() time.Duration { return tp.VotingPeriod
 func (tp *TextProposal) SetVotingPeriod(votingPeriod time.Duration) {
 	tp.VotingPeriod = votingPeriod
 }
+func (tp TextProposal) GetExpedited() bool           { return tp.Expedited }
+func (tp *TextProposal) SetExpedited(expedited bool) { tp.Expedited = expedited }
+func (tp TextProposal) GetRegularVotingPeriod() time.Duration {
+	return tp.RegularVotingPeriod
+}
+func (tp *TextProposal) SetRegularVotingPeriod(votingPeriod time.Duration) {
+	tp.RegularVotingPeriod = votingPeriod
+}
+func (tp TextProposal) GetEmergency() bool           { return tp.Emergency }
+func (tp *TextProposal) SetEmergency(emergency bool) { tp.Emergency = emergency }
+func (tp TextProposal) GetMetadata() string          { return tp.Metadata }
+func (tp *TextProposal) SetMetadata(metadata string) { tp.Metadata = metadata }
 
 //-----------------------------------------------------------
 // ProposalQueue
@@ -131,6 +164,12 @@ const (
 	ProposalTypeRemoveValidator      ProposalKind = 0x07
 	ProposalTypeDelistTradingPair    ProposalKind = 0x08
 	ProposalTypeManageChanPermission ProposalKind = 0x09
+	ProposalTypeMultipleChoice       ProposalKind = 0x0A
+	ProposalTypeCircuitBreaker       ProposalKind = 0x0B
+	ProposalTypeGenericParamChange   ProposalKind = 0x0C
+	ProposalTypeCancelExecution      ProposalKind = 0x0D
+	ProposalTypeBatch                ProposalKind = 0x0E
+	ProposalTypeForceUndelegation    ProposalKind = 0x0F
 )
 
 // String to proposalType byte.  Returns ff if invalid.
@@ -158,7 +197,22 @@ func ProposalTypeFromString(str string) (ProposalKind, error) {
 		return ProposalTypeCSCParamsChange, nil
 	case "ManageChanPermission":
 		return ProposalTypeManageChanPermission, nil
+	case "MultipleChoice":
+		return ProposalTypeMultipleChoice, nil
+	case "CircuitBreaker":
+		return ProposalTypeCircuitBreaker, nil
+	case "GenericParamChange":
+		return ProposalTypeGenericParamChange, nil
+	case "CancelExecution":
+		return ProposalTypeCancelExecution, nil
+	case "Batch":
+		return ProposalTypeBatch, nil
+	case "ForceUndelegation":
+		return ProposalTypeForceUndelegation, nil
 	default:
+		if pt, ok := registeredProposalTypeFromString(str); ok {
+			return pt, nil
+		}
 		return ProposalKind(0xff), errors.Errorf("'%s' is not a valid proposal type", str)
 	}
 }
@@ -173,10 +227,17 @@ func validProposalType(pt ProposalKind) bool {
 		pt == ProposalTypeCreateValidator ||
 		pt == ProposalTypeRemoveValidator ||
 		pt == ProposalTypeDelistTradingPair ||
-		pt == ProposalTypeManageChanPermission {
+		pt == ProposalTypeManageChanPermission ||
+		pt == ProposalTypeMultipleChoice ||
+		pt == ProposalTypeCircuitBreaker ||
+		pt == ProposalTypeGenericParamChange ||
+		pt == ProposalTypeCancelExecution ||
+		pt == ProposalTypeBatch ||
+		pt == ProposalTypeForceUndelegation {
 		return true
 	}
-	return false
+	_, ok := registeredProposalType(pt)
+	return ok
 }
 
 // Marshal needed for protobuf compatibility
@@ -236,7 +297,22 @@ func (pt ProposalKind) String() string {
 		return "CSCParamsChange"
 	case ProposalTypeManageChanPermission:
 		return "ManageChanPermission"
+	case ProposalTypeMultipleChoice:
+		return "MultipleChoice"
+	case ProposalTypeCircuitBreaker:
+		return "CircuitBreaker"
+	case ProposalTypeGenericParamChange:
+		return "GenericParamChange"
+	case ProposalTypeCancelExecution:
+		return "CancelExecution"
+	case ProposalTypeBatch:
+		return "Batch"
+	case ProposalTypeForceUndelegation:
+		return "ForceUndelegation"
 	default:
+		if handler, ok := registeredProposalType(pt); ok {
+			return handler.name
+		}
 		return ""
 	}
 }
@@ -267,6 +343,10 @@ const (
 	StatusPassed        ProposalStatus = 0x03
 	StatusRejected      ProposalStatus = 0x04
 	StatusExecuted      ProposalStatus = 0x05
+	// a passed proposal waiting for the execution delay of its type, see ExecutionDelayParams
+	StatusPendingExecution ProposalStatus = 0x06
+	// a pending proposal cancelled by a CancelExecution proposal, it is never executed
+	StatusCancelled ProposalStatus = 0x07
 )
 
 // ProposalStatusToString turns a string into a ProposalStatus
@@ -282,6 +362,10 @@ func ProposalStatusFromString(str string) (ProposalStatus, error) {
 		return StatusRejected, nil
 	case "Executed":
 		return StatusExecuted, nil
+	case "PendingExecution":
+		return StatusPendingExecution, nil
+	case "Cancelled":
+		return StatusCancelled, nil
 	case "":
 		return StatusNil, nil
 	default:
@@ -295,7 +379,9 @@ func validProposalStatus(status ProposalStatus) bool {
 		status == StatusVotingPeriod ||
 		status == StatusPassed ||
 		status == StatusRejected ||
-		status == StatusExecuted {
+		status == StatusExecuted ||
+		status == StatusPendingExecution ||
+		status == StatusCancelled {
 		return true
 	}
 	return false
@@ -346,6 +432,10 @@ func (status ProposalStatus) String() string {
 		return "Rejected"
 	case StatusExecuted:
 		return "Executed"
+	case StatusPendingExecution:
+		return "PendingExecution"
+	case StatusCancelled:
+		return "Cancelled"
 	default:
 		return ""
 	}
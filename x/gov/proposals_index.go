@@ -0,0 +1,111 @@
+package gov
+
+import (
+	"bytes"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//-----------------------------------------------------------
+// Side Chain Proposals Index
+
+// IndexedProposal is the entry of a side chain proposal in the index kept on the native chain, so that the
+// proposals of all the side chains are listed without switching to the store of each side chain
+type IndexedProposal struct {
+	SideChainId     string         `json:"side_chain_id"`
+	ProposalID      int64          `json:"proposal_id"`
+	Title           string         `json:"title"`
+	ProposalType    ProposalKind   `json:"proposal_type"`
+	Status          ProposalStatus `json:"proposal_status"`
+	TallyResult     TallyResult    `json:"tally_result"`
+	VotingStartTime time.Time      `json:"voting_start_time"`
+	Metadata        string         `json:"metadata,omitempty"`
+}
+
+func newIndexedProposal(sideChainId string, proposal Proposal) IndexedProposal {
+	return IndexedProposal{
+		SideChainId:     sideChainId,
+		ProposalID:      proposal.GetProposalID(),
+		Title:           proposal.GetTitle(),
+		ProposalType:    proposal.GetProposalType(),
+		Status:          proposal.GetStatus(),
+		TallyResult:     proposal.GetTallyResult(),
+		VotingStartTime: proposal.GetVotingStartTime(),
+		Metadata:        proposal.GetMetadata(),
+	}
+}
+
+// sideChainIdOf returns the id of the side chain whose store ctx is prefixed with, false for the native chain
+func (keeper Keeper) sideChainIdOf(ctx sdk.Context) (string, bool) {
+	prefix := ctx.SideChainKeyPrefix()
+	if len(prefix) == 0 {
+		return "", false
+	}
+	if ctx.SideChainId() != "" {
+		return ctx.SideChainId(), true
+	}
+	if keeper.ScKeeper == nil {
+		return "", false
+	}
+	sideChainIDs, storePrefixes := keeper.ScKeeper.GetAllSideChainPrefixes(ctx)
+	for i := range storePrefixes {
+		if bytes.Equal(storePrefixes[i], prefix) {
+			return sideChainIDs[i], true
+		}
+	}
+	return "", false
+}
+
+// indexProposal updates the entry of proposal in the index when it is a side chain proposal
+func (keeper Keeper) indexProposal(ctx sdk.Context, proposal Proposal) {
+	sideChainId, ok := keeper.sideChainIdOf(ctx)
+	if !ok {
+		return
+	}
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(newIndexedProposal(sideChainId, proposal))
+	store.Set(KeyIndexedProposal(sideChainId, proposal.GetProposalID()), bz)
+}
+
+// unindexProposal removes the entry of proposal from the index when it is a side chain proposal
+func (keeper Keeper) unindexProposal(ctx sdk.Context, proposal Proposal) {
+	sideChainId, ok := keeper.sideChainIdOf(ctx)
+	if !ok {
+		return
+	}
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	store.Delete(KeyIndexedProposal(sideChainId, proposal.GetProposalID()))
+}
+
+// GetIndexedProposals returns the entries of the side chain proposals, by side chain and proposal id.
+// Only the proposals of sideChainId are returned unless it is empty.
+func (keeper Keeper) GetIndexedProposals(ctx sdk.Context, sideChainId string) []IndexedProposal {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, KeyIndexedProposalsSubspace(sideChainId))
+	defer iterator.Close()
+
+	proposals := make([]IndexedProposal, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var proposal IndexedProposal
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &proposal)
+		proposals = append(proposals, proposal)
+	}
+	return proposals
+}
+
+// RebuildProposalIndex indexes all the proposals of the side chains, it is meant to be called once at the
+// upgrade the index is introduced, the proposals written since then are indexed as they are set
+func (keeper Keeper) RebuildProposalIndex(ctx sdk.Context) {
+	if keeper.ScKeeper == nil {
+		return
+	}
+	sideChainIDs, storePrefixes := keeper.ScKeeper.GetAllSideChainPrefixes(ctx)
+	for i := range storePrefixes {
+		scCtx := ctx.WithSideChainKeyPrefix(storePrefixes[i]).WithSideChainId(sideChainIDs[i])
+		keeper.Iterate(scCtx, nil, nil, StatusNil, 0, false, func(proposal Proposal) bool {
+			keeper.indexProposal(scCtx, proposal)
+			return false
+		})
+	}
+}
@@ -11,8 +11,10 @@ const (
 func validSideProposalType(pt ProposalKind) bool {
 	if pt == ProposalTypeText ||
 		pt == ProposalTypeSCParamsChange ||
-		pt == ProposalTypeCSCParamsChange {
+		pt == ProposalTypeCSCParamsChange ||
+		pt == ProposalTypeGenericParamChange {
 		return true
 	}
-	return false
+	handler, ok := registeredProposalType(pt)
+	return ok && handler.sideChain
 }
@@ -0,0 +1,141 @@
+package gov
+
+import (
+	"fmt"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov/events"
+)
+
+// maximum number of proposal ids examined for pruning per block and chain
+const MaxPrunedProposalsPerBlock = 100
+
+//-----------------------------------------------------------
+// Pruning
+
+// The finished proposals of a chain are pruned once the retention period of its PruningParams is over since the
+// end of their voting period: each of them is replaced by its archived record, and its votes and settled deposits
+// are deleted. The proposal ids are examined in order from the oldest proposal not pruned yet, so a proposal still
+// in flight delays the pruning of the proposals submitted more than MaxPrunedProposalsPerBlock ids after it.
+
+// finished tells whether a proposal is settled for good, i.e. it can not be voted, executed or cancelled anymore
+func finished(proposal Proposal) bool {
+	switch proposal.GetStatus() {
+	case StatusPassed, StatusRejected, StatusExecuted, StatusCancelled:
+		return true
+	}
+	return false
+}
+
+// GetArchivedProposal returns the archived record of a pruned proposal
+func (keeper Keeper) GetArchivedProposal(ctx sdk.Context, proposalID int64) (ArchivedProposal, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyArchivedProposal(proposalID))
+	if bz == nil {
+		return ArchivedProposal{}, false
+	}
+	var archived ArchivedProposal
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &archived)
+	return archived, true
+}
+
+// GetArchivedProposals returns the archived records of all the pruned proposals, ordered by proposal id
+func (keeper Keeper) GetArchivedProposals(ctx sdk.Context) []ArchivedProposal {
+	store := ctx.KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, KeyArchivedProposals)
+	defer iterator.Close()
+
+	proposals := make([]ArchivedProposal, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var archived ArchivedProposal
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &archived)
+		proposals = append(proposals, archived)
+	}
+	return proposals
+}
+
+func (keeper Keeper) getPruneCursor(ctx sdk.Context) int64 {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyPruneCursor)
+	if bz == nil {
+		return 0
+	}
+	var cursor int64
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &cursor)
+	return cursor
+}
+
+func (keeper Keeper) setPruneCursor(ctx sdk.Context, cursor int64) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(KeyPruneCursor, keeper.cdc.MustMarshalBinaryLengthPrefixed(cursor))
+}
+
+// PruneProposal replaces a finished proposal by its archived record, and deletes its votes and settled deposits
+func (keeper Keeper) PruneProposal(ctx sdk.Context, chainID string, proposal Proposal) ArchivedProposal {
+	store := ctx.KVStore(keeper.storeKey)
+	proposalID := proposal.GetProposalID()
+
+	archived := newArchivedProposal(chainID, proposal)
+	votesIterator := keeper.GetVotes(ctx, proposalID)
+	for ; votesIterator.Valid(); votesIterator.Next() {
+		store.Delete(votesIterator.Key())
+		archived.NumVotes++
+	}
+	votesIterator.Close()
+
+	settledIterator := sdk.KVStorePrefixIterator(store, KeySettledDepositsSubspace(proposalID))
+	for ; settledIterator.Valid(); settledIterator.Next() {
+		store.Delete(settledIterator.Key())
+	}
+	settledIterator.Close()
+
+	store.Set(KeyArchivedProposal(proposalID), keeper.cdc.MustMarshalBinaryLengthPrefixed(archived))
+	keeper.DeleteProposal(ctx, proposal)
+	return archived
+}
+
+// pruneProposals prunes the finished proposals of the chain of ctx whose retention period is over
+func pruneProposals(ctx sdk.Context, keeper Keeper, chainId string) sdk.Events {
+	resEvents := sdk.EmptyEvents()
+	retentionPeriod := keeper.GetPruningParams(ctx).RetentionPeriod
+	if retentionPeriod <= 0 {
+		return resEvents
+	}
+	maxProposalID, err := keeper.peekCurrentProposalID(ctx)
+	if err != nil {
+		return resEvents
+	}
+
+	cursor := keeper.getPruneCursor(ctx)
+	newCursor, contiguous := cursor, true
+	for proposalID := cursor; proposalID < maxProposalID && proposalID < cursor+MaxPrunedProposalsPerBlock; proposalID++ {
+		// the proposals dropped in deposit period are deleted already
+		done := true
+		if proposal := keeper.GetProposal(ctx, proposalID); proposal != nil {
+			votingEndTime := proposal.GetVotingStartTime().Add(proposal.GetVotingPeriod())
+			done = finished(proposal) && !ctx.BlockHeader().Time.Before(votingEndTime.Add(retentionPeriod))
+			if done {
+				keeper.PruneProposal(ctx, chainId, proposal)
+
+				event := sdk.NewEvent(events.EventTypeProposalPruned, sdk.NewAttribute(events.ProposalID,
+					strconv.FormatInt(proposalID, 10)))
+				if chainId != NativeChainID {
+					event = event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
+				}
+				resEvents = resEvents.AppendEvent(event)
+			}
+		}
+		contiguous = contiguous && done
+		if contiguous {
+			newCursor = proposalID + 1
+		}
+	}
+	if newCursor != cursor {
+		keeper.setPruneCursor(ctx, newCursor)
+	}
+	if len(resEvents) > 0 {
+		ctx.Logger().With("module", "x/gov").Info(fmt.Sprintf("pruned %d finished proposals", len(resEvents)), "chain", chainId)
+	}
+	return resEvents
+}
@@ -1,6 +1,9 @@
 package gov
 
 import (
+	"bytes"
+	"fmt"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -17,6 +20,21 @@ const (
 	QueryVotes     = "votes"
 	QueryVote      = "vote"
 	QueryTally     = "tally"
+	QueryAuditLog  = "auditLog"
+	QueryArchive   = "archive"
+
+	QueryHaltedRoutes      = "haltedRoutes"
+	QueryIndexedProposals  = "indexedProposals"
+	QuerySettledDeposits   = "settledDeposits"
+	QueryTallySimulation   = "tallySimulation"
+	QueryArchivedProposals = "archivedProposals"
+	QueryVoterHistory      = "voterHistory"
+	QueryInvariants        = "invariants"
+
+	// MaxAuditRecordsPerQuery bounds the records returned by an audit log query
+	MaxAuditRecordsPerQuery = 100
+	// MaxResultsPerPage bounds the results of a page of a paginated query
+	MaxResultsPerPage = 100
 )
 
 func NewQuerier(keeper Keeper) sdk.Querier {
@@ -43,6 +61,20 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 				return res, err
 			}
 			return queryDeposits(ctx, path[1:], req, p, keeper)
+		case QuerySettledDeposits:
+			p := new(QuerySettledDepositsParams)
+			ctx, err = RequestPrepare(ctx, keeper, req, p)
+			if err != nil {
+				return res, err
+			}
+			return querySettledDeposits(ctx, p, keeper)
+		case QueryArchivedProposals:
+			p := new(QueryArchivedProposalsParams)
+			ctx, err = RequestPrepare(ctx, keeper, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryArchivedProposals(ctx, p, keeper)
 		case QueryDeposit:
 			p := new(QueryDepositParams)
 			ctx, err = RequestPrepare(ctx, keeper, req, p)
@@ -71,6 +103,43 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 				return res, err
 			}
 			return queryTally(ctx, path[1:], req, p, keeper)
+		case QueryTallySimulation:
+			p := new(QueryTallySimulationParams)
+			ctx, err = RequestPrepare(ctx, keeper, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryTallySimulation(ctx, p, keeper)
+		case QueryAuditLog:
+			p := new(QueryAuditLogParams)
+			if len(req.Data) != 0 {
+				if err2 := keeper.cdc.UnmarshalJSON(req.Data, p); err2 != nil {
+					return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("can not unmarshal request", err2.Error()))
+				}
+			}
+			return queryAuditLog(ctx, path[1:], req, p, keeper)
+		case QueryArchive:
+			return queryArchive(ctx, keeper)
+		case QueryHaltedRoutes:
+			return queryHaltedRoutes(ctx, keeper)
+		case QueryInvariants:
+			return queryInvariants(ctx, keeper)
+		case QueryIndexedProposals:
+			p := new(QueryIndexedProposalsParams)
+			if len(req.Data) != 0 {
+				if err2 := keeper.cdc.UnmarshalJSON(req.Data, p); err2 != nil {
+					return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("can not unmarshal request", err2.Error()))
+				}
+			}
+			return queryIndexedProposals(ctx, p, keeper)
+		case QueryVoterHistory:
+			p := new(QueryVoterHistoryParams)
+			if len(req.Data) != 0 {
+				if err2 := keeper.cdc.UnmarshalJSON(req.Data, p); err2 != nil {
+					return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("can not unmarshal request", err2.Error()))
+				}
+			}
+			return queryVoterHistory(ctx, p, keeper)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown gov query endpoint")
 		}
@@ -132,76 +201,222 @@ func queryVote(ctx sdk.Context, path []string, req abci.RequestQuery, params *Qu
 	return bz, nil
 }
 
-// Params for query 'custom/gov/deposits'
+// Params for query 'custom/gov/deposits', the deposits are paginated if PageParams is set
 type QueryDepositsParams struct {
 	BaseParams
 	ProposalID int64
+	PageParams
+}
+
+// PagedDeposits is the answer to a paginated deposits query
+type PagedDeposits struct {
+	Deposits []Deposit `json:"deposits"`
+	NextKey  []byte    `json:"next_key"` // key to query the next page with, empty after the last page
 }
 
 // nolint: unparam
 func queryDeposits(ctx sdk.Context, path []string, req abci.RequestQuery, params *QueryDepositsParams, keeper Keeper) (res []byte, err sdk.Error) {
 	var deposits []Deposit
-	depositsIterator := keeper.GetDeposits(ctx, params.ProposalID)
-	defer depositsIterator.Close()
-	for ; depositsIterator.Valid(); depositsIterator.Next() {
-		deposit := Deposit{}
-		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(depositsIterator.Value(), &deposit)
-		deposits = append(deposits, deposit)
+	var result interface{} = &deposits
+	if params.Paginated() {
+		page := PagedDeposits{Deposits: []Deposit{}}
+		page.NextKey, err = params.iterate(ctx.KVStore(keeper.storeKey), KeyDepositsSubspace(params.ProposalID), func(value []byte) {
+			deposit := Deposit{}
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(value, &deposit)
+			page.Deposits = append(page.Deposits, deposit)
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = page
+	} else {
+		depositsIterator := keeper.GetDeposits(ctx, params.ProposalID)
+		defer depositsIterator.Close()
+		for ; depositsIterator.Valid(); depositsIterator.Next() {
+			deposit := Deposit{}
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(depositsIterator.Value(), &deposit)
+			deposits = append(deposits, deposit)
+		}
 	}
 
-	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, deposits)
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
 	if err2 != nil {
 		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
 	}
 	return bz, nil
 }
 
-// Params for query 'custom/gov/votes'
+// Params for query 'custom/gov/tallySimulation', the quorum and the threshold left nil are the current ones
+type QueryTallySimulationParams struct {
+	BaseParams
+	Quorum    *sdk.Dec
+	Threshold *sdk.Dec
+	NumLatest int // number of the last tallied proposals simulated, at most MaxResultsPerPage
+}
+
+// nolint: unparam
+func queryTallySimulation(ctx sdk.Context, params *QueryTallySimulationParams, keeper Keeper) (res []byte, err sdk.Error) {
+	numLatest := params.NumLatest
+	if numLatest <= 0 || numLatest > MaxResultsPerPage {
+		numLatest = MaxResultsPerPage
+	}
+	simulations := SimulateTallyParams(ctx, keeper, params.Quorum, params.Threshold, numLatest)
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, simulations)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/gov/settledDeposits', the settled deposits are always paginated
+type QuerySettledDepositsParams struct {
+	BaseParams
+	ProposalID int64
+	PageParams
+}
+
+// PagedSettledDeposits is the answer to a settled deposits query
+type PagedSettledDeposits struct {
+	Deposits []SettledDeposit `json:"deposits"`
+	NextKey  []byte           `json:"next_key"` // key to query the next page with, empty after the last page
+}
+
+func querySettledDeposits(ctx sdk.Context, params *QuerySettledDepositsParams, keeper Keeper) (res []byte, err sdk.Error) {
+	page := PagedSettledDeposits{Deposits: []SettledDeposit{}}
+	page.NextKey, err = params.iterate(ctx.KVStore(keeper.storeKey), KeySettledDepositsSubspace(params.ProposalID), func(value []byte) {
+		settled := SettledDeposit{}
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(value, &settled)
+		page.Deposits = append(page.Deposits, settled)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, page)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/gov/archivedProposals', the archived proposals are always paginated
+type QueryArchivedProposalsParams struct {
+	BaseParams
+	PageParams
+}
+
+// PagedArchivedProposals is the answer to an archived proposals query
+type PagedArchivedProposals struct {
+	Proposals []ArchivedProposal `json:"proposals"`
+	NextKey   []byte             `json:"next_key"` // key to query the next page with, empty after the last page
+}
+
+func queryArchivedProposals(ctx sdk.Context, params *QueryArchivedProposalsParams, keeper Keeper) (res []byte, err sdk.Error) {
+	page := PagedArchivedProposals{Proposals: []ArchivedProposal{}}
+	page.NextKey, err = params.iterate(ctx.KVStore(keeper.storeKey), KeyArchivedProposals, func(value []byte) {
+		archived := ArchivedProposal{}
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(value, &archived)
+		page.Proposals = append(page.Proposals, archived)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, page)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/gov/votes', the votes are paginated if PageParams is set
 type QueryVotesParams struct {
 	BaseParams
 	ProposalID int64
+	PageParams
+}
+
+// PagedVotes is the answer to a paginated votes query
+type PagedVotes struct {
+	Votes   []Vote `json:"votes"`
+	NextKey []byte `json:"next_key"` // key to query the next page with, empty after the last page
 }
 
 // nolint: unparam
 func queryVotes(ctx sdk.Context, path []string, req abci.RequestQuery, params *QueryVotesParams, keeper Keeper) (res []byte, err sdk.Error) {
 	var votes []Vote
-	votesIterator := keeper.GetVotes(ctx, params.ProposalID)
-	defer votesIterator.Close()
-	for ; votesIterator.Valid(); votesIterator.Next() {
-		vote := Vote{}
-		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(votesIterator.Value(), &vote)
-		votes = append(votes, vote)
+	var result interface{} = &votes
+	if params.Paginated() {
+		page := PagedVotes{Votes: []Vote{}}
+		page.NextKey, err = params.iterate(ctx.KVStore(keeper.storeKey), KeyVotesSubspace(params.ProposalID), func(value []byte) {
+			vote := Vote{}
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(value, &vote)
+			page.Votes = append(page.Votes, vote)
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = page
+	} else {
+		votesIterator := keeper.GetVotes(ctx, params.ProposalID)
+		defer votesIterator.Close()
+		for ; votesIterator.Valid(); votesIterator.Next() {
+			vote := Vote{}
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(votesIterator.Value(), &vote)
+			votes = append(votes, vote)
+		}
 	}
 
-	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, votes)
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
 	if err2 != nil {
 		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
 	}
 	return bz, nil
 }
 
-// Params for query 'custom/gov/proposals'
+// Params for query 'custom/gov/proposals', the matching proposals are paginated if PageParams is set
 type QueryProposalsParams struct {
 	BaseParams
 	Voter              sdk.AccAddress
 	Depositer          sdk.AccAddress
 	ProposalStatus     ProposalStatus
 	NumLatestProposals int64
+	PageParams
+}
+
+// PagedProposals is the answer to a paginated proposals query
+type PagedProposals struct {
+	Proposals []Proposal `json:"proposals"`
+	NextKey   []byte     `json:"next_key"` // key to query the next page with, empty after the last page
 }
 
 // nolint: unparam
 func queryProposals(ctx sdk.Context, path []string, req abci.RequestQuery, params *QueryProposalsParams, keeper Keeper) (res []byte, err sdk.Error) {
+	var result interface{}
+	if params.Paginated() {
+		page := PagedProposals{Proposals: []Proposal{}}
+		page.NextKey, err = iterateProposalsPage(ctx, keeper, params, func(proposal Proposal) {
+			page.Proposals = append(page.Proposals, proposal)
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = page
+	} else {
+		result = keeper.GetProposalsFiltered(ctx, params.Voter, params.Depositer, params.ProposalStatus, params.NumLatestProposals)
+	}
 
-	proposals := keeper.GetProposalsFiltered(ctx, params.Voter, params.Depositer, params.ProposalStatus, params.NumLatestProposals)
-
-	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, proposals)
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
 	if err2 != nil {
 		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
 	}
 	return bz, nil
 }
 
-// Params for query 'custom/gov/tally'
+// Params for query 'custom/gov/tally'. The tally of a proposal in its voting period is computed at the
+// height of the query, with the votes and the voting power of that height, so the support of a proposal
+// can be charted over its voting period as long as the heights are not pruned.
 type QueryTallyParams struct {
 	BaseParams
 	ProposalID int64
@@ -215,11 +430,15 @@ func queryTally(ctx sdk.Context, path []string, req abci.RequestQuery, params *Q
 		return nil, ErrUnknownProposal(DefaultCodespace, params.ProposalID)
 	}
 
+	if mcProposal, ok := proposal.(*MultipleChoiceProposal); ok {
+		return queryChoiceTally(ctx, keeper, mcProposal)
+	}
+
 	var tallyResult TallyResult
 
 	if proposal.GetStatus() == StatusDepositPeriod {
 		tallyResult = EmptyTallyResult()
-	} else if proposal.GetStatus() == StatusPassed || proposal.GetStatus() == StatusRejected {
+	} else if proposal.GetStatus() != StatusVotingPeriod {
 		tallyResult = proposal.GetTallyResult()
 	} else {
 		_, _, tallyResult = Tally(ctx, keeper, proposal)
@@ -232,6 +451,22 @@ func queryTally(ctx sdk.Context, path []string, req abci.RequestQuery, params *Q
 	return bz, nil
 }
 
+// queryChoiceTally answers a tally query on a multiple choice proposal with its ChoiceTallyResult
+func queryChoiceTally(ctx sdk.Context, keeper Keeper, proposal *MultipleChoiceProposal) (res []byte, err sdk.Error) {
+	switch proposal.GetStatus() {
+	case StatusDepositPeriod:
+		proposal.ChoiceTallyResult = EmptyChoiceTallyResult(len(proposal.Choices))
+	case StatusVotingPeriod:
+		Tally(ctx, keeper, proposal)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, proposal.ChoiceTallyResult)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
 func RequestPrepare(ctx sdk.Context, k Keeper, req abci.RequestQuery, p SideChainIder) (newCtx sdk.Context, err sdk.Error) {
 	if req.Data == nil || len(req.Data) == 0 {
 		return ctx, nil
@@ -258,6 +493,146 @@ func prepareSideChainCtx(ctx sdk.Context, k Keeper, sideChainId string) (sdk.Con
 	return scCtx, nil
 }
 
+// Params for query 'custom/gov/auditLog', the log of all the chains is kept on the native chain
+type QueryAuditLogParams struct {
+	FromSequence int64
+	// Limit defaults to MaxAuditRecordsPerQuery
+	Limit int
+}
+
+// queryAuditLog returns the records of the audit log from params.FromSequence, the
+// records are followed by querying again from the sequence after the last one returned
+// nolint: unparam
+func queryAuditLog(ctx sdk.Context, path []string, req abci.RequestQuery, params *QueryAuditLogParams, keeper Keeper) (res []byte, err sdk.Error) {
+	if params.FromSequence < 0 {
+		return nil, sdk.ErrUnknownRequest("from sequence should not be negative")
+	}
+	limit := params.Limit
+	if limit <= 0 || limit > MaxAuditRecordsPerQuery {
+		limit = MaxAuditRecordsPerQuery
+	}
+
+	records := keeper.GetAuditRecords(ctx, params.FromSequence, limit)
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, records)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
+func queryArchive(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
+	archive, ok := keeper.GetArchive(ctx)
+	if !ok {
+		return nil, sdk.ErrUnknownRequest("the proposals are not archived")
+	}
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, archive)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
+// nolint: unparam
+func queryHaltedRoutes(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, keeper.GetHaltedRoutes(ctx))
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
+// nolint: unparam
+func queryInvariants(ctx sdk.Context, keeper Keeper) (res []byte, err sdk.Error) {
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, keeper.CheckInvariants(ctx))
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/gov/indexedProposals', the index of the proposals of all the side chains is kept on the native chain
+type QueryIndexedProposalsParams struct {
+	// SideChainId selects the proposals of a side chain, of all the side chains if empty
+	SideChainId string
+	PageParams
+}
+
+// PagedIndexedProposals is the answer to a paginated indexed proposals query
+type PagedIndexedProposals struct {
+	Proposals []IndexedProposal `json:"proposals"`
+	NextKey   []byte            `json:"next_key"` // key to query the next page with, empty after the last page
+}
+
+func queryIndexedProposals(ctx sdk.Context, params *QueryIndexedProposalsParams, keeper Keeper) (res []byte, err sdk.Error) {
+	var result interface{}
+	if params.Paginated() {
+		page := PagedIndexedProposals{Proposals: []IndexedProposal{}}
+		store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+		page.NextKey, err = params.iterate(store, KeyIndexedProposalsSubspace(params.SideChainId), func(value []byte) {
+			var proposal IndexedProposal
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(value, &proposal)
+			page.Proposals = append(page.Proposals, proposal)
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = page
+	} else {
+		result = keeper.GetIndexedProposals(ctx, params.SideChainId)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/gov/voterHistory', the history of the votes on all the chains is kept on the native chain
+type QueryVoterHistoryParams struct {
+	Voter sdk.AccAddress
+	// ProposalStatus selects the votes on the proposals with this status, all of them if StatusNil
+	ProposalStatus ProposalStatus
+	PageParams
+}
+
+// PagedVoterHistory is the answer to a paginated voter history query
+type PagedVoterHistory struct {
+	Votes   []VoterHistoryEntry `json:"votes"`
+	NextKey []byte              `json:"next_key"` // key to query the next page with, empty after the last page
+}
+
+func queryVoterHistory(ctx sdk.Context, params *QueryVoterHistoryParams, keeper Keeper) (res []byte, err sdk.Error) {
+	if params.Voter.Empty() {
+		return nil, sdk.ErrUnknownRequest("the voter of a voter history query is required")
+	}
+	var result interface{}
+	if params.Paginated() {
+		page := PagedVoterHistory{Votes: []VoterHistoryEntry{}}
+		store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+		// fn is called with the value just matched, whose entry is kept
+		var entry VoterHistoryEntry
+		page.NextKey, err = params.iterateMatching(store, KeyVoterHistorySubspace(params.Voter), func(value []byte) bool {
+			entry = keeper.voterHistoryEntry(ctx, value)
+			return params.ProposalStatus == StatusNil || entry.Status == params.ProposalStatus
+		}, func(value []byte) {
+			page.Votes = append(page.Votes, entry)
+		})
+		if err != nil {
+			return nil, err
+		}
+		result = page
+	} else {
+		result = keeper.GetVoterHistory(ctx, params.Voter, params.ProposalStatus)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, result)
+	if err2 != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err2.Error()))
+	}
+	return bz, nil
+}
+
 type BaseParams struct {
 	SideChainId string
 }
@@ -275,3 +650,101 @@ func NewBaseParams(sideChainId string) BaseParams {
 		SideChainId: sideChainId,
 	}
 }
+
+// PageParams paginates the results of the votes, deposits and proposals queries. A page is
+// either selected by its number or by the NextKey returned with the previous page, which
+// takes precedence and is not affected by the results added since the previous page.
+type PageParams struct {
+	Page    int    // 1-based page number
+	Limit   int    // results per page, defaults to MaxResultsPerPage
+	NextKey []byte // key of the first result, as returned with the previous page
+}
+
+// Paginated tells whether the results are to be paginated
+func (p PageParams) Paginated() bool {
+	return p.Page > 0 || p.Limit > 0 || len(p.NextKey) > 0
+}
+
+func (p PageParams) limit() int {
+	if p.Limit <= 0 || p.Limit > MaxResultsPerPage {
+		return MaxResultsPerPage
+	}
+	return p.Limit
+}
+
+// skipped returns the number of results before the page, when it is selected by its number
+func (p PageParams) skipped() int {
+	if len(p.NextKey) > 0 || p.Page <= 1 {
+		return 0
+	}
+	return (p.Page - 1) * p.limit()
+}
+
+// iterate calls fn with the values of the page of the entries under prefix and returns the
+// key of the first entry of the next page, or nil after the last page
+func (p PageParams) iterate(store sdk.KVStore, prefix []byte, fn func(value []byte)) ([]byte, sdk.Error) {
+	return p.iterateMatching(store, prefix, nil, fn)
+}
+
+// iterateMatching is iterate over the entries under prefix whose values match, all of them if match is nil
+func (p PageParams) iterateMatching(store sdk.KVStore, prefix []byte, match func(value []byte) bool, fn func(value []byte)) ([]byte, sdk.Error) {
+	start := prefix
+	if len(p.NextKey) > 0 {
+		if !bytes.HasPrefix(p.NextKey, prefix) {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid next key %X", p.NextKey))
+		}
+		start = p.NextKey
+	}
+	iterator := store.Iterator(start, sdk.PrefixEndBytes(prefix))
+	defer iterator.Close()
+
+	// next moves the iterator to the first matching entry from its position
+	next := func() {
+		for match != nil && iterator.Valid() && !match(iterator.Value()) {
+			iterator.Next()
+		}
+	}
+	next()
+	for skipped := p.skipped(); skipped > 0 && iterator.Valid(); skipped-- {
+		iterator.Next()
+		next()
+	}
+	for count := 0; count < p.limit() && iterator.Valid(); count++ {
+		fn(iterator.Value())
+		iterator.Next()
+		next()
+	}
+	if !iterator.Valid() {
+		return nil, nil
+	}
+	return append([]byte{}, iterator.Key()...), nil
+}
+
+// iterateProposalsPage calls fn with the page of the proposals matching the filters of params
+// and returns the key of the first proposal of the next page, or nil after the last page
+func iterateProposalsPage(ctx sdk.Context, keeper Keeper, params *QueryProposalsParams, fn func(Proposal)) ([]byte, sdk.Error) {
+	var startID int64
+	if len(params.NextKey) > 0 {
+		if _, err := fmt.Sscanf(string(params.NextKey), "proposals:%d", &startID); err != nil || !bytes.Equal(params.NextKey, KeyProposal(startID)) {
+			return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid next key %X", params.NextKey))
+		}
+	}
+	skipped, limit := params.skipped(), params.limit()
+	var nextKey []byte
+	keeper.Iterate(ctx, params.Voter, params.Depositer, params.ProposalStatus, params.NumLatestProposals, false, func(proposal Proposal) bool {
+		switch {
+		case proposal.GetProposalID() < startID:
+			return false
+		case skipped > 0:
+			skipped--
+			return false
+		case limit == 0:
+			nextKey = KeyProposal(proposal.GetProposalID())
+			return true
+		}
+		fn(proposal)
+		limit--
+		return false
+	})
+	return nextKey, nil
+}
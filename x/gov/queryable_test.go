@@ -0,0 +1,211 @@
+package gov_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+func TestPaginatedQueries(t *testing.T) {
+	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 5)
+	SortAddresses(addrs)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	var proposalIDs []int64
+	for i := 0; i < 3; i++ {
+		proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+		proposal.SetStatus(gov.StatusVotingPeriod)
+		keeper.SetProposal(ctx, proposal)
+		proposalIDs = append(proposalIDs, proposal.GetProposalID())
+	}
+	for _, addr := range addrs {
+		require.Nil(t, keeper.AddVote(ctx, proposalIDs[0], addr, gov.OptionYes))
+	}
+
+	cdc := codec.New()
+	gov.RegisterCodec(cdc)
+	querier := gov.NewQuerier(keeper)
+	query := func(path string, params interface{}, result interface{}) sdk.Error {
+		bz, err := cdc.MarshalJSON(params)
+		require.NoError(t, err)
+		res, sdkErr := querier(ctx, []string{path}, abci.RequestQuery{Data: bz})
+		if sdkErr == nil {
+			require.NoError(t, cdc.UnmarshalJSON(res, result))
+		}
+		return sdkErr
+	}
+
+	// the results are not paginated without page params
+	var votes []gov.Vote
+	require.Nil(t, query(gov.QueryVotes, gov.QueryVotesParams{ProposalID: proposalIDs[0]}, &votes))
+	require.Len(t, votes, 5)
+
+	// follow the next keys
+	params := gov.QueryVotesParams{ProposalID: proposalIDs[0], PageParams: gov.PageParams{Limit: 2}}
+	var followed []gov.Vote
+	for pages := 1; ; pages++ {
+		var page gov.PagedVotes
+		require.Nil(t, query(gov.QueryVotes, params, &page))
+		followed = append(followed, page.Votes...)
+		if len(page.NextKey) == 0 {
+			require.Equal(t, 3, pages)
+			break
+		}
+		params.NextKey = page.NextKey
+	}
+	require.Equal(t, votes, followed)
+
+	// select a page by its number
+	var page gov.PagedVotes
+	params = gov.QueryVotesParams{ProposalID: proposalIDs[0], PageParams: gov.PageParams{Page: 2, Limit: 2}}
+	require.Nil(t, query(gov.QueryVotes, params, &page))
+	require.Equal(t, votes[2:4], page.Votes)
+	params.Page = 4
+	page = gov.PagedVotes{}
+	require.Nil(t, query(gov.QueryVotes, params, &page))
+	require.Empty(t, page.Votes)
+	require.Empty(t, page.NextKey)
+
+	// the next key must be under the votes of the proposal
+	params = gov.QueryVotesParams{ProposalID: proposalIDs[0], PageParams: gov.PageParams{NextKey: gov.KeyVotesSubspace(proposalIDs[1])}}
+	require.NotNil(t, query(gov.QueryVotes, params, &page))
+
+	var proposals gov.PagedProposals
+	proposalsParams := gov.QueryProposalsParams{PageParams: gov.PageParams{Limit: 2}}
+	require.Nil(t, query(gov.QueryProposals, proposalsParams, &proposals))
+	require.Len(t, proposals.Proposals, 2)
+	require.Equal(t, gov.KeyProposal(proposalIDs[2]), proposals.NextKey)
+	proposalsParams.NextKey = proposals.NextKey
+	proposals = gov.PagedProposals{}
+	require.Nil(t, query(gov.QueryProposals, proposalsParams, &proposals))
+	require.Len(t, proposals.Proposals, 1)
+	require.Equal(t, proposalIDs[2], proposals.Proposals[0].GetProposalID())
+	require.Empty(t, proposals.NextKey)
+
+	// the filters are applied before paginating
+	proposalsParams = gov.QueryProposalsParams{Voter: addrs[0], PageParams: gov.PageParams{Limit: 2}}
+	proposals = gov.PagedProposals{}
+	require.Nil(t, query(gov.QueryProposals, proposalsParams, &proposals))
+	require.Len(t, proposals.Proposals, 1)
+	require.Empty(t, proposals.NextKey)
+
+	proposalsParams.NextKey = []byte("votes:1:")
+	require.NotNil(t, query(gov.QueryProposals, proposalsParams, &proposals))
+}
+
+func TestIndexedProposals(t *testing.T) {
+	mapp, _, keeper, _, _, _, _ := getMockApp(t, 0)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	// the proposals of the native chain are not indexed
+	keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	require.Empty(t, keeper.GetIndexedProposals(ctx, ""))
+
+	bscCtx := ctx.WithSideChainKeyPrefix([]byte{0x01}).WithSideChainId("bsc")
+	abcCtx := ctx.WithSideChainKeyPrefix([]byte{0x02}).WithSideChainId("abc")
+	require.Nil(t, keeper.SetInitialProposalID(bscCtx, 1))
+	require.Nil(t, keeper.SetInitialProposalID(abcCtx, 1))
+	bscProposal := keeper.NewTextProposal(bscCtx, "Test", "description", gov.ProposalTypeSCParamsChange, 1000*time.Second)
+	keeper.NewTextProposal(bscCtx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	abcProposal := keeper.NewTextProposal(abcCtx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+
+	indexed := keeper.GetIndexedProposals(ctx, "")
+	require.Len(t, indexed, 3)
+	require.Equal(t, "abc", indexed[0].SideChainId)
+	require.Equal(t, "bsc", indexed[1].SideChainId)
+	require.Equal(t, gov.ProposalTypeSCParamsChange, indexed[1].ProposalType)
+	require.Len(t, keeper.GetIndexedProposals(ctx, "bsc"), 2)
+
+	// the entries follow the proposals
+	bscProposal.SetStatus(gov.StatusPassed)
+	bscProposal.SetTallyResult(gov.TallyResult{Yes: sdk.NewDecWithoutFra(10), Abstain: sdk.ZeroDec(), No: sdk.ZeroDec(), NoWithVeto: sdk.ZeroDec(), Total: sdk.NewDecWithoutFra(10)})
+	keeper.SetProposal(bscCtx, bscProposal)
+	indexed = keeper.GetIndexedProposals(ctx, "bsc")
+	require.Equal(t, gov.StatusPassed, indexed[0].Status)
+	require.Equal(t, bscProposal.GetTallyResult(), indexed[0].TallyResult)
+	keeper.DeleteProposal(abcCtx, abcProposal)
+	require.Empty(t, keeper.GetIndexedProposals(ctx, "abc"))
+
+	cdc := codec.New()
+	gov.RegisterCodec(cdc)
+	querier := gov.NewQuerier(keeper)
+	bz, err := cdc.MarshalJSON(gov.QueryIndexedProposalsParams{PageParams: gov.PageParams{Limit: 1}})
+	require.NoError(t, err)
+	res, sdkErr := querier(ctx, []string{gov.QueryIndexedProposals}, abci.RequestQuery{Data: bz})
+	require.Nil(t, sdkErr)
+	var page gov.PagedIndexedProposals
+	require.NoError(t, cdc.UnmarshalJSON(res, &page))
+	require.Len(t, page.Proposals, 1)
+	require.Equal(t, gov.KeyIndexedProposal("bsc", 2), page.NextKey)
+}
+
+func TestVoterHistory(t *testing.T) {
+	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	bscCtx := ctx.WithSideChainKeyPrefix([]byte{0x01}).WithSideChainId("bsc")
+	require.Nil(t, keeper.SetInitialProposalID(bscCtx, 1))
+
+	newProposal := func(ctx sdk.Context) gov.Proposal {
+		proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+		proposal.SetStatus(gov.StatusVotingPeriod)
+		keeper.SetProposal(ctx, proposal)
+		return proposal
+	}
+	proposal1, proposal2, bscProposal := newProposal(ctx), newProposal(ctx), newProposal(bscCtx)
+	require.Nil(t, keeper.AddVote(ctx, proposal1.GetProposalID(), addrs[0], gov.OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposal2.GetProposalID(), addrs[0], gov.OptionNo))
+	require.Nil(t, keeper.AddVote(bscCtx, bscProposal.GetProposalID(), addrs[0], gov.OptionYes))
+	require.Nil(t, keeper.AddVote(ctx, proposal1.GetProposalID(), addrs[1], gov.OptionNo))
+
+	// the votes are kept once the proposal is tallied, with its current status
+	proposal2.SetStatus(gov.StatusRejected)
+	keeper.SetProposal(ctx, proposal2)
+	_, _, _ = gov.Tally(ctx, keeper, proposal2)
+	history := keeper.GetVoterHistory(ctx, addrs[0], gov.StatusNil)
+	require.Len(t, history, 3)
+	require.Equal(t, "", history[1].SideChainId)
+	require.Equal(t, gov.OptionNo, history[1].Vote.Option)
+	require.Equal(t, gov.StatusRejected, history[1].Status)
+	require.Equal(t, "bsc", history[2].SideChainId)
+	require.Equal(t, gov.StatusVotingPeriod, history[2].Status)
+	require.Len(t, keeper.GetVoterHistory(ctx, addrs[0], gov.StatusRejected), 1)
+
+	// a changed vote replaces the recorded one
+	require.Nil(t, keeper.AddVote(ctx, proposal1.GetProposalID(), addrs[0], gov.OptionAbstain))
+	history = keeper.GetVoterHistory(ctx, addrs[0], gov.StatusNil)
+	require.Len(t, history, 3)
+	require.Equal(t, gov.OptionAbstain, history[0].Vote.Option)
+
+	cdc := codec.New()
+	gov.RegisterCodec(cdc)
+	querier := gov.NewQuerier(keeper)
+	params := gov.QueryVoterHistoryParams{Voter: addrs[0], ProposalStatus: gov.StatusVotingPeriod, PageParams: gov.PageParams{Limit: 1}}
+	var followed []gov.VoterHistoryEntry
+	for pages := 1; ; pages++ {
+		bz, err := cdc.MarshalJSON(params)
+		require.NoError(t, err)
+		res, sdkErr := querier(ctx, []string{gov.QueryVoterHistory}, abci.RequestQuery{Data: bz})
+		require.Nil(t, sdkErr)
+		var page gov.PagedVoterHistory
+		require.NoError(t, cdc.UnmarshalJSON(res, &page))
+		followed = append(followed, page.Votes...)
+		if len(page.NextKey) == 0 {
+			require.Equal(t, 2, pages)
+			break
+		}
+		require.Equal(t, gov.KeyVoterHistory(addrs[0], "bsc", bscProposal.GetProposalID()), page.NextKey)
+		params.NextKey = page.NextKey
+	}
+	require.Len(t, followed, 2)
+	require.Equal(t, proposal1.GetProposalID(), followed[0].Vote.ProposalID)
+	require.Equal(t, "bsc", followed[1].SideChainId)
+}
@@ -0,0 +1,67 @@
+package gov
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	pTypes "github.com/cosmos/cosmos-sdk/x/paramHub/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// SideChainParams are the gov params of a side chain managed by the param hub. Once GovSideChainDepositParams is
+// upgraded, they are changed with the params of the other modules by the side chain param change proposals, so
+// that each side chain has its own min deposit, deposit period and voting period.
+type SideChainParams struct {
+	DepositParams DepositParams `json:"deposit_params"`
+	VotingParams  VotingParams  `json:"voting_params"`
+}
+
+var _ pTypes.SCParam = &SideChainParams{}
+
+// Implements params.ParamSet
+func (p *SideChainParams) KeyValuePairs() params.KeyValuePairs {
+	return params.KeyValuePairs{
+		{ParamStoreKeyDepositParams, &p.DepositParams},
+		{ParamStoreKeyVotingParams, &p.VotingParams},
+	}
+}
+
+func (p *SideChainParams) UpdateCheck() error {
+	if err := p.DepositParams.Validate(); err != nil {
+		return err
+	}
+	return p.VotingParams.Validate()
+}
+
+func (p *SideChainParams) GetParamAttribute() (string, bool) {
+	return "gov", false
+}
+
+// SubscribeParamChange registers the gov params of the side chains to the param hub
+func (keeper *Keeper) SubscribeParamChange(hub pTypes.ParamChangePublisher) {
+	hub.SubscribeParamChange(
+		func(context sdk.Context, iChange interface{}) {
+			switch change := iChange.(type) {
+			case *SideChainParams:
+				if !context.UpgradeMgr().IsUpgrade(sdk.GovSideChainDepositParams) {
+					context.Logger().Error("[sc] skip gov param change before upgrade", "param", change)
+					break
+				}
+				// do double check
+				err := change.UpdateCheck()
+				if err != nil {
+					context.Logger().Error("[sc] skip invalid param change", "err", err, "param", change)
+				} else {
+					keeper.SetDepositParams(context, change.DepositParams)
+					keeper.paramSpace.Set(context, ParamStoreKeyVotingParams, &change.VotingParams)
+					break
+				}
+			default:
+				context.Logger().Debug("[sc] skip unknown param change")
+			}
+		},
+		&pTypes.ParamSpaceProto{ParamSpace: keeper.paramSpace, Proto: func() pTypes.SCParam {
+			return new(SideChainParams)
+		}},
+		nil,
+		nil,
+	)
+}
@@ -1,15 +1,25 @@
 package simulation
 
 import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
 )
 
 // AllInvariants tests all governance invariants
-func AllInvariants() simulation.Invariant {
+func AllInvariants(k gov.Keeper) simulation.Invariant {
 	return func(app *baseapp.BaseApp) error {
-		// TODO Add some invariants!
-		// Checking proposal queues, no passed-but-unexecuted proposals, etc.
+		ctx := app.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+		for _, result := range k.CheckInvariants(ctx) {
+			if result.Broken {
+				return fmt.Errorf("gov invariant %s broken: %s", result.Name, result.Message)
+			}
+		}
 		return nil
 	}
 }
@@ -0,0 +1,189 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ChainSnapshot is the complete gov state of the native chain or of one side chain,
+// NextProposalID is 0 if the chain has never set its initial proposal id.
+type ChainSnapshot struct {
+	ChainID               string        `json:"chain_id"`
+	NextProposalID        int64         `json:"next_proposal_id"`
+	DepositParams         DepositParams `json:"deposit_params"`
+	TallyParams           TallyParams   `json:"tally_params"`
+	Proposals             []Proposal    `json:"proposals"`
+	Deposits              []Deposit     `json:"deposits"`
+	Votes                 []Vote        `json:"votes"`
+	ActiveProposalQueue   ProposalQueue `json:"active_proposal_queue"`
+	InactiveProposalQueue ProposalQueue `json:"inactive_proposal_queue"`
+
+	ProposalTypeParams []ProposalTypeParams `json:"proposal_type_params,omitempty"`
+}
+
+// Snapshot is a self-contained bundle of the gov state of every chain, it can be
+// imported into a fresh testnet to rehearse a proposal in the state it had on mainnet.
+//
+// The deposits are recorded but the coins backing them are not, the testnet must
+// fund DepositedCoinsAccAddr with the total deposits for refunds to succeed.
+type Snapshot struct {
+	Height int64           `json:"height"`
+	Chains []ChainSnapshot `json:"chains"`
+}
+
+// ExportSnapshot exports the gov state of the native chain and of all the registered side chains
+func ExportSnapshot(ctx sdk.Context, keeper Keeper) Snapshot {
+	snapshot := Snapshot{
+		Height: ctx.BlockHeight(),
+		Chains: []ChainSnapshot{exportChainSnapshot(ctx, keeper, NativeChainID)},
+	}
+	if keeper.ScKeeper != nil {
+		sideChainIDs, storePrefixes := keeper.ScKeeper.GetAllSideChainPrefixes(ctx)
+		for i := range sideChainIDs {
+			scCtx := ctx.WithSideChainKeyPrefix(storePrefixes[i]).WithSideChainId(sideChainIDs[i])
+			snapshot.Chains = append(snapshot.Chains, exportChainSnapshot(scCtx, keeper, sideChainIDs[i]))
+		}
+	}
+	return snapshot
+}
+
+func exportChainSnapshot(ctx sdk.Context, keeper Keeper, chainID string) ChainSnapshot {
+	nextProposalID, err := keeper.peekCurrentProposalID(ctx)
+	if err != nil {
+		nextProposalID = 0
+	}
+	snapshot := ChainSnapshot{
+		ChainID:               chainID,
+		NextProposalID:        nextProposalID,
+		DepositParams:         keeper.GetDepositParams(ctx),
+		TallyParams:           keeper.GetTallyParams(ctx),
+		Proposals:             []Proposal{},
+		Deposits:              []Deposit{},
+		Votes:                 []Vote{},
+		ActiveProposalQueue:   keeper.getActiveProposalQueue(ctx),
+		InactiveProposalQueue: keeper.getInactiveProposalQueue(ctx),
+		ProposalTypeParams:    keeper.GetProposalTypeParams(ctx),
+	}
+
+	keeper.Iterate(ctx, nil, nil, StatusNil, 0, false, func(proposal Proposal) bool {
+		proposalID := proposal.GetProposalID()
+		snapshot.Proposals = append(snapshot.Proposals, proposal)
+
+		depositsIterator := keeper.GetDeposits(ctx, proposalID)
+		for ; depositsIterator.Valid(); depositsIterator.Next() {
+			var deposit Deposit
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(depositsIterator.Value(), &deposit)
+			snapshot.Deposits = append(snapshot.Deposits, deposit)
+		}
+		depositsIterator.Close()
+
+		votesIterator := keeper.GetVotes(ctx, proposalID)
+		for ; votesIterator.Valid(); votesIterator.Next() {
+			var vote Vote
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(votesIterator.Value(), &vote)
+			snapshot.Votes = append(snapshot.Votes, vote)
+		}
+		votesIterator.Close()
+		return false
+	})
+	return snapshot
+}
+
+// ImportSnapshot restores a snapshot produced by ExportSnapshot. The snapshot is validated
+// first and every chain it contains must not have any proposal yet, so nothing is written
+// if an error is returned. Side chains must be registered in the side chain keeper.
+func ImportSnapshot(ctx sdk.Context, keeper Keeper, snapshot Snapshot) sdk.Error {
+	contexts := make([]sdk.Context, 0, len(snapshot.Chains))
+	seen := make(map[string]bool, len(snapshot.Chains))
+	for _, chain := range snapshot.Chains {
+		if seen[chain.ChainID] {
+			return ErrInvalidGenesis(keeper.codespace, fmt.Sprintf("chain %s appears twice in the snapshot", chain.ChainID))
+		}
+		seen[chain.ChainID] = true
+
+		chainCtx := ctx
+		if chain.ChainID != NativeChainID {
+			if keeper.ScKeeper == nil {
+				return ErrInvalidSideChainId(keeper.codespace, chain.ChainID)
+			}
+			var err error
+			chainCtx, err = keeper.ScKeeper.PrepareCtxForSideChain(ctx, chain.ChainID)
+			if err != nil {
+				return ErrInvalidSideChainId(keeper.codespace, chain.ChainID)
+			}
+		}
+		if err := validateChainSnapshot(chainCtx, keeper, chain); err != nil {
+			return err
+		}
+		contexts = append(contexts, chainCtx)
+	}
+
+	for i, chain := range snapshot.Chains {
+		importChainSnapshot(contexts[i], keeper, chain)
+	}
+	return nil
+}
+
+func validateChainSnapshot(ctx sdk.Context, keeper Keeper, chain ChainSnapshot) sdk.Error {
+	if keeper.GetLastProposalID(ctx) > 0 {
+		return ErrInvalidGenesis(keeper.codespace, fmt.Sprintf("chain %s already has proposals", chain.ChainID))
+	}
+	// proposal ids start at 1, 0 is only valid for a chain without proposals as checked below
+	if chain.NextProposalID < 0 {
+		return ErrInvalidGenesis(keeper.codespace, fmt.Sprintf("invalid next proposal id %d of chain %s",
+			chain.NextProposalID, chain.ChainID))
+	}
+
+	proposals := make(map[int64]bool, len(chain.Proposals))
+	for _, proposal := range chain.Proposals {
+		proposalID := proposal.GetProposalID()
+		if proposalID <= 0 || proposalID >= chain.NextProposalID {
+			return ErrInvalidGenesis(keeper.codespace, fmt.Sprintf("proposal %d of chain %s is out of range, next proposal id is %d",
+				proposalID, chain.ChainID, chain.NextProposalID))
+		}
+		proposals[proposalID] = true
+	}
+	for _, deposit := range chain.Deposits {
+		if !proposals[deposit.ProposalID] {
+			return ErrUnknownProposal(keeper.codespace, deposit.ProposalID)
+		}
+	}
+	for _, vote := range chain.Votes {
+		if !proposals[vote.ProposalID] {
+			return ErrUnknownProposal(keeper.codespace, vote.ProposalID)
+		}
+	}
+	for _, queue := range []ProposalQueue{chain.ActiveProposalQueue, chain.InactiveProposalQueue} {
+		for _, proposalID := range queue {
+			if !proposals[proposalID] {
+				return ErrUnknownProposal(keeper.codespace, proposalID)
+			}
+		}
+	}
+	return validateProposalTypeParams(keeper.codespace, chain.ProposalTypeParams)
+}
+
+func importChainSnapshot(ctx sdk.Context, keeper Keeper, chain ChainSnapshot) {
+	if chain.NextProposalID > 0 {
+		store := ctx.KVStore(keeper.storeKey)
+		store.Set(KeyNextProposalID, keeper.cdc.MustMarshalBinaryLengthPrefixed(chain.NextProposalID))
+	}
+	keeper.SetDepositParams(ctx, chain.DepositParams)
+	keeper.SetTallyParams(ctx, chain.TallyParams)
+	if len(chain.ProposalTypeParams) > 0 {
+		keeper.SetProposalTypeParams(ctx, chain.ProposalTypeParams) // nolint: errcheck
+	}
+
+	for _, proposal := range chain.Proposals {
+		keeper.SetProposal(ctx, proposal)
+	}
+	for _, deposit := range chain.Deposits {
+		keeper.setDeposit(ctx, deposit.ProposalID, deposit.Depositer, deposit)
+	}
+	for _, vote := range chain.Votes {
+		keeper.setVote(ctx, vote.ProposalID, vote.Voter, vote)
+	}
+	keeper.setActiveProposalQueue(ctx, chain.ActiveProposalQueue)
+	keeper.setInactiveProposalQueue(ctx, chain.InactiveProposalQueue)
+}
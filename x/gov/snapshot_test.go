@@ -0,0 +1,93 @@
+package gov_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+func TestExportImportSnapshot(t *testing.T) {
+	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	proposal1 := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	proposal2 := keeper.NewTextProposal(ctx, "Test2", "description", gov.ProposalTypeText, 1000*time.Second)
+	err, _ := keeper.AddDeposit(ctx, proposal1.GetProposalID(), addrs[0], sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 500e8)})
+	require.Nil(t, err)
+	keeper.ActivateVotingPeriod(ctx, proposal2)
+	require.Nil(t, keeper.AddVote(ctx, proposal2.GetProposalID(), addrs[1], gov.OptionNo))
+
+	snapshot := gov.ExportSnapshot(ctx, keeper)
+	require.Len(t, snapshot.Chains, 1)
+	require.Len(t, snapshot.Chains[0].Proposals, 2)
+	require.Len(t, snapshot.Chains[0].Deposits, 1)
+	require.Len(t, snapshot.Chains[0].Votes, 1)
+
+	// the bundle survives a json round trip
+	bz := mapp.Cdc.MustMarshalJSON(snapshot)
+	var restored gov.Snapshot
+	mapp.Cdc.MustUnmarshalJSON(bz, &restored)
+
+	// restore into a fresh chain
+	mapp2, _, keeper2, _, _, _, _ := getMockApp(t, 0)
+	mapp2.BeginBlock(abci.RequestBeginBlock{})
+	ctx2 := mapp2.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	require.Nil(t, gov.ImportSnapshot(ctx2, keeper2, restored))
+
+	require.True(t, gov.ProposalEqual(proposal1, keeper2.GetProposal(ctx2, proposal1.GetProposalID())))
+	require.True(t, gov.ProposalEqual(proposal2, keeper2.GetProposal(ctx2, proposal2.GetProposalID())))
+	deposit, found := keeper2.GetDeposit(ctx2, proposal1.GetProposalID(), addrs[0])
+	require.True(t, found)
+	require.True(t, deposit.Amount.IsEqual(sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 500e8)}))
+	vote, found := keeper2.GetVote(ctx2, proposal2.GetProposalID(), addrs[1])
+	require.True(t, found)
+	require.Equal(t, gov.OptionNo, vote.Option)
+	require.Equal(t, proposal2.GetProposalID(), keeper2.ActiveProposalQueuePeek(ctx2).GetProposalID())
+	require.Equal(t, proposal1.GetProposalID(), keeper2.InactiveProposalQueuePeek(ctx2).GetProposalID())
+
+	// new proposals continue after the restored ones
+	proposal3 := keeper2.NewTextProposal(ctx2, "Test3", "description", gov.ProposalTypeText, 1000*time.Second)
+	require.Equal(t, proposal2.GetProposalID()+1, proposal3.GetProposalID())
+
+	// a chain that already has proposals is rejected
+	require.NotNil(t, gov.ImportSnapshot(ctx2, keeper2, restored))
+}
+
+func TestImportSnapshotInvalid(t *testing.T) {
+	mapp, _, keeper, _, addrs, _, _ := getMockApp(t, 1)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+
+	snapshot := gov.ExportSnapshot(ctx, keeper)
+	chain := snapshot.Chains[0]
+
+	// vote on an unknown proposal
+	chain.Votes = []gov.Vote{{Voter: addrs[0], ProposalID: 1, Option: gov.OptionYes}}
+	require.NotNil(t, gov.ImportSnapshot(ctx, keeper, gov.Snapshot{Chains: []gov.ChainSnapshot{chain}}))
+
+	// negative next proposal id
+	chain = snapshot.Chains[0]
+	chain.NextProposalID = -1
+	require.NotNil(t, gov.ImportSnapshot(ctx, keeper, gov.Snapshot{Chains: []gov.ChainSnapshot{chain}}))
+
+	// a chain without initial proposal id can't have proposals
+	chain = snapshot.Chains[0]
+	chain.NextProposalID = 0
+	chain.Proposals = []gov.Proposal{&gov.TextProposal{ProposalID: 1}}
+	require.NotNil(t, gov.ImportSnapshot(ctx, keeper, gov.Snapshot{Chains: []gov.ChainSnapshot{chain}}))
+
+	// unknown side chain
+	chain = snapshot.Chains[0]
+	chain.ChainID = "unknown"
+	require.NotNil(t, gov.ImportSnapshot(ctx, keeper, gov.Snapshot{Chains: []gov.ChainSnapshot{chain}}))
+
+	// nothing has been written
+	require.Nil(t, keeper.GetProposal(ctx, 1))
+	require.Equal(t, int64(0), keeper.GetLastProposalID(ctx))
+}
@@ -0,0 +1,114 @@
+package gov
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov/events"
+)
+
+// The chain sunset is scheduled with the upgrade heights of sdk.GovSunset and sdk.GovArchive.
+//
+// From the GovSunset height governance is read-only: no proposal can be submitted anymore, on
+// any chain, but the proposals in flight can still be deposited on, voted and are settled as usual.
+// At the GovArchive height, the results of all the proposals are archived in the store, so that
+// they can be queried from the final state of the chain.
+
+// IsReadOnly tells whether governance stopped accepting new proposals for the chain sunset
+func IsReadOnly(ctx sdk.Context) bool {
+	return ctx.UpgradeMgr().IsUpgrade(sdk.GovSunset)
+}
+
+// ArchivedProposal is the final result of a proposal
+type ArchivedProposal struct {
+	ChainID         string         `json:"chain_id"`
+	ProposalID      int64          `json:"proposal_id"`
+	Title           string         `json:"title"`
+	Description     string         `json:"description"`
+	ProposalType    ProposalKind   `json:"proposal_type"`
+	Status          ProposalStatus `json:"status"`
+	TallyResult     TallyResult    `json:"tally_result"`
+	SubmitTime      time.Time      `json:"submit_time"`
+	TotalDeposit    sdk.Coins      `json:"total_deposit"`
+	VotingStartTime time.Time      `json:"voting_start_time"`
+	VotingPeriod    time.Duration  `json:"voting_period"`
+	NumVotes        int64          `json:"num_votes,omitempty"` // number of votes of a pruned proposal
+}
+
+// Archive is the record of the results of all the proposals of the native chain, followed by
+// those of the side chains in the order of their registration, each ordered by proposal id.
+// The proposals still in flight when it's taken are recorded with their current status, the
+// pruned proposals with their archived record.
+type Archive struct {
+	Height    int64              `json:"height"`
+	Time      time.Time          `json:"time"`
+	Proposals []ArchivedProposal `json:"proposals"`
+}
+
+// GetArchive returns the archive of the proposal results, if it has been taken
+func (keeper Keeper) GetArchive(ctx sdk.Context) (Archive, bool) {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	bz := store.Get(KeyArchive)
+	if bz == nil {
+		return Archive{}, false
+	}
+	var archive Archive
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &archive)
+	return archive, true
+}
+
+func (keeper Keeper) setArchive(ctx sdk.Context, archive Archive) {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	store.Set(KeyArchive, keeper.cdc.MustMarshalBinaryLengthPrefixed(archive))
+}
+
+// archiveProposals stores the archive of the results of the proposals of all the chains
+func archiveProposals(ctx sdk.Context, keeper Keeper) sdk.Event {
+	archive := Archive{
+		Height:    ctx.BlockHeight(),
+		Time:      ctx.BlockHeader().Time,
+		Proposals: archiveChainProposals(ctx, keeper, NativeChainID),
+	}
+	if keeper.ScKeeper != nil {
+		sideChainIDs, storePrefixes := keeper.ScKeeper.GetAllSideChainPrefixes(ctx)
+		for i := range sideChainIDs {
+			scCtx := ctx.WithSideChainKeyPrefix(storePrefixes[i])
+			archive.Proposals = append(archive.Proposals, archiveChainProposals(scCtx, keeper, sideChainIDs[i])...)
+		}
+	}
+	keeper.setArchive(ctx, archive)
+
+	ctx.Logger().With("module", "x/gov").Info("archived the proposal results", "proposals", len(archive.Proposals))
+	return sdk.NewEvent(events.EventTypeProposalsArchived,
+		sdk.NewAttribute(events.NumProposals, strconv.Itoa(len(archive.Proposals))))
+}
+
+func archiveChainProposals(ctx sdk.Context, keeper Keeper, chainID string) []ArchivedProposal {
+	proposals := keeper.GetArchivedProposals(ctx)
+	keeper.Iterate(ctx, nil, nil, StatusNil, 0, false, func(proposal Proposal) bool {
+		proposals = append(proposals, newArchivedProposal(chainID, proposal))
+		return false
+	})
+	sort.SliceStable(proposals, func(i, j int) bool {
+		return proposals[i].ProposalID < proposals[j].ProposalID
+	})
+	return proposals
+}
+
+func newArchivedProposal(chainID string, proposal Proposal) ArchivedProposal {
+	return ArchivedProposal{
+		ChainID:         chainID,
+		ProposalID:      proposal.GetProposalID(),
+		Title:           proposal.GetTitle(),
+		Description:     proposal.GetDescription(),
+		ProposalType:    proposal.GetProposalType(),
+		Status:          proposal.GetStatus(),
+		TallyResult:     proposal.GetTallyResult(),
+		SubmitTime:      proposal.GetSubmitTime(),
+		TotalDeposit:    proposal.GetTotalDeposit(),
+		VotingStartTime: proposal.GetVotingStartTime(),
+		VotingPeriod:    proposal.GetVotingPeriod(),
+	}
+}
@@ -15,4 +15,5 @@ var (
 	VotingPeriodStart = "voting-period-start"
 	Depositer         = "depositer"
 	Voter             = "voter"
+	Metadata          = "metadata"
 )
@@ -1,6 +1,9 @@
 package gov
 
 import (
+	"bytes"
+	"sort"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -10,29 +13,116 @@ type validatorGovInfo struct {
 	Power               sdk.Dec        // Power of a Validator
 	DelegatorShares     sdk.Dec        // Total outstanding delegator shares
 	DelegatorDeductions sdk.Dec        // Delegator deductions from validator's delegators voting independently
-	Vote                VoteOption     // Vote of the validator
+	Vote                *Vote          // Vote of the validator, nil if it did not vote
 }
 
 func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refundDeposits bool, tallyResults TallyResult) {
+	passes, refundDeposits, tallyResults, _ = tally(ctx, keeper, proposal)
+	return
+}
+
+// tally also returns the bonded validators that voted, sorted by operator address.
+// The votes are deleted, except those of an expedited proposal that did not pass, which count
+// again once the proposal is converted to a regular one.
+func tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refundDeposits bool, tallyResults TallyResult, voters []sdk.ValAddress) {
+	passes, refundDeposits, tallyResults, voters, votes := tallyVotes(ctx, keeper, proposal)
+	if passes || !proposal.GetExpedited() {
+		for _, voter := range votes {
+			keeper.deleteVote(ctx, proposal.GetProposalID(), voter)
+		}
+		keeper.deletePowerSnapshot(ctx, proposal.GetProposalID())
+	}
+	return passes, refundDeposits, tallyResults, voters
+}
+
+func tallyVotes(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refundDeposits bool, tallyResults TallyResult, voters []sdk.ValAddress, votes []sdk.AccAddress) {
+	if mcProposal, ok := proposal.(*MultipleChoiceProposal); ok {
+		return tallyChoiceVotes(ctx, keeper, mcProposal)
+	}
+
 	results := make(map[VoteOption]sdk.Dec)
 	results[OptionYes] = sdk.ZeroDec()
 	results[OptionAbstain] = sdk.ZeroDec()
 	results[OptionNo] = sdk.ZeroDec()
 	results[OptionNoWithVeto] = sdk.ZeroDec()
 
-	totalVotingPower := sdk.ZeroDec()
+	totalVotingPower, voters, votes := tallyVotingPower(ctx, keeper, proposal, func(vote Vote, votingPower sdk.Dec) {
+		for _, option := range vote.WeightedOptions() {
+			results[option.Option] = results[option.Option].Add(votingPower.Mul(option.Weight))
+		}
+	})
+
+	tallyingParams := keeper.tallyParams(ctx, proposal)
+	totalPower := keeper.totalPower(ctx, proposal.GetProposalID())
+	tallyResults = TallyResult{
+		Yes:        results[OptionYes],
+		Abstain:    results[OptionAbstain],
+		No:         results[OptionNo],
+		NoWithVeto: results[OptionNoWithVeto],
+		Total:      totalPower,
+	}
+
+	passes, refundDeposits = tallyOutcome(tallyResults, totalVotingPower, tallyingParams)
+	return passes, refundDeposits, tallyResults, voters, votes
+}
+
+// tallyOutcome tells whether a proposal with tallyResults passes with tallyParams and whether its deposits
+// are refunded, totalVotingPower being the voting power of the votes
+func tallyOutcome(tallyResults TallyResult, totalVotingPower sdk.Dec, tallyParams TallyParams) (passes bool, refundDeposits bool) {
+	// If there is no staked coins, the proposal fails
+	if tallyResults.Total.IsZero() {
+		return false, true
+	}
+	// If there is not enough quorum of votes, the proposal fails
+	percentVoting := totalVotingPower.Quo(tallyResults.Total)
+	if percentVoting.LT(tallyParams.Quorum) {
+		return false, true
+	}
+	// If no one votes, proposal fails
+	if totalVotingPower.Sub(tallyResults.Abstain).Equal(sdk.ZeroDec()) {
+		return false, true
+	}
+	// If more than 1/3 of voters veto, proposal fails
+	if tallyResults.NoWithVeto.Quo(totalVotingPower).GT(tallyParams.Veto) {
+		return false, false
+	}
+	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
+	if tallyResults.Yes.Quo(totalVotingPower.Sub(tallyResults.Abstain)).GT(tallyParams.Threshold) {
+		return true, true
+	}
+	// If more than 1/2 of non-abstaining voters vote No, proposal fails
+
+	return false, false
+}
+
+// tallyVotingPower counts the voting power of each vote on the proposal and returns the total voting
+// power of the votes, the bonded validators that voted, sorted by operator address, and the voters.
+// The voting power is that of the snapshot of the proposal if it has one, the live one otherwise.
+func tallyVotingPower(ctx sdk.Context, keeper Keeper, proposal Proposal, count func(vote Vote, votingPower sdk.Dec)) (totalVotingPower sdk.Dec, voters []sdk.ValAddress, votes []sdk.AccAddress) {
+	totalVotingPower = sdk.ZeroDec()
 	currValidators := make(map[string]validatorGovInfo)
 
-	keeper.vs.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
-		currValidators[validator.GetOperator().String()] = validatorGovInfo{
-			Address:             validator.GetOperator(),
-			Power:               validator.GetPower(),
-			DelegatorShares:     validator.GetDelegatorShares(),
-			DelegatorDeductions: sdk.ZeroDec(),
-			Vote:                OptionEmpty,
+	snapshot, snapshotted := keeper.GetPowerSnapshot(ctx, proposal.GetProposalID())
+	if snapshotted {
+		for _, validator := range snapshot.Validators {
+			currValidators[validator.Operator.String()] = validatorGovInfo{
+				Address:             validator.Operator,
+				Power:               validator.Power,
+				DelegatorShares:     validator.DelegatorShares,
+				DelegatorDeductions: sdk.ZeroDec(),
+			}
 		}
-		return false
-	})
+	} else {
+		keeper.vs.IterateValidatorsBonded(ctx, func(index int64, validator sdk.Validator) (stop bool) {
+			currValidators[validator.GetOperator().String()] = validatorGovInfo{
+				Address:             validator.GetOperator(),
+				Power:               validator.GetPower(),
+				DelegatorShares:     validator.GetDelegatorShares(),
+				DelegatorDeductions: sdk.ZeroDec(),
+			}
+			return false
+		})
+	}
 
 	// iterate over all the votes
 	votesIterator := keeper.GetVotes(ctx, proposal.GetProposalID())
@@ -45,77 +135,94 @@ func Tally(ctx sdk.Context, keeper Keeper, proposal Proposal) (passes bool, refu
 		// if delegator tally voting power
 		valAddrStr := sdk.ValAddress(vote.Voter).String()
 		if val, ok := currValidators[valAddrStr]; ok {
-			val.Vote = vote.Option
+			val.Vote = vote
 			currValidators[valAddrStr] = val
 		} else {
 
-			keeper.ds.IterateDelegations(ctx, vote.Voter, func(index int64, delegation sdk.Delegation) (stop bool) {
-				valAddrStr := delegation.GetValidatorAddr().String()
+			for _, delegation := range keeper.voterDelegations(ctx, proposal.GetProposalID(), snapshotted, vote.Voter) {
+				valAddrStr := delegation.ValidatorAddr.String()
 
 				if val, ok := currValidators[valAddrStr]; ok {
-					val.DelegatorDeductions = val.DelegatorDeductions.Add(delegation.GetShares())
+					val.DelegatorDeductions = val.DelegatorDeductions.Add(delegation.Shares)
 					currValidators[valAddrStr] = val
 
-					delegatorShare := delegation.GetShares().Quo(val.DelegatorShares)
+					delegatorShare := delegation.Shares.Quo(val.DelegatorShares)
 					votingPower := val.Power.Mul(delegatorShare)
 
-					results[vote.Option] = results[vote.Option].Add(votingPower)
+					count(*vote, votingPower)
 					totalVotingPower = totalVotingPower.Add(votingPower)
 				}
-
-				return false
-			})
+			}
 		}
 
-		keeper.deleteVote(ctx, vote.ProposalID, vote.Voter)
+		votes = append(votes, vote.Voter)
 	}
 
 	// iterate over the validators again to tally their voting power
 	for _, val := range currValidators {
-		if val.Vote == OptionEmpty {
+		if val.Vote == nil {
 			continue
 		}
+		voters = append(voters, val.Address)
 
 		sharesAfterMinus := val.DelegatorShares.Sub(val.DelegatorDeductions)
 		percentAfterMinus := sharesAfterMinus.Quo(val.DelegatorShares)
 		votingPower := val.Power.Mul(percentAfterMinus)
 
-		results[val.Vote] = results[val.Vote].Add(votingPower)
+		count(*val.Vote, votingPower)
 		totalVotingPower = totalVotingPower.Add(votingPower)
 	}
 
-	tallyingParams := keeper.GetTallyParams(ctx)
-	totalPower := keeper.vs.TotalPower(ctx)
-	tallyResults = TallyResult{
-		Yes:        results[OptionYes],
-		Abstain:    results[OptionAbstain],
-		No:         results[OptionNo],
-		NoWithVeto: results[OptionNoWithVeto],
-		Total:      totalPower,
-	}
+	sort.Slice(voters, func(i, j int) bool { return bytes.Compare(voters[i], voters[j]) < 0 })
+	return totalVotingPower, voters, votes
+}
 
-	// If there is no staked coins, the proposal fails
-	if keeper.vs.TotalPower(ctx).IsZero() {
-		return false, true, tallyResults
-	}
-	// If there is not enough quorum of votes, the proposal fails
-	percentVoting := totalVotingPower.Quo(totalPower)
-	if percentVoting.LT(tallyingParams.Quorum) {
-		return false, true, tallyResults
-	}
-	// If no one votes, proposal fails
-	if totalVotingPower.Sub(results[OptionAbstain]).Equal(sdk.ZeroDec()) {
-		return false, true, tallyResults
-	}
-	// If more than 1/3 of voters veto, proposal fails
-	if results[OptionNoWithVeto].Quo(totalVotingPower).GT(tallyingParams.Veto) {
-		return false, false, tallyResults
-	}
-	// If more than 1/2 of non-abstaining voters vote Yes, proposal passes
-	if results[OptionYes].Quo(totalVotingPower.Sub(results[OptionAbstain])).GT(tallyingParams.Threshold) {
-		return true, true, tallyResults
-	}
-	// If more than 1/2 of non-abstaining voters vote No, proposal fails
+// TallySimulation is the outcome of a tallied proposal recomputed from its stored tally result with other
+// tally params
+type TallySimulation struct {
+	ProposalID   int64          `json:"proposal_id"`
+	ProposalType ProposalKind   `json:"proposal_type"`
+	Status       ProposalStatus `json:"status"`       //  Status the proposal was settled with
+	TallyResult  TallyResult    `json:"tally_result"` //  Tally result stored at the end of the voting period
+	Passes       bool           `json:"passes"`       //  Whether the proposal passes with the simulated params
+	Changed      bool           `json:"changed"`      //  Whether the simulated outcome differs from the settled one
+}
+
+// SimulateTallyParams recomputes the outcomes of the last numLatest tallied proposals of the chain of ctx with
+// the quorum and the threshold, the ones left nil being the current params of each proposal type. The stored
+// tally results are used, so the voting power is the one at the end of the voting period. The expedited,
+// emergency and multiple choice proposals are tallied with other params and are skipped.
+func SimulateTallyParams(ctx sdk.Context, keeper Keeper, quorum, threshold *sdk.Dec, numLatest int) []TallySimulation {
+	simulations := make([]TallySimulation, 0)
+	keeper.Iterate(ctx, nil, nil, StatusNil, 0, true, func(proposal Proposal) bool {
+		if len(simulations) >= numLatest {
+			return true
+		}
+		status := proposal.GetStatus()
+		settled := status != StatusDepositPeriod && status != StatusVotingPeriod
+		if !settled || proposal.GetExpedited() || proposal.GetEmergency() || proposal.GetProposalType() == ProposalTypeMultipleChoice {
+			return false
+		}
 
-	return false, false, tallyResults
+		tallyParams := keeper.GetTallyParamsOf(ctx, proposal.GetProposalType())
+		if quorum != nil {
+			tallyParams.Quorum = *quorum
+		}
+		if threshold != nil {
+			tallyParams.Threshold = *threshold
+		}
+		tallyResult := proposal.GetTallyResult()
+		totalVotingPower := tallyResult.Yes.Add(tallyResult.Abstain).Add(tallyResult.No).Add(tallyResult.NoWithVeto)
+		passes, _ := tallyOutcome(tallyResult, totalVotingPower, tallyParams)
+		simulations = append(simulations, TallySimulation{
+			ProposalID:   proposal.GetProposalID(),
+			ProposalType: proposal.GetProposalType(),
+			Status:       status,
+			TallyResult:  tallyResult,
+			Passes:       passes,
+			Changed:      passes != (status != StatusRejected),
+		})
+		return false
+	})
+	return simulations
 }
@@ -90,6 +90,51 @@ func TestTallyOnlyValidatorsAllYes(t *testing.T) {
 	require.False(t, tallyResults.Equals(gov.EmptyTallyResult()))
 }
 
+func TestTallyOnlyValidatorsWeighted(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:2]))
+	for i, addr := range addrs[:2] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5})
+	stake.EndBlocker(ctx, sk)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(gov.StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+
+	err := keeper.AddWeightedVote(ctx, proposalID, addrs[0], gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, sdk.NewDecWithPrec(5, 1))})
+	require.NotNil(t, err, "weights should sum up to 1")
+
+	// a single option is stored as a plain vote
+	err = keeper.AddWeightedVote(ctx, proposalID, addrs[0], gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionNo, sdk.OneDec())})
+	require.Nil(t, err)
+	vote, found := keeper.GetVote(ctx, proposalID, addrs[0])
+	require.True(t, found)
+	require.Equal(t, gov.OptionNo, vote.Option)
+	require.Empty(t, vote.Options)
+
+	options := gov.WeightedVoteOptions{gov.NewWeightedVoteOption(gov.OptionYes, sdk.NewDecWithPrec(8, 1)), gov.NewWeightedVoteOption(gov.OptionNo, sdk.NewDecWithPrec(2, 1))}
+	err = keeper.AddWeightedVote(ctx, proposalID, addrs[1], options)
+	require.Nil(t, err)
+	vote, found = keeper.GetVote(ctx, proposalID, addrs[1])
+	require.True(t, found)
+	require.Equal(t, options, vote.WeightedOptions())
+
+	passes, _, tallyResults := gov.Tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+
+	// 0.8 of the second validator votes yes and 1.2 of the same power votes no
+	require.False(t, passes)
+	require.True(t, tallyResults.Yes.GT(sdk.ZeroDec()))
+	require.True(t, tallyResults.No.Equal(tallyResults.Yes.Mul(sdk.NewDecWithPrec(15, 1))))
+}
+
 func TestTallyOnlyValidators51No(t *testing.T) {
 	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
 	mapp.BeginBlock(abci.RequestBeginBlock{})
@@ -539,3 +584,240 @@ func TestTallyJailedValidator(t *testing.T) {
 	require.True(t, passes)
 	require.False(t, tallyResults.Equals(gov.EmptyTallyResult()))
 }
+
+func TestTallyMultipleChoice(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:3]))
+	for i, addr := range addrs[:3] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+
+	newProposal := func() int64 {
+		proposal := keeper.NewMultipleChoiceProposal(ctx, "Test", "description", []string{"low", "medium", "high"}, 1000*time.Second)
+		proposal.SetStatus(gov.StatusVotingPeriod)
+		keeper.SetProposal(ctx, proposal)
+		return proposal.GetProposalID()
+	}
+
+	proposalID := newProposal()
+	require.NotNil(t, keeper.AddVote(ctx, proposalID, addrs[0], gov.OptionYes), "multiple choice proposals are voted by choice")
+	require.NotNil(t, keeper.AddChoiceVote(ctx, proposalID, addrs[0], 4))
+	require.Nil(t, keeper.AddChoiceVote(ctx, proposalID, addrs[0], 1))
+	require.Nil(t, keeper.AddChoiceVote(ctx, proposalID, addrs[1], 3))
+	require.Nil(t, keeper.AddChoiceVote(ctx, proposalID, addrs[2], 3))
+
+	proposal := keeper.GetProposal(ctx, proposalID).(*gov.MultipleChoiceProposal)
+	passes, _, _ := gov.Tally(ctx, keeper, proposal)
+	require.True(t, passes)
+	result := proposal.ChoiceTallyResult
+	require.Equal(t, int64(3), result.WinningChoice)
+	require.True(t, result.Distribution[0].GT(sdk.ZeroDec()))
+	require.True(t, result.Distribution[1].IsZero())
+	require.True(t, result.Distribution[2].Equal(result.Distribution[0].Mul(sdk.NewDecWithoutFra(2))))
+
+	// there is no winning choice on a tie
+	proposalID = newProposal()
+	require.Nil(t, keeper.AddChoiceVote(ctx, proposalID, addrs[0], 1))
+	require.Nil(t, keeper.AddChoiceVote(ctx, proposalID, addrs[1], 2))
+	proposal = keeper.GetProposal(ctx, proposalID).(*gov.MultipleChoiceProposal)
+	passes, _, _ = gov.Tally(ctx, keeper, proposal)
+	require.False(t, passes)
+	require.Equal(t, int64(0), proposal.ChoiceTallyResult.WinningChoice)
+
+	// regular proposals are not voted by choice
+	textProposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	textProposal.SetStatus(gov.StatusVotingPeriod)
+	keeper.SetProposal(ctx, textProposal)
+	require.NotNil(t, keeper.AddChoiceVote(ctx, textProposal.GetProposalID(), addrs[0], 1))
+}
+
+func TestDelegatorVoteOverride(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	stakeHandler := stake.NewStakeHandler(sk)
+	govHandler := gov.NewHandler(keeper)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:3]))
+	for i, addr := range addrs[:3] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 6, 7})
+	stake.EndBlocker(ctx, sk)
+
+	delegator1Msg := stake.NewMsgDelegate(addrs[3], sdk.ValAddress(addrs[2]), sdk.NewCoin(gov.DefaultDepositDenom, 30))
+	require.True(t, stakeHandler(ctx, delegator1Msg).IsOK())
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	proposalID := proposal.GetProposalID()
+	proposal.SetStatus(gov.StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+	emergencyProposal := keeper.NewEmergencyTextProposal(ctx, "Test", `{"routes":["bank"],"halt":true}`, gov.ProposalTypeCircuitBreaker, 1000*time.Second)
+	emergencyProposal.SetStatus(gov.StatusVotingPeriod)
+	keeper.SetProposal(ctx, emergencyProposal)
+
+	// only the validators vote before the upgrade
+	res := govHandler(ctx, gov.NewMsgVote(addrs[3], proposalID, gov.OptionNo))
+	require.False(t, res.IsOK())
+
+	height := sdk.UpgradeMgr.GetHeight()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovDelegatorVote, 10)
+	sdk.UpgradeMgr.SetHeight(10)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.GovDelegatorVote)
+		sdk.UpgradeMgr.SetHeight(height)
+	}()
+
+	for _, addr := range addrs[:3] {
+		res = govHandler(ctx, gov.NewMsgVote(addr, proposalID, gov.OptionYes))
+		require.True(t, res.IsOK(), res.Log)
+	}
+	res = govHandler(ctx, gov.NewMsgVote(addrs[3], proposalID, gov.OptionNo))
+	require.True(t, res.IsOK(), res.Log)
+	res = govHandler(ctx, gov.NewMsgVote(addrs[4], proposalID, gov.OptionNo))
+	require.False(t, res.IsOK(), "not a delegator")
+	res = govHandler(ctx, gov.NewMsgVote(addrs[3], emergencyProposal.GetProposalID(), gov.OptionNo))
+	require.False(t, res.IsOK(), "only the validators vote on emergency proposals")
+
+	// the delegator's share of the validator's power, 30 of 37, is counted as No
+	passes, _, tallyResults := gov.Tally(ctx, keeper, keeper.GetProposal(ctx, proposalID))
+	require.False(t, passes)
+	require.True(t, tallyResults.No.GT(tallyResults.Yes))
+}
+
+func TestTallyPowerSnapshot(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	sk = sk.WithHooks(keeper.Hooks())
+	stakeHandler := stake.NewStakeHandler(sk)
+	govHandler := gov.NewHandler(keeper)
+	// the gov store is not prefixed, only the side chain id matters to the snapshot
+	sideCtx := ctx.WithSideChainId("bsc")
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:3]))
+	for i, addr := range addrs[:3] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 6, 7})
+	stake.EndBlocker(ctx, sk)
+	require.True(t, stakeHandler(ctx, stake.NewMsgDelegate(addrs[3], valAddrs[2], sdk.NewCoin(gov.DefaultDepositDenom, 10))).IsOK())
+
+	height := sdk.UpgradeMgr.GetHeight()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovDelegatorVote, 10)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovPowerSnapshot, 20)
+	sdk.UpgradeMgr.SetHeight(10)
+	defer func() {
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.GovDelegatorVote)
+		delete(sdk.UpgradeMgr.Config.HeightMap, sdk.GovPowerSnapshot)
+		sdk.UpgradeMgr.SetHeight(height)
+	}()
+
+	// the proposal whose voting period starts before the upgrade is tallied with the live voting power
+	liveProposal := keeper.NewTextProposal(sideCtx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	keeper.ActivateVotingPeriod(sideCtx, liveProposal)
+	_, ok := keeper.GetPowerSnapshot(ctx, liveProposal.GetProposalID())
+	require.False(t, ok)
+
+	sdk.UpgradeMgr.SetHeight(20)
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	keeper.ActivateVotingPeriod(ctx, proposal)
+	_, ok = keeper.GetPowerSnapshot(ctx, proposal.GetProposalID())
+	require.False(t, ok, "the native chain proposals are not snapshotted")
+	keeper.DeleteProposal(ctx, proposal)
+
+	proposal = keeper.NewTextProposal(sideCtx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+	keeper.ActivateVotingPeriod(sideCtx, proposal)
+	snapshot, ok := keeper.GetPowerSnapshot(ctx, proposal.GetProposalID())
+	require.True(t, ok)
+	require.Len(t, snapshot.Validators, 3)
+
+	// a delegation made during the voting period does not count on the snapshotted proposal
+	require.True(t, stakeHandler(ctx, stake.NewMsgDelegate(addrs[4], valAddrs[0], sdk.NewCoin(gov.DefaultDepositDenom, 100))).IsOK())
+	for _, proposalID := range []int64{liveProposal.GetProposalID(), proposal.GetProposalID()} {
+		for _, addr := range addrs[:3] {
+			require.True(t, govHandler(ctx, gov.NewMsgVote(addr, proposalID, gov.OptionYes)).IsOK())
+		}
+		for _, addr := range addrs[3:5] {
+			res := govHandler(ctx, gov.NewMsgVote(addr, proposalID, gov.OptionNo))
+			require.True(t, res.IsOK(), res.Log)
+		}
+	}
+
+	passes, _, tallyResults := gov.Tally(sideCtx, keeper, keeper.GetProposal(ctx, liveProposal.GetProposalID()))
+	require.False(t, passes)
+	require.True(t, tallyResults.No.GT(tallyResults.Yes))
+
+	passes, _, tallyResults = gov.Tally(sideCtx, keeper, keeper.GetProposal(ctx, proposal.GetProposalID()))
+	require.True(t, passes)
+	require.True(t, tallyResults.Yes.GT(tallyResults.No))
+	require.Equal(t, snapshot.TotalPower, tallyResults.Total)
+	_, ok = keeper.GetPowerSnapshot(ctx, proposal.GetProposalID())
+	require.False(t, ok, "the snapshot is deleted with the votes")
+}
+
+func TestSimulateTallyParams(t *testing.T) {
+	mapp, _, keeper, _, _, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+	keeper.SetTallyParams(ctx, gov.TallyParams{
+		Quorum:    sdk.NewDecWithPrec(5, 1),
+		Threshold: sdk.NewDecWithPrec(5, 1),
+		Veto:      sdk.NewDecWithPrec(334, 3),
+	})
+
+	settle := func(status gov.ProposalStatus, yes, no int64) int64 {
+		proposal := keeper.NewTextProposal(ctx, "Test", "description", gov.ProposalTypeText, 1000*time.Second)
+		proposal.SetStatus(status)
+		proposal.SetTallyResult(gov.TallyResult{
+			Yes:        sdk.NewDecWithoutFra(yes),
+			Abstain:    sdk.ZeroDec(),
+			No:         sdk.NewDecWithoutFra(no),
+			NoWithVeto: sdk.ZeroDec(),
+			Total:      sdk.NewDecWithoutFra(100),
+		})
+		keeper.SetProposal(ctx, proposal)
+		return proposal.GetProposalID()
+	}
+	passedID := settle(gov.StatusPassed, 30, 20)
+	rejectedID := settle(gov.StatusRejected, 20, 20)
+	// the proposals not tallied yet are skipped
+	settle(gov.StatusVotingPeriod, 50, 0)
+
+	// the current params give the settled outcomes
+	simulations := gov.SimulateTallyParams(ctx, keeper, nil, nil, 10)
+	require.Len(t, simulations, 2)
+	require.Equal(t, rejectedID, simulations[0].ProposalID)
+	require.Equal(t, passedID, simulations[1].ProposalID)
+	require.False(t, simulations[0].Changed)
+	require.False(t, simulations[1].Changed)
+
+	// the rejected proposal reaches the lower quorum but not the threshold
+	quorum := sdk.NewDecWithPrec(4, 1)
+	simulations = gov.SimulateTallyParams(ctx, keeper, &quorum, nil, 10)
+	require.False(t, simulations[0].Passes)
+	require.False(t, simulations[0].Changed)
+
+	// and passes with a lower threshold
+	threshold := sdk.NewDecWithPrec(45, 2)
+	simulations = gov.SimulateTallyParams(ctx, keeper, &quorum, &threshold, 10)
+	require.True(t, simulations[0].Passes)
+	require.True(t, simulations[0].Changed)
+
+	// the passed proposal fails with a higher threshold
+	threshold = sdk.NewDecWithPrec(7, 1)
+	simulations = gov.SimulateTallyParams(ctx, keeper, nil, &threshold, 1)
+	require.Len(t, simulations, 1)
+	require.Equal(t, rejectedID, simulations[0].ProposalID)
+	simulations = gov.SimulateTallyParams(ctx, keeper, nil, &threshold, 2)
+	require.False(t, simulations[1].Passes)
+	require.True(t, simulations[1].Changed)
+}
@@ -0,0 +1,190 @@
+package gov
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov/events"
+)
+
+//-----------------------------------------------------------
+// Execution Delay
+
+// CancelExecutionSetting is the description of a CancelExecution proposal, it cancels the execution of a
+// proposal pending execution on the native chain, or on a side chain if SideChainID is set
+type CancelExecutionSetting struct {
+	ProposalID  int64  `json:"proposal_id"`
+	SideChainID string `json:"side_chain_id,omitempty"`
+}
+
+func (setting CancelExecutionSetting) Check() error {
+	if setting.ProposalID <= 0 {
+		return fmt.Errorf("invalid proposal id %d", setting.ProposalID)
+	}
+	return nil
+}
+
+// parseCancelExecutionSetting parses the description of a CancelExecution proposal
+func parseCancelExecutionSetting(codespace sdk.CodespaceType, description string) (CancelExecutionSetting, sdk.Error) {
+	var setting CancelExecutionSetting
+	if err := msgCdc.UnmarshalJSON([]byte(description), &setting); err != nil {
+		return setting, ErrInvalidDescription(codespace, fmt.Sprintf("the description of a cancel execution proposal is not a cancel execution setting: %v", err))
+	}
+	if err := setting.Check(); err != nil {
+		return setting, ErrInvalidDescription(codespace, err.Error())
+	}
+	return setting, nil
+}
+
+// executionDelay returns the delay between the passing of a proposal and its execution, the emergency
+// proposals are never delayed
+func (keeper Keeper) executionDelay(ctx sdk.Context, proposal Proposal) time.Duration {
+	if proposal.GetEmergency() {
+		return 0
+	}
+	return keeper.GetExecutionDelayParams(ctx).Delay(proposal.GetProposalType())
+}
+
+// GetExecutionTime returns the time a proposal pending execution is executed at
+func (keeper Keeper) GetExecutionTime(ctx sdk.Context, proposalID int64) (time.Time, bool) {
+	store := ctx.KVStore(keeper.storeKey)
+	bz := store.Get(KeyExecutionTime(proposalID))
+	if bz == nil {
+		return time.Time{}, false
+	}
+	executionTime, err := sdk.ParseTimeBytes(bz)
+	if err != nil {
+		panic(err)
+	}
+	return executionTime, true
+}
+
+// ExecutionQueueInsert queues a proposal pending execution until executionTime
+func (keeper Keeper) ExecutionQueueInsert(ctx sdk.Context, proposalID int64, executionTime time.Time) {
+	store := ctx.KVStore(keeper.storeKey)
+	store.Set(KeyExecutionQueueProposal(executionTime, proposalID), []byte{})
+	store.Set(KeyExecutionTime(proposalID), sdk.FormatTimeBytes(executionTime))
+}
+
+// ExecutionQueueRemove removes a proposal from the execution queue
+func (keeper Keeper) ExecutionQueueRemove(ctx sdk.Context, proposalID int64) {
+	executionTime, ok := keeper.GetExecutionTime(ctx, proposalID)
+	if !ok {
+		return
+	}
+	store := ctx.KVStore(keeper.storeKey)
+	store.Delete(KeyExecutionQueueProposal(executionTime, proposalID))
+	store.Delete(KeyExecutionTime(proposalID))
+}
+
+// dueExecutions returns the ids of the proposals whose execution time is not after endTime, in execution order
+func (keeper Keeper) dueExecutions(ctx sdk.Context, endTime time.Time) []int64 {
+	store := ctx.KVStore(keeper.storeKey)
+	endKey := append(append([]byte{}, KeyExecutionQueue...), sdk.FormatTimeBytes(endTime)...)
+	iterator := store.Iterator(KeyExecutionQueue, sdk.PrefixEndBytes(endKey))
+	defer iterator.Close()
+
+	proposalIDs := make([]int64, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		proposalID, err := strconv.ParseInt(string(key[len(key)-20:]), 10, 64)
+		if err != nil {
+			panic(err)
+		}
+		proposalIDs = append(proposalIDs, proposalID)
+	}
+	return proposalIDs
+}
+
+// executeProposal applies the effects of a passed proposal, those of the proposals consumed by the other
+// modules, e.g. the param hub, are applied by them once they see the passed proposal
+func executeProposal(ctx sdk.Context, keeper Keeper, chainId string, proposal Proposal) sdk.Events {
+	resEvents := sdk.EmptyEvents()
+	switch proposal.GetProposalType() {
+	case ProposalTypeCircuitBreaker:
+		executeCircuitBreaker(ctx, keeper, proposal)
+	case ProposalTypeGenericParamChange:
+		executeParamChange(ctx, keeper, proposal)
+	case ProposalTypeCancelExecution:
+		resEvents = resEvents.AppendEvents(executeCancelExecution(ctx, keeper, proposal))
+	case ProposalTypeBatch:
+		executeBatch(ctx, keeper, proposal)
+	case ProposalTypeForceUndelegation:
+		executeForceUndelegation(ctx, keeper, proposal)
+	default:
+		executeRegisteredProposal(ctx, keeper, chainId, proposal)
+	}
+	if chainId != NativeChainID {
+		resEvents = resEvents.AppendEvents(sendProposalResult(ctx, keeper, chainId, proposal))
+	}
+	return resEvents
+}
+
+// releasePendingProposals executes the proposals of the chain of ctx whose execution delay is over
+func releasePendingProposals(ctx sdk.Context, keeper Keeper, chainId string) sdk.Events {
+	logger := ctx.Logger().With("module", "x/gov")
+	resEvents := sdk.EmptyEvents()
+	for _, proposalID := range keeper.dueExecutions(ctx, ctx.BlockHeader().Time) {
+		keeper.ExecutionQueueRemove(ctx, proposalID)
+		proposal := keeper.GetProposal(ctx, proposalID)
+		if proposal == nil || proposal.GetStatus() != StatusPendingExecution {
+			continue
+		}
+
+		proposal.SetStatus(StatusPassed)
+		resEvents = resEvents.AppendEvents(executeProposal(ctx, keeper, chainId, proposal))
+		keeper.SetProposal(ctx, proposal)
+
+		logger.Info(fmt.Sprintf("proposal %d (%s) executed after its execution delay", proposalID, proposal.GetTitle()))
+		resEvents = resEvents.AppendEvent(proposalEvent(ctx, keeper, events.EventTypeProposalPassed, proposal).
+			AppendAttributes(sdk.NewAttribute(events.Outcome, proposal.GetStatus().String())))
+	}
+	return resEvents
+}
+
+// executeCancelExecution cancels the execution of the target proposal of a passed CancelExecution proposal,
+// which must still be pending execution
+func executeCancelExecution(ctx sdk.Context, keeper Keeper, proposal Proposal) sdk.Events {
+	logger := ctx.Logger().With("module", "x/gov")
+	setting, err := parseCancelExecutionSetting(keeper.codespace, proposal.GetDescription())
+	if err != nil {
+		logger.Error("Get broken cancel execution setting, will skip.", "proposalId", proposal.GetProposalID(), "err", err)
+		return nil
+	}
+
+	targetCtx := ctx.DepriveSideChainKeyPrefix()
+	if setting.SideChainID != "" {
+		if keeper.ScKeeper == nil {
+			logger.Error("Side chains are not supported, will skip.", "proposalId", proposal.GetProposalID())
+			return nil
+		}
+		var scErr error
+		targetCtx, scErr = keeper.ScKeeper.PrepareCtxForSideChain(targetCtx, setting.SideChainID)
+		if scErr != nil {
+			logger.Error("Get invalid side chain, will skip.", "proposalId", proposal.GetProposalID(), "err", scErr)
+			return nil
+		}
+	}
+	target := keeper.GetProposal(targetCtx, setting.ProposalID)
+	if target == nil || target.GetStatus() != StatusPendingExecution {
+		logger.Error("The proposal to cancel is not pending execution, will skip.",
+			"proposalId", proposal.GetProposalID(), "target", setting.ProposalID)
+		return nil
+	}
+
+	keeper.ExecutionQueueRemove(targetCtx, target.GetProposalID())
+	target.SetStatus(StatusCancelled)
+	keeper.SetProposal(targetCtx, target)
+	proposal.SetStatus(StatusExecuted)
+	keeper.AppendAuditRecord(ctx, setting.SideChainID, proposal, AuditActionExecutionCancelled)
+
+	logger.Info(fmt.Sprintf("proposal %d cancelled the execution of proposal %d", proposal.GetProposalID(), target.GetProposalID()))
+	event := sdk.NewEvent(events.EventTypeProposalExecutionCancelled, sdk.NewAttribute(events.ProposalID,
+		strconv.FormatInt(target.GetProposalID(), 10)))
+	if setting.SideChainID != "" {
+		event = event.AppendAttributes(sdk.NewAttribute(events.SideChainID, setting.SideChainID))
+	}
+	return sdk.Events{event}
+}
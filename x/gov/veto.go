@@ -0,0 +1,94 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov/events"
+)
+
+// CommunityPoolFunder is optionally implemented by the CommunityPool to receive the deposits of the vetoed
+// proposals, see VetoDepositCommunityPool
+type CommunityPoolFunder interface {
+	// FundCommunityPool sends amount from sender to the community pool
+	FundCommunityPool(ctx sdk.Context, amount sdk.Coins, sender sdk.AccAddress) sdk.Error
+}
+
+// vetoed tells whether a rejected proposal was vetoed, the NoWithVeto votes exceeding the veto of tallyParams
+func vetoed(tallyResults TallyResult, tallyParams TallyParams) bool {
+	totalVotingPower := tallyResults.Yes.Add(tallyResults.Abstain).Add(tallyResults.No).Add(tallyResults.NoWithVeto)
+	if totalVotingPower.IsZero() {
+		return false
+	}
+	return tallyResults.NoWithVeto.Quo(totalVotingPower).GT(tallyParams.Veto)
+}
+
+// removeDeposits deletes all the deposits on a specific proposal and returns them with their total
+func (keeper Keeper) removeDeposits(ctx sdk.Context, proposalID int64) ([]Deposit, sdk.Coins) {
+	store := ctx.KVStore(keeper.storeKey)
+	depositsIterator := keeper.GetDeposits(ctx, proposalID)
+	var deposits []Deposit
+	depositCoins := sdk.Coins{}
+	for ; depositsIterator.Valid(); depositsIterator.Next() {
+		deposit := Deposit{}
+		keeper.cdc.MustUnmarshalBinaryLengthPrefixed(depositsIterator.Value(), &deposit)
+
+		depositCoins = depositCoins.Plus(deposit.Amount)
+		store.Delete(depositsIterator.Key())
+		deposits = append(deposits, deposit)
+	}
+	depositsIterator.Close()
+	return deposits, depositCoins
+}
+
+// BurnDeposits burns the deposits on a specific proposal, the burnt deposits are returned and kept as settled deposits
+func (keeper Keeper) BurnDeposits(ctx sdk.Context, proposalID int64) []Deposit {
+	deposits, depositCoins := keeper.removeDeposits(ctx, proposalID)
+	keeper.setSettledDeposits(ctx, deposits, DepositOutcomeBurnt)
+
+	_, _, err := keeper.ck.SubtractCoins(ctx, DepositedCoinsAccAddr, depositCoins)
+	if err != nil {
+		panic(fmt.Sprintf("burn deposits error(%s) should not happen", err.Error()))
+	}
+	keeper.pool.AddAddrs([]sdk.AccAddress{DepositedCoinsAccAddr})
+	return deposits
+}
+
+// SendDepositsToCommunityPool sends the deposits on a specific proposal to the community pool, the deposits
+// sent are returned and kept as settled deposits. It fails without side effect unless the CommunityPool of the
+// keeper is a CommunityPoolFunder.
+func (keeper Keeper) SendDepositsToCommunityPool(ctx sdk.Context, proposalID int64) ([]Deposit, sdk.Error) {
+	funder, ok := keeper.communityPool.(CommunityPoolFunder)
+	if !ok {
+		return nil, sdk.ErrInternal("the community pool can not be funded")
+	}
+	deposits, depositCoins := keeper.removeDeposits(ctx, proposalID)
+	keeper.setSettledDeposits(ctx, deposits, DepositOutcomeCommunityPool)
+
+	// the community pool is shared by the native chain and the side chains
+	if err := funder.FundCommunityPool(ctx.DepriveSideChainKeyPrefix(), depositCoins, DepositedCoinsAccAddr); err != nil {
+		panic(fmt.Sprintf("fund community pool error(%s) should not happen", err.Error()))
+	}
+	keeper.pool.AddAddrs([]sdk.AccAddress{DepositedCoinsAccAddr})
+	return deposits, nil
+}
+
+// settleVetoedDeposits applies the VetoParams of the chain of ctx to the deposits of a vetoed proposal,
+// it returns the events of the deposits and whether they were refunded
+func settleVetoedDeposits(ctx sdk.Context, keeper Keeper, chainId string, proposal Proposal) (sdk.Events, bool) {
+	proposalID := proposal.GetProposalID()
+	switch keeper.GetVetoParams(ctx).DepositAction {
+	case VetoDepositRefund:
+		return depositEvents(events.EventTypeDepositRefunded, chainId, keeper.RefundDeposits(ctx, proposalID)), true
+	case VetoDepositBurn:
+		return depositEvents(events.EventTypeDepositBurnt, chainId, keeper.BurnDeposits(ctx, proposalID)), false
+	case VetoDepositCommunityPool:
+		deposits, err := keeper.SendDepositsToCommunityPool(ctx, proposalID)
+		if err == nil {
+			return depositEvents(events.EventTypeDepositToCommunityPool, chainId, deposits), false
+		}
+		ctx.Logger().With("module", "x/gov").Error("distribute the deposits of the vetoed proposal instead",
+			"proposalId", proposalID, "err", err.Error())
+	}
+	return depositEvents(events.EventTypeDepositDistributed, chainId, keeper.DistributeDeposits(ctx, proposalID)), false
+}
@@ -0,0 +1,54 @@
+package gov
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov/events"
+)
+
+// CommunityPool is the source of the vote incentives, e.g. the distribution keeper
+type CommunityPool interface {
+	// DistributeFromCommunityPool sends amount from the community pool to receiver, it fails
+	// without side effect if the pool is short of amount
+	DistributeFromCommunityPool(ctx sdk.Context, amount sdk.Coins, receiver sdk.AccAddress) sdk.Error
+}
+
+// distributeVoteIncentives rewards the validators that voted on a tallied proposal, whatever its
+// outcome, from the community pool. The reward and the pool are shared by the native chain and
+// the side chains, the rewards are paid to the fee addresses of the validators until the pool is
+// short of the reward.
+func distributeVoteIncentives(ctx sdk.Context, keeper Keeper, chainId string, proposal Proposal, voters []sdk.ValAddress) sdk.Events {
+	resEvents := sdk.EmptyEvents()
+	if keeper.communityPool == nil || len(voters) == 0 {
+		return resEvents
+	}
+	nativeCtx := ctx.DepriveSideChainKeyPrefix()
+	reward := keeper.GetVoteIncentiveParams(nativeCtx).Reward
+	if !reward.IsPositive() {
+		return resEvents
+	}
+
+	for _, voter := range voters {
+		validator := keeper.vs.Validator(ctx, voter)
+		if validator == nil {
+			continue
+		}
+		feeAddr := validator.GetFeeAddr()
+		if err := keeper.communityPool.DistributeFromCommunityPool(nativeCtx, reward, feeAddr); err != nil {
+			ctx.Logger().Info("stop distributing vote incentives", "proposal", proposal.GetProposalID(), "err", err.Error())
+			break
+		}
+		keeper.pool.AddAddrs([]sdk.AccAddress{feeAddr})
+
+		event := sdk.NewEvent(events.EventTypeVoteIncentiveDistributed,
+			sdk.NewAttribute(events.ProposalID, strconv.FormatInt(proposal.GetProposalID(), 10)),
+			sdk.NewAttribute(events.Validator, voter.String()),
+			sdk.NewAttribute(events.Amount, reward.String()))
+		if chainId != NativeChainID {
+			event = event.AppendAttributes(sdk.NewAttribute(events.SideChainID, chainId))
+		}
+		resEvents = resEvents.AppendEvent(event)
+	}
+	return resEvents
+}
@@ -0,0 +1,86 @@
+package gov
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+//-----------------------------------------------------------
+// Voter history
+
+// The votes are deleted once their proposal is tallied, so every vote cast on the native chain or on a side chain is
+// also recorded in the voter history kept on the native chain, by voter, chain and proposal id. A changed vote
+// replaces the recorded one. The history starts at the upgrade it is introduced with.
+
+// VoterHistoryEntry is a vote of the voter history
+type VoterHistoryEntry struct {
+	SideChainId string `json:"side_chain_id"` // empty for the native chain
+	Vote        Vote   `json:"vote"`
+	// Status is the current status of the proposal, set when the history is queried
+	Status ProposalStatus `json:"proposal_status"`
+}
+
+// recordVote records a vote cast on the chain of ctx in the voter history
+func (keeper Keeper) recordVote(ctx sdk.Context, vote Vote) {
+	sideChainId, ok := keeper.sideChainIdOf(ctx)
+	if !ok {
+		sideChainId = NativeChainID
+	}
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	bz := keeper.cdc.MustMarshalBinaryLengthPrefixed(VoterHistoryEntry{SideChainId: sideChainId, Vote: vote})
+	store.Set(KeyVoterHistory(vote.Voter, sideChainId, vote.ProposalID), bz)
+}
+
+// proposalStatus returns the current status of a proposal of a chain, pruned or not, StatusNil if it is unknown.
+// The status of a side chain proposal is that of its entry in the proposal index.
+func (keeper Keeper) proposalStatus(ctx sdk.Context, sideChainId string, proposalID int64) ProposalStatus {
+	chainCtx := ctx.DepriveSideChainKeyPrefix()
+	if sideChainId == NativeChainID {
+		if proposal := keeper.GetProposal(chainCtx, proposalID); proposal != nil {
+			return proposal.GetStatus()
+		}
+	} else {
+		if bz := chainCtx.KVStore(keeper.storeKey).Get(KeyIndexedProposal(sideChainId, proposalID)); bz != nil {
+			var indexed IndexedProposal
+			keeper.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &indexed)
+			return indexed.Status
+		}
+		// the pruned proposals are not indexed anymore
+		if keeper.ScKeeper == nil {
+			return StatusNil
+		}
+		scCtx, err := keeper.ScKeeper.PrepareCtxForSideChain(chainCtx, sideChainId)
+		if err != nil {
+			return StatusNil
+		}
+		chainCtx = scCtx
+	}
+	if archived, ok := keeper.GetArchivedProposal(chainCtx, proposalID); ok {
+		return archived.Status
+	}
+	return StatusNil
+}
+
+// voterHistoryEntry unmarshals an entry of the voter history and sets the current status of its proposal
+func (keeper Keeper) voterHistoryEntry(ctx sdk.Context, value []byte) VoterHistoryEntry {
+	var entry VoterHistoryEntry
+	keeper.cdc.MustUnmarshalBinaryLengthPrefixed(value, &entry)
+	entry.Status = keeper.proposalStatus(ctx, entry.SideChainId, entry.Vote.ProposalID)
+	return entry
+}
+
+// GetVoterHistory returns the votes of a voter on the proposals of all the chains, by chain and proposal id.
+// Only the votes on the proposals with status are returned unless it is StatusNil.
+func (keeper Keeper) GetVoterHistory(ctx sdk.Context, voterAddr sdk.AccAddress, status ProposalStatus) []VoterHistoryEntry {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(keeper.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, KeyVoterHistorySubspace(voterAddr))
+	defer iterator.Close()
+
+	entries := make([]VoterHistoryEntry, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		entry := keeper.voterHistoryEntry(ctx, iterator.Value())
+		if status == StatusNil || entry.Status == status {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -14,6 +14,12 @@ const (
 	CodeWritePackageForbidden sdk.CodeType = 104
 )
 
+func init() {
+	sdk.RegisterCodespace(DefaultCodespace, "ibc",
+		CodeDuplicatedSequence, CodeFeeParamMismatch, CodeInvalidChainId,
+		CodeWritePackageForbidden)
+}
+
 func ErrDuplicatedSequence(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeDuplicatedSequence, msg)
 }
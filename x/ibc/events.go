@@ -2,6 +2,8 @@ package ibc
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -11,8 +13,31 @@ const (
 	ibcEventType                 = "IBCPackage"
 	ibcPackageInfoAttributeKey   = "IBCPackageInfo"
 	ibcPackageInfoAttributeValue = "%d" + separator + "%d" + separator + "%d" // destChainID channelID sequence
+
+	// PackageEventKey is the key of the attributes of the packages created in a block, among the
+	// events of the block in a Tendermint subscription
+	PackageEventKey = ibcEventType + "." + ibcPackageInfoAttributeKey
 )
 
 func buildIBCPackageAttributeValue(sideChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) string {
 	return fmt.Sprintf(ibcPackageInfoAttributeValue, sideChainID, channelID, sequence)
 }
+
+// ParsePackageEventValue parses the value of an attribute of the event emitted for the packages
+// created in a block
+func ParsePackageEventValue(value string) (destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64, err error) {
+	parts := strings.Split(value, separator)
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("invalid package event value %q", value)
+	}
+	if destChainID, err = sdk.ParseChainID(parts[0]); err != nil {
+		return 0, 0, 0, err
+	}
+	if channelID, err = sdk.ParseChannelID(parts[1]); err != nil {
+		return 0, 0, 0, err
+	}
+	if sequence, err = strconv.ParseUint(parts[2], 10, 64); err != nil {
+		return 0, 0, 0, err
+	}
+	return destChainID, channelID, sequence, nil
+}
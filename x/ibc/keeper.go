@@ -78,6 +78,10 @@ func (k *Keeper) CreateRawIBCPackageById(ctx sdk.Context, destChainID sdk.ChainI
 func (k *Keeper) CreateRawIBCPackageByIdWithFee(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
 	packageType sdk.CrossChainPackageType, packageLoad []byte, relayerFee big.Int) (uint64, sdk.Error) {
 
+	_, span := sdk.StartSpan(ctx, "CrossChainPackage", "chain_id", uint16(destChainID),
+		"channel_id", uint8(channelID), "package_type", uint8(packageType))
+	defer span.End()
+
 	if packageType == sdk.SynCrossChainPackageType && k.sideKeeper.GetChannelSendPermission(ctx, destChainID, channelID) != sdk.ChannelAllow {
 		return 0, ErrWritePackageForbidden(DefaultCodespace, fmt.Sprintf("channel %d is not allowed to write syn package", channelID))
 	}
@@ -94,6 +98,7 @@ func (k *Keeper) CreateRawIBCPackageByIdWithFee(ctx sdk.Context, destChainID sdk
 
 	kvStore.Set(key, append(packageHeader, packageLoad...))
 	k.sideKeeper.IncrSendSequence(ctx, destChainID, channelID)
+	span.SetAttribute("sequence", sequence)
 
 	if ctx.IsDeliverTx() {
 		k.packageCollector.collectedPackages = append(k.packageCollector.collectedPackages, packageRecord{
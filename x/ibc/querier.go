@@ -0,0 +1,61 @@
+package ibc
+
+import (
+	"fmt"
+	"strconv"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PackageProofQuerier answers the queries "/prove/<route>/<srcChainID>/<destChainID>/<channelID>/<sequence>"
+// with the cross chain package of the sequence, header included, along with the proof of the package
+type PackageProofQuerier struct {
+	storeName string
+}
+
+var _ sdk.ProofQuerier = PackageProofQuerier{}
+
+// NewPackageProofQuerier returns a PackageProofQuerier of the packages kept in the store storeName
+func NewPackageProofQuerier(storeName string) PackageProofQuerier {
+	return PackageProofQuerier{storeName: storeName}
+}
+
+// PackageProofQueryPath returns the path of the query of a package answered by a PackageProofQuerier
+// registered under route
+func PackageProofQueryPath(route string, srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) string {
+	return fmt.Sprintf("/prove/%s/%d/%d/%d/%d", route, srcChainID, destChainID, channelID, sequence)
+}
+
+// Key implements sdk.ProofQuerier
+func (q PackageProofQuerier) Key(path []string, req abci.RequestQuery) (string, []byte, sdk.Error) {
+	if len(path) != 4 {
+		return "", nil, sdk.ErrUnknownRequest("expected path like <srcChainID>/<destChainID>/<channelID>/<sequence>")
+	}
+	srcChainID, err := sdk.ParseChainID(path[0])
+	if err != nil {
+		return "", nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid source chain id %s", path[0]))
+	}
+	destChainID, err := sdk.ParseChainID(path[1])
+	if err != nil {
+		return "", nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid destination chain id %s", path[1]))
+	}
+	channelID, err := sdk.ParseChannelID(path[2])
+	if err != nil {
+		return "", nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid channel id %s", path[2]))
+	}
+	sequence, err := strconv.ParseUint(path[3], 10, 64)
+	if err != nil {
+		return "", nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid sequence %s", path[3]))
+	}
+	return q.storeName, buildIBCPackageKey(srcChainID, destChainID, channelID, sequence), nil
+}
+
+// Result implements sdk.ProofQuerier
+func (q PackageProofQuerier) Result(value []byte, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+	if value == nil {
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("no package of sequence %s", path[3]))
+	}
+	return value, nil
+}
@@ -0,0 +1,41 @@
+package oracle
+
+import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+var _ sdk.BlockValidatorCreator = NewClaimBlockValidator
+
+// claimBlockValidator rejects a block containing more than one claim of a validator for the same chain
+type claimBlockValidator struct {
+	claimed map[string]bool
+}
+
+// NewClaimBlockValidator creates the BlockValidator allowing at most one claim per validator
+// and per side chain in a block, see BaseApp.SetBlockValidatorCreator. The app should also set
+// the checker of NewClaimTxChecker, which keeps the duplicated claims out of the mempool so that
+// the proposers do not build blocks the validator rejects.
+func NewClaimBlockValidator(_ sdk.Context, _ abci.Header) sdk.BlockValidator {
+	return &claimBlockValidator{claimed: make(map[string]bool)}
+}
+
+func (v *claimBlockValidator) ValidateTx(_ sdk.Context, tx sdk.Tx) sdk.Error {
+	for _, msg := range tx.GetMsgs() {
+		claimMsg, ok := msg.(types.ClaimMsg)
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%d:%s", claimMsg.ChainId, claimMsg.ValidatorAddress.String())
+		if v.claimed[key] {
+			return types.ErrDuplicateClaim(fmt.Sprintf("validator %s has already claimed for chain %d in this block",
+				claimMsg.ValidatorAddress.String(), claimMsg.ChainId))
+		}
+		v.claimed[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+func TestClaimBlockValidator(t *testing.T) {
+	val1 := sdk.AccAddress([]byte("validator1__________"))
+	val2 := sdk.AccAddress([]byte("validator2__________"))
+	newTx := func(chainId sdk.ChainID, validator sdk.AccAddress, sequence uint64) sdk.Tx {
+		msg := NewClaimMsg(chainId, sequence, []byte("payload"), validator)
+		return auth.NewStdTx([]sdk.Msg{msg}, nil, "", 0, nil)
+	}
+
+	validator := NewClaimBlockValidator(sdk.Context{}, abci.Header{})
+	require.Nil(t, validator.ValidateTx(sdk.Context{}, newTx(1, val1, 0)))
+	require.Nil(t, validator.ValidateTx(sdk.Context{}, newTx(1, val2, 0)))
+	require.Nil(t, validator.ValidateTx(sdk.Context{}, newTx(2, val1, 0)))
+	require.NotNil(t, validator.ValidateTx(sdk.Context{}, newTx(1, val1, 1)))
+
+	// a new block starts from scratch
+	validator = NewClaimBlockValidator(sdk.Context{}, abci.Header{})
+	require.Nil(t, validator.ValidateTx(sdk.Context{}, newTx(1, val1, 1)))
+}
@@ -0,0 +1,44 @@
+package oracle
+
+import (
+	"encoding/hex"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// EndBlocker executes the delayed prophecies whose execute height has been reached, so that the
+// channel of a high value claim does not stall until another claim arrives after the delay
+func EndBlocker(ctx sdk.Context, oracleKeeper Keeper) {
+	logger := ctx.Logger().With("module", "oracle")
+	for _, delayed := range oracleKeeper.DequeueMatureDelayedProphecies(ctx) {
+		prophecy := delayed.Prophecy
+		prophecy.Status.Text = types.SuccessStatusText
+
+		var packages types.Packages
+		payload, err := hex.DecodeString(prophecy.Status.FinalClaim)
+		if err == nil {
+			err = rlp.DecodeBytes(payload, &packages)
+		}
+		if err != nil {
+			logger.Error("failed to decode delayed claim", "id", prophecy.ID, "err", err)
+			oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
+			continue
+		}
+
+		// there is no tx executing the packages
+		cacheCtx, write := ctx.CacheContext()
+		result := executePackages(cacheCtx.WithValue(baseapp.TxHashKey, ""), oracleKeeper, delayed.ChainId, prophecy, packages)
+		if !result.IsOK() {
+			// the validators have to claim the packages again
+			logger.Error("failed to execute delayed claim", "id", prophecy.ID, "err", result.Log)
+			oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
+			continue
+		}
+		write()
+		logger.Info("executed delayed claim", "id", prophecy.ID)
+		ctx.EventManager().EmitEvents(result.Events)
+	}
+}
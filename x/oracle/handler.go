@@ -3,6 +3,7 @@ package oracle
 import (
 	"encoding/hex"
 	"fmt"
+	"math"
 	"runtime/debug"
 	"strconv"
 
@@ -54,9 +55,20 @@ func handleClaimMsg(ctx sdk.Context, oracleKeeper Keeper, msg ClaimMsg) sdk.Resu
 		return types.ErrInvalidPayload("decode packages error").Result()
 	}
 
+	if delayed, ok := delayHighValueClaim(ctx, oracleKeeper, msg.ChainId, prophecy, packages); ok {
+		return sdk.Result{
+			Log: fmt.Sprintf("high value claim is delayed until height %d", delayed.Status.ExecuteHeight),
+		}
+	}
+
+	return executePackages(ctx, oracleKeeper, msg.ChainId, prophecy, packages)
+}
+
+// executePackages executes the packages of a successful prophecy and deletes it
+func executePackages(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, prophecy types.Prophecy, packages types.Packages) sdk.Result {
 	events := make([]sdk.Event, 0, len(packages))
 	for _, pack := range packages {
-		event, sdkErr := handlePackage(ctx, oracleKeeper, msg.ChainId, &pack)
+		event, sdkErr := handlePackage(ctx, oracleKeeper, chainId, &pack)
 		if sdkErr != nil {
 			// only do log, but let reset package get chance to execute.
 			ctx.Logger().With("module", "oracle").Error(fmt.Sprintf("process package failed, channel=%d, sequence=%d, error=%v", pack.ChannelId, pack.Sequence, sdkErr))
@@ -67,18 +79,57 @@ func handleClaimMsg(ctx sdk.Context, oracleKeeper Keeper, msg ClaimMsg) sdk.Resu
 		events = append(events, event)
 
 		// increase channel sequence
-		oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, msg.ChainId, pack.ChannelId)
+		oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, chainId, pack.ChannelId)
 	}
 
 	// delete prophecy when execute claim success
 	oracleKeeper.DeleteProphecy(ctx, prophecy.ID)
-	oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, msg.ChainId, types.RelayPackagesChannelId)
+	oracleKeeper.ScKeeper.IncrReceiveSequence(ctx, chainId, types.RelayPackagesChannelId)
 
 	return sdk.Result{
 		Events: events,
 	}
 }
 
+// delayHighValueClaim delays a successful claim carrying more value than the finality threshold,
+// validators can still cancel it by changing their claims during the delay.
+func delayHighValueClaim(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, prophecy types.Prophecy, packages types.Packages) (types.Prophecy, bool) {
+	// the claim has already been delayed
+	if prophecy.Status.ExecuteHeight != 0 {
+		return prophecy, false
+	}
+	valueThreshold, delay := oracleKeeper.GetFinalityParams(ctx)
+	if valueThreshold <= 0 || delay <= 0 {
+		return prophecy, false
+	}
+	if packagesValue(ctx, oracleKeeper, packages) <= valueThreshold {
+		return prophecy, false
+	}
+	return oracleKeeper.DelayProphecy(ctx, chainId, prophecy, delay), true
+}
+
+// packagesValue sums the value of the packages whose channel app is a CrossChainPackageValuer.
+// A package whose value cannot be decoded is considered to carry the maximum value.
+func packagesValue(ctx sdk.Context, oracleKeeper Keeper, packages types.Packages) int64 {
+	var total int64
+	for _, pack := range packages {
+		valuer, ok := oracleKeeper.ScKeeper.GetCrossChainApp(ctx, pack.ChannelId).(sdk.CrossChainPackageValuer)
+		if !ok {
+			continue
+		}
+		packageType, _, err := sTypes.DecodePackageHeader(pack.Payload)
+		if err != nil {
+			return math.MaxInt64
+		}
+		value, err := valuer.PackageValue(ctx, pack.Payload[sTypes.PackageHeaderLength:], packageType)
+		if err != nil || value < 0 || total > math.MaxInt64-value {
+			return math.MaxInt64
+		}
+		total += value
+	}
+	return total
+}
+
 func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pack *types.Package) (sdk.Event, sdk.Error) {
 	logger := ctx.Logger().With("module", "x/oracle")
 
@@ -146,7 +197,7 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 		if crash {
 			var ibcErr sdk.Error
 			var sendSeq uint64
-			if sdk.IsUpgrade(sdk.FixFailAckPackage) && len(pack.Payload) >= sTypes.PackageHeaderLength {
+			if ctx.UpgradeMgr().IsUpgrade(sdk.FixFailAckPackage) && len(pack.Payload) >= sTypes.PackageHeaderLength {
 				sendSeq, ibcErr = oracleKeeper.IbcKeeper.CreateRawIBCPackageById(ctx, chainId,
 					pack.ChannelId, sdk.FailAckCrossChainPackageType, pack.Payload[sTypes.PackageHeaderLength:])
 			} else {
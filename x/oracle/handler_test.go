@@ -0,0 +1,153 @@
+package oracle
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// valuedApp is a cross chain app whose packages all carry the same value
+type valuedApp struct {
+	value    int64
+	executed int
+}
+
+func (app *valuedApp) ExecuteSynPackage(sdk.Context, []byte, int64) sdk.ExecuteResult {
+	app.executed++
+	return sdk.ExecuteResult{}
+}
+
+func (app *valuedApp) ExecuteAckPackage(sdk.Context, []byte) sdk.ExecuteResult {
+	app.executed++
+	return sdk.ExecuteResult{}
+}
+
+func (app *valuedApp) ExecuteFailAckPackage(sdk.Context, []byte) sdk.ExecuteResult {
+	app.executed++
+	return sdk.ExecuteResult{}
+}
+
+func (app *valuedApp) PackageValue(sdk.Context, []byte, sdk.CrossChainPackageType) (int64, error) {
+	return app.value, nil
+}
+
+func setupHandlerTest(t *testing.T) (sdk.Context, Keeper, []sdk.ValAddress) {
+	mapp := mock.NewApp()
+	stake.RegisterCodec(mapp.Cdc)
+
+	keyGlobalParams := sdk.NewKVStoreKey("params")
+	tkeyGlobalParams := sdk.NewTransientStoreKey("transient_params")
+	keyStake := sdk.NewKVStoreKey("stake")
+	keyStakeReward := sdk.NewKVStoreKey("stake_reward")
+	tkeyStake := sdk.NewTransientStoreKey("transient_stake")
+	keyOracle := sdk.NewKVStoreKey("oracle")
+	keyIbc := sdk.NewKVStoreKey("ibc")
+	keySideChain := sdk.NewKVStoreKey("side")
+
+	pk := params.NewKeeper(mapp.Cdc, keyGlobalParams, tkeyGlobalParams)
+	ck := bank.NewBaseKeeper(mapp.AccountKeeper)
+	sk := stake.NewKeeper(mapp.Cdc, keyStake, keyStakeReward, tkeyStake, ck, nil, pk.Subspace(stake.DefaultParamspace), mapp.RegisterCodespace(stake.DefaultCodespace), sdk.ChainID(0), "")
+	scK := sidechain.NewKeeper(keySideChain, pk.Subspace(sidechain.DefaultParamspace), mapp.Cdc)
+	ibcKeeper := ibc.NewKeeper(keyIbc, pk.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace, scK)
+
+	mapp.SetInitChainer(func(ctx sdk.Context, req abci.RequestInitChain) abci.ResponseInitChain {
+		mapp.InitChainer(ctx, req)
+		stakeGenesis := stake.DefaultGenesisState()
+		stakeGenesis.Pool.LooseTokens = sdk.NewDecWithoutFra(100000)
+		validators, err := stake.InitGenesis(ctx, sk, stakeGenesis)
+		require.NoError(t, err)
+		return abci.ResponseInitChain{Validators: validators}
+	})
+	require.NoError(t, mapp.CompleteSetup(keyStake, tkeyStake, keyOracle, keyIbc, keySideChain, keyGlobalParams, tkeyGlobalParams))
+	genAccs, addrs, _, _ := mock.CreateGenAccounts(3, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 5000e8)})
+	mock.SetGenesis(mapp, genAccs)
+	keeper := NewKeeper(mapp.Cdc, keyOracle, pk.Subspace("testoracle"), sk, scK, ibcKeeper, ck, &sdk.Pool{})
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Height: 10})
+
+	stakeHandler := stake.NewStakeHandler(sk)
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+		msg := stake.NewMsgCreateValidator(valAddrs[i], ed25519.GenPrivKey().PubKey(), sdk.NewCoin(gov.DefaultDepositDenom, 5),
+			stake.NewDescription("T", "E", "S", "T"), stake.NewCommissionMsg(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()))
+		require.True(t, stakeHandler(ctx, msg).IsOK())
+	}
+	stake.EndBlocker(ctx, sk)
+
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(6, 1), FinalityValueThreshold: 100, FinalityDelay: 5})
+	_, _, err := ck.AddCoins(ctx, sdk.PegAccount, sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, 100e8)})
+	require.Nil(t, err)
+	return ctx, keeper, valAddrs
+}
+
+func newPackage(channelId sdk.ChannelID, sequence uint64) types.Package {
+	payload := append(sTypes.EncodePackageHeader(sdk.AckCrossChainPackageType, *big.NewInt(1)), []byte("payload")...)
+	return types.Package{ChannelId: channelId, Sequence: sequence, Payload: payload}
+}
+
+func TestHandleHighValueClaim(t *testing.T) {
+	ctx, keeper, valAddrs := setupHandlerTest(t)
+	lowValueApp, highValueApp := &valuedApp{value: 50}, &valuedApp{value: 200}
+	require.NoError(t, keeper.ScKeeper.RegisterChannel("low", 100, lowValueApp))
+	require.NoError(t, keeper.ScKeeper.RegisterChannel("high", 101, highValueApp))
+	handler := NewHandler(keeper)
+	chainId := sdk.ChainID(1)
+
+	claim := func(ctx sdk.Context, sequence uint64, packages types.Packages) []sdk.Result {
+		payload, err := rlp.EncodeToBytes(packages)
+		require.NoError(t, err)
+		var results []sdk.Result
+		for _, valAddr := range valAddrs[:2] {
+			results = append(results, handler(ctx, NewClaimMsg(chainId, sequence, payload, sdk.AccAddress(valAddr))))
+		}
+		return results
+	}
+
+	require.Equal(t, int64(250), packagesValue(ctx, keeper, types.Packages{newPackage(100, 0), newPackage(101, 0)}))
+	// channels without valuer carry no value, undecodable packages the maximum one
+	require.Equal(t, int64(0), packagesValue(ctx, keeper, types.Packages{newPackage(102, 0)}))
+	require.Equal(t, int64(math.MaxInt64), packagesValue(ctx, keeper, types.Packages{{ChannelId: 100, Payload: []byte{1}}}))
+
+	// low value claims are executed right away
+	results := claim(ctx, 0, types.Packages{newPackage(100, 0)})
+	require.True(t, results[1].IsOK(), results[1].Log)
+	require.Equal(t, 1, lowValueApp.executed)
+	require.Equal(t, uint64(1), keeper.ScKeeper.GetReceiveSequence(ctx, chainId, types.RelayPackagesChannelId))
+
+	// high value claims are delayed
+	results = claim(ctx, 1, types.Packages{newPackage(101, 0)})
+	require.True(t, results[1].IsOK(), results[1].Log)
+	require.Contains(t, results[1].Log, "delayed until height 15")
+	require.Equal(t, 0, highValueApp.executed)
+	prophecy, found := keeper.GetProphecy(ctx, types.GetClaimId(chainId, types.RelayPackagesChannelId, 1))
+	require.True(t, found)
+	require.Equal(t, types.DelayedStatusText, prophecy.Status.Text)
+
+	// and executed by the EndBlocker once the delay has passed, without any new claim
+	EndBlocker(ctx.WithBlockHeight(14), keeper)
+	require.Equal(t, 0, highValueApp.executed)
+	EndBlocker(ctx.WithBlockHeight(15), keeper)
+	require.Equal(t, 1, highValueApp.executed)
+	require.Equal(t, uint64(2), keeper.ScKeeper.GetReceiveSequence(ctx, chainId, types.RelayPackagesChannelId))
+	require.Equal(t, uint64(1), keeper.ScKeeper.GetReceiveSequence(ctx, chainId, 101))
+	_, found = keeper.GetProphecy(ctx, types.GetClaimId(chainId, types.RelayPackagesChannelId, 1))
+	require.False(t, found)
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"encoding/binary"
+
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/pubsub"
@@ -39,6 +41,17 @@ const (
 	DefaultParamSpace = "oracle"
 )
 
+// DelayedProphecyQueuePrefix indexes the delayed prophecies by execute height, the ids of the
+// prophecies are made of digits and colons so they never collide with the prefix
+var DelayedProphecyQueuePrefix = []byte{0x01}
+
+func delayedProphecyKey(executeHeight int64, id string) []byte {
+	key := make([]byte, len(DelayedProphecyQueuePrefix)+8, len(DelayedProphecyQueuePrefix)+8+len(id))
+	copy(key, DelayedProphecyQueuePrefix)
+	binary.BigEndian.PutUint64(key[len(DelayedProphecyQueuePrefix):], uint64(executeHeight))
+	return append(key, id...)
+}
+
 func ParamTypeTable() param.TypeTable {
 	return param.NewTypeTable().RegisterParamSet(&types.Params{})
 }
@@ -65,6 +78,20 @@ func (k Keeper) GetConsensusNeeded(ctx sdk.Context) (consensusNeeded sdk.Dec) {
 	return
 }
 
+// GetFinalityParams returns the value above which a claim is delayed and the delay in blocks
+func (k Keeper) GetFinalityParams(ctx sdk.Context) (valueThreshold int64, delay int64) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyFinalityValueThreshold, &valueThreshold)
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyFinalityDelay, &delay)
+	return
+}
+
+// GetUserLaneLimit returns the maximum total size of the txs of a block other than oracle claims,
+// it can be used as the baseapp.UserLaneLimit of the app
+func (k Keeper) GetUserLaneLimit(ctx sdk.Context) (maxBytes int64) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyUserLaneMaxBytes, &maxBytes)
+	return
+}
+
 func (k *Keeper) EnablePrometheusMetrics() {
 	k.Metrics = metrics.PrometheusMetrics()
 }
@@ -136,6 +163,14 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 	switch prophecy.Status.Text {
 	case types.PendingStatusText:
 		// continue processing
+	case types.DelayedStatusText:
+		executeHeight := prophecy.Status.ExecuteHeight
+		prophecy = k.processDelayedClaim(ctx, prophecy, claim)
+		if prophecy.Status.Text != types.DelayedStatusText {
+			ctx.KVStore(k.storeKey).Delete(delayedProphecyKey(executeHeight, prophecy.ID))
+		}
+		k.setProphecy(ctx, prophecy)
+		return prophecy, nil
 	default:
 		return types.Prophecy{}, types.ErrProphecyFinalized()
 	}
@@ -147,6 +182,79 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 	return prophecy, nil
 }
 
+// DelayProphecy puts a successful prophecy on hold for delay blocks before it can be executed,
+// it is executed by the EndBlocker of the execute height unless a claim settles it before.
+func (k Keeper) DelayProphecy(ctx sdk.Context, chainId sdk.ChainID, prophecy types.Prophecy, delay int64) types.Prophecy {
+	prophecy.Status = types.Status{
+		Text:          types.DelayedStatusText,
+		FinalClaim:    prophecy.Status.FinalClaim,
+		ExecuteHeight: ctx.BlockHeight() + delay,
+	}
+	k.setProphecy(ctx, prophecy)
+
+	bz := make([]byte, 2)
+	binary.BigEndian.PutUint16(bz, uint16(chainId))
+	ctx.KVStore(k.storeKey).Set(delayedProphecyKey(prophecy.Status.ExecuteHeight, prophecy.ID), bz)
+	return prophecy
+}
+
+// DelayedProphecy is a delayed prophecy and the chain it comes from
+type DelayedProphecy struct {
+	ChainId  sdk.ChainID
+	Prophecy types.Prophecy
+}
+
+// DequeueMatureDelayedProphecies removes the delayed prophecies whose execute height has been reached
+// from the queue and returns them, with the prophecies settled by a claim in the meantime left out.
+func (k Keeper) DequeueMatureDelayedProphecies(ctx sdk.Context) []DelayedProphecy {
+	store := ctx.KVStore(k.storeKey)
+	end := delayedProphecyKey(ctx.BlockHeight()+1, "")
+	iterator := store.Iterator(DelayedProphecyQueuePrefix, end)
+
+	var keys [][]byte
+	var matured []DelayedProphecy
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, append([]byte{}, iterator.Key()...))
+		id := string(iterator.Key()[len(DelayedProphecyQueuePrefix)+8:])
+		prophecy, found := k.GetProphecy(ctx, id)
+		if !found || prophecy.Status.Text != types.DelayedStatusText {
+			continue
+		}
+		matured = append(matured, DelayedProphecy{
+			ChainId:  sdk.ChainID(binary.BigEndian.Uint16(iterator.Value())),
+			Prophecy: prophecy,
+		})
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+	return matured
+}
+
+// processDelayedClaim adds a claim to a delayed prophecy. Validators can cancel the prophecy by
+// changing their claim so that the final claim loses consensus, otherwise the prophecy becomes
+// successful again once the execute height is reached.
+func (k Keeper) processDelayedClaim(ctx sdk.Context, prophecy types.Prophecy, claim types.Claim) types.Prophecy {
+	finalClaim, executeHeight := prophecy.Status.FinalClaim, prophecy.Status.ExecuteHeight
+
+	prophecy.AddClaim(claim.ValidatorAddress, claim.Payload)
+	prophecy.Status = types.NewStatus(types.PendingStatusText, "")
+	prophecy = k.processCompletion(ctx, prophecy)
+
+	if prophecy.Status.Text != types.SuccessStatusText || prophecy.Status.FinalClaim != finalClaim {
+		prophecy.Status = types.NewStatus(types.FailedStatusText, "")
+		return prophecy
+	}
+	text := types.SuccessStatusText
+	if ctx.BlockHeight() < executeHeight {
+		text = types.DelayedStatusText
+	}
+	prophecy.Status = types.Status{Text: text, FinalClaim: finalClaim, ExecuteHeight: executeHeight}
+	return prophecy
+}
+
 // processCompletion looks at a given prophecy
 // and assesses whether the claim with the highest power on that prophecy has enough
 // power to be considered successful, or alternatively,
@@ -278,3 +278,70 @@ func TestNonValidator(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "claim must be made by actively bonded validator"))
 }
+
+func TestDelayedProphecy(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Height: 10})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(6, 1), FinalityValueThreshold: 100, FinalityDelay: 5})
+
+	valueThreshold, delay := keeper.GetFinalityParams(ctx)
+	require.Equal(t, int64(100), valueThreshold)
+	require.Equal(t, int64(5), delay)
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+
+	prophecy = keeper.DelayProphecy(ctx, 1, prophecy, delay)
+	require.Equal(t, types.DelayedStatusText, prophecy.Status.Text)
+	require.Equal(t, int64(15), prophecy.Status.ExecuteHeight)
+	require.Len(t, keeper.DequeueMatureDelayedProphecies(ctx.WithBlockHeight(14)), 0)
+
+	// still delayed before the execute height
+	prophecy, err = keeper.ProcessClaim(ctx.WithBlockHeight(14), types.NewClaim(TestID, valAddrs[2], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.DelayedStatusText, prophecy.Status.Text)
+
+	// successful once the execute height is reached
+	prophecy, err = keeper.ProcessClaim(ctx.WithBlockHeight(15), types.NewClaim(TestID, valAddrs[2], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.SuccessStatusText, prophecy.Status.Text)
+	require.Equal(t, TestString, prophecy.Status.FinalClaim)
+	require.Equal(t, int64(15), prophecy.Status.ExecuteHeight)
+	// settled by the claim, it is not executed by the EndBlocker anymore
+	require.Len(t, keeper.DequeueMatureDelayedProphecies(ctx.WithBlockHeight(15)), 0)
+
+	// validators changing their claims during the delay cancel the prophecy
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(AlternateTestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(AlternateTestID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	keeper.DelayProphecy(ctx, 1, prophecy, delay)
+
+	prophecy, err = keeper.ProcessClaim(ctx.WithBlockHeight(11), types.NewClaim(AlternateTestID, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+	require.Equal(t, types.FailedStatusText, prophecy.Status.Text)
+	require.Len(t, keeper.DequeueMatureDelayedProphecies(ctx.WithBlockHeight(15)), 0)
+
+	// matured delayed prophecies are dequeued once
+	prophecy = types.NewProphecy(TestID)
+	prophecy.Status = types.Status{Text: types.SuccessStatusText, FinalClaim: TestString}
+	keeper.DelayProphecy(ctx, 2, prophecy, delay)
+	matured := keeper.DequeueMatureDelayedProphecies(ctx.WithBlockHeight(16))
+	require.Len(t, matured, 1)
+	require.Equal(t, sdk.ChainID(2), matured[0].ChainId)
+	require.Equal(t, TestID, matured[0].Prophecy.ID)
+	require.Len(t, keeper.DequeueMatureDelayedProphecies(ctx.WithBlockHeight(16)), 0)
+}
@@ -5,8 +5,8 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/oracle/types"
 )
 
-func RegisterUpgradeBeginBlocker(keeper Keeper) {
-	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.LaunchBscUpgrade, func(ctx sdk.Context) {
+func RegisterUpgradeBeginBlocker(upgradeMgr *sdk.UpgradeManager, keeper Keeper) {
+	upgradeMgr.RegisterBeginBlocker(sdk.LaunchBscUpgrade, func(ctx sdk.Context) {
 		keeper.SetParams(ctx, types.Params{ConsensusNeeded: types.DefaultConsensusNeeded})
 	})
 
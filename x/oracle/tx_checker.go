@@ -0,0 +1,32 @@
+package oracle
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// NewClaimTxChecker creates the ProposalTxChecker allowing at most one claim per validator and per
+// side chain in a block, see BaseApp.SetProposalTxChecker. The claims are recorded in the transient
+// store of tkey, which has to be mounted with BaseApp.MountStoresTransient, so only the claims of
+// successful txs are counted, and the rule applies to CheckTx as well as to DeliverTx. It is the
+// mempool side of the block rule of NewClaimBlockValidator.
+func NewClaimTxChecker(tkey sdk.StoreKey) sdk.ProposalTxChecker {
+	return func(ctx sdk.Context, tx sdk.Tx) sdk.Error {
+		store := ctx.TransientStore(tkey)
+		for _, msg := range tx.GetMsgs() {
+			claimMsg, ok := msg.(types.ClaimMsg)
+			if !ok {
+				continue
+			}
+			key := []byte(fmt.Sprintf("%d:%s", claimMsg.ChainId, claimMsg.ValidatorAddress.String()))
+			if store.Has(key) {
+				return types.ErrDuplicateClaim(fmt.Sprintf("validator %s has already claimed for chain %d in this block",
+					claimMsg.ValidatorAddress.String(), claimMsg.ChainId))
+			}
+			store.Set(key, []byte{})
+		}
+		return nil
+	}
+}
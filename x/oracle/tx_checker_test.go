@@ -0,0 +1,42 @@
+package oracle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+func TestClaimTxChecker(t *testing.T) {
+	tkey := sdk.NewTransientStoreKey("transient_oracle")
+	ms := store.NewCommitMultiStore(dbm.NewMemDB())
+	ms.MountStoreWithDB(tkey, sdk.StoreTypeTransient, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{}, sdk.RunTxModeDeliver, log.NewNopLogger())
+
+	val1 := sdk.AccAddress([]byte("validator1__________"))
+	val2 := sdk.AccAddress([]byte("validator2__________"))
+	newTx := func(chainId sdk.ChainID, validator sdk.AccAddress, sequence uint64) sdk.Tx {
+		msg := NewClaimMsg(chainId, sequence, []byte("payload"), validator)
+		return auth.NewStdTx([]sdk.Msg{msg}, nil, "", 0, nil)
+	}
+
+	checker := NewClaimTxChecker(tkey)
+	require.Nil(t, checker(ctx, newTx(1, val1, 0)))
+	require.Nil(t, checker(ctx, newTx(1, val2, 0)))
+	require.Nil(t, checker(ctx, newTx(2, val1, 0)))
+	err := checker(ctx, newTx(1, val1, 1))
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeDuplicateClaim, err.Code())
+
+	// a new block starts from scratch
+	ms.Commit()
+	require.Nil(t, checker(ctx, newTx(1, val1, 1)))
+}
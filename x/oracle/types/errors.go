@@ -24,8 +24,17 @@ const (
 	CodeInvalidLengthOfPayload        sdk.CodeType = 1011
 	CodeFeeOverflow                   sdk.CodeType = 1012
 	CodeInvalidPayload                sdk.CodeType = 1013
+	CodeDuplicateClaim                sdk.CodeType = 1014
 )
 
+func init() {
+	sdk.RegisterCodespace(DefaultCodespace, "oracle",
+		CodeProphecyNotFound, CodeMinimumConsensusNeededInvalid, CodeNoClaims,
+		CodeInvalidIdentifier, CodeProphecyFinalized, CodeDuplicateMessage, CodeInvalidClaim,
+		CodeInvalidValidator, CodeInternalDB, CodeInvalidSequence, CodeChannelNotRegistered,
+		CodeInvalidLengthOfPayload, CodeFeeOverflow, CodeInvalidPayload, CodeDuplicateClaim)
+}
+
 func ErrProphecyNotFound() sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeProphecyNotFound, fmt.Sprintf("prophecy with given id not found"))
 }
@@ -85,3 +94,7 @@ func ErrFeeOverflow(msg string) sdk.Error {
 func ErrInvalidPayload(msg string) sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidPayload, msg)
 }
+
+func ErrDuplicateClaim(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeDuplicateClaim, msg)
+}
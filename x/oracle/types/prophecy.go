@@ -13,16 +13,38 @@ var (
 	// prophecy to be finalized
 	DefaultConsensusNeeded      sdk.Dec = sdk.NewDecWithPrec(7, 1)
 	ParamStoreKeyProphecyParams         = []byte("prophecyParams")
+
+	ParamStoreKeyFinalityValueThreshold = []byte("finalityValueThreshold")
+	ParamStoreKeyFinalityDelay          = []byte("finalityDelay")
+	ParamStoreKeyUserLaneMaxBytes       = []byte("userLaneMaxBytes")
 )
 
 type Params struct {
 	ConsensusNeeded sdk.Dec `json:"ConsensusNeeded"` //  Minimum deposit for a proposal to enter voting period.
+
+	// Claims carrying more value than FinalityValueThreshold are executed FinalityDelay blocks
+	// after reaching consensus. Zero values disable the delay.
+	FinalityValueThreshold int64 `json:"FinalityValueThreshold"`
+	FinalityDelay          int64 `json:"FinalityDelay"`
+
+	// UserLaneMaxBytes limits the total size of the txs of a block other than oracle claims,
+	// so that claims are not crowded out of full blocks. Zero disables the limit.
+	UserLaneMaxBytes int64 `json:"UserLaneMaxBytes"`
 }
 
 func (p *Params) UpdateCheck() error {
 	if p.ConsensusNeeded.IsNil() || p.ConsensusNeeded.GT(sdk.OneDec()) || p.ConsensusNeeded.LT(sdk.NewDecWithPrec(5, 1)) {
 		return fmt.Errorf("the value should be in range 0.5 to 1")
 	}
+	if p.FinalityValueThreshold < 0 {
+		return fmt.Errorf("the finality value threshold should not be negative")
+	}
+	if p.FinalityDelay < 0 {
+		return fmt.Errorf("the finality delay should not be negative")
+	}
+	if p.UserLaneMaxBytes < 0 {
+		return fmt.Errorf("the user lane max bytes should not be negative")
+	}
 	return nil
 }
 
@@ -33,6 +55,9 @@ func (p *Params) GetParamAttribute() (string, bool) {
 func (p *Params) KeyValuePairs() params.KeyValuePairs {
 	return params.KeyValuePairs{
 		{ParamStoreKeyProphecyParams, &p.ConsensusNeeded},
+		{ParamStoreKeyFinalityValueThreshold, &p.FinalityValueThreshold},
+		{ParamStoreKeyFinalityDelay, &p.FinalityDelay},
+		{ParamStoreKeyUserLaneMaxBytes, &p.UserLaneMaxBytes},
 	}
 }
 
@@ -125,7 +150,7 @@ func (prophecy Prophecy) FindHighestClaim(ctx sdk.Context, stakeKeeper StakingKe
 		}
 	}
 	totalPower := int64(0)
-	if !sdk.IsUpgrade(sdk.BEP159Phase2) {
+	if !ctx.UpgradeMgr().IsUpgrade(sdk.BEP159Phase2) {
 		totalPower = stakeKeeper.GetLastTotalPower(ctx)
 	} else {
 		for _, power := range validatorsPowerMap {
@@ -165,6 +190,8 @@ func NewProphecy(id string) Prophecy {
 type Status struct {
 	Text       StatusText `json:"text"`
 	FinalClaim string     `json:"final_claim"`
+	// ExecuteHeight is set when a high value claim is delayed, it is the height from which it can be executed
+	ExecuteHeight int64 `json:"execute_height,omitempty"`
 }
 
 // NewStatus returns a new Status with the given data contained
@@ -13,13 +13,16 @@ const (
 	PendingStatusText StatusText = iota
 	SuccessStatusText
 	FailedStatusText
+	// DelayedStatusText is the status of a high value claim that reached consensus but waits for the finality delay
+	DelayedStatusText
 )
 
-var StatusTextToString = [...]string{"pending", "success", "failed"}
+var StatusTextToString = [...]string{"pending", "success", "failed", "delayed"}
 var StringToStatusText = map[string]StatusText{
 	"pending": PendingStatusText,
 	"success": SuccessStatusText,
 	"failed":  FailedStatusText,
+	"delayed": DelayedStatusText,
 }
 
 func (text StatusText) String() string {
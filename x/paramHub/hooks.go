@@ -81,7 +81,14 @@ func (hooks SCParamsChangeHooks) OnProposalSubmitted(ctx sdk.Context, proposal g
 	if err != nil {
 		return fmt.Errorf("get broken data when unmarshal SCParamsChange msg. proposalId %d, err %v", proposal.GetProposalID(), err)
 	}
-	return changeParam.Check()
+	// use literal string to avoid import cycle
+	if changeParam.HasParam("gov") && !ctx.UpgradeMgr().IsUpgrade(sdk.GovSideChainDepositParams) {
+		return fmt.Errorf("the gov params of a side chain can not be changed before %s", sdk.GovSideChainDepositParams)
+	}
+	if err := changeParam.Check(); err != nil {
+		return err
+	}
+	return changeParam.UpgradeCheck(ctx.UpgradeMgr())
 }
 
 //---------------------    BCParamsChangeHook  -----------------
@@ -97,7 +104,7 @@ var _ gov.GovHooks = BCParamsChangeHooks{}
 
 func (hooks BCParamsChangeHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
 	// enable after BEP159
-	if !sdk.IsUpgrade(sdk.BEP159) {
+	if !ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 		return nil
 	}
 	if proposal.GetProposalType() != gov.ProposalTypeParameterChange {
@@ -110,5 +117,8 @@ func (hooks BCParamsChangeHooks) OnProposalSubmitted(ctx sdk.Context, proposal g
 	if err != nil {
 		return fmt.Errorf("get broken data when unmarshal BCParamsChange msg. proposalId %d, err %v", proposal.GetProposalID(), err)
 	}
-	return changeParam.Check()
+	if err := changeParam.Check(); err != nil {
+		return err
+	}
+	return changeParam.UpgradeCheck(ctx.UpgradeMgr())
 }
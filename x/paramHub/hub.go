@@ -9,8 +9,8 @@ import (
 
 const AbciQueryPrefix = "param"
 
-func RegisterUpgradeBeginBlocker(paramHub *ParamHub) {
-	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.BEP9, func(ctx sdk.Context) {
+func RegisterUpgradeBeginBlocker(upgradeMgr *sdk.UpgradeManager, paramHub *ParamHub) {
+	upgradeMgr.RegisterBeginBlocker(sdk.BEP9, func(ctx sdk.Context) {
 		timeLockFeeParams := []param.FeeParam{
 			&param.FixedFeeParams{MsgType: "timeLock", Fee: TimeLockFee, FeeFor: sdk.FeeForProposer},
 			&param.FixedFeeParams{MsgType: "timeUnlock", Fee: TimeUnlockFee, FeeFor: sdk.FeeForProposer},
@@ -18,13 +18,13 @@ func RegisterUpgradeBeginBlocker(paramHub *ParamHub) {
 		}
 		paramHub.UpdateFeeParams(ctx, timeLockFeeParams)
 	})
-	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.BEP12, func(ctx sdk.Context) {
+	upgradeMgr.RegisterBeginBlocker(sdk.BEP12, func(ctx sdk.Context) {
 		accountFlagsFeeParams := []param.FeeParam{
 			&param.FixedFeeParams{MsgType: "setAccountFlags", Fee: SetAccountFlagsFee, FeeFor: sdk.FeeForProposer},
 		}
 		paramHub.UpdateFeeParams(ctx, accountFlagsFeeParams)
 	})
-	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.BEP3, func(ctx sdk.Context) {
+	upgradeMgr.RegisterBeginBlocker(sdk.BEP3, func(ctx sdk.Context) {
 		swapFeeParams := []param.FeeParam{
 			&param.FixedFeeParams{MsgType: "HTLT", Fee: HTLTFee, FeeFor: sdk.FeeForProposer},
 			&param.FixedFeeParams{MsgType: "depositHTLT", Fee: DepositHTLTFee, FeeFor: sdk.FeeForProposer},
@@ -33,7 +33,7 @@ func RegisterUpgradeBeginBlocker(paramHub *ParamHub) {
 		}
 		paramHub.UpdateFeeParams(ctx, swapFeeParams)
 	})
-	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.LaunchBscUpgrade, func(ctx sdk.Context) {
+	upgradeMgr.RegisterBeginBlocker(sdk.LaunchBscUpgrade, func(ctx sdk.Context) {
 		if ctx.ChainID() == sdk.ChainIdGanges {
 			updateFeeParams := []param.FeeParam{
 				&param.FixedFeeParams{MsgType: "side_create_validator", Fee: CreateSideChainValidatorFee, FeeFor: sdk.FeeForProposer},
@@ -90,7 +90,7 @@ func RegisterUpgradeBeginBlocker(paramHub *ParamHub) {
 			paramHub.UpdateFeeParams(ctx, updateFeeParams)
 		}
 	})
-	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.BEP8, func(ctx sdk.Context) {
+	upgradeMgr.RegisterBeginBlocker(sdk.BEP8, func(ctx sdk.Context) {
 		if ctx.ChainID() == sdk.ChainIdGanges {
 			miniTokenFeeParams := []param.FeeParam{
 				&param.FixedFeeParams{MsgType: "tinyIssueMsg", Fee: TinyIssueFee, FeeFor: sdk.FeeForAll},
@@ -109,20 +109,20 @@ func RegisterUpgradeBeginBlocker(paramHub *ParamHub) {
 			paramHub.UpdateFeeParams(ctx, miniTokenFeeParams)
 		}
 	})
-	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.BEP82, func(ctx sdk.Context) {
+	upgradeMgr.RegisterBeginBlocker(sdk.BEP82, func(ctx sdk.Context) {
 		updateFeeParams := []param.FeeParam{
 			&param.FixedFeeParams{MsgType: "transferOwnership", Fee: TransferOwnershipFee, FeeFor: sdk.FeeForProposer},
 		}
 		paramHub.UpdateFeeParams(ctx, updateFeeParams)
 	})
-	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.BEP153, func(ctx sdk.Context) {
+	upgradeMgr.RegisterBeginBlocker(sdk.BEP153, func(ctx sdk.Context) {
 		crossStakeFeeParams := []param.FeeParam{
 			&param.FixedFeeParams{MsgType: "crossDistributeRewardRelayFee", Fee: CrossDistributeRewardRelayFee, FeeFor: sdk.FeeForAll},
 			&param.FixedFeeParams{MsgType: "crossDistributeUndelegatedRelayFee", Fee: CrossDistributeUndelegatedRelayFee, FeeFor: sdk.FeeForAll},
 		}
 		paramHub.UpdateFeeParams(ctx, crossStakeFeeParams)
 	})
-	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.BEP159, func(ctx sdk.Context) {
+	upgradeMgr.RegisterBeginBlocker(sdk.BEP159, func(ctx sdk.Context) {
 		updateFeeParams := []param.FeeParam{
 			&param.FixedFeeParams{MsgType: "create_validator_open", Fee: CreateValidatorFee, FeeFor: sdk.FeeForProposer},
 			&param.FixedFeeParams{MsgType: "edit_validator", Fee: EditChainValidatorFee, FeeFor: sdk.FeeForProposer},
@@ -200,5 +200,11 @@ func init() {
 		"redelegate":                         fees.FixedFeeCalculatorGen,
 		"undelegate":                         fees.FixedFeeCalculatorGen,
 		"unjail":                             fees.FixedFeeCalculatorGen,
+		"set_attestation":                    fees.FixedFeeCalculatorGen,
+		"recheck_attestation":                fees.FixedFeeCalculatorGen,
+		"sweepDust":                          fees.FixedFeeCalculatorGen,
+		"vote_weighted":                      fees.FixedFeeCalculatorGen,
+		"side_vote_weighted":                 fees.FixedFeeCalculatorGen,
+		"vote_choice":                        fees.FixedFeeCalculatorGen,
 	}
 }
@@ -31,6 +31,7 @@ func (keeper *Keeper) getLastBCParamChanges(ctx sdk.Context) *types.BCChangePara
 			keeper.Logger(ctx).Error("The BCParamsChange proposal is invalid, will skip.", "proposalId", (*latestProposal).GetProposalID(), "param", changeParam, "err", err)
 			return nil
 		}
+		keeper.govKeeper.AppendAuditRecord(ctx, "", *latestProposal, gov.AuditActionParamsChanged)
 		return &changeParam
 	}
 	return nil
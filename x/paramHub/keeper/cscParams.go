@@ -31,7 +31,7 @@ func (keeper *Keeper) updateCSCParams(ctx sdk.Context, updates types.CSCParamCha
 	}
 }
 
-func (keeper *Keeper) getLastCSCParamChanges(ctx sdk.Context) []types.CSCParamChange {
+func (keeper *Keeper) getLastCSCParamChanges(ctx sdk.Context, sideChainId string) []types.CSCParamChange {
 	changes := make([]types.CSCParamChange, 0)
 	// It can still find the valid proposal if the block chain stop for SafeToleratePeriod time
 	backPeriod := SafeToleratePeriod + gov.MaxVotingPeriod
@@ -58,6 +58,7 @@ func (keeper *Keeper) getLastCSCParamChanges(ctx sdk.Context) []types.CSCParamCh
 				keeper.Logger(ctx).Error("The CSCParamChange proposal is invalid, will skip.", "proposalId", proposal.GetProposalID(), "param", changeParam, "err", err)
 				return false
 			}
+			keeper.govKeeper.AppendAuditRecord(ctx, sideChainId, proposal, gov.AuditActionParamsChanged)
 			changes = append(changes, changeParam)
 		}
 		return false
@@ -125,6 +125,7 @@ func (keeper *Keeper) getLastFeeChangeParam(ctx sdk.Context) []types.FeeParam {
 			log.Error("The latest fee param change proposal is invalid.", "proposalId", (*latestProposal).GetProposalID(), "param", changeParam, "err", err)
 			return nil
 		}
+		keeper.govKeeper.AppendAuditRecord(ctx, "", *latestProposal, gov.AuditActionFeesChanged)
 		return changeParam.FeeParams
 	}
 	return nil
@@ -115,11 +115,11 @@ func (keeper *Keeper) EndBreatheBlock(ctx sdk.Context) {
 	if feeChange != nil {
 		keeper.notifyOnUpdate(ctx, feeChange)
 	}
-	if sdk.IsUpgrade(sdk.LaunchBscUpgrade) {
-		_, storePrefixes := keeper.ScKeeper.GetAllSideChainPrefixes(ctx)
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LaunchBscUpgrade) {
+		sideChainIds, storePrefixes := keeper.ScKeeper.GetAllSideChainPrefixes(ctx)
 		for i := range storePrefixes {
 			sideChainCtx := ctx.WithSideChainKeyPrefix(storePrefixes[i])
-			scParamChanges := keeper.getLastSCParamChanges(sideChainCtx)
+			scParamChanges := keeper.getLastSCParamChanges(sideChainCtx, sideChainIds[i])
 			if scParamChanges != nil {
 				for _, change := range scParamChanges.SCParams {
 					keeper.notifyOnUpdate(sideChainCtx, change)
@@ -133,17 +133,17 @@ func (keeper *Keeper) EndBreatheBlock(ctx sdk.Context) {
 func (keeper *Keeper) EndBlock(ctx sdk.Context) {
 	log := keeper.Logger(ctx)
 	log.Info("Sync params proposals.")
-	if sdk.IsUpgrade(sdk.LaunchBscUpgrade) && keeper.ScKeeper != nil {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LaunchBscUpgrade) && keeper.ScKeeper != nil {
 		sideChainIds, storePrefixes := keeper.ScKeeper.GetAllSideChainPrefixes(ctx)
 		for idx := range storePrefixes {
 			sideChainCtx := ctx.WithSideChainKeyPrefix(storePrefixes[idx])
-			cscChanges := keeper.getLastCSCParamChanges(sideChainCtx)
+			cscChanges := keeper.getLastCSCParamChanges(sideChainCtx, sideChainIds[idx])
 			if len(cscChanges) > 0 {
 				keeper.notifyOnUpdate(sideChainCtx, types.CSCParamChanges{Changes: cscChanges, ChainID: sideChainIds[idx]})
 			}
 		}
 	}
-	if sdk.IsUpgrade(sdk.BEP159) {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 		bcParamChanges := keeper.getLastBCParamChanges(ctx)
 		if bcParamChanges != nil {
 			for _, change := range bcParamChanges.BCParams {
@@ -6,7 +6,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/paramHub/types"
 )
 
-func (keeper *Keeper) getLastSCParamChanges(ctx sdk.Context) *types.SCChangeParams {
+func (keeper *Keeper) getLastSCParamChanges(ctx sdk.Context, sideChainId string) *types.SCChangeParams {
 	var latestProposal *gov.Proposal
 	lastProposalId := keeper.GetLastSCParamChangeProposalId(ctx)
 	keeper.govKeeper.Iterate(ctx, nil, nil, gov.StatusPassed, lastProposalId.ProposalID, true, func(proposal gov.Proposal) bool {
@@ -31,6 +31,7 @@ func (keeper *Keeper) getLastSCParamChanges(ctx sdk.Context) *types.SCChangePara
 			keeper.Logger(ctx).Error("The SCParamsChange proposal is invalid, will skip.", "proposalId", (*latestProposal).GetProposalID(), "param", changeParam, "err", err)
 			return nil
 		}
+		keeper.govKeeper.AppendAuditRecord(ctx, sideChainId, *latestProposal, gov.AuditActionParamsChanged)
 		return &changeParam
 	}
 	return nil
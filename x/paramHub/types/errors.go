@@ -15,6 +15,11 @@ const (
 	CodeInvalidCrossChainPackage CodeType = 103
 )
 
+func init() {
+	sdk.RegisterCodespace(DefaultCodespace, "paramHub",
+		CodeMissSideChainId, CodeInvalidSideChainId, CodeInvalidCrossChainPackage)
+}
+
 func ErrMissSideChainId(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeMissSideChainId, "side chain id is missing")
 }
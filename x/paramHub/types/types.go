@@ -78,6 +78,15 @@ var (
 		"redelegate":            {},
 		"undelegate":            {},
 		"unjail":                {},
+
+		"set_attestation":     {},
+		"recheck_attestation": {},
+
+		"sweepDust": {},
+
+		"vote_weighted":      {},
+		"side_vote_weighted": {},
+		"vote_choice":        {},
 	}
 
 	ValidTransferFeeMsgTypes = map[string]struct{}{
@@ -299,18 +308,29 @@ type SCChangeParams struct {
 	Description string    `json:"description"`
 }
 
+// UpgradeChecker is implemented by the params whose valid values depend on the upgrades of the chain,
+// which UpdateCheck can not tell as it has no context
+type UpgradeChecker interface {
+	UpgradeCheck(upgradeMgr *sdk.UpgradeManager) error
+}
+
 func (s *SCChangeParams) Check() error {
 	// use literal string to avoid  import cycle
 	supportParams := []string{"slash", "ibc", "oracle", "staking"}
+	// the optional params may be left out of a change
+	optionalParams := []string{"gov"}
 
-	if len(s.SCParams) != len(supportParams) {
-		return fmt.Errorf("the sc_params length mismatch, suppose %d", len(supportParams))
+	if len(s.SCParams) < len(supportParams) || len(s.SCParams) > len(supportParams)+len(optionalParams) {
+		return fmt.Errorf("the sc_params length mismatch, suppose %d to %d", len(supportParams), len(supportParams)+len(optionalParams))
 	}
 
 	paramSet := make(map[string]bool)
 	for _, s := range supportParams {
 		paramSet[s] = true
 	}
+	for _, s := range optionalParams {
+		paramSet[s] = true
+	}
 
 	for _, sc := range s.SCParams {
 		if sc == nil {
@@ -327,9 +347,39 @@ func (s *SCChangeParams) Check() error {
 			return fmt.Errorf("unsupported param type %s", paramType)
 		}
 	}
+	for _, s := range supportParams {
+		if paramSet[s] {
+			return fmt.Errorf("missing param type %s", s)
+		}
+	}
 	return nil
 }
 
+// UpgradeCheck checks the params implementing UpgradeChecker against the upgrades of upgradeMgr
+func (s *SCChangeParams) UpgradeCheck(upgradeMgr *sdk.UpgradeManager) error {
+	for _, sc := range s.SCParams {
+		if checker, ok := sc.(UpgradeChecker); ok {
+			if err := checker.UpgradeCheck(upgradeMgr); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// HasParam tells whether the change has a param of paramType
+func (s *SCChangeParams) HasParam(paramType string) bool {
+	for _, sc := range s.SCParams {
+		if sc == nil {
+			continue
+		}
+		if t, _ := sc.GetParamAttribute(); t == paramType {
+			return true
+		}
+	}
+	return false
+}
+
 // ---------   Definition beacon chain prams change ------------------- //
 type BCParamSpaceProto struct {
 	ParamSpace subspace.Subspace
@@ -350,8 +400,10 @@ type BCChangeParams struct {
 func (s *BCChangeParams) Check() error {
 	// use literal string to avoid import cycle
 	supportParams := []string{"staking"}
+	// the params added later are optional, so that the proposals passed before are still valid
+	optionalParams := []string{"auth"}
 
-	if len(s.BCParams) != len(supportParams) {
+	if len(s.BCParams) < len(supportParams) || len(s.BCParams) > len(supportParams)+len(optionalParams) {
 		return fmt.Errorf("the bc_params length mismatch, suppose %d", len(supportParams))
 	}
 
@@ -359,6 +411,9 @@ func (s *BCChangeParams) Check() error {
 	for _, s := range supportParams {
 		paramSet[s] = true
 	}
+	for _, s := range optionalParams {
+		paramSet[s] = false
+	}
 
 	for _, bc := range s.BCParams {
 		if bc == nil {
@@ -369,11 +424,28 @@ func (s *BCChangeParams) Check() error {
 			return err
 		}
 		paramType := bc.GetBCParamAttribute()
-		if exist := paramSet[paramType]; exist {
+		if _, exist := paramSet[paramType]; exist {
 			delete(paramSet, paramType)
 		} else {
 			return fmt.Errorf("unsupported param type %s", paramType)
 		}
 	}
+	for paramType, required := range paramSet {
+		if required {
+			return fmt.Errorf("bc_params misses param type %s", paramType)
+		}
+	}
+	return nil
+}
+
+// UpgradeCheck checks the params implementing UpgradeChecker against the upgrades of upgradeMgr
+func (s *BCChangeParams) UpgradeCheck(upgradeMgr *sdk.UpgradeManager) error {
+	for _, bc := range s.BCParams {
+		if checker, ok := bc.(UpgradeChecker); ok {
+			if err := checker.UpgradeCheck(upgradeMgr); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
@@ -3,6 +3,7 @@ package types_test
 import (
 	"encoding/hex"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/oracle/types"
 	fTypes "github.com/cosmos/cosmos-sdk/x/paramHub/types"
@@ -158,6 +159,13 @@ func TestSCParamCheck(t *testing.T) {
 		{cp: generatSCParamChange(&stake.Params{UnbondingTime: 24 * time.Hour, MaxValidators: 10, BondDenom: "BNB", MinSelfDelegation: 100e8, MinDelegationChange: 1e5, RewardDistributionBatchSize: 5010}, 0), expectError: true},
 		{cp: fTypes.SCChangeParams{SCParams: []fTypes.SCParam{nil}}, expectError: true},
 		{cp: fTypes.SCChangeParams{SCParams: []fTypes.SCParam{}}, expectError: true},
+		// the gov params are optional
+		{cp: appendSCParam(generatSCParamChange(&types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1)}, 2), testGovParams(sdk.Coins{sdk.NewCoin("BNB", 1000e8)}, 24*time.Hour)), expectError: false},
+		{cp: appendSCParam(generatSCParamChange(&types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1)}, 2), testGovParams(sdk.Coins{}, 24*time.Hour)), expectError: true},
+		{cp: appendSCParam(generatSCParamChange(&types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1)}, 2), testGovParams(sdk.Coins{sdk.NewCoin("BNB", 1000e8)}, gov.MaxVotingPeriod+time.Second)), expectError: true},
+		{cp: appendSCParam(appendSCParam(generatSCParamChange(&types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1)}, 2), testGovParams(sdk.Coins{sdk.NewCoin("BNB", 1000e8)}, 0)), testGovParams(sdk.Coins{sdk.NewCoin("BNB", 1000e8)}, 0)), expectError: true},
+		// the required params can not be replaced by the optional ones
+		{cp: fTypes.SCChangeParams{SCParams: append(generatSCParamChange(&types.Params{ConsensusNeeded: sdk.NewDecWithPrec(7, 1)}, 2).SCParams[:3], testGovParams(sdk.Coins{sdk.NewCoin("BNB", 1000e8)}, 0))}, expectError: true},
 	}
 
 	for _, c := range testcases {
@@ -188,6 +196,18 @@ func generatSCParamChange(s fTypes.SCParam, idx int) fTypes.SCChangeParams {
 	return fTypes.SCChangeParams{SCParams: iScPrams, Description: "test"}
 }
 
+func appendSCParam(change fTypes.SCChangeParams, s fTypes.SCParam) fTypes.SCChangeParams {
+	change.SCParams = append(change.SCParams, s)
+	return change
+}
+
+func testGovParams(minDeposit sdk.Coins, votingPeriod time.Duration) *gov.SideChainParams {
+	return &gov.SideChainParams{
+		DepositParams: gov.DepositParams{MinDeposit: minDeposit, MaxDepositPeriod: 48 * time.Hour},
+		VotingParams:  gov.VotingParams{VotingPeriod: votingPeriod},
+	}
+}
+
 // Register concrete types on wire codec
 func testRegisterWire(cdc *amino.Codec) {
 	cdc.RegisterInterface((*fTypes.SCParam)(nil), nil)
@@ -1,6 +1,7 @@
 package subspace
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -142,6 +143,20 @@ func (s Subspace) Set(ctx sdk.Context, key []byte, param interface{}) {
 
 }
 
+// ParseParam unmarshals the JSON encoded value of a parameter into its registered type, it returns a
+// pointer to the value that can be passed to Set
+func (s Subspace) ParseParam(key []byte, bz []byte) (interface{}, error) {
+	ty, ok := s.table.m[string(key)]
+	if !ok {
+		return nil, fmt.Errorf("parameter %s is not registered in subspace %s", key, s.name)
+	}
+	ptr := reflect.New(ty).Interface()
+	if err := s.cdc.UnmarshalJSON(bz, ptr); err != nil {
+		return nil, fmt.Errorf("invalid value of parameter %s in subspace %s: %v", key, s.name, err)
+	}
+	return ptr, nil
+}
+
 // Get to ParamSet
 func (s Subspace) GetParamSet(ctx sdk.Context, ps ParamSet) {
 	for _, pair := range ps.KeyValuePairs() {
@@ -22,8 +22,10 @@ var (
 	CrossChainContractAddr, _ = hex.DecodeString("0000000000000000000000000000000000002000")
 )
 
-func (k *Keeper) getLastChanPermissionChanges(ctx sdk.Context) []types.ChanPermissionSetting {
+// getLastChanPermissionChanges returns the changes of the passed proposals, along with the proposals
+func (k *Keeper) getLastChanPermissionChanges(ctx sdk.Context) ([]types.ChanPermissionSetting, []gov.Proposal) {
 	changes := make([]types.ChanPermissionSetting, 0)
+	proposals := make([]gov.Proposal, 0)
 	// It can still find the valid proposal if the block chain stop for SafeToleratePeriod time
 	backPeriod := SafeToleratePeriod + gov.MaxVotingPeriod
 	k.govKeeper.Iterate(ctx, nil, nil, gov.StatusNil, 0, true, func(proposal gov.Proposal) bool {
@@ -62,10 +64,11 @@ func (k *Keeper) getLastChanPermissionChanges(ctx sdk.Context) []types.ChanPermi
 				return false
 			}
 			changes = append(changes, setting)
+			proposals = append(proposals, proposal)
 		}
 		return false
 	})
-	return changes
+	return changes, proposals
 }
 
 func (k *Keeper) SaveChannelSettingChangeToIbc(ctx sdk.Context, sideChainId sdk.ChainID, channelId sdk.ChannelID, permission sdk.ChannelPermission) (seq uint64, sdkErr sdk.Error) {
@@ -10,6 +10,11 @@ const (
 	CodeInvalidSideChainId sdk.CodeType = 101
 )
 
+func init() {
+	sdk.RegisterCodespace(DefaultCodespace, "sidechain",
+		CodeInvalidSideChainId)
+}
+
 func ErrInvalidSideChainId(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidSideChainId, msg)
 }
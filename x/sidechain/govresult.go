@@ -0,0 +1,71 @@
+package sidechain
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+var _ gov.ProposalResultSender = &Keeper{}
+
+// SendProposalResult pushes the result of a passed side chain proposal to the side chain through the
+// ProposalResultChannelId, it is called by the gov EndBlocker for the types in gov.ResultRelayParams.
+// The channel must be registered with RegisterProposalResultChannel and allowed to send.
+func (k *Keeper) SendProposalResult(ctx sdk.Context, sideChainId string, proposal gov.Proposal) (uint64, sdk.Error) {
+	destChainID, err := k.GetDestChainID(sideChainId)
+	if err != nil {
+		return 0, sdk.ErrInternal(fmt.Sprintf("unknown side chain %s", sideChainId))
+	}
+
+	tallyResult := proposal.GetTallyResult()
+	descriptionHash := sha256.Sum256([]byte(proposal.GetDescription()))
+	resultPackage := types.ProposalResultPackage{
+		ProposalId:      uint64(proposal.GetProposalID()),
+		ProposalType:    uint8(proposal.GetProposalType()),
+		DescriptionHash: descriptionHash[:],
+		Yes:             uint64(tallyResult.Yes.RawInt()),
+		Abstain:         uint64(tallyResult.Abstain.RawInt()),
+		No:              uint64(tallyResult.No.RawInt()),
+		NoWithVeto:      uint64(tallyResult.NoWithVeto.RawInt()),
+		Total:           uint64(tallyResult.Total.RawInt()),
+	}
+	bz, err := rlp.EncodeToBytes(&resultPackage)
+	if err != nil {
+		return 0, sdk.ErrInternal("failed to encode proposal result")
+	}
+	return k.ibcKeeper.CreateRawIBCPackageById(ctx, destChainID, types.ProposalResultChannelId, sdk.SynCrossChainPackageType, bz)
+}
+
+// RegisterProposalResultChannel registers the channel of the proposal results pushed to the side chains
+func (k *Keeper) RegisterProposalResultChannel() error {
+	return k.RegisterChannel(types.ProposalResultChannelName, types.ProposalResultChannelId, proposalResultApp{})
+}
+
+// proposalResultApp is the cross chain application of the proposal result channel, the side chain only
+// acknowledges the results
+type proposalResultApp struct{}
+
+func (app proposalResultApp) ExecuteSynPackage(ctx sdk.Context, payload []byte, _ int64) sdk.ExecuteResult {
+	ctx.Logger().Error("receive unexpected proposal result syn package")
+	return sdk.ExecuteResult{}
+}
+
+func (app proposalResultApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	return sdk.ExecuteResult{}
+}
+
+// When the ack application crash, payload is the payload of the origin package.
+func (app proposalResultApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte) sdk.ExecuteResult {
+	var resultPackage types.ProposalResultPackage
+	if err := rlp.DecodeBytes(payload, &resultPackage); err != nil {
+		ctx.Logger().With("module", "side_chain").Error("receive broken proposal result fail ack package", "err", err)
+		return sdk.ExecuteResult{}
+	}
+	ctx.Logger().With("module", "side_chain").Error("the side chain failed to apply the proposal result",
+		"proposalId", resultPackage.ProposalId)
+	return sdk.ExecuteResult{}
+}
@@ -0,0 +1,68 @@
+package sidechain
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+type mockIbcKeeper struct {
+	destChainID sdk.ChainID
+	channelID   sdk.ChannelID
+	packageLoad []byte
+}
+
+func (k *mockIbcKeeper) CreateRawIBCPackageById(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	packageType sdk.CrossChainPackageType, packageLoad []byte) (uint64, sdk.Error) {
+	k.destChainID, k.channelID, k.packageLoad = destChainID, channelID, packageLoad
+	return 3, nil
+}
+
+func TestKeeper_SendProposalResult(t *testing.T) {
+	ctx, keeper := CreateTestInput(t, false)
+	ibcKeeper := &mockIbcKeeper{}
+	keeper.SetIbcKeeper(ibcKeeper)
+	require.Nil(t, keeper.RegisterProposalResultChannel())
+
+	proposal := &gov.TextProposal{
+		ProposalID:   5,
+		Description:  "raise the gas limit",
+		ProposalType: gov.ProposalTypeSCParamsChange,
+		TallyResult: gov.TallyResult{
+			Yes:        sdk.NewDecWithoutFra(30),
+			Abstain:    sdk.ZeroDec(),
+			No:         sdk.NewDecWithoutFra(10),
+			NoWithVeto: sdk.ZeroDec(),
+			Total:      sdk.NewDecWithoutFra(50),
+		},
+	}
+
+	// the side chain must be registered
+	_, err := keeper.SendProposalResult(ctx, "bsc", proposal)
+	require.NotNil(t, err)
+
+	require.Nil(t, keeper.RegisterDestChain("bsc", sdk.ChainID(1)))
+	seq, err := keeper.SendProposalResult(ctx, "bsc", proposal)
+	require.Nil(t, err)
+	require.Equal(t, uint64(3), seq)
+	require.Equal(t, sdk.ChainID(1), ibcKeeper.destChainID)
+	require.Equal(t, types.ProposalResultChannelId, ibcKeeper.channelID)
+
+	var resultPackage types.ProposalResultPackage
+	require.Nil(t, rlp.DecodeBytes(ibcKeeper.packageLoad, &resultPackage))
+	descriptionHash := sha256.Sum256([]byte("raise the gas limit"))
+	require.Equal(t, types.ProposalResultPackage{
+		ProposalId:      5,
+		ProposalType:    uint8(gov.ProposalTypeSCParamsChange),
+		DescriptionHash: descriptionHash[:],
+		Yes:             uint64(sdk.NewDecWithoutFra(30).RawInt()),
+		No:              uint64(sdk.NewDecWithoutFra(10).RawInt()),
+		Total:           uint64(sdk.NewDecWithoutFra(50).RawInt()),
+	}, resultPackage)
+}
@@ -225,14 +225,15 @@ func (k *Keeper) incrSequence(ctx sdk.Context, destChainID sdk.ChainID, channelI
 }
 
 func EndBlock(ctx sdk.Context, k Keeper) {
-	if sdk.IsUpgrade(sdk.LaunchBscUpgrade) && k.govKeeper != nil {
-		chanPermissions := k.getLastChanPermissionChanges(ctx)
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LaunchBscUpgrade) && k.govKeeper != nil {
+		chanPermissions, proposals := k.getLastChanPermissionChanges(ctx)
 		// should in reverse order
 		for j := len(chanPermissions) - 1; j >= 0; j-- {
 			change := chanPermissions[j]
 			// must exist
 			id, _ := k.cfg.destChainNameToID[change.SideChainId]
 			k.SetChannelSendPermission(ctx, id, change.ChannelId, change.Permission)
+			k.govKeeper.AppendAuditRecord(ctx, change.SideChainId, proposals[j], gov.AuditActionChannelPermissionSet)
 			_, err := k.SaveChannelSettingChangeToIbc(ctx, id, change.ChannelId, change.Permission)
 			if err != nil {
 				ctx.Logger().With("module", "side_chain").Error("failed to write cross chain channel permission change message ",
@@ -58,3 +58,19 @@ func TestKeeper_SetSideChainIdAndStorePrefix(t *testing.T) {
 	require.Equal(t, scIds[1], "xyz")
 	require.Equal(t, scPrefixes[1], []byte{0xab})
 }
+
+func TestKeeper_SequencePerDestChain(t *testing.T) {
+	ctx, keeper := CreateTestInput(t, false)
+	bscChainID, otherChainID := sdk.ChainID(1), sdk.ChainID(2)
+	channelID := sdk.ChannelID(1)
+
+	keeper.IncrSendSequence(ctx, bscChainID, channelID)
+	keeper.IncrSendSequence(ctx, bscChainID, channelID)
+	keeper.IncrReceiveSequence(ctx, bscChainID, channelID)
+
+	// the sequences of a channel are not shared between destination chains
+	require.Equal(t, uint64(2), keeper.GetSendSequence(ctx, bscChainID, channelID))
+	require.Equal(t, uint64(1), keeper.GetReceiveSequence(ctx, bscChainID, channelID))
+	require.Equal(t, uint64(0), keeper.GetSendSequence(ctx, otherChainID, channelID))
+	require.Equal(t, uint64(0), keeper.GetReceiveSequence(ctx, otherChainID, channelID))
+}
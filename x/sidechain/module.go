@@ -0,0 +1,33 @@
+package sidechain
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ModuleName is the name of the sidechain module in the ModuleManager
+const ModuleName = "sidechain"
+
+var _ sdk.AppModuleEndBlocker = AppModule{}
+
+// AppModule registers the sidechain module in the ModuleManager
+type AppModule struct {
+	keeper *Keeper
+}
+
+// NewAppModule creates a new AppModule of sidechain
+func NewAppModule(keeper *Keeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// Name returns the module name
+func (AppModule) Name() string {
+	return ModuleName
+}
+
+// EndBlock applies the channel permission changes passed by gov
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	EndBlock(ctx, *am.keeper)
+	return nil
+}
@@ -12,6 +12,9 @@ const (
 	MaxSideChainIdLength = 20
 
 	GovChannelId = sdk.ChannelID(9)
+
+	ProposalResultChannelName = "govResult"
+	ProposalResultChannelId   = sdk.ChannelID(18)
 )
 
 const (
@@ -50,6 +53,19 @@ func GenCommonAckPackage(code uint32) ([]byte, error) {
 	return rlp.EncodeToBytes(&CommonAckPackage{Code: code})
 }
 
+// ProposalResultPackage is the result of a passed side chain proposal pushed to the side chain through the
+// ProposalResultChannelId. The votes are the raw amounts of the tally, in the smallest unit of the token.
+type ProposalResultPackage struct {
+	ProposalId      uint64
+	ProposalType    uint8
+	DescriptionHash []byte // sha256 of the description of the proposal
+	Yes             uint64
+	Abstain         uint64
+	No              uint64
+	NoWithVeto      uint64
+	Total           uint64
+}
+
 type ChanPermissionSetting struct {
 	SideChainId string                `json:"side_chain_id"`
 	ChannelId   sdk.ChannelID         `json:"channel_id"`
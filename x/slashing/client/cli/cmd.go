@@ -28,6 +28,7 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 			GetCmdQuerySideChainSlashRecord(slashingStoreName, cdc),
 			GetCmdQuerySideChainSlashRecords(cdc),
 			GetCmdQueryAllSideSlashRecords(slashingStoreName, cdc),
+			GetCmdQuerySlashSimulation(cdc),
 		)...)
 
 	root.AddCommand(slashingCmd)
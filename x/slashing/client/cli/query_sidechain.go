@@ -269,6 +269,55 @@ func GetCmdQuerySideChainSlashRecords(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdQuerySlashSimulation implements the command to simulate the slash of a validator
+func GetCmdQuerySlashSimulation(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "slash-simulation [validator-addr]",
+		Short: "Query the amounts each delegator of a validator would lose if it was slashed now",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			infractionType, err := convertInfractionType(viper.GetString(FlagInfractionType))
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			var sideChainId string
+			if len(viper.GetString(FlagSideChainId)) != 0 {
+				sideChainId, _, err = getSideChainConfig(cliCtx)
+				if err != nil {
+					return err
+				}
+			}
+
+			params := slashing.QuerySlashSimulationParams{
+				BaseParams:     slashing.NewBaseParams(sideChainId),
+				ValAddr:        valAddr,
+				InfractionType: infractionType,
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+			response, err := cliCtx.QueryWithData(fmt.Sprintf("custom/slashing/%s", slashing.QuerySlashSimulation), bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(response))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagInfractionType, "", "infraction type, 'DoubleSign;Downtime'")
+	cmd.Flags().String(FlagSideChainId, "", "chain-id of the side chain the validator belongs to")
+	cmd.MarkFlagRequired(FlagInfractionType)
+	return cmd
+}
+
 func getSideChainConfig(cliCtx context.CLIContext) (sideChainId string, prefix []byte, error error) {
 	sideChainId, error = getSideChainId()
 	if error != nil {
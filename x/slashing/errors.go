@@ -3,6 +3,8 @@ package slashing
 
 import (
 	"fmt"
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -20,6 +22,7 @@ const (
 	CodeMissingSelfDelegation        CodeType = 104
 	CodeSelfDelegationTooLowToUnjail CodeType = 105
 	CodeInvalidClaim                 CodeType = 106
+	CodeRecentDowntime               CodeType = 107
 
 	CodeExpiredEvidence        CodeType = 201
 	CodeFailSlash              CodeType = 202
@@ -29,6 +32,14 @@ const (
 	CodeDuplicateDowntimeClaim CodeType = 206
 )
 
+func init() {
+	sdk.RegisterCodespace(DefaultCodespace, "slashing",
+		CodeInvalidInput, CodeInvalidValidator, CodeValidatorJailed, CodeValidatorNotJailed,
+		CodeMissingSelfDelegation, CodeSelfDelegationTooLowToUnjail, CodeInvalidClaim, CodeRecentDowntime,
+		CodeExpiredEvidence, CodeFailSlash, CodeHandledEvidence, CodeInvalidEvidence,
+		CodeInvalidSideChain, CodeDuplicateDowntimeClaim)
+}
+
 func ErrNoValidatorForAddress(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "that address is not associated with any known validator")
 }
@@ -41,6 +52,15 @@ func ErrValidatorJailed(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeValidatorJailed, "validator still jailed, cannot yet be unjailed")
 }
 
+func ErrValidatorJailedUntil(codespace sdk.CodespaceType, jailedUntil time.Time) sdk.Error {
+	return sdk.NewError(codespace, CodeValidatorJailed, fmt.Sprintf("validator jailed until %v, cannot yet be unjailed", jailedUntil))
+}
+
+func ErrRecentDowntime(codespace sdk.CodespaceType, slashHeight int64, lookback int64) sdk.Error {
+	return sdk.NewError(codespace, CodeRecentDowntime,
+		fmt.Sprintf("validator slashed for downtime at height %d, cannot be unjailed until height %d", slashHeight, slashHeight+lookback))
+}
+
 func ErrValidatorNotJailed(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeValidatorNotJailed, "validator not jailed, cannot be unjailed")
 }
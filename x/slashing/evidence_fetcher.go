@@ -0,0 +1,97 @@
+package slashing
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EvidenceFetcher ingests the slash indications of the side chains delivered via the slash
+// channel and schedules their handling, see Keeper.SetEvidenceFetcher
+type EvidenceFetcher interface {
+	// Ingest validates an indication, rejects it if it duplicates a slash record or a scheduled
+	// indication, and schedules its handling
+	Ingest(ctx sdk.Context, pack *SideDowntimeSlashPackage) sdk.Error
+	// Process handles the indications scheduled for the block of ctx, it is run by the BeginBlocker
+	Process(ctx sdk.Context)
+}
+
+// evidenceFetcher is the default EvidenceFetcher, it queues the indications in the store and
+// handles at most maxPerBlock of them per block, in the order they have been delivered
+type evidenceFetcher struct {
+	k           Keeper
+	maxPerBlock int
+}
+
+// NewEvidenceFetcher returns the default EvidenceFetcher. k must be set up for the side chains,
+// with its pubsub server if any, before it is passed here.
+func NewEvidenceFetcher(k Keeper, maxPerBlock int) EvidenceFetcher {
+	if maxPerBlock < 1 {
+		panic(fmt.Sprintf("invalid max slash indications per block: %d", maxPerBlock))
+	}
+	return evidenceFetcher{k: k, maxPerBlock: maxPerBlock}
+}
+
+func (f evidenceFetcher) Ingest(ctx sdk.Context, pack *SideDowntimeSlashPackage) sdk.Error {
+	sideChainName, err := f.k.ScKeeper.GetDestChainName(pack.SideChainId)
+	if err != nil {
+		return ErrInvalidSideChainId(DefaultCodespace)
+	}
+	sideCtx, err := f.k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainName)
+	if err != nil {
+		return ErrInvalidSideChainId(DefaultCodespace)
+	}
+	if f.k.hasSlashRecord(sideCtx, pack.SideConsAddr, Downtime, pack.SideHeight) {
+		return ErrDuplicateDowntimeClaim(f.k.Codespace)
+	}
+
+	store := ctx.KVStore(f.k.storeKey)
+	pendingKey := GetPendingSlashIndicationKey(pack.SideChainId, pack.SideConsAddr, pack.SideHeight)
+	if store.Has(pendingKey) {
+		return ErrDuplicateDowntimeClaim(f.k.Codespace)
+	}
+
+	var seq uint64
+	if bz := store.Get(NextSlashIndicationSeqKey); bz != nil {
+		f.k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &seq)
+	}
+	store.Set(NextSlashIndicationSeqKey, f.k.cdc.MustMarshalBinaryLengthPrefixed(seq+1))
+	store.Set(GetSlashIndicationQueueKey(seq), f.k.cdc.MustMarshalBinaryLengthPrefixed(*pack))
+	store.Set(pendingKey, []byte{0x01})
+
+	if f.k.hooks != nil {
+		if validator := f.k.validatorSet.ValidatorByConsAddr(sideCtx, sdk.ConsAddress(pack.SideConsAddr)); validator != nil {
+			f.k.hooks.OnEvidencePending(sideCtx, validator.GetOperator())
+		}
+	}
+	return nil
+}
+
+func (f evidenceFetcher) Process(ctx sdk.Context) {
+	store := ctx.KVStore(f.k.storeKey)
+	var keys [][]byte
+	var packs []SideDowntimeSlashPackage
+	iterator := sdk.KVStorePrefixIterator(store, SlashIndicationQueueKey)
+	for ; iterator.Valid() && len(keys) < f.maxPerBlock; iterator.Next() {
+		var pack SideDowntimeSlashPackage
+		f.k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &pack)
+		keys = append(keys, iterator.Key())
+		packs = append(packs, pack)
+	}
+	iterator.Close()
+
+	logger := ctx.Logger().With("module", "x/slashing")
+	for i, pack := range packs {
+		store.Delete(keys[i])
+		store.Delete(GetPendingSlashIndicationKey(pack.SideChainId, pack.SideConsAddr, pack.SideHeight))
+
+		// the indication may have expired or its validator may be gone since it has been ingested
+		cacheCtx, write := ctx.CacheContext()
+		if err := f.k.slashingSideDowntime(cacheCtx, &pack); err != nil {
+			logger.Error("failed to handle side chain slash indication", "sideChainId", pack.SideChainId,
+				"sideConsAddr", sdk.HexEncode(pack.SideConsAddr), "sideHeight", pack.SideHeight, "err", err)
+			continue
+		}
+		write()
+	}
+}
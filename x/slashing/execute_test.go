@@ -105,6 +105,56 @@ func TestSideChainSlashDowntime(t *testing.T) {
 
 }
 
+func TestEvidenceFetcher(t *testing.T) {
+	slashingParams := DefaultParams()
+	slashingParams.MaxEvidenceAge = 12 * 60 * 60 * time.Second
+	ctx, sideCtx, _, stakeKeeper, _, keeper := createSideTestInput(t, slashingParams)
+	keeper.SetEvidenceFetcher(NewEvidenceFetcher(keeper, 1))
+
+	sideConsAddr, sideFeeAddr := createSideAddr(20), createSideAddr(20)
+	msgCreateVal := newTestMsgCreateSideValidator(addrs[0], sideConsAddr, sideFeeAddr, 10000e8)
+	got := stake.NewHandler(stakeKeeper, gov.Keeper{})(ctx, msgCreateVal)
+	require.True(t, got.IsOK(), "expected create validator msg to be ok, got: %v", got)
+	stake.EndBreatheBlock(ctx, stakeKeeper)
+
+	claim := SideDowntimeSlashPackage{
+		SideConsAddr:  sideConsAddr,
+		SideHeight:    100,
+		SideChainId:   sdk.ChainID(1),
+		SideTimestamp: uint64(ctx.BlockHeader().Time.Add(-6 * 60 * 60 * time.Second).Unix()),
+	}
+	deliver := func(claim SideDowntimeSlashPackage) sdk.ExecuteResult {
+		bz, err := rlp.EncodeToBytes(&claim)
+		require.NoError(t, err)
+		return keeper.ExecuteSynPackage(ctx, bz, 0)
+	}
+
+	// the indications are queued, the duplicates are rejected
+	require.Nil(t, deliver(claim).Err)
+	require.EqualValues(t, CodeDuplicateDowntimeClaim, deliver(claim).Err.Code())
+	_, found := keeper.getSlashRecord(sideCtx, sideConsAddr, Downtime, 100)
+	require.False(t, found)
+	claim.SideHeight = 200
+	require.Nil(t, deliver(claim).Err)
+
+	// one indication is handled per block
+	keeper.evidenceFetcher.Process(ctx)
+	_, found = keeper.getSlashRecord(sideCtx, sideConsAddr, Downtime, 100)
+	require.True(t, found)
+	store := ctx.KVStore(keeper.storeKey)
+	require.True(t, store.Has(GetPendingSlashIndicationKey(claim.SideChainId, sideConsAddr, 200)))
+
+	// a handled indication is a duplicate of its slash record
+	claim.SideHeight = 100
+	require.EqualValues(t, CodeDuplicateDowntimeClaim, deliver(claim).Err.Code())
+
+	keeper.evidenceFetcher.Process(ctx)
+	require.False(t, store.Has(GetPendingSlashIndicationKey(claim.SideChainId, sideConsAddr, 200)))
+	iterator := sdk.KVStorePrefixIterator(store, SlashIndicationQueueKey)
+	require.False(t, iterator.Valid())
+	iterator.Close()
+}
+
 func TestSlashDowntimeBalanceVerify(t *testing.T) {
 
 	slashingParams := DefaultParams()
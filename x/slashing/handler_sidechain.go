@@ -28,7 +28,7 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	var sideConsAddr2 bsc.Address
 	var err2 error
 
-	if sdk.IsUpgrade(sdk.FixDoubleSignChainId) {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.FixDoubleSignChainId) {
 		sideConsAddr, err = msg.Headers[0].ExtractSignerFromHeader(chainID)
 		sideConsAddr2, err2 = msg.Headers[1].ExtractSignerFromHeader(chainID)
 	} else {
@@ -66,8 +66,7 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	}
 
 	bondDenom := k.validatorSet.BondDenom(sideCtx)
-	submitterReward := k.SubmitterReward(sideCtx)
-	submitterRewardReal := sdk.MinInt64(slashedAmount.RawInt(), submitterReward)
+	submitterRewardReal := k.submitterReward(sideCtx, validator, msg.Submitter, slashedAmount)
 	submitterRewardCoin := sdk.NewCoin(bondDenom, submitterRewardReal)
 
 	if submitterRewardReal > 0 {
@@ -93,7 +92,7 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 		}
 	}
 
-	jailUntil := header.Time.Add(k.DoubleSignUnbondDuration(sideCtx))
+	jailUntil := k.sideJailUntil(sideCtx, k.DoubleSignUnbondDuration(sideCtx))
 	sr := SlashRecord{
 		ConsAddr:         sideConsAddr.Bytes(),
 		InfractionType:   DoubleSign,
@@ -132,9 +131,26 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	return sdk.Result{}
 }
 
-func handleMsgSideChainUnjail(ctx sdk.Context, msg MsgSideChainUnjail, k Keeper) sdk.Result {
+// submitterReward is the bounty of the submitter of the first accepted double sign evidence against validator,
+// at most the slashed amount. Once SubmitterRewardRatio is set, it is that ratio of the slashed amount, and
+// the validator reporting itself through its operator or fee address gets nothing, otherwise it is the
+// fixed SubmitterReward.
+func (k Keeper) submitterReward(sideCtx sdk.Context, validator sdk.Validator, submitter sdk.AccAddress, slashedAmount sdk.Dec) int64 {
+	ratio := k.SubmitterRewardRatio(sideCtx)
+	if ratio.IsZero() {
+		return sdk.MinInt64(slashedAmount.RawInt(), k.SubmitterReward(sideCtx))
+	}
+	if submitter.Equals(sdk.AccAddress(validator.GetOperator())) || submitter.Equals(validator.GetFeeAddr()) {
+		return 0
+	}
+	return sdk.MinInt64(slashedAmount.RawInt(), slashedAmount.Mul(ratio).RawInt())
+}
 
-	scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId)
+// handleMsgSideChainUnjail unjails a side chain validator and charges the UnjailFee of the side chain to its operator,
+// either both happen or nothing does
+func handleMsgSideChainUnjail(ctx sdk.Context, msg MsgSideChainUnjail, k Keeper) sdk.Result {
+	cacheCtx, write := ctx.CacheContext()
+	scCtx, err := k.ScKeeper.PrepareCtxForSideChain(cacheCtx, msg.SideChainId)
 	if err != nil {
 		return ErrInvalidSideChainId(DefaultCodespace).Result()
 	}
@@ -143,6 +159,18 @@ func handleMsgSideChainUnjail(ctx sdk.Context, msg MsgSideChainUnjail, k Keeper)
 		return err.Result()
 	}
 
+	var unjailFee sdk.Coins
+	if fee := k.UnjailFee(scCtx); fee > 0 {
+		unjailFee = sdk.Coins{sdk.NewCoin(k.validatorSet.BondDenom(scCtx), fee)}
+		if _, _, err := k.BankKeeper.SubtractCoins(cacheCtx, sdk.AccAddress(msg.ValidatorAddr), unjailFee); err != nil {
+			return err.Result()
+		}
+	}
+	write()
+	if unjailFee != nil && ctx.IsDeliverTx() {
+		fees.Pool.AddAndCommitFee("side_unjail_fee", sdk.NewFee(unjailFee, sdk.FeeForAll))
+	}
+
 	tags := sdk.NewTags("sideChainId", []byte(msg.SideChainId), "validator", []byte(msg.ValidatorAddr.String()))
 
 	return sdk.Result{
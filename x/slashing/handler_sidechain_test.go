@@ -123,6 +123,55 @@ func TestSideChainSlashDoubleSign(t *testing.T) {
 	require.EqualValues(t, ctx.BlockHeader().Time.Add(slashParams.DoubleSignUnbondDuration).Unix(), slashRecord.JailUntil.Unix())
 }
 
+func TestSideChainSlashDoubleSignRewardRatio(t *testing.T) {
+	slashParams := DefaultParams()
+	slashParams.DoubleSignUnbondDuration = 5 * time.Second
+	slashParams.MaxEvidenceAge = math.MaxInt64
+	slashParams.DoubleSignSlashAmount = 6000e8
+	slashParams.SubmitterRewardRatio = sdk.NewDecWithPrec(1, 1)
+	require.Nil(t, slashParams.UpdateCheck())
+
+	bondAmount := int64(10000e8)
+	mValAddr := addrs[0]
+	for _, tc := range []struct {
+		submitter sdk.AccAddress
+		reward    int64
+	}{
+		{sdk.AccAddress(addrs[2]), 600e8},
+		// the validator reporting itself gets no bounty
+		{sdk.AccAddress(mValAddr), 0},
+	} {
+		ctx, sideCtx, bankKeeper, stakeKeeper, _, keeper := createSideTestInput(t, slashParams)
+		require.True(t, keeper.SubmitterRewardRatio(sideCtx).Equal(slashParams.SubmitterRewardRatio))
+
+		// create a malicious validator
+		ctx = ctx.WithBlockHeight(100)
+		mSideConsAddr, err := sdk.HexDecode("0xed24ff64903c07B5bD57C898CE0967D407aFCB0d")
+		require.Nil(t, err)
+		msgCreateVal := newTestMsgCreateSideValidator(mValAddr, mSideConsAddr, createSideAddr(20), bondAmount)
+		got := stake.NewHandler(stakeKeeper, gov.Keeper{})(ctx, msgCreateVal)
+		require.True(t, got.IsOK(), "expected create validator msg to be ok, got: %v", got)
+		stake.EndBreatheBlock(ctx, stakeKeeper)
+
+		ctx = ctx.WithBlockHeight(300)
+		headers := make([]bsc.Header, 0)
+		headersJson := `[{"parentHash":"0x6116de25352c93149542e950162c7305f207bbc17b0eb725136b78c80aed79cc","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xe7cb9d2fd449f7bd11126bff55266e7b74936f2f230e21d44d75c04b7780dfeb","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x1","gasLimit":"0x47e7c4","gasUsed":"0x0","timestamp":"0x5ea6a002","extraData":"0x0000000000000000000000000000000000000000000000000000000000000000fc3e4bbcd4936a8e1fd9fc45461d071ca571ca80fbed85e0cc52e007ed557aff0a6ea1875b4e13171d301037036b3a26af3c7c2b317487323fd7557df717856b00","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x1532065752393ff2f6e7ef9b64f80d6e10efe42a4d9bdd8149fcbac6f86b365b"},{"parentHash":"0x6116de25352c93149542e950162c7305f207bbc17b0eb725136b78c80aed79cc","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","miner":"0x0000000000000000000000000000000000000000","stateRoot":"0xe7cb9d2fd449f7bd11126bff55266e7b74936f2f230e21d44d75c04b7780dfeb","transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","difficulty":"0x20000","number":"0x1","gasLimit":"0x47e7c4","gasUsed":"0x64","timestamp":"0x5ea6a002","extraData":"0x00000000000000000000000000000000000000000000000000000000000000003a849df14e9cc1502f218431c449f239a51fddb1fd408ca37e61834adf921f0c21fd269c86acf7f0b40aa7ce691bbd7f446d8234a4a6b19a98c77614da9a5fcb01","mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000","nonce":"0x0000000000000000","hash":"0x811a42453f826f05e9d85998551636f59eb740d5b03fe2416700058a4f31ca1e"}]`
+		err = json.Unmarshal([]byte(headersJson), &headers)
+		require.Nil(t, err)
+
+		submitterBalance := bankKeeper.GetCoins(ctx, tc.submitter).AmountOf("steak")
+		sdk.UpgradeMgr.AddUpgradeHeight(sdk.FixDoubleSignChainId, 199)
+		sdk.UpgradeMgr.SetHeight(200)
+		got = NewHandler(keeper)(ctx, NewMsgBscSubmitEvidence(tc.submitter, headers))
+		require.True(t, got.IsOK(), "expected submit evidence msg to be ok, got: %v", got)
+		require.EqualValues(t, submitterBalance+tc.reward, bankKeeper.GetCoins(ctx, tc.submitter).AmountOf("steak"))
+
+		// only the first evidence is accepted
+		got = NewHandler(keeper)(ctx, NewMsgBscSubmitEvidence(sdk.AccAddress(addrs[1]), headers))
+		require.False(t, got.IsOK())
+	}
+}
+
 func TestSideChainSlashDoubleSignUBD(t *testing.T) {
 
 	slashParams := DefaultParams()
@@ -190,3 +239,66 @@ func TestSideChainSlashDoubleSignUBD(t *testing.T) {
 	require.EqualValues(t, 4000e8, stakingPoolBalance)
 
 }
+
+func TestSideChainUnjail(t *testing.T) {
+	slashParams := DefaultParams()
+	slashParams.MaxEvidenceAge = 12 * time.Hour
+	slashParams.MinJailDuration = time.Hour
+	slashParams.UnjailFee = 5e8
+	slashParams.DowntimeLookback = 100
+	ctx, sideCtx, bankKeeper, stakeKeeper, _, keeper := createSideTestInput(t, slashParams)
+
+	// the operator is left with less than the unjail fee
+	ctx = ctx.WithBlockHeight(100)
+	valAddr := addrs[0]
+	sideConsAddr, sideFeeAddr := createSideAddr(20), createSideAddr(20)
+	msgCreateVal := newTestMsgCreateSideValidator(valAddr, sideConsAddr, sideFeeAddr, initCoins-1e8)
+	got := stake.NewHandler(stakeKeeper, gov.Keeper{})(ctx, msgCreateVal)
+	require.True(t, got.IsOK(), "expected create validator msg to be ok, got: %v", got)
+	stake.EndBreatheBlock(ctx, stakeKeeper)
+
+	claim := SideDowntimeSlashPackage{
+		SideConsAddr:  sideConsAddr,
+		SideHeight:    100,
+		SideChainId:   sdk.ChainID(1),
+		SideTimestamp: uint64(ctx.BlockHeader().Time.Unix()),
+	}
+	require.Nil(t, keeper.slashingSideDowntime(ctx, &claim))
+
+	// the validator is jailed for MinJailDuration rather than the shorter DowntimeUnbondDuration
+	jailedAt := ctx.BlockHeader().Time
+	info, found := keeper.getValidatorSigningInfo(sideCtx, sideConsAddr)
+	require.True(t, found)
+	require.EqualValues(t, jailedAt.Add(time.Hour).Unix(), info.JailedUntil.Unix())
+
+	unjail := func(height int64, after time.Duration) sdk.Result {
+		ctx = ctx.WithBlockHeight(height).WithBlockTime(jailedAt.Add(after))
+		return NewHandler(keeper)(ctx, NewMsgSideChainUnjail(valAddr, "bsc"))
+	}
+	jailed := func() bool {
+		validator, found := stakeKeeper.GetValidator(sideCtx, valAddr)
+		require.True(t, found)
+		return validator.Jailed
+	}
+
+	got = unjail(300, 30*time.Minute)
+	require.EqualValues(t, CodeValidatorJailed, got.Code)
+
+	// the downtime is still within the lookback window
+	got = unjail(150, 2*time.Hour)
+	require.EqualValues(t, CodeRecentDowntime, got.Code)
+
+	// the fee cannot be paid, the validator stays jailed
+	got = unjail(300, 2*time.Hour)
+	require.False(t, got.IsOK())
+	require.True(t, jailed())
+
+	_, _, err := bankKeeper.AddCoins(ctx, sdk.AccAddress(valAddr), sdk.Coins{sdk.NewCoin("steak", 10e8)})
+	require.Nil(t, err)
+	feesInPoolBefore := fees.Pool.BlockFees().Tokens.AmountOf("steak")
+	got = unjail(300, 2*time.Hour)
+	require.True(t, got.IsOK(), "expected unjail msg to be ok, got: %v", got)
+	require.False(t, jailed())
+	require.EqualValues(t, 6e8, bankKeeper.GetCoins(ctx, sdk.AccAddress(valAddr)).AmountOf("steak"))
+	require.EqualValues(t, slashParams.UnjailFee, fees.Pool.BlockFees().Tokens.AmountOf("steak")-feesInPoolBefore)
+}
@@ -56,25 +56,27 @@ func (k Keeper) onSelfDelDropBelowMin(ctx sdk.Context, valAddress sdk.ValAddress
 	if validator == nil {
 		return
 	}
+	header := ctx.BlockHeader()
 	var consAddr []byte
+	jailUntil := header.Time.Add(k.TooLowDelUnbondDuration(ctx))
 	if validator.IsSideChainValidator() {
 		consAddr = validator.GetSideChainConsAddr()
+		jailUntil = k.sideJailUntil(ctx, k.TooLowDelUnbondDuration(ctx))
 	} else {
 		consAddr = validator.GetConsAddr().Bytes()
 	}
 
-	header := ctx.BlockHeader()
 	signingInfo, found := k.getValidatorSigningInfo(ctx, consAddr)
 	if !found {
 		signingInfo := ValidatorSigningInfo{
 			StartHeight:         header.Height,
 			IndexOffset:         0,
-			JailedUntil:         header.Time.Add(k.TooLowDelUnbondDuration(ctx)),
+			JailedUntil:         jailUntil,
 			MissedBlocksCounter: 0,
 		}
 		k.setValidatorSigningInfo(ctx, consAddr, signingInfo)
 	} else {
-		signingInfo.JailedUntil = header.Time.Add(k.TooLowDelUnbondDuration(ctx))
+		signingInfo.JailedUntil = jailUntil
 		k.setValidatorSigningInfo(ctx, consAddr, signingInfo)
 	}
 }
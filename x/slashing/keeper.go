@@ -36,6 +36,10 @@ type Keeper struct {
 	ScKeeper   *sidechain.Keeper
 
 	PbsbServer *pubsub.Server
+
+	evidenceFetcher EvidenceFetcher
+
+	hooks sdk.SlashingHooks
 }
 
 // NewKeeper creates a slashing keeper
@@ -51,6 +55,15 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, vs sdk.ValidatorSet, paramspa
 	return keeper
 }
 
+// WithHooks sets the hooks called before a validator is likely to be slashed
+func (k Keeper) WithHooks(sh sdk.SlashingHooks) Keeper {
+	if k.hooks != nil {
+		panic("cannot set slashing hooks twice")
+	}
+	k.hooks = sh
+	return k
+}
+
 func (k *Keeper) SetSideChain(scKeeper *sidechain.Keeper) {
 	k.ScKeeper = scKeeper
 	k.initIbc()
@@ -67,6 +80,12 @@ func (k *Keeper) SetPbsbServer(server *pubsub.Server) {
 	k.PbsbServer = server
 }
 
+// SetEvidenceFetcher schedules the handling of the slash indications of the side chains with
+// fetcher instead of handling them as soon as they are delivered by the slash channel
+func (k *Keeper) SetEvidenceFetcher(fetcher EvidenceFetcher) {
+	k.evidenceFetcher = fetcher
+}
+
 // handle a validator signing two blocks at the same height
 // power: power of the double-signing validator at the height of infraction
 func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractionHeight int64, timestamp time.Time, power int64) {
@@ -125,6 +144,21 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 	k.setValidatorSigningInfo(ctx, consAddr, signInfo)
 }
 
+// checkDowntimeWarning calls the OnDowntimeWarning hook when the missed blocks of a validator
+// reach half of the blocks it may miss in the signing window
+func (k Keeper) checkDowntimeWarning(ctx sdk.Context, consAddr sdk.ConsAddress, missedBlocks int64) {
+	if k.hooks == nil {
+		return
+	}
+	maxMissed := k.SignedBlocksWindow(ctx) - k.MinSignedPerWindow(ctx)
+	if maxMissed < 2 || missedBlocks != maxMissed/2 {
+		return
+	}
+	if validator := k.validatorSet.ValidatorByConsAddr(ctx, consAddr); validator != nil {
+		k.hooks.OnDowntimeWarning(ctx, validator.GetOperator())
+	}
+}
+
 // handle a validator signature, must be called once per validator per block
 // TODO refactor to take in a consensus address, additionally should maybe just take in the pubkey too
 func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, power int64, signed bool) {
@@ -154,6 +188,7 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 		// Array value has changed from not missed to missed, increment counter
 		k.setValidatorMissedBlockBitArray(ctx, consAddr, index, true)
 		signInfo.MissedBlocksCounter++
+		k.checkDowntimeWarning(ctx, consAddr, signInfo.MissedBlocksCounter)
 	case previous && !missed:
 		// Array value has changed from missed to not missed, decrement counter
 		k.setValidatorMissedBlockBitArray(ctx, consAddr, index, false)
@@ -239,7 +274,11 @@ func (k *Keeper) ExecuteSynPackage(ctx sdk.Context, payload []byte, _ int64) sdk
 	var resCode uint32
 	pack, err := k.checkSideDowntimeSlashPackage(payload)
 	if err == nil {
-		err = k.slashingSideDowntime(ctx, pack)
+		if k.evidenceFetcher != nil {
+			err = k.evidenceFetcher.Ingest(ctx, pack)
+		} else {
+			err = k.slashingSideDowntime(ctx, pack)
+		}
 	}
 	if err != nil {
 		resCode = uint32(err.ABCICode())
@@ -338,7 +377,7 @@ func (k *Keeper) slashingSideDowntime(ctx sdk.Context, pack *SideDowntimeSlashPa
 		}
 	}
 
-	jailUntil := header.Time.Add(k.DowntimeUnbondDuration(sideCtx))
+	jailUntil := k.sideJailUntil(sideCtx, k.DowntimeUnbondDuration(sideCtx))
 	sr := SlashRecord{
 		ConsAddr:         pack.SideConsAddr,
 		InfractionType:   Downtime,
@@ -14,6 +14,9 @@ var (
 	ValidatorSlashingPeriodKey      = []byte{0x03} // Prefix for slashing period
 	AddrPubkeyRelationKey           = []byte{0x04} // Prefix for address-pubkey relation
 	SlashRecordKey                  = []byte{0x05} // Prefix for slash record
+	SlashIndicationQueueKey         = []byte{0x06} // Prefix for the queue of side chain slash indications
+	PendingSlashIndicationKey       = []byte{0x07} // Prefix for the index of the queued slash indications
+	NextSlashIndicationSeqKey       = []byte{0x08} // Key for the sequence of the next queued slash indication
 )
 
 // stored by *Tendermint* address (not operator address)
@@ -46,6 +49,21 @@ func GetValidatorSlashingPeriodKey(v sdk.ConsAddress, startHeight int64) []byte
 	return append(GetValidatorSlashingPeriodPrefix(v), b...)
 }
 
+// stored by sequence, in the order of the ingestion
+func GetSlashIndicationQueueKey(seq uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, seq)
+	return append(SlashIndicationQueueKey, b...)
+}
+
+func GetPendingSlashIndicationKey(sideChainId sdk.ChainID, sideConsAddr []byte, sideHeight uint64) []byte {
+	b := make([]byte, 2+len(sideConsAddr)+8)
+	binary.BigEndian.PutUint16(b, uint16(sideChainId))
+	copy(b[2:], sideConsAddr)
+	binary.BigEndian.PutUint64(b[2+len(sideConsAddr):], sideHeight)
+	return append(PendingSlashIndicationKey, b...)
+}
+
 func getAddrPubkeyRelationKey(address []byte) []byte {
 	return append(AddrPubkeyRelationKey, address...)
 }
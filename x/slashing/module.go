@@ -0,0 +1,67 @@
+package slashing
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// ModuleName is the name of the slashing module in the ModuleManager
+const ModuleName = "slashing"
+
+var (
+	_ sdk.AppModuleGenesis      = AppModule{}
+	_ sdk.AppModuleBeginBlocker = AppModule{}
+)
+
+// AppModule registers the slashing module in the ModuleManager
+type AppModule struct {
+	keeper *Keeper
+}
+
+// NewAppModule creates a new AppModule of slashing
+func NewAppModule(keeper *Keeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// Name returns the module name
+func (AppModule) Name() string {
+	return ModuleName
+}
+
+// DefaultGenesis returns the default genesis state
+func (AppModule) DefaultGenesis() json.RawMessage {
+	return MsgCdc.MustMarshalJSON(DefaultGenesisState())
+}
+
+// InitGenesis initializes the params and the pubkeys of the validators.
+// CONTRACT: the stake genesis must be initialized before, see ModuleManager.SetOrderInitGenesis,
+// the genesis validators are read back from the stake store.
+func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	var genesisState GenesisState
+	MsgCdc.MustUnmarshalJSON(data, &genesisState)
+	var stakeGenesis types.GenesisState
+	am.keeper.validatorSet.IterateValidators(ctx, func(_ int64, validator sdk.Validator) (stop bool) {
+		stakeGenesis.Validators = append(stakeGenesis.Validators, validator.(types.Validator))
+		return false
+	})
+	InitGenesis(ctx, *am.keeper, genesisState, stakeGenesis)
+	return nil
+}
+
+// ExportGenesis exports the genesis state
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	var params Params
+	am.keeper.paramspace.GetParamSet(ctx, &params)
+	return MsgCdc.MustMarshalJSON(GenesisState{Params: params})
+}
+
+// BeginBlock handles the signatures and evidences of the last block, the tags of
+// BeginBlocker are emitted as an event of the slashing module
+func (am AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
+	tags := BeginBlocker(ctx, req, *am.keeper)
+	ctx.EventManager().EmitEvent(sdk.Event{Type: ModuleName, Attributes: tags.ToKVPairs()})
+}
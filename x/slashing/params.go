@@ -27,6 +27,10 @@ var (
 	KeyDowntimeSlashAmount      = []byte("DowntimeSlashAmount")
 	KeySubmitterReward          = []byte("SubmitterReward")
 	KeyDowntimeSlashFee         = []byte("DowntimeSlashFee")
+	KeySubmitterRewardRatio     = []byte("SubmitterRewardRatio")
+	KeyMinJailDuration          = []byte("MinJailDuration")
+	KeyUnjailFee                = []byte("UnjailFee")
+	KeyDowntimeLookback         = []byte("DowntimeLookback")
 )
 
 // ParamTypeTable for slashing module
@@ -48,6 +52,11 @@ type Params struct {
 	DowntimeSlashAmount      int64         `json:"downtime_slash_amount"`
 	SubmitterReward          int64         `json:"submitter_reward"`
 	DowntimeSlashFee         int64         `json:"downtime_slash_fee"`
+	SubmitterRewardRatio     sdk.Dec       `json:"submitter_reward_ratio"` // the ratio of the slashed amount paid to the submitter of a double sign evidence instead of SubmitterReward, 0 to disable
+
+	MinJailDuration  time.Duration `json:"min_jail_duration,omitempty"` // the minimum time a side chain validator stays jailed, 0 to disable
+	UnjailFee        int64         `json:"unjail_fee,omitempty"`        // the fee paid to the fee pool to unjail a side chain validator, 0 to disable
+	DowntimeLookback int64         `json:"downtime_lookback,omitempty"` // the number of blocks a side chain validator cannot be unjailed for after a downtime slash, 0 to disable
 }
 
 func (p *Params) GetParamAttribute() (string, bool) {
@@ -80,6 +89,18 @@ func (p *Params) UpdateCheck() error {
 	if p.DowntimeSlashFee < 1e8 || p.DowntimeSlashFee > 1000e8 {
 		return fmt.Errorf("the downtime_slash_fee should be in range 1e8 to 1000e8")
 	}
+	if p.SubmitterRewardRatio.LT(sdk.ZeroDec()) || p.SubmitterRewardRatio.GT(sdk.NewDecWithPrec(5, 1)) {
+		return fmt.Errorf("the submitter_reward_ratio should be in range 0 to 0.5")
+	}
+	if p.MinJailDuration < 0 || p.MinJailDuration > 100*24*time.Hour {
+		return fmt.Errorf("the min_jail_duration should be in range 0 to 100 day")
+	}
+	if p.UnjailFee < 0 || p.UnjailFee > 1000e8 {
+		return fmt.Errorf("the unjail_fee should be in range 0 to 1000e8")
+	}
+	if p.DowntimeLookback < 0 {
+		return fmt.Errorf("the downtime_lookback should be no less than 0")
+	}
 	return nil
 }
 
@@ -98,6 +119,10 @@ func (p *Params) KeyValuePairs() params.KeyValuePairs {
 		{KeyDowntimeSlashAmount, &p.DowntimeSlashAmount},
 		{KeySubmitterReward, &p.SubmitterReward},
 		{KeyDowntimeSlashFee, &p.DowntimeSlashFee},
+		{KeySubmitterRewardRatio, &p.SubmitterRewardRatio},
+		{KeyMinJailDuration, &p.MinJailDuration},
+		{KeyUnjailFee, &p.UnjailFee},
+		{KeyDowntimeLookback, &p.DowntimeLookback},
 	}
 }
 
@@ -206,6 +231,40 @@ func (k Keeper) DowntimeSlashFee(ctx sdk.Context) (downtimeSlashFee int64) {
 	return
 }
 
+// SubmitterRewardRatio - 0 until set, the submitter of a double sign evidence gets the fixed SubmitterReward
+func (k Keeper) SubmitterRewardRatio(ctx sdk.Context) (ratio sdk.Dec) {
+	ratio = sdk.ZeroDec()
+	k.paramspace.GetIfExists(ctx, KeySubmitterRewardRatio, &ratio)
+	return
+}
+
+// MinJailDuration - 0 until set, a side chain validator is jailed for the unbond duration of its infraction only
+func (k Keeper) MinJailDuration(ctx sdk.Context) (res time.Duration) {
+	k.paramspace.GetIfExists(ctx, KeyMinJailDuration, &res)
+	return
+}
+
+// UnjailFee - 0 until set, unjailing a side chain validator is free
+func (k Keeper) UnjailFee(ctx sdk.Context) (res int64) {
+	k.paramspace.GetIfExists(ctx, KeyUnjailFee, &res)
+	return
+}
+
+// DowntimeLookback - 0 until set, a side chain validator can be unjailed right after its jail of a downtime ends
+func (k Keeper) DowntimeLookback(ctx sdk.Context) (res int64) {
+	k.paramspace.GetIfExists(ctx, KeyDowntimeLookback, &res)
+	return
+}
+
+// sideJailUntil returns until when a side chain validator jailed now for the given unbond duration stays jailed, at
+// least MinJailDuration
+func (k Keeper) sideJailUntil(sideCtx sdk.Context, unbondDuration time.Duration) time.Time {
+	if minJailDuration := k.MinJailDuration(sideCtx); unbondDuration < minJailDuration {
+		unbondDuration = minJailDuration
+	}
+	return sideCtx.BlockHeader().Time.Add(unbondDuration)
+}
+
 // set the params
 func (k Keeper) SetParams(ctx sdk.Context, params Params) {
 	k.paramspace.SetParamSet(ctx, &params)
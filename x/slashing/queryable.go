@@ -12,6 +12,7 @@ import (
 const (
 	QueryConsAddrSlashRecords     = "consAddrSlashHistories"
 	QueryConsAddrTypeSlashRecords = "consAddrTypeSlashHistories"
+	QuerySlashSimulation          = "slashSimulation"
 )
 
 // creates a querier for staking REST endpoints
@@ -32,6 +33,13 @@ func NewQuerier(k Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryConsAddrTypeSlashRecords(ctx, k, param)
+		case QuerySlashSimulation:
+			param := new(QuerySlashSimulationParams)
+			ctx, err = RequestPrepare(ctx, k, req, param)
+			if err != nil {
+				return res, err
+			}
+			return querySlashSimulation(ctx, k, param)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown slashing query endpoint")
 		}
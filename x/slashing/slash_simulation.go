@@ -0,0 +1,96 @@
+package slashing
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type QuerySlashSimulationParams struct {
+	BaseParams
+	ValAddr        sdk.ValAddress
+	InfractionType byte
+}
+
+// DelegatorSlash is the amount a delegator would lose
+type DelegatorSlash struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+	Amount    int64          `json:"amount"`
+}
+
+// SlashSimulation is the outcome of a hypothetical infraction of a validator under the current params
+// and stake, without the slashing period cap of double signs and the unbonding delegations and
+// redelegations which would be slashed for an infraction in the past.
+type SlashSimulation struct {
+	Validator      sdk.ValAddress   `json:"validator"`
+	InfractionType byte             `json:"infraction_type"`
+	SlashAmount    int64            `json:"slash_amount"`
+	Delegators     []DelegatorSlash `json:"delegators"`
+	// part of the slash amount of a side chain validator beyond its self delegation, taken from its
+	// self unbonding delegation up to the balance of it
+	RemainingAmount int64 `json:"remaining_amount"`
+}
+
+// SimulateSlash computes the amounts each delegator of a validator would lose if it was slashed for
+// infractionType now. The validators of the side chains are slashed a fixed amount from their self
+// delegation, the others a fraction of their tokens shared by all their delegators.
+func (k Keeper) SimulateSlash(ctx sdk.Context, valAddr sdk.ValAddress, infractionType byte) (SlashSimulation, sdk.Error) {
+	if infractionType != DoubleSign && infractionType != Downtime {
+		return SlashSimulation{}, ErrInvalidInput(k.Codespace, fmt.Sprintf("unknown infraction type %d", infractionType))
+	}
+	validator := k.validatorSet.Validator(ctx, valAddr)
+	if validator == nil {
+		return SlashSimulation{}, ErrNoValidatorForAddress(k.Codespace)
+	}
+	if validator.GetStatus() == sdk.Unbonded {
+		return SlashSimulation{}, ErrInvalidInput(k.Codespace, "unbonded validators are not slashed")
+	}
+
+	simulation := SlashSimulation{
+		Validator:      valAddr,
+		InfractionType: infractionType,
+		Delegators:     make([]DelegatorSlash, 0),
+	}
+	if validator.IsSideChainValidator() {
+		slashAmount := k.DoubleSignSlashAmount(ctx)
+		if infractionType == Downtime {
+			slashAmount = k.DowntimeSlashAmount(ctx)
+		}
+		simulation.SlashAmount = slashAmount
+		simulation.RemainingAmount = slashAmount
+		if delegation := k.validatorSet.Delegation(ctx, validator.GetFeeAddr(), valAddr); delegation != nil {
+			selfTokens := validator.TokensFromShares(delegation.GetShares()).RawInt()
+			amount := sdk.MinInt64(slashAmount, selfTokens)
+			simulation.Delegators = append(simulation.Delegators, DelegatorSlash{validator.GetFeeAddr(), amount})
+			simulation.RemainingAmount -= amount
+		}
+		return simulation, nil
+	}
+
+	fraction := k.SlashFractionDoubleSign(ctx)
+	if infractionType == Downtime {
+		fraction = k.SlashFractionDowntime(ctx)
+	}
+	simulation.SlashAmount = validator.GetTokens().Mul(fraction).RawInt()
+	k.validatorSet.IterateDelegationsToValidator(ctx, valAddr, func(del sdk.Delegation) (stop bool) {
+		amount := validator.TokensFromShares(del.GetShares()).Mul(fraction).RawInt()
+		simulation.Delegators = append(simulation.Delegators, DelegatorSlash{del.GetDelegatorAddr(), amount})
+		return false
+	})
+	return simulation, nil
+}
+
+func querySlashSimulation(ctx sdk.Context, k Keeper, params *QuerySlashSimulationParams) (res []byte, err sdk.Error) {
+	simulation, err := k.SimulateSlash(ctx, params.ValAddr, params.InfractionType)
+	if err != nil {
+		return nil, err
+	}
+
+	res, resErr := codec.MarshalJSONIndent(k.cdc, simulation)
+	if resErr != nil {
+		return res, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", resErr.Error()))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,45 @@
+package slashing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestSimulateSlash(t *testing.T) {
+	ctx, _, sk, _, keeper := createTestInput(t, DefaultParams())
+	sh := stake.NewStakeHandler(sk)
+	amt := sdk.NewDecWithoutFra(100).RawInt()
+	got := sh(ctx, NewTestMsgCreateValidator(addrs[0], pks[0], amt))
+	require.True(t, got.IsOK())
+	got = sh(ctx, newTestMsgDelegate(sdk.AccAddress(addrs[2]), addrs[0], 3*amt))
+	require.True(t, got.IsOK())
+	stake.EndBlocker(ctx, sk)
+
+	_, err := keeper.SimulateSlash(ctx, addrs[1], DoubleSign)
+	require.NotNil(t, err, "unknown validator")
+	_, err = keeper.SimulateSlash(ctx, addrs[0], 2)
+	require.NotNil(t, err, "unknown infraction type")
+
+	// 5% of the stake of each delegator for a double sign
+	simulation, err := keeper.SimulateSlash(ctx, addrs[0], DoubleSign)
+	require.Nil(t, err)
+	require.Equal(t, 4*amt/20, simulation.SlashAmount)
+	losses := make(map[string]int64)
+	for _, delegator := range simulation.Delegators {
+		losses[delegator.Delegator.String()] = delegator.Amount
+	}
+	require.Equal(t, map[string]int64{
+		sdk.AccAddress(addrs[0]).String(): amt / 20,
+		sdk.AccAddress(addrs[2]).String(): 3 * amt / 20,
+	}, losses)
+
+	// 1% for a downtime
+	simulation, err = keeper.SimulateSlash(ctx, addrs[0], Downtime)
+	require.Nil(t, err)
+	require.Equal(t, 4*amt/100, simulation.SlashAmount)
+	require.Len(t, simulation.Delegators, 2)
+}
@@ -36,5 +36,10 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, sk Keeper) (tags
 		}
 	}
 
+	// Handle the slash indications of the side chains scheduled for this block
+	if sk.evidenceFetcher != nil {
+		sk.evidenceFetcher.Process(ctx)
+	}
+
 	return
 }
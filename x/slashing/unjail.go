@@ -17,7 +17,11 @@ func (k Keeper) Unjail(ctx sdk.Context, validatorAddr sdk.ValAddress) sdk.Error
 		return ErrMissingSelfDelegation(k.Codespace)
 	}
 
-	if validator.TokensFromShares(selfDel.GetShares()).RawInt() < k.validatorSet.MinSelfDelegation(ctx) {
+	minSelfDelegation := k.validatorSet.MinSelfDelegation(ctx)
+	if validator.GetMinSelfDelegation() > minSelfDelegation {
+		minSelfDelegation = validator.GetMinSelfDelegation()
+	}
+	if validator.TokensFromShares(selfDel.GetShares()).RawInt() < minSelfDelegation {
 		return ErrSelfDelegationTooLowToUnjail(k.Codespace)
 	}
 
@@ -39,9 +43,23 @@ func (k Keeper) Unjail(ctx sdk.Context, validatorAddr sdk.ValAddress) sdk.Error
 
 	// cannot be unjailed until out of jail
 	if ctx.BlockHeader().Time.Before(info.JailedUntil) {
+		if validator.IsSideChainValidator() {
+			return ErrValidatorJailedUntil(k.Codespace, info.JailedUntil)
+		}
 		return ErrValidatorJailed(k.Codespace)
 	}
 
+	// cannot be unjailed while a downtime slash is within the lookback window
+	if validator.IsSideChainValidator() {
+		if lookback := k.DowntimeLookback(ctx); lookback > 0 {
+			for _, record := range k.getSlashRecordsByConsAddrAndType(ctx, consAddr, Downtime) {
+				if record.SlashHeight+lookback > ctx.BlockHeight() {
+					return ErrRecentDowntime(k.Codespace, record.SlashHeight, lookback)
+				}
+			}
+		}
+	}
+
 	// unjail the validator
 	if validator.IsSideChainValidator() {
 		k.validatorSet.UnjailSideChain(ctx, consAddr)
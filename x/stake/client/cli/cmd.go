@@ -32,6 +32,10 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 		client.GetCommands(
 			GetCmdQueryValidator(storeKey, cdc),
 			GetCmdQueryValidators(storeKey, cdc),
+			GetCmdQueryValidatorDelegations(cdc),
+			GetCmdQueryDelegatorExport(cdc),
+			GetCmdQueryDelegatorPosition(cdc),
+			GetCmdQueryHistoricalValidators(cdc),
 			GetCmdQueryParams(storeKey, cdc),
 			GetCmdQueryDelegation(storeKey, cdc),
 			GetCmdQueryDelegations(storeKey, cdc),
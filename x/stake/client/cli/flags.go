@@ -24,6 +24,7 @@ const (
 	FlagCommissionRate          = "commission-rate"
 	FlagCommissionMaxRate       = "commission-max-rate"
 	FlagCommissionMaxChangeRate = "commission-max-change-rate"
+	FlagMinSelfDelegation       = "min-self-delegation"
 
 	FlagGenesisFormat = "genesis-format"
 	FlagOffline       = "offline"
@@ -40,6 +41,10 @@ const (
 	FlagSideChainId  = "side-chain-id"
 	FlagSideConsAddr = "side-cons-addr"
 	FlagSideFeeAddr  = "side-fee-addr"
+
+	FlagStatus = "status"
+	FlagPage   = "page"
+	FlagLimit  = "limit"
 )
 
 // common flagsets to add to various functions
@@ -50,6 +55,7 @@ var (
 	fsDescriptionCreate = flag.NewFlagSet("", flag.ContinueOnError)
 	fsCommissionCreate  = flag.NewFlagSet("", flag.ContinueOnError)
 	fsCommissionUpdate  = flag.NewFlagSet("", flag.ContinueOnError)
+	fsMinSelfDelegation = flag.NewFlagSet("", flag.ContinueOnError)
 	fsDescriptionEdit   = flag.NewFlagSet("", flag.ContinueOnError)
 	fsValidator         = flag.NewFlagSet("", flag.ContinueOnError)
 	fsDelegator         = flag.NewFlagSet("", flag.ContinueOnError)
@@ -57,6 +63,7 @@ var (
 	fsSideChainFull     = flag.NewFlagSet("", flag.ContinueOnError)
 	fsSideChainEdit     = flag.NewFlagSet("", flag.ContinueOnError)
 	fsSideChainId       = flag.NewFlagSet("", flag.ContinueOnError)
+	fsPage              = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -72,6 +79,7 @@ func init() {
 	fsCommissionCreate.String(FlagCommissionRate, "", "The initial commission rate percentage")
 	fsCommissionCreate.String(FlagCommissionMaxRate, "", "The maximum commission rate percentage")
 	fsCommissionCreate.String(FlagCommissionMaxChangeRate, "", "The maximum commission change rate percentage (per day)")
+	fsMinSelfDelegation.Int64(FlagMinSelfDelegation, 0, "The self delegation the validator commits to keep at least, it can only be increased")
 	fsDescriptionEdit.String(FlagMoniker, types.DoNotModifyDesc, "validator name")
 	fsDescriptionEdit.String(FlagIdentity, types.DoNotModifyDesc, "optional identity signature (ex. UPort or Keybase)")
 	fsDescriptionEdit.String(FlagWebsite, types.DoNotModifyDesc, "optional website")
@@ -87,4 +95,6 @@ func init() {
 	fsSideChainEdit.String(FlagSideFeeAddr, "", "address that validator collects fee rewards on side chain, please use hex format prefixed with 0x")
 	fsSideChainEdit.String(FlagSideConsAddr, "", "consensus address of the validator on side chain, please use hex format prefixed with 0x")
 	fsSideChainId.String(FlagSideChainId, "", "chain-id of the side chain the validator belongs to")
+	fsPage.Int(FlagPage, 0, "(optional) page of the results to get, starting from 1")
+	fsPage.Int(FlagLimit, 0, "(optional) number of results per page, at most 100")
 }
@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -71,9 +73,19 @@ func GetCmdQueryValidators(storeName string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "validators",
 		Short: "Query for all validators",
+		Long: `Query for all validators, or for a page of them with --page or --limit.
+The validators can be filtered by their status with --status bonded|unbonding|unbonded.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			key := stake.ValidatorsKey
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			params := stake.QueryValidatorsParams{
+				PageParams: stake.PageParams{Page: viper.GetInt(FlagPage), Limit: viper.GetInt(FlagLimit)},
+				Status:     viper.GetString(FlagStatus),
+			}
+			if params.Paginated() || params.Status != "" {
+				return queryValidatorsPage(cliCtx, cdc, params)
+			}
+
+			key := stake.ValidatorsKey
 
 			resKVs, err := cliCtx.QuerySubspace(key, storeName)
 			if err != nil {
@@ -114,6 +126,212 @@ func GetCmdQueryValidators(storeName string, cdc *codec.Codec) *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().String(FlagStatus, "", "(optional) status of the validators, bonded|unbonding|unbonded")
+	cmd.Flags().AddFlagSet(fsPage)
+
+	return cmd
+}
+
+// queryValidatorsPage queries the validators with the custom query, which filters and paginates them
+func queryValidatorsPage(cliCtx context.CLIContext, cdc *codec.Codec, params stake.QueryValidatorsParams) error {
+	bz, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	res, err := cliCtx.QueryWithData("custom/stake/validators", bz)
+	if err != nil {
+		return err
+	}
+
+	var page stake.PagedValidators
+	if params.Paginated() {
+		err = cdc.UnmarshalJSON(res, &page)
+	} else {
+		err = cdc.UnmarshalJSON(res, &page.Validators)
+		page.Total = len(page.Validators)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch viper.Get(cli.OutputFlag) {
+	case "text":
+		for _, validator := range page.Validators {
+			resp, err := validator.HumanReadableString()
+			if err != nil {
+				return err
+			}
+			fmt.Println(resp)
+		}
+		fmt.Printf("total: %d\n", page.Total)
+	case "json":
+		fmt.Println(string(res))
+	}
+	return nil
+}
+
+// GetCmdQueryValidatorDelegations implements the command to query the delegations to a validator.
+func GetCmdQueryValidatorDelegations(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator-delegations [operator-addr]",
+		Short: "Query the delegations made to one validator, a page of them with --page or --limit",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			valAddr, err := sdk.ValAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := stake.QueryValDelegationsParams{
+				BaseParams:    stake.NewBaseParams(viper.GetString(FlagSideChainId)),
+				PageParams:    stake.PageParams{Page: viper.GetInt(FlagPage), Limit: viper.GetInt(FlagLimit)},
+				ValidatorAddr: valAddr,
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+			res, err := cliCtx.QueryWithData("custom/stake/validatorDelegations", bz)
+			if err != nil {
+				return err
+			}
+
+			var page stake.PagedDelegations
+			if params.Paginated() {
+				err = cdc.UnmarshalJSON(res, &page)
+			} else {
+				err = cdc.UnmarshalJSON(res, &page.Delegations)
+				page.Total = len(page.Delegations)
+			}
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				for _, delegation := range page.Delegations {
+					resp, err := delegation.HumanReadableString()
+					if err != nil {
+						return err
+					}
+					fmt.Printf("%s  Balance: %s\n", resp, sdk.FormatCoin(delegation.Balance))
+				}
+				fmt.Printf("total: %d\n", page.Total)
+			case "json":
+				fmt.Println(string(res))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagSideChainId, "", "(optional) chain-id of the side chain the validator belongs to")
+	cmd.Flags().AddFlagSet(fsPage)
+
+	return cmd
+}
+
+// GetCmdQueryDelegatorExport implements the command to export the staking state of a delegator.
+func GetCmdQueryDelegatorExport(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-delegator [delegator-addr]",
+		Short: "Export the delegations, unbonding delegations and unpaid rewards of one delegator with their hash",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := stake.QueryDelegatorParams{
+				BaseParams:    stake.NewBaseParams(viper.GetString(FlagSideChainId)),
+				DelegatorAddr: delAddr,
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+			res, err := cliCtx.QueryWithData("custom/stake/delegatorExport", bz)
+			if err != nil {
+				return err
+			}
+
+			var export stake.DelegatorExport
+			if err = cdc.UnmarshalJSON(res, &export); err != nil {
+				return err
+			}
+			fmt.Println(string(export.CanonicalBytes()))
+			fmt.Printf("hash: %X\n", export.Hash())
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagSideChainId, "", "(optional) chain-id of the side chain to export the delegator on")
+
+	return cmd
+}
+
+// GetCmdQueryDelegatorPosition implements the command to query the stake of a delegator on all the chains.
+func GetCmdQueryDelegatorPosition(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegator-position [delegator-addr]",
+		Short: "Query the bonded, unbonding and unpaid reward amounts of a delegator across the native chain and all the side chains",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := stake.QueryDelegatorParams{
+				DelegatorAddr: delAddr,
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+			res, err := cliCtx.QueryWithData("custom/stake/delegatorPosition", bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// GetCmdQueryHistoricalValidators implements the command to query the validator set at a past height.
+func GetCmdQueryHistoricalValidators(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "historical-validators [height]",
+		Short: "Query the validator set elected in the last breathe block at or before a height",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := stake.QueryHistoricalValidatorsParams{
+				BaseParams: stake.NewBaseParams(viper.GetString(FlagSideChainId)),
+				Height:     height,
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+			res, err := cliCtx.QueryWithData("custom/stake/historical-validators", bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+	cmd.Flags().String(FlagSideChainId, "", "(optional) chain-id of the side chain to query the validator set of")
 
 	return cmd
 }
@@ -479,7 +697,15 @@ func GetCmdQueryParams(storeName string, cdc *codec.Codec) *cobra.Command {
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			bz, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryParameters, nil)
+			var data []byte
+			if sideChainId := viper.GetString(FlagSideChainId); sideChainId != "" {
+				var err error
+				data, err = json.Marshal(stake.NewBaseParams(sideChainId))
+				if err != nil {
+					return err
+				}
+			}
+			bz, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryParameters, data)
 			if err != nil {
 				return err
 			}
@@ -508,6 +734,7 @@ func GetCmdQueryParams(storeName string, cdc *codec.Codec) *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().String(FlagSideChainId, "", "(optional) chain-id of the side chain to query the parameters of")
 
 	return cmd
 }
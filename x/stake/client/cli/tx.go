@@ -278,6 +278,8 @@ func GetCmdCreateValidatorOpen(cdc *codec.Codec) *cobra.Command {
 				Description:   description,
 				Commission:    commissionMsg,
 				Delegation:    amount,
+
+				MinSelfDelegation: viper.GetInt64(FlagMinSelfDelegation),
 			}
 			if viper.GetString(FlagAddressDelegator) != "" {
 				delAddr, err := sdk.AccAddressFromBech32(viper.GetString(FlagAddressDelegator))
@@ -295,6 +297,7 @@ func GetCmdCreateValidatorOpen(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(fsAmount)
 	cmd.Flags().AddFlagSet(fsDescriptionCreate)
 	cmd.Flags().AddFlagSet(fsCommissionCreate)
+	cmd.Flags().AddFlagSet(fsMinSelfDelegation)
 	cmd.Flags().AddFlagSet(fsDelegator)
 	cmd.MarkFlagRequired(client.FlagFrom)
 
@@ -339,12 +342,14 @@ func GetCmdEditValidator(cdc *codec.Codec) *cobra.Command {
 			pkStr := viper.GetString(FlagPubKey)
 
 			msg := stake.NewMsgEditValidator(sdk.ValAddress(valAddr), description, newRate, pkStr)
+			msg.MinSelfDelegation = viper.GetInt64(FlagMinSelfDelegation)
 			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
 		},
 	}
 
 	cmd.Flags().AddFlagSet(fsDescriptionEdit)
 	cmd.Flags().AddFlagSet(fsCommissionUpdate)
+	cmd.Flags().AddFlagSet(fsMinSelfDelegation)
 	cmd.Flags().AddFlagSet(fsPk)
 
 	return cmd
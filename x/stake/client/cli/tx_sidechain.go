@@ -65,7 +65,7 @@ func GetCmdCreateSideChainValidator(cdc *codec.Codec) *cobra.Command {
 			return err
 		}
 
-		var msg sdk.Msg
+		var msg stake.MsgCreateSideChainValidator
 		if viper.GetString(FlagAddressDelegator) != "" {
 			delAddr, err := sdk.AccAddressFromBech32(viper.GetString(FlagAddressDelegator))
 			if err != nil {
@@ -78,6 +78,7 @@ func GetCmdCreateSideChainValidator(cdc *codec.Codec) *cobra.Command {
 			msg = stake.NewMsgCreateSideChainValidator(
 				sdk.ValAddress(valAddr), amount, description, commissionMsg, sideChainId, sideConsAddr, sideFeeAddr)
 		}
+		msg.MinSelfDelegation = viper.GetInt64(FlagMinSelfDelegation)
 
 		return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
 	}
@@ -85,6 +86,7 @@ func GetCmdCreateSideChainValidator(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(fsAmount)
 	cmd.Flags().AddFlagSet(fsDescriptionCreate)
 	cmd.Flags().AddFlagSet(fsCommissionCreate)
+	cmd.Flags().AddFlagSet(fsMinSelfDelegation)
 	cmd.Flags().AddFlagSet(fsDelegator)
 	cmd.Flags().AddFlagSet(fsSideChainFull)
 	cmd.MarkFlagRequired(client.FlagFrom)
@@ -131,11 +133,13 @@ func GetCmdEditSideChainValidator(cdc *codec.Codec) *cobra.Command {
 			return err
 		}
 		msg := stake.NewMsgEditSideChainValidator(sideChainId, sdk.ValAddress(valAddr), description, newRate, sideFeeAddr, sideConsAddr)
+		msg.MinSelfDelegation = viper.GetInt64(FlagMinSelfDelegation)
 		return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
 	}
 
 	cmd.Flags().AddFlagSet(fsDescriptionEdit)
 	cmd.Flags().AddFlagSet(fsCommissionUpdate)
+	cmd.Flags().AddFlagSet(fsMinSelfDelegation)
 	cmd.Flags().AddFlagSet(fsSideChainEdit)
 	return cmd
 }
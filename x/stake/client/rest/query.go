@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 	"github.com/gorilla/mux"
 )
@@ -35,6 +36,12 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		delegatorRedelegationsHandlerFn(cliCtx, cdc),
 	).Methods("GET")
 
+	// Get the stake of a delegator aggregated across the native chain and all the side chains
+	r.HandleFunc(
+		"/stake/delegators/{delegatorAddr}/position",
+		delegatorPositionHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
 	// Get all staking txs (i.e msgs) from a delegator
 	r.HandleFunc(
 		"/stake/delegators/{delegatorAddr}/txs",
@@ -83,12 +90,24 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		validatorUnbondingDelegationsHandlerFn(cliCtx, cdc),
 	).Methods("GET")
 
+	// Get the delegations to a validator
+	r.HandleFunc(
+		"/stake/validators/{validatorAddr}/delegations",
+		validatorDelegationsHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
 	// Get all outgoing redelegations from a validator
 	r.HandleFunc(
 		"/stake/validators/{validatorAddr}/redelegations",
 		validatorRedelegationsHandlerFn(cliCtx, cdc),
 	).Methods("GET")
 
+	// Get the validator set at a past height
+	r.HandleFunc(
+		"/stake/historical_validators/{height}",
+		historicalValidatorsHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
 	// Get the current state of the staking pool
 	r.HandleFunc(
 		"/stake/pool",
@@ -108,6 +127,11 @@ func delegatorDelegationsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec)
 	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorDelegations")
 }
 
+// HTTP request handler to query the stake of a delegator on all the chains
+func delegatorPositionHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorPosition")
+}
+
 // HTTP request handler to query a delegator unbonding delegations
 func delegatorUnbondingDelegationsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
 	return queryDelegator(cliCtx, cdc, "custom/stake/delegatorUnbondingDelegations")
@@ -206,10 +230,24 @@ func delegatorValidatorHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) ht
 	return queryBonds(cliCtx, cdc, "custom/stake/delegatorValidator")
 }
 
-// HTTP request handler to query list of validators
+// HTTP request handler to query list of validators, the optional status, page and limit query parameters filter and
+// paginate them
 func validatorsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		res, err := cliCtx.QueryWithData("custom/stake/validators", nil)
+		params := stake.QueryValidatorsParams{Status: r.URL.Query().Get("status")}
+		if !parsePageParamsOrReturnBadRequest(w, r, &params.PageParams) {
+			return
+		}
+
+		var bz []byte
+		if params.Paginated() || params.Status != "" {
+			var err error
+			if bz, err = cdc.MarshalJSON(params); err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		res, err := cliCtx.QueryWithData("custom/stake/validators", bz)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -223,6 +261,61 @@ func validatorHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.Handle
 	return queryValidator(cliCtx, cdc, "custom/stake/validator")
 }
 
+// HTTP request handler to query the delegations to a validator, the optional page and limit query parameters paginate
+// them
+func validatorDelegationsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		validatorAddr, err := sdk.ValAddressFromBech32(mux.Vars(r)["validatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		params := stake.QueryValDelegationsParams{ValidatorAddr: validatorAddr}
+		if !parsePageParamsOrReturnBadRequest(w, r, &params.PageParams) {
+			return
+		}
+
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		res, err := cliCtx.QueryWithData("custom/stake/validatorDelegations", bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// HTTP request handler to query the validator set at a past height, of the side chain given by the optional
+// side_chain_id query parameter
+func historicalValidatorsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		height, ok := utils.ParseInt64OrReturnBadRequest(w, mux.Vars(r)["height"])
+		if !ok {
+			return
+		}
+		params := stake.QueryHistoricalValidatorsParams{
+			BaseParams: stake.NewBaseParams(r.URL.Query().Get("side_chain_id")),
+			Height:     height,
+		}
+
+		bz, err := cdc.MarshalJSON(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		res, err := cliCtx.QueryWithData("custom/stake/historical-validators", bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
 // HTTP request handler to query all unbonding delegations from a validator
 func validatorUnbondingDelegationsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
 	return queryValidator(cliCtx, cdc, "custom/stake/validatorUnbondingDelegations")
@@ -245,10 +338,20 @@ func poolHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc
 	}
 }
 
-// HTTP request handler to query the staking params values
+// HTTP request handler to query the staking params values, of the side chain given by the optional side_chain_id
+// query parameter
 func paramsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		res, err := cliCtx.QueryWithData("custom/stake/parameters", nil)
+		var bz []byte
+		if sideChainId := r.URL.Query().Get("side_chain_id"); sideChainId != "" {
+			var err error
+			bz, err = cdc.MarshalJSON(stake.NewBaseParams(sideChainId))
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		res, err := cliCtx.QueryWithData("custom/stake/parameters", bz)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -112,6 +112,25 @@ func queryDelegator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 	}
 }
 
+// parsePageParamsOrReturnBadRequest reads the optional page and limit query parameters into params
+func parsePageParamsOrReturnBadRequest(w http.ResponseWriter, r *http.Request, params *stake.PageParams) bool {
+	if strPage := r.URL.Query().Get("page"); strPage != "" {
+		page, ok := utils.ParseInt64OrReturnBadRequest(w, strPage)
+		if !ok {
+			return false
+		}
+		params.Page = int(page)
+	}
+	if strLimit := r.URL.Query().Get("limit"); strLimit != "" {
+		limit, ok := utils.ParseInt64OrReturnBadRequest(w, strLimit)
+		if !ok {
+			return false
+		}
+		params.Limit = int(limit)
+	}
+	return true
+}
+
 func queryValidator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -1,6 +1,7 @@
 package cross_stake
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
@@ -291,6 +292,13 @@ func (app *CrossStakeApp) handleRedelegate(ctx sdk.Context, pack *types.CrossSta
 		}, errCode, nil
 	}
 
+	if sdkErr := app.stakeKeeper.CheckRedelegationRestriction(ctx, pack.ValSrc); sdkErr != nil {
+		errCode = CrossStakeErrBadDelegation
+		return sdk.ExecuteResult{
+			Err: sdkErr,
+		}, errCode, nil
+	}
+
 	delAddr := types.GetStakeCAoB(pack.DelAddr[:], types.DelegateCAoBSalt)
 	shares, sdkErr := app.stakeKeeper.ValidateUnbondAmount(ctx, delAddr, pack.ValSrc, pack.Amount.Int64())
 	if sdkErr != nil {
@@ -379,3 +387,61 @@ func (app *CrossStakeApp) handleDistributeUndelegatedRefund(ctx sdk.Context, pac
 		Tags: sdk.Tags{sdk.GetPegOutTag(symbol, pack.Amount.Int64())},
 	}, nil
 }
+
+var _ sdk.CrossChainPackageValuer = &CrossStakeApp{}
+
+// PackageValue implements sdk.CrossChainPackageValuer, the value of a package is the amount
+// delegated, undelegated, redelegated or refunded by it, so that the oracle can delay high value packages
+func (app *CrossStakeApp) PackageValue(_ sdk.Context, payload []byte, packageType sdk.CrossChainPackageType) (int64, error) {
+	// empty packages are ignored by the Execute functions
+	if len(payload) == 0 {
+		return 0, nil
+	}
+
+	var amount *big.Int
+	fromBSC := false
+	switch packageType {
+	case sdk.SynCrossChainPackageType:
+		pack, err := DeserializeCrossStakeSynPackage(payload)
+		if err != nil {
+			return 0, err
+		}
+		switch p := pack.(type) {
+		case *types.CrossStakeDelegateSynPackage:
+			amount = p.Amount
+		case *types.CrossStakeUndelegateSynPackage:
+			amount = p.Amount
+		case *types.CrossStakeRedelegateSynPackage:
+			amount = p.Amount
+		}
+	case sdk.AckCrossChainPackageType:
+		pack, err := DeserializeCrossStakeRefundPackage(payload)
+		if err != nil {
+			return 0, err
+		}
+		amount = pack.Amount
+	case sdk.FailAckCrossChainPackageType:
+		pack, err := DeserializeCrossStakeFailAckPackage(payload)
+		if err != nil {
+			return 0, err
+		}
+		switch p := pack.(type) {
+		case *types.CrossStakeDistributeRewardSynPackage:
+			amount = p.Amount
+		case *types.CrossStakeDistributeUndelegatedSynPackage:
+			amount = p.Amount
+		}
+		fromBSC = true
+	}
+	if amount == nil || amount.Sign() < 0 {
+		return 0, fmt.Errorf("invalid cross stake package amount")
+	}
+	if fromBSC {
+		decimals := new(big.Int).Exp(big.NewInt(10), big.NewInt(bsc.BNBDecimalOnBSC-bsc.BNBDecimalOnBC), nil)
+		amount = new(big.Int).Div(amount, decimals)
+	}
+	if !amount.IsInt64() {
+		return 0, fmt.Errorf("cross stake package amount %s overflows", amount.String())
+	}
+	return amount.Int64(), nil
+}
@@ -0,0 +1,53 @@
+package cross_stake
+
+import (
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/crossquery"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// DelegationQueryParams are the params of a crossquery.QueryTypeDelegation query
+type DelegationQueryParams struct {
+	DelAddr   sdk.SmartChainAddress
+	Validator sdk.ValAddress
+}
+
+// DelegationQueryResult is the amount delegated to the validator with cross stake by the smart
+// chain address, in the unit of the smart chain, 0 if there is no such delegation
+type DelegationQueryResult struct {
+	Amount *big.Int
+}
+
+// NewDelegationQueryHandler returns the handler of the crossquery.QueryTypeDelegation queries, the
+// delegations are looked up in the stake of the side chain of the keeper. It's registered with
+//
+//	crossquery.RegisterQueryHandler(crossquery.QueryTypeDelegation, cross_stake.NewDelegationQueryHandler(keeper))
+func NewDelegationQueryHandler(k Keeper) crossquery.QueryHandler {
+	return func(ctx sdk.Context, params []byte) ([]byte, sdk.Error) {
+		var query DelegationQueryParams
+		if err := rlp.DecodeBytes(params, &query); err != nil {
+			return nil, crossquery.ErrInvalidQuery(crossquery.DefaultCodespace, err.Error())
+		}
+		scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, k.DestChainName)
+		if err != nil {
+			return nil, types.ErrInvalidSideChainId(k.Codespace())
+		}
+
+		amount := int64(0)
+		delAddr := types.GetStakeCAoB(query.DelAddr[:], types.DelegateCAoBSalt)
+		if delegation, found := k.GetDelegation(scCtx, delAddr, query.Validator); found {
+			if validator, found := k.GetValidator(scCtx, query.Validator); found {
+				amount = validator.TokensFromShares(delegation.GetShares()).RawInt()
+			}
+		}
+		result, encodeErr := rlp.EncodeToBytes(&DelegationQueryResult{Amount: bsc.ConvertBCAmountToBSCAmount(amount)})
+		if encodeErr != nil {
+			return nil, sdk.ErrInternal(encodeErr.Error())
+		}
+		return result, nil
+	}
+}
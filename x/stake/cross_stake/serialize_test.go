@@ -2,8 +2,13 @@ package cross_stake
 
 import (
 	"encoding/hex"
+	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -25,3 +30,30 @@ func TestDeserializeSynPackage(t *testing.T) {
 		t.Error("wrong event type")
 	}
 }
+
+func TestPackageValue(t *testing.T) {
+	app := NewCrossStakeApp(Keeper{})
+
+	params, err := rlp.EncodeToBytes(types.CrossStakeDelegateSynPackage{Amount: big.NewInt(100e8)})
+	require.NoError(t, err)
+	payload, err := rlp.EncodeToBytes(CrossStakeSynPackageFromBSC{EventType: types.CrossStakeTypeDelegate, ParamsBytes: params})
+	require.NoError(t, err)
+	value, err := app.PackageValue(sdk.Context{}, payload, sdk.SynCrossChainPackageType)
+	require.NoError(t, err)
+	require.Equal(t, int64(100e8), value)
+
+	// fail ack amounts are in the decimals of BSC
+	bscAmount := new(big.Int).Mul(big.NewInt(3e8), big.NewInt(1e10))
+	payload, err = rlp.EncodeToBytes(types.CrossStakeDistributeRewardSynPackage{
+		EventType: types.CrossStakeTypeDistributeReward, Amount: bscAmount})
+	require.NoError(t, err)
+	value, err = app.PackageValue(sdk.Context{}, payload, sdk.FailAckCrossChainPackageType)
+	require.NoError(t, err)
+	require.Equal(t, int64(3e8), value)
+
+	value, err = app.PackageValue(sdk.Context{}, nil, sdk.SynCrossChainPackageType)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), value)
+	_, err = app.PackageValue(sdk.Context{}, []byte("garbage"), sdk.SynCrossChainPackageType)
+	require.Error(t, err)
+}
@@ -13,13 +13,13 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.Valid
 	// only change validator set in breath block after BEP159
 	var events sdk.Events
 	var csEvents sdk.Events
-	if !sdk.IsUpgrade(sdk.BEP159) {
+	if !ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 		_, validatorUpdates, completedUbds, _, events = handleValidatorAndDelegations(ctx, k)
 	} else {
 		k.DistributeInBlock(ctx, types.ChainIDForBeaconChain)
 		validatorUpdates = k.PopPendingABCIValidatorUpdate(ctx)
 	}
-	if sdk.IsUpgrade(sdk.BEP128) {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP128) {
 		sideChainIds, storePrefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
 		if len(sideChainIds) == len(storePrefixes) {
 			for i := range storePrefixes {
@@ -30,9 +30,10 @@ func EndBlocker(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.Valid
 			panic("sideChainIds does not equal to sideChainStores")
 		}
 	}
-	if sdk.IsUpgrade(sdk.BEP153) {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP153) {
 		events = events.AppendEvents(csEvents)
 	}
+	events = events.AppendEvents(checkSideChainHeartbeats(ctx, k))
 	ctx.EventManager().EmitEvents(events)
 	return
 }
@@ -52,11 +53,11 @@ func EndBreatheBlock(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.
 		}
 		k.PbsbServer.Publish(sideValidatorsEvent)
 	}
-	if sdk.IsUpgrade(sdk.BEP159) {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 		storeValidatorsWithHeight(ctx, newVals, k)
 	}
 
-	if sdk.IsUpgrade(sdk.LaunchBscUpgrade) && k.ScKeeper != nil {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LaunchBscUpgrade) && k.ScKeeper != nil {
 		// distribute sidechain rewards
 		sideChainIds, storePrefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
 		for i := range storePrefixes {
@@ -74,27 +75,41 @@ func EndBreatheBlock(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.
 			storeValidatorsWithHeight(sideChainCtx, newVals, k)
 
 			var csEvents sdk.Events
-			if sdk.IsUpgrade(sdk.BEP128) {
+			if ctx.UpgradeMgr().IsUpgrade(sdk.BEP128) {
 				csEvents = k.DistributeInBreathBlock(sideChainCtx, sideChainIds[i])
 			} else {
 				k.Distribute(sideChainCtx, sideChainIds[i])
 			}
-			if sdk.IsUpgrade(sdk.BEP153) {
+			if ctx.UpgradeMgr().IsUpgrade(sdk.BEP153) {
 				events = events.AppendEvents(csEvents)
 			}
 
 			publishCompletedUBD(k, completedUbds, sideChainIds[i], ctx.BlockHeight())
 			publishCompletedRED(k, completedREDs, sideChainIds[i])
 		}
-		if sdk.IsUpgrade(sdk.BEP159) {
+		if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 			// distribute beacon chain rewards
 			k.DistributeInBreathBlock(ctx, types.ChainIDForBeaconChain)
 		}
 	}
+	events = events.AppendEvents(checkSideChainHeartbeats(ctx, k))
 	ctx.EventManager().EmitEvents(events)
 	return
 }
 
+// checkSideChainHeartbeats returns the warnings about the side chain validators missing their heartbeats,
+// the heartbeats are enabled by the HeartbeatTimeout of each side chain
+func checkSideChainHeartbeats(ctx sdk.Context, k keeper.Keeper) (events sdk.Events) {
+	if k.ScKeeper == nil {
+		return nil
+	}
+	sideChainIds, storePrefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
+	for i := range storePrefixes {
+		events = events.AppendEvents(k.CheckHeartbeats(ctx.WithSideChainKeyPrefix(storePrefixes[i]), sideChainIds[i]))
+	}
+	return events
+}
+
 func publishCompletedUBD(k keeper.Keeper, completedUbds []types.UnbondingDelegation, sideChainId string, height int64) {
 	if k.PbsbServer != nil && len(completedUbds) > 0 {
 		compUBDsEvent := types.CompletedUBDEvent{
@@ -144,19 +159,25 @@ func saveSideChainValidatorsToIBC(ctx sdk.Context, sideChainId string, newVals [
 
 func storeValidatorsWithHeight(ctx sdk.Context, validators []types.Validator, k keeper.Keeper) {
 	blockHeight := ctx.BlockHeight()
-	for _, validator := range validators {
-		simplifiedDelegations := k.GetSimplifiedDelegationsByValidator(ctx, validator.OperatorAddr)
-		k.SetSimplifiedDelegations(ctx, blockHeight, validator.OperatorAddr, simplifiedDelegations)
+	// the rewards are not allocated to the delegations of the snapshot anymore with the lazy reward distribution
+	if !ctx.UpgradeMgr().IsUpgrade(sdk.LazyRewardDistribution) {
+		for _, validator := range validators {
+			simplifiedDelegations := k.GetSimplifiedDelegationsByValidator(ctx, validator.OperatorAddr)
+			k.SetSimplifiedDelegations(ctx, blockHeight, validator.OperatorAddr, simplifiedDelegations)
+		}
 	}
 	k.SetValidatorsByHeight(ctx, blockHeight, validators)
+	if ctx.UpgradeMgr().IsUpgrade(sdk.HistoricalValidatorSets) {
+		k.SaveHistoricalValidatorSet(ctx, validators)
+	}
 }
 
 func handleValidatorAndDelegations(ctx sdk.Context, k keeper.Keeper) ([]types.Validator, []abci.ValidatorUpdate, []types.UnbondingDelegation, []types.DVVTriplet, sdk.Events) {
 	// calculate validator set changes
 	var newVals []types.Validator
 	var validatorUpdates []abci.ValidatorUpdate
-	ctx.Logger().Debug("handleValidatorAndDelegations", "height", ctx.BlockHeader().Height, "addSnapshot", sdk.IsUpgrade(sdk.BEP159) && ctx.SideChainKeyPrefix() == nil)
-	if sdk.IsUpgrade(sdk.BEP159) && ctx.SideChainKeyPrefix() == nil {
+	ctx.Logger().Debug("handleValidatorAndDelegations", "height", ctx.BlockHeader().Height, "addSnapshot", ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) && ctx.SideChainKeyPrefix() == nil)
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) && ctx.SideChainKeyPrefix() == nil {
 		validatorUpdatesOfEditValidators := k.PopPendingABCIValidatorUpdate(ctx)
 		var validatorUpdatesElect []abci.ValidatorUpdate
 		newVals, validatorUpdatesElect = k.UpdateAndElectValidators(ctx)
@@ -218,7 +239,7 @@ func handleMatureUnbondingDelegations(k keeper.Keeper, ctx sdk.Context) ([]types
 			continue
 		}
 		completed[i] = ubd
-		if sdk.IsUpgrade(sdk.BEP153) {
+		if ctx.UpgradeMgr().IsUpgrade(sdk.BEP153) {
 			events = events.AppendEvents(csEvents)
 		}
 		events = events.AppendEvent(sdk.NewEvent(
@@ -0,0 +1,84 @@
+package stake
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+//---------------------    ForceUndelegationHooks  -----------------
+
+// ForceUndelegationHooks executes the ForceUndelegation proposals of gov, it is enabled by
+// `govKeeper.SetForceUndelegator(stake.NewForceUndelegationHooks(stakeKeeper))`
+type ForceUndelegationHooks struct {
+	k keeper.Keeper
+}
+
+func NewForceUndelegationHooks(k keeper.Keeper) ForceUndelegationHooks {
+	return ForceUndelegationHooks{k}
+}
+
+var _ gov.ForceUndelegator = ForceUndelegationHooks{}
+
+func (hooks ForceUndelegationHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	if proposal.GetProposalType() != gov.ProposalTypeForceUndelegation {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+
+	_, err := hooks.checkForceUndelegation(ctx, proposal)
+	return err
+}
+
+// ForceUndelegate starts the unbonding of all the delegations to the validator of a passed ForceUndelegation
+// proposal, the evidence is checked again as the validator may have changed since the submission. The delegators
+// already unbonding from the validator are skipped, they can undelegate the rest once their unbonding is over.
+func (hooks ForceUndelegationHooks) ForceUndelegate(ctx sdk.Context, proposal gov.Proposal) sdk.Error {
+	setting, err := hooks.checkForceUndelegation(ctx, proposal)
+	if err != nil {
+		return ErrInvalidProposal(hooks.k.Codespace(), err.Error())
+	}
+
+	// collect the delegations first, the unbonding deletes them
+	var delegations []sdk.Delegation
+	hooks.k.IterateDelegationsToValidator(ctx, setting.ValidatorAddr, func(del sdk.Delegation) (stop bool) {
+		delegations = append(delegations, del)
+		return false
+	})
+
+	logger := ctx.Logger().With("module", "stake")
+	for _, del := range delegations {
+		if _, found := hooks.k.GetUnbondingDelegation(ctx, del.GetDelegatorAddr(), del.GetValidatorAddr()); found {
+			logger.Info("The delegator is already unbonding, skip it.", "proposalId", proposal.GetProposalID(),
+				"delegator", del.GetDelegatorAddr())
+			continue
+		}
+		if _, err := hooks.k.BeginUnbonding(ctx, del.GetDelegatorAddr(), del.GetValidatorAddr(), del.GetShares()); err != nil {
+			return err
+		}
+	}
+	logger.Info(fmt.Sprintf("force undelegated %d delegations", len(delegations)), "proposalId", proposal.GetProposalID(),
+		"validator", setting.ValidatorAddr)
+	return nil
+}
+
+// checkForceUndelegation parses the description of a ForceUndelegation proposal and verifies its evidence
+func (hooks ForceUndelegationHooks) checkForceUndelegation(ctx sdk.Context, proposal gov.Proposal) (types.ForceUndelegationSetting, error) {
+	var setting types.ForceUndelegationSetting
+	if err := types.MsgCdc.UnmarshalJSON([]byte(proposal.GetDescription()), &setting); err != nil {
+		return setting, fmt.Errorf("the description of a force undelegation proposal is not a force undelegation setting: %v", err)
+	}
+	if err := setting.Check(); err != nil {
+		return setting, err
+	}
+	validator, found := hooks.k.GetValidator(ctx, setting.ValidatorAddr)
+	if !found {
+		return setting, fmt.Errorf("validator %s does not exist", setting.ValidatorAddr)
+	}
+	if err := setting.Verify(ctx.ChainID(), validator); err != nil {
+		return setting, err
+	}
+	return setting, nil
+}
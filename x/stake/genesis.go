@@ -22,8 +22,26 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 	// We need to pretend to be "n blocks before genesis", where "n" is the validator update delay,
 	// so that e.g. slashing periods are correctly initialized for the validator set
 	// e.g. with a one-block offset - the first TM block is at height 0, so state updates applied from genesis.json are in block -1.
-	ctx = ctx.WithBlockHeight(-types.ValidatorUpdateDelay)
+	ctx = genesisContext(ctx)
+	if err = initGenesisValidators(ctx, keeper, data); err != nil {
+		return res, err
+	}
 
+	for _, delegation := range data.Bonds {
+		initGenesisDelegation(ctx, keeper, delegation)
+	}
+
+	_, res = keeper.ApplyAndReturnValidatorSetUpdates(ctx)
+	return
+}
+
+// genesisContext pretends to be "n blocks before genesis", see InitGenesis
+func genesisContext(ctx sdk.Context) sdk.Context {
+	return ctx.WithBlockHeight(-types.ValidatorUpdateDelay)
+}
+
+// initGenesisValidators sets the pool, the parameters and the validators of data, its bonds are ignored
+func initGenesisValidators(ctx sdk.Context, keeper Keeper, data types.GenesisState) error {
 	keeper.SetPool(ctx, data.Pool)
 	keeper.SetParams(ctx, data.Params)
 
@@ -32,10 +50,10 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 		keeper.SetValidator(ctx, validator)
 
 		if validator.Tokens.IsZero() {
-			return res, errors.Errorf("genesis validator cannot have zero pool shares, validator: %v", validator)
+			return errors.Errorf("genesis validator cannot have zero pool shares, validator: %v", validator)
 		}
 		if validator.DelegatorShares.IsZero() {
-			return res, errors.Errorf("genesis validator cannot have zero delegator shares, validator: %v", validator)
+			return errors.Errorf("genesis validator cannot have zero delegator shares, validator: %v", validator)
 		}
 
 		// Manually set indices for the first time
@@ -43,14 +61,12 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 		keeper.SetValidatorByPowerIndex(ctx, validator)
 		keeper.OnValidatorCreated(ctx, validator.OperatorAddr)
 	}
+	return nil
+}
 
-	for _, delegation := range data.Bonds {
-		keeper.SetDelegation(ctx, delegation)
-		keeper.OnDelegationCreated(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
-	}
-
-	_, res = keeper.ApplyAndReturnValidatorSetUpdates(ctx)
-	return
+func initGenesisDelegation(ctx sdk.Context, keeper Keeper, delegation types.Delegation) {
+	keeper.SetDelegation(ctx, delegation)
+	keeper.OnDelegationCreated(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
 }
 
 // WriteGenesis returns a GenesisState for a given context and keeper. The
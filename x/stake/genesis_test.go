@@ -1,7 +1,10 @@
 package stake
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
@@ -108,6 +111,40 @@ func TestInitGenesisLargeValidatorSet(t *testing.T) {
 	require.Equal(t, abcivals, vals)
 }
 
+func TestStreamingGenesis(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+
+	pool := keeper.GetPool(ctx)
+	pool.BondedTokens = sdk.NewDecWithoutFra(2)
+	validators := []Validator{
+		NewValidator(sdk.ValAddress(keep.Addrs[0]), keep.PKs[0], Description{Moniker: "hoop"}),
+		NewValidator(sdk.ValAddress(keep.Addrs[1]), keep.PKs[1], Description{Moniker: "bloop"}),
+	}
+	delegations := make([]Delegation, len(validators))
+	for i := range validators {
+		validators[i].Status = sdk.Bonded
+		validators[i].Tokens = sdk.OneDec()
+		validators[i].DelegatorShares = sdk.OneDec()
+		delegations[i] = Delegation{DelegatorAddr: keep.Addrs[i], ValidatorAddr: validators[i].OperatorAddr, Shares: sdk.OneDec()}
+	}
+	vals, err := InitGenesis(ctx, keeper, types.NewGenesisState(pool, keeper.GetParams(ctx), validators, delegations))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, NewAppModule(&keeper).ExportGenesisStream(ctx, &buf))
+
+	ctx2, _, keeper2 := keep.CreateTestInput(t, false, 1000)
+	vals2, err := NewAppModule(&keeper2).InitGenesisStream(ctx2, json.NewDecoder(bytes.NewReader(buf.Bytes())))
+	require.NoError(t, err)
+	require.Equal(t, vals, vals2)
+	require.Equal(t, WriteGenesis(ctx, keeper), WriteGenesis(ctx2, keeper2))
+
+	// the bonds must be the last field
+	ctx3, _, keeper3 := keep.CreateTestInput(t, false, 1000)
+	_, err = NewAppModule(&keeper3).InitGenesisStream(ctx3, json.NewDecoder(strings.NewReader(`{"bonds":[],"validators":[]}`)))
+	require.Error(t, err)
+}
+
 func TestValidateGenesis(t *testing.T) {
 	genValidators1 := make([]types.Validator, 1, 5)
 	pk := ed25519.GenPrivKey().PubKey()
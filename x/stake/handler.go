@@ -19,7 +19,7 @@ func NewHandler(k keeper.Keeper, govKeeper gov.Keeper) sdk.Handler {
 		// NOTE msg already has validate basic run
 		switch msg := msg.(type) {
 		case types.MsgCreateValidatorProposal:
-			if sdk.IsUpgrade(sdk.BEP159) {
+			if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 				return sdk.ErrMsgNotSupported("MsgCreateValidatorProposal disabled in BEP-159").Result()
 			}
 			return handleMsgCreateValidatorAfterProposal(ctx, msg, k, govKeeper)
@@ -27,7 +27,7 @@ func NewHandler(k keeper.Keeper, govKeeper gov.Keeper) sdk.Handler {
 			return handleMsgRemoveValidatorAfterProposal(ctx, msg, k, govKeeper)
 		// Beacon Chain New Staking in BEP-159
 		case types.MsgCreateValidatorOpen:
-			if !sdk.IsUpgrade(sdk.BEP159Phase2) {
+			if !ctx.UpgradeMgr().IsUpgrade(sdk.BEP159Phase2) {
 				return sdk.ErrMsgNotSupported("BEP-159 Phase 2 not activated yet").Result()
 			}
 			return handleMsgCreateValidatorOpen(ctx, msg, k)
@@ -37,6 +37,10 @@ func NewHandler(k keeper.Keeper, govKeeper gov.Keeper) sdk.Handler {
 			return handleMsgDelegateV1(ctx, msg, k)
 		case types.MsgUndelegate:
 			return handleMsgUndelegate(ctx, msg, k)
+		case types.MsgSetAttestation:
+			return handleMsgSetAttestation(ctx, msg, k)
+		case types.MsgRecheckAttestation:
+			return handleMsgRecheckAttestation(ctx, msg, k)
 		//case MsgSideChain
 		case types.MsgCreateSideChainValidator:
 			return handleMsgCreateSideChainValidator(ctx, msg, k)
@@ -48,6 +52,28 @@ func NewHandler(k keeper.Keeper, govKeeper gov.Keeper) sdk.Handler {
 			return handleMsgSideChainRedelegate(ctx, msg, k)
 		case types.MsgSideChainUndelegate:
 			return handleMsgSideChainUndelegate(ctx, msg, k)
+		case types.MsgSideChainStakeMigration:
+			if !ctx.UpgradeMgr().IsUpgrade(sdk.SideChainStakeMigration) {
+				return sdk.ErrMsgNotSupported("MsgSideChainStakeMigration not supported yet").Result()
+			}
+			return handleMsgSideChainStakeMigration(ctx, msg, k)
+		case types.MsgSideChainHeartbeat:
+			return handleMsgSideChainHeartbeat(ctx, msg, k)
+		case types.MsgCancelUnbondingDelegation:
+			if !ctx.UpgradeMgr().IsUpgrade(sdk.CancelUnbonding) {
+				return sdk.ErrMsgNotSupported("MsgCancelUnbondingDelegation not supported yet").Result()
+			}
+			return handleMsgCancelUnbondingDelegation(ctx, msg, k)
+		case types.MsgWithdrawDelegatorReward:
+			if !ctx.UpgradeMgr().IsUpgrade(sdk.LazyRewardDistribution) {
+				return sdk.ErrMsgNotSupported("MsgWithdrawDelegatorReward not supported yet").Result()
+			}
+			return handleMsgWithdrawDelegatorReward(ctx, msg, k)
+		case types.MsgSetRestake:
+			if !ctx.UpgradeMgr().IsUpgrade(sdk.RestakeRewards) {
+				return sdk.ErrMsgNotSupported("MsgSetRestake not supported yet").Result()
+			}
+			return handleMsgSetRestake(ctx, msg, k)
 		default:
 			return sdk.ErrTxDecode("invalid message parse in staking module").Result()
 		}
@@ -88,7 +114,11 @@ func handleMsgCreateValidatorAfterProposal(ctx sdk.Context, msg MsgCreateValidat
 		}
 	}
 
-	return handleMsgCreateValidator(ctx, msg.MsgCreateValidator, k)
+	result := handleMsgCreateValidator(ctx, msg.MsgCreateValidator, k)
+	if height != 0 && result.IsOK() {
+		govKeeper.AppendAuditRecord(ctx, "", govKeeper.GetProposal(ctx, msg.ProposalId), gov.AuditActionValidatorCreated)
+	}
+	return result
 }
 
 func handleMsgRemoveValidatorAfterProposal(ctx sdk.Context, msg MsgRemoveValidator, k keeper.Keeper, govKeeper gov.Keeper) sdk.Result {
@@ -118,6 +148,7 @@ func handleMsgRemoveValidatorAfterProposal(ctx sdk.Context, msg MsgRemoveValidat
 		return result
 	}
 
+	govKeeper.AppendAuditRecord(ctx, "", govKeeper.GetProposal(ctx, msg.ProposalId), gov.AuditActionValidatorRemoved)
 	return sdk.Result{Tags: tags}
 }
 
@@ -133,6 +164,8 @@ func handleMsgCreateValidatorOpen(ctx sdk.Context, msg MsgCreateValidatorOpen, k
 		ValidatorAddr: msg.ValidatorAddr,
 		PubKey:        pubkey,
 		Delegation:    msg.Delegation,
+
+		MinSelfDelegation: msg.MinSelfDelegation,
 	}
 	return handleMsgCreateValidator(ctx, msgCreateValidator, k)
 }
@@ -157,21 +190,29 @@ func handleMsgCreateValidator(ctx sdk.Context, msg MsgCreateValidator, k keeper.
 		return ErrBadDenom(k.Codespace()).Result()
 	}
 
-	if sdk.IsUpgrade(sdk.BEP159) {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 		minSelfDelegation := k.MinSelfDelegation(ctx)
 		if msg.Delegation.Amount < minSelfDelegation {
 			return ErrBadDelegationAmount(DefaultCodespace,
 				fmt.Sprintf("self delegation must not be less than %d", minSelfDelegation)).Result()
 		}
 	}
+	minSelfDelegation, err := newValidatorMinSelfDelegation(ctx, k, msg.MinSelfDelegation)
+	if err != nil {
+		return err.Result()
+	}
 	// self-delegate address will be used to collect fees.
 	feeAddr := msg.DelegatorAddr
 	validator := NewValidatorWithFeeAddr(feeAddr, msg.ValidatorAddr, msg.PubKey, msg.Description)
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
+		validator.DistributionAddr = types.GenerateDistributionAddr(msg.ValidatorAddr, types.ChainIDForBeaconChain)
+	}
+	validator.MinSelfDelegation = minSelfDelegation
 	commission := NewCommissionWithTime(
 		msg.Commission.Rate, msg.Commission.MaxRate,
 		msg.Commission.MaxChangeRate, ctx.BlockHeader().Time,
 	)
-	validator, err := validator.SetInitialCommission(commission)
+	validator, err = validator.SetInitialCommission(commission)
 	if err != nil {
 		return err.Result()
 	}
@@ -200,6 +241,43 @@ func handleMsgCreateValidator(ctx sdk.Context, msg MsgCreateValidator, k keeper.
 	}
 }
 
+// newValidatorMinSelfDelegation returns the minimum self delegation a validator is created with, the one of the msg
+// or the MinSelfDelegation param if it is lower
+func newValidatorMinSelfDelegation(ctx sdk.Context, k keeper.Keeper, msgMinSelfDelegation int64) (int64, sdk.Error) {
+	if !ctx.UpgradeMgr().IsUpgrade(sdk.ValidatorMinSelfDelegation) {
+		if msgMinSelfDelegation != 0 {
+			return 0, types.ErrMinSelfDelegationBeforeUpgrade(k.Codespace())
+		}
+		return 0, nil
+	}
+	if minSelfDelegation := k.MinSelfDelegation(ctx); msgMinSelfDelegation < minSelfDelegation {
+		return minSelfDelegation, nil
+	}
+	return msgMinSelfDelegation, nil
+}
+
+// editValidatorMinSelfDelegation raises the minimum self delegation of a validator, up to its self delegation
+func editValidatorMinSelfDelegation(ctx sdk.Context, k keeper.Keeper, validator Validator, msgMinSelfDelegation int64) (Validator, sdk.Error) {
+	if msgMinSelfDelegation == 0 {
+		return validator, nil
+	}
+	if !ctx.UpgradeMgr().IsUpgrade(sdk.ValidatorMinSelfDelegation) {
+		return validator, types.ErrMinSelfDelegationBeforeUpgrade(k.Codespace())
+	}
+	if msgMinSelfDelegation <= validator.MinSelfDelegation {
+		return validator, types.ErrMinSelfDelegationDecreased(k.Codespace(), validator.MinSelfDelegation)
+	}
+	var selfDelegation int64
+	if delegation, found := k.GetDelegation(ctx, validator.FeeAddr, validator.OperatorAddr); found {
+		selfDelegation = validator.TokensFromShares(delegation.Shares).RawInt()
+	}
+	if msgMinSelfDelegation > selfDelegation {
+		return validator, types.ErrSelfDelegationBelowMinSelfDelegation(k.Codespace(), selfDelegation)
+	}
+	validator.MinSelfDelegation = msgMinSelfDelegation
+	return validator, nil
+}
+
 func checkCreateProposal(ctx sdk.Context, keeper keeper.Keeper, govKeeper gov.Keeper, msg MsgCreateValidatorProposal) error {
 	proposal := govKeeper.GetProposal(ctx, msg.ProposalId)
 	if proposal == nil {
@@ -285,6 +363,9 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 	if !found {
 		return ErrNoValidatorFound(k.Codespace()).Result()
 	}
+	if err := k.RecordValidatorEdit(ctx, msg.ValidatorAddr); err != nil {
+		return err.Result()
+	}
 
 	onValidatorModified := false
 	if len(msg.PubKey) != 0 {
@@ -313,13 +394,21 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 
 	validator.Description = description
 
+	var events sdk.Events
 	if msg.CommissionRate != nil {
+		previousRate := validator.Commission.Rate
 		commission, err := k.UpdateValidatorCommission(ctx, validator, *msg.CommissionRate)
 		if err != nil {
 			return err.Result()
 		}
 		validator.Commission = commission
 		onValidatorModified = true
+		events = events.AppendEvent(commissionChangeEvent(validator, previousRate))
+	}
+
+	validator, err = editValidatorMinSelfDelegation(ctx, k, validator, msg.MinSelfDelegation)
+	if err != nil {
+		return err.Result()
 	}
 	if onValidatorModified {
 		k.OnValidatorModified(ctx, msg.ValidatorAddr)
@@ -334,8 +423,25 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 	)
 
 	return sdk.Result{
-		Tags: tags,
+		Tags:   tags,
+		Events: events,
+	}
+}
+
+// commissionChangeEvent is emitted on every change of the commission rate of a validator, so that its delegators can
+// follow it
+func commissionChangeEvent(validator Validator, previousRate sdk.Dec) sdk.Event {
+	event := sdk.NewEvent(types.EventTypeCommissionChange,
+		sdk.NewAttribute(types.AttributeKeyValidator, validator.OperatorAddr.String()),
+		sdk.NewAttribute(types.AttributeKeyPreviousRate, previousRate.String()),
+		sdk.NewAttribute(types.AttributeKeyCommissionRate, validator.Commission.Rate.String()),
+		sdk.NewAttribute(types.AttributeKeyMaxRate, validator.Commission.MaxRate.String()),
+		sdk.NewAttribute(types.AttributeKeyMaxChangeRate, validator.Commission.MaxChangeRate.String()),
+	)
+	if validator.IsSideChainValidator() {
+		event = event.AppendAttributes(sdk.NewAttribute(types.AttributeKeySideChainId, validator.SideChainId))
 	}
+	return event
 }
 
 // handleMsgDelegateV1 is used before we open staking to common users
@@ -440,6 +546,94 @@ func handleMsgUndelegate(ctx sdk.Context, msg types.MsgUndelegate, k keeper.Keep
 	return res
 }
 
+func handleMsgCancelUnbondingDelegation(ctx sdk.Context, msg types.MsgCancelUnbondingDelegation, k keeper.Keeper) sdk.Result {
+	if msg.SideChainId != "" {
+		if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+			return ErrInvalidSideChainId(k.Codespace()).Result()
+		} else {
+			ctx = scCtx
+		}
+	}
+
+	if msg.Amount.Denom != k.BondDenom(ctx) {
+		return ErrBadDenom(k.Codespace()).Result()
+	}
+
+	if validator, found := k.GetValidator(ctx, msg.ValidatorAddr); found {
+		if err := checkOperatorAsDelegator(k, msg.DelegatorAddr, validator); err != nil {
+			return err.Result()
+		}
+	}
+
+	ubd, err := k.CancelUnbonding(ctx, msg.DelegatorAddr, msg.ValidatorAddr, msg.CreationHeight, msg.Amount)
+	if err != nil {
+		return err.Result()
+	}
+	remaining := types.MsgCdc.MustMarshalBinaryLengthPrefixed(ubd.Balance)
+
+	tags := sdk.NewTags(
+		tags.Delegator, []byte(msg.DelegatorAddr.String()),
+		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+	)
+	return sdk.Result{Data: remaining, Tags: tags}
+}
+
+func handleMsgWithdrawDelegatorReward(ctx sdk.Context, msg types.MsgWithdrawDelegatorReward, k keeper.Keeper) sdk.Result {
+	if msg.SideChainId != "" {
+		if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+			return ErrInvalidSideChainId(k.Codespace()).Result()
+		} else {
+			ctx = scCtx
+		}
+	}
+
+	rewards, err := k.WithdrawDelegationRewards(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
+	if err != nil {
+		return err.Result()
+	}
+
+	tags := sdk.NewTags(
+		tags.Delegator, []byte(msg.DelegatorAddr.String()),
+		tags.SrcValidator, []byte(msg.ValidatorAddr.String()),
+	)
+	amount := types.MsgCdc.MustMarshalBinaryLengthPrefixed(sdk.NewCoin(k.BondDenom(ctx), rewards))
+	return sdk.Result{Data: amount, Tags: tags}
+}
+
+func handleMsgSetRestake(ctx sdk.Context, msg types.MsgSetRestake, k keeper.Keeper) sdk.Result {
+	if msg.SideChainId != "" {
+		if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+			return ErrInvalidSideChainId(k.Codespace()).Result()
+		} else {
+			ctx = scCtx
+		}
+	}
+
+	delegation, found := k.GetDelegation(ctx, msg.DelegatorAddr, msg.ValidatorAddr)
+	if !found {
+		return types.ErrNoDelegation(k.Codespace()).Result()
+	}
+	if delegation.CrossStake {
+		return ErrInvalidDelegator(k.Codespace()).Result()
+	}
+	// only the self-delegator delegates on the native chain
+	if msg.SideChainId == "" && ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
+		if selfDelegate, err := k.IsSelfDelegator(ctx, msg.DelegatorAddr, msg.ValidatorAddr); err != nil {
+			return err.Result()
+		} else if !selfDelegate {
+			return ErrNotSelfDelegate(k.Codespace()).Result()
+		}
+	}
+
+	k.SetRestaking(ctx, msg.DelegatorAddr, msg.ValidatorAddr, msg.Restake)
+
+	tags := sdk.NewTags(
+		tags.Delegator, []byte(msg.DelegatorAddr.String()),
+		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+	)
+	return sdk.Result{Tags: tags}
+}
+
 func handleMsgBeginUnbonding(ctx sdk.Context, msg types.MsgBeginUnbonding, k keeper.Keeper) sdk.Result {
 	ubd, err := k.BeginUnbonding(ctx, msg.DelegatorAddr, msg.ValidatorAddr, msg.SharesAmount)
 	if err != nil {
@@ -470,6 +664,10 @@ func handleMsgRedelegate(ctx sdk.Context, msg types.MsgRedelegate, k keeper.Keep
 		return err.Result()
 	}
 
+	if err := k.CheckRedelegationRestriction(ctx, msg.ValidatorSrcAddr); err != nil {
+		return err.Result()
+	}
+
 	shares, err := k.ValidateUnbondAmount(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr, msg.Amount.Amount)
 	if err != nil {
 		return err.Result()
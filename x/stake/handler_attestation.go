@@ -0,0 +1,48 @@
+package stake
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/tags"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func handleMsgSetAttestation(ctx sdk.Context, msg types.MsgSetAttestation, k keeper.Keeper) sdk.Result {
+	if _, found := k.GetValidator(ctx, msg.ValidatorAddr); !found {
+		return ErrNoValidatorFound(k.Codespace()).Result()
+	}
+
+	if len(msg.ProofHash) == 0 {
+		k.RemoveAttestation(ctx, msg.ValidatorAddr, msg.AttestationType)
+	} else {
+		// a new proof has to be checked again
+		k.SetAttestation(ctx, msg.ValidatorAddr, types.Attestation{
+			Type:      msg.AttestationType,
+			Target:    msg.Target,
+			ProofHash: msg.ProofHash,
+			Status:    types.AttestationUnverified,
+		})
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+			tags.AttestationType, []byte(msg.AttestationType),
+		),
+	}
+}
+
+func handleMsgRecheckAttestation(ctx sdk.Context, msg types.MsgRecheckAttestation, k keeper.Keeper) sdk.Result {
+	attestation, err := k.RecheckAttestation(ctx, msg.ValidatorAddr, msg.AttestationType, msg.Proof)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+			tags.AttestationType, []byte(msg.AttestationType),
+			tags.AttestationStatus, []byte(attestation.Status.String()),
+		),
+	}
+}
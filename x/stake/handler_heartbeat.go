@@ -0,0 +1,31 @@
+package stake
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/tags"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func handleMsgSideChainHeartbeat(ctx sdk.Context, msg types.MsgSideChainHeartbeat, k keeper.Keeper) sdk.Result {
+	if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+		return ErrInvalidSideChainId(k.Codespace()).Result()
+	} else {
+		ctx = scCtx
+	}
+
+	heartbeat, err := k.RecordHeartbeat(ctx, msg.SideChainId, msg.ValidatorAddr, msg.Height, msg.Signature)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+			tags.SideChainId, []byte(msg.SideChainId),
+			tags.HeartbeatHeight, []byte(strconv.FormatInt(heartbeat.Height, 10)),
+		),
+	}
+}
@@ -38,14 +38,19 @@ func handleMsgCreateSideChainValidator(ctx sdk.Context, msg MsgCreateSideChainVa
 		return ErrBadDenom(k.Codespace()).Result()
 	}
 
+	minSelfDelegation, err := newValidatorMinSelfDelegation(ctx, k, msg.MinSelfDelegation)
+	if err != nil {
+		return err.Result()
+	}
+
 	// self-delegate address will be used to collect fees.
 	feeAddr := msg.DelegatorAddr
 	validator := NewSideChainValidator(feeAddr, msg.ValidatorAddr, msg.Description, msg.SideChainId, msg.SideConsAddr, msg.SideFeeAddr)
+	validator.MinSelfDelegation = minSelfDelegation
 	commission := NewCommissionWithTime(
 		msg.Commission.Rate, msg.Commission.MaxRate,
 		msg.Commission.MaxChangeRate, ctx.BlockHeader().Time,
 	)
-	var err sdk.Error
 	validator, err = validator.SetInitialCommission(commission)
 	if err != nil {
 		return err.Result()
@@ -74,7 +79,7 @@ func handleMsgCreateSideChainValidator(ctx sdk.Context, msg MsgCreateSideChainVa
 
 func handleMsgEditSideChainValidator(ctx sdk.Context, msg MsgEditSideChainValidator, k keeper.Keeper) sdk.Result {
 	if len(msg.SideConsAddr) != 0 {
-		if !sdk.IsUpgrade(sdk.BEP159) {
+		if !ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 			return ErrEditConsensusKeyBeforeBEP159(k.Codespace()).Result()
 		}
 	}
@@ -89,6 +94,9 @@ func handleMsgEditSideChainValidator(ctx sdk.Context, msg MsgEditSideChainValida
 	if !found {
 		return ErrNoValidatorFound(k.Codespace()).Result()
 	}
+	if err := k.RecordValidatorEdit(ctx, msg.ValidatorAddr); err != nil {
+		return err.Result()
+	}
 
 	// replace all editable fields (clients should autofill existing values)
 	if description, err := validator.Description.UpdateDescription(msg.Description); err != nil {
@@ -97,25 +105,35 @@ func handleMsgEditSideChainValidator(ctx sdk.Context, msg MsgEditSideChainValida
 		validator.Description = description
 	}
 
+	// the new rate is bound by the max rate and the max change rate the validator was created with
+	var events sdk.Events
 	if msg.CommissionRate != nil {
+		previousRate := validator.Commission.Rate
 		commission, err := k.UpdateValidatorCommission(ctx, validator, *msg.CommissionRate)
 		if err != nil {
 			return err.Result()
 		}
 		validator.Commission = commission
 		k.OnValidatorModified(ctx, msg.ValidatorAddr)
+		events = events.AppendEvent(commissionChangeEvent(validator, previousRate))
 	}
 
 	if len(msg.SideFeeAddr) != 0 {
 		validator.SideFeeAddr = msg.SideFeeAddr
 	}
 
-	if len(msg.SideConsAddr) != 0 && sdk.IsUpgrade(sdk.BEP159) {
+	if edited, err := editValidatorMinSelfDelegation(ctx, k, validator, msg.MinSelfDelegation); err != nil {
+		return err.Result()
+	} else {
+		validator = edited
+	}
+
+	if len(msg.SideConsAddr) != 0 && ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 		_, found = k.GetValidatorBySideConsAddr(ctx, msg.SideConsAddr)
 		if found {
 			return ErrValidatorSideConsAddrExist(k.Codespace()).Result()
 		}
-		if sdk.IsUpgrade(sdk.LimitConsAddrUpdateInterval) {
+		if ctx.UpgradeMgr().IsUpgrade(sdk.LimitConsAddrUpdateInterval) {
 			// check update sideConsAddr interval
 			latestUpdateConsAddrTime, err := k.GetValLatestUpdateConsAddrTime(ctx, validator.OperatorAddr)
 			if err != nil {
@@ -138,6 +156,7 @@ func handleMsgEditSideChainValidator(ctx sdk.Context, msg MsgEditSideChainValida
 			tags.Moniker, []byte(validator.Description.Moniker),
 			tags.Identity, []byte(validator.Description.Identity),
 		),
+		Events: events,
 	}
 }
 
@@ -224,6 +243,10 @@ func handleMsgSideChainRedelegate(ctx sdk.Context, msg MsgSideChainRedelegate, k
 		return err.Result()
 	}
 
+	if err := k.CheckRedelegationRestriction(ctx, msg.ValidatorSrcAddr); err != nil {
+		return err.Result()
+	}
+
 	shares, err := k.ValidateUnbondAmount(ctx, msg.DelegatorAddr, msg.ValidatorSrcAddr, msg.Amount.Amount)
 	if err != nil {
 		return err.Result()
@@ -316,6 +339,23 @@ func handleMsgSideChainUndelegate(ctx sdk.Context, msg MsgSideChainUndelegate, k
 	return sdk.Result{Data: finishTime, Tags: tags}
 }
 
+// the undelegation is handled as MsgSideChainUndelegate, the recipient is recorded for the completion of the unbonding
+func handleMsgSideChainStakeMigration(ctx sdk.Context, msg MsgSideChainStakeMigration, k keeper.Keeper) sdk.Result {
+	result := handleMsgSideChainUndelegate(ctx, msg.UndelegateMsg(), k)
+	if !result.IsOK() {
+		return result
+	}
+
+	scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId)
+	if err != nil {
+		return ErrInvalidSideChainId(k.Codespace()).Result()
+	}
+	k.SetUnbondingTransfer(scCtx, msg.DelegatorAddr, msg.ValidatorAddr, msg.Recipient)
+
+	result.Tags = result.Tags.AppendTag(tags.Recipient, []byte(msg.Recipient.String()))
+	return result
+}
+
 // we allow the self-delegator delegating/redelegating to its validator.
 // but the operator is not allowed if it is not a self-delegator
 func checkOperatorAsDelegator(k Keeper, delegator sdk.AccAddress, validator Validator) sdk.Error {
@@ -1,6 +1,7 @@
 package stake
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"testing"
 	"time"
@@ -52,7 +53,7 @@ func TestValidatorByPowerIndex(t *testing.T) {
 	// verify that the by power index exists
 	validator, found := keeper.GetValidator(ctx, validatorAddr)
 	require.True(t, found)
-	power := keep.GetValidatorsByPowerIndexKey(validator)
+	power := keep.GetValidatorsByPowerIndexKey(ctx, validator)
 	require.True(t, keep.ValidatorByPowerIndexExists(ctx, keeper, power))
 
 	// create a second validator keep it bonded
@@ -81,11 +82,11 @@ func TestValidatorByPowerIndex(t *testing.T) {
 	// but the new power record should have been created
 	validator, found = keeper.GetValidator(ctx, validatorAddr)
 	require.True(t, found)
-	power2 := GetValidatorsByPowerIndexKey(validator)
+	power2 := GetValidatorsByPowerIndexKey(ctx, validator)
 	require.True(t, keep.ValidatorByPowerIndexExists(ctx, keeper, power2))
 
 	// now the new record power index should be the same as the original record
-	power3 := GetValidatorsByPowerIndexKey(validator)
+	power3 := GetValidatorsByPowerIndexKey(ctx, validator)
 	require.Equal(t, power2, power3)
 
 	// unbond self-delegation
@@ -594,6 +595,57 @@ func TestJailValidator(t *testing.T) {
 	require.True(t, got.IsOK(), "expected ok, got %v", got)
 }
 
+func TestValidatorAttestation(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr, checkerAddr := sdk.ValAddress(keep.Addrs[0]), keep.Addrs[1]
+
+	// an attestation can only be attached to an existing validator
+	proof := "I am validator " + validatorAddr.String() + " on Binance Chain"
+	proofHash := sha256.Sum256([]byte(proof))
+	msgSetAttestation := NewMsgSetAttestation(validatorAddr, types.AttestationTypeDNS, "example.com", proofHash[:])
+	require.Nil(t, msgSetAttestation.ValidateBasic())
+	got := handleMsgSetAttestation(ctx, msgSetAttestation, keeper)
+	require.False(t, got.IsOK(), "expected error, got %v", got)
+
+	msgCreateValidator := NewTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	got = handleMsgSetAttestation(ctx, msgSetAttestation, keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	attestation, found := keeper.GetAttestation(ctx, validatorAddr, types.AttestationTypeDNS)
+	require.True(t, found)
+	require.Equal(t, types.AttestationUnverified, attestation.Status)
+
+	// a proof of another validator fails
+	ctx = ctx.WithBlockHeight(10)
+	otherProof := "I am validator " + sdk.ValAddress(keep.Addrs[2]).String()
+	got = handleMsgRecheckAttestation(ctx, NewMsgRecheckAttestation(checkerAddr, validatorAddr, types.AttestationTypeDNS, otherProof), keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	attestation, _ = keeper.GetAttestation(ctx, validatorAddr, types.AttestationTypeDNS)
+	require.Equal(t, types.AttestationFailed, attestation.Status)
+	require.Equal(t, int64(10), attestation.CheckedHeight)
+
+	// the committed proof is verified
+	got = handleMsgRecheckAttestation(ctx, NewMsgRecheckAttestation(checkerAddr, validatorAddr, types.AttestationTypeDNS, proof), keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	require.Equal(t, []types.Attestation{{
+		Type:          types.AttestationTypeDNS,
+		Target:        "example.com",
+		ProofHash:     proofHash[:],
+		Status:        types.AttestationVerified,
+		CheckedHeight: 10,
+	}}, keeper.GetAttestations(ctx, validatorAddr))
+
+	// there is nothing to re-check without attestation
+	got = handleMsgRecheckAttestation(ctx, NewMsgRecheckAttestation(checkerAddr, validatorAddr, types.AttestationTypeKeybase, proof), keeper)
+	require.False(t, got.IsOK(), "expected error, got %v", got)
+
+	// an empty proof hash removes the attestation
+	got = handleMsgSetAttestation(ctx, NewMsgSetAttestation(validatorAddr, types.AttestationTypeDNS, "", nil), keeper)
+	require.True(t, got.IsOK(), "expected ok, got %v", got)
+	require.Empty(t, keeper.GetAttestations(ctx, validatorAddr))
+}
+
 func TestValidatorQueue(t *testing.T) {
 	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
 	validatorAddr, delegatorAddr := sdk.ValAddress(keep.Addrs[0]), keep.Addrs[1]
@@ -910,6 +962,148 @@ func TestConflictingRedelegation(t *testing.T) {
 	require.True(t, got.IsOK(), "expected no error")
 }
 
+func TestConcurrentRedelegations(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr := sdk.ValAddress(keep.Addrs[0])
+	validatorAddr2 := sdk.ValAddress(keep.Addrs[1])
+	delegatorAddr := keep.Addrs[2]
+
+	// allow two redelegations in progress at once
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 10 * time.Second
+	params.MaxRedelegationEntries = 2
+	keeper.SetParams(ctx, params)
+
+	// create the validators
+	msgCreateValidator := NewTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	msgCreateValidator = NewTestMsgCreateValidator(validatorAddr2, keep.PKs[1], 10)
+	got = handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+
+	// end block to bond them
+	EndBlocker(ctx, keeper)
+
+	msgDelegate := NewTestMsgDelegate(delegatorAddr, validatorAddr, 30)
+	got = handleMsgDelegate(ctx, msgDelegate, keeper)
+	require.True(t, got.IsOK())
+
+	startTime := ctx.BlockHeader().Time
+	msgBeginRedelegate := NewMsgRedelegate(delegatorAddr, validatorAddr, validatorAddr2, sdk.NewCoin("steak", 5))
+	got = handleMsgRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+
+	// a second redelegation runs beside the first one
+	ctx = ctx.WithBlockTime(startTime.Add(3 * time.Second))
+	got = handleMsgRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+	red, found := keeper.GetRedelegation(ctx, delegatorAddr, validatorAddr, validatorAddr2)
+	require.True(t, found)
+	require.Len(t, red.Entries, 1)
+	require.Len(t, red.AllEntries(), 2)
+
+	// a third one is over the limit
+	got = handleMsgRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.False(t, got.IsOK(), "expected an error, msg: %v", msgBeginRedelegate)
+	require.Equal(t, types.ErrMaxRedelegationEntries(DefaultCodespace, 2).ABCILog(), got.Log)
+
+	// the first redelegation completes, the second one stays in progress
+	ctx = ctx.WithBlockTime(startTime.Add(10 * time.Second))
+	EndBlocker(ctx, keeper)
+	red, found = keeper.GetRedelegation(ctx, delegatorAddr, validatorAddr, validatorAddr2)
+	require.True(t, found)
+	require.Len(t, red.Entries, 0)
+	require.True(t, startTime.Add(13*time.Second).Equal(red.MinTime))
+
+	// which leaves room for another one
+	got = handleMsgRedelegate(ctx, msgBeginRedelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+
+	// all of them complete eventually
+	ctx = ctx.WithBlockTime(startTime.Add(13 * time.Second))
+	EndBlocker(ctx, keeper)
+	ctx = ctx.WithBlockTime(startTime.Add(20 * time.Second))
+	EndBlocker(ctx, keeper)
+	_, found = keeper.GetRedelegation(ctx, delegatorAddr, validatorAddr, validatorAddr2)
+	require.False(t, found)
+}
+
+func TestCancelUnbondingDelegation(t *testing.T) {
+	ctx, accMapper, keeper := keep.CreateTestInput(t, false, 1000)
+	validatorAddr := sdk.ValAddress(keep.Addrs[0])
+	delegatorAddr := keep.Addrs[1]
+	unit := sdk.NewDecWithoutFra(1).RawInt()
+
+	params := keeper.GetParams(ctx)
+	params.UnbondingTime = 10 * time.Second
+	keeper.SetParams(ctx, params)
+
+	msgCreateValidator := NewTestMsgCreateValidator(validatorAddr, keep.PKs[0], 10)
+	got := handleMsgCreateValidator(ctx, msgCreateValidator, keeper)
+	require.True(t, got.IsOK(), "expected no error on runMsgCreateValidator")
+	EndBlocker(ctx, keeper)
+
+	msgDelegate := NewTestMsgDelegate(delegatorAddr, validatorAddr, 30)
+	got = handleMsgDelegate(ctx, msgDelegate, keeper)
+	require.True(t, got.IsOK())
+
+	msgUndelegate := NewMsgUndelegate(delegatorAddr, validatorAddr, sdk.NewCoin("steak", 10*unit))
+	got = handleMsgUndelegate(ctx, msgUndelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+	var finishTime time.Time
+	types.MsgCdc.MustUnmarshalBinaryLengthPrefixed(got.Data, &finishTime)
+	coins := accMapper.GetAccount(ctx, delegatorAddr).GetCoins()
+
+	// the creation height must match the pending unbonding
+	msgCancel := NewMsgCancelUnbondingDelegation(delegatorAddr, validatorAddr, sdk.NewCoin("steak", 4*unit), ctx.BlockHeight()+1, "")
+	got = handleMsgCancelUnbondingDelegation(ctx, msgCancel, keeper)
+	require.False(t, got.IsOK())
+	require.Equal(t, types.ErrNoUnbondingDelegation(DefaultCodespace).ABCILog(), got.Log)
+
+	// a part of the unbonding is delegated back
+	msgCancel.CreationHeight = ctx.BlockHeight()
+	got = handleMsgCancelUnbondingDelegation(ctx, msgCancel, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+	ubd, found := keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, 6*unit, ubd.Balance.Amount)
+	delegation, found := keeper.GetDelegation(ctx, delegatorAddr, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, 24*unit, delegation.Shares.RawInt())
+
+	// no more than the unbonding balance can be canceled
+	msgCancel.Amount = sdk.NewCoin("steak", 7*unit)
+	got = handleMsgCancelUnbondingDelegation(ctx, msgCancel, keeper)
+	require.False(t, got.IsOK())
+
+	// canceling the rest removes the unbonding from the queue
+	msgCancel.Amount = sdk.NewCoin("steak", 6*unit)
+	got = handleMsgCancelUnbondingDelegation(ctx, msgCancel, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+	_, found = keeper.GetUnbondingDelegation(ctx, delegatorAddr, validatorAddr)
+	require.False(t, found)
+	require.Empty(t, keeper.GetUnbondingQueueTimeSlice(ctx, finishTime))
+	delegation, found = keeper.GetDelegation(ctx, delegatorAddr, validatorAddr)
+	require.True(t, found)
+	require.Equal(t, 30*unit, delegation.Shares.RawInt())
+
+	// nothing is paid out at the finish time
+	ctx = ctx.WithBlockTime(finishTime)
+	EndBlocker(ctx, keeper)
+	require.True(t, coins.IsEqual(accMapper.GetAccount(ctx, delegatorAddr).GetCoins()))
+
+	// a mature unbonding cannot be canceled
+	got = handleMsgUndelegate(ctx, msgUndelegate, keeper)
+	require.True(t, got.IsOK(), "expected no error, %v", got)
+	types.MsgCdc.MustUnmarshalBinaryLengthPrefixed(got.Data, &finishTime)
+	ctx = ctx.WithBlockTime(finishTime)
+	msgCancel.Amount = sdk.NewCoin("steak", 10*unit)
+	got = handleMsgCancelUnbondingDelegation(ctx, msgCancel, keeper)
+	require.False(t, got.IsOK())
+}
+
 func TestUnbondingWhenExcessValidators(t *testing.T) {
 	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
 	validatorAddr1 := sdk.ValAddress(keep.Addrs[0])
@@ -1277,3 +1471,117 @@ func TestNewStakingProcess(t *testing.T) {
 	require.Equal(t, sdk.NewDecWithoutFra(bondAmount*2), bond.Shares)
 	require.Equal(t, sdk.NewDecWithoutFra(bondAmount*3), validator.DelegatorShares)
 }
+
+func TestValidatorMinSelfDelegation(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 100000)
+	defer sdk.UpgradeMgr.Reset()
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ValidatorMinSelfDelegation, 10)
+	sdk.UpgradeMgr.SetHeight(9)
+
+	valAddr, valAddr2 := sdk.ValAddress(keep.Addrs[0]), sdk.ValAddress(keep.Addrs[1])
+	msgCreateVal := NewTestMsgCreateValidator(valAddr, keep.PKs[0], 1000)
+	msgCreateVal.MinSelfDelegation = sdk.NewDecWithoutFra(600).RawInt()
+	require.Nil(t, msgCreateVal.ValidateBasic())
+
+	// the validators cannot commit to a minimum self delegation before the upgrade
+	got := handleMsgCreateValidator(ctx, msgCreateVal, keeper)
+	require.Equal(t, types.ErrMinSelfDelegationBeforeUpgrade(keeper.Codespace()).ABCILog(), got.Log)
+
+	sdk.UpgradeMgr.SetHeight(10)
+	got = handleMsgCreateValidator(ctx, msgCreateVal, keeper)
+	require.True(t, got.IsOK(), "expected create validator msg to be ok, got %v", got)
+	validator, found := keeper.GetValidator(ctx, valAddr)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithoutFra(600).RawInt(), validator.MinSelfDelegation)
+
+	// without a minimum self delegation, the validator is committed to the params one
+	got = handleMsgCreateValidator(ctx, NewTestMsgCreateValidator(valAddr2, keep.PKs[1], 1000), keeper)
+	require.True(t, got.IsOK(), "expected create validator msg to be ok, got %v", got)
+	validator2, found := keeper.GetValidator(ctx, valAddr2)
+	require.True(t, found)
+	require.Equal(t, keeper.MinSelfDelegation(ctx), validator2.MinSelfDelegation)
+
+	// the minimum self delegation can only be increased, up to the self delegation
+	msgEditVal := NewMsgEditValidator(valAddr, Description{Moniker: "moniker"}, nil, "")
+	msgEditVal.MinSelfDelegation = sdk.NewDecWithoutFra(500).RawInt()
+	got = handleMsgEditValidator(ctx, msgEditVal, keeper)
+	require.Equal(t, types.ErrMinSelfDelegationDecreased(keeper.Codespace(), sdk.NewDecWithoutFra(600).RawInt()).ABCILog(), got.Log)
+	msgEditVal.MinSelfDelegation = sdk.NewDecWithoutFra(2000).RawInt()
+	got = handleMsgEditValidator(ctx, msgEditVal, keeper)
+	require.Equal(t, types.ErrSelfDelegationBelowMinSelfDelegation(keeper.Codespace(), sdk.NewDecWithoutFra(1000).RawInt()).ABCILog(), got.Log)
+	msgEditVal.MinSelfDelegation = sdk.NewDecWithoutFra(700).RawInt()
+	got = handleMsgEditValidator(ctx, msgEditVal, keeper)
+	require.True(t, got.IsOK(), "expected edit validator msg to be ok, got %v", got)
+
+	// the validator is jailed once its self delegation drops below its minimum
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(sdk.AccAddress(valAddr), valAddr, sdk.NewDecWithoutFra(200)), keeper)
+	require.True(t, got.IsOK(), "expected begin unbonding msg to be ok, got %v", got)
+	validator, _ = keeper.GetValidator(ctx, valAddr)
+	require.False(t, validator.Jailed)
+	got = handleMsgBeginUnbonding(ctx, NewMsgBeginUnbonding(sdk.AccAddress(valAddr), valAddr, sdk.NewDecWithoutFra(200)), keeper)
+	require.True(t, got.IsOK(), "expected begin unbonding msg to be ok, got %v", got)
+	validator, _ = keeper.GetValidator(ctx, valAddr)
+	require.True(t, validator.Jailed)
+
+	// the validators created before the upgrade are committed to the params one by the migration
+	validator2.MinSelfDelegation = 0
+	keeper.SetValidator(ctx, validator2)
+	keep.MigrateValidatorMinSelfDelegation(ctx, keeper)
+	validator2, _ = keeper.GetValidator(ctx, valAddr2)
+	require.Equal(t, keeper.MinSelfDelegation(ctx), validator2.MinSelfDelegation)
+	validator, _ = keeper.GetValidator(ctx, valAddr)
+	require.Equal(t, sdk.NewDecWithoutFra(700).RawInt(), validator.MinSelfDelegation)
+}
+
+func TestEditSideChainValidatorCommission(t *testing.T) {
+	ctx, _, keeper := keep.CreateTestInput(t, false, 1000)
+	sideChainId := "bsc"
+	keeper.ScKeeper.SetSideChainIdAndStorePrefix(ctx, sideChainId, []byte{0x99})
+	scCtx, err := keeper.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
+	require.Nil(t, err)
+
+	valAddr := sdk.ValAddress(keep.Addrs[0])
+	validator := types.NewSideChainValidator(keep.Addrs[0], valAddr, Description{Moniker: "moniker"}, sideChainId, keep.Addrs[1], keep.Addrs[2])
+	validator, sdkErr := validator.SetInitialCommission(NewCommissionWithTime(
+		sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(2, 1), sdk.NewDecWithPrec(5, 2), time.Unix(0, 0)))
+	require.Nil(t, sdkErr)
+	keeper.SetValidator(scCtx, validator)
+
+	ctx = ctx.WithBlockTime(time.Unix(0, 0).Add(48 * time.Hour))
+	edit := func(rate sdk.Dec) sdk.Result {
+		msg := NewMsgEditSideChainValidator(sideChainId, valAddr, Description{Moniker: "moniker"}, &rate, nil, nil)
+		return handleMsgEditSideChainValidator(ctx, msg, keeper)
+	}
+
+	// the new rate is bound by the max rate and the max change rate
+	got := edit(sdk.NewDecWithPrec(3, 1))
+	require.Equal(t, types.ErrCommissionGTMaxRate(DefaultCodespace).ABCILog(), got.Log)
+	got = edit(sdk.NewDecWithPrec(18, 2))
+	require.Equal(t, types.ErrCommissionGTMaxChangeRate(DefaultCodespace).ABCILog(), got.Log)
+
+	got = edit(sdk.NewDecWithPrec(14, 2))
+	require.True(t, got.IsOK(), "expected edit validator msg to be ok, got %v", got)
+	require.Len(t, got.Events, 1)
+	event := got.Events[0]
+	require.Equal(t, types.EventTypeCommissionChange, event.Type)
+	attributes := make(map[string]string)
+	for _, attr := range event.Attributes {
+		attributes[string(attr.Key)] = string(attr.Value)
+	}
+	require.Equal(t, valAddr.String(), attributes[types.AttributeKeyValidator])
+	require.Equal(t, sideChainId, attributes[types.AttributeKeySideChainId])
+	require.Equal(t, sdk.NewDecWithPrec(1, 1).String(), attributes[types.AttributeKeyPreviousRate])
+	require.Equal(t, sdk.NewDecWithPrec(14, 2).String(), attributes[types.AttributeKeyCommissionRate])
+	require.Equal(t, sdk.NewDecWithPrec(2, 1).String(), attributes[types.AttributeKeyMaxRate])
+	require.Equal(t, sdk.NewDecWithPrec(5, 2).String(), attributes[types.AttributeKeyMaxChangeRate])
+
+	// at most once a day
+	got = edit(sdk.NewDecWithPrec(15, 2))
+	require.Equal(t, types.ErrCommissionUpdateTime(DefaultCodespace).ABCILog(), got.Log)
+
+	// no event without a commission change
+	msg := NewMsgEditSideChainValidator(sideChainId, valAddr, Description{Moniker: "moniker"}, nil, nil, nil)
+	got = handleMsgEditSideChainValidator(ctx, msg, keeper)
+	require.True(t, got.IsOK(), "expected edit validator msg to be ok, got %v", got)
+	require.Empty(t, got.Events)
+}
@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// get the attestation of a validator for a type
+func (k Keeper) GetAttestation(ctx sdk.Context, valAddr sdk.ValAddress, attestationType types.AttestationType) (attestation types.Attestation, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetValidatorAttestationKey(valAddr, attestationType))
+	if bz == nil {
+		return attestation, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &attestation)
+	return attestation, true
+}
+
+// get all the attestations of a validator
+func (k Keeper) GetAttestations(ctx sdk.Context, valAddr sdk.ValAddress) (attestations []types.Attestation) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetValidatorAttestationsKey(valAddr))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var attestation types.Attestation
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &attestation)
+		attestations = append(attestations, attestation)
+	}
+	return attestations
+}
+
+// set the attestation of a validator
+func (k Keeper) SetAttestation(ctx sdk.Context, valAddr sdk.ValAddress, attestation types.Attestation) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(attestation)
+	store.Set(GetValidatorAttestationKey(valAddr, attestation.Type), bz)
+}
+
+// remove the attestation of a validator for a type
+func (k Keeper) RemoveAttestation(ctx sdk.Context, valAddr sdk.ValAddress, attestationType types.AttestationType) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorAttestationKey(valAddr, attestationType))
+}
+
+// remove all the attestations of a removed validator
+func (k Keeper) removeAttestations(ctx sdk.Context, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetValidatorAttestationsKey(valAddr))
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+// RecheckAttestation verifies the attestation of a validator against the proof fetched off-chain,
+// and records the result at the current height
+func (k Keeper) RecheckAttestation(ctx sdk.Context, valAddr sdk.ValAddress, attestationType types.AttestationType, proof string) (types.Attestation, sdk.Error) {
+	attestation, found := k.GetAttestation(ctx, valAddr, attestationType)
+	if !found {
+		return attestation, types.ErrNoAttestationFound(k.Codespace())
+	}
+	if types.VerifyAttestationProof(attestation, valAddr, proof) {
+		attestation.Status = types.AttestationVerified
+	} else {
+		attestation.Status = types.AttestationFailed
+	}
+	attestation.CheckedHeight = ctx.BlockHeight()
+	k.SetAttestation(ctx, valAddr, attestation)
+	return attestation, nil
+}
@@ -45,6 +45,20 @@ func (k Keeper) GetAllDelegations(ctx sdk.Context) (delegations []types.Delegati
 	return delegations
 }
 
+// iterate through all of the delegations
+func (k Keeper) IterateAllDelegations(ctx sdk.Context, fn func(delegation types.Delegation) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, DelegationKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Key(), iterator.Value())
+		if fn(delegation) {
+			break
+		}
+	}
+}
+
 // return a given amount of all the delegations from a delegator
 func (k Keeper) GetDelegatorDelegations(ctx sdk.Context, delegator sdk.AccAddress,
 	maxRetrieve uint16) (delegations []types.Delegation) {
@@ -86,7 +100,7 @@ func (k Keeper) SetDelegation(ctx sdk.Context, delegation types.Delegation) {
 	store.Set(GetDelegationKey(delegation.DelegatorAddr, delegation.ValidatorAddr), b)
 
 	// sync delegation to the store with DelegationKeyByVal based
-	if len(ctx.SideChainId()) > 0 || sdk.IsUpgrade(sdk.BEP159) {
+	if len(ctx.SideChainId()) > 0 || ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 		k.SetDelegationByVal(ctx, delegation)
 	}
 
@@ -119,6 +133,7 @@ func (k Keeper) RemoveDelegation(ctx sdk.Context, delegation types.Delegation) {
 	k.OnDelegationRemoved(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
 	store := ctx.KVStore(k.storeKey)
 	store.Delete(GetDelegationKey(delegation.DelegatorAddr, delegation.ValidatorAddr))
+	store.Delete(GetDelegationRestakeKey(delegation.DelegatorAddr, delegation.ValidatorAddr))
 
 	// sync delegation to the store with DelegationKeyByVal based
 	if len(ctx.SideChainId()) > 0 {
@@ -272,6 +287,7 @@ func (k Keeper) RemoveUnbondingDelegation(ctx sdk.Context, ubd types.UnbondingDe
 	key := GetUBDKey(ubd.DelegatorAddr, ubd.ValidatorAddr)
 	store.Delete(key)
 	store.Delete(GetUBDByValIndexKey(ubd.DelegatorAddr, ubd.ValidatorAddr))
+	store.Delete(GetUnbondingTransferKey(ubd.DelegatorAddr, ubd.ValidatorAddr))
 }
 
 // gets a specific unbonding queue timeslice. A timeslice is a slice of DVPairs corresponding to unbonding delegations
@@ -305,6 +321,22 @@ func (k Keeper) InsertUnbondingQueue(ctx sdk.Context, ubd types.UnbondingDelegat
 	}
 }
 
+// Remove an unbonding delegation from its timeslice in the unbonding queue
+func (k Keeper) RemoveFromUnbondingQueue(ctx sdk.Context, ubd types.UnbondingDelegation) {
+	timeSlice := k.GetUnbondingQueueTimeSlice(ctx, ubd.MinTime)
+	remaining := make([]types.DVPair, 0, len(timeSlice))
+	for _, dvPair := range timeSlice {
+		if !dvPair.DelegatorAddr.Equals(ubd.DelegatorAddr) || !dvPair.ValidatorAddr.Equals(ubd.ValidatorAddr) {
+			remaining = append(remaining, dvPair)
+		}
+	}
+	if len(remaining) == 0 {
+		ctx.KVStore(k.storeKey).Delete(GetUnbondingDelegationTimeKey(ubd.MinTime))
+	} else {
+		k.SetUnbondingQueueTimeSlice(ctx, ubd.MinTime, remaining)
+	}
+}
+
 // Returns all the unbonding queue timeslices from time 0 until endTime
 func (k Keeper) UnbondingQueueIterator(ctx sdk.Context, endTime time.Time) sdk.Iterator {
 	store := ctx.KVStore(k.storeKey)
@@ -448,13 +480,25 @@ func (k Keeper) SetRedelegationQueueTimeSlice(ctx sdk.Context, timestamp time.Ti
 
 // Insert an redelegation delegation to the appropriate timeslice in the redelegation queue
 func (k Keeper) InsertRedelegationQueue(ctx sdk.Context, red types.Redelegation) {
-	timeSlice := k.GetRedelegationQueueTimeSlice(ctx, red.MinTime)
+	k.InsertRedelegationEntryQueue(ctx, red, red.MinTime)
+}
+
+// Insert an entry of a redelegation to the timeslice of its completion time in the redelegation queue, the
+// redelegation is queued once per timeslice whatever the number of its entries completing at that time
+func (k Keeper) InsertRedelegationEntryQueue(ctx sdk.Context, red types.Redelegation, minTime time.Time) {
+	timeSlice := k.GetRedelegationQueueTimeSlice(ctx, minTime)
 	dvvTriplet := types.DVVTriplet{red.DelegatorAddr, red.ValidatorSrcAddr, red.ValidatorDstAddr}
 	if len(timeSlice) == 0 {
-		k.SetRedelegationQueueTimeSlice(ctx, red.MinTime, []types.DVVTriplet{dvvTriplet})
+		k.SetRedelegationQueueTimeSlice(ctx, minTime, []types.DVVTriplet{dvvTriplet})
 	} else {
+		for _, queued := range timeSlice {
+			if queued.DelegatorAddr.Equals(red.DelegatorAddr) && queued.ValidatorSrcAddr.Equals(red.ValidatorSrcAddr) &&
+				queued.ValidatorDstAddr.Equals(red.ValidatorDstAddr) {
+				return
+			}
+		}
 		timeSlice = append(timeSlice, dvvTriplet)
-		k.SetRedelegationQueueTimeSlice(ctx, red.MinTime, timeSlice)
+		k.SetRedelegationQueueTimeSlice(ctx, minTime, timeSlice)
 	}
 }
 
@@ -510,6 +554,9 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Co
 	} else {
 		k.OnDelegationCreated(ctx, delAddr, validator.OperatorAddr)
 	}
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LazyRewardDistribution) {
+		k.withdrawDelegationRewards(ctx, delAddr, validator.OperatorAddr)
+	}
 
 	if subtractAccount {
 		err = k.transferBondTokens(ctx, delegation.DelegatorAddr, DelegationAccAddr, bondAmt)
@@ -527,6 +574,9 @@ func (k Keeper) Delegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Co
 	delegation.Shares = delegation.Shares.Add(newShares)
 	delegation.Height = ctx.BlockHeight()
 	k.SetDelegation(ctx, delegation)
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LazyRewardDistribution) {
+		k.initializeDelegationRewards(ctx, delegation)
+	}
 	return newShares, nil
 }
 
@@ -573,16 +623,20 @@ func (k Keeper) unbond(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValA
 		return
 	}
 
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LazyRewardDistribution) {
+		k.withdrawDelegationRewards(ctx, delAddr, valAddr)
+	}
+
 	// subtract shares from delegator
 	delegation.Shares = delegation.Shares.Sub(shares)
 
 	// if the delegation is the operator of the validator and undelegating will decrease the validator's self delegation below their minimum
 	// trigger a jail validator
 	if validator.IsSelfDelegator(delegation.DelegatorAddr) && !validator.Jailed &&
-		validator.TokensFromShares(delegation.Shares).RawInt() < k.MinSelfDelegation(ctx) {
+		validator.TokensFromShares(delegation.Shares).RawInt() < k.ValidatorMinSelfDelegation(ctx, validator) {
 		k.jailValidator(ctx, validator)
 		k.OnSelfDelDropBelowMin(ctx, valAddr)
-		if sdk.IsUpgrade(sdk.BEP159) && ctx.SideChainId() == "" && validator.IsBonded() {
+		if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) && ctx.SideChainId() == "" && validator.IsBonded() {
 			k.AddPendingABCIValidatorUpdate(ctx, []abci.ValidatorUpdate{validator.ABCIValidatorUpdateZero()})
 			k.DeleteLastValidatorPower(ctx, validator.OperatorAddr)
 		}
@@ -671,6 +725,61 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context,
 	return ubd, nil
 }
 
+// cancel a part or all of an unbonding record before it completes, the amount is delegated back to the validator
+// it is unbonding from. creationHeight must be the height the unbonding started at.
+func (k Keeper) CancelUnbonding(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress,
+	creationHeight int64, amount sdk.Coin) (types.UnbondingDelegation, sdk.Error) {
+
+	ubd, found := k.GetUnbondingDelegation(ctx, delAddr, valAddr)
+	if !found || ubd.CreationHeight != creationHeight {
+		return ubd, types.ErrNoUnbondingDelegation(k.Codespace())
+	}
+	if ubd.CrossStake {
+		return ubd, types.ErrBadCancelUnbonding(k.Codespace(), "cross chain unbonding cannot be canceled")
+	}
+	if !ubd.MinTime.After(ctx.BlockHeader().Time) {
+		return ubd, types.ErrBadCancelUnbonding(k.Codespace(), "unbonding is already mature")
+	}
+	if amount.Denom != ubd.Balance.Denom {
+		return ubd, types.ErrBadDenom(k.Codespace())
+	}
+	if amount.Amount > ubd.Balance.Amount {
+		return ubd, types.ErrBadCancelUnbonding(k.Codespace(),
+			fmt.Sprintf("the amount must not be greater than the unbonding balance %d", ubd.Balance.Amount))
+	}
+	if minDelegationChange := k.MinDelegationChange(ctx); amount.Amount < minDelegationChange && amount.Amount != ubd.Balance.Amount {
+		return ubd, types.ErrBadCancelUnbonding(k.Codespace(),
+			fmt.Sprintf("the amount must not be less than %d, or the amount is all the unbonding balance", minDelegationChange))
+	}
+
+	validator, found := k.GetValidator(ctx, valAddr)
+	if !found {
+		return ubd, types.ErrNoValidatorFound(k.Codespace())
+	}
+	if validator.Jailed && !validator.IsSelfDelegator(delAddr) {
+		return ubd, types.ErrValidatorJailed(k.Codespace())
+	}
+
+	// the unbonding tokens are kept by DelegationAccAddr, so they are delegated without any transfer
+	if _, err := k.Delegate(ctx, delAddr, amount, validator, false); err != nil {
+		return ubd, err
+	}
+
+	ubd.Balance.Amount -= amount.Amount
+	ubd.InitialBalance.Amount -= amount.Amount
+	if ubd.InitialBalance.Amount < 0 {
+		// the unbonding was slashed, the whole initial balance is not unbonding anymore
+		ubd.InitialBalance.Amount = 0
+	}
+	if ubd.Balance.Amount == 0 {
+		k.RemoveFromUnbondingQueue(ctx, ubd)
+		k.RemoveUnbondingDelegation(ctx, ubd)
+	} else {
+		k.SetUnbondingDelegation(ctx, ubd)
+	}
+	return ubd, nil
+}
+
 // complete unbonding an unbonding record
 // CONTRACT: Expects unbonding passed in has finished the unbonding period
 func (k Keeper) CompleteUnbonding(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (types.UnbondingDelegation, sdk.Events, sdk.Error) {
@@ -693,6 +802,19 @@ func (k Keeper) CompleteUnbonding(ctx sdk.Context, delAddr sdk.AccAddress, valAd
 		if k.AddrPool != nil {
 			k.AddrPool.AddAddrs([]sdk.AccAddress{sdk.PegAccount})
 		}
+	} else if recipient, found := k.GetUnbondingTransfer(ctx, delAddr, valAddr); found {
+		// the tokens are left to the delegator if they cannot be transferred
+		cacheCtx, write := ctx.CacheContext()
+		if transferEvents, err := k.transferUndelegated(cacheCtx, delAddr, valAddr, recipient, ubd.Balance.Amount); err != nil {
+			k.Logger(ctx).Error("failed to transfer the unbonded tokens to BSC", "delegator", delAddr.String(),
+				"validator", valAddr.String(), "recipient", recipient.String(), "err", err.Error())
+		} else {
+			write()
+			events = transferEvents
+			if k.AddrPool != nil {
+				k.AddrPool.AddAddrs([]sdk.AccAddress{sdk.PegAccount})
+			}
+		}
 	}
 
 	if k.AddrPool != nil {
@@ -717,11 +839,17 @@ func (k Keeper) BeginRedelegation(ctx sdk.Context, delAddr sdk.AccAddress,
 		return types.Redelegation{}, types.ErrBadRedelegationDst(k.Codespace())
 	}
 
-	// check if there is already a redelgation in progress from src to dst
+	// check if there are already as many redelegations in progress from src to dst as allowed
 	// TODO quick fix, instead we should use an index, see https://github.com/cosmos/cosmos-sdk/issues/1402
-	_, found = k.GetRedelegation(ctx, delAddr, valSrcAddr, valDstAddr)
+	existing, found := k.GetRedelegation(ctx, delAddr, valSrcAddr, valDstAddr)
 	if found {
-		return types.Redelegation{}, types.ErrConflictingRedelegation(k.Codespace())
+		maxEntries := k.MaxRedelegationEntries(ctx)
+		if maxEntries <= 1 {
+			return types.Redelegation{}, types.ErrConflictingRedelegation(k.Codespace())
+		}
+		if int64(1+len(existing.Entries)) >= maxEntries {
+			return types.Redelegation{}, types.ErrMaxRedelegationEntries(k.Codespace(), maxEntries)
+		}
 	}
 
 	// check if this is a transitive redelegation
@@ -749,6 +877,21 @@ func (k Keeper) BeginRedelegation(ctx sdk.Context, delAddr sdk.AccAddress,
 		return types.Redelegation{MinTime: minTime}, nil
 	}
 
+	if found {
+		// the redelegation runs beside the ones in progress
+		existing.Entries = append(existing.Entries, types.RedelegationEntry{
+			CreationHeight: height,
+			MinTime:        minTime,
+			SharesDst:      sharesCreated,
+			SharesSrc:      sharesAmount,
+			Balance:        returnCoin,
+			InitialBalance: returnCoin,
+		})
+		k.SetRedelegation(ctx, existing)
+		k.InsertRedelegationEntryQueue(ctx, existing, minTime)
+		return existing, nil
+	}
+
 	red := types.Redelegation{
 		DelegatorAddr:    delAddr,
 		ValidatorSrcAddr: valSrcAddr,
@@ -774,13 +917,24 @@ func (k Keeper) CompleteRedelegation(ctx sdk.Context, delAddr sdk.AccAddress,
 		return types.ErrNoRedelegation(k.Codespace())
 	}
 
-	// ensure that enough time has passed
+	// ensure that enough time has passed, the mature entries only are completed
 	ctxTime := ctx.BlockHeader().Time
-	if red.MinTime.After(ctxTime) {
+	entries := red.AllEntries()
+	remaining := make([]types.RedelegationEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.MinTime.After(ctxTime) {
+			remaining = append(remaining, entry)
+		}
+	}
+	if len(remaining) == len(entries) {
 		return types.ErrNotMature(k.Codespace(), "redelegation", "unit-time", red.MinTime, ctxTime)
 	}
 
-	k.RemoveRedelegation(ctx, red)
+	if len(remaining) == 0 {
+		k.RemoveRedelegation(ctx, red)
+	} else {
+		k.SetRedelegation(ctx, red.WithEntries(remaining))
+	}
 	return nil
 }
 
@@ -818,8 +972,21 @@ func (k Keeper) ValidateUnbondAmount(
 }
 
 func (k Keeper) crossDistributeUndelegated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (sdk.Events, sdk.Error) {
+	amount := k.BankKeeper.GetCoins(ctx, delAddr).AmountOf(k.BondDenom(ctx))
+	delBscAddrAcc := types.GetStakeCAoB(delAddr.Bytes(), types.DelegateCAoBSalt)
+	delBscAddr := hex.EncodeToString(delBscAddrAcc.Bytes())
+	recipient, err := sdk.NewSmartChainAddress(delBscAddr)
+	if err != nil {
+		return sdk.Events{}, sdk.ErrInternal(err.Error())
+	}
+	return k.transferUndelegated(ctx, delAddr, valAddr, recipient, amount)
+}
+
+// transferUndelegated transfers amount of the undelegated tokens of a delegator to recipient on BSC, the relay fee is
+// paid out of the amount
+func (k Keeper) transferUndelegated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress,
+	recipient sdk.SmartChainAddress, amount int64) (sdk.Events, sdk.Error) {
 	denom := k.BondDenom(ctx)
-	amount := k.BankKeeper.GetCoins(ctx, delAddr).AmountOf(denom)
 	relayFeeCalc := fees.GetCalculator(types.CrossDistributeUndelegatedRelayFee)
 	if relayFeeCalc == nil {
 		return sdk.Events{}, sdk.ErrInternal("no fee calculator of distributeUndelegated")
@@ -831,13 +998,6 @@ func (k Keeper) crossDistributeUndelegated(ctx sdk.Context, delAddr sdk.AccAddre
 	bscRelayFee := bsc.ConvertBCAmountToBSCAmount(relayFee.Tokens.AmountOf(denom))
 	bscTransferAmount := new(big.Int).Sub(bsc.ConvertBCAmountToBSCAmount(amount), bscRelayFee)
 
-	delBscAddrAcc := types.GetStakeCAoB(delAddr.Bytes(), types.DelegateCAoBSalt)
-	delBscAddr := hex.EncodeToString(delBscAddrAcc.Bytes())
-	recipient, err := sdk.NewSmartChainAddress(delBscAddr)
-	if err != nil {
-		return sdk.Events{}, sdk.ErrInternal(err.Error())
-	}
-
 	transferPackage := types.CrossStakeDistributeUndelegatedSynPackage{
 		EventType: types.CrossStakeTypeDistributeUndelegated,
 		Amount:    bscTransferAmount,
@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// GetDelegatorPosition aggregates the delegations, the unbonding delegations and the unpaid rewards of a delegator on
+// the native chain and all the side chains. The chains the delegator has no stake on are left out.
+func (k Keeper) GetDelegatorPosition(ctx sdk.Context, delAddr sdk.AccAddress) types.DelegatorPosition {
+	ctx = ctx.DepriveSideChainKeyPrefix()
+	position := types.DelegatorPosition{
+		DelegatorAddr: delAddr,
+		Height:        ctx.BlockHeight(),
+		Chains:        []types.ChainPosition{},
+	}
+
+	contexts := []sdk.Context{ctx}
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LaunchBscUpgrade) && k.ScKeeper != nil {
+		sideChainIds, storePrefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
+		for i := range sideChainIds {
+			contexts = append(contexts, ctx.WithSideChainKeyPrefix(storePrefixes[i]).WithSideChainId(sideChainIds[i]))
+		}
+	}
+	for _, chainCtx := range contexts {
+		export := k.ExportDelegator(chainCtx, delAddr)
+		if len(export.Delegations) == 0 && len(export.UnbondingDelegations) == 0 {
+			continue
+		}
+		chain := types.ChainPosition{
+			ChainId:   export.ChainId,
+			BondDenom: k.BondDenom(chainCtx),
+		}
+		for _, delegation := range export.Delegations {
+			chain.Bonded += delegation.Tokens
+			chain.Rewards += delegation.Rewards
+		}
+		for _, ubd := range export.UnbondingDelegations {
+			chain.Unbonding += ubd.Balance
+		}
+		position.Chains = append(position.Chains, chain)
+		position.Bonded = addCoin(position.Bonded, chain.BondDenom, chain.Bonded)
+		position.Unbonding = addCoin(position.Unbonding, chain.BondDenom, chain.Unbonding)
+		position.Rewards = addCoin(position.Rewards, chain.BondDenom, chain.Rewards)
+	}
+	return position
+}
+
+func addCoin(coins sdk.Coins, denom string, amount int64) sdk.Coins {
+	if amount <= 0 {
+		return coins
+	}
+	return coins.Plus(sdk.Coins{sdk.NewCoin(denom, amount)})
+}
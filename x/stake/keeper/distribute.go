@@ -149,7 +149,7 @@ func (k Keeper) DistributeInBreathBlock(ctx sdk.Context, sideChainId string) sdk
 	// force getting FeeFromBscToBcRatio from bc context
 	feeFromBscToBcRatio := k.FeeFromBscToBcRatio(ctx.WithSideChainKeyPrefix(nil))
 	avgFeeForBcVals := sdk.ZeroDec()
-	if sdk.IsUpgrade(sdk.BEP159) && sideChainId == types.ChainIDForBeaconChain {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) && sideChainId == types.ChainIDForBeaconChain {
 		feeForAllBcValsCoins := k.BankKeeper.GetCoins(ctx, FeeForAllBcValsAccAddr)
 		feeForAllBcVals := feeForAllBcValsCoins.AmountOf(bondDenom)
 		avgFeeForBcVals = sdk.NewDec(feeForAllBcVals / int64(len(validators)))
@@ -161,7 +161,7 @@ func (k Keeper) DistributeInBreathBlock(ctx sdk.Context, sideChainId string) sdk
 		totalReward := distAccCoins.AmountOf(bondDenom)
 		totalRewardDec := sdk.NewDec(totalReward)
 		ctx.Logger().Info("FeeCalculation validator", "DistributionAddr", validator.DistributionAddr, "totalReward", totalReward, "height", height, "validator", validator)
-		if sdk.IsUpgrade(sdk.BEP159) {
+		if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 			if sideChainId != types.ChainIDForBeaconChain {
 				// split a portion of fees to BC validators
 				feeToBC := totalRewardDec.Mul(feeFromBscToBcRatio)
@@ -191,15 +191,6 @@ func (k Keeper) DistributeInBreathBlock(ctx sdk.Context, sideChainId string) sdk
 		rewards := make([]types.PreReward, 0)
 		crossStake := make(map[string]bool)
 		if totalReward > 0 {
-			delegations, found := k.GetSimplifiedDelegations(ctx, height, validator.OperatorAddr)
-			if !found {
-				panic(fmt.Sprintf("no delegations found with height=%d, validator=%s", height, validator.OperatorAddr))
-			}
-			for _, del := range delegations {
-				if del.CrossStake {
-					crossStake[del.DelegatorAddr.String()] = true
-				}
-			}
 			totalRewardDec = sdk.NewDec(totalReward)
 
 			//distribute commission
@@ -213,10 +204,26 @@ func (k Keeper) DistributeInBreathBlock(ctx sdk.Context, sideChainId string) sdk
 				}
 			}
 
-			//calculate rewards for delegators
 			remainReward := totalRewardDec.Sub(commission)
-			ctx.Logger().Info("FeeCalculation commission", "rate", validator.Commission.Rate, "commission", commission, "remainReward", remainReward, "delegations", delegations)
-			rewards = allocate(simDelsToSharers(delegations), remainReward)
+			if ctx.UpgradeMgr().IsUpgrade(sdk.LazyRewardDistribution) {
+				// the rewards are accumulated for the delegators to withdraw them
+				ctx.Logger().Info("FeeCalculation commission", "rate", validator.Commission.Rate, "commission", commission, "remainReward", remainReward)
+				k.allocateDelegatorRewards(ctx, validator, remainReward.RawInt())
+				k.restakeDelegatorRewards(ctx, sideChainId, validator.OperatorAddr)
+			} else {
+				delegations, found := k.GetSimplifiedDelegations(ctx, height, validator.OperatorAddr)
+				if !found {
+					panic(fmt.Sprintf("no delegations found with height=%d, validator=%s", height, validator.OperatorAddr))
+				}
+				for _, del := range delegations {
+					if del.CrossStake {
+						crossStake[del.DelegatorAddr.String()] = true
+					}
+				}
+				//calculate rewards for delegators
+				ctx.Logger().Info("FeeCalculation commission", "rate", validator.Commission.Rate, "commission", commission, "remainReward", remainReward, "delegations", delegations)
+				rewards = allocate(simDelsToSharers(delegations), remainReward)
+			}
 			for i := range rewards {
 				// previous tokens calculation is in `node` repo, move it to here
 				tokens, err := sdk.MulQuoDec(validator.GetTokens(), rewards[i].Shares, validator.GetDelegatorShares())
@@ -340,7 +347,13 @@ func (k Keeper) distributeSingleBatch(ctx sdk.Context, sideChainId string) sdk.E
 			distAddrBalanceMap[distAddr.String()] = reward.Amount
 		}
 
-		if reward.CrossStake && sdk.IsUpgrade(sdk.BEP153) {
+		if k.restakeReward(ctx, sideChainId, reward) {
+			toPublishRewards = append(toPublishRewards, reward)
+			changedAddrs = append(changedAddrs, DelegationAccAddr)
+			continue
+		}
+
+		if reward.CrossStake && ctx.UpgradeMgr().IsUpgrade(sdk.BEP153) {
 			rewardCAoB := types.GetStakeCAoB(reward.AccAddr.Bytes(), types.RewardCAoBSalt)
 			crossStakeAddrSet = append(crossStakeAddrSet, rewardCAoB)
 			reward.AccAddr = rewardCAoB
@@ -0,0 +1,85 @@
+package keeper
+
+import (
+	"bytes"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// sortElectionCandidates sorts the validators of a snapshot election, by accumulated stake
+// then by operator address, the higher first
+func sortElectionCandidates(validators []types.Validator) {
+	sort.SliceStable(validators, func(i, j int) bool {
+		if validators[i].AccumulatedStake.GT(validators[j].AccumulatedStake) {
+			return true
+		}
+		if validators[i].AccumulatedStake.LT(validators[j].AccumulatedStake) {
+			return false
+		}
+		// for the same accumulated stake, sort by operator address
+		return bytes.Compare(validators[i].OperatorAddr, validators[j].OperatorAddr) > 0
+	})
+}
+
+// GetElectionRanking explains the ranking of all the validators in the election of the validator
+// set, with the snapshot election of BEP159 on the native chain and the power store otherwise.
+// The accumulated stakes are the ones of the latest election.
+func (k Keeper) GetElectionRanking(ctx sdk.Context) []types.ElectionCandidate {
+	snapshotElection := ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) && ctx.SideChainKeyPrefix() == nil
+
+	validators := k.GetAllValidators(ctx)
+	candidates := make([]types.ElectionCandidate, 0, len(validators))
+	for _, validator := range validators {
+		candidate := types.ElectionCandidate{
+			OperatorAddr: validator.OperatorAddr,
+			Stake:        validator.Tokens,
+			TieBreaker:   append([]byte{}, validator.OperatorAddr...),
+			Jailed:       validator.Jailed,
+		}
+		if validator.IsSideChainValidator() {
+			candidate.HeartbeatAbsence, _ = k.HeartbeatAbsence(ctx, validator.OperatorAddr)
+		}
+		if snapshotElection {
+			candidate.Stake = validator.AccumulatedStake
+		} else {
+			for i, b := range candidate.TieBreaker {
+				candidate.TieBreaker[i] = ^b
+			}
+		}
+
+		switch {
+		case validator.Jailed:
+			candidate.ExclusionReason = types.ExclusionJailed
+		case validator.Tokens.IsZero() || !candidate.Stake.GT(sdk.ZeroDec()):
+			candidate.ExclusionReason = types.ExclusionNoStake
+		default:
+			candidate.Eligible = true
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Eligible != candidates[j].Eligible {
+			return candidates[i].Eligible
+		}
+		if !candidates[i].Stake.Equal(candidates[j].Stake) {
+			return candidates[i].Stake.GT(candidates[j].Stake)
+		}
+		return bytes.Compare(candidates[i].TieBreaker, candidates[j].TieBreaker) > 0
+	})
+
+	maxValidators := int(k.MaxValidators(ctx))
+	for i := range candidates {
+		if !candidates[i].Eligible {
+			break
+		}
+		candidates[i].Rank = i + 1
+		candidates[i].Elected = i < maxValidators
+		if !candidates[i].Elected {
+			candidates[i].ExclusionReason = types.ExclusionBelowCutoff
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,90 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// ExportDelegator exports the delegations, the unbonding delegations and the unpaid rewards of a delegator
+func (k Keeper) ExportDelegator(ctx sdk.Context, delAddr sdk.AccAddress) types.DelegatorExport {
+	chainId := ctx.SideChainId()
+	if chainId == "" {
+		chainId = types.ChainIDForBeaconChain
+	}
+	export := types.DelegatorExport{
+		ChainId:              chainId,
+		Height:               ctx.BlockHeight(),
+		DelegatorAddr:        delAddr,
+		Delegations:          []types.ExportedDelegation{},
+		UnbondingDelegations: []types.ExportedUnbondingDelegation{},
+	}
+
+	lazyRewards := ctx.UpgradeMgr().IsUpgrade(sdk.LazyRewardDistribution)
+	for _, delegation := range k.GetAllDelegatorDelegations(ctx, delAddr) {
+		exported := types.ExportedDelegation{
+			ValidatorAddr: delegation.ValidatorAddr,
+			Shares:        delegation.Shares,
+			CrossStake:    delegation.CrossStake,
+		}
+		if validator, found := k.GetValidator(ctx, delegation.ValidatorAddr); found {
+			exported.Tokens = validator.TokensFromShares(delegation.Shares).RawInt()
+		}
+		// the rewards are paid at the distribution before the lazy reward distribution
+		if lazyRewards {
+			exported.Rewards, _ = k.GetDelegationRewards(ctx, delAddr, delegation.ValidatorAddr)
+		}
+		export.Delegations = append(export.Delegations, exported)
+	}
+	for _, ubd := range k.GetAllUnbondingDelegations(ctx, delAddr) {
+		export.UnbondingDelegations = append(export.UnbondingDelegations, types.ExportedUnbondingDelegation{
+			ValidatorAddr:  ubd.ValidatorAddr,
+			CreationHeight: ubd.CreationHeight,
+			MinTime:        ubd.MinTime.UTC(),
+			Balance:        ubd.Balance.Amount,
+			CrossStake:     ubd.CrossStake,
+		})
+	}
+	return export
+}
+
+// IterateDelegatorExports exports the delegators having delegations or unbonding delegations one by one, in the order
+// of their addresses from start, or from the first one if start is empty
+func (k Keeper) IterateDelegatorExports(ctx sdk.Context, start sdk.AccAddress, fn func(export types.DelegatorExport) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	delIterator := store.Iterator(append(DelegationKey, start...), sdk.PrefixEndBytes(DelegationKey))
+	defer delIterator.Close()
+	ubdIterator := store.Iterator(append(UnbondingDelegationKey, start...), sdk.PrefixEndBytes(UnbondingDelegationKey))
+	defer ubdIterator.Close()
+
+	// the keys of both are the prefix followed by the delegator and the validator addresses
+	delegatorOf := func(iterator sdk.Iterator) sdk.AccAddress {
+		return sdk.AccAddress(iterator.Key()[1 : 1+sdk.AddrLen])
+	}
+	skip := func(iterator sdk.Iterator, delAddr sdk.AccAddress) {
+		for iterator.Valid() && bytes.Equal(delegatorOf(iterator), delAddr) {
+			iterator.Next()
+		}
+	}
+
+	for {
+		var delAddr sdk.AccAddress
+		if delIterator.Valid() {
+			delAddr = delegatorOf(delIterator)
+		}
+		if ubdIterator.Valid() {
+			if ubdDelAddr := delegatorOf(ubdIterator); delAddr == nil || bytes.Compare(ubdDelAddr, delAddr) < 0 {
+				delAddr = ubdDelAddr
+			}
+		}
+		if delAddr == nil {
+			return
+		}
+		if fn(k.ExportDelegator(ctx, delAddr)) {
+			return
+		}
+		skip(delIterator, delAddr)
+		skip(ubdIterator, delAddr)
+	}
+}
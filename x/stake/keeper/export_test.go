@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"bytes"
+	"sort"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegatorExports(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	bondDenom := keeper.BondDenom(ctx)
+	for _, valAddr := range addrVals[:2] {
+		keeper.SetValidator(ctx, types.NewValidator(valAddr, PKs[0], types.Description{}))
+	}
+
+	// the first delegator delegates to two validators and unbonds, the second one only unbonds
+	for _, valAddr := range addrVals[:2] {
+		validator, found := keeper.GetValidator(ctx, valAddr)
+		require.True(t, found)
+		_, err := keeper.Delegate(ctx, addrDels[0], sdk.NewCoin(bondDenom, 100), validator, true)
+		require.Nil(t, err)
+	}
+	for _, delAddr := range addrDels {
+		keeper.SetUnbondingDelegation(ctx, types.UnbondingDelegation{
+			DelegatorAddr:  delAddr,
+			ValidatorAddr:  addrVals[0],
+			CreationHeight: 10,
+			MinTime:        time.Unix(100, 0),
+			InitialBalance: sdk.NewCoin(bondDenom, 50),
+			Balance:        sdk.NewCoin(bondDenom, 40),
+		})
+	}
+
+	export := keeper.ExportDelegator(ctx, addrDels[0])
+	require.Equal(t, types.ChainIDForBeaconChain, export.ChainId)
+	require.Len(t, export.Delegations, 2)
+	require.EqualValues(t, 100, export.Delegations[0].Tokens)
+	require.Len(t, export.UnbondingDelegations, 1)
+	require.EqualValues(t, 40, export.UnbondingDelegations[0].Balance)
+	require.Equal(t, export.Hash(), keeper.ExportDelegator(ctx, addrDels[0]).Hash())
+	require.NotEqual(t, export.Hash(), keeper.ExportDelegator(ctx, addrDels[1]).Hash())
+
+	// all the delegators are exported once, in the order of their addresses
+	expected := []sdk.AccAddress{addrDels[0], addrDels[1]}
+	sort.Slice(expected, func(i, j int) bool { return bytes.Compare(expected[i], expected[j]) < 0 })
+	var exported []sdk.AccAddress
+	keeper.IterateDelegatorExports(ctx, nil, func(export types.DelegatorExport) bool {
+		exported = append(exported, export.DelegatorAddr)
+		return false
+	})
+	require.Equal(t, expected, exported)
+
+	exported = nil
+	keeper.IterateDelegatorExports(ctx, expected[1], func(export types.DelegatorExport) bool {
+		exported = append(exported, export.DelegatorAddr)
+		return false
+	})
+	require.Equal(t, expected[1:], exported)
+}
+
+func TestDelegatorPosition(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	bondDenom := keeper.BondDenom(ctx)
+	keeper.SetValidator(ctx, types.NewValidator(addrVals[0], PKs[0], types.Description{}))
+	validator, found := keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	_, err := keeper.Delegate(ctx, addrDels[0], sdk.NewCoin(bondDenom, 100), validator, true)
+	require.Nil(t, err)
+
+	// a side chain bonding another denom
+	keeper.ScKeeper.SetSideChainIdAndStorePrefix(ctx, "bsc", []byte{0x99})
+	scCtx := ctx.WithSideChainKeyPrefix([]byte{0x99})
+	params := types.DefaultParams()
+	params.BondDenom = "SCB"
+	keeper.SetParams(scCtx, params)
+	scValidator := types.NewValidator(addrVals[1], PKs[1], types.Description{})
+	scValidator.Tokens = sdk.NewDec(600)
+	scValidator.DelegatorShares = sdk.NewDec(600)
+	keeper.SetValidator(scCtx, scValidator)
+	keeper.SetDelegation(scCtx, types.Delegation{DelegatorAddr: addrDels[0], ValidatorAddr: addrVals[1], Shares: sdk.NewDec(300)})
+	keeper.SetUnbondingDelegation(scCtx, types.UnbondingDelegation{
+		DelegatorAddr:  addrDels[0],
+		ValidatorAddr:  addrVals[1],
+		CreationHeight: 10,
+		MinTime:        time.Unix(100, 0),
+		InitialBalance: sdk.NewCoin("SCB", 50),
+		Balance:        sdk.NewCoin("SCB", 40),
+	})
+
+	// the same position is answered from any chain
+	position := keeper.GetDelegatorPosition(scCtx, addrDels[0])
+	require.Equal(t, []types.ChainPosition{
+		{ChainId: types.ChainIDForBeaconChain, BondDenom: bondDenom, Bonded: 100},
+		{ChainId: "bsc", BondDenom: "SCB", Bonded: 300, Unbonding: 40},
+	}, position.Chains)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("SCB", 300), sdk.NewCoin(bondDenom, 100)}, position.Bonded)
+	require.Equal(t, sdk.Coins{sdk.NewCoin("SCB", 40)}, position.Unbonding)
+	require.Empty(t, position.Rewards)
+
+	// the delegators without stake have an empty position
+	require.Empty(t, keeper.GetDelegatorPosition(ctx, addrDels[1]).Chains)
+}
@@ -0,0 +1,99 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// get the last heartbeat of a side chain validator
+func (k Keeper) GetHeartbeat(ctx sdk.Context, valAddr sdk.ValAddress) (heartbeat types.Heartbeat, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetValidatorHeartbeatKey(valAddr))
+	if bz == nil {
+		return heartbeat, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &heartbeat)
+	return heartbeat, true
+}
+
+// set the last heartbeat of a side chain validator
+func (k Keeper) SetHeartbeat(ctx sdk.Context, valAddr sdk.ValAddress, heartbeat types.Heartbeat) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(heartbeat)
+	store.Set(GetValidatorHeartbeatKey(valAddr), bz)
+}
+
+// remove the last heartbeat of a side chain validator
+func (k Keeper) RemoveHeartbeat(ctx sdk.Context, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorHeartbeatKey(valAddr))
+}
+
+// RecordHeartbeat verifies the heartbeat of a side chain validator signed by its BSC consensus key and records
+// it. ctx is the context of the side chain. The heartbeat must be recent and newer than the last one.
+func (k Keeper) RecordHeartbeat(ctx sdk.Context, sideChainId string, valAddr sdk.ValAddress, height int64, signature []byte) (types.Heartbeat, sdk.Error) {
+	if k.HeartbeatTimeout(ctx) == 0 {
+		return types.Heartbeat{}, types.ErrInvalidHeartbeat(k.Codespace(), "heartbeats are disabled")
+	}
+	validator, found := k.GetValidator(ctx, valAddr)
+	if !found {
+		return types.Heartbeat{}, types.ErrNoValidatorFound(k.Codespace())
+	}
+	if !validator.IsSideChainValidator() || len(validator.SideConsAddr) == 0 {
+		return types.Heartbeat{}, types.ErrInvalidHeartbeat(k.Codespace(), "only side chain validators send heartbeats")
+	}
+	if height > ctx.BlockHeight() || height <= ctx.BlockHeight()-types.MaxHeartbeatDelay {
+		return types.Heartbeat{}, types.ErrInvalidHeartbeat(k.Codespace(),
+			fmt.Sprintf("heartbeat height %d should be within the last %d blocks", height, types.MaxHeartbeatDelay))
+	}
+	if last, found := k.GetHeartbeat(ctx, valAddr); found && height <= last.Height {
+		return types.Heartbeat{}, types.ErrInvalidHeartbeat(k.Codespace(),
+			fmt.Sprintf("heartbeat height %d should be higher than the last heartbeat %d", height, last.Height))
+	}
+	if !types.VerifyHeartbeatSignature(ctx.ChainID(), sideChainId, valAddr, height, signature, validator.SideConsAddr) {
+		return types.Heartbeat{}, types.ErrInvalidHeartbeat(k.Codespace(), "heartbeat is not signed by the side chain consensus address")
+	}
+
+	heartbeat := types.Heartbeat{Height: height, ReceivedHeight: ctx.BlockHeight()}
+	k.SetHeartbeat(ctx, valAddr, heartbeat)
+	return heartbeat, nil
+}
+
+// HeartbeatAbsence returns the number of blocks since the last heartbeat of a side chain validator, false if it
+// never sent one, heartbeats being optional
+func (k Keeper) HeartbeatAbsence(ctx sdk.Context, valAddr sdk.ValAddress) (int64, bool) {
+	heartbeat, found := k.GetHeartbeat(ctx, valAddr)
+	if !found {
+		return 0, false
+	}
+	return ctx.BlockHeight() - heartbeat.Height, true
+}
+
+// CheckHeartbeats returns a warning event for each bonded validator of the side chain of ctx whose heartbeat
+// has been absent for a multiple of the HeartbeatTimeout blocks, so that a dead BSC signer is noticed before
+// it is slashed for downtime. Only the validators that sent a heartbeat once are checked.
+func (k Keeper) CheckHeartbeats(ctx sdk.Context, sideChainId string) sdk.Events {
+	timeout := k.HeartbeatTimeout(ctx)
+	if timeout <= 0 {
+		return nil
+	}
+	var events sdk.Events
+	for _, validator := range k.GetLastValidators(ctx) {
+		absence, tracked := k.HeartbeatAbsence(ctx, validator.OperatorAddr)
+		if !tracked || absence < timeout || absence%timeout != 0 {
+			continue
+		}
+		heartbeat, _ := k.GetHeartbeat(ctx, validator.OperatorAddr)
+		ctx.Logger().Info("side chain validator heartbeat missing", "sideChainId", sideChainId,
+			"validator", validator.OperatorAddr.String(), "lastHeartbeat", heartbeat.Height, "absentBlocks", absence)
+		events = events.AppendEvent(sdk.NewEvent(types.EventTypeHeartbeatMissing,
+			sdk.NewAttribute(types.AttributeKeyValidator, validator.OperatorAddr.String()),
+			sdk.NewAttribute(types.AttributeKeySideChainId, sideChainId),
+			sdk.NewAttribute(types.AttributeKeyLastHeartbeat, fmt.Sprintf("%d", heartbeat.Height)),
+			sdk.NewAttribute(types.AttributeKeyHeartbeatAbsent, fmt.Sprintf("%d", absence)),
+		))
+	}
+	return events
+}
@@ -0,0 +1,86 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestSideChainHeartbeat(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	scCtx := ctx.WithSideChainKeyPrefix([]byte{0x99}).WithBlockHeight(200)
+	sideChainId := "bsc"
+
+	privKey, err := btcec.NewPrivateKey()
+	require.Nil(t, err)
+	sideConsAddr := bsc.Keccak256(privKey.PubKey().SerializeUncompressed()[1:])[12:]
+	sign := func(valAddr sdk.ValAddress, height int64) []byte {
+		compact, err := ecdsa.SignCompact(privKey, types.HeartbeatSignHash(ctx.ChainID(), sideChainId, valAddr, height), false)
+		require.Nil(t, err)
+		// [27 + V || R || S] to the eth-style [R || S || V]
+		return append(compact[1:], compact[0]-27)
+	}
+
+	valAddr := sdk.ValAddress(Addrs[0])
+	validator := types.NewSideChainValidator(Addrs[0], valAddr, types.Description{}, sideChainId, sideConsAddr, Addrs[0])
+	keeper.SetValidator(scCtx, validator)
+	keeper.SetLastValidatorPower(scCtx, valAddr, 10)
+
+	// the heartbeats are disabled by default
+	_, sdkErr := keeper.RecordHeartbeat(scCtx, sideChainId, valAddr, 195, sign(valAddr, 195))
+	require.NotNil(t, sdkErr)
+	require.Empty(t, keeper.CheckHeartbeats(scCtx, sideChainId))
+
+	params := keeper.GetParams(scCtx)
+	params.HeartbeatTimeout = 10
+	keeper.SetParams(scCtx, params)
+
+	// a heartbeat signed by another key, for another validator or for another height is rejected
+	otherKey, err := btcec.NewPrivateKey()
+	require.Nil(t, err)
+	compact, err := ecdsa.SignCompact(otherKey, types.HeartbeatSignHash(ctx.ChainID(), sideChainId, valAddr, 195), false)
+	require.Nil(t, err)
+	_, sdkErr = keeper.RecordHeartbeat(scCtx, sideChainId, valAddr, 195, append(compact[1:], compact[0]-27))
+	require.NotNil(t, sdkErr)
+	_, sdkErr = keeper.RecordHeartbeat(scCtx, sideChainId, valAddr, 195, sign(sdk.ValAddress(Addrs[1]), 195))
+	require.NotNil(t, sdkErr)
+	_, sdkErr = keeper.RecordHeartbeat(scCtx, sideChainId, valAddr, 195, sign(valAddr, 196))
+	require.NotNil(t, sdkErr)
+
+	// a heartbeat too old or in the future is rejected
+	_, sdkErr = keeper.RecordHeartbeat(scCtx, sideChainId, valAddr, 100, sign(valAddr, 100))
+	require.NotNil(t, sdkErr)
+	_, sdkErr = keeper.RecordHeartbeat(scCtx, sideChainId, valAddr, 201, sign(valAddr, 201))
+	require.NotNil(t, sdkErr)
+
+	heartbeat, sdkErr := keeper.RecordHeartbeat(scCtx, sideChainId, valAddr, 195, sign(valAddr, 195))
+	require.Nil(t, sdkErr)
+	require.Equal(t, types.Heartbeat{Height: 195, ReceivedHeight: 200}, heartbeat)
+
+	// a heartbeat can not be replayed
+	_, sdkErr = keeper.RecordHeartbeat(scCtx, sideChainId, valAddr, 195, sign(valAddr, 195))
+	require.NotNil(t, sdkErr)
+
+	// the absence is warned about at each multiple of the timeout
+	absence, tracked := keeper.HeartbeatAbsence(scCtx, valAddr)
+	require.True(t, tracked)
+	require.Equal(t, int64(5), absence)
+	require.Empty(t, keeper.CheckHeartbeats(scCtx, sideChainId))
+	require.Len(t, keeper.CheckHeartbeats(scCtx.WithBlockHeight(205), sideChainId), 1)
+	require.Empty(t, keeper.CheckHeartbeats(scCtx.WithBlockHeight(206), sideChainId))
+	require.Len(t, keeper.CheckHeartbeats(scCtx.WithBlockHeight(215), sideChainId), 1)
+
+	// the validators that never sent a heartbeat are not checked
+	_, tracked = keeper.HeartbeatAbsence(scCtx, sdk.ValAddress(Addrs[1]))
+	require.False(t, tracked)
+
+	keeper.RemoveValidator(scCtx, valAddr)
+	_, found := keeper.GetHeartbeat(scCtx, valAddr)
+	require.False(t, found)
+}
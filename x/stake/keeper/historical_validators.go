@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// MaxHistoricalValidatorSets is the number of the last validator sets of each chain kept for the queries by height,
+// about a year of breathe blocks
+const MaxHistoricalValidatorSets = 365
+
+// SaveHistoricalValidatorSet keeps the validator set elected in the breathe block, the oldest one is pruned once
+// there are more than MaxHistoricalValidatorSets of them
+func (k Keeper) SaveHistoricalValidatorSet(ctx sdk.Context, validators []types.Validator) {
+	set := types.HistoricalValidatorSet{
+		Height:     ctx.BlockHeight(),
+		Validators: make([]types.HistoricalValidator, 0, len(validators)),
+	}
+	for _, validator := range validators {
+		var consAddr []byte
+		if validator.IsSideChainValidator() {
+			consAddr = validator.SideConsAddr
+		} else if validator.ConsPubKey != nil {
+			consAddr = validator.GetConsAddr()
+		}
+		set.Validators = append(set.Validators, types.HistoricalValidator{
+			OperatorAddr: validator.OperatorAddr,
+			ConsAddr:     consAddr,
+			Power:        validator.GetPower().RawInt(),
+		})
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetHistoricalValidatorSetKey(set.Height), k.cdc.MustMarshalBinaryLengthPrefixed(set))
+
+	iterator := sdk.KVStorePrefixIterator(store, HistoricalValidatorSetKey)
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for i := 0; i < len(keys)-MaxHistoricalValidatorSets; i++ {
+		store.Delete(keys[i])
+	}
+}
+
+// GetHistoricalValidatorSet returns the validator set of the chain at height, the last one elected at or before it
+func (k Keeper) GetHistoricalValidatorSet(ctx sdk.Context, height int64) (set types.HistoricalValidatorSet, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.ReverseIterator(HistoricalValidatorSetKey, GetHistoricalValidatorSetKey(height+1))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return set, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &set)
+	return set, true
+}
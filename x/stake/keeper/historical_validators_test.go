@@ -0,0 +1,45 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoricalValidatorSets(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator.Tokens = sdk.NewDecWithoutFra(100)
+	validator.Status = sdk.Bonded
+
+	keeper.SaveHistoricalValidatorSet(ctx.WithBlockHeight(10), []types.Validator{validator})
+	keeper.SaveHistoricalValidatorSet(ctx.WithBlockHeight(20), nil)
+
+	_, found := keeper.GetHistoricalValidatorSet(ctx, 9)
+	require.False(t, found)
+	for _, height := range []int64{10, 15, 19} {
+		set, found := keeper.GetHistoricalValidatorSet(ctx, height)
+		require.True(t, found)
+		require.EqualValues(t, 10, set.Height)
+		require.Len(t, set.Validators, 1)
+		require.Equal(t, addrVals[0], set.Validators[0].OperatorAddr)
+		require.Equal(t, []byte(validator.GetConsAddr()), set.Validators[0].ConsAddr)
+		require.EqualValues(t, 100e8, set.Validators[0].Power)
+	}
+	set, found := keeper.GetHistoricalValidatorSet(ctx, 1000)
+	require.True(t, found)
+	require.EqualValues(t, 20, set.Height)
+	require.Empty(t, set.Validators)
+
+	// the oldest sets are pruned
+	for height := int64(21); height < 20+MaxHistoricalValidatorSets; height++ {
+		keeper.SaveHistoricalValidatorSet(ctx.WithBlockHeight(height), nil)
+	}
+	_, found = keeper.GetHistoricalValidatorSet(ctx, 15)
+	require.False(t, found)
+	set, found = keeper.GetHistoricalValidatorSet(ctx, 20)
+	require.True(t, found)
+	require.EqualValues(t, 20, set.Height)
+}
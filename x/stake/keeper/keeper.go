@@ -197,12 +197,18 @@ func (k *Keeper) SubscribeParamChange(hub pTypes.ParamChangePublisher) {
 			case *types.Params:
 				// do double check
 				err := change.UpdateCheck()
+				if err == nil {
+					err = change.UpgradeCheck(context.UpgradeMgr())
+				}
 				if err != nil {
 					context.Logger().Error("[sc] skip invalid param change", "err", err, "param", change)
 				} else {
 					res := k.GetParams(context)
-					// ignore BondDenom update if have.
-					change.BondDenom = res.BondDenom
+					// ignore BondDenom update if have, unless the side chain has no stake yet.
+					if change.BondDenom != res.BondDenom && !k.canUpdateBondDenom(context) {
+						context.Logger().Error("[sc] skip the bond_denom change of a side chain with stake", "bond_denom", change.BondDenom)
+						change.BondDenom = res.BondDenom
+					}
 					k.SetParams(context, *change)
 					break
 				}
@@ -225,9 +231,14 @@ func (k *Keeper) SubscribeBCParamChange(hub pTypes.BCParamChangePublisher) {
 			switch change := iChange.(type) {
 			case *types.Params:
 				err := change.UpdateCheck()
+				if err == nil {
+					err = change.UpgradeCheck(context.UpgradeMgr())
+				}
 				if err != nil {
 					context.Logger().Error("[bc] skip invalid param change", "err", err, "param", change)
 				} else {
+					// the native chain keeps its bond denom
+					change.BondDenom = k.BondDenom(context)
 					k.SetParams(context, *change)
 					break
 				}
@@ -39,6 +39,12 @@ var (
 	DelegationKeyByVal               = []byte{0x37} // prefix for each key for a delegation, by validator operator and delegator
 	SimplifiedDelegationsKey         = []byte{0x38} // prefix for each key for an simplifiedDelegations, by height and validator operator
 	ValLatestUpdateConsAddrTimeKey   = []byte{0x39} // prefix for each key for an latest update ConsAddr time, by validator operator
+	ValidatorAttestationKey          = []byte{0x3A} // prefix for each key for a validator attestation, by validator operator and type
+	ValidatorHeartbeatKey            = []byte{0x3B} // prefix for each key for the last heartbeat of a side chain validator, by validator operator
+	RedelegationRestrictionKey       = []byte{0x3C} // prefix for each key for the height until which redelegating away from a validator is forbidden
+	ValidatorCurrentRewardsKey       = []byte{0x3D} // prefix for each key for the rewards of the current period of a validator, by validator operator
+	ValidatorHistoricalRewardsKey    = []byte{0x3E} // prefix for each key for the rewards of a past period of a validator, by validator operator and period
+	DelegatorStartingInfoKey         = []byte{0x3F} // prefix for each key for the starting info of a delegation, by validator operator and delegator
 
 	UnbondingQueueKey    = []byte{0x41} // prefix for the timestamps in unbonding queue
 	RedelegationQueueKey = []byte{0x42} // prefix for the timestamps in redelegations queue
@@ -46,6 +52,12 @@ var (
 
 	SideChainStorePrefixByIdKey = []byte{0x51} // prefix for each key to a side chain store prefix, by side chain id
 
+	DelegationRestakeKey = []byte{0x61} // prefix for each key for a delegation whose rewards are restaked, by validator operator and delegator
+	UnbondingTransferKey = []byte{0x62} // prefix for each key for the BSC recipient of an unbonding delegation, by delegator and validator operator
+
+	HistoricalValidatorSetKey = []byte{0x63} // prefix for each key for a validator set elected in a breathe block, by height
+	ValidatorLastEditKey      = []byte{0x64} // prefix for each key for the height of the last edit of a validator, by validator operator
+
 	// Keys for reward store prefix
 	RewardBatchKey       = []byte{0x01} // key for batch of rewards
 	RewardValDistAddrKey = []byte{0x02} // key for rewards' validator <-> distribution address mapping
@@ -83,9 +95,9 @@ func AddressFromLastValidatorPowerKey(key []byte) []byte {
 // Power index is the key used in the power-store, and represents the relative
 // power ranking of the validator.
 // VALUE: validator operator address ([]byte)
-func GetValidatorsByPowerIndexKey(validator types.Validator) []byte {
+func GetValidatorsByPowerIndexKey(ctx sdk.Context, validator types.Validator) []byte {
 	var keyBytes []byte
-	sdk.Upgrade(sdk.LaunchBscUpgrade, func() {
+	ctx.UpgradeMgr().Upgrade(sdk.LaunchBscUpgrade, func() {
 		keyBytes = getValidatorPowerRank(validator)
 	}, nil, func() {
 		keyBytes = getValidatorPowerRankNew(validator)
@@ -335,3 +347,82 @@ func GetREDsByDelToValDstIndexKey(delAddr sdk.AccAddress, valDstAddr sdk.ValAddr
 func GetValLatestUpdateConsAddrTimeKey(valAddr sdk.ValAddress) []byte {
 	return append(ValLatestUpdateConsAddrTimeKey, valAddr.Bytes()...)
 }
+
+// gets the key for the attestation of a validator
+// VALUE: stake/types.Attestation
+func GetValidatorAttestationKey(valAddr sdk.ValAddress, attestationType types.AttestationType) []byte {
+	return append(GetValidatorAttestationsKey(valAddr), []byte(attestationType)...)
+}
+
+// gets the prefix for all the attestations of a validator
+func GetValidatorAttestationsKey(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorAttestationKey, valAddr.Bytes()...)
+}
+
+// gets the key for the last heartbeat of a side chain validator
+// VALUE: stake/types.Heartbeat
+func GetValidatorHeartbeatKey(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorHeartbeatKey, valAddr.Bytes()...)
+}
+
+// gets the key for the height until which redelegating away from a validator is forbidden
+// VALUE: int64
+func GetRedelegationRestrictionKey(valAddr sdk.ValAddress) []byte {
+	return append(RedelegationRestrictionKey, valAddr.Bytes()...)
+}
+
+// gets the key for the rewards of the current period of a validator
+// VALUE: stake/types.ValidatorCurrentRewards
+func GetValidatorCurrentRewardsKey(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorCurrentRewardsKey, valAddr.Bytes()...)
+}
+
+// gets the prefix for the rewards of the past periods of a validator
+func GetValidatorHistoricalRewardsPrefix(valAddr sdk.ValAddress) []byte {
+	return append(ValidatorHistoricalRewardsKey, valAddr.Bytes()...)
+}
+
+// gets the key for the rewards of a past period of a validator
+// VALUE: stake/types.ValidatorHistoricalRewards
+func GetValidatorHistoricalRewardsKey(valAddr sdk.ValAddress, period int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(period))
+	return append(GetValidatorHistoricalRewardsPrefix(valAddr), bz...)
+}
+
+// gets the key for the starting info of a delegation
+// VALUE: stake/types.DelegatorStartingInfo
+func GetDelegatorStartingInfoKey(delAddr sdk.AccAddress, valAddr sdk.ValAddress) []byte {
+	return append(append(DelegatorStartingInfoKey, valAddr.Bytes()...), delAddr.Bytes()...)
+}
+
+// gets the prefix for the delegations to a validator whose rewards are restaked
+func GetDelegationsRestakePrefix(valAddr sdk.ValAddress) []byte {
+	return append(DelegationRestakeKey, valAddr.Bytes()...)
+}
+
+// gets the key for a delegation whose rewards are restaked
+// VALUE: none
+func GetDelegationRestakeKey(delAddr sdk.AccAddress, valAddr sdk.ValAddress) []byte {
+	return append(GetDelegationsRestakePrefix(valAddr), delAddr.Bytes()...)
+}
+
+// gets the key for the validator set elected in the breathe block at height
+// VALUE: types.HistoricalValidatorSet
+func GetHistoricalValidatorSetKey(height int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return append(HistoricalValidatorSetKey, bz...)
+}
+
+// gets the key for the BSC recipient of the tokens of an unbonding delegation
+// VALUE: sdk.SmartChainAddress
+func GetUnbondingTransferKey(delAddr sdk.AccAddress, valAddr sdk.ValAddress) []byte {
+	return append(append(UnbondingTransferKey, delAddr.Bytes()...), valAddr.Bytes()...)
+}
+
+// gets the key for the height of the last edit of a validator
+// VALUE: int64
+func GetValidatorLastEditKey(operatorAddr sdk.ValAddress) []byte {
+	return append(ValidatorLastEditKey, operatorAddr.Bytes()...)
+}
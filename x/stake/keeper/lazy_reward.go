@@ -0,0 +1,259 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// Once LazyRewardDistribution is upgraded, the rewards of the delegators are not allocated to every delegation in the
+// breathe blocks anymore. The rewards left to the delegators of a validator after its commission are moved to
+// RewardPoolAccAddr and accumulated in the current period of the validator. Every change of the shares of a
+// delegation ends the period of its validator, recording the cumulative rewards per share. The rewards of a delegation
+// are its shares times the difference between the cumulative rewards per share of the last period and of the period
+// it started after, they are paid when the delegation changes or when the delegator withdraws them.
+// The delegations existing at the upgrade have no starting info, they start after the period 0 whose ratio is zero.
+
+// get the rewards of the current period of a validator
+func (k Keeper) GetValidatorCurrentRewards(ctx sdk.Context, valAddr sdk.ValAddress) types.ValidatorCurrentRewards {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetValidatorCurrentRewardsKey(valAddr))
+	if bz == nil {
+		return types.ValidatorCurrentRewards{Period: 1}
+	}
+	var rewards types.ValidatorCurrentRewards
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &rewards)
+	return rewards
+}
+
+func (k Keeper) setValidatorCurrentRewards(ctx sdk.Context, valAddr sdk.ValAddress, rewards types.ValidatorCurrentRewards) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetValidatorCurrentRewardsKey(valAddr), k.cdc.MustMarshalBinaryLengthPrefixed(rewards))
+}
+
+// get the rewards of a past period of a validator, the ratio is zero for the period 0
+func (k Keeper) GetValidatorHistoricalRewards(ctx sdk.Context, valAddr sdk.ValAddress, period int64) types.ValidatorHistoricalRewards {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetValidatorHistoricalRewardsKey(valAddr, period))
+	if bz == nil {
+		return types.ValidatorHistoricalRewards{CumulativeRewardRatio: sdk.ZeroInt()}
+	}
+	var rewards types.ValidatorHistoricalRewards
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &rewards)
+	return rewards
+}
+
+func (k Keeper) setValidatorHistoricalRewards(ctx sdk.Context, valAddr sdk.ValAddress, period int64, rewards types.ValidatorHistoricalRewards) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetValidatorHistoricalRewardsKey(valAddr, period), k.cdc.MustMarshalBinaryLengthPrefixed(rewards))
+}
+
+// get the starting info of a delegation
+func (k Keeper) GetDelegatorStartingInfo(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (info types.DelegatorStartingInfo, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetDelegatorStartingInfoKey(delAddr, valAddr))
+	if bz == nil {
+		return info, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &info)
+	return info, true
+}
+
+// remove the rewards records of a removed validator
+func (k Keeper) removeValidatorRewards(ctx sdk.Context, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorCurrentRewardsKey(valAddr))
+
+	iterator := sdk.KVStorePrefixIterator(store, GetValidatorHistoricalRewardsPrefix(valAddr))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) incrementReferenceCount(ctx sdk.Context, valAddr sdk.ValAddress, period int64) {
+	if period == 0 {
+		return
+	}
+	rewards := k.GetValidatorHistoricalRewards(ctx, valAddr, period)
+	rewards.ReferenceCount++
+	k.setValidatorHistoricalRewards(ctx, valAddr, period, rewards)
+}
+
+func (k Keeper) decrementReferenceCount(ctx sdk.Context, valAddr sdk.ValAddress, period int64) {
+	if period == 0 {
+		return
+	}
+	rewards := k.GetValidatorHistoricalRewards(ctx, valAddr, period)
+	rewards.ReferenceCount--
+	if rewards.ReferenceCount <= 0 {
+		ctx.KVStore(k.storeKey).Delete(GetValidatorHistoricalRewardsKey(valAddr, period))
+	} else {
+		k.setValidatorHistoricalRewards(ctx, valAddr, period, rewards)
+	}
+}
+
+// currentRewardRatio returns the cumulative rewards per share of a validator including its current period
+func (k Keeper) currentRewardRatio(ctx sdk.Context, valAddr sdk.ValAddress, current types.ValidatorCurrentRewards) sdk.Int {
+	ratio := k.GetValidatorHistoricalRewards(ctx, valAddr, current.Period-1).CumulativeRewardRatio
+	if current.Rewards == 0 {
+		return ratio
+	}
+	validator, found := k.GetValidator(ctx, valAddr)
+	if !found || validator.DelegatorShares.RawInt() <= 0 {
+		return ratio
+	}
+	return ratio.Add(sdk.NewInt(current.Rewards).Mul(types.RewardRatioPrecision).DivRaw(validator.DelegatorShares.RawInt()))
+}
+
+// incrementValidatorPeriod ends the current period of a validator and returns it. It is called before the shares of
+// the validator change.
+func (k Keeper) incrementValidatorPeriod(ctx sdk.Context, valAddr sdk.ValAddress) int64 {
+	current := k.GetValidatorCurrentRewards(ctx, valAddr)
+	ratio := k.currentRewardRatio(ctx, valAddr, current)
+
+	// the current period no longer refers to the previous one
+	k.decrementReferenceCount(ctx, valAddr, current.Period-1)
+	k.setValidatorHistoricalRewards(ctx, valAddr, current.Period, types.ValidatorHistoricalRewards{
+		CumulativeRewardRatio: ratio,
+		ReferenceCount:        1,
+	})
+	k.setValidatorCurrentRewards(ctx, valAddr, types.ValidatorCurrentRewards{Period: current.Period + 1})
+	return current.Period
+}
+
+// allocateDelegatorRewards moves the rewards of the delegators of a validator from its distribution address to the
+// reward pool, they are accumulated in the current period of the validator. The rewards go to the self-delegator if
+// the validator has no delegators anymore.
+func (k Keeper) allocateDelegatorRewards(ctx sdk.Context, validator types.Validator, amount int64) {
+	if amount <= 0 {
+		return
+	}
+	coins := sdk.Coins{sdk.NewCoin(k.BondDenom(ctx), amount)}
+	current, found := k.GetValidator(ctx, validator.OperatorAddr)
+	if !found || current.DelegatorShares.RawInt() <= 0 {
+		if _, err := k.BankKeeper.SendCoins(ctx, validator.DistributionAddr, validator.FeeAddr, coins); err != nil {
+			panic(err)
+		}
+		return
+	}
+	if _, err := k.BankKeeper.SendCoins(ctx, validator.DistributionAddr, RewardPoolAccAddr, coins); err != nil {
+		panic(err)
+	}
+	if k.AddrPool != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{RewardPoolAccAddr})
+	}
+	rewards := k.GetValidatorCurrentRewards(ctx, validator.OperatorAddr)
+	rewards.Rewards += amount
+	k.setValidatorCurrentRewards(ctx, validator.OperatorAddr, rewards)
+}
+
+// delegationStartingInfo returns the starting info of a delegation, the delegations existing at the upgrade start
+// after the period 0
+func (k Keeper) delegationStartingInfo(ctx sdk.Context, delegation types.Delegation) types.DelegatorStartingInfo {
+	info, found := k.GetDelegatorStartingInfo(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
+	if !found {
+		info = types.DelegatorStartingInfo{Shares: delegation.Shares}
+	}
+	return info
+}
+
+func delegationRewards(info types.DelegatorStartingInfo, startingRatio, endingRatio sdk.Int) int64 {
+	return endingRatio.Sub(startingRatio).MulRaw(info.Shares.RawInt()).Div(types.RewardRatioPrecision).Int64()
+}
+
+// withdrawDelegationRewards pays the rewards of a delegation since its starting period, if it exists, and ends its
+// starting info. It is called before the shares of the delegation change.
+func (k Keeper) withdrawDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) int64 {
+	endingPeriod := k.incrementValidatorPeriod(ctx, valAddr)
+	delegation, found := k.GetDelegation(ctx, delAddr, valAddr)
+	if !found {
+		return 0
+	}
+
+	info := k.delegationStartingInfo(ctx, delegation)
+	startingRatio := k.GetValidatorHistoricalRewards(ctx, valAddr, info.PreviousPeriod).CumulativeRewardRatio
+	endingRatio := k.GetValidatorHistoricalRewards(ctx, valAddr, endingPeriod).CumulativeRewardRatio
+	rewards := delegationRewards(info, startingRatio, endingRatio)
+	k.decrementReferenceCount(ctx, valAddr, info.PreviousPeriod)
+	ctx.KVStore(k.storeKey).Delete(GetDelegatorStartingInfoKey(delAddr, valAddr))
+	if rewards <= 0 {
+		return 0
+	}
+
+	recipient := delAddr
+	if delegation.CrossStake && ctx.UpgradeMgr().IsUpgrade(sdk.BEP153) {
+		recipient = types.GetStakeCAoB(delAddr.Bytes(), types.RewardCAoBSalt)
+	}
+	bondDenom := k.BondDenom(ctx)
+	if _, err := k.BankKeeper.SendCoins(ctx, RewardPoolAccAddr, recipient, sdk.Coins{sdk.NewCoin(bondDenom, rewards)}); err != nil {
+		panic(err)
+	}
+	if k.AddrPool != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{RewardPoolAccAddr, recipient})
+	}
+
+	if ctx.IsDeliverTx() && k.PbsbServer != nil {
+		chainId := ctx.SideChainId()
+		if chainId == "" {
+			chainId = types.ChainIDForBeaconChain
+		}
+		var tokens sdk.Dec
+		if validator, found := k.GetValidator(ctx, valAddr); found {
+			tokens = validator.TokensFromShares(info.Shares)
+		}
+		k.PbsbServer.Publish(types.DistributionEvent{
+			ChainId: chainId,
+			Data: []types.DistributionData{{
+				Rewards: []types.Reward{{
+					ValAddr:    valAddr,
+					AccAddr:    recipient,
+					Tokens:     tokens,
+					Amount:     rewards,
+					CrossStake: delegation.CrossStake,
+				}},
+			}},
+		})
+	}
+	return rewards
+}
+
+// initializeDelegationRewards starts the rewards of a delegation after the last ended period of its validator. It is
+// called after the shares of the delegation changed.
+func (k Keeper) initializeDelegationRewards(ctx sdk.Context, delegation types.Delegation) {
+	previousPeriod := k.GetValidatorCurrentRewards(ctx, delegation.ValidatorAddr).Period - 1
+	k.incrementReferenceCount(ctx, delegation.ValidatorAddr, previousPeriod)
+	info := types.DelegatorStartingInfo{
+		PreviousPeriod: previousPeriod,
+		Shares:         delegation.Shares,
+		Height:         ctx.BlockHeight(),
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetDelegatorStartingInfoKey(delegation.DelegatorAddr, delegation.ValidatorAddr), k.cdc.MustMarshalBinaryLengthPrefixed(info))
+}
+
+// WithdrawDelegationRewards pays the rewards accumulated by a delegation and returns their amount
+func (k Keeper) WithdrawDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (int64, sdk.Error) {
+	delegation, found := k.GetDelegation(ctx, delAddr, valAddr)
+	if !found {
+		return 0, types.ErrNoDelegation(k.Codespace())
+	}
+	rewards := k.withdrawDelegationRewards(ctx, delAddr, valAddr)
+	k.initializeDelegationRewards(ctx, delegation)
+	return rewards, nil
+}
+
+// GetDelegationRewards returns the rewards accumulated by a delegation which are not withdrawn yet
+func (k Keeper) GetDelegationRewards(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (int64, sdk.Error) {
+	delegation, found := k.GetDelegation(ctx, delAddr, valAddr)
+	if !found {
+		return 0, types.ErrNoDelegation(k.Codespace())
+	}
+	info := k.delegationStartingInfo(ctx, delegation)
+	startingRatio := k.GetValidatorHistoricalRewards(ctx, valAddr, info.PreviousPeriod).CumulativeRewardRatio
+	endingRatio := k.currentRewardRatio(ctx, valAddr, k.GetValidatorCurrentRewards(ctx, valAddr))
+	return delegationRewards(info, startingRatio, endingRatio), nil
+}
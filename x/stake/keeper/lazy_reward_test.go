@@ -0,0 +1,81 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLazyRewardDistribution(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.LazyRewardDistribution, 100)
+	defer sdk.UpgradeMgr.Reset()
+	bondDenom := keeper.BondDenom(ctx)
+	balance := func(addr sdk.AccAddress) int64 {
+		return keeper.BankKeeper.GetCoins(ctx, addr).AmountOf(bondDenom)
+	}
+	allocate := func(amount int64) {
+		validator, found := keeper.GetValidator(ctx, addrVals[0])
+		require.True(t, found)
+		_, _, err := keeper.BankKeeper.AddCoins(ctx, validator.DistributionAddr, sdk.Coins{sdk.NewCoin(bondDenom, amount)})
+		require.Nil(t, err)
+		keeper.allocateDelegatorRewards(ctx, validator, amount)
+	}
+	delegate := func(delAddr sdk.AccAddress, amount int64) {
+		validator, found := keeper.GetValidator(ctx, addrVals[0])
+		require.True(t, found)
+		_, err := keeper.Delegate(ctx, delAddr, sdk.NewCoin(bondDenom, sdk.NewDecWithoutFra(amount).RawInt()), validator, true)
+		require.Nil(t, err)
+	}
+	pendingRewards := func(delAddr sdk.AccAddress) int64 {
+		rewards, err := keeper.GetDelegationRewards(ctx, delAddr, addrVals[0])
+		require.Nil(t, err)
+		return rewards
+	}
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator.FeeAddr = addrDels[0]
+	validator.DistributionAddr = CreateTestAddr()
+	keeper.SetValidator(ctx, validator)
+	delegate(addrDels[0], 100)
+	delegate(addrDels[1], 300)
+
+	// the rewards are shared by the shares
+	allocate(4000)
+	require.EqualValues(t, 1000, pendingRewards(addrDels[0]))
+	require.EqualValues(t, 3000, pendingRewards(addrDels[1]))
+	require.EqualValues(t, 4000, balance(RewardPoolAccAddr))
+
+	// the rewards are paid when the delegation changes
+	before := balance(addrDels[1])
+	delegate(addrDels[1], 400)
+	require.EqualValues(t, before+3000-sdk.NewDecWithoutFra(400).RawInt(), balance(addrDels[1]))
+	require.EqualValues(t, 0, pendingRewards(addrDels[1]))
+
+	// the new shares earn the rewards allocated afterwards
+	allocate(8000)
+	require.EqualValues(t, 2000, pendingRewards(addrDels[0]))
+	require.EqualValues(t, 7000, pendingRewards(addrDels[1]))
+
+	before = balance(addrDels[0])
+	rewards, err := keeper.WithdrawDelegationRewards(ctx, addrDels[0], addrVals[0])
+	require.Nil(t, err)
+	require.EqualValues(t, 2000, rewards)
+	require.EqualValues(t, before+2000, balance(addrDels[0]))
+	require.EqualValues(t, 0, pendingRewards(addrDels[0]))
+
+	// removing a delegation pays its rewards, and the pool is left empty
+	delegation, found := keeper.GetDelegation(ctx, addrDels[1], addrVals[0])
+	require.True(t, found)
+	_, err = keeper.unbond(ctx, addrDels[1], addrVals[0], delegation.Shares)
+	require.Nil(t, err)
+	_, found = keeper.GetDelegatorStartingInfo(ctx, addrDels[1], addrVals[0])
+	require.False(t, found)
+	require.EqualValues(t, 0, balance(RewardPoolAccAddr))
+
+	_, err = keeper.WithdrawDelegationRewards(ctx, addrDels[1], addrVals[0])
+	require.NotNil(t, err)
+	require.Equal(t, types.ErrNoDelegation(types.DefaultCodespace).Code(), err.Code())
+}
@@ -48,6 +48,29 @@ func MigrateValidators(ctx sdk.Context, k Keeper) {
 	}
 }
 
+// MigrateValidatorMinSelfDelegation commits the validators of the native chain and of all the side chains which did
+// not commit to a minimum self delegation yet to the MinSelfDelegation param of their chain, it is run at the
+// ValidatorMinSelfDelegation upgrade
+func MigrateValidatorMinSelfDelegation(ctx sdk.Context, k Keeper) {
+	contexts := []sdk.Context{ctx}
+	if k.ScKeeper != nil {
+		_, storePrefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
+		for _, storePrefix := range storePrefixes {
+			contexts = append(contexts, ctx.WithSideChainKeyPrefix(storePrefix))
+		}
+	}
+	for _, chainCtx := range contexts {
+		minSelfDelegation := k.MinSelfDelegation(chainCtx)
+		for _, validator := range k.GetAllValidators(chainCtx) {
+			if validator.MinSelfDelegation != 0 {
+				continue
+			}
+			validator.MinSelfDelegation = minSelfDelegation
+			k.SetValidator(chainCtx, validator)
+		}
+	}
+}
+
 func MigrateWhiteLabelOracleRelayer(ctx sdk.Context, k Keeper) {
 	validators, _, found := k.GetHeightValidatorsByIndex(ctx, 1)
 	if !found {
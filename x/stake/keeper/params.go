@@ -19,6 +19,7 @@ var (
 	FeeCollectorAddr       = sdk.AccAddress(crypto.AddressHash([]byte("FeeCollector")))
 	DelegationAccAddr      = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeDelegation")))
 	FeeForAllBcValsAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeFeeForAllBcVals")))
+	RewardPoolAccAddr      = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeRewardPool")))
 )
 
 // ParamTable for stake module
@@ -79,6 +80,45 @@ func (k Keeper) FeeFromBscToBcRatio(ctx sdk.Context) (res sdk.Dec) {
 	return
 }
 
+func (k Keeper) RedelegationCooldown(ctx sdk.Context) (res int64) {
+	k.paramstore.GetIfExists(ctx, types.KeyRedelegationCooldown, &res)
+	return
+}
+
+func (k Keeper) HeartbeatTimeout(ctx sdk.Context) (res int64) {
+	k.paramstore.GetIfExists(ctx, types.KeyHeartbeatTimeout, &res)
+	return
+}
+
+func (k Keeper) MaxRedelegationEntries(ctx sdk.Context) (res int64) {
+	k.paramstore.GetIfExists(ctx, types.KeyMaxRedelegationEntries, &res)
+	return
+}
+
+func (k Keeper) EditValidatorCooldown(ctx sdk.Context) (res int64) {
+	k.paramstore.GetIfExists(ctx, types.KeyEditValidatorCooldown, &res)
+	return
+}
+
+// canUpdateBondDenom returns whether the bond denom of the chain of the context can be changed: only the side chains
+// without any validator or unbonding delegation can once SideChainStakeParams is upgraded, since the delegated tokens
+// are accounted in the bond denom.
+func (k Keeper) canUpdateBondDenom(ctx sdk.Context) bool {
+	if !ctx.UpgradeMgr().IsUpgrade(sdk.SideChainStakeParams) || ctx.SideChainKeyPrefix() == nil {
+		return false
+	}
+	store := ctx.KVStore(k.storeKey)
+	for _, prefix := range [][]byte{ValidatorsKey, UnbondingDelegationKey} {
+		iterator := sdk.KVStorePrefixIterator(store, prefix)
+		empty := !iterator.Valid()
+		iterator.Close()
+		if !empty {
+			return false
+		}
+	}
+	return true
+}
+
 // Get all parameters as types.Params
 func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	res.UnbondingTime = k.UnbondingTime(ctx)
@@ -91,6 +131,10 @@ func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	res.BonusProposerRewardRatio = k.BonusProposerRewardRatio(ctx)
 	res.MaxStakeSnapshots = k.MaxStakeSnapshots(ctx)
 	res.FeeFromBscToBcRatio = k.FeeFromBscToBcRatio(ctx)
+	res.RedelegationCooldown = k.RedelegationCooldown(ctx)
+	res.HeartbeatTimeout = k.HeartbeatTimeout(ctx)
+	res.MaxRedelegationEntries = k.MaxRedelegationEntries(ctx)
+	res.EditValidatorCooldown = k.EditValidatorCooldown(ctx)
 	return
 }
 
@@ -161,7 +205,7 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.paramstore.Set(ctx, types.KeyUnbondingTime, params.UnbondingTime)
 	k.paramstore.Set(ctx, types.KeyMaxValidators, params.MaxValidators)
 	k.paramstore.Set(ctx, types.KeyBondDenom, params.BondDenom)
-	if sdk.IsUpgrade(sdk.LaunchBscUpgrade) {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LaunchBscUpgrade) {
 		// the reason for this logic is:
 		// 1. when the testnet is set up, the config of `MaxValidators` and `MinSelfDelegation` is different from the default value in code
 		// 2. the first fix has a bug that the overwrite of the configs happens after the bsc upgrade instead of only taking effect block 1
@@ -176,13 +220,26 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 			k.paramstore.Set(ctx, types.KeyMinDelegationChange, params.MinDelegationChange)
 		}
 	}
-	if sdk.IsUpgrade(sdk.BEP128) {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP128) {
 		k.paramstore.Set(ctx, types.KeyRewardDistributionBatchSize, params.RewardDistributionBatchSize)
 	}
-	if sdk.IsUpgrade(sdk.BEP159) {
+	if ctx.UpgradeMgr().IsUpgrade(sdk.BEP159) {
 		k.paramstore.Set(ctx, types.KeyMaxStakeSnapshots, params.MaxStakeSnapshots)
 		k.paramstore.Set(ctx, types.KeyBaseProposerRewardRatio, params.BaseProposerRewardRatio)
 		k.paramstore.Set(ctx, types.KeyBonusProposerRewardRatio, params.BonusProposerRewardRatio)
 		k.paramstore.Set(ctx, types.KeyFeeFromBscToBcRatio, params.FeeFromBscToBcRatio)
 	}
+	// the cooldown is only written once set, so that the state does not change until then
+	if params.RedelegationCooldown != 0 || k.paramstore.Has(ctx, types.KeyRedelegationCooldown) {
+		k.paramstore.Set(ctx, types.KeyRedelegationCooldown, params.RedelegationCooldown)
+	}
+	if params.HeartbeatTimeout != 0 || k.paramstore.Has(ctx, types.KeyHeartbeatTimeout) {
+		k.paramstore.Set(ctx, types.KeyHeartbeatTimeout, params.HeartbeatTimeout)
+	}
+	if params.MaxRedelegationEntries != 0 || k.paramstore.Has(ctx, types.KeyMaxRedelegationEntries) {
+		k.paramstore.Set(ctx, types.KeyMaxRedelegationEntries, params.MaxRedelegationEntries)
+	}
+	if params.EditValidatorCooldown != 0 || k.paramstore.Has(ctx, types.KeyEditValidatorCooldown) {
+		k.paramstore.Set(ctx, types.KeyEditValidatorCooldown, params.EditValidatorCooldown)
+	}
 }
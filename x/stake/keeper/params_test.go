@@ -64,3 +64,29 @@ func TestSetParams(t *testing.T) {
 	require.True(t, k.paramstore.Has(ctx, types.KeyMinDelegationChange))
 	require.True(t, k.paramstore.Has(ctx, types.KeyRewardDistributionBatchSize))
 }
+
+func TestSideChainParams(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	scCtx := ctx.WithSideChainKeyPrefix([]byte{0x99})
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SideChainStakeParams, 1)
+	defer sdk.UpgradeMgr.Reset()
+
+	params := types.DefaultParams()
+	params.MaxValidators = 21
+	params.MinDelegationChange = 1e6
+	params.BondDenom = "SCBNB"
+	keeper.SetParams(scCtx, params)
+
+	// the params of a side chain are its own
+	require.EqualValues(t, 21, keeper.MaxValidators(scCtx))
+	require.EqualValues(t, 1e6, keeper.MinDelegationChange(scCtx))
+	require.Equal(t, "SCBNB", keeper.BondDenom(scCtx))
+	require.Equal(t, types.DefaultParams().MaxValidators, keeper.MaxValidators(ctx))
+	require.Equal(t, types.DefaultParams().BondDenom, keeper.BondDenom(ctx))
+
+	// the bond denom can only change on a side chain without stake
+	require.False(t, keeper.canUpdateBondDenom(ctx))
+	require.True(t, keeper.canUpdateBondDenom(scCtx))
+	keeper.SetValidator(scCtx, types.NewValidator(addrVals[0], PKs[0], types.Description{}))
+	require.False(t, keeper.canUpdateBondDenom(scCtx))
+}
@@ -100,6 +100,51 @@ func (k Keeper) GetAllRedelegations(ctx sdk.Context, delegator sdk.AccAddress) (
 	return redelegations
 }
 
+// return the validators with the given status, or all of them if status is nil, from the offset-th one and at most
+// limit of them if limit is positive. The total number of the validators with the status is returned as well.
+func (k Keeper) GetValidatorsPage(ctx sdk.Context, status *sdk.BondStatus, offset, limit int) (validators []types.Validator, total int) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorsKey)
+	defer iterator.Close()
+
+	validators = []types.Validator{}
+	for ; iterator.Valid(); iterator.Next() {
+		inPage := total >= offset && (limit <= 0 || total < offset+limit)
+		// the validators out of the page are only counted, they need no decoding without a status
+		if !inPage && status == nil {
+			total++
+			continue
+		}
+		validator := types.MustUnmarshalValidator(k.cdc, iterator.Value())
+		if status != nil && !validator.Status.Equal(*status) {
+			continue
+		}
+		if inPage {
+			validators = append(validators, validator)
+		}
+		total++
+	}
+	return validators, total
+}
+
+// return the delegations to a validator from the offset-th one and at most limit of them if limit is positive, with
+// the total number of the delegations to the validator. The delegations are read from the index by validator, which
+// is kept on the side chains, and on the native chain since BEP159.
+func (k Keeper) GetValidatorDelegationsPage(ctx sdk.Context, valAddr sdk.ValAddress, offset, limit int) (delegations []types.Delegation, total int) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, GetDelegationsKeyByVal(valAddr))
+	defer iterator.Close()
+
+	delegations = []types.Delegation{}
+	for ; iterator.Valid(); iterator.Next() {
+		if total >= offset && (limit <= 0 || total < offset+limit) {
+			delegations = append(delegations, types.MustUnmarshalDelegationValAsKey(k.cdc, iterator.Key(), iterator.Value()))
+		}
+		total++
+	}
+	return delegations, total
+}
+
 func (k Keeper) GetTopValidatorsByPower(ctx sdk.Context, maxRetrieve int) []types.Validator {
 	store := ctx.KVStore(k.storeKey)
 	validators := make([]types.Validator, maxRetrieve)
@@ -0,0 +1,66 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// RestrictRedelegation forbids redelegating away from a validator for the RedelegationCooldown
+// blocks following the block of ctx, so that its delegators can not hop to another validator
+// before the slash it is likely to get lands
+func (k Keeper) RestrictRedelegation(ctx sdk.Context, valAddr sdk.ValAddress) {
+	cooldown := k.RedelegationCooldown(ctx)
+	if cooldown <= 0 {
+		return
+	}
+	until := ctx.BlockHeight() + cooldown
+	if current, restricted := k.GetRedelegationRestriction(ctx, valAddr); restricted && current >= until {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetRedelegationRestrictionKey(valAddr), k.cdc.MustMarshalBinaryLengthPrefixed(until))
+}
+
+// GetRedelegationRestriction returns the height until which, included, redelegating away from a
+// validator is forbidden, false if it is allowed in the block of ctx
+func (k Keeper) GetRedelegationRestriction(ctx sdk.Context, valAddr sdk.ValAddress) (until int64, restricted bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetRedelegationRestrictionKey(valAddr))
+	if bz == nil {
+		return 0, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &until)
+	return until, ctx.BlockHeight() <= until
+}
+
+// CheckRedelegationRestriction returns an error if redelegating away from a validator is forbidden
+func (k Keeper) CheckRedelegationRestriction(ctx sdk.Context, valAddr sdk.ValAddress) sdk.Error {
+	if until, restricted := k.GetRedelegationRestriction(ctx, valAddr); restricted {
+		return types.ErrRedelegationRestricted(k.Codespace(), until)
+	}
+	return nil
+}
+
+//_________________________________________________________________________
+
+// SlashingHooks restricts the redelegations away from the validators likely to be slashed
+type SlashingHooks struct {
+	k Keeper
+}
+
+var _ sdk.SlashingHooks = SlashingHooks{}
+
+// SlashingHooks returns the slashing hooks of the keeper, to be set on the slashing keeper
+func (k Keeper) SlashingHooks() SlashingHooks {
+	return SlashingHooks{k}
+}
+
+// Implements sdk.SlashingHooks
+func (h SlashingHooks) OnDowntimeWarning(ctx sdk.Context, operator sdk.ValAddress) {
+	h.k.RestrictRedelegation(ctx, operator)
+}
+
+// Implements sdk.SlashingHooks
+func (h SlashingHooks) OnEvidencePending(ctx sdk.Context, operator sdk.ValAddress) {
+	h.k.RestrictRedelegation(ctx, operator)
+}
@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedelegationRestriction(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+	ctx = ctx.WithBlockHeight(10)
+	hooks := keeper.SlashingHooks()
+
+	// disabled by default
+	hooks.OnDowntimeWarning(ctx, addrVals[0])
+	require.Nil(t, keeper.CheckRedelegationRestriction(ctx, addrVals[0]))
+
+	params := keeper.GetParams(ctx)
+	params.RedelegationCooldown = 5
+	keeper.SetParams(ctx, params)
+	require.Equal(t, int64(5), keeper.GetParams(ctx).RedelegationCooldown)
+
+	hooks.OnEvidencePending(ctx, addrVals[0])
+	until, restricted := keeper.GetRedelegationRestriction(ctx, addrVals[0])
+	require.True(t, restricted)
+	require.Equal(t, int64(15), until)
+	require.NotNil(t, keeper.CheckRedelegationRestriction(ctx.WithBlockHeight(15), addrVals[0]))
+	require.Nil(t, keeper.CheckRedelegationRestriction(ctx.WithBlockHeight(16), addrVals[0]))
+	// the other validators are not restricted
+	require.Nil(t, keeper.CheckRedelegationRestriction(ctx, addrVals[1]))
+
+	// an earlier restriction does not shorten a later one
+	hooks.OnDowntimeWarning(ctx.WithBlockHeight(12), addrVals[0])
+	until, _ = keeper.GetRedelegationRestriction(ctx, addrVals[0])
+	require.Equal(t, int64(17), until)
+	keeper.RestrictRedelegation(ctx, addrVals[0])
+	until, _ = keeper.GetRedelegationRestriction(ctx, addrVals[0])
+	require.Equal(t, int64(17), until)
+}
@@ -0,0 +1,97 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// Once RestakeRewards is upgraded, a delegator can have the rewards of a delegation delegated again to its validator
+// when they are distributed, instead of receiving them. The rewards distributed in batches are delegated one by one in
+// their batch, and with the lazy reward distribution, the rewards of the restaking delegations of a validator are
+// withdrawn and delegated in the breathe block which allocates its rewards. The rewards are paid as usual if the
+// validator is jailed, and the cross chain delegations do not restake.
+
+// whether the rewards of a delegation are restaked
+func (k Keeper) IsRestaking(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetDelegationRestakeKey(delAddr, valAddr))
+}
+
+// set whether the rewards of a delegation are restaked
+func (k Keeper) SetRestaking(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, restake bool) {
+	store := ctx.KVStore(k.storeKey)
+	if restake {
+		store.Set(GetDelegationRestakeKey(delAddr, valAddr), []byte{})
+	} else {
+		store.Delete(GetDelegationRestakeKey(delAddr, valAddr))
+	}
+}
+
+// restakeReward delegates a reward distributed in a batch to its validator if its delegation restakes, the reward
+// must still be held by the distribution address of the validator. It returns whether the reward is restaked.
+func (k Keeper) restakeReward(ctx sdk.Context, sideChainId string, reward types.Reward) bool {
+	if !ctx.UpgradeMgr().IsUpgrade(sdk.RestakeRewards) || reward.CrossStake || reward.Amount <= 0 {
+		return false
+	}
+	if sideChainId != types.ChainIDForBeaconChain {
+		ctx = ctx.WithSideChainId(sideChainId)
+	}
+	if !k.IsRestaking(ctx, reward.AccAddr, reward.ValAddr) {
+		return false
+	}
+	validator, found := k.GetValidator(ctx, reward.ValAddr)
+	if !found || validator.Jailed {
+		return false
+	}
+
+	coin := sdk.NewCoin(k.BondDenom(ctx), reward.Amount)
+	if _, _, err := k.BankKeeper.AddCoins(ctx, DelegationAccAddr, sdk.Coins{coin}); err != nil {
+		panic(err)
+	}
+	if _, err := k.Delegate(ctx, reward.AccAddr, coin, validator, false); err != nil {
+		panic(err)
+	}
+	return true
+}
+
+// restakeDelegatorRewards withdraws the rewards of the restaking delegations of a validator and delegates them again
+// to the validator, with the lazy reward distribution
+func (k Keeper) restakeDelegatorRewards(ctx sdk.Context, sideChainId string, valAddr sdk.ValAddress) {
+	if !ctx.UpgradeMgr().IsUpgrade(sdk.RestakeRewards) {
+		return
+	}
+	if sideChainId != types.ChainIDForBeaconChain {
+		ctx = ctx.WithSideChainId(sideChainId)
+	}
+	if validator, found := k.GetValidator(ctx, valAddr); !found || validator.Jailed {
+		return
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	prefix := GetDelegationsRestakePrefix(valAddr)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	var delAddrs []sdk.AccAddress
+	for ; iterator.Valid(); iterator.Next() {
+		delAddrs = append(delAddrs, sdk.AccAddress(iterator.Key()[len(prefix):]))
+	}
+	iterator.Close()
+
+	bondDenom := k.BondDenom(ctx)
+	for _, delAddr := range delAddrs {
+		delegation, found := k.GetDelegation(ctx, delAddr, valAddr)
+		if !found || delegation.CrossStake {
+			continue
+		}
+		rewards, err := k.WithdrawDelegationRewards(ctx, delAddr, valAddr)
+		if err != nil {
+			panic(err)
+		}
+		if rewards <= 0 {
+			continue
+		}
+		validator := k.mustGetValidator(ctx, valAddr)
+		if _, err := k.Delegate(ctx, delAddr, sdk.NewCoin(bondDenom, rewards), validator, true); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestakeRewards(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.RestakeRewards, 100)
+	defer sdk.UpgradeMgr.Reset()
+	bondDenom := keeper.BondDenom(ctx)
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator.FeeAddr = addrDels[0]
+	validator.DistributionAddr = CreateTestAddr()
+	keeper.SetValidator(ctx, validator)
+	for _, delAddr := range addrDels[:2] {
+		validator, found := keeper.GetValidator(ctx, addrVals[0])
+		require.True(t, found)
+		_, err := keeper.Delegate(ctx, delAddr, sdk.NewCoin(bondDenom, sdk.NewDecWithoutFra(100).RawInt()), validator, true)
+		require.Nil(t, err)
+	}
+
+	keeper.SetRestaking(ctx, addrDels[1], addrVals[0], true)
+	require.True(t, keeper.IsRestaking(ctx, addrDels[1], addrVals[0]))
+	require.False(t, keeper.IsRestaking(ctx, addrDels[0], addrVals[0]))
+
+	// the reward of a delegation which does not restake is paid
+	require.False(t, keeper.restakeReward(ctx, types.ChainIDForBeaconChain, types.Reward{
+		ValAddr: addrVals[0], AccAddr: addrDels[0], Amount: 500,
+	}))
+
+	// the reward of a restaking delegation is delegated again
+	before, found := keeper.GetDelegation(ctx, addrDels[1], addrVals[0])
+	require.True(t, found)
+	require.True(t, keeper.restakeReward(ctx, types.ChainIDForBeaconChain, types.Reward{
+		ValAddr: addrVals[0], AccAddr: addrDels[1], Amount: 500,
+	}))
+	after, found := keeper.GetDelegation(ctx, addrDels[1], addrVals[0])
+	require.True(t, found)
+	require.True(t, after.Shares.GT(before.Shares))
+
+	// the reward is paid if the validator is jailed
+	validator, found = keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	validator.Jailed = true
+	keeper.SetValidator(ctx, validator)
+	require.False(t, keeper.restakeReward(ctx, types.ChainIDForBeaconChain, types.Reward{
+		ValAddr: addrVals[0], AccAddr: addrDels[1], Amount: 500,
+	}))
+
+	// the flag goes away with the delegation
+	keeper.RemoveDelegation(ctx, after)
+	require.False(t, keeper.IsRestaking(ctx, addrDels[1], addrVals[0]))
+}
+
+func TestRestakeLazyRewards(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.LazyRewardDistribution, 100)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.RestakeRewards, 100)
+	defer sdk.UpgradeMgr.Reset()
+	bondDenom := keeper.BondDenom(ctx)
+
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator.FeeAddr = addrDels[0]
+	validator.DistributionAddr = CreateTestAddr()
+	keeper.SetValidator(ctx, validator)
+	for _, delAddr := range addrDels[:2] {
+		validator, found := keeper.GetValidator(ctx, addrVals[0])
+		require.True(t, found)
+		_, err := keeper.Delegate(ctx, delAddr, sdk.NewCoin(bondDenom, sdk.NewDecWithoutFra(100).RawInt()), validator, true)
+		require.Nil(t, err)
+	}
+	keeper.SetRestaking(ctx, addrDels[1], addrVals[0], true)
+
+	validator, found := keeper.GetValidator(ctx, addrVals[0])
+	require.True(t, found)
+	_, _, err := keeper.BankKeeper.AddCoins(ctx, validator.DistributionAddr, sdk.Coins{sdk.NewCoin(bondDenom, 4000)})
+	require.Nil(t, err)
+	keeper.allocateDelegatorRewards(ctx, validator, 4000)
+
+	before, found := keeper.GetDelegation(ctx, addrDels[1], addrVals[0])
+	require.True(t, found)
+	balance := keeper.BankKeeper.GetCoins(ctx, addrDels[1]).AmountOf(bondDenom)
+	keeper.restakeDelegatorRewards(ctx, types.ChainIDForBeaconChain, addrVals[0])
+
+	// the rewards of the restaking delegation are delegated, the others are left to withdraw
+	after, found := keeper.GetDelegation(ctx, addrDels[1], addrVals[0])
+	require.True(t, found)
+	require.True(t, after.Shares.GT(before.Shares))
+	require.Equal(t, balance, keeper.BankKeeper.GetCoins(ctx, addrDels[1]).AmountOf(bondDenom))
+	rewards, err := keeper.GetDelegationRewards(ctx, addrDels[1], addrVals[0])
+	require.Nil(t, err)
+	require.EqualValues(t, 0, rewards)
+	rewards, err = keeper.GetDelegationRewards(ctx, addrDels[0], addrVals[0])
+	require.Nil(t, err)
+	require.EqualValues(t, 2000, rewards)
+}
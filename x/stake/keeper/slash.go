@@ -185,7 +185,7 @@ func (k Keeper) slashUnbondingDelegation(ctx sdk.Context, unbondingDelegation ty
 	return
 }
 
-// slash a redelegation and update the pool
+// slash a redelegation and update the pool, each of its entries in progress is slashed
 // return the amount that would have been slashed assuming
 // the unbonding delegation had enough stake to slash
 // (the amount actually slashed may be less if there's
@@ -194,41 +194,60 @@ func (k Keeper) slashUnbondingDelegation(ctx sdk.Context, unbondingDelegation ty
 func (k Keeper) slashRedelegation(ctx sdk.Context, validator types.Validator, redelegation types.Redelegation,
 	infractionHeight int64, slashFactor sdk.Dec) (slashAmount sdk.Dec) {
 
+	slashAmount = sdk.ZeroDec()
+	entries := redelegation.AllEntries()
+	updated := false
+	for i := range entries {
+		entrySlashAmount, slashed := k.slashRedelegationEntry(ctx, redelegation, &entries[i], infractionHeight, slashFactor)
+		slashAmount = slashAmount.Add(entrySlashAmount)
+		updated = updated || slashed
+	}
+
+	// Update redelegation if necessary
+	if updated {
+		k.SetRedelegation(ctx, redelegation.WithEntries(entries))
+	}
+	return slashAmount
+}
+
+// slash an entry of a redelegation, its balance is updated but not stored
+// return the amount that would have been slashed, and whether the balance changed
+func (k Keeper) slashRedelegationEntry(ctx sdk.Context, redelegation types.Redelegation, entry *types.RedelegationEntry,
+	infractionHeight int64, slashFactor sdk.Dec) (slashAmount sdk.Dec, slashed bool) {
+
 	now := ctx.BlockHeader().Time
 
 	// If redelegation started before this height, stake didn't contribute to infraction
-	if redelegation.CreationHeight < infractionHeight {
-		return sdk.ZeroDec()
+	if entry.CreationHeight < infractionHeight {
+		return sdk.ZeroDec(), false
 	}
 
-	if redelegation.MinTime.Before(now) {
+	if entry.MinTime.Before(now) {
 		// Redelegation no longer eligible for slashing, skip it
 		// TODO Delete it automatically?
-		return sdk.ZeroDec()
+		return sdk.ZeroDec(), false
 	}
 
 	// Calculate slash amount proportional to stake contributing to infraction
-	slashAmount = slashFactor.Mul(sdk.NewDec(redelegation.InitialBalance.Amount))
+	slashAmount = slashFactor.Mul(sdk.NewDec(entry.InitialBalance.Amount))
 
 	// Don't slash more tokens than held
 	// Possible since the redelegation may already
 	// have been slashed, and slash amounts are calculated
 	// according to stake held at time of infraction
-	redelegationSlashAmount := sdk.MinInt64(slashAmount.RawInt(), redelegation.Balance.Amount)
-
-	// Update redelegation if necessary
+	redelegationSlashAmount := sdk.MinInt64(slashAmount.RawInt(), entry.Balance.Amount)
 	if redelegationSlashAmount != 0 {
-		redelegation.Balance.Amount = redelegation.Balance.Amount - redelegationSlashAmount
-		k.SetRedelegation(ctx, redelegation)
+		entry.Balance.Amount = entry.Balance.Amount - redelegationSlashAmount
+		slashed = true
 	}
 
 	// Unbond from target validator
-	sharesToUnbond := slashFactor.Mul(redelegation.SharesDst)
+	sharesToUnbond := slashFactor.Mul(entry.SharesDst)
 	if !sharesToUnbond.IsZero() {
 		delegation, found := k.GetDelegation(ctx, redelegation.DelegatorAddr, redelegation.ValidatorDstAddr)
 		if !found {
 			// If deleted, delegation has zero shares, and we can't unbond any more
-			return slashAmount
+			return slashAmount, slashed
 		}
 		if sharesToUnbond.GT(delegation.Shares) {
 			sharesToUnbond = delegation.Shares
@@ -244,5 +263,5 @@ func (k Keeper) slashRedelegation(ctx sdk.Context, validator types.Validator, re
 		k.SetPool(ctx, pool)
 	}
 
-	return slashAmount
+	return slashAmount, slashed
 }
@@ -183,6 +183,63 @@ func TestSlashRedelegation(t *testing.T) {
 	require.Equal(t, sdk.NewDecWithoutFra(5), oldPool.BondedTokens.Sub(newPool.BondedTokens))
 }
 
+// tests slashRedelegation with redelegations in progress beside the first one
+func TestSlashRedelegationEntries(t *testing.T) {
+	ctx, keeper, params := setupHelper(t, 10)
+	fraction := sdk.NewDecWithPrec(5, 1)
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(0, 0)})
+
+	// the first redelegation started before the infraction, the second one after it
+	rd := types.Redelegation{
+		DelegatorAddr:    addrDels[0],
+		ValidatorSrcAddr: addrVals[0],
+		ValidatorDstAddr: addrVals[1],
+		CreationHeight:   0,
+		MinTime:          time.Unix(5, 0),
+		SharesSrc:        sdk.NewDecWithoutFra(4),
+		SharesDst:        sdk.NewDecWithoutFra(4),
+		InitialBalance:   sdk.NewCoin(params.BondDenom, sdk.NewDecWithoutFra(4).RawInt()),
+		Balance:          sdk.NewCoin(params.BondDenom, sdk.NewDecWithoutFra(4).RawInt()),
+		Entries: []types.RedelegationEntry{{
+			CreationHeight: 2,
+			MinTime:        time.Unix(10, 0),
+			SharesSrc:      sdk.NewDecWithoutFra(6),
+			SharesDst:      sdk.NewDecWithoutFra(6),
+			InitialBalance: sdk.NewCoin(params.BondDenom, sdk.NewDecWithoutFra(6).RawInt()),
+			Balance:        sdk.NewCoin(params.BondDenom, sdk.NewDecWithoutFra(6).RawInt()),
+		}},
+	}
+	keeper.SetRedelegation(ctx, rd)
+	keeper.SetDelegation(ctx, types.Delegation{
+		DelegatorAddr: addrDels[0],
+		ValidatorAddr: addrVals[1],
+		Shares:        sdk.NewDecWithoutFra(10),
+	})
+
+	// only the second one contributed to the infraction
+	validator, found := keeper.GetValidator(ctx, addrVals[1])
+	require.True(t, found)
+	slashAmount := keeper.slashRedelegation(ctx, validator, rd, 1, fraction)
+	require.Equal(t, sdk.NewDecWithoutFra(3), slashAmount)
+	rd, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithoutFra(4).RawInt(), rd.Balance.Amount)
+	require.Len(t, rd.Entries, 1)
+	require.Equal(t, sdk.NewDecWithoutFra(3).RawInt(), rd.Entries[0].Balance.Amount)
+	require.Equal(t, sdk.NewDecWithoutFra(6).RawInt(), rd.Entries[0].InitialBalance.Amount)
+	del, found := keeper.GetDelegation(ctx, addrDels[0], addrVals[1])
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithoutFra(7), del.Shares)
+
+	// both contributed to an earlier infraction
+	slashAmount = keeper.slashRedelegation(ctx, validator, rd, 0, fraction)
+	require.Equal(t, sdk.NewDecWithoutFra(5), slashAmount)
+	rd, found = keeper.GetRedelegation(ctx, addrDels[0], addrVals[0], addrVals[1])
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithoutFra(2).RawInt(), rd.Balance.Amount)
+	require.Equal(t, int64(0), rd.Entries[0].Balance.Amount)
+}
+
 // tests Slash at a future height (must panic)
 func TestSlashAtFutureHeight(t *testing.T) {
 	ctx, keeper, _ := setupHelper(t, 10)
@@ -0,0 +1,26 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Once SideChainStakeMigration is upgraded, a side chain delegator can undelegate and have the tokens transferred to
+// a BSC address at once. The recipient is kept beside the unbonding delegation, and the tokens are transferred out
+// with a cross chain package when the unbonding completes, the relay fee being paid out of them.
+
+// get the BSC recipient of the tokens of an unbonding delegation
+func (k Keeper) GetUnbondingTransfer(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (recipient sdk.SmartChainAddress, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetUnbondingTransferKey(delAddr, valAddr))
+	if bz == nil {
+		return recipient, false
+	}
+	recipient.SetBytes(bz)
+	return recipient, true
+}
+
+// set the BSC recipient of the tokens of an unbonding delegation, it is removed with the unbonding delegation
+func (k Keeper) SetUnbondingTransfer(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress, recipient sdk.SmartChainAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetUnbondingTransferKey(delAddr, valAddr), recipient[:])
+}
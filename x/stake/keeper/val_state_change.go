@@ -162,16 +162,7 @@ func (k Keeper) UpdateAndElectValidators(ctx sdk.Context) (newVals []types.Valid
 		}
 		validators = append(validators, validator)
 	}
-	sort.SliceStable(validators, func(i, j int) bool {
-		if validators[i].AccumulatedStake.GT(validators[j].AccumulatedStake) {
-			return true
-		}
-		if validators[i].AccumulatedStake.LT(validators[j].AccumulatedStake) {
-			return false
-		}
-		// for the same accumulated stake, sort by operator address
-		return bytes.Compare(validators[i].OperatorAddr, validators[j].OperatorAddr) > 0
-	})
+	sortElectionCandidates(validators)
 
 	var valsNotElected []types.Validator
 	maxValidators := int(k.MaxValidators(ctx))
@@ -26,6 +26,16 @@ func (k Keeper) GetValidator(ctx sdk.Context, addr sdk.ValAddress) (validator ty
 	return validator, true
 }
 
+// ValidatorMinSelfDelegation returns the self delegation a validator must keep not to be jailed, the larger of the
+// MinSelfDelegation param and the one the validator committed to
+func (k Keeper) ValidatorMinSelfDelegation(ctx sdk.Context, validator types.Validator) int64 {
+	minSelfDelegation := k.MinSelfDelegation(ctx)
+	if validator.MinSelfDelegation > minSelfDelegation {
+		return validator.MinSelfDelegation
+	}
+	return minSelfDelegation
+}
+
 func (k Keeper) mustGetValidator(ctx sdk.Context, addr sdk.ValAddress) types.Validator {
 	validator, found := k.GetValidator(ctx, addr)
 	if !found {
@@ -183,19 +193,19 @@ func (k Keeper) SetValidatorByPowerIndex(ctx sdk.Context, validator types.Valida
 		return
 	}
 	store := ctx.KVStore(k.storeKey)
-	store.Set(GetValidatorsByPowerIndexKey(validator), validator.OperatorAddr)
+	store.Set(GetValidatorsByPowerIndexKey(ctx, validator), validator.OperatorAddr)
 }
 
 // validator index
 func (k Keeper) DeleteValidatorByPowerIndex(ctx sdk.Context, validator types.Validator) {
 	store := ctx.KVStore(k.storeKey)
-	store.Delete(GetValidatorsByPowerIndexKey(validator))
+	store.Delete(GetValidatorsByPowerIndexKey(ctx, validator))
 }
 
 // validator index
 func (k Keeper) SetNewValidatorByPowerIndex(ctx sdk.Context, validator types.Validator) {
 	store := ctx.KVStore(k.storeKey)
-	store.Set(GetValidatorsByPowerIndexKey(validator), validator.OperatorAddr)
+	store.Set(GetValidatorsByPowerIndexKey(ctx, validator), validator.OperatorAddr)
 }
 
 func (k Keeper) SetValidatorsByHeight(ctx sdk.Context, height int64, validators []types.Validator) {
@@ -282,7 +292,13 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.ValAddress) {
 	} else {
 		store.Delete(GetValidatorByConsAddrKey(sdk.ConsAddress(validator.ConsPubKey.Address())))
 	}
-	store.Delete(GetValidatorsByPowerIndexKey(validator))
+	store.Delete(GetValidatorsByPowerIndexKey(ctx, validator))
+	store.Delete(GetValidatorLastEditKey(address))
+	k.removeAttestations(ctx, address)
+	k.RemoveHeartbeat(ctx, address)
+	if ctx.UpgradeMgr().IsUpgrade(sdk.LazyRewardDistribution) {
+		k.removeValidatorRewards(ctx, address)
+	}
 
 	// publish validator update
 	if k.PbsbServer != nil && ctx.IsDeliverTx() {
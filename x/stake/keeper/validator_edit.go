@@ -0,0 +1,35 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// Once EditValidatorCooldown is set on a chain, the validators of the chain can only be edited once per
+// EditValidatorCooldown blocks, so that they can not edit their description again and again to appear among the
+// recently updated validators. The heights of the edits are only recorded while the cooldown is set.
+
+// get the height of the last edit of a validator
+func (k Keeper) GetValidatorLastEdit(ctx sdk.Context, valAddr sdk.ValAddress) (height int64, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(GetValidatorLastEditKey(valAddr))
+	if bz == nil {
+		return 0, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &height)
+	return height, true
+}
+
+// RecordValidatorEdit checks that a validator can be edited in the block of ctx and records the edit
+func (k Keeper) RecordValidatorEdit(ctx sdk.Context, valAddr sdk.ValAddress) sdk.Error {
+	cooldown := k.EditValidatorCooldown(ctx)
+	if cooldown <= 0 {
+		return nil
+	}
+	if lastEdit, found := k.GetValidatorLastEdit(ctx, valAddr); found && ctx.BlockHeight() < lastEdit+cooldown {
+		return types.ErrEditValidatorCooldown(k.Codespace(), lastEdit, lastEdit+cooldown)
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetValidatorLastEditKey(valAddr), k.cdc.MustMarshalBinaryLengthPrefixed(ctx.BlockHeight()))
+	return nil
+}
@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 	"time"
@@ -139,7 +140,7 @@ func TestUpdateValidatorByPowerIndex(t *testing.T) {
 	require.True(t, found)
 	require.Equal(t, sdk.NewDecWithoutFra(100), validator.Tokens, "\nvalidator %v\npool %v", validator, pool)
 
-	power := GetValidatorsByPowerIndexKey(validator)
+	power := GetValidatorsByPowerIndexKey(ctx, validator)
 	require.True(t, validatorByPowerIndexExists(keeper, ctx, power))
 
 	// burn half the delegator shares
@@ -152,7 +153,7 @@ func TestUpdateValidatorByPowerIndex(t *testing.T) {
 
 	validator, found = keeper.GetValidator(ctx, addrVals[0])
 	require.True(t, found)
-	power = GetValidatorsByPowerIndexKey(validator)
+	power = GetValidatorsByPowerIndexKey(ctx, validator)
 	require.True(t, validatorByPowerIndexExists(keeper, ctx, power))
 }
 
@@ -1119,3 +1120,77 @@ func TestUpdateValidatorCommission(t *testing.T) {
 		}
 	}
 }
+
+func TestGetElectionRanking(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	params := keeper.GetParams(ctx)
+	params.MaxValidators = 2
+	keeper.SetParams(ctx, params)
+	pool := keeper.GetPool(ctx)
+
+	tokens := []int64{10, 20, 20, 30}
+	var validators [4]types.Validator
+	for i, amt := range tokens {
+		validators[i] = types.NewValidator(sdk.ValAddress(Addrs[i]), PKs[i], types.Description{})
+		validators[i], pool, _ = validators[i].AddTokensFromDel(pool, amt)
+	}
+	validators[3].Jailed = true
+	for _, validator := range validators {
+		keeper.SetValidator(ctx, validator)
+	}
+
+	// the validators with the same stake are ranked by their operator address, the lower first
+	first, second := validators[1], validators[2]
+	if bytes.Compare(first.OperatorAddr, second.OperatorAddr) > 0 {
+		first, second = second, first
+	}
+
+	candidates := keeper.GetElectionRanking(ctx)
+	require.Len(t, candidates, 4)
+	require.Equal(t, first.OperatorAddr, candidates[0].OperatorAddr)
+	require.Equal(t, 1, candidates[0].Rank)
+	require.True(t, candidates[0].Elected)
+	require.Equal(t, second.OperatorAddr, candidates[1].OperatorAddr)
+	require.True(t, candidates[1].Elected)
+
+	require.Equal(t, validators[0].OperatorAddr, candidates[2].OperatorAddr)
+	require.Equal(t, 3, candidates[2].Rank)
+	require.False(t, candidates[2].Elected)
+	require.Equal(t, types.ExclusionBelowCutoff, candidates[2].ExclusionReason)
+
+	require.Equal(t, validators[3].OperatorAddr, candidates[3].OperatorAddr)
+	require.False(t, candidates[3].Eligible)
+	require.Equal(t, 0, candidates[3].Rank)
+	require.Equal(t, types.ExclusionJailed, candidates[3].ExclusionReason)
+}
+
+func TestEditValidatorCooldown(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	valAddr := addrVals[0]
+	keeper.SetValidator(ctx, types.NewValidator(valAddr, PKs[0], types.Description{}))
+
+	// no cooldown, no record
+	require.Nil(t, keeper.RecordValidatorEdit(ctx.WithBlockHeight(10), valAddr))
+	require.Nil(t, keeper.RecordValidatorEdit(ctx.WithBlockHeight(10), valAddr))
+	_, found := keeper.GetValidatorLastEdit(ctx, valAddr)
+	require.False(t, found)
+
+	params := keeper.GetParams(ctx)
+	params.EditValidatorCooldown = 100
+	keeper.SetParams(ctx, params)
+	require.Nil(t, keeper.RecordValidatorEdit(ctx.WithBlockHeight(10), valAddr))
+	err := keeper.RecordValidatorEdit(ctx.WithBlockHeight(109), valAddr)
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeEditValidatorCooldown, err.Code())
+	require.Nil(t, keeper.RecordValidatorEdit(ctx.WithBlockHeight(110), valAddr))
+	lastEdit, found := keeper.GetValidatorLastEdit(ctx, valAddr)
+	require.True(t, found)
+	require.EqualValues(t, 110, lastEdit)
+
+	// the record goes with the validator
+	validator, found := keeper.GetValidator(ctx, valAddr)
+	require.True(t, found)
+	keeper.RemoveValidator(ctx, validator.OperatorAddr)
+	_, found = keeper.GetValidatorLastEdit(ctx, valAddr)
+	require.False(t, found)
+}
@@ -0,0 +1,167 @@
+package stake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// ModuleName is the name of the stake module in the ModuleManager
+const ModuleName = "stake"
+
+var (
+	_ sdk.AppModuleStreamingGenesis = AppModule{}
+	_ sdk.AppModuleEndBlocker       = AppModule{}
+)
+
+// AppModule registers the stake module in the ModuleManager
+type AppModule struct {
+	keeper *Keeper
+}
+
+// NewAppModule creates a new AppModule of stake
+func NewAppModule(keeper *Keeper) AppModule {
+	return AppModule{keeper: keeper}
+}
+
+// Name returns the module name
+func (AppModule) Name() string {
+	return ModuleName
+}
+
+// DefaultGenesis returns the default genesis state
+func (AppModule) DefaultGenesis() json.RawMessage {
+	return types.MsgCdc.MustMarshalJSON(types.DefaultGenesisState())
+}
+
+// InitGenesis initializes the genesis state and returns the initial validator set
+func (am AppModule) InitGenesis(ctx sdk.Context, data json.RawMessage) []abci.ValidatorUpdate {
+	var genesisState types.GenesisState
+	types.MsgCdc.MustUnmarshalJSON(data, &genesisState)
+	validators, err := InitGenesis(ctx, *am.keeper, genesisState)
+	if err != nil {
+		panic(fmt.Sprintf("failed to init stake genesis: %v", err))
+	}
+	return validators
+}
+
+// ExportGenesis exports the genesis state
+func (am AppModule) ExportGenesis(ctx sdk.Context) json.RawMessage {
+	return types.MsgCdc.MustMarshalJSON(WriteGenesis(ctx, *am.keeper))
+}
+
+// InitGenesisStream is the streaming version of InitGenesis, the delegations are set while being decoded.
+// The genesis state has the same format as the one of InitGenesis, but the bonds must be its last field.
+func (am AppModule) InitGenesisStream(ctx sdk.Context, decoder *json.Decoder) ([]abci.ValidatorUpdate, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := token.(json.Delim); !ok || d != '{' {
+		return nil, fmt.Errorf("expected stake genesis object but got %v", token)
+	}
+
+	ctx = genesisContext(ctx)
+	var genesisState types.GenesisState
+	validatorsSet := false
+	setValidators := func() error {
+		if validatorsSet {
+			return nil
+		}
+		validatorsSet = true
+		return initGenesisValidators(ctx, *am.keeper, genesisState)
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if validatorsSet {
+			return nil, fmt.Errorf("bonds must be the last field of the stake genesis, got %v", token)
+		}
+		switch token {
+		case "pool":
+			err = decodeAminoJSON(decoder, &genesisState.Pool)
+		case "params":
+			err = decodeAminoJSON(decoder, &genesisState.Params)
+		case "validators":
+			err = decodeAminoJSON(decoder, &genesisState.Validators)
+		case "bonds":
+			if err = setValidators(); err != nil {
+				return nil, err
+			}
+			err = sdk.DecodeJSONArray(decoder, func(element json.RawMessage) error {
+				var delegation types.Delegation
+				if err := types.MsgCdc.UnmarshalJSON(element, &delegation); err != nil {
+					return err
+				}
+				initGenesisDelegation(ctx, *am.keeper, delegation)
+				return nil
+			})
+		default:
+			err = fmt.Errorf("unknown stake genesis field %v", token)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	if err := setValidators(); err != nil {
+		return nil, err
+	}
+
+	_, validators := am.keeper.ApplyAndReturnValidatorSetUpdates(ctx)
+	return validators, nil
+}
+
+func decodeAminoJSON(decoder *json.Decoder, ptr interface{}) error {
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+	return types.MsgCdc.UnmarshalJSON(raw, ptr)
+}
+
+// ExportGenesisStream writes the genesis state with the delegations written one by one while iterating the store
+func (am AppModule) ExportGenesisStream(ctx sdk.Context, w io.Writer) error {
+	_, err := fmt.Fprintf(w, `{"pool":%s,"params":%s,"validators":%s,"bonds":[`,
+		types.MsgCdc.MustMarshalJSON(am.keeper.GetPool(ctx)),
+		types.MsgCdc.MustMarshalJSON(am.keeper.GetParams(ctx)),
+		types.MsgCdc.MustMarshalJSON(am.keeper.GetAllValidators(ctx)))
+	if err != nil {
+		return err
+	}
+	first := true
+	am.keeper.IterateAllDelegations(ctx, func(delegation types.Delegation) (stop bool) {
+		var bz []byte
+		bz, err = types.MsgCdc.MarshalJSON(delegation)
+		if err == nil && !first {
+			_, err = io.WriteString(w, ",")
+		}
+		if err == nil {
+			_, err = w.Write(bz)
+		}
+		first = false
+		return err != nil
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "]}")
+	return err
+}
+
+// EndBlock distributes rewards and returns the validator set updates of the block,
+// the completed unbonding delegations are published to the pubsub server of the keeper
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	validatorUpdates, completedUbds := EndBlocker(ctx, *am.keeper)
+	publishCompletedUBD(*am.keeper, completedUbds, ChainIDForBeaconChain, ctx.BlockHeight())
+	return validatorUpdates
+}
@@ -2,6 +2,8 @@ package querier
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -31,19 +33,34 @@ const (
 	QueryAllValidatorsCount            = "allValidatorsCount"
 	QueryAllUnJailValidatorsCount      = "allUnJailValidatorsCount"
 	QueryCrossStakeInfoByBscAddress    = "crossStakeInfoByBscAddress"
+	QueryDelegatorsBonds               = "delegatorsBonds"
+	QueryValidatorAttestations         = "validatorAttestations"
+	QueryElectionRanking               = "electionRanking"
+	QueryDelegationRewards             = "delegationRewards"
+	QueryValidatorDelegations          = "validatorDelegations"
+	QueryDelegatorExport               = "delegatorExport"
+	QueryDelegatorExports              = "delegatorExports"
+	QueryHistoricalValidators          = "historical-validators"
+	QueryDelegatorPosition             = "delegatorPosition"
 )
 
+// MaxDelegatorsPerBondsQuery is the max number of delegators of a 'custom/stake/delegatorsBonds' query
+const MaxDelegatorsPerBondsQuery = 100
+
+// MaxResultsPerPage is the max number of results of a page of a paginated query
+const MaxResultsPerPage = 100
+
 // creates a querier for staking REST endpoints
 func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
 		switch path[0] {
 		case QueryValidators:
-			p := new(BaseParams)
+			p := new(QueryValidatorsParams)
 			ctx, err = RequestPrepare(ctx, k, req, p)
 			if err != nil {
 				return res, err
 			}
-			return queryValidators(ctx, cdc, k)
+			return queryValidators(ctx, cdc, p, k)
 		case QueryValidator:
 			p := new(QueryValidatorParams)
 			ctx, err = RequestPrepare(ctx, k, req, p)
@@ -65,6 +82,34 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryValidatorRedelegations(ctx, cdc, p, k)
+		case QueryValidatorDelegations:
+			p := new(QueryValDelegationsParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryValidatorDelegations(ctx, cdc, p, k)
+		case QueryHistoricalValidators:
+			p := new(QueryHistoricalValidatorsParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryHistoricalValidators(ctx, cdc, p, k)
+		case QueryValidatorAttestations:
+			p := new(QueryValidatorParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryValidatorAttestations(ctx, cdc, p, k)
+		case QueryElectionRanking:
+			p := new(BaseParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryElectionRanking(ctx, cdc, k)
 		case QueryDelegation:
 			p := new(QueryBondsParams)
 			ctx, err = RequestPrepare(ctx, k, req, p)
@@ -72,6 +117,13 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryDelegation(ctx, cdc, p, k)
+		case QueryDelegationRewards:
+			p := new(QueryBondsParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryDelegationRewards(ctx, cdc, p, k)
 		case QueryRedelegation:
 			p := new(QueryRedelegationParams)
 			ctx, err = RequestPrepare(ctx, k, req, p)
@@ -100,6 +152,27 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryDelegatorUnbondingDelegations(ctx, cdc, p, k)
+		case QueryDelegatorExport:
+			p := new(QueryDelegatorParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryDelegatorExport(ctx, cdc, p, k)
+		case QueryDelegatorPosition:
+			p := new(QueryDelegatorParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryDelegatorPosition(ctx, cdc, p, k)
+		case QueryDelegatorExports:
+			p := new(QueryDelegatorExportsParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryDelegatorExports(ctx, cdc, p, k)
 		case QueryDelegatorRedelegations:
 			p := new(QueryDelegatorParams)
 			ctx, err = RequestPrepare(ctx, k, req, p)
@@ -163,6 +236,13 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryCrossStakeInfoByBscAddress(ctx, cdc, p, k)
+		case QueryDelegatorsBonds:
+			p := new(QueryDelegatorsParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryDelegatorsBonds(ctx, cdc, p, k)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown stake query endpoint")
 		}
@@ -213,10 +293,86 @@ type QueryDelegatorParams struct {
 	DelegatorAddr sdk.AccAddress
 }
 
+// defines the params for 'custom/stake/delegatorExports', the exports start from StartDelegator and there are at
+// most Limit of them, which defaults to MaxResultsPerPage
+type QueryDelegatorExportsParams struct {
+	BaseParams
+	StartDelegator sdk.AccAddress
+	Limit          int
+}
+
+// defines the params for 'custom/stake/delegatorsBonds'
+type QueryDelegatorsParams struct {
+	BaseParams
+	DelegatorAddrs []sdk.AccAddress
+}
+
+// PageParams selects a page of the results of a query, the results are paginated if Page or Limit is set
+type PageParams struct {
+	Page  int // 1-based page number
+	Limit int // results per page, defaults to MaxResultsPerPage
+}
+
+// Paginated tells whether the results are to be paginated
+func (p PageParams) Paginated() bool {
+	return p.Page > 0 || p.Limit > 0
+}
+
+// bounds returns the offset of the first result of the page and the number of results per page
+func (p PageParams) bounds() (offset, limit int) {
+	limit = p.Limit
+	if limit <= 0 || limit > MaxResultsPerPage {
+		limit = MaxResultsPerPage
+	}
+	if p.Page > 1 {
+		offset = (p.Page - 1) * limit
+	}
+	return offset, limit
+}
+
+// defines the params for 'custom/stake/validators', the validators are filtered by Status if it is set, one of
+// "bonded", "unbonding" and "unbonded"
+type QueryValidatorsParams struct {
+	BaseParams
+	PageParams
+	Status string
+}
+
+func (p QueryValidatorsParams) bondStatus() (*sdk.BondStatus, sdk.Error) {
+	var status sdk.BondStatus
+	switch strings.ToLower(p.Status) {
+	case "":
+		return nil, nil
+	case "bonded":
+		status = sdk.Bonded
+	case "unbonding":
+		status = sdk.Unbonding
+	case "unbonded":
+		status = sdk.Unbonded
+	default:
+		return nil, sdk.ErrUnknownRequest(fmt.Sprintf("invalid validator status %q", p.Status))
+	}
+	return &status, nil
+}
+
+// defines the params for 'custom/stake/historical-validators'
+type QueryHistoricalValidatorsParams struct {
+	BaseParams
+	Height int64
+}
+
+// defines the params for 'custom/stake/validatorDelegations'
+type QueryValDelegationsParams struct {
+	BaseParams
+	PageParams
+	ValidatorAddr sdk.ValAddress
+}
+
 // defines the params for the following queries:
 // - 'custom/stake/validator'
 // - 'custom/stake/validatorUnbondingDelegations'
 // - 'custom/stake/validatorRedelegations'
+// - 'custom/stake/validatorAttestations'
 type QueryValidatorParams struct {
 	BaseParams
 	ValidatorAddr sdk.ValAddress
@@ -251,12 +407,62 @@ type QueryCrossStakeInfoParams struct {
 	BscAddress sdk.SmartChainAddress
 }
 
-func queryValidators(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
-	stakeParams := k.GetParams(ctx)
-	validators := k.GetValidators(ctx, stakeParams.MaxValidators)
+func queryValidators(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorsParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	status, err := params.bondStatus()
+	if err != nil {
+		return nil, err
+	}
 
-	res, errRes := codec.MarshalJSONIndent(cdc, validators)
+	var result interface{}
+	if params.Paginated() {
+		page := types.PagedValidators{}
+		offset, limit := params.bounds()
+		page.Validators, page.Total = k.GetValidatorsPage(ctx, status, offset, limit)
+		result = page
+	} else if status != nil {
+		result, _ = k.GetValidatorsPage(ctx, status, 0, 0)
+	} else {
+		stakeParams := k.GetParams(ctx)
+		result = k.GetValidators(ctx, stakeParams.MaxValidators)
+	}
+
+	res, errRes := codec.MarshalJSONIndent(cdc, result)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryHistoricalValidators(ctx sdk.Context, cdc *codec.Codec, params *QueryHistoricalValidatorsParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	set, found := k.GetHistoricalValidatorSet(ctx, params.Height)
+	if !found {
+		return nil, types.ErrNoHistoricalValidatorSet(k.Codespace(), params.Height)
+	}
+
+	res, errRes := codec.MarshalJSONIndent(cdc, set)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryValidatorDelegations(ctx sdk.Context, cdc *codec.Codec, params *QueryValDelegationsParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	var offset, limit int
+	if params.Paginated() {
+		offset, limit = params.bounds()
+	}
+	delegations, total := k.GetValidatorDelegationsPage(ctx, params.ValidatorAddr, offset, limit)
+	delResponses, err := delegationsToDelegationResponses(ctx, k, delegations)
 	if err != nil {
+		return res, err
+	}
+
+	var result interface{} = delResponses
+	if params.Paginated() {
+		result = types.PagedDelegations{Delegations: delResponses, Total: total}
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, result)
+	if errRes != nil {
 		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
 	}
 	return res, nil
@@ -276,6 +482,28 @@ func queryValidator(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorPar
 	return res, nil
 }
 
+func queryValidatorAttestations(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorParams, k keep.Keeper) (res []byte, err sdk.Error) {
+
+	attestations := k.GetAttestations(ctx, params.ValidatorAddr)
+
+	res, errRes := codec.MarshalJSONIndent(cdc, attestations)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryElectionRanking(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
+
+	candidates := k.GetElectionRanking(ctx)
+
+	res, errRes := codec.MarshalJSONIndent(cdc, candidates)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
 func queryValidatorUnbondingDelegations(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorParams, k keep.Keeper) (res []byte, err sdk.Error) {
 
 	unbonds := k.GetUnbondingDelegationsFromValidator(ctx, params.ValidatorAddr)
@@ -311,6 +539,49 @@ func queryDelegatorDelegations(ctx sdk.Context, cdc *codec.Codec, params *QueryD
 	return res, nil
 }
 
+func queryDelegatorExport(ctx sdk.Context, cdc *codec.Codec, params *QueryDelegatorParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	export := k.ExportDelegator(ctx, params.DelegatorAddr)
+
+	res, errRes := codec.MarshalJSONIndent(cdc, export)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+// queryDelegatorPosition answers the stake of a delegator on all the chains, whatever the side chain of the params
+func queryDelegatorPosition(ctx sdk.Context, cdc *codec.Codec, params *QueryDelegatorParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	position := k.GetDelegatorPosition(ctx, params.DelegatorAddr)
+
+	res, errRes := codec.MarshalJSONIndent(cdc, position)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryDelegatorExports(ctx sdk.Context, cdc *codec.Codec, params *QueryDelegatorExportsParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	limit := params.Limit
+	if limit <= 0 || limit > MaxResultsPerPage {
+		limit = MaxResultsPerPage
+	}
+	exports := types.DelegatorExports{Exports: []types.DelegatorExport{}}
+	k.IterateDelegatorExports(ctx, params.StartDelegator, func(export types.DelegatorExport) bool {
+		if len(exports.Exports) == limit {
+			exports.NextDelegator = export.DelegatorAddr
+			return true
+		}
+		exports.Exports = append(exports.Exports, export)
+		return false
+	})
+
+	res, errRes := codec.MarshalJSONIndent(cdc, exports)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
 func queryDelegatorUnbondingDelegations(ctx sdk.Context, cdc *codec.Codec, params *QueryDelegatorParams, k keep.Keeper) (res []byte, err sdk.Error) {
 	unbondingDelegations := k.GetAllUnbondingDelegations(ctx, params.DelegatorAddr)
 
@@ -374,6 +645,19 @@ func queryDelegation(ctx sdk.Context, cdc *codec.Codec, params *QueryBondsParams
 	return res, nil
 }
 
+func queryDelegationRewards(ctx sdk.Context, cdc *codec.Codec, params *QueryBondsParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	rewards, err := k.GetDelegationRewards(ctx, params.DelegatorAddr, params.ValidatorAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	res, errRes := codec.MarshalJSONIndent(cdc, sdk.NewCoin(k.BondDenom(ctx), rewards))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
 func queryRedelegation(ctx sdk.Context, cdc *codec.Codec, params *QueryRedelegationParams, k keep.Keeper) (res []byte, err sdk.Error) {
 	redelegation, found := k.GetRedelegation(ctx, params.DelegatorAddr, params.ValSrcAddr, params.ValDstAddr)
 	if !found {
@@ -477,6 +761,33 @@ func queryCrossStakeInfoByBscAddress(ctx sdk.Context, cdc *codec.Codec, params *
 	return res, nil
 }
 
+// queryDelegatorsBonds returns the delegations and the unbonding delegations of a list of delegators,
+// so that the bonds of many accounts can be fetched without a query per account
+func queryDelegatorsBonds(ctx sdk.Context, cdc *codec.Codec, params *QueryDelegatorsParams, k keep.Keeper) (res []byte, err sdk.Error) {
+	if len(params.DelegatorAddrs) == 0 || len(params.DelegatorAddrs) > MaxDelegatorsPerBondsQuery {
+		return []byte{}, sdk.ErrInternal(fmt.Sprintf("the number of delegators must be between 1 and %d", MaxDelegatorsPerBondsQuery))
+	}
+
+	bonds := make([]types.DelegatorBondsResponse, len(params.DelegatorAddrs))
+	for i, delAddr := range params.DelegatorAddrs {
+		delResponses, err := delegationsToDelegationResponses(ctx, k, k.GetAllDelegatorDelegations(ctx, delAddr))
+		if err != nil {
+			return res, err
+		}
+		bonds[i] = types.DelegatorBondsResponse{
+			DelegatorAddr:        delAddr,
+			Delegations:          delResponses,
+			UnbondingDelegations: k.GetAllUnbondingDelegations(ctx, delAddr),
+		}
+	}
+
+	res, errRes := codec.MarshalJSONIndent(cdc, bonds)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
 func prepareSideChainCtx(ctx sdk.Context, k keep.Keeper, sideChainId string) (sdk.Context, sdk.Error) {
 	scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
 	if err != nil {
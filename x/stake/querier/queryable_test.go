@@ -150,7 +150,7 @@ func TestQueryValidators(t *testing.T) {
 	// Query Validators
 	queriedValidators := keeper.GetValidators(ctx, params.MaxValidators)
 
-	res, err := queryValidators(ctx, cdc, keeper)
+	res, err := queryValidators(ctx, cdc, new(QueryValidatorsParams), keeper)
 	require.Nil(t, err)
 
 	var validatorsResp []types.Validator
@@ -182,6 +182,81 @@ func TestQueryValidators(t *testing.T) {
 	require.Equal(t, queriedValidators[0], validator)
 }
 
+func TestQueryValidatorsPage(t *testing.T) {
+	cdc := codec.New()
+	ctx, _, keeper := keep.CreateTestInput(t, false, 10000)
+	for i := 0; i < 5; i++ {
+		validator := types.NewValidator(sdk.ValAddress(keep.Addrs[i]), keep.PKs[i], types.Description{})
+		if i%2 == 0 {
+			validator.Status = sdk.Bonded
+		}
+		keeper.SetValidator(ctx, validator)
+	}
+	querier := NewQuerier(keeper, cdc)
+	query := func(params QueryValidatorsParams) []byte {
+		bz, errRes := json.Marshal(params)
+		require.Nil(t, errRes)
+		res, err := querier(ctx, []string{QueryValidators}, abci.RequestQuery{Data: bz})
+		require.Nil(t, err)
+		return res
+	}
+
+	var page types.PagedValidators
+	require.Nil(t, cdc.UnmarshalJSON(query(QueryValidatorsParams{PageParams: PageParams{Page: 2, Limit: 2}}), &page))
+	require.Equal(t, 5, page.Total)
+	require.Len(t, page.Validators, 2)
+
+	require.Nil(t, cdc.UnmarshalJSON(query(QueryValidatorsParams{PageParams: PageParams{Page: 2, Limit: 2}, Status: "bonded"}), &page))
+	require.Equal(t, 3, page.Total)
+	require.Len(t, page.Validators, 1)
+	require.Equal(t, sdk.Bonded, page.Validators[0].Status)
+
+	// the status alone filters without paginating
+	var validators []types.Validator
+	require.Nil(t, cdc.UnmarshalJSON(query(QueryValidatorsParams{Status: "Unbonded"}), &validators))
+	require.Len(t, validators, 2)
+
+	bz, errRes := json.Marshal(QueryValidatorsParams{Status: "jailed"})
+	require.Nil(t, errRes)
+	_, err := querier(ctx, []string{QueryValidators}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+}
+
+func TestQueryValidatorDelegations(t *testing.T) {
+	cdc := codec.New()
+	ctx, _, keeper := keep.CreateTestInput(t, false, 10000)
+	validator := types.NewValidator(addrVal1, pk1, types.Description{})
+	keeper.SetValidator(ctx, validator)
+	// the delegations by validator are indexed on the side chains
+	scCtx := ctx.WithSideChainId("bsc")
+	for i := 0; i < 3; i++ {
+		keeper.SetDelegation(scCtx, types.Delegation{
+			DelegatorAddr: keep.Addrs[i],
+			ValidatorAddr: addrVal1,
+			Shares:        sdk.NewDecWithoutFra(int64(i + 1)),
+		})
+	}
+	querier := NewQuerier(keeper, cdc)
+	query := func(params QueryValDelegationsParams) []byte {
+		bz, errRes := json.Marshal(params)
+		require.Nil(t, errRes)
+		res, err := querier(ctx, []string{QueryValidatorDelegations}, abci.RequestQuery{Data: bz})
+		require.Nil(t, err)
+		return res
+	}
+
+	var delegations []types.DelegationResponse
+	require.Nil(t, cdc.UnmarshalJSON(query(QueryValDelegationsParams{ValidatorAddr: addrVal1}), &delegations))
+	require.Len(t, delegations, 3)
+
+	var page types.PagedDelegations
+	require.Nil(t, cdc.UnmarshalJSON(query(QueryValDelegationsParams{ValidatorAddr: addrVal1, PageParams: PageParams{Limit: 2}}), &page))
+	require.Equal(t, 3, page.Total)
+	require.Equal(t, delegations[:2], page.Delegations)
+	require.Nil(t, cdc.UnmarshalJSON(query(QueryValDelegationsParams{ValidatorAddr: addrVal1, PageParams: PageParams{Page: 2, Limit: 2}}), &page))
+	require.Equal(t, delegations[2:], page.Delegations)
+}
+
 func TestQueryDelegation(t *testing.T) {
 	cdc := codec.New()
 	ctx, _, keeper := keep.CreateTestInput(t, false, 10000)
@@ -415,3 +490,55 @@ func TestQueryRedelegations(t *testing.T) {
 
 	require.Equal(t, redelegation, redsRes[0])
 }
+
+func TestQueryDelegatorsBonds(t *testing.T) {
+	cdc := codec.New()
+	ctx, _, keeper := keep.CreateTestInput(t, false, 10000)
+
+	val1 := types.NewValidator(addrVal1, pk1, types.Description{})
+	keeper.SetValidator(ctx, val1)
+	keeper.SetValidatorByPowerIndex(ctx, val1)
+
+	keeper.Delegate(ctx, addrAcc2, sdk.NewCoin("steak", sdk.NewDecWithoutFra(20).RawInt()), val1, true)
+	keeper.ApplyAndReturnValidatorSetUpdates(ctx)
+	_, err := keeper.BeginUnbonding(ctx, addrAcc2, val1.OperatorAddr, sdk.NewDec(sdk.NewDecWithoutFra(10).RawInt()))
+	require.Nil(t, err)
+
+	querier := NewQuerier(keeper, cdc)
+	queryParams := QueryDelegatorsParams{DelegatorAddrs: []sdk.AccAddress{addrAcc1, addrAcc2}}
+	bz, errRes := json.Marshal(queryParams)
+	require.Nil(t, errRes)
+
+	res, err := querier(ctx, []string{QueryDelegatorsBonds}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+
+	var bonds []types.DelegatorBondsResponse
+	require.Nil(t, cdc.UnmarshalJSON(res, &bonds))
+	require.Len(t, bonds, 2)
+	require.Equal(t, addrAcc1, bonds[0].DelegatorAddr)
+	require.Empty(t, bonds[0].Delegations)
+	require.Empty(t, bonds[0].UnbondingDelegations)
+
+	require.Equal(t, addrAcc2, bonds[1].DelegatorAddr)
+	require.Len(t, bonds[1].Delegations, 1)
+	require.Equal(t, addrVal1, bonds[1].Delegations[0].ValidatorAddr)
+	unbond, found := keeper.GetUnbondingDelegation(ctx, addrAcc2, addrVal1)
+	require.True(t, found)
+	require.Equal(t, []types.UnbondingDelegation{unbond}, bonds[1].UnbondingDelegations)
+
+	// the number of delegators is bounded
+	queryParams.DelegatorAddrs = make([]sdk.AccAddress, MaxDelegatorsPerBondsQuery+1)
+	for i := range queryParams.DelegatorAddrs {
+		queryParams.DelegatorAddrs[i] = addrAcc1
+	}
+	bz, errRes = json.Marshal(queryParams)
+	require.Nil(t, errRes)
+	_, err = querier(ctx, []string{QueryDelegatorsBonds}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+
+	queryParams.DelegatorAddrs = nil
+	bz, errRes = json.Marshal(queryParams)
+	require.Nil(t, errRes)
+	_, err = querier(ctx, []string{QueryDelegatorsBonds}, abci.RequestQuery{Data: bz})
+	require.NotNil(t, err)
+}
@@ -26,13 +26,32 @@ type (
 	MsgBeginUnbonding          = types.MsgBeginUnbonding
 	MsgRedelegate              = types.MsgRedelegate
 	MsgUndelegate              = types.MsgUndelegate
+	MsgSetAttestation          = types.MsgSetAttestation
+	MsgRecheckAttestation      = types.MsgRecheckAttestation
+	MsgSideChainHeartbeat      = types.MsgSideChainHeartbeat
+	Attestation                = types.Attestation
+	ElectionCandidate          = types.ElectionCandidate
 	GenesisState               = types.GenesisState
 	QueryDelegatorParams       = querier.QueryDelegatorParams
+	QueryDelegatorsParams      = querier.QueryDelegatorsParams
+	DelegatorBondsResponse     = types.DelegatorBondsResponse
 	QueryValidatorParams       = querier.QueryValidatorParams
 	QueryBondsParams           = querier.QueryBondsParams
 	QueryCrossStakeInfoParams  = querier.QueryCrossStakeInfoParams
 	CreateValidatorJsonMsg     = types.CreateValidatorJsonMsg
+	ForceUndelegationSetting   = types.ForceUndelegationSetting
+	KeyLossEvidence            = types.KeyLossEvidence
+	KeyLossStatement           = types.KeyLossStatement
 	QueryTopValidatorsParams   = querier.QueryTopValidatorsParams
+	QueryValidatorsParams      = querier.QueryValidatorsParams
+	QueryValDelegationsParams  = querier.QueryValDelegationsParams
+	PageParams                 = querier.PageParams
+	PagedValidators            = types.PagedValidators
+	PagedDelegations           = types.PagedDelegations
+	DelegatorExport            = types.DelegatorExport
+	DelegatorExports           = types.DelegatorExports
+	DelegatorPosition          = types.DelegatorPosition
+	ChainPosition              = types.ChainPosition
 	BaseParams                 = querier.BaseParams
 
 	MsgCreateSideChainValidator = types.MsgCreateSideChainValidator
@@ -40,6 +59,10 @@ type (
 	MsgSideChainDelegate        = types.MsgSideChainDelegate
 	MsgSideChainRedelegate      = types.MsgSideChainRedelegate
 	MsgSideChainUndelegate      = types.MsgSideChainUndelegate
+	MsgSideChainStakeMigration  = types.MsgSideChainStakeMigration
+
+	HistoricalValidatorSet          = types.HistoricalValidatorSet
+	QueryHistoricalValidatorsParams = querier.QueryHistoricalValidatorsParams
 
 	DistributionEvent      = types.DistributionEvent
 	DistributionData       = types.DistributionData
@@ -93,6 +116,7 @@ var (
 	MigratePowerRankKey              = keeper.MigratePowerRankKey
 	MigrateValidatorDistributionAddr = keeper.MigrateValidators
 	MigrateWhiteLabelOracleRelayer   = keeper.MigrateWhiteLabelOracleRelayer
+	MigrateMinSelfDelegation         = keeper.MigrateValidatorMinSelfDelegation
 
 	DefaultParamspace = keeper.DefaultParamspace
 	KeyUnbondingTime  = types.KeyUnbondingTime
@@ -119,7 +143,13 @@ var (
 	NewMsgEditValidator             = types.NewMsgEditValidator
 	NewMsgDelegate                  = types.NewMsgDelegate
 	NewMsgUndelegate                = types.NewMsgUndelegate
+	NewMsgCancelUnbondingDelegation = types.NewMsgCancelUnbondingDelegation
+	NewMsgWithdrawDelegatorReward   = types.NewMsgWithdrawDelegatorReward
+	NewMsgSetRestake                = types.NewMsgSetRestake
 	NewMsgRedelegate                = types.NewMsgRedelegate
+	NewMsgSetAttestation            = types.NewMsgSetAttestation
+	NewMsgRecheckAttestation        = types.NewMsgRecheckAttestation
+	NewMsgSideChainHeartbeat        = types.NewMsgSideChainHeartbeat
 
 	NewMsgCreateSideChainValidator           = types.NewMsgCreateSideChainValidator
 	NewMsgCreateSideChainValidatorOnBehalfOf = types.NewMsgCreateSideChainValidatorOnBehalfOf
@@ -127,6 +157,7 @@ var (
 	NewMsgSideChainDelegate                  = types.NewMsgSideChainDelegate
 	NewMsgSideChainRedelegate                = types.NewMsgSideChainRedelegate
 	NewMsgSideChainUndelegate                = types.NewMsgSideChainUndelegate
+	NewMsgSideChainStakeMigration            = types.NewMsgSideChainStakeMigration
 
 	NewQuerier    = querier.NewQuerier
 	NewBaseParams = querier.NewBaseParams
@@ -141,6 +172,8 @@ const (
 	QueryValidator                     = querier.QueryValidator
 	QueryValidatorUnbondingDelegations = querier.QueryValidatorUnbondingDelegations
 	QueryValidatorRedelegations        = querier.QueryValidatorRedelegations
+	QueryValidatorAttestations         = querier.QueryValidatorAttestations
+	QueryElectionRanking               = querier.QueryElectionRanking
 	QueryDelegation                    = querier.QueryDelegation
 	QueryUnbondingDelegation           = querier.QueryUnbondingDelegation
 	QueryDelegatorDelegations          = querier.QueryDelegatorDelegations
@@ -151,6 +184,10 @@ const (
 	QueryPool                          = querier.QueryPool
 	QueryParameters                    = querier.QueryParameters
 	QueryCrossStakeInfo                = querier.QueryCrossStakeInfoByBscAddress
+	QueryDelegatorsBonds               = querier.QueryDelegatorsBonds
+	QueryDelegatorPosition             = querier.QueryDelegatorPosition
+
+	MaxDelegatorsPerBondsQuery = querier.MaxDelegatorsPerBondsQuery
 
 	Topic = types.Topic
 )
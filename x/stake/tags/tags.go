@@ -13,4 +13,11 @@ var (
 	Moniker      = "moniker"
 	Identity     = "identity"
 	EndTime      = "end-time"
+	Recipient    = "recipient"
+
+	AttestationType   = "attestation-type"
+	AttestationStatus = "attestation-status"
+
+	SideChainId     = "side-chain-id"
+	HeartbeatHeight = "heartbeat-height"
 )
@@ -0,0 +1,182 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// AttestationType is the kind of off-chain proof binding an identity of a validator description
+// to its operator address
+type AttestationType string
+
+const (
+	// the proof is a keybase-style signed statement published by the identity
+	AttestationTypeKeybase AttestationType = "keybase"
+	// the proof is the content of a DNS TXT record of the website domain
+	AttestationTypeDNS AttestationType = "dns"
+)
+
+func (t AttestationType) IsValid() bool {
+	return t == AttestationTypeKeybase || t == AttestationTypeDNS
+}
+
+// AttestationStatus is the result of the last verification of an attestation
+type AttestationStatus byte
+
+const (
+	AttestationUnverified AttestationStatus = 0x00
+	AttestationVerified   AttestationStatus = 0x01
+	AttestationFailed     AttestationStatus = 0x02
+)
+
+func (s AttestationStatus) String() string {
+	switch s {
+	case AttestationUnverified:
+		return "Unverified"
+	case AttestationVerified:
+		return "Verified"
+	case AttestationFailed:
+		return "Failed"
+	default:
+		return ""
+	}
+}
+
+const (
+	MaxAttestationTargetLength = 140
+	MaxAttestationProofLength  = 3000
+)
+
+// Attestation is the record of an off-chain proof of an identity of a validator, e.g. the
+// keybase username or the website domain of its description. The proof itself stays off-chain,
+// only its hash is committed by the operator, and the status is maintained by the re-checks.
+type Attestation struct {
+	Type          AttestationType   `json:"type"`
+	Target        string            `json:"target"`     // the attested identity, e.g. a keybase username or a domain
+	ProofHash     []byte            `json:"proof_hash"` // sha256 of the proof content
+	Status        AttestationStatus `json:"status"`
+	CheckedHeight int64             `json:"checked_height"` // height of the last re-check, 0 if never checked
+}
+
+// VerifyAttestationProof checks that proof is the content committed by the attestation and that
+// it names the operator, so that a proof can not be reused by another validator
+func VerifyAttestationProof(attestation Attestation, operator sdk.ValAddress, proof string) bool {
+	hash := sha256.Sum256([]byte(proof))
+	return bytes.Equal(hash[:], attestation.ProofHash) && strings.Contains(proof, operator.String())
+}
+
+//______________________________________________________________________
+
+// MsgSetAttestation - struct for attaching an attestation to a validator, an empty proof hash
+// removes the attestation of the type
+type MsgSetAttestation struct {
+	ValidatorAddr   sdk.ValAddress  `json:"validator_address"`
+	AttestationType AttestationType `json:"type"`
+	Target          string          `json:"target"`
+	ProofHash       []byte          `json:"proof_hash"`
+}
+
+func NewMsgSetAttestation(valAddr sdk.ValAddress, attestationType AttestationType, target string, proofHash []byte) MsgSetAttestation {
+	return MsgSetAttestation{
+		ValidatorAddr:   valAddr,
+		AttestationType: attestationType,
+		Target:          target,
+		ProofHash:       proofHash,
+	}
+}
+
+//nolint
+func (msg MsgSetAttestation) Route() string { return MsgRoute }
+func (msg MsgSetAttestation) Type() string  { return "set_attestation" }
+func (msg MsgSetAttestation) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSetAttestation) GetSignBytes() []byte {
+	b := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgSetAttestation) ValidateBasic() sdk.Error {
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	if !msg.AttestationType.IsValid() {
+		return ErrInvalidAttestation(DefaultCodespace, fmt.Sprintf("unknown attestation type %q", msg.AttestationType))
+	}
+	if len(msg.ProofHash) == 0 {
+		return nil
+	}
+	if len(msg.ProofHash) != sha256.Size {
+		return ErrInvalidAttestation(DefaultCodespace, fmt.Sprintf("proof hash should be %d bytes", sha256.Size))
+	}
+	if len(msg.Target) == 0 || len(msg.Target) > MaxAttestationTargetLength {
+		return ErrDescriptionLength(DefaultCodespace, "attestation target", len(msg.Target), MaxAttestationTargetLength)
+	}
+	return nil
+}
+
+func (msg MsgSetAttestation) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+//______________________________________________________________________
+
+// MsgRecheckAttestation - struct for re-checking an attestation against its proof. The proof is
+// fetched off-chain by the sender, typically a service querying the attestations, anyone can
+// send it.
+type MsgRecheckAttestation struct {
+	SenderAddr      sdk.AccAddress  `json:"sender_address"`
+	ValidatorAddr   sdk.ValAddress  `json:"validator_address"`
+	AttestationType AttestationType `json:"type"`
+	Proof           string          `json:"proof"`
+}
+
+func NewMsgRecheckAttestation(sender sdk.AccAddress, valAddr sdk.ValAddress, attestationType AttestationType, proof string) MsgRecheckAttestation {
+	return MsgRecheckAttestation{
+		SenderAddr:      sender,
+		ValidatorAddr:   valAddr,
+		AttestationType: attestationType,
+		Proof:           proof,
+	}
+}
+
+//nolint
+func (msg MsgRecheckAttestation) Route() string { return MsgRoute }
+func (msg MsgRecheckAttestation) Type() string  { return "recheck_attestation" }
+func (msg MsgRecheckAttestation) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.SenderAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgRecheckAttestation) GetSignBytes() []byte {
+	b := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgRecheckAttestation) ValidateBasic() sdk.Error {
+	if len(msg.SenderAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("sender address length should be %d", sdk.AddrLen))
+	}
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	if !msg.AttestationType.IsValid() {
+		return ErrInvalidAttestation(DefaultCodespace, fmt.Sprintf("unknown attestation type %q", msg.AttestationType))
+	}
+	if len(msg.Proof) > MaxAttestationProofLength {
+		return ErrDescriptionLength(DefaultCodespace, "attestation proof", len(msg.Proof), MaxAttestationProofLength)
+	}
+	return nil
+}
+
+func (msg MsgRecheckAttestation) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.SenderAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
@@ -15,12 +15,19 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgBeginUnbonding{}, "cosmos-sdk/MsgBeginUnbonding", nil)
 	cdc.RegisterConcrete(MsgRedelegate{}, "cosmos-sdk/MsgRedelegate", nil)
 	cdc.RegisterConcrete(MsgUndelegate{}, "cosmos-sdk/MsgUndelegate", nil)
+	cdc.RegisterConcrete(MsgCancelUnbondingDelegation{}, "cosmos-sdk/MsgCancelUnbondingDelegation", nil)
+	cdc.RegisterConcrete(MsgWithdrawDelegatorReward{}, "cosmos-sdk/MsgWithdrawDelegatorReward", nil)
+	cdc.RegisterConcrete(MsgSetRestake{}, "cosmos-sdk/MsgSetRestake", nil)
+	cdc.RegisterConcrete(MsgSetAttestation{}, "cosmos-sdk/MsgSetAttestation", nil)
+	cdc.RegisterConcrete(MsgRecheckAttestation{}, "cosmos-sdk/MsgRecheckAttestation", nil)
+	cdc.RegisterConcrete(MsgSideChainHeartbeat{}, "cosmos-sdk/MsgSideChainHeartbeat", nil)
 
 	cdc.RegisterConcrete(MsgCreateSideChainValidator{}, "cosmos-sdk/MsgCreateSideChainValidator", nil)
 	cdc.RegisterConcrete(MsgEditSideChainValidator{}, "cosmos-sdk/MsgEditSideChainValidator", nil)
 	cdc.RegisterConcrete(MsgSideChainDelegate{}, "cosmos-sdk/MsgSideChainDelegate", nil)
 	cdc.RegisterConcrete(MsgSideChainRedelegate{}, "cosmos-sdk/MsgSideChainRedelegate", nil)
 	cdc.RegisterConcrete(MsgSideChainUndelegate{}, "cosmos-sdk/MsgSideChainUndelegate", nil)
+	cdc.RegisterConcrete(MsgSideChainStakeMigration{}, "cosmos-sdk/MsgSideChainStakeMigration", nil)
 
 	cdc.RegisterConcrete(&Params{}, "params/StakeParamSet", nil)
 }
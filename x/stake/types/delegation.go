@@ -248,7 +248,7 @@ func (d UnbondingDelegation) HumanReadableString() (string, error) {
 	resp += fmt.Sprintf("Validator: %s\n", d.ValidatorAddr)
 	resp += fmt.Sprintf("Creation height: %v\n", d.CreationHeight)
 	resp += fmt.Sprintf("Min time to unbond (unix): %v\n", d.MinTime)
-	resp += fmt.Sprintf("Expected balance: %s\n", d.Balance.String())
+	resp += fmt.Sprintf("Expected balance: %s\n", sdk.FormatCoin(d.Balance))
 	resp += fmt.Sprintf("Cross stake: %t", d.CrossStake)
 
 	return resp, nil
@@ -266,8 +266,52 @@ type Redelegation struct {
 	Balance          sdk.Coin       `json:"balance"`            // current balance
 	SharesSrc        sdk.Dec        `json:"shares_src"`         // amount of source shares redelegating
 	SharesDst        sdk.Dec        `json:"shares_dst"`         // amount of destination shares redelegating
+
+	// the redelegations started while this one was in progress, by creation, at most MaxRedelegationEntries-1
+	Entries []RedelegationEntry `json:"entries,omitempty"`
+}
+
+// RedelegationEntry is a redelegation in progress of a delegator from a validator to another, beside the first one
+type RedelegationEntry struct {
+	CreationHeight int64     `json:"creation_height"` // height which the redelegation took place
+	MinTime        time.Time `json:"min_time"`        // unix time for redelegation completion
+	InitialBalance sdk.Coin  `json:"initial_balance"` // initial balance when redelegation started
+	Balance        sdk.Coin  `json:"balance"`         // current balance
+	SharesSrc      sdk.Dec   `json:"shares_src"`      // amount of source shares redelegating
+	SharesDst      sdk.Dec   `json:"shares_dst"`      // amount of destination shares redelegating
 }
 
+// AllEntries returns the redelegations in progress, the first one included, by creation
+func (d Redelegation) AllEntries() []RedelegationEntry {
+	entries := make([]RedelegationEntry, 0, 1+len(d.Entries))
+	entries = append(entries, RedelegationEntry{
+		CreationHeight: d.CreationHeight,
+		MinTime:        d.MinTime,
+		InitialBalance: d.InitialBalance,
+		Balance:        d.Balance,
+		SharesSrc:      d.SharesSrc,
+		SharesDst:      d.SharesDst,
+	})
+	return append(entries, d.Entries...)
+}
+
+// WithEntries returns the redelegation with the redelegations in progress replaced by entries, which must not be empty
+func (d Redelegation) WithEntries(entries []RedelegationEntry) Redelegation {
+	first := entries[0]
+	d.CreationHeight = first.CreationHeight
+	d.MinTime = first.MinTime
+	d.InitialBalance = first.InitialBalance
+	d.Balance = first.Balance
+	d.SharesSrc = first.SharesSrc
+	d.SharesDst = first.SharesDst
+	d.Entries = nil
+	if len(entries) > 1 {
+		d.Entries = append([]RedelegationEntry{}, entries[1:]...)
+	}
+	return d
+}
+
+// the entries are appended, so that the encoding of the redelegations without them does not change
 type redValue struct {
 	CreationHeight int64
 	MinTime        time.Time
@@ -275,6 +319,7 @@ type redValue struct {
 	Balance        sdk.Coin
 	SharesSrc      sdk.Dec
 	SharesDst      sdk.Dec
+	Entries        []RedelegationEntry
 }
 
 // return the redelegation without fields contained within the key for the store
@@ -286,6 +331,7 @@ func MustMarshalRED(cdc *codec.Codec, red Redelegation) []byte {
 		red.Balance,
 		red.SharesSrc,
 		red.SharesDst,
+		red.Entries,
 	}
 	return cdc.MustMarshalBinaryLengthPrefixed(val)
 }
@@ -326,6 +372,7 @@ func UnmarshalRED(cdc *codec.Codec, key, value []byte) (red Redelegation, err er
 		Balance:          storeValue.Balance,
 		SharesSrc:        storeValue.SharesSrc,
 		SharesDst:        storeValue.SharesDst,
+		Entries:          storeValue.Entries,
 	}, nil
 }
 
@@ -348,7 +395,11 @@ func (d Redelegation) HumanReadableString() (string, error) {
 	resp += fmt.Sprintf("Min time to unbond (unix): %v\n", d.MinTime)
 	resp += fmt.Sprintf("Source shares: %s\n", d.SharesSrc.String())
 	resp += fmt.Sprintf("Destination shares: %s\n", d.SharesDst.String())
-	resp += fmt.Sprintf("Balance: %s", d.Balance.String())
+	resp += fmt.Sprintf("Balance: %s", sdk.FormatCoin(d.Balance))
+	for i, entry := range d.Entries {
+		resp += fmt.Sprintf("\nEntry %d: creation height %d, min time %v, balance %s, source shares %s, destination shares %s",
+			i+1, entry.CreationHeight, entry.MinTime, sdk.FormatCoin(entry.Balance), entry.SharesSrc, entry.SharesDst)
+	}
 
 	return resp, nil
 
@@ -378,7 +429,20 @@ func (dr DelegationResponse) HumanReadableString() (string, error) {
 	resp := "Delegation \n"
 	resp += fmt.Sprintf("Delegator: %s\n", dr.DelegatorAddr)
 	resp += fmt.Sprintf("Validator: %s\n", dr.ValidatorAddr)
-	resp += fmt.Sprintf("Balance: %s", dr.Balance.String())
+	resp += fmt.Sprintf("Balance: %s", sdk.FormatCoin(dr.Balance))
 
 	return resp, nil
 }
+
+// PagedDelegations is a page of the delegations to a validator, Total is the number of all of them
+type PagedDelegations struct {
+	Delegations []DelegationResponse `json:"delegations"`
+	Total       int                  `json:"total"`
+}
+
+// DelegatorBondsResponse contains the delegations and the unbonding delegations of a delegator
+type DelegatorBondsResponse struct {
+	DelegatorAddr        sdk.AccAddress        `json:"delegator_addr"`
+	Delegations          []DelegationResponse  `json:"delegations"`
+	UnbondingDelegations []UnbondingDelegation `json:"unbonding_delegations"`
+}
@@ -80,6 +80,7 @@ func TestUnbondingDelegationHumanReadableString(t *testing.T) {
 	ud := UnbondingDelegation{
 		DelegatorAddr: sdk.AccAddress(addr1),
 		ValidatorAddr: addr2,
+		Balance:       sdk.NewCoin(sdk.NativeTokenSymbol, 150000000),
 		CrossStake:    true,
 	}
 
@@ -89,6 +90,7 @@ func TestUnbondingDelegationHumanReadableString(t *testing.T) {
 	fmt.Println(valStr)
 	require.Nil(t, err)
 	require.NotEmpty(t, valStr)
+	require.Contains(t, valStr, "Expected balance: 1.5:BNB\n")
 }
 
 func TestRedelegationEqual(t *testing.T) {
@@ -121,6 +123,7 @@ func TestRedelegationHumanReadableString(t *testing.T) {
 		ValidatorDstAddr: addr3,
 		SharesDst:        sdk.NewDec(10),
 		SharesSrc:        sdk.NewDec(20),
+		Balance:          sdk.NewCoin(sdk.NativeTokenSymbol, 250000000),
 	}
 
 	// NOTE: Being that the validator's keypair is random, we cannot test the
@@ -129,4 +132,5 @@ func TestRedelegationHumanReadableString(t *testing.T) {
 	fmt.Println(valStr)
 	require.Nil(t, err)
 	require.NotEmpty(t, valStr)
+	require.Contains(t, valStr, "Balance: 2.5:BNB")
 }
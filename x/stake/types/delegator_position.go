@@ -0,0 +1,26 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DelegatorPosition is the staking balance of a delegator on the native chain and all the side chains at a height.
+// The totals are coins since the side chains can bond other denoms than the native chain.
+type DelegatorPosition struct {
+	DelegatorAddr sdk.AccAddress  `json:"delegator_addr"`
+	Height        int64           `json:"height"`
+	Bonded        sdk.Coins       `json:"bonded"`
+	Unbonding     sdk.Coins       `json:"unbonding"`
+	Rewards       sdk.Coins       `json:"rewards"`
+	Chains        []ChainPosition `json:"chains"`
+}
+
+// ChainPosition is the staking balance of a delegator on a chain, Rewards are the rewards it accumulated which are
+// not paid yet
+type ChainPosition struct {
+	ChainId   string `json:"chain_id"`
+	BondDenom string `json:"bond_denom"`
+	Bonded    int64  `json:"bonded"`
+	Unbonding int64  `json:"unbonding"`
+	Rewards   int64  `json:"rewards"`
+}
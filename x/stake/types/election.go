@@ -0,0 +1,34 @@
+package types
+
+import (
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// reasons for which a validator is not elected
+const (
+	ExclusionJailed      = "jailed"
+	ExclusionNoStake     = "no stake"
+	ExclusionBelowCutoff = "below cutoff"
+)
+
+// ElectionCandidate explains the ranking of a validator in the election of the validator set.
+// The eligible candidates are ranked by Stake, then by TieBreaker, the higher first, and the
+// first MaxValidators of them are elected.
+type ElectionCandidate struct {
+	OperatorAddr sdk.ValAddress `json:"operator_address"`
+	// the accumulated stake over the snapshots, or the tokens without snapshot election
+	Stake sdk.Dec `json:"stake"`
+	// the operator address, bitwise inverted without snapshot election so that the lower
+	// addresses rank first as in the power store
+	TieBreaker      cmn.HexBytes `json:"tie_breaker"`
+	Jailed          bool         `json:"jailed"`
+	Eligible        bool         `json:"eligible"`
+	Rank            int          `json:"rank"` // from 1 among the eligible candidates, 0 if not eligible
+	Elected         bool         `json:"elected"`
+	ExclusionReason string       `json:"exclusion_reason,omitempty"`
+	// the number of blocks since the last heartbeat of a side chain validator, for the elections weighing
+	// the liveness of the BSC signers, 0 if it never sent one. It does not change the ranking.
+	HeartbeatAbsence int64 `json:"heartbeat_absence,omitempty"`
+}
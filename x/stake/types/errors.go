@@ -25,12 +25,24 @@ const (
 	CodeCrossStakingNoBalance        CodeType = 110
 	CodeCrossStakingNotEnoughBalance CodeType = 111
 	CodeInvalidConsAddrUpdateTime    CodeType = 112
+	CodeInvalidAttestation           CodeType = 113
+	CodeInvalidHeartbeat             CodeType = 114
+	CodeEditValidatorCooldown        CodeType = 115
 	CodeInvalidAddress               CodeType = sdk.CodeInvalidAddress
 	CodeUnauthorized                 CodeType = sdk.CodeUnauthorized
 	CodeInternal                     CodeType = sdk.CodeInternal
 	CodeUnknownRequest               CodeType = sdk.CodeUnknownRequest
 )
 
+func init() {
+	sdk.RegisterCodespace(DefaultCodespace, "stake",
+		CodeInvalidValidator, CodeInvalidDelegation, CodeInvalidInput, CodeValidatorJailed,
+		CodeInvalidProposal, CodeInvalidSideChain, CodeInvalidCrossChainPackage,
+		CodeDeserializePackageFailed, CodeExpiredCrossStakeSyncPackage, CodeCrossStakingNoBalance,
+		CodeCrossStakingNotEnoughBalance, CodeInvalidConsAddrUpdateTime, CodeInvalidAttestation,
+		CodeInvalidHeartbeat, CodeEditValidatorCooldown, CodeInvalidAddress, CodeUnauthorized, CodeInternal, CodeUnknownRequest)
+}
+
 // validator
 func ErrNilValidatorAddr(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidInput, "validator address is nil")
@@ -52,10 +64,28 @@ func ErrNoValidatorFound(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "validator does not exist for that address")
 }
 
+func ErrNoHistoricalValidatorSet(codespace sdk.CodespaceType, height int64) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator, fmt.Sprintf("no validator set is kept for height %d", height))
+}
+
 func ErrEditConsensusKeyBeforeBEP159(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeUnauthorized, "side consensus address cannot be updated before BEP159")
 }
 
+func ErrMinSelfDelegationBeforeUpgrade(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeUnauthorized, "minimum self delegation cannot be set before the ValidatorMinSelfDelegation upgrade")
+}
+
+func ErrMinSelfDelegationDecreased(codespace sdk.CodespaceType, current int64) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator,
+		fmt.Sprintf("minimum self delegation can only be increased, it is %d", current))
+}
+
+func ErrSelfDelegationBelowMinSelfDelegation(codespace sdk.CodespaceType, selfDelegation int64) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidValidator,
+		fmt.Sprintf("minimum self delegation must not be greater than the self delegation %d", selfDelegation))
+}
+
 func ErrValidatorOwnerExists(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "validator already exist for this operator address, must use new validator operator address")
 }
@@ -187,6 +217,10 @@ func ErrExistingUnbondingDelegation(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidDelegation, "existing unbonding delegation found")
 }
 
+func ErrBadCancelUnbonding(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation, fmt.Sprintf("unbonding delegation cannot be canceled: %s", msg))
+}
+
 func ErrBadRedelegationAddr(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidInput, "unexpected address length for this (address, srcValidator, dstValidator) tuple")
 }
@@ -221,6 +255,16 @@ func ErrConflictingRedelegation(codespace sdk.CodespaceType) sdk.Error {
 		"conflicting redelegation from this source validator to this dest validator already exists, you must wait for it to finish")
 }
 
+func ErrMaxRedelegationEntries(codespace sdk.CodespaceType, maxEntries int64) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation,
+		fmt.Sprintf("too many redelegations from this source validator to this dest validator in progress, at most %d are allowed, you must wait for one to finish", maxEntries))
+}
+
+func ErrRedelegationRestricted(codespace sdk.CodespaceType, until int64) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation,
+		fmt.Sprintf("redelegating away from this validator is forbidden up to height %d, it is likely to be slashed", until))
+}
+
 func ErrBothShareMsgsGiven(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidInput, "both shares amount and shares percent provided")
 }
@@ -265,6 +309,23 @@ func ErrNotSelfDelegate(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeInvalidValidator, "only self delegate is allowed")
 }
 
+func ErrEditValidatorCooldown(codespace sdk.CodespaceType, lastEdit, nextEdit int64) sdk.Error {
+	return sdk.NewError(codespace, CodeEditValidatorCooldown,
+		fmt.Sprintf("validator was edited at height %d, it cannot be edited again before height %d", lastEdit, nextEdit))
+}
+
 func ErrConsAddrUpdateTime() sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidConsAddrUpdateTime, "ConsAddr cannot be changed more than once in 30 days")
 }
+
+func ErrInvalidAttestation(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAttestation, msg)
+}
+
+func ErrNoAttestationFound(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAttestation, "attestation does not exist for that validator and type")
+}
+
+func ErrInvalidHeartbeat(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidHeartbeat, msg)
+}
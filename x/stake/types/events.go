@@ -11,16 +11,23 @@ const (
 
 	EventTypeCrossStake        = "cross_stake"
 	EventTypeTotalDistribution = "total_distribution"
+	EventTypeHeartbeatMissing  = "heartbeat_missing"
+	EventTypeCommissionChange  = "commission_change"
 
 	AttributeKeyValidator         = "validator"
 	AttributeKeyCommissionRate    = "commission_rate"
+	AttributeKeyPreviousRate      = "previous_commission_rate"
+	AttributeKeyMaxRate           = "commission_max_rate"
+	AttributeKeyMaxChangeRate     = "commission_max_change_rate"
 	AttributeKeyMinSelfDelegation = "min_self_delegation"
 	AttributeKeySrcValidator      = "source_validator"
 	AttributeKeyDstValidator      = "destination_validator"
 	AttributeKeyDelegator         = "delegator"
 	AttributeKeyCompletionTime    = "completion_time"
 
-	AttributeKeySideChainId = "side_chain_id"
+	AttributeKeySideChainId     = "side_chain_id"
+	AttributeKeyLastHeartbeat   = "last_heartbeat_height"
+	AttributeKeyHeartbeatAbsent = "heartbeat_absent_blocks"
 
 	AttributeKeyRewardSum = "reward_sum"
 )
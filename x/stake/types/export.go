@@ -0,0 +1,54 @@
+package types
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// DelegatorExport is the staking state of a delegator on a chain at a height, as exported for the migration of the
+// balances to BSC. The delegations and the unbonding delegations are sorted by validator, as they are stored.
+type DelegatorExport struct {
+	ChainId              string                        `json:"chain_id"`
+	Height               int64                         `json:"height"`
+	DelegatorAddr        sdk.AccAddress                `json:"delegator_addr"`
+	Delegations          []ExportedDelegation          `json:"delegations"`
+	UnbondingDelegations []ExportedUnbondingDelegation `json:"unbonding_delegations"`
+}
+
+// ExportedDelegation is a delegation of an exported delegator, Rewards are the rewards it accumulated which are not
+// paid yet
+type ExportedDelegation struct {
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Shares        sdk.Dec        `json:"shares"`
+	Tokens        int64          `json:"tokens"`
+	Rewards       int64          `json:"rewards"`
+	CrossStake    bool           `json:"cross_stake"`
+}
+
+// ExportedUnbondingDelegation is an unbonding delegation of an exported delegator
+type ExportedUnbondingDelegation struct {
+	ValidatorAddr  sdk.ValAddress `json:"validator_addr"`
+	CreationHeight int64          `json:"creation_height"`
+	MinTime        time.Time      `json:"min_time"`
+	Balance        int64          `json:"balance"`
+	CrossStake     bool           `json:"cross_stake"`
+}
+
+// CanonicalBytes returns the canonical encoding of the export, the sorted JSON of its fields
+func (e DelegatorExport) CanonicalBytes() []byte {
+	return sdk.MustSortJSON(MsgCdc.MustMarshalJSON(e))
+}
+
+// Hash returns the hash of the canonical encoding of the export
+func (e DelegatorExport) Hash() []byte {
+	return tmhash.Sum(e.CanonicalBytes())
+}
+
+// DelegatorExports is a page of the exports of all the delegators, sorted by delegator address. NextDelegator is the
+// first delegator of the next page, empty after the last page.
+type DelegatorExports struct {
+	Exports       []DelegatorExport `json:"exports"`
+	NextDelegator sdk.AccAddress    `json:"next_delegator"`
+}
@@ -0,0 +1,81 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// KeyLossStatementText is the statement signed by the evidence of a force undelegation proposal
+const KeyLossStatementText = "the operator key of this validator is lost or compromised"
+
+// KeyLossStatement is the message signed by the evidence of a force undelegation proposal, bound to a chain and
+// a validator
+type KeyLossStatement struct {
+	ChainID   string         `json:"chain_id"`
+	Validator sdk.ValAddress `json:"validator"`
+	Statement string         `json:"statement"`
+}
+
+func NewKeyLossStatement(chainID string, validator sdk.ValAddress) KeyLossStatement {
+	return KeyLossStatement{
+		ChainID:   chainID,
+		Validator: validator,
+		Statement: KeyLossStatementText,
+	}
+}
+
+// GetSignBytes returns the bytes signed by the evidence
+func (statement KeyLossStatement) GetSignBytes() []byte {
+	return sdk.MustSortJSON(MsgCdc.MustMarshalJSON(statement))
+}
+
+// KeyLossEvidence proves that the operator key of a validator is lost or compromised. It is the signature of the
+// KeyLossStatement either by the operator key itself, which only an attacker holding it would produce, or by the
+// consensus key of the validator, which is still held by whoever runs the validator once the operator key is lost.
+type KeyLossEvidence struct {
+	PubKey    crypto.PubKey `json:"pub_key"`
+	Signature []byte        `json:"signature"`
+}
+
+// ForceUndelegationSetting is the description of a ForceUndelegation proposal: once it passes, all the delegations
+// to the validator start unbonding
+type ForceUndelegationSetting struct {
+	ValidatorAddr sdk.ValAddress  `json:"validator_address"`
+	Evidence      KeyLossEvidence `json:"evidence"`
+}
+
+func (setting ForceUndelegationSetting) Check() error {
+	if len(setting.ValidatorAddr) != sdk.AddrLen {
+		return fmt.Errorf("expected validator address length is %d, actual length is %d", sdk.AddrLen, len(setting.ValidatorAddr))
+	}
+	if setting.Evidence.PubKey == nil {
+		return errors.New("the evidence has no pub key")
+	}
+	if len(setting.Evidence.Signature) == 0 {
+		return errors.New("the evidence has no signature")
+	}
+	return nil
+}
+
+// Verify checks that the evidence of setting is a valid KeyLossEvidence of validator on chain chainID
+func (setting ForceUndelegationSetting) Verify(chainID string, validator Validator) error {
+	if !setting.ValidatorAddr.Equals(validator.OperatorAddr) {
+		return fmt.Errorf("the validator %s is not the one of the setting", validator.OperatorAddr)
+	}
+	pubKey := setting.Evidence.PubKey
+	operatorKey := bytes.Equal(pubKey.Address(), validator.OperatorAddr)
+	consensusKey := validator.ConsPubKey != nil && pubKey.Equals(validator.ConsPubKey)
+	if !operatorKey && !consensusKey {
+		return errors.New("the evidence is signed by neither the operator key nor the consensus key of the validator")
+	}
+	statement := NewKeyLossStatement(chainID, validator.OperatorAddr)
+	if !pubKey.VerifyBytes(statement.GetSignBytes(), setting.Evidence.Signature) {
+		return errors.New("invalid signature of the evidence")
+	}
+	return nil
+}
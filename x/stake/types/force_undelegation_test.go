@@ -0,0 +1,58 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestForceUndelegationSettingVerify(t *testing.T) {
+	operatorKey := secp256k1.GenPrivKey()
+	consensusKey := ed25519.GenPrivKey()
+	otherKey := secp256k1.GenPrivKey()
+	valAddr := sdk.ValAddress(operatorKey.PubKey().Address())
+	validator := NewValidator(valAddr, consensusKey.PubKey(), Description{})
+	chainID := "test-chain"
+
+	sign := func(key crypto.PrivKey, chainID string) ForceUndelegationSetting {
+		signature, err := key.Sign(NewKeyLossStatement(chainID, valAddr).GetSignBytes())
+		require.Nil(t, err)
+		return ForceUndelegationSetting{
+			ValidatorAddr: valAddr,
+			Evidence:      KeyLossEvidence{PubKey: key.PubKey(), Signature: signature},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		setting    ForceUndelegationSetting
+		expectPass bool
+	}{
+		{"signed by the operator key", sign(operatorKey, chainID), true},
+		{"signed by the consensus key", sign(consensusKey, chainID), true},
+		{"signed by another key", sign(otherKey, chainID), false},
+		{"signed for another chain", sign(operatorKey, "other-chain"), false},
+	}
+	for _, tc := range tests {
+		require.Nil(t, tc.setting.Check(), "test: %v", tc.name)
+		bz := MsgCdc.MustMarshalJSON(tc.setting)
+		var setting ForceUndelegationSetting
+		require.Nil(t, MsgCdc.UnmarshalJSON(bz, &setting), "test: %v", tc.name)
+		if tc.expectPass {
+			require.Nil(t, setting.Verify(chainID, validator), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, setting.Verify(chainID, validator), "test: %v", tc.name)
+		}
+	}
+
+	// the evidence is bound to its validator
+	otherValidator := NewValidator(sdk.ValAddress(otherKey.PubKey().Address()), consensusKey.PubKey(), Description{})
+	require.NotNil(t, sign(consensusKey, chainID).Verify(chainID, otherValidator))
+
+	require.NotNil(t, ForceUndelegationSetting{ValidatorAddr: valAddr}.Check())
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain/types"
+)
+
+const (
+	MsgTypeSideChainHeartbeat = "side_heartbeat"
+
+	// the length of an eth-style [R || S || V] signature
+	HeartbeatSignatureLength = 65
+	// the number of blocks a heartbeat is accepted for after its height
+	MaxHeartbeatDelay = 100
+)
+
+// prefix of the signed hash of a heartbeat, so that the BSC consensus key is not tricked into signing anything else
+var heartbeatSignPrefix = []byte("BNB Beacon Chain validator heartbeat")
+
+// Heartbeat is the record of the last heartbeat of a side chain validator
+type Heartbeat struct {
+	Height         int64 `json:"height"`          // the signed height
+	ReceivedHeight int64 `json:"received_height"` // the height the heartbeat was included at
+}
+
+// HeartbeatSignHash returns the hash signed by the BSC consensus key of a side chain validator for a heartbeat,
+// it commits to the chain so that a heartbeat can not be replayed on another chain
+func HeartbeatSignHash(chainId, sideChainId string, valAddr sdk.ValAddress, height int64) []byte {
+	heightBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBz, uint64(height))
+	return bsc.Keccak256(heartbeatSignPrefix, []byte(chainId), []byte{0}, []byte(sideChainId), []byte{0}, valAddr.Bytes(), heightBz)
+}
+
+// VerifyHeartbeatSignature checks that signature is the signature of the heartbeat by the BSC consensus
+// address sideConsAddr
+func VerifyHeartbeatSignature(chainId, sideChainId string, valAddr sdk.ValAddress, height int64, signature []byte, sideConsAddr []byte) bool {
+	if len(signature) != HeartbeatSignatureLength {
+		return false
+	}
+	pubKey, err := secp256k1.RecoverPubkey(HeartbeatSignHash(chainId, sideChainId, valAddr, height), signature)
+	if err != nil || len(pubKey) == 0 {
+		return false
+	}
+	return bytes.Equal(bsc.Keccak256(pubKey[1:])[12:], sideConsAddr)
+}
+
+//______________________________________________________________________
+
+// MsgSideChainHeartbeat - struct for recording the heartbeat of a side chain validator, signed by its BSC
+// consensus key. The heartbeat is relayed by the sender, typically the operator or a monitoring service,
+// anyone can send it.
+type MsgSideChainHeartbeat struct {
+	SenderAddr    sdk.AccAddress `json:"sender_address"`
+	SideChainId   string         `json:"side_chain_id"`
+	ValidatorAddr sdk.ValAddress `json:"validator_address"`
+	Height        int64          `json:"height"`
+	Signature     []byte         `json:"signature"`
+}
+
+func NewMsgSideChainHeartbeat(sender sdk.AccAddress, sideChainId string, valAddr sdk.ValAddress, height int64, signature []byte) MsgSideChainHeartbeat {
+	return MsgSideChainHeartbeat{
+		SenderAddr:    sender,
+		SideChainId:   sideChainId,
+		ValidatorAddr: valAddr,
+		Height:        height,
+		Signature:     signature,
+	}
+}
+
+//nolint
+func (msg MsgSideChainHeartbeat) Route() string { return MsgRoute }
+func (msg MsgSideChainHeartbeat) Type() string  { return MsgTypeSideChainHeartbeat }
+func (msg MsgSideChainHeartbeat) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.SenderAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSideChainHeartbeat) GetSignBytes() []byte {
+	b := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgSideChainHeartbeat) ValidateBasic() sdk.Error {
+	if len(msg.SenderAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("sender address length should be %d", sdk.AddrLen))
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id must be included and max length is 20 bytes")
+	}
+	if msg.ValidatorAddr == nil {
+		return ErrNilValidatorAddr(DefaultCodespace)
+	}
+	if msg.Height <= 0 {
+		return ErrInvalidHeartbeat(DefaultCodespace, "heartbeat height should be positive")
+	}
+	if len(msg.Signature) != HeartbeatSignatureLength {
+		return ErrInvalidHeartbeat(DefaultCodespace, fmt.Sprintf("heartbeat signature should be %d bytes", HeartbeatSignatureLength))
+	}
+	return nil
+}
+
+func (msg MsgSideChainHeartbeat) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.SenderAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+func (msg MsgSideChainHeartbeat) GetSideChainId() string {
+	return msg.SideChainId
+}
@@ -0,0 +1,20 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// HistoricalValidator is a validator of a historical validator set, ConsAddr is the consensus address of the
+// validator on its chain, the side chain consensus address for the side chain validators
+type HistoricalValidator struct {
+	OperatorAddr sdk.ValAddress `json:"operator_address"`
+	ConsAddr     []byte         `json:"cons_addr"`
+	Power        int64          `json:"power"`
+}
+
+// HistoricalValidatorSet is the validator set elected in the breathe block at Height, it is the validator set of its
+// chain until the next breathe block
+type HistoricalValidatorSet struct {
+	Height     int64                 `json:"height"`
+	Validators []HistoricalValidator `json:"validators"`
+}
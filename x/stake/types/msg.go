@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sidechain "github.com/cosmos/cosmos-sdk/x/sidechain/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 )
@@ -25,6 +26,9 @@ type MsgCreateValidator struct {
 	ValidatorAddr sdk.ValAddress `json:"validator_address"`
 	PubKey        crypto.PubKey  `json:"pubkey"`
 	Delegation    sdk.Coin       `json:"delegation"`
+
+	// the self delegation the validator commits to keep at least, 0 for the MinSelfDelegation param
+	MinSelfDelegation int64 `json:"min_self_delegation,omitempty"`
 }
 
 type CreateValidatorJsonMsg struct {
@@ -34,6 +38,8 @@ type CreateValidatorJsonMsg struct {
 	ValidatorAddr sdk.ValAddress `json:"validator_address"`
 	PubKey        []byte         `json:"pubkey"`
 	Delegation    sdk.Coin       `json:"delegation"`
+
+	MinSelfDelegation int64 `json:"min_self_delegation,omitempty"`
 }
 
 func (jsonMsg CreateValidatorJsonMsg) ToMsgCreateValidator() (MsgCreateValidator, error) {
@@ -51,6 +57,8 @@ func (jsonMsg CreateValidatorJsonMsg) ToMsgCreateValidator() (MsgCreateValidator
 		ValidatorAddr: jsonMsg.ValidatorAddr,
 		PubKey:        pubkey,
 		Delegation:    jsonMsg.Delegation,
+
+		MinSelfDelegation: jsonMsg.MinSelfDelegation,
 	}, nil
 }
 
@@ -106,11 +114,15 @@ func (msg MsgCreateValidator) GetSignBytes() []byte {
 		ValidatorAddr sdk.ValAddress `json:"validator_address"`
 		PubKey        string         `json:"pubkey"`
 		Delegation    sdk.Coin       `json:"delegation"`
+
+		MinSelfDelegation int64 `json:"min_self_delegation,omitempty"`
 	}{
 		Description:   msg.Description,
 		ValidatorAddr: msg.ValidatorAddr,
 		PubKey:        sdk.MustBech32ifyConsPub(msg.PubKey),
 		Delegation:    msg.Delegation,
+
+		MinSelfDelegation: msg.MinSelfDelegation,
 	})
 	return sdk.MustSortJSON(b)
 }
@@ -140,6 +152,9 @@ func (msg MsgCreateValidator) ValidateBasic() sdk.Error {
 	if err := commission.Validate(); err != nil {
 		return err
 	}
+	if err := validateMinSelfDelegation(msg.MinSelfDelegation, msg.Delegation); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -157,7 +172,19 @@ func (msg MsgCreateValidator) Equals(other MsgCreateValidator) bool {
 		msg.DelegatorAddr.Equals(other.DelegatorAddr) &&
 		msg.ValidatorAddr.Equals(other.ValidatorAddr) &&
 		msg.PubKey.Equals(other.PubKey) &&
-		msg.Description.Equals(other.Description)
+		msg.Description.Equals(other.Description) &&
+		msg.MinSelfDelegation == other.MinSelfDelegation
+}
+
+// validateMinSelfDelegation checks the minimum self delegation a validator is created with
+func validateMinSelfDelegation(minSelfDelegation int64, selfDelegation sdk.Coin) sdk.Error {
+	if minSelfDelegation < 0 {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "minimum self delegation must not be negative")
+	}
+	if minSelfDelegation > selfDelegation.Amount {
+		return ErrSelfDelegationBelowMinSelfDelegation(DefaultCodespace, selfDelegation.Amount)
+	}
+	return nil
 }
 
 //______________________________________________________________________
@@ -172,6 +199,9 @@ type MsgCreateValidatorOpen struct {
 	ValidatorAddr sdk.ValAddress `json:"validator_address"`
 	PubKey        string         `json:"pubkey"`
 	Delegation    sdk.Coin       `json:"delegation"`
+
+	// the self delegation the validator commits to keep at least, 0 for the MinSelfDelegation param
+	MinSelfDelegation int64 `json:"min_self_delegation,omitempty"`
 }
 
 func (msg MsgCreateValidatorOpen) Route() string { return MsgRoute }
@@ -218,6 +248,9 @@ func (msg MsgCreateValidatorOpen) ValidateBasic() sdk.Error {
 	if err := commission.Validate(); err != nil {
 		return err
 	}
+	if err := validateMinSelfDelegation(msg.MinSelfDelegation, msg.Delegation); err != nil {
+		return err
+	}
 	if len(msg.PubKey) != 0 {
 		if _, err := sdk.GetConsPubKeyBech32(msg.PubKey); err != nil {
 			return sdk.ErrInvalidPubKey(err.Error())
@@ -236,7 +269,8 @@ func (msg MsgCreateValidatorOpen) Equals(other MsgCreateValidatorOpen) bool {
 		msg.DelegatorAddr.Equals(other.DelegatorAddr) &&
 		msg.ValidatorAddr.Equals(other.ValidatorAddr) &&
 		msg.PubKey == other.PubKey &&
-		msg.Description.Equals(other.Description)
+		msg.Description.Equals(other.Description) &&
+		msg.MinSelfDelegation == other.MinSelfDelegation
 }
 
 //______________________________________________________________________
@@ -253,6 +287,9 @@ type MsgEditValidator struct {
 	// REF: #2373
 	CommissionRate *sdk.Dec `json:"commission_rate"`
 	PubKey         string   `json:"pubkey"`
+
+	// the new minimum self delegation of the validator, which can only be increased, 0 to keep it
+	MinSelfDelegation int64 `json:"min_self_delegation,omitempty"`
 }
 
 func NewMsgEditValidator(valAddr sdk.ValAddress, description Description, newRate *sdk.Dec, pubkey string) MsgEditValidator {
@@ -293,6 +330,10 @@ func (msg MsgEditValidator) ValidateBasic() sdk.Error {
 		}
 	}
 
+	if msg.MinSelfDelegation < 0 {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "minimum self delegation must not be negative")
+	}
+
 	return nil
 }
 
@@ -335,16 +376,12 @@ func (msg MsgDelegate) GetSignBytes() []byte {
 
 // quick validity check
 func (msg MsgDelegate) ValidateBasic() sdk.Error {
-	if msg.DelegatorAddr == nil {
-		return ErrNilDelegatorAddr(DefaultCodespace)
-	}
-	if msg.ValidatorAddr == nil {
-		return ErrNilValidatorAddr(DefaultCodespace)
-	}
-	if msg.Delegation.Amount < 1e8 {
-		return ErrBadDelegationAmount(DefaultCodespace, "delegation must not be less than 1e8")
-	}
-	return nil
+	return sdk.NewMsgValidator().
+		Require(msg.DelegatorAddr != nil, "delegator_addr", ErrNilDelegatorAddr(DefaultCodespace)).
+		Require(msg.ValidatorAddr != nil, "validator_addr", ErrNilValidatorAddr(DefaultCodespace)).
+		Require(msg.Delegation.Amount >= 1e8, "delegation",
+			ErrBadDelegationAmount(DefaultCodespace, "delegation must not be less than 1e8")).
+		Error()
 }
 
 func (msg MsgDelegate) GetInvolvedAddresses() []sdk.AccAddress {
@@ -391,19 +428,13 @@ func (msg MsgRedelegate) GetInvolvedAddresses() []sdk.AccAddress {
 
 // ValidateBasic is used to quickly disqualify obviously invalid messages quickly
 func (msg MsgRedelegate) ValidateBasic() sdk.Error {
-	if len(msg.DelegatorAddr) != sdk.AddrLen {
-		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
-	}
-	if len(msg.ValidatorSrcAddr) != sdk.AddrLen {
-		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorSrcAddr)))
-	}
-	if len(msg.ValidatorDstAddr) != sdk.AddrLen {
-		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorDstAddr)))
-	}
-	if msg.Amount.Amount <= 0 {
-		return sdk.ErrInvalidCoins(fmt.Sprintf("Expected positive amount, actual amount is %v", msg.Amount.Amount))
-	}
-	return nil
+	return sdk.NewMsgValidator().
+		Address("delegator_addr", msg.DelegatorAddr).
+		Address("validator_src_addr", msg.ValidatorSrcAddr).
+		Address("validator_dst_addr", msg.ValidatorDstAddr).
+		Require(msg.Amount.Amount > 0, "amount",
+			sdk.ErrInvalidCoins(fmt.Sprintf("Expected positive amount, actual amount is %v", msg.Amount.Amount))).
+		Error()
 }
 
 type MsgUndelegate struct {
@@ -451,6 +482,172 @@ func (msg MsgUndelegate) GetInvolvedAddresses() []sdk.AccAddress {
 	return []sdk.AccAddress{msg.DelegatorAddr, sdk.AccAddress(msg.ValidatorAddr)}
 }
 
+// MsgCancelUnbondingDelegation - struct for canceling a part or all of a pending unbonding, the amount is delegated
+// back to the validator it is unbonding from. The unbonding is on the side chain SideChainId if it is set.
+type MsgCancelUnbondingDelegation struct {
+	DelegatorAddr  sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr  sdk.ValAddress `json:"validator_addr"`
+	Amount         sdk.Coin       `json:"amount"`
+	CreationHeight int64          `json:"creation_height"`
+	SideChainId    string         `json:"side_chain_id,omitempty"`
+}
+
+func NewMsgCancelUnbondingDelegation(delAddr sdk.AccAddress, valAddr sdk.ValAddress, amount sdk.Coin,
+	creationHeight int64, sideChainId string) MsgCancelUnbondingDelegation {
+	return MsgCancelUnbondingDelegation{
+		DelegatorAddr:  delAddr,
+		ValidatorAddr:  valAddr,
+		Amount:         amount,
+		CreationHeight: creationHeight,
+		SideChainId:    sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgCancelUnbondingDelegation) Route() string { return MsgRoute }
+func (msg MsgCancelUnbondingDelegation) Type() string  { return "cancel_unbonding" }
+func (msg MsgCancelUnbondingDelegation) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgCancelUnbondingDelegation) GetSignBytes() []byte {
+	b := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgCancelUnbondingDelegation) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
+	}
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if msg.Amount.Amount <= 0 {
+		return ErrBadDelegationAmount(DefaultCodespace, "cancel unbonding amount must be positive")
+	}
+	if msg.CreationHeight < 0 {
+		return ErrBadCancelUnbonding(DefaultCodespace, "creation height must not be negative")
+	}
+	if len(msg.SideChainId) > sidechain.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgCancelUnbondingDelegation) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+func (msg MsgCancelUnbondingDelegation) GetSideChainId() string {
+	return msg.SideChainId
+}
+
+// MsgWithdrawDelegatorReward - struct for withdrawing the rewards accumulated by a delegation. The delegation is on
+// the side chain SideChainId if it is set.
+type MsgWithdrawDelegatorReward struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	SideChainId   string         `json:"side_chain_id,omitempty"`
+}
+
+func NewMsgWithdrawDelegatorReward(delAddr sdk.AccAddress, valAddr sdk.ValAddress, sideChainId string) MsgWithdrawDelegatorReward {
+	return MsgWithdrawDelegatorReward{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		SideChainId:   sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgWithdrawDelegatorReward) Route() string { return MsgRoute }
+func (msg MsgWithdrawDelegatorReward) Type() string  { return "withdraw_delegator_reward" }
+func (msg MsgWithdrawDelegatorReward) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgWithdrawDelegatorReward) GetSignBytes() []byte {
+	b := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgWithdrawDelegatorReward) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
+	}
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if len(msg.SideChainId) > sidechain.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgWithdrawDelegatorReward) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+func (msg MsgWithdrawDelegatorReward) GetSideChainId() string {
+	return msg.SideChainId
+}
+
+// MsgSetRestake - struct for setting whether the rewards of a delegation are delegated again to its validator. The
+// delegation is on the side chain SideChainId if it is set.
+type MsgSetRestake struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Restake       bool           `json:"restake"`
+	SideChainId   string         `json:"side_chain_id,omitempty"`
+}
+
+func NewMsgSetRestake(delAddr sdk.AccAddress, valAddr sdk.ValAddress, restake bool, sideChainId string) MsgSetRestake {
+	return MsgSetRestake{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		Restake:       restake,
+		SideChainId:   sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgSetRestake) Route() string { return MsgRoute }
+func (msg MsgSetRestake) Type() string  { return "set_restake" }
+func (msg MsgSetRestake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+// get the bytes for the message signer to sign on
+func (msg MsgSetRestake) GetSignBytes() []byte {
+	b := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(b)
+}
+
+// quick validity check
+func (msg MsgSetRestake) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
+	}
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if len(msg.SideChainId) > sidechain.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgSetRestake) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+func (msg MsgSetRestake) GetSideChainId() string {
+	return msg.SideChainId
+}
+
 // MsgBeginUnbonding - struct for unbonding transactions
 type MsgBeginUnbonding struct {
 	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
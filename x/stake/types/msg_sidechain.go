@@ -14,6 +14,7 @@ const (
 	MsgTypeSideChainDelegate        = "side_delegate"
 	MsgTypeSideChainRedelegate      = "side_redelegate"
 	MsgTypeSideChainUndelegate      = "side_undelegate"
+	MsgTypeSideChainStakeMigration  = "side_stake_migration"
 )
 
 type SideChainIder interface {
@@ -29,6 +30,9 @@ type MsgCreateSideChainValidator struct {
 	SideChainId   string         `json:"side_chain_id"`
 	SideConsAddr  []byte         `json:"side_cons_addr"`
 	SideFeeAddr   []byte         `json:"side_fee_addr"`
+
+	// the self delegation the validator commits to keep at least, 0 for the MinSelfDelegation param
+	MinSelfDelegation int64 `json:"min_self_delegation,omitempty"`
 }
 
 func NewMsgCreateSideChainValidator(valAddr sdk.ValAddress, delegation sdk.Coin,
@@ -94,6 +98,9 @@ func (msg MsgCreateSideChainValidator) ValidateBasic() sdk.Error {
 	if err := commission.Validate(); err != nil {
 		return err
 	}
+	if err := validateMinSelfDelegation(msg.MinSelfDelegation, msg.Delegation); err != nil {
+		return err
+	}
 
 	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
 		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id must be included and max length is 20 bytes")
@@ -131,6 +138,9 @@ type MsgEditSideChainValidator struct {
 	SideFeeAddr []byte `json:"side_fee_addr"`
 
 	SideConsAddr []byte `json:"side_cons_addr,omitempty"`
+
+	// the new minimum self delegation of the validator, which can only be increased, 0 to keep it
+	MinSelfDelegation int64 `json:"min_self_delegation,omitempty"`
 }
 
 func NewMsgEditSideChainValidator(sideChainId string, validatorAddr sdk.ValAddress, description Description, commissionRate *sdk.Dec, sideFeeAddr, sideConsAddr []byte) MsgEditSideChainValidator {
@@ -190,6 +200,9 @@ func (msg MsgEditSideChainValidator) ValidateBasic() sdk.Error {
 			return err
 		}
 	}
+	if msg.MinSelfDelegation < 0 {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "minimum self delegation must not be negative")
+	}
 	return nil
 }
 
@@ -374,3 +387,61 @@ func (msg MsgSideChainUndelegate) GetInvolvedAddresses() []sdk.AccAddress {
 func (msg MsgSideChainUndelegate) GetSideChainId() string {
 	return msg.SideChainId
 }
+
+//______________________________________________________________________
+// MsgSideChainStakeMigration undelegates like MsgSideChainUndelegate, and the tokens are transferred to Recipient on
+// BSC when the unbonding completes
+type MsgSideChainStakeMigration struct {
+	DelegatorAddr sdk.AccAddress        `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress        `json:"validator_addr"`
+	Amount        sdk.Coin              `json:"amount"`
+	Recipient     sdk.SmartChainAddress `json:"recipient"`
+	SideChainId   string                `json:"side_chain_id"`
+}
+
+func NewMsgSideChainStakeMigration(sideChainId string, delegatorAddr sdk.AccAddress, valAddr sdk.ValAddress, amount sdk.Coin,
+	recipient sdk.SmartChainAddress) MsgSideChainStakeMigration {
+	return MsgSideChainStakeMigration{
+		DelegatorAddr: delegatorAddr,
+		ValidatorAddr: valAddr,
+		Amount:        amount,
+		Recipient:     recipient,
+		SideChainId:   sideChainId,
+	}
+}
+
+//nolint
+func (msg MsgSideChainStakeMigration) Route() string { return MsgRoute }
+func (msg MsgSideChainStakeMigration) Type() string  { return MsgTypeSideChainStakeMigration }
+func (msg MsgSideChainStakeMigration) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+func (msg MsgSideChainStakeMigration) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgSideChainStakeMigration) ValidateBasic() sdk.Error {
+	if err := msg.UndelegateMsg().ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.Recipient.IsEmpty() {
+		return sdk.ErrInvalidAddress("recipient must not be empty")
+	}
+	return nil
+}
+
+func (msg MsgSideChainStakeMigration) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+func (msg MsgSideChainStakeMigration) GetSideChainId() string {
+	return msg.SideChainId
+}
+
+// UndelegateMsg returns the undelegation the migration starts with
+func (msg MsgSideChainStakeMigration) UndelegateMsg() MsgSideChainUndelegate {
+	return NewMsgSideChainUndelegate(msg.SideChainId, msg.DelegatorAddr, msg.ValidatorAddr, msg.Amount)
+}
@@ -206,6 +206,37 @@ func TestMsgBeginUnbonding(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for MsgSideChainStakeMigration
+func TestMsgSideChainStakeMigration(t *testing.T) {
+	recipient, err := sdk.NewSmartChainAddress("0x9fB29AAc15b9A4B7F17c3385939b007540f4d791")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		delegatorAddr sdk.AccAddress
+		validatorAddr sdk.ValAddress
+		amount        sdk.Coin
+		recipient     sdk.SmartChainAddress
+		sideChainId   string
+		expectPass    bool
+	}{
+		{"regular", sdk.AccAddress(addr1), addr2, coinPos, recipient, "bsc", true},
+		{"zero amount", sdk.AccAddress(addr1), addr2, coinZero, recipient, "bsc", false},
+		{"empty delegator", sdk.AccAddress(emptyAddr), addr2, coinPos, recipient, "bsc", false},
+		{"empty recipient", sdk.AccAddress(addr1), addr2, coinPos, sdk.SmartChainAddress{}, "bsc", false},
+		{"empty side chain id", sdk.AccAddress(addr1), addr2, coinPos, recipient, "", false},
+	}
+
+	for _, tc := range tests {
+		msg := NewMsgSideChainStakeMigration(tc.sideChainId, tc.delegatorAddr, tc.validatorAddr, tc.amount, tc.recipient)
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", tc.name)
+		}
+	}
+}
+
 func TestMsgSideChainDelegate_Type(t *testing.T) {
 	msg := NewMsgSideChainDelegate("aaa", sdk.AccAddress(addr1), addr2, coinPos)
 	bz, err := json.Marshal(msg)
@@ -32,6 +32,10 @@ const (
 	defaultRewardDistributionBatchSize = 1000
 
 	ConsAddrUpdateIntervalInHours = 24 * 30
+
+	// MaxRedelegationEntriesLimit bounds the MaxRedelegationEntries, so that slashing a validator only goes
+	// through a bounded number of entries per redelegation
+	MaxRedelegationEntriesLimit int64 = 20
 )
 
 // nolint - Keys for parameter access
@@ -46,6 +50,10 @@ var (
 	KeyBaseProposerRewardRatio     = []byte("BaseProposerRewardRatio")
 	KeyBonusProposerRewardRatio    = []byte("BonusProposerRewardRatio")
 	KeyFeeFromBscToBcRatio         = []byte("FeeFromBscToBcRatio")
+	KeyRedelegationCooldown        = []byte("RedelegationCooldown")
+	KeyHeartbeatTimeout            = []byte("HeartbeatTimeout")
+	KeyMaxRedelegationEntries      = []byte("MaxRedelegationEntries")
+	KeyEditValidatorCooldown       = []byte("EditValidatorCooldown")
 )
 
 var _ params.ParamSet = (*Params)(nil)
@@ -64,6 +72,13 @@ type Params struct {
 	BaseProposerRewardRatio  types.Dec `json:"base_proposer_reward_ratio"`  // the base proposer reward ratio
 	BonusProposerRewardRatio types.Dec `json:"bonus_proposer_reward_ratio"` // the bonus proposer reward ratio
 	FeeFromBscToBcRatio      types.Dec `json:"fee_from_bsc_to_bc_ratio"`    // the fee from bsc to bc ratio
+
+	RedelegationCooldown int64 `json:"redelegation_cooldown,omitempty"` // the number of blocks redelegating away from a validator is forbidden after a downtime warning or evidence against it, 0 to disable
+	HeartbeatTimeout     int64 `json:"heartbeat_timeout,omitempty"`     // the number of blocks without heartbeat after which a side chain validator is warned about, 0 to disable the heartbeats
+	// the maximum number of redelegations of a delegator from a validator to another in progress at once, 0 for one
+	MaxRedelegationEntries int64 `json:"max_redelegation_entries,omitempty"`
+	// the number of blocks a validator has to wait between two edits of its description or commission, 0 to disable
+	EditValidatorCooldown int64 `json:"edit_validator_cooldown,omitempty"`
 }
 
 func (p *Params) GetBCParamAttribute() string {
@@ -74,10 +89,19 @@ func (p *Params) GetParamAttribute() (string, bool) {
 	return "staking", false
 }
 
-func (p *Params) UpdateCheck() error {
-	if p.BondDenom != types.NativeTokenSymbol {
+// UpgradeCheck checks the params against the upgrades of the chain
+func (p *Params) UpgradeCheck(upgradeMgr *types.UpgradeManager) error {
+	// the side chains can have another bond denom once SideChainStakeParams is upgraded
+	if !upgradeMgr.IsUpgrade(types.SideChainStakeParams) && p.BondDenom != types.NativeTokenSymbol {
 		return fmt.Errorf("only native token is availabe as bond_denom so far")
 	}
+	return nil
+}
+
+func (p *Params) UpdateCheck() error {
+	if p.BondDenom == "" {
+		return fmt.Errorf("the bond_denom should not be empty")
+	}
 	// the valid range is 1 minute to 100 day.
 	if p.UnbondingTime > 100*24*time.Hour || p.UnbondingTime < time.Minute {
 		return fmt.Errorf("the UnbondingTime should be in range 1 minute to 100 days")
@@ -85,8 +109,7 @@ func (p *Params) UpdateCheck() error {
 	if p.MaxValidators < 1 || p.MaxValidators > 500 {
 		return fmt.Errorf("the max validator should be in range 1 to 500")
 	}
-	// BondDenom do not check here, it should be native token and do not support update so far.
-	// Leave the check in node repo.
+	// the update of the BondDenom of a side chain is checked against its state by the keeper.
 
 	if p.MinSelfDelegation > 10000000e8 || p.MinSelfDelegation < 1e8 {
 		return fmt.Errorf("the min_self_delegation should be in range 1e8 to 10000000e8")
@@ -110,6 +133,18 @@ func (p *Params) UpdateCheck() error {
 	if p.FeeFromBscToBcRatio.LT(types.ZeroDec()) {
 		return fmt.Errorf("the fee_from_bsc_to_bc_ratio should be no less than 0")
 	}
+	if p.RedelegationCooldown < 0 {
+		return fmt.Errorf("the redelegation_cooldown should be no less than 0")
+	}
+	if p.HeartbeatTimeout < 0 {
+		return fmt.Errorf("the heartbeat_timeout should be no less than 0")
+	}
+	if p.MaxRedelegationEntries < 0 || p.MaxRedelegationEntries > MaxRedelegationEntriesLimit {
+		return fmt.Errorf("the max_redelegation_entries should be in range 0 to %d", MaxRedelegationEntriesLimit)
+	}
+	if p.EditValidatorCooldown < 0 {
+		return fmt.Errorf("the edit_validator_cooldown should be no less than 0")
+	}
 
 	return nil
 }
@@ -127,6 +162,10 @@ func (p *Params) KeyValuePairs() params.KeyValuePairs {
 		{KeyBaseProposerRewardRatio, &p.BaseProposerRewardRatio},
 		{KeyBonusProposerRewardRatio, &p.BonusProposerRewardRatio},
 		{KeyFeeFromBscToBcRatio, &p.FeeFromBscToBcRatio},
+		{KeyRedelegationCooldown, &p.RedelegationCooldown},
+		{KeyHeartbeatTimeout, &p.HeartbeatTimeout},
+		{KeyMaxRedelegationEntries, &p.MaxRedelegationEntries},
+		{KeyEditValidatorCooldown, &p.EditValidatorCooldown},
 	}
 }
 
@@ -168,6 +207,10 @@ func (p Params) HumanReadableString() string {
 	resp += fmt.Sprintf("Base proposer reward ratio: %s\n", p.BaseProposerRewardRatio)
 	resp += fmt.Sprintf("Bonus proposer reward ratio: %s\n", p.BonusProposerRewardRatio)
 	resp += fmt.Sprintf("Fee from BSC to BC ratio: %s\n", p.FeeFromBscToBcRatio)
+	resp += fmt.Sprintf("Redelegation cooldown: %d blocks\n", p.RedelegationCooldown)
+	resp += fmt.Sprintf("Heartbeat timeout: %d blocks\n", p.HeartbeatTimeout)
+	resp += fmt.Sprintf("Max redelegation entries: %d\n", p.MaxRedelegationEntries)
+	resp += fmt.Sprintf("Edit validator cooldown: %d blocks\n", p.EditValidatorCooldown)
 	return resp
 }
 
@@ -26,6 +26,32 @@ type Reward struct {
 	CrossStake bool
 }
 
+// reward model after LazyRewardDistribution upgrade
+
+// ValidatorCurrentRewards are the rewards of the delegators of a validator accumulated in its current period
+type ValidatorCurrentRewards struct {
+	Period  int64 `json:"period"`
+	Rewards int64 `json:"rewards"`
+}
+
+// ValidatorHistoricalRewards are the rewards per share of the delegators of a validator accumulated until the end of
+// a period, multiplied by RewardRatioPrecision. ReferenceCount is the number of the delegations and of the current
+// period referring to it.
+type ValidatorHistoricalRewards struct {
+	CumulativeRewardRatio sdk.Int `json:"cumulative_reward_ratio"`
+	ReferenceCount        int64   `json:"reference_count"`
+}
+
+// DelegatorStartingInfo is the period of the validator a delegation starts earning rewards after, with its shares
+type DelegatorStartingInfo struct {
+	PreviousPeriod int64   `json:"previous_period"`
+	Shares         sdk.Dec `json:"shares"`
+	Height         int64   `json:"height"`
+}
+
+// RewardRatioPrecision is the precision of the cumulative reward ratios, in raw reward units per raw share unit
+var RewardRatioPrecision = sdk.NewIntWithDecimal(1, 18)
+
 type StoredValDistAddr struct {
 	Validator      sdk.ValAddress
 	DistributeAddr sdk.AccAddress
@@ -48,6 +48,14 @@ type Validator struct {
 
 	StakeSnapshots   []sdk.Dec `json:"stake_snapshots,omitempty"`   // staked tokens snapshot over a period of time, e.g. 30 days
 	AccumulatedStake sdk.Dec   `json:"accumulated_stake,omitempty"` // accumulated stake, sum of StakeSnapshots
+
+	MinSelfDelegation int64 `json:"min_self_delegation,omitempty"` // the self delegation the validator commits to keep at least, on top of the params
+}
+
+// PagedValidators is a page of the validators, Total is the number of all the validators matching the query
+type PagedValidators struct {
+	Validators []Validator `json:"validators"`
+	Total      int         `json:"total"`
 }
 
 // NewValidator - initialize a new validator
@@ -57,7 +65,7 @@ func NewValidator(operator sdk.ValAddress, pubKey crypto.PubKey, description Des
 
 // Note a few fields are initialized with default value. They will be updated later
 func NewValidatorWithFeeAddr(feeAddr sdk.AccAddress, operator sdk.ValAddress, pubKey crypto.PubKey, description Description) Validator {
-	val := Validator{
+	return Validator{
 		FeeAddr:            feeAddr,
 		OperatorAddr:       operator,
 		ConsPubKey:         pubKey,
@@ -72,10 +80,6 @@ func NewValidatorWithFeeAddr(feeAddr sdk.AccAddress, operator sdk.ValAddress, pu
 		UnbondingMinTime:   time.Unix(0, 0).UTC(),
 		Commission:         NewCommission(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()),
 	}
-	if sdk.IsUpgrade(sdk.BEP159) {
-		val.DistributionAddr = GenerateDistributionAddr(operator, ChainIDForBeaconChain)
-	}
-	return val
 }
 
 func NewSideChainValidator(feeAddr sdk.AccAddress, operator sdk.ValAddress, description Description, sideChainId string, sideConsAddr, sideFeeAddr []byte) Validator {
@@ -176,6 +180,9 @@ func (v Validator) HumanReadableString() (string, error) {
 	}
 	resp += fmt.Sprintf("StakeSnapshots: %s\n", v.StakeSnapshots)
 	resp += fmt.Sprintf("AccumulatedStake: %s\n", v.AccumulatedStake)
+	if v.MinSelfDelegation != 0 {
+		resp += fmt.Sprintf("Minimal self-delegation amount: %d\n", v.MinSelfDelegation)
+	}
 
 	return resp, nil
 }
@@ -209,6 +216,8 @@ type bechValidator struct {
 
 	StakeSnapshots   []sdk.Dec `json:"stake_snapshots,omitempty"`   // staked tokens snapshot over a period of time, e.g. 30 days
 	AccumulatedStake sdk.Dec   `json:"accumulated_stake,omitempty"` // accumulated stake, sum of StakeSnapshots
+
+	MinSelfDelegation int64 `json:"min_self_delegation,omitempty"` // the self delegation the validator commits to keep at least, on top of the params
 }
 
 // MarshalJSON marshals the validator to JSON using Bech32
@@ -242,6 +251,7 @@ func (v Validator) MarshalJSON() ([]byte, error) {
 		SideFeeAddr:        sdk.HexAddress(v.SideFeeAddr),
 		StakeSnapshots:     v.StakeSnapshots,
 		AccumulatedStake:   v.AccumulatedStake,
+		MinSelfDelegation:  v.MinSelfDelegation,
 	})
 }
 
@@ -276,6 +286,7 @@ func (v *Validator) UnmarshalJSON(data []byte) error {
 		Commission:         bv.Commission,
 		StakeSnapshots:     bv.StakeSnapshots,
 		AccumulatedStake:   bv.AccumulatedStake,
+		MinSelfDelegation:  bv.MinSelfDelegation,
 	}
 	if len(bv.SideChainId) != 0 {
 		v.DistributionAddr = bv.DistributionAddr
@@ -591,5 +602,6 @@ func (v Validator) GetDelegatorShares() sdk.Dec  { return v.DelegatorShares }
 func (v Validator) GetBondHeight() int64         { return v.BondHeight }
 func (v Validator) GetSideChainConsAddr() []byte { return v.SideConsAddr }
 func (v Validator) IsSideChainValidator() bool   { return len(v.SideChainId) != 0 }
+func (v Validator) GetMinSelfDelegation() int64  { return v.MinSelfDelegation }
 
 func (v Validator) IsSelfDelegator(address sdk.AccAddress) bool { return v.FeeAddr.Equals(address) }